@@ -0,0 +1,120 @@
+// Code generated by wit-bindgen-go. DO NOT EDIT.
+
+// Package blobstore represents the imported interface "wasi:blobstore/blobstore@0.2.0-draft".
+//
+// wasi-cloud Blobstore service definition
+package blobstore
+
+import (
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"github.com/wasmCloud/component-sdk-go/_examples/grpc-client/gen/wasi/blobstore/container"
+	"github.com/wasmCloud/component-sdk-go/_examples/grpc-client/gen/wasi/blobstore/types"
+)
+
+// CreateContainer represents the imported function "create-container".
+//
+// creates a new empty container
+//
+//	create-container: func(name: container-name) -> result<container, error>
+//
+//go:nosplit
+func CreateContainer(name types.ContainerName) (result cm.Result[string, container.Container, types.Error]) {
+	name0, name1 := cm.LowerString(name)
+	wasmimport_CreateContainer((*uint8)(name0), (uint32)(name1), &result)
+	return
+}
+
+//go:wasmimport wasi:blobstore/blobstore@0.2.0-draft create-container
+//go:noescape
+func wasmimport_CreateContainer(name0 *uint8, name1 uint32, result *cm.Result[string, container.Container, types.Error])
+
+// GetContainer represents the imported function "get-container".
+//
+// retrieves a container by name
+//
+//	get-container: func(name: container-name) -> result<container, error>
+//
+//go:nosplit
+func GetContainer(name types.ContainerName) (result cm.Result[string, container.Container, types.Error]) {
+	name0, name1 := cm.LowerString(name)
+	wasmimport_GetContainer((*uint8)(name0), (uint32)(name1), &result)
+	return
+}
+
+//go:wasmimport wasi:blobstore/blobstore@0.2.0-draft get-container
+//go:noescape
+func wasmimport_GetContainer(name0 *uint8, name1 uint32, result *cm.Result[string, container.Container, types.Error])
+
+// DeleteContainer represents the imported function "delete-container".
+//
+// deletes a container and all objects within it
+//
+//	delete-container: func(name: container-name) -> result<_, error>
+//
+//go:nosplit
+func DeleteContainer(name types.ContainerName) (result cm.Result[types.Error, struct{}, types.Error]) {
+	name0, name1 := cm.LowerString(name)
+	wasmimport_DeleteContainer((*uint8)(name0), (uint32)(name1), &result)
+	return
+}
+
+//go:wasmimport wasi:blobstore/blobstore@0.2.0-draft delete-container
+//go:noescape
+func wasmimport_DeleteContainer(name0 *uint8, name1 uint32, result *cm.Result[types.Error, struct{}, types.Error])
+
+// ContainerExists represents the imported function "container-exists".
+//
+// returns true if the container exists
+//
+//	container-exists: func(name: container-name) -> result<bool, error>
+//
+//go:nosplit
+func ContainerExists(name types.ContainerName) (result cm.Result[string, bool, types.Error]) {
+	name0, name1 := cm.LowerString(name)
+	wasmimport_ContainerExists((*uint8)(name0), (uint32)(name1), &result)
+	return
+}
+
+//go:wasmimport wasi:blobstore/blobstore@0.2.0-draft container-exists
+//go:noescape
+func wasmimport_ContainerExists(name0 *uint8, name1 uint32, result *cm.Result[string, bool, types.Error])
+
+// CopyObject represents the imported function "copy-object".
+//
+// copies (duplicates) an object, to the same or a different container.
+// returns an error if the target container does not exist.
+// overwrites destination object if it already existed.
+//
+//	copy-object: func(src: object-id, dest: object-id) -> result<_, error>
+//
+//go:nosplit
+func CopyObject(src types.ObjectID, dest types.ObjectID) (result cm.Result[types.Error, struct{}, types.Error]) {
+	src0, src1, src2, src3 := lower_ObjectID(src)
+	dest0, dest1, dest2, dest3 := lower_ObjectID(dest)
+	wasmimport_CopyObject((*uint8)(src0), (uint32)(src1), (*uint8)(src2), (uint32)(src3), (*uint8)(dest0), (uint32)(dest1), (*uint8)(dest2), (uint32)(dest3), &result)
+	return
+}
+
+//go:wasmimport wasi:blobstore/blobstore@0.2.0-draft copy-object
+//go:noescape
+func wasmimport_CopyObject(src0 *uint8, src1 uint32, src2 *uint8, src3 uint32, dest0 *uint8, dest1 uint32, dest2 *uint8, dest3 uint32, result *cm.Result[types.Error, struct{}, types.Error])
+
+// MoveObject represents the imported function "move-object".
+//
+// moves or renames an object, to the same or a different container
+// returns an error if the destination container does not exist.
+// overwrites destination object if it already existed.
+//
+//	move-object: func(src: object-id, dest: object-id) -> result<_, error>
+//
+//go:nosplit
+func MoveObject(src types.ObjectID, dest types.ObjectID) (result cm.Result[types.Error, struct{}, types.Error]) {
+	src0, src1, src2, src3 := lower_ObjectID(src)
+	dest0, dest1, dest2, dest3 := lower_ObjectID(dest)
+	wasmimport_MoveObject((*uint8)(src0), (uint32)(src1), (*uint8)(src2), (uint32)(src3), (*uint8)(dest0), (uint32)(dest1), (*uint8)(dest2), (uint32)(dest3), &result)
+	return
+}
+
+//go:wasmimport wasi:blobstore/blobstore@0.2.0-draft move-object
+//go:noescape
+func wasmimport_MoveObject(src0 *uint8, src1 uint32, src2 *uint8, src3 uint32, dest0 *uint8, dest1 uint32, dest2 *uint8, dest3 uint32, result *cm.Result[types.Error, struct{}, types.Error])
@@ -0,0 +1,12 @@
+// Code generated by wit-bindgen-go. DO NOT EDIT.
+
+package streams
+
+import (
+	"unsafe"
+)
+
+// StreamErrorShape is used for storage in variant or result types.
+type StreamErrorShape struct {
+	shape [unsafe.Sizeof(StreamError{})]byte
+}
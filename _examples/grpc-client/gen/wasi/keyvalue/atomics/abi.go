@@ -0,0 +1,13 @@
+// Code generated by wit-bindgen-go. DO NOT EDIT.
+
+package atomics
+
+import (
+	"github.com/wasmCloud/component-sdk-go/_examples/grpc-client/gen/wasi/keyvalue/store"
+	"unsafe"
+)
+
+// ErrorShape is used for storage in variant or result types.
+type ErrorShape struct {
+	shape [unsafe.Sizeof(store.Error{})]byte
+}
@@ -0,0 +1,12 @@
+// Code generated by wit-bindgen-go. DO NOT EDIT.
+
+package network
+
+import (
+	"unsafe"
+)
+
+// IPv6SocketAddressShape is used for storage in variant or result types.
+type IPv6SocketAddressShape struct {
+	shape [unsafe.Sizeof(IPv6SocketAddress{})]byte
+}
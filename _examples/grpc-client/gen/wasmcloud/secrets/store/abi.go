@@ -0,0 +1,12 @@
+// Code generated by wit-bindgen-go. DO NOT EDIT.
+
+package store
+
+import (
+	"unsafe"
+)
+
+// SecretsErrorShape is used for storage in variant or result types.
+type SecretsErrorShape struct {
+	shape [unsafe.Sizeof(SecretsError{})]byte
+}
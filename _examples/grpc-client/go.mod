@@ -0,0 +1,22 @@
+module github.com/wasmCloud/component-sdk-go/_examples/grpc-client
+
+go 1.22.5
+
+require (
+	github.com/bytecodealliance/wasm-tools-go v0.2.0
+	go.wasmcloud.dev/component v0.0.0-20240910182305-2785f866ff0f
+	google.golang.org/grpc v1.67.3
+)
+
+require (
+	github.com/samber/lo v1.44.0 // indirect
+	github.com/samber/slog-common v0.17.1 // indirect
+	golang.org/x/net v0.28.0 // indirect
+	golang.org/x/sys v0.28.0 // indirect
+	golang.org/x/text v0.17.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240814211410-ddb44dafa142 // indirect
+	google.golang.org/protobuf v1.34.2 // indirect
+)
+
+// TODO: remove this line once the module is published
+replace go.wasmcloud.dev/component => ../..
@@ -0,0 +1,395 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"net"
+	"net/netip"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"go.wasmcloud.dev/component/gen/wasi/config/runtime"
+	"go.wasmcloud.dev/component/gen/wasi/io/poll"
+	"go.wasmcloud.dev/component/gen/wasi/io/streams"
+	ipnamelookup "go.wasmcloud.dev/component/gen/wasi/sockets/ip-name-lookup"
+	"go.wasmcloud.dev/component/gen/wasi/sockets/network"
+	"go.wasmcloud.dev/component/gen/wasi/sockets/tcp"
+)
+
+// host implements the `wasi:sockets` client side over the stdlib network,
+// standing in for a host granting raw socket access.
+var host = struct {
+	sync.Mutex
+	next      uint32
+	resources map[uint32]any
+}{
+	next:      1000,
+	resources: map[uint32]any{},
+}
+
+type (
+	hostPollable func() bool
+
+	hostSocket struct {
+		addr      netip.AddrPort
+		connected chan struct{}
+		conn      net.Conn
+		err       error
+	}
+
+	hostInput struct {
+		buf []byte
+		err error
+	}
+
+	hostOutput struct {
+		conn net.Conn
+	}
+
+	hostAddrs struct {
+		addrs []netip.Addr
+		done  chan struct{}
+		err   error
+	}
+)
+
+// add registers a resource, it must be called with host held.
+func add(resource any) uint32 {
+	host.next++
+	host.resources[host.next] = resource
+	return host.next
+}
+
+func get[T any](handle uint32) T {
+	host.Lock()
+	defer host.Unlock()
+	return host.resources[handle].(T)
+}
+
+func drop(handle uint32) {
+	host.Lock()
+	defer host.Unlock()
+	delete(host.resources, handle)
+}
+
+func subscribe(ready hostPollable) uint32 {
+	host.Lock()
+	defer host.Unlock()
+	return add(ready)
+}
+
+func closed(ch chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+//go:linkname wasmimport_InstanceNetwork go.wasmcloud.dev/component/gen/wasi/sockets/instance-network.wasmimport_InstanceNetwork
+func wasmimport_InstanceNetwork() (result0 uint32) {
+	return 1
+}
+
+//go:linkname wasmimport_ResolveAddresses go.wasmcloud.dev/component/gen/wasi/sockets/ip-name-lookup.wasmimport_ResolveAddresses
+func wasmimport_ResolveAddresses(network0 uint32, name0 *uint8, name1 uint32, result *cm.Result[ipnamelookup.ResolveAddressStream, ipnamelookup.ResolveAddressStream, network.ErrorCode]) {
+	name := string(unsafe.Slice(name0, name1))
+	stream := &hostAddrs{done: make(chan struct{})}
+	go func() {
+		addrs, err := net.DefaultResolver.LookupNetIP(context.Background(), "ip", name)
+		host.Lock()
+		defer host.Unlock()
+		stream.addrs, stream.err = addrs, err
+		close(stream.done)
+	}()
+
+	host.Lock()
+	defer host.Unlock()
+	*result = cm.OK[cm.Result[ipnamelookup.ResolveAddressStream, ipnamelookup.ResolveAddressStream, network.ErrorCode]](ipnamelookup.ResolveAddressStream(add(stream)))
+}
+
+//go:linkname wasmimport_ResolveAddressStreamResolveNextAddress go.wasmcloud.dev/component/gen/wasi/sockets/ip-name-lookup.wasmimport_ResolveAddressStreamResolveNextAddress
+func wasmimport_ResolveAddressStreamResolveNextAddress(self0 uint32, result *cm.Result[ipnamelookup.OptionIPAddressShape, cm.Option[network.IPAddress], network.ErrorCode]) {
+	type R = cm.Result[ipnamelookup.OptionIPAddressShape, cm.Option[network.IPAddress], network.ErrorCode]
+
+	stream := get[*hostAddrs](self0)
+
+	host.Lock()
+	defer host.Unlock()
+
+	switch {
+	case !closed(stream.done):
+		*result = cm.Err[R](network.ErrorCodeWouldBlock)
+	case stream.err != nil:
+		*result = cm.Err[R](network.ErrorCodeNameUnresolvable)
+	case len(stream.addrs) == 0:
+		*result = cm.OK[R](cm.None[network.IPAddress]())
+	default:
+		ip := stream.addrs[0].Unmap()
+		stream.addrs = stream.addrs[1:]
+		if ip.Is4() {
+			*result = cm.OK[R](cm.Some(network.IPAddressIPv4(ip.As4())))
+		} else {
+			b := ip.As16()
+			var segments network.IPv6Address
+			for i := range segments {
+				segments[i] = uint16(b[2*i])<<8 | uint16(b[2*i+1])
+			}
+			*result = cm.OK[R](cm.Some(network.IPAddressIPv6(segments)))
+		}
+	}
+}
+
+//go:linkname wasmimport_ResolveAddressStreamSubscribe go.wasmcloud.dev/component/gen/wasi/sockets/ip-name-lookup.wasmimport_ResolveAddressStreamSubscribe
+func wasmimport_ResolveAddressStreamSubscribe(self0 uint32) (result0 uint32) {
+	stream := get[*hostAddrs](self0)
+	return subscribe(func() bool { return closed(stream.done) })
+}
+
+//go:linkname wasmimport_ResolveAddressStreamResourceDrop go.wasmcloud.dev/component/gen/wasi/sockets/ip-name-lookup.wasmimport_ResolveAddressStreamResourceDrop
+func wasmimport_ResolveAddressStreamResourceDrop(self0 uint32) {
+	drop(self0)
+}
+
+//go:linkname wasmimport_CreateTCPSocket go.wasmcloud.dev/component/gen/wasi/sockets/tcp-create-socket.wasmimport_CreateTCPSocket
+func wasmimport_CreateTCPSocket(addressFamily0 uint32, result *cm.Result[tcp.TCPSocket, tcp.TCPSocket, network.ErrorCode]) {
+	host.Lock()
+	defer host.Unlock()
+	*result = cm.OK[cm.Result[tcp.TCPSocket, tcp.TCPSocket, network.ErrorCode]](tcp.TCPSocket(add(&hostSocket{})))
+}
+
+//go:linkname wasmimport_TCPSocketSetKeepAliveEnabled go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketSetKeepAliveEnabled
+func wasmimport_TCPSocketSetKeepAliveEnabled(self0 uint32, value0 uint32, result *cm.Result[network.ErrorCode, struct{}, network.ErrorCode]) {
+	*result = cm.OK[cm.Result[network.ErrorCode, struct{}, network.ErrorCode]](struct{}{})
+}
+
+//go:linkname wasmimport_TCPSocketSetKeepAliveIdleTime go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketSetKeepAliveIdleTime
+func wasmimport_TCPSocketSetKeepAliveIdleTime(self0 uint32, value0 uint64, result *cm.Result[network.ErrorCode, struct{}, network.ErrorCode]) {
+	*result = cm.OK[cm.Result[network.ErrorCode, struct{}, network.ErrorCode]](struct{}{})
+}
+
+//go:linkname wasmimport_TCPSocketSetKeepAliveInterval go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketSetKeepAliveInterval
+func wasmimport_TCPSocketSetKeepAliveInterval(self0 uint32, value0 uint64, result *cm.Result[network.ErrorCode, struct{}, network.ErrorCode]) {
+	*result = cm.OK[cm.Result[network.ErrorCode, struct{}, network.ErrorCode]](struct{}{})
+}
+
+//go:linkname wasmimport_TCPSocketStartConnect go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketStartConnect
+func wasmimport_TCPSocketStartConnect(self0 uint32, network0 uint32, remoteAddress0 uint32, remoteAddress1 uint32, remoteAddress2 uint32, remoteAddress3 uint32, remoteAddress4 uint32, remoteAddress5 uint32, remoteAddress6 uint32, remoteAddress7 uint32, remoteAddress8 uint32, remoteAddress9 uint32, remoteAddress10 uint32, remoteAddress11 uint32, result *cm.Result[network.ErrorCode, struct{}, network.ErrorCode]) {
+	var addr netip.AddrPort
+	if remoteAddress0 == 0 {
+		ip := netip.AddrFrom4([4]byte{byte(remoteAddress2), byte(remoteAddress3), byte(remoteAddress4), byte(remoteAddress5)})
+		addr = netip.AddrPortFrom(ip, uint16(remoteAddress1))
+	} else {
+		var b [16]byte
+		for i, s := range []uint32{remoteAddress3, remoteAddress4, remoteAddress5, remoteAddress6, remoteAddress7, remoteAddress8, remoteAddress9, remoteAddress10} {
+			b[2*i], b[2*i+1] = byte(s>>8), byte(s)
+		}
+		addr = netip.AddrPortFrom(netip.AddrFrom16(b), uint16(remoteAddress1))
+	}
+
+	socket := get[*hostSocket](self0)
+	connected := make(chan struct{})
+
+	host.Lock()
+	socket.addr = addr
+	socket.connected = connected
+	host.Unlock()
+
+	go func() {
+		conn, err := net.Dial("tcp", addr.String())
+		host.Lock()
+		defer host.Unlock()
+		socket.conn, socket.err = conn, err
+		close(connected)
+	}()
+	*result = cm.OK[cm.Result[network.ErrorCode, struct{}, network.ErrorCode]](struct{}{})
+}
+
+//go:linkname wasmimport_TCPSocketFinishConnect go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketFinishConnect
+func wasmimport_TCPSocketFinishConnect(self0 uint32, result *cm.Result[tcp.TupleInputStreamOutputStreamShape, cm.Tuple[streams.InputStream, streams.OutputStream], network.ErrorCode]) {
+	type R = cm.Result[tcp.TupleInputStreamOutputStreamShape, cm.Tuple[streams.InputStream, streams.OutputStream], network.ErrorCode]
+
+	socket := get[*hostSocket](self0)
+
+	host.Lock()
+	defer host.Unlock()
+
+	switch {
+	case !closed(socket.connected):
+		*result = cm.Err[R](network.ErrorCodeWouldBlock)
+	case socket.err != nil:
+		*result = cm.Err[R](network.ErrorCodeConnectionRefused)
+	default:
+		input := &hostInput{}
+		go func() {
+			buf := make([]byte, 32<<10)
+			for {
+				n, err := socket.conn.Read(buf)
+				host.Lock()
+				input.buf = append(input.buf, buf[:n]...)
+				input.err = err
+				host.Unlock()
+				if err != nil {
+					return
+				}
+			}
+		}()
+		*result = cm.OK[R](cm.Tuple[streams.InputStream, streams.OutputStream]{
+			F0: streams.InputStream(add(input)),
+			F1: streams.OutputStream(add(&hostOutput{conn: socket.conn})),
+		})
+	}
+}
+
+//go:linkname wasmimport_TCPSocketSubscribe go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketSubscribe
+func wasmimport_TCPSocketSubscribe(self0 uint32) (result0 uint32) {
+	socket := get[*hostSocket](self0)
+	return subscribe(func() bool { return socket.connected == nil || closed(socket.connected) })
+}
+
+//go:linkname wasmimport_TCPSocketLocalAddress go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketLocalAddress
+func wasmimport_TCPSocketLocalAddress(self0 uint32, result *cm.Result[tcp.IPSocketAddressShape, network.IPSocketAddress, network.ErrorCode]) {
+	*result = cm.Err[cm.Result[tcp.IPSocketAddressShape, network.IPSocketAddress, network.ErrorCode]](network.ErrorCodeNotSupported)
+}
+
+//go:linkname wasmimport_TCPSocketRemoteAddress go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketRemoteAddress
+func wasmimport_TCPSocketRemoteAddress(self0 uint32, result *cm.Result[tcp.IPSocketAddressShape, network.IPSocketAddress, network.ErrorCode]) {
+	*result = cm.Err[cm.Result[tcp.IPSocketAddressShape, network.IPSocketAddress, network.ErrorCode]](network.ErrorCodeNotSupported)
+}
+
+//go:linkname wasmimport_TCPSocketResourceDrop go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketResourceDrop
+func wasmimport_TCPSocketResourceDrop(self0 uint32) {
+	host.Lock()
+	defer host.Unlock()
+
+	if socket := host.resources[self0].(*hostSocket); socket.conn != nil {
+		socket.conn.Close()
+	}
+	delete(host.resources, self0)
+}
+
+//go:linkname wasmimport_InputStreamRead go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_InputStreamRead
+func wasmimport_InputStreamRead(self0 uint32, len0 uint64, result *cm.Result[cm.List[uint8], cm.List[uint8], streams.StreamError]) {
+	type R = cm.Result[cm.List[uint8], cm.List[uint8], streams.StreamError]
+
+	input := get[*hostInput](self0)
+
+	host.Lock()
+	defer host.Unlock()
+
+	if len(input.buf) == 0 && input.err != nil {
+		*result = cm.Err[R](streams.StreamErrorClosed())
+		return
+	}
+
+	n := min(uint64(len(input.buf)), len0)
+	data := append([]byte(nil), input.buf[:n]...)
+	input.buf = input.buf[n:]
+	*result = cm.OK[R](cm.ToList(data))
+}
+
+//go:linkname wasmimport_InputStreamSubscribe go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_InputStreamSubscribe
+func wasmimport_InputStreamSubscribe(self0 uint32) (result0 uint32) {
+	input := get[*hostInput](self0)
+	return subscribe(func() bool { return len(input.buf) > 0 || input.err != nil })
+}
+
+//go:linkname wasmimport_InputStreamResourceDrop go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_InputStreamResourceDrop
+func wasmimport_InputStreamResourceDrop(self0 uint32) {
+	drop(self0)
+}
+
+//go:linkname wasmimport_OutputStreamCheckWrite go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_OutputStreamCheckWrite
+func wasmimport_OutputStreamCheckWrite(self0 uint32, result *cm.Result[uint64, uint64, streams.StreamError]) {
+	*result = cm.OK[cm.Result[uint64, uint64, streams.StreamError]](4096)
+}
+
+//go:linkname wasmimport_OutputStreamWrite go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_OutputStreamWrite
+func wasmimport_OutputStreamWrite(self0 uint32, contents0 *uint8, contents1 uint32, result *cm.Result[streams.StreamError, struct{}, streams.StreamError]) {
+	type R = cm.Result[streams.StreamError, struct{}, streams.StreamError]
+
+	output := get[*hostOutput](self0)
+	if _, err := output.conn.Write(unsafe.Slice(contents0, contents1)); err != nil {
+		*result = cm.Err[R](streams.StreamErrorClosed())
+		return
+	}
+	*result = cm.OK[R](struct{}{})
+}
+
+//go:linkname wasmimport_OutputStreamFlush go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_OutputStreamFlush
+func wasmimport_OutputStreamFlush(self0 uint32, result *cm.Result[streams.StreamError, struct{}, streams.StreamError]) {
+	*result = cm.OK[cm.Result[streams.StreamError, struct{}, streams.StreamError]](struct{}{})
+}
+
+//go:linkname wasmimport_OutputStreamBlockingFlush go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_OutputStreamBlockingFlush
+func wasmimport_OutputStreamBlockingFlush(self0 uint32, result *cm.Result[streams.StreamError, struct{}, streams.StreamError]) {
+	*result = cm.OK[cm.Result[streams.StreamError, struct{}, streams.StreamError]](struct{}{})
+}
+
+//go:linkname wasmimport_OutputStreamSubscribe go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_OutputStreamSubscribe
+func wasmimport_OutputStreamSubscribe(self0 uint32) (result0 uint32) {
+	return subscribe(func() bool { return true })
+}
+
+//go:linkname wasmimport_OutputStreamResourceDrop go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_OutputStreamResourceDrop
+func wasmimport_OutputStreamResourceDrop(self0 uint32) {
+	drop(self0)
+}
+
+//go:linkname wasmimport_ErrorToDebugString go.wasmcloud.dev/component/gen/wasi/io/error.wasmimport_ErrorToDebugString
+func wasmimport_ErrorToDebugString(self0 uint32, result *string) {
+	*result = "host error"
+}
+
+//go:linkname wasmimport_ErrorResourceDrop go.wasmcloud.dev/component/gen/wasi/io/error.wasmimport_ErrorResourceDrop
+func wasmimport_ErrorResourceDrop(self0 uint32) {}
+
+//go:linkname wasmimport_SubscribeDuration go.wasmcloud.dev/component/gen/wasi/clocks/monotonic-clock.wasmimport_SubscribeDuration
+func wasmimport_SubscribeDuration(when0 uint64) (result0 uint32) {
+	deadline := time.Now().Add(time.Duration(when0))
+	return subscribe(func() bool { return !time.Now().Before(deadline) })
+}
+
+// NOTE: the host blocks until a pollable is ready, the stub returns after a
+// while regardless, letting the Poller observe cancellation.
+//
+//go:linkname wasmimport_Poll go.wasmcloud.dev/component/gen/wasi/io/poll.wasmimport_Poll
+func wasmimport_Poll(in0 *poll.Pollable, in1 uint32, result *cm.List[uint32]) {
+	var indices []uint32
+	for start := time.Now(); len(indices) == 0 && time.Since(start) < 10*time.Millisecond; {
+		time.Sleep(100 * time.Microsecond)
+
+		host.Lock()
+		for i, p := range unsafe.Slice(in0, in1) {
+			if ready, ok := host.resources[uint32(p)].(hostPollable); ok && ready() {
+				indices = append(indices, uint32(i))
+			}
+		}
+		host.Unlock()
+	}
+	*result = cm.ToList(indices)
+}
+
+//go:linkname wasmimport_PollableResourceDrop go.wasmcloud.dev/component/gen/wasi/io/poll.wasmimport_PollableResourceDrop
+func wasmimport_PollableResourceDrop(self0 uint32) {
+	drop(self0)
+}
+
+//go:linkname wasmimport_GetRandomBytes go.wasmcloud.dev/component/gen/wasi/random/random.wasmimport_GetRandomBytes
+func wasmimport_GetRandomBytes(len0 uint64, result *cm.List[uint8]) {
+	b := make([]byte, len0)
+	rand.Read(b)
+	*result = cm.ToList(b)
+}
+
+//go:linkname wasmimport_Get go.wasmcloud.dev/component/gen/wasi/config/runtime.wasmimport_Get
+func wasmimport_Get(key0 *uint8, key1 uint32, result *cm.Result[runtime.OptionStringShape, cm.Option[string], runtime.ConfigError]) {
+	*result = cm.OK[cm.Result[runtime.OptionStringShape, cm.Option[string], runtime.ConfigError]](cm.None[string]())
+}
+
+//go:linkname wasmimport_Log go.wasmcloud.dev/component/gen/wasi/logging/logging.wasmimport_Log
+func wasmimport_Log(level0 uint32, context0 *uint8, context1 uint32, message0 *uint8, message1 uint32) {
+}
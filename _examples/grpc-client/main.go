@@ -0,0 +1,65 @@
+package main
+
+//go:generate wit-bindgen-go generate --world example --out gen ./wit
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"time"
+
+	"go.wasmcloud.dev/component/log/wasilog"
+	"go.wasmcloud.dev/component/net/wasinet"
+	"go.wasmcloud.dev/component/wasmcloud"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Checks the health of a gRPC server over `wasi:sockets`, for hosts granting raw socket access:
+//
+//	wasmtime run -S inherit-network build/grpc_client.wasm
+func main() {
+	logger := slog.New(wasilog.DefaultOptions().NewHandler())
+
+	target := wasmcloud.GetConfigOrDefault("target", "localhost:50051")
+
+	creds := insecure.NewCredentials()
+	if wasmcloud.GetConfigOrDefault("tls", "false") == "true" {
+		config, err := wasinet.TLSClientConfig(nil)
+		if err != nil {
+			logger.Error("failed to load TLS config", "error", err)
+			os.Exit(1)
+		}
+		creds = credentials.NewTLS(config)
+	}
+
+	conn, err := newClient(target, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		logger.Error("failed to create client", "error", err)
+		os.Exit(1)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		logger.Error("health check failed", "target", target, "error", err)
+		os.Exit(1)
+	}
+	logger.Info("health check", "target", target, "status", resp.GetStatus())
+}
+
+// newClient creates a gRPC client connecting to target through wasinet.
+//
+// The "passthrough" scheme hands target to the dialer as-is, which resolves it
+// through `wasi:sockets/ip-name-lookup`. The default gRPC DNS resolver relies
+// on the stdlib resolver, which is not available in components.
+func newClient(target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	d := &wasinet.Dialer{Timeout: 10 * time.Second}
+	opts = append([]grpc.DialOption{grpc.WithContextDialer(d.ContextDialer("tcp"))}, opts...)
+	return grpc.NewClient("passthrough:///"+target, opts...)
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func startServer(t *testing.T) (*health.Server, string) {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := grpc.NewServer()
+	healthSrv := health.NewServer()
+	healthpb.RegisterHealthServer(srv, healthSrv)
+
+	go srv.Serve(l)
+	t.Cleanup(srv.Stop)
+
+	return healthSrv, l.Addr().String()
+}
+
+func TestHealthCheck(t *testing.T) {
+	_, addr := startServer(t)
+
+	conn, err := newClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := healthpb.HealthCheckResponse_SERVING; resp.GetStatus() != want {
+		t.Errorf("expected: %v, got: %v", want, resp.GetStatus())
+	}
+}
+
+// TestHealthWatch streams server updates while the client keeps the connection
+// busy with unary calls, interleaving HTTP/2 frames in both directions.
+func TestHealthWatch(t *testing.T) {
+	healthSrv, addr := startServer(t)
+
+	conn, err := newClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client := healthpb.NewHealthClient(conn)
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: "example"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statuses := []healthpb.HealthCheckResponse_ServingStatus{
+		healthpb.HealthCheckResponse_SERVICE_UNKNOWN,
+		healthpb.HealthCheckResponse_SERVING,
+		healthpb.HealthCheckResponse_NOT_SERVING,
+		healthpb.HealthCheckResponse_SERVING,
+	}
+	for i, want := range statuses {
+		if i > 0 {
+			healthSrv.SetServingStatus("example", want)
+		}
+
+		if _, err := client.Check(ctx, &healthpb.HealthCheckRequest{}); err != nil {
+			t.Fatal(err)
+		}
+
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.GetStatus() != want {
+			t.Errorf("expected: %v, got: %v", want, resp.GetStatus())
+		}
+	}
+}
+
+func TestDialCanceled(t *testing.T) {
+	// NOTE: a non-routable address, the connect never completes
+	conn, err := newClient("10.255.255.1:50051", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{}); err == nil {
+		t.Fatal("expected health check to fail")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the check to give up with the context, took %v", elapsed)
+	}
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	_ "unsafe"
+
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+	monotonicclock "go.wasmcloud.dev/component/gen/wasi/clocks/monotonic-clock"
+	"go.wasmcloud.dev/component/gen/wasi/io/streams"
+	"go.wasmcloud.dev/component/gen/wasi/sockets/network"
+	"go.wasmcloud.dev/component/gen/wasi/sockets/tcp"
+)
+
+// NOTE: gRPC uses reflection, which keeps every method of the bindings linked
+// in, the remaining imports are not implemented by the test host.
+
+//go:linkname wasmimport_PollableBlock go.wasmcloud.dev/component/gen/wasi/io/poll.wasmimport_PollableBlock
+func wasmimport_PollableBlock(self0 uint32) {
+	panic("not implemented by the test host")
+}
+
+//go:linkname wasmimport_PollableReady go.wasmcloud.dev/component/gen/wasi/io/poll.wasmimport_PollableReady
+func wasmimport_PollableReady(self0 uint32) (result0 uint32) {
+	panic("not implemented by the test host")
+}
+
+//go:linkname wasmimport_InputStreamBlockingRead go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_InputStreamBlockingRead
+func wasmimport_InputStreamBlockingRead(self0 uint32, len0 uint64, result *cm.Result[cm.List[uint8], cm.List[uint8], streams.StreamError]) {
+	panic("not implemented by the test host")
+}
+
+//go:linkname wasmimport_InputStreamBlockingSkip go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_InputStreamBlockingSkip
+func wasmimport_InputStreamBlockingSkip(self0 uint32, len0 uint64, result *cm.Result[uint64, uint64, streams.StreamError]) {
+	panic("not implemented by the test host")
+}
+
+//go:linkname wasmimport_InputStreamSkip go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_InputStreamSkip
+func wasmimport_InputStreamSkip(self0 uint32, len0 uint64, result *cm.Result[uint64, uint64, streams.StreamError]) {
+	panic("not implemented by the test host")
+}
+
+//go:linkname wasmimport_OutputStreamBlockingSplice go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_OutputStreamBlockingSplice
+func wasmimport_OutputStreamBlockingSplice(self0 uint32, src0 uint32, len0 uint64, result *cm.Result[uint64, uint64, streams.StreamError]) {
+	panic("not implemented by the test host")
+}
+
+//go:linkname wasmimport_OutputStreamBlockingWriteAndFlush go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_OutputStreamBlockingWriteAndFlush
+func wasmimport_OutputStreamBlockingWriteAndFlush(self0 uint32, contents0 *uint8, contents1 uint32, result *cm.Result[streams.StreamError, struct{}, streams.StreamError]) {
+	panic("not implemented by the test host")
+}
+
+//go:linkname wasmimport_OutputStreamBlockingWriteZeroesAndFlush go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_OutputStreamBlockingWriteZeroesAndFlush
+func wasmimport_OutputStreamBlockingWriteZeroesAndFlush(self0 uint32, len0 uint64, result *cm.Result[streams.StreamError, struct{}, streams.StreamError]) {
+	panic("not implemented by the test host")
+}
+
+//go:linkname wasmimport_OutputStreamSplice go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_OutputStreamSplice
+func wasmimport_OutputStreamSplice(self0 uint32, src0 uint32, len0 uint64, result *cm.Result[uint64, uint64, streams.StreamError]) {
+	panic("not implemented by the test host")
+}
+
+//go:linkname wasmimport_OutputStreamWriteZeroes go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_OutputStreamWriteZeroes
+func wasmimport_OutputStreamWriteZeroes(self0 uint32, len0 uint64, result *cm.Result[streams.StreamError, struct{}, streams.StreamError]) {
+	panic("not implemented by the test host")
+}
+
+//go:linkname wasmimport_NetworkResourceDrop go.wasmcloud.dev/component/gen/wasi/sockets/network.wasmimport_NetworkResourceDrop
+func wasmimport_NetworkResourceDrop(self0 uint32) {
+	panic("not implemented by the test host")
+}
+
+//go:linkname wasmimport_TCPSocketAccept go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketAccept
+func wasmimport_TCPSocketAccept(self0 uint32, result *cm.Result[tcp.TupleTCPSocketInputStreamOutputStreamShape, cm.Tuple3[tcp.TCPSocket, streams.InputStream, streams.OutputStream], network.ErrorCode]) {
+	panic("not implemented by the test host")
+}
+
+//go:linkname wasmimport_TCPSocketAddressFamily go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketAddressFamily
+func wasmimport_TCPSocketAddressFamily(self0 uint32) (result0 uint32) {
+	panic("not implemented by the test host")
+}
+
+//go:linkname wasmimport_TCPSocketFinishBind go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketFinishBind
+func wasmimport_TCPSocketFinishBind(self0 uint32, result *cm.Result[network.ErrorCode, struct{}, network.ErrorCode]) {
+	panic("not implemented by the test host")
+}
+
+//go:linkname wasmimport_TCPSocketFinishListen go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketFinishListen
+func wasmimport_TCPSocketFinishListen(self0 uint32, result *cm.Result[network.ErrorCode, struct{}, network.ErrorCode]) {
+	panic("not implemented by the test host")
+}
+
+//go:linkname wasmimport_TCPSocketHopLimit go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketHopLimit
+func wasmimport_TCPSocketHopLimit(self0 uint32, result *cm.Result[uint8, uint8, network.ErrorCode]) {
+	panic("not implemented by the test host")
+}
+
+//go:linkname wasmimport_TCPSocketIsListening go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketIsListening
+func wasmimport_TCPSocketIsListening(self0 uint32) (result0 uint32) {
+	panic("not implemented by the test host")
+}
+
+//go:linkname wasmimport_TCPSocketKeepAliveCount go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketKeepAliveCount
+func wasmimport_TCPSocketKeepAliveCount(self0 uint32, result *cm.Result[uint32, uint32, network.ErrorCode]) {
+	panic("not implemented by the test host")
+}
+
+//go:linkname wasmimport_TCPSocketKeepAliveEnabled go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketKeepAliveEnabled
+func wasmimport_TCPSocketKeepAliveEnabled(self0 uint32, result *cm.Result[bool, bool, network.ErrorCode]) {
+	panic("not implemented by the test host")
+}
+
+//go:linkname wasmimport_TCPSocketKeepAliveIdleTime go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketKeepAliveIdleTime
+func wasmimport_TCPSocketKeepAliveIdleTime(self0 uint32, result *cm.Result[uint64, monotonicclock.Duration, network.ErrorCode]) {
+	panic("not implemented by the test host")
+}
+
+//go:linkname wasmimport_TCPSocketKeepAliveInterval go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketKeepAliveInterval
+func wasmimport_TCPSocketKeepAliveInterval(self0 uint32, result *cm.Result[uint64, monotonicclock.Duration, network.ErrorCode]) {
+	panic("not implemented by the test host")
+}
+
+//go:linkname wasmimport_TCPSocketReceiveBufferSize go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketReceiveBufferSize
+func wasmimport_TCPSocketReceiveBufferSize(self0 uint32, result *cm.Result[uint64, uint64, network.ErrorCode]) {
+	panic("not implemented by the test host")
+}
+
+//go:linkname wasmimport_TCPSocketSendBufferSize go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketSendBufferSize
+func wasmimport_TCPSocketSendBufferSize(self0 uint32, result *cm.Result[uint64, uint64, network.ErrorCode]) {
+	panic("not implemented by the test host")
+}
+
+//go:linkname wasmimport_TCPSocketSetHopLimit go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketSetHopLimit
+func wasmimport_TCPSocketSetHopLimit(self0 uint32, value0 uint32, result *cm.Result[network.ErrorCode, struct{}, network.ErrorCode]) {
+	panic("not implemented by the test host")
+}
+
+//go:linkname wasmimport_TCPSocketSetKeepAliveCount go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketSetKeepAliveCount
+func wasmimport_TCPSocketSetKeepAliveCount(self0 uint32, value0 uint32, result *cm.Result[network.ErrorCode, struct{}, network.ErrorCode]) {
+	panic("not implemented by the test host")
+}
+
+//go:linkname wasmimport_TCPSocketSetListenBacklogSize go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketSetListenBacklogSize
+func wasmimport_TCPSocketSetListenBacklogSize(self0 uint32, value0 uint64, result *cm.Result[network.ErrorCode, struct{}, network.ErrorCode]) {
+	panic("not implemented by the test host")
+}
+
+//go:linkname wasmimport_TCPSocketSetReceiveBufferSize go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketSetReceiveBufferSize
+func wasmimport_TCPSocketSetReceiveBufferSize(self0 uint32, value0 uint64, result *cm.Result[network.ErrorCode, struct{}, network.ErrorCode]) {
+	panic("not implemented by the test host")
+}
+
+//go:linkname wasmimport_TCPSocketSetSendBufferSize go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketSetSendBufferSize
+func wasmimport_TCPSocketSetSendBufferSize(self0 uint32, value0 uint64, result *cm.Result[network.ErrorCode, struct{}, network.ErrorCode]) {
+	panic("not implemented by the test host")
+}
+
+//go:linkname wasmimport_TCPSocketShutdown go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketShutdown
+func wasmimport_TCPSocketShutdown(self0 uint32, shutdownType0 uint32, result *cm.Result[network.ErrorCode, struct{}, network.ErrorCode]) {
+	panic("not implemented by the test host")
+}
+
+//go:linkname wasmimport_TCPSocketStartBind go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketStartBind
+func wasmimport_TCPSocketStartBind(self0 uint32, network0 uint32, localAddress0 uint32, localAddress1 uint32, localAddress2 uint32, localAddress3 uint32, localAddress4 uint32, localAddress5 uint32, localAddress6 uint32, localAddress7 uint32, localAddress8 uint32, localAddress9 uint32, localAddress10 uint32, localAddress11 uint32, result *cm.Result[network.ErrorCode, struct{}, network.ErrorCode]) {
+	panic("not implemented by the test host")
+}
+
+//go:linkname wasmimport_TCPSocketStartListen go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketStartListen
+func wasmimport_TCPSocketStartListen(self0 uint32, result *cm.Result[network.ErrorCode, struct{}, network.ErrorCode]) {
+	panic("not implemented by the test host")
+}
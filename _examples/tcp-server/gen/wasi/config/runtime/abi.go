@@ -0,0 +1,18 @@
+// Code generated by wit-bindgen-go. DO NOT EDIT.
+
+package runtime
+
+import (
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"unsafe"
+)
+
+// OptionStringShape is used for storage in variant or result types.
+type OptionStringShape struct {
+	shape [unsafe.Sizeof(cm.Option[string]{})]byte
+}
+
+// ConfigErrorShape is used for storage in variant or result types.
+type ConfigErrorShape struct {
+	shape [unsafe.Sizeof(ConfigError{})]byte
+}
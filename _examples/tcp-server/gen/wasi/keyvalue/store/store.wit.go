@@ -0,0 +1,310 @@
+// Code generated by wit-bindgen-go. DO NOT EDIT.
+
+// Package store represents the imported interface "wasi:keyvalue/store@0.2.0-draft".
+//
+// A keyvalue interface that provides eventually consistent key-value operations.
+//
+// Each of these operations acts on a single key-value pair.
+//
+// The value in the key-value pair is defined as a `u8` byte array and the intention
+// is that it is
+// the common denominator for all data types defined by different key-value stores
+// to handle data,
+// ensuring compatibility between different key-value stores. Note: the clients will
+// be expecting
+// serialization/deserialization overhead to be handled by the key-value store. The
+// value could be
+// a serialized object from JSON, HTML or vendor-specific data types like AWS S3 objects.
+//
+// Data consistency in a key value store refers to the guarantee that once a write
+// operation
+// completes, all subsequent read operations will return the value that was written.
+//
+// Any implementation of this interface must have enough consistency to guarantee
+// "reading your
+// writes." In particular, this means that the client should never get a value that
+// is older than
+// the one it wrote, but it MAY get a newer value if one was written around the same
+// time. These
+// guarantees only apply to the same client (which will likely be provided by the
+// host or an
+// external capability of some kind). In this context a "client" is referring to the
+// caller or
+// guest that is consuming this interface. Once a write request is committed by a
+// specific client,
+// all subsequent read requests by the same client will reflect that write or any
+// subsequent
+// writes. Another client running in a different context may or may not immediately
+// see the result
+// due to the replication lag. As an example of all of this, if a value at a given
+// key is A, and
+// the client writes B, then immediately reads, it should get B. If something else
+// writes C in
+// quick succession, then the client may get C. However, a client running in a separate
+// context may
+// still see A or B
+package store
+
+import (
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+)
+
+// Error represents the variant "wasi:keyvalue/store@0.2.0-draft#error".
+//
+// The set of errors which may be raised by functions in this package
+//
+//	variant error {
+//		no-such-store,
+//		access-denied,
+//		other(string),
+//	}
+type Error cm.Variant[uint8, string, string]
+
+// ErrorNoSuchStore returns a [Error] of case "no-such-store".
+//
+// The host does not recognize the store identifier requested.
+func ErrorNoSuchStore() Error {
+	var data struct{}
+	return cm.New[Error](0, data)
+}
+
+// NoSuchStore returns true if [Error] represents the variant case "no-such-store".
+func (self *Error) NoSuchStore() bool {
+	return self.Tag() == 0
+}
+
+// ErrorAccessDenied returns a [Error] of case "access-denied".
+//
+// The requesting component does not have access to the specified store
+// (which may or may not exist).
+func ErrorAccessDenied() Error {
+	var data struct{}
+	return cm.New[Error](1, data)
+}
+
+// AccessDenied returns true if [Error] represents the variant case "access-denied".
+func (self *Error) AccessDenied() bool {
+	return self.Tag() == 1
+}
+
+// ErrorOther returns a [Error] of case "other".
+//
+// Some implementation-specific error has occurred (e.g. I/O)
+func ErrorOther(data string) Error {
+	return cm.New[Error](2, data)
+}
+
+// Other returns a non-nil *[string] if [Error] represents the variant case "other".
+func (self *Error) Other() *string {
+	return cm.Case[string](self, 2)
+}
+
+// KeyResponse represents the record "wasi:keyvalue/store@0.2.0-draft#key-response".
+//
+// A response to a `list-keys` operation.
+//
+//	record key-response {
+//		keys: list<string>,
+//		cursor: option<u64>,
+//	}
+type KeyResponse struct {
+	// The list of keys returned by the query.
+	Keys cm.List[string]
+
+	// The continuation token to use to fetch the next page of keys. If this is `null`,
+	// then
+	// there are no more keys to fetch.
+	Cursor cm.Option[uint64]
+}
+
+// Bucket represents the imported resource "wasi:keyvalue/store@0.2.0-draft#bucket".
+//
+// A bucket is a collection of key-value pairs. Each key-value pair is stored as a
+// entry in the
+// bucket, and the bucket itself acts as a collection of all these entries.
+//
+// It is worth noting that the exact terminology for bucket in key-value stores can
+// very
+// depending on the specific implementation. For example:
+//
+// 1. Amazon DynamoDB calls a collection of key-value pairs a table
+// 2. Redis has hashes, sets, and sorted sets as different types of collections
+// 3. Cassandra calls a collection of key-value pairs a column family
+// 4. MongoDB calls a collection of key-value pairs a collection
+// 5. Riak calls a collection of key-value pairs a bucket
+// 6. Memcached calls a collection of key-value pairs a slab
+// 7. Azure Cosmos DB calls a collection of key-value pairs a container
+//
+// In this interface, we use the term `bucket` to refer to a collection of key-value
+// pairs
+//
+//	resource bucket
+type Bucket cm.Resource
+
+// ResourceDrop represents the imported resource-drop for resource "bucket".
+//
+// Drops a resource handle.
+//
+//go:nosplit
+func (self Bucket) ResourceDrop() {
+	self0 := cm.Reinterpret[uint32](self)
+	wasmimport_BucketResourceDrop((uint32)(self0))
+	return
+}
+
+//go:wasmimport wasi:keyvalue/store@0.2.0-draft [resource-drop]bucket
+//go:noescape
+func wasmimport_BucketResourceDrop(self0 uint32)
+
+// Delete represents the imported method "delete".
+//
+// Delete the key-value pair associated with the key in the store.
+//
+// If the key does not exist in the store, it does nothing.
+//
+// If any other error occurs, it returns an `Err(error)`.
+//
+//	delete: func(key: string) -> result<_, error>
+//
+//go:nosplit
+func (self Bucket) Delete(key string) (result cm.Result[Error, struct{}, Error]) {
+	self0 := cm.Reinterpret[uint32](self)
+	key0, key1 := cm.LowerString(key)
+	wasmimport_BucketDelete((uint32)(self0), (*uint8)(key0), (uint32)(key1), &result)
+	return
+}
+
+//go:wasmimport wasi:keyvalue/store@0.2.0-draft [method]bucket.delete
+//go:noescape
+func wasmimport_BucketDelete(self0 uint32, key0 *uint8, key1 uint32, result *cm.Result[Error, struct{}, Error])
+
+// Exists represents the imported method "exists".
+//
+// Check if the key exists in the store.
+//
+// If the key exists in the store, it returns `Ok(true)`. If the key does
+// not exist in the store, it returns `Ok(false)`.
+//
+// If any other error occurs, it returns an `Err(error)`.
+//
+//	exists: func(key: string) -> result<bool, error>
+//
+//go:nosplit
+func (self Bucket) Exists(key string) (result cm.Result[ErrorShape, bool, Error]) {
+	self0 := cm.Reinterpret[uint32](self)
+	key0, key1 := cm.LowerString(key)
+	wasmimport_BucketExists((uint32)(self0), (*uint8)(key0), (uint32)(key1), &result)
+	return
+}
+
+//go:wasmimport wasi:keyvalue/store@0.2.0-draft [method]bucket.exists
+//go:noescape
+func wasmimport_BucketExists(self0 uint32, key0 *uint8, key1 uint32, result *cm.Result[ErrorShape, bool, Error])
+
+// Get represents the imported method "get".
+//
+// Get the value associated with the specified `key`
+//
+// The value is returned as an option. If the key-value pair exists in the
+// store, it returns `Ok(value)`. If the key does not exist in the
+// store, it returns `Ok(none)`.
+//
+// If any other error occurs, it returns an `Err(error)`.
+//
+//	get: func(key: string) -> result<option<list<u8>>, error>
+//
+//go:nosplit
+func (self Bucket) Get(key string) (result cm.Result[OptionListU8Shape, cm.Option[cm.List[uint8]], Error]) {
+	self0 := cm.Reinterpret[uint32](self)
+	key0, key1 := cm.LowerString(key)
+	wasmimport_BucketGet((uint32)(self0), (*uint8)(key0), (uint32)(key1), &result)
+	return
+}
+
+//go:wasmimport wasi:keyvalue/store@0.2.0-draft [method]bucket.get
+//go:noescape
+func wasmimport_BucketGet(self0 uint32, key0 *uint8, key1 uint32, result *cm.Result[OptionListU8Shape, cm.Option[cm.List[uint8]], Error])
+
+// ListKeys represents the imported method "list-keys".
+//
+// Get all the keys in the store with an optional cursor (for use in pagination).
+// It
+// returns a list of keys. Please note that for most KeyValue implementations, this
+// is a
+// can be a very expensive operation and so it should be used judiciously. Implementations
+// can return any number of keys in a single response, but they should never attempt
+// to
+// send more data than is reasonable (i.e. on a small edge device, this may only be
+// a few
+// KB, while on a large machine this could be several MB). Any response should also
+// return
+// a cursor that can be used to fetch the next page of keys. See the `key-response`
+// record
+// for more information.
+//
+// Note that the keys are not guaranteed to be returned in any particular order.
+//
+// If the store is empty, it returns an empty list.
+//
+// MAY show an out-of-date list of keys if there are concurrent writes to the store.
+//
+// If any error occurs, it returns an `Err(error)`.
+//
+//	list-keys: func(cursor: option<u64>) -> result<key-response, error>
+//
+//go:nosplit
+func (self Bucket) ListKeys(cursor cm.Option[uint64]) (result cm.Result[KeyResponseShape, KeyResponse, Error]) {
+	self0 := cm.Reinterpret[uint32](self)
+	cursor0, cursor1 := lower_OptionU64(cursor)
+	wasmimport_BucketListKeys((uint32)(self0), (uint32)(cursor0), (uint64)(cursor1), &result)
+	return
+}
+
+//go:wasmimport wasi:keyvalue/store@0.2.0-draft [method]bucket.list-keys
+//go:noescape
+func wasmimport_BucketListKeys(self0 uint32, cursor0 uint32, cursor1 uint64, result *cm.Result[KeyResponseShape, KeyResponse, Error])
+
+// Set represents the imported method "set".
+//
+// Set the value associated with the key in the store. If the key already
+// exists in the store, it overwrites the value.
+//
+// If the key does not exist in the store, it creates a new key-value pair.
+//
+// If any other error occurs, it returns an `Err(error)`.
+//
+//	set: func(key: string, value: list<u8>) -> result<_, error>
+//
+//go:nosplit
+func (self Bucket) Set(key string, value cm.List[uint8]) (result cm.Result[Error, struct{}, Error]) {
+	self0 := cm.Reinterpret[uint32](self)
+	key0, key1 := cm.LowerString(key)
+	value0, value1 := cm.LowerList(value)
+	wasmimport_BucketSet((uint32)(self0), (*uint8)(key0), (uint32)(key1), (*uint8)(value0), (uint32)(value1), &result)
+	return
+}
+
+//go:wasmimport wasi:keyvalue/store@0.2.0-draft [method]bucket.set
+//go:noescape
+func wasmimport_BucketSet(self0 uint32, key0 *uint8, key1 uint32, value0 *uint8, value1 uint32, result *cm.Result[Error, struct{}, Error])
+
+// Open represents the imported function "open".
+//
+// Get the bucket with the specified identifier.
+//
+// `identifier` must refer to a bucket provided by the host.
+//
+// `error::no-such-store` will be raised if the `identifier` is not recognized.
+//
+//	open: func(identifier: string) -> result<bucket, error>
+//
+//go:nosplit
+func Open(identifier string) (result cm.Result[ErrorShape, Bucket, Error]) {
+	identifier0, identifier1 := cm.LowerString(identifier)
+	wasmimport_Open((*uint8)(identifier0), (uint32)(identifier1), &result)
+	return
+}
+
+//go:wasmimport wasi:keyvalue/store@0.2.0-draft open
+//go:noescape
+func wasmimport_Open(identifier0 *uint8, identifier1 uint32, result *cm.Result[ErrorShape, Bucket, Error])
@@ -0,0 +1,25 @@
+// Code generated by wit-bindgen-go. DO NOT EDIT.
+
+// Package types represents the imported interface "wasmcloud:messaging/types@0.2.0".
+//
+// Types common to message broker interactions
+package types
+
+import (
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+)
+
+// BrokerMessage represents the record "wasmcloud:messaging/types@0.2.0#broker-message".
+//
+// A message sent to or received from a broker
+//
+//	record broker-message {
+//		subject: string,
+//		body: list<u8>,
+//		reply-to: option<string>,
+//	}
+type BrokerMessage struct {
+	Subject string
+	Body    cm.List[uint8]
+	ReplyTo cm.Option[string]
+}
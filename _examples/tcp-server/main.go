@@ -0,0 +1,48 @@
+package main
+
+//go:generate wit-bindgen-go generate --world example --out gen ./wit
+
+import (
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"go.wasmcloud.dev/component/log/wasilog"
+	"go.wasmcloud.dev/component/net/wasinet"
+)
+
+// Serves plain HTTP over `wasi:sockets`, for hosts granting raw socket access:
+//
+//	wasmtime run -S inherit-network build/tcp_server.wasm
+func main() {
+	logger := slog.New(wasilog.DefaultOptions().NewHandler())
+
+	l, err := wasinet.Listen("tcp", ":8080")
+	if err != nil {
+		logger.Error("failed to listen", "error", err)
+		os.Exit(1)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("Hello from " + r.RemoteAddr + "\n"))
+	})
+
+	srv := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+		// idle keep-alive connections are closed through wasinet read deadlines
+		IdleTimeout: 30 * time.Second,
+		ConnState: func(conn net.Conn, state http.ConnState) {
+			logger.Info("connection state", "remote", conn.RemoteAddr(), "state", state)
+		},
+	}
+
+	logger.Info("listening", "addr", l.Addr())
+	if err := srv.Serve(l); err != nil {
+		logger.Error("server stopped", "error", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,280 @@
+package wasiblob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"go.wasmcloud.dev/component/internal/backend"
+	"go.wasmcloud.dev/component/wasmcloud"
+)
+
+// listBatchSize is the number of object names read per host call.
+const listBatchSize = 100
+
+// ContainerInfo describes a container.
+type ContainerInfo struct {
+	Name      string
+	CreatedAt time.Time
+}
+
+// ObjectInfo describes an object.
+type ObjectInfo struct {
+	Name      string
+	Container string
+	CreatedAt time.Time
+	Size      int64
+}
+
+// Container is a `wasi:blobstore/container` container.
+type Container struct {
+	name      string
+	link      string
+	container backend.Container
+}
+
+// Open opens the existing container with the given name.
+func Open(name string) (*Container, error) {
+	return OpenContext(context.Background(), name)
+}
+
+// OpenContext opens the existing container with the given name of the link
+// selected by wasmcloud.WithLinkName, e.g. "backup-blobs". The container is
+// called through that link until it is closed.
+func OpenContext(ctx context.Context, name string) (*Container, error) {
+	link := wasmcloud.LinkName(ctx)
+	wasmcloud.UseLinkName(link, interfaces...)
+	c, err := openContainer(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open container %q: %w", name, err)
+	}
+	return &Container{name: name, link: link, container: linkedContainer{link: link, container: c}}, nil
+}
+
+// Create creates a new empty container with the given name.
+func Create(name string) (*Container, error) {
+	return CreateContext(context.Background(), name)
+}
+
+// CreateContext creates a new empty container with the given name with the
+// link selected by wasmcloud.WithLinkName, see [OpenContext].
+func CreateContext(ctx context.Context, name string) (*Container, error) {
+	link := wasmcloud.LinkName(ctx)
+	c, err := linkedHost(link).CreateContainer(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create container %q: %w", name, err)
+	}
+	return &Container{name: name, link: link, container: linkedContainer{link: link, container: c}}, nil
+}
+
+// DeleteContainer deletes the named container and every object within it.
+func DeleteContainer(name string) error {
+	return DeleteContainerContext(context.Background(), name)
+}
+
+// DeleteContainerContext deletes the named container of the link selected by
+// wasmcloud.WithLinkName, see [OpenContext].
+func DeleteContainerContext(ctx context.Context, name string) error {
+	if err := linkedHost(wasmcloud.LinkName(ctx)).DeleteContainer(name); err != nil {
+		return fmt.Errorf("failed to delete container %q: %w", name, err)
+	}
+	return nil
+}
+
+// ContainerExists reports whether the named container exists.
+func ContainerExists(name string) (bool, error) {
+	return ContainerExistsContext(context.Background(), name)
+}
+
+// ContainerExistsContext reports whether the named container of the link
+// selected by wasmcloud.WithLinkName exists, see [OpenContext].
+func ContainerExistsContext(ctx context.Context, name string) (bool, error) {
+	ok, err := linkedHost(wasmcloud.LinkName(ctx)).ContainerExists(name)
+	if err != nil {
+		return false, fmt.Errorf("failed to check container %q: %w", name, err)
+	}
+	return ok, nil
+}
+
+// Name returns the name the container was opened with.
+func (c *Container) Name() string {
+	return c.name
+}
+
+// Close releases the container, it must not be used afterwards.
+func (c *Container) Close() error {
+	c.container.Close()
+	return nil
+}
+
+// Info returns the container metadata.
+func (c *Container) Info() (ContainerInfo, error) {
+	createdAt, err := c.container.CreatedAt()
+	if err != nil {
+		return ContainerInfo{}, fmt.Errorf("failed to get container %q info: %w", c.name, err)
+	}
+	return ContainerInfo{Name: c.name, CreatedAt: timestamp(createdAt)}, nil
+}
+
+// Stat returns the metadata of the named object.
+func (c *Container) Stat(name string) (ObjectInfo, error) {
+	info, err := c.container.ObjectInfo(name)
+	if err != nil {
+		return ObjectInfo{}, fmt.Errorf("failed to stat %q: %w", name, err)
+	}
+	return ObjectInfo{
+		Name:      info.Name,
+		Container: info.Container,
+		CreatedAt: timestamp(info.CreatedAt),
+		Size:      int64(info.Size),
+	}, nil
+}
+
+// Has reports whether the named object exists.
+func (c *Container) Has(name string) (bool, error) {
+	ok, err := c.container.HasObject(name)
+	if err != nil {
+		return false, fmt.Errorf("failed to check %q: %w", name, err)
+	}
+	return ok, nil
+}
+
+// Delete removes the named object, it is not an error if it does not exist.
+func (c *Container) Delete(name string) error {
+	if err := c.container.DeleteObject(name); err != nil {
+		return fmt.Errorf("failed to delete %q: %w", name, err)
+	}
+	return nil
+}
+
+// DeleteObjects removes the named objects in a single host call.
+func (c *Container) DeleteObjects(names ...string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	if err := c.container.DeleteObjects(names); err != nil {
+		return fmt.Errorf("failed to delete objects: %w", err)
+	}
+	return nil
+}
+
+// Clear removes every object, leaving the container empty.
+func (c *Container) Clear() error {
+	if err := c.container.Clear(); err != nil {
+		return fmt.Errorf("failed to clear container %q: %w", c.name, err)
+	}
+	return nil
+}
+
+// Get returns the contents of the named object.
+func (c *Container) Get(name string) ([]byte, error) {
+	r, err := c.NewReader(name)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// Put creates or replaces the named object with data.
+func (c *Container) Put(name string, data []byte) error {
+	w, err := c.NewWriter(name)
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return errors.Join(err, w.Close())
+	}
+	return w.Close()
+}
+
+// List returns the names of every object in the container, in no particular order.
+func (c *Container) List() ([]string, error) {
+	var names []string
+	s := c.ScanObjects()
+	defer s.Close()
+	for s.Next() {
+		names = append(names, s.Name())
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// ObjectScanner iterates over the object names of a [Container], reading them
+// from the host in batches. It must be closed once done.
+type ObjectScanner struct {
+	container *Container
+	stream    backend.ObjectNames
+	batch     []string
+	name      string
+	done      bool
+	err       error
+}
+
+// ScanObjects returns an [ObjectScanner] over the object names of the container.
+func (c *Container) ScanObjects() *ObjectScanner {
+	return &ObjectScanner{container: c}
+}
+
+// Next advances to the next object name, it returns false once the names are
+// exhausted or an error occurred.
+func (s *ObjectScanner) Next() bool {
+	for len(s.batch) == 0 {
+		if s.done || s.err != nil {
+			s.name = ""
+			return false
+		}
+		s.read()
+	}
+	s.name = s.batch[0]
+	s.batch = s.batch[1:]
+	return true
+}
+
+// Name returns the current object name.
+func (s *ObjectScanner) Name() string {
+	return s.name
+}
+
+// Err returns the error, if any, that stopped the scan.
+func (s *ObjectScanner) Err() error {
+	return s.err
+}
+
+// Close releases the host listing.
+func (s *ObjectScanner) Close() error {
+	if s.stream != nil {
+		s.stream.Close()
+		s.stream = nil
+	}
+	s.done = true
+	return nil
+}
+
+func (s *ObjectScanner) read() {
+	if s.stream == nil {
+		stream, err := s.container.container.ListObjects()
+		if err != nil {
+			s.err = fmt.Errorf("failed to list container %q: %w", s.container.name, err)
+			return
+		}
+		s.stream = stream
+	}
+
+	s.batch, s.done, s.err = s.stream.Read(listBatchSize)
+	if s.err != nil {
+		s.err = fmt.Errorf("failed to list container %q: %w", s.container.name, s.err)
+	}
+}
+
+// timestamp converts a blobstore timestamp, which the hosts report in seconds
+// since the Unix epoch.
+//
+// NOTE: `wasi:blobstore@0.2.0-draft` leaves the timestamp unit unspecified.
+func timestamp(ts uint64) time.Time {
+	return time.Unix(int64(ts), 0)
+}
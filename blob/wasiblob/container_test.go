@@ -0,0 +1,196 @@
+//go:build !wasm
+
+package wasiblob
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"go.wasmcloud.dev/component/internal/fakehost"
+	"go.wasmcloud.dev/component/wasmcloud"
+)
+
+func TestContainer(t *testing.T) {
+	c, err := Create("photos")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if _, err := Create("photos"); err == nil {
+		t.Error("expected error creating an existing container")
+	}
+	if ok, err := ContainerExists("photos"); err != nil || !ok {
+		t.Errorf("expected: %v, got: %v (%v)", true, ok, err)
+	}
+
+	info, err := c.Info()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (ContainerInfo{Name: "photos", CreatedAt: time.Unix(createdAt, 0)}); info != want {
+		t.Errorf("expected: %v, got: %v", want, info)
+	}
+
+	for _, name := range []string{"a.jpg", "b.jpg", "c.jpg", "d.jpg"} {
+		if err := c.Put(name, []byte("contents of "+name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data, err := c.Get("b.jpg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "contents of b.jpg"; string(data) != want {
+		t.Errorf("expected: %v, got: %v", want, string(data))
+	}
+
+	obj, err := c.Stat("a.jpg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (ObjectInfo{Name: "a.jpg", Container: "photos", CreatedAt: time.Unix(createdAt, 0), Size: 17}); obj != want {
+		t.Errorf("expected: %v, got: %v", want, obj)
+	}
+
+	names, err := c.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	slices.Sort(names)
+	if want := []string{"a.jpg", "b.jpg", "c.jpg", "d.jpg"}; !slices.Equal(names, want) {
+		t.Errorf("expected: %v, got: %v", want, names)
+	}
+
+	if err := c.Delete("a.jpg"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DeleteObjects("b.jpg", "c.jpg"); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := c.Has("a.jpg"); err != nil || ok {
+		t.Errorf("expected: %v, got: %v (%v)", false, ok, err)
+	}
+	if ok, err := c.Has("d.jpg"); err != nil || !ok {
+		t.Errorf("expected: %v, got: %v (%v)", true, ok, err)
+	}
+
+	if err := c.Clear(); err != nil {
+		t.Fatal(err)
+	}
+	if names, err := c.List(); err != nil || len(names) != 0 {
+		t.Errorf("expected empty container, got: %v (%v)", names, err)
+	}
+
+	if err := DeleteContainer("photos"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open("photos"); err == nil {
+		t.Error("expected error opening a deleted container")
+	}
+}
+
+func TestLinkName(t *testing.T) {
+	backup := wasmcloud.WithLinkName(context.Background(), "backup-blobs")
+
+	tests := map[string]func(ctx context.Context) error{
+		"exists": func(ctx context.Context) error {
+			_, err := ContainerExistsContext(ctx, "linked")
+			return err
+		},
+		"copy": func(ctx context.Context) error {
+			return CopyObjectContext(ctx, ObjectID{Container: "linked", Name: "a"}, ObjectID{Container: "linked", Name: "b"})
+		},
+		"move": func(ctx context.Context) error {
+			return MoveObjectContext(ctx, ObjectID{Container: "linked", Name: "a"}, ObjectID{Container: "linked", Name: "b"})
+		},
+		"upload": func(ctx context.Context) error {
+			c, err := OpenContext(ctx, "linked")
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			// another call switched the link, the upload moves with the link of c
+			wasmcloud.UseLinkName(wasmcloud.DefaultLinkName, interfaces...)
+			_, err = NewUploader(c, "d", UploadOptions{}).Upload(context.Background(), strings.NewReader("data"))
+			return err
+		},
+	}
+
+	for name, call := range tests {
+		t.Run(name, func(t *testing.T) {
+			for _, link := range []string{"backup-blobs", wasmcloud.DefaultLinkName} {
+				fakeContainers("linked", map[string]string{"a": "data"})
+				ctx := wasmcloud.WithLinkName(context.Background(), link)
+				if err := call(ctx); err != nil {
+					t.Fatal(err)
+				}
+				if got := fakehost.LinkName("wasi:blobstore/blobstore"); got != link {
+					t.Errorf("expected: %v, got: %v", link, got)
+				}
+			}
+		})
+	}
+
+	if err := DeleteContainerContext(backup, "linked"); err != nil {
+		t.Fatal(err)
+	}
+	if got := fakehost.LinkName("wasi:blobstore/blobstore"); got != "backup-blobs" {
+		t.Errorf("expected: %v, got: %v", "backup-blobs", got)
+	}
+}
+
+func TestObjectTransfer(t *testing.T) {
+	fakeContainers("src", map[string]string{"a": "data", "b": "data"})
+	fakeContainers("dst", map[string]string{})
+
+	tests := map[string]struct {
+		transfer   func(src, dst ObjectID) error
+		src        ObjectID
+		srcRemains bool
+		err        bool
+	}{
+		"copy":              {transfer: CopyObject, src: ObjectID{Container: "src", Name: "a"}, srcRemains: true},
+		"move":              {transfer: MoveObject, src: ObjectID{Container: "src", Name: "b"}},
+		"missing object":    {transfer: CopyObject, src: ObjectID{Container: "src", Name: "missing"}, err: true},
+		"missing container": {transfer: MoveObject, src: ObjectID{Container: "missing", Name: "a"}, err: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			dst := ObjectID{Container: "dst", Name: name}
+			err := tt.transfer(tt.src, dst)
+			if tt.err {
+				if err == nil {
+					t.Error("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			c, err := Open("dst")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer c.Close()
+			if data, err := c.Get(name); err != nil || string(data) != "data" {
+				t.Errorf("expected: %v, got: %v (%v)", "data", string(data), err)
+			}
+
+			src, err := Open(tt.src.Container)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer src.Close()
+			if ok, _ := src.Has(tt.src.Name); ok != tt.srcRemains {
+				t.Errorf("expected: %v, got: %v", tt.srcRemains, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,310 @@
+package wasiblob
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	"time"
+)
+
+// FS returns a read-only fs.FS over the objects of c, for use with e.g.
+// http.FileServerFS or fs.WalkDir. Object names are paths separated by "/",
+// directories are implied by the names of the objects within them.
+//
+// The returned fs.FS also implements fs.ReadDirFS, fs.ReadFileFS and fs.StatFS.
+// Objects whose names are not valid fs.FS paths are not reachable.
+//
+// NOTE: `wasi:blobstore@0.2.0-draft` cannot list by prefix, so opening or
+// reading a directory lists every object of the container.
+func FS(c *Container) fs.FS {
+	return containerFS{c}
+}
+
+type containerFS struct {
+	c *Container
+}
+
+func (fsys containerFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+
+	info, err := fsys.stat(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	if info.IsDir() {
+		entries, _, err := fsys.readDir(name)
+		if err != nil {
+			return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+		}
+		return &dir{info: info, entries: entries}, nil
+	}
+	return &file{c: fsys.c, name: name, info: info}, nil
+}
+
+func (fsys containerFS) Stat(name string) (fs.FileInfo, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrInvalid}
+	}
+	info, err := fsys.stat(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: err}
+	}
+	return info, nil
+}
+
+func (fsys containerFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+	entries, found, err := fsys.readDir(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: err}
+	}
+	if !found && name != "." {
+		if ok, err := fsys.c.Has(name); err == nil && ok {
+			return nil, &fs.PathError{Op: "readdir", Path: name, Err: errors.New("not a directory")}
+		}
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+	return entries, nil
+}
+
+func (fsys containerFS) ReadFile(name string) ([]byte, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrInvalid}
+	}
+	if name == "." {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: errors.New("is a directory")}
+	}
+	if ok, err := fsys.c.Has(name); err != nil || !ok {
+		if err == nil {
+			err = fs.ErrNotExist
+		}
+		return nil, &fs.PathError{Op: "read", Path: name, Err: err}
+	}
+	data, err := fsys.c.Get(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: err}
+	}
+	return data, nil
+}
+
+// stat returns the info of the object at name, or of the directory implied
+// by the objects below it.
+func (fsys containerFS) stat(name string) (fs.FileInfo, error) {
+	if name == "." {
+		return dirInfo{name: "."}, nil
+	}
+
+	ok, err := fsys.c.Has(name)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		info, err := fsys.c.Stat(name)
+		if err != nil {
+			return nil, err
+		}
+		return objectInfo{name: path.Base(name), size: info.Size, modTime: info.CreatedAt}, nil
+	}
+
+	_, found, err := fsys.readDir(name)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fs.ErrNotExist
+	}
+	return dirInfo{name: path.Base(name)}, nil
+}
+
+// readDir lists the entries directly below name, sorted by name. found reports
+// whether any object is below name, including "name/" directory markers.
+func (fsys containerFS) readDir(name string) (entries []fs.DirEntry, found bool, err error) {
+	prefix := ""
+	if name != "." {
+		prefix = name + "/"
+	}
+
+	seen := map[string]bool{}
+	s := fsys.c.ScanObjects()
+	defer s.Close()
+	for s.Next() {
+		object := s.Name()
+		rest, ok := strings.CutPrefix(object, prefix)
+		if !ok || !fs.ValidPath(strings.TrimSuffix(object, "/")) {
+			continue
+		}
+		found = true
+
+		base, _, isDir := strings.Cut(rest, "/")
+		if base == "" || seen[base] {
+			continue
+		}
+		seen[base] = true
+
+		if isDir {
+			entries = append(entries, fs.FileInfoToDirEntry(dirInfo{name: base}))
+		} else {
+			entries = append(entries, &objectEntry{c: fsys.c, object: object, name: base})
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, false, err
+	}
+
+	slices.SortFunc(entries, func(a, b fs.DirEntry) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+	return entries, found, nil
+}
+
+type objectInfo struct {
+	name    string
+	size    int64
+	modTime time.Time
+}
+
+func (i objectInfo) Name() string       { return i.name }
+func (i objectInfo) Size() int64        { return i.size }
+func (i objectInfo) Mode() fs.FileMode  { return 0o444 }
+func (i objectInfo) ModTime() time.Time { return i.modTime }
+func (i objectInfo) IsDir() bool        { return false }
+func (i objectInfo) Sys() any           { return nil }
+
+type dirInfo struct {
+	name string
+}
+
+func (i dirInfo) Name() string       { return i.name }
+func (i dirInfo) Size() int64        { return 0 }
+func (i dirInfo) Mode() fs.FileMode  { return fs.ModeDir | 0o555 }
+func (i dirInfo) ModTime() time.Time { return time.Time{} }
+func (i dirInfo) IsDir() bool        { return true }
+func (i dirInfo) Sys() any           { return nil }
+
+// objectEntry is a directory entry for an object, its metadata is fetched on
+// the first call to Info.
+type objectEntry struct {
+	c      *Container
+	object string
+	name   string
+}
+
+func (e *objectEntry) Name() string      { return e.name }
+func (e *objectEntry) IsDir() bool       { return false }
+func (e *objectEntry) Type() fs.FileMode { return 0 }
+
+func (e *objectEntry) Info() (fs.FileInfo, error) {
+	info, err := e.c.Stat(e.object)
+	if err != nil {
+		return nil, err
+	}
+	return objectInfo{name: e.name, size: info.Size, modTime: info.CreatedAt}, nil
+}
+
+// file is an object opened through [FS]. Reads open a range reader at the
+// current offset, so seeking only costs a new host stream.
+type file struct {
+	c      *Container
+	name   string
+	info   fs.FileInfo
+	offset int64
+	r      *Reader
+	closed bool
+}
+
+func (f *file) Stat() (fs.FileInfo, error) {
+	return f.info, nil
+}
+
+func (f *file) Read(p []byte) (int, error) {
+	if f.closed {
+		return 0, &fs.PathError{Op: "read", Path: f.name, Err: fs.ErrClosed}
+	}
+	if f.offset >= f.info.Size() {
+		return 0, io.EOF
+	}
+	if f.r == nil {
+		r, err := f.c.NewRangeReader(f.name, f.offset, f.info.Size()-f.offset)
+		if err != nil {
+			return 0, &fs.PathError{Op: "read", Path: f.name, Err: err}
+		}
+		f.r = r
+	}
+
+	n, err := f.r.Read(p)
+	f.offset += int64(n)
+	return n, err
+}
+
+func (f *file) Seek(offset int64, whence int) (int64, error) {
+	if f.closed {
+		return 0, &fs.PathError{Op: "seek", Path: f.name, Err: fs.ErrClosed}
+	}
+
+	switch whence {
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		offset += f.info.Size()
+	}
+	if offset < 0 {
+		return 0, &fs.PathError{Op: "seek", Path: f.name, Err: fs.ErrInvalid}
+	}
+
+	if offset != f.offset && f.r != nil {
+		f.r.Close()
+		f.r = nil
+	}
+	f.offset = offset
+	return offset, nil
+}
+
+func (f *file) Close() error {
+	if f.closed {
+		return &fs.PathError{Op: "close", Path: f.name, Err: fs.ErrClosed}
+	}
+	f.closed = true
+	if f.r != nil {
+		return f.r.Close()
+	}
+	return nil
+}
+
+type dir struct {
+	info    fs.FileInfo
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (d *dir) Stat() (fs.FileInfo, error) {
+	return d.info, nil
+}
+
+func (d *dir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.Name(), Err: errors.New("is a directory")}
+}
+
+func (d *dir) ReadDir(n int) ([]fs.DirEntry, error) {
+	rest := d.entries[d.offset:]
+	if n <= 0 {
+		d.offset = len(d.entries)
+		return rest, nil
+	}
+	if len(rest) == 0 {
+		return nil, io.EOF
+	}
+	n = min(n, len(rest))
+	d.offset += n
+	return rest[:n], nil
+}
+
+func (d *dir) Close() error {
+	return nil
+}
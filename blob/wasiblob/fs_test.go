@@ -0,0 +1,89 @@
+//go:build !wasm
+
+package wasiblob
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"testing/fstest"
+)
+
+func TestFS(t *testing.T) {
+	fakeContainers("site", map[string]string{
+		"index.html":         "<h1>home</h1>",
+		"css/site.css":       "body{}",
+		"docs/a/b/deep.txt":  "deep",
+		"docs/readme.md":     "# readme",
+		"docs/marker/":       "",
+		"invalid//name":      "skipped",
+		"/leading-slash.txt": "skipped",
+	})
+	c, err := Open("site")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	fsys := FS(c)
+
+	if err := fstest.TestFS(fsys, "index.html", "css/site.css", "docs/readme.md", "docs/a/b/deep.txt"); err != nil {
+		t.Fatal(err)
+	}
+
+	var walked []string
+	err = fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		walked = append(walked, path)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{".", "css", "css/site.css", "docs", "docs/a", "docs/a/b", "docs/a/b/deep.txt", "docs/marker", "docs/readme.md", "index.html"}
+	if !slices.Equal(walked, want) {
+		t.Errorf("expected: %v, got: %v", want, walked)
+	}
+
+	tests := map[string]struct {
+		name string
+		err  error
+	}{
+		"missing":     {name: "missing.txt", err: fs.ErrNotExist},
+		"missing dir": {name: "css/missing/x", err: fs.ErrNotExist},
+		"invalid":     {name: "../etc/passwd", err: fs.ErrInvalid},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := fsys.Open(tt.name); !errors.Is(err, tt.err) {
+				t.Errorf("expected: %v, got: %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestFSFileServer(t *testing.T) {
+	fakeContainers("static", map[string]string{"file.txt": "0123456789"})
+	c, err := Open("static")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	req := httptest.NewRequest(http.MethodGet, "/file.txt", nil)
+	req.Header.Set("Range", "bytes=2-5")
+	rec := httptest.NewRecorder()
+	http.FileServerFS(FS(c)).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusPartialContent {
+		t.Errorf("expected: %v, got: %v", http.StatusPartialContent, rec.Code)
+	}
+	if body, _ := io.ReadAll(rec.Body); string(body) != "2345" {
+		t.Errorf("expected: %v, got: %v", "2345", string(body))
+	}
+}
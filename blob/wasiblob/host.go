@@ -0,0 +1,354 @@
+package wasiblob
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"go.wasmcloud.dev/component/gen/wasi/blobstore/blobstore"
+	"go.wasmcloud.dev/component/gen/wasi/blobstore/container"
+	"go.wasmcloud.dev/component/gen/wasi/blobstore/types"
+	"go.wasmcloud.dev/component/gen/wasi/io/streams"
+	"go.wasmcloud.dev/component/internal/backend"
+	"go.wasmcloud.dev/component/io/wasipoll"
+)
+
+// blobstoreHost returns the `wasi:blobstore` host.
+func blobstoreHost() backend.Blobstore {
+	return hostBlobstore{}
+}
+
+// openContainer opens the named container on the host.
+func openContainer(name string) (backend.Container, error) {
+	return blobstoreHost().GetContainer(name)
+}
+
+// relayObject copies src to dst on the host of link, deleting src afterwards
+// if move is set.
+func relayObject(link string, src, dst ObjectID, move bool) error {
+	host := linkedHost(link)
+	if move {
+		return host.MoveObject(src.Container, src.Name, dst.Container, dst.Name)
+	}
+	return host.CopyObject(src.Container, src.Name, dst.Container, dst.Name)
+}
+
+type hostBlobstore struct{}
+
+func (hostBlobstore) CreateContainer(name string) (backend.Container, error) {
+	res := blobstore.CreateContainer(types.ContainerName(name))
+	if res.IsErr() {
+		return nil, blobError(res.Err())
+	}
+	return hostContainer{*res.OK()}, nil
+}
+
+func (hostBlobstore) GetContainer(name string) (backend.Container, error) {
+	res := blobstore.GetContainer(types.ContainerName(name))
+	if res.IsErr() {
+		return nil, blobError(res.Err())
+	}
+	return hostContainer{*res.OK()}, nil
+}
+
+func (hostBlobstore) DeleteContainer(name string) error {
+	if res := blobstore.DeleteContainer(types.ContainerName(name)); res.IsErr() {
+		return blobError(res.Err())
+	}
+	return nil
+}
+
+func (hostBlobstore) ContainerExists(name string) (bool, error) {
+	res := blobstore.ContainerExists(types.ContainerName(name))
+	if res.IsErr() {
+		return false, blobError(res.Err())
+	}
+	return *res.OK(), nil
+}
+
+func (hostBlobstore) CopyObject(srcContainer, srcObject, dstContainer, dstObject string) error {
+	src := types.ObjectID{Container: types.ContainerName(srcContainer), Object: types.ObjectName(srcObject)}
+	dst := types.ObjectID{Container: types.ContainerName(dstContainer), Object: types.ObjectName(dstObject)}
+	if res := blobstore.CopyObject(src, dst); res.IsErr() {
+		return blobError(res.Err())
+	}
+	return nil
+}
+
+func (hostBlobstore) MoveObject(srcContainer, srcObject, dstContainer, dstObject string) error {
+	src := types.ObjectID{Container: types.ContainerName(srcContainer), Object: types.ObjectName(srcObject)}
+	dst := types.ObjectID{Container: types.ContainerName(dstContainer), Object: types.ObjectName(dstObject)}
+	if res := blobstore.MoveObject(src, dst); res.IsErr() {
+		return blobError(res.Err())
+	}
+	return nil
+}
+
+// hostContainer is a `wasi:blobstore/container` resource.
+type hostContainer struct {
+	container container.Container
+}
+
+func (c hostContainer) CreatedAt() (uint64, error) {
+	res := c.container.Info()
+	if res.IsErr() {
+		return 0, blobError(res.Err())
+	}
+	return uint64(res.OK().CreatedAt), nil
+}
+
+func (c hostContainer) ObjectInfo(name string) (backend.ObjectInfo, error) {
+	res := c.container.ObjectInfo(types.ObjectName(name))
+	if res.IsErr() {
+		return backend.ObjectInfo{}, blobError(res.Err())
+	}
+	info := res.OK()
+	return backend.ObjectInfo{
+		Name:      string(info.Name),
+		Container: string(info.Container),
+		CreatedAt: uint64(info.CreatedAt),
+		Size:      uint64(info.Size),
+	}, nil
+}
+
+func (c hostContainer) HasObject(name string) (bool, error) {
+	res := c.container.HasObject(types.ObjectName(name))
+	if res.IsErr() {
+		return false, blobError(res.Err())
+	}
+	return *res.OK(), nil
+}
+
+func (c hostContainer) DeleteObject(name string) error {
+	if res := c.container.DeleteObject(types.ObjectName(name)); res.IsErr() {
+		return blobError(res.Err())
+	}
+	return nil
+}
+
+func (c hostContainer) DeleteObjects(names []string) error {
+	objects := make([]types.ObjectName, len(names))
+	for i, name := range names {
+		objects[i] = types.ObjectName(name)
+	}
+	if res := c.container.DeleteObjects(cm.ToList(objects)); res.IsErr() {
+		return blobError(res.Err())
+	}
+	return nil
+}
+
+func (c hostContainer) Clear() error {
+	if res := c.container.Clear(); res.IsErr() {
+		return blobError(res.Err())
+	}
+	return nil
+}
+
+func (c hostContainer) ListObjects() (backend.ObjectNames, error) {
+	res := c.container.ListObjects()
+	if res.IsErr() {
+		return nil, blobError(res.Err())
+	}
+	return hostObjectNames{*res.OK()}, nil
+}
+
+func (c hostContainer) GetData(name string, start, end uint64) (io.ReadCloser, error) {
+	res := c.container.GetData(types.ObjectName(name), start, end)
+	if res.IsErr() {
+		return nil, blobError(res.Err())
+	}
+	body := types.IncomingValueIncomingValueConsumeAsync(*res.OK())
+	if body.IsErr() {
+		return nil, blobError(body.Err())
+	}
+	stream := *body.OK()
+	return &hostReader{stream: stream, pollable: stream.Subscribe()}, nil
+}
+
+// NOTE: the host is handed the value before any data is written, so it
+// consumes the stream while it is written rather than buffering the object.
+func (c hostContainer) WriteData(name string) (io.WriteCloser, error) {
+	value := types.OutgoingValueNewOutgoingValue()
+	body := value.OutgoingValueWriteBody()
+	if body.IsErr() {
+		value.ResourceDrop()
+		return nil, errors.New("body already taken")
+	}
+	stream := *body.OK()
+
+	if res := c.container.WriteData(types.ObjectName(name), value); res.IsErr() {
+		stream.ResourceDrop()
+		value.ResourceDrop()
+		return nil, blobError(res.Err())
+	}
+	return &hostWriter{name: name, value: value, stream: stream, pollable: stream.Subscribe()}, nil
+}
+
+func (c hostContainer) Close() {
+	c.container.ResourceDrop()
+}
+
+// hostObjectNames is a `wasi:blobstore/container.stream-object-names` resource.
+type hostObjectNames struct {
+	stream container.StreamObjectNames
+}
+
+func (s hostObjectNames) Read(n uint64) ([]string, bool, error) {
+	res := s.stream.ReadStreamObjectNames(n)
+	if res.IsErr() {
+		return nil, false, blobError(res.Err())
+	}
+	names := res.OK().F0.Slice()
+	out := make([]string, len(names))
+	for i, name := range names {
+		out[i] = string(name)
+	}
+	return out, res.OK().F1, nil
+}
+
+func (s hostObjectNames) Close() {
+	s.stream.ResourceDrop()
+}
+
+// hostReader reads an incoming value stream, waiting through io/wasipoll for
+// data.
+type hostReader struct {
+	stream   streams.InputStream
+	pollable wasipoll.Pollable
+}
+
+func (r *hostReader) Read(p []byte) (int, error) {
+	for {
+		res := r.stream.Read(uint64(len(p)))
+		if res.IsErr() {
+			if res.Err().Closed() {
+				return 0, io.EOF
+			}
+			return 0, streamError(res.Err())
+		}
+
+		if data := res.OK().Slice(); len(data) > 0 {
+			return copy(p, data), nil
+		}
+		wasipoll.Wait(r.pollable)
+	}
+}
+
+func (r *hostReader) Close() error {
+	r.pollable.ResourceDrop()
+	r.stream.ResourceDrop()
+	return nil
+}
+
+// hostWriter writes an outgoing value stream, waiting through io/wasipoll for
+// the host to accept the data.
+type hostWriter struct {
+	name     string
+	value    types.OutgoingValue
+	stream   streams.OutputStream
+	pollable wasipoll.Pollable
+}
+
+func (w *hostWriter) Write(p []byte) (int, error) {
+	var n int
+	for n < len(p) {
+		budget, err := w.ready()
+		if err != nil {
+			return n, err
+		}
+
+		chunk := p[n:]
+		if uint64(len(chunk)) > budget {
+			chunk = chunk[:budget]
+		}
+		if res := w.stream.Write(cm.ToList(chunk)); res.IsErr() {
+			return n, w.streamError(res.Err())
+		}
+		n += len(chunk)
+	}
+	return n, nil
+}
+
+// Close flushes the written data and finishes the value.
+func (w *hostWriter) Close() error {
+	err := w.flush()
+	w.pollable.ResourceDrop()
+	w.stream.ResourceDrop()
+	if res := types.OutgoingValueFinish(w.value); res.IsErr() {
+		return errors.Join(err, fmt.Errorf("failed to finish %q: %s", w.name, *res.Err()))
+	}
+	return err
+}
+
+// ready waits until the host accepts writes, returning the permitted size.
+func (w *hostWriter) ready() (uint64, error) {
+	for {
+		res := w.stream.CheckWrite()
+		if res.IsErr() {
+			return 0, w.streamError(res.Err())
+		}
+		if budget := *res.OK(); budget > 0 {
+			return budget, nil
+		}
+		wasipoll.Wait(w.pollable)
+	}
+}
+
+func (w *hostWriter) flush() error {
+	if res := w.stream.Flush(); res.IsErr() {
+		return w.streamError(res.Err())
+	}
+	_, err := w.ready()
+	return err
+}
+
+// splice moves the data of r on the host, so it does not pass through the
+// component.
+func (w *hostWriter) splice(r *hostReader) (int64, error) {
+	var n int64
+	for {
+		budget, err := w.ready()
+		if err != nil {
+			return n, err
+		}
+
+		res := w.stream.Splice(r.stream, min(budget, spliceSize))
+		if res.IsErr() {
+			if res.Err().Closed() {
+				// NOTE: splice cannot tell which side closed, an open writer means the reader is done
+				if check := w.stream.CheckWrite(); check.IsErr() {
+					return n, w.streamError(check.Err())
+				}
+				return n, nil
+			}
+			return n, streamError(res.Err())
+		}
+
+		spliced := *res.OK()
+		if spliced == 0 {
+			wasipoll.Wait(r.pollable)
+			continue
+		}
+		n += int64(spliced)
+	}
+}
+
+func (w *hostWriter) streamError(err *streams.StreamError) error {
+	if err.Closed() {
+		return io.ErrClosedPipe
+	}
+	return streamError(err)
+}
+
+func streamError(err *streams.StreamError) error {
+	ioErr := err.LastOperationFailed()
+	defer ioErr.ResourceDrop()
+
+	return fmt.Errorf("stream operation failed: %s", ioErr.ToDebugString())
+}
+
+// blobError converts the error string reported by the host.
+func blobError[T ~string](err *T) error {
+	return errors.New(string(*err))
+}
@@ -0,0 +1,42 @@
+//go:build !wasm
+
+package wasiblob
+
+import (
+	"time"
+
+	"go.wasmcloud.dev/component/internal/fakehost"
+	"go.wasmcloud.dev/component/internal/memory"
+	"go.wasmcloud.dev/component/time/wasiclock"
+)
+
+const (
+	// createdAt is the creation timestamp of every fake container and object.
+	createdAt = 1700000000
+	// chunkSize bounds the bytes per stream read and write, so the streaming
+	// loops are exercised with small objects.
+	chunkSize = 3
+)
+
+// fakeBlobstore is the in-memory `wasi:blobstore` served by the host.
+var fakeBlobstore = memory.NewBlobstore()
+
+// fakeContainers replaces the contents of the named container.
+func fakeContainers(name string, objects map[string]string) {
+	data := make(map[string][]byte, len(objects))
+	for k, v := range objects {
+		data[k] = []byte(v)
+	}
+	fakeBlobstore.Put(name, data)
+}
+
+// openHandles returns the number of live handles.
+func openHandles() int {
+	return len(fakehost.Live(0))
+}
+
+func init() {
+	fakehost.Blobstore = fakeBlobstore
+	fakehost.ChunkSize = chunkSize
+	wasiclock.NowFunc = func() time.Time { return time.Unix(createdAt, 0) }
+}
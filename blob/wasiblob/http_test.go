@@ -0,0 +1,112 @@
+//go:build !wasm
+
+package wasiblob
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestServeObject(t *testing.T) {
+	fakeContainers("downloads", map[string]string{
+		"data.json":   `{"hello":"world"}`,
+		"archive.bin": "0123456789",
+		"notes":       "plain text without an extension",
+	})
+	c, err := Open("downloads")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	etag := ETag(ObjectInfo{CreatedAt: timestamp(createdAt), Size: 10})
+
+	tests := map[string]struct {
+		method      string
+		name        string
+		header      http.Header
+		status      int
+		body        string
+		contentType string
+	}{
+		"full": {
+			name:        "data.json",
+			status:      http.StatusOK,
+			body:        `{"hello":"world"}`,
+			contentType: "application/json",
+		},
+		"sniffed": {
+			name:        "notes",
+			status:      http.StatusOK,
+			body:        "plain text without an extension",
+			contentType: "text/plain; charset=utf-8",
+		},
+		"range": {
+			name:   "archive.bin",
+			header: http.Header{"Range": {"bytes=3-6"}},
+			status: http.StatusPartialContent,
+			body:   "3456",
+		},
+		"suffix range": {
+			name:   "archive.bin",
+			header: http.Header{"Range": {"bytes=-2"}},
+			status: http.StatusPartialContent,
+			body:   "89",
+		},
+		"unsatisfiable": {
+			name:   "archive.bin",
+			header: http.Header{"Range": {"bytes=20-"}},
+			status: http.StatusRequestedRangeNotSatisfiable,
+		},
+		"not modified": {
+			name:   "archive.bin",
+			header: http.Header{"If-None-Match": {etag}},
+			status: http.StatusNotModified,
+		},
+		"stale etag": {
+			name:   "archive.bin",
+			header: http.Header{"If-None-Match": {`"other"`}},
+			status: http.StatusOK,
+			body:   "0123456789",
+		},
+		"head": {
+			method: http.MethodHead,
+			name:   "archive.bin",
+			status: http.StatusOK,
+		},
+		"missing": {
+			name:   "missing",
+			status: http.StatusNotFound,
+			body:   "404 page not found\n",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			req := httptest.NewRequest(method, "/"+tt.name, nil)
+			for k, v := range tt.header {
+				req.Header[k] = v
+			}
+			rec := httptest.NewRecorder()
+			ServeObject(rec, req, c, tt.name)
+
+			if rec.Code != tt.status {
+				t.Errorf("expected: %v, got: %v", tt.status, rec.Code)
+			}
+			if tt.body != "" && rec.Body.String() != tt.body {
+				t.Errorf("expected: %q, got: %q", tt.body, rec.Body.String())
+			}
+			if tt.contentType != "" && rec.Header().Get("Content-Type") != tt.contentType {
+				t.Errorf("expected: %v, got: %v", tt.contentType, rec.Header().Get("Content-Type"))
+			}
+			if tt.name == "archive.bin" && rec.Header().Get("Etag") != etag {
+				t.Errorf("expected: %v, got: %v", etag, rec.Header().Get("Etag"))
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package wasiblob
+
+import (
+	"io"
+
+	"go.wasmcloud.dev/component/internal/backend"
+	"go.wasmcloud.dev/component/wasmcloud"
+)
+
+// interfaces are the interfaces called by wasiblob, routed by link name.
+var interfaces = []string{"wasi:blobstore/blobstore", "wasi:blobstore/container", "wasi:blobstore/types"}
+
+// linkedHost returns the blobstore host, called with the link name link.
+func linkedHost(link string) backend.Blobstore {
+	wasmcloud.UseLinkName(link, interfaces...)
+	return blobstoreHost()
+}
+
+// linkedContainer calls the container with the link name it was opened with.
+//
+// NOTE: the streams of GetData and WriteData are `wasi:io` streams, they are
+// not routed by link name once the call returned
+type linkedContainer struct {
+	link      string
+	container backend.Container
+}
+
+func (c linkedContainer) CreatedAt() (uint64, error) {
+	wasmcloud.UseLinkName(c.link, interfaces...)
+	return c.container.CreatedAt()
+}
+
+func (c linkedContainer) ObjectInfo(name string) (backend.ObjectInfo, error) {
+	wasmcloud.UseLinkName(c.link, interfaces...)
+	return c.container.ObjectInfo(name)
+}
+
+func (c linkedContainer) HasObject(name string) (bool, error) {
+	wasmcloud.UseLinkName(c.link, interfaces...)
+	return c.container.HasObject(name)
+}
+
+func (c linkedContainer) DeleteObject(name string) error {
+	wasmcloud.UseLinkName(c.link, interfaces...)
+	return c.container.DeleteObject(name)
+}
+
+func (c linkedContainer) DeleteObjects(names []string) error {
+	wasmcloud.UseLinkName(c.link, interfaces...)
+	return c.container.DeleteObjects(names)
+}
+
+func (c linkedContainer) Clear() error {
+	wasmcloud.UseLinkName(c.link, interfaces...)
+	return c.container.Clear()
+}
+
+func (c linkedContainer) ListObjects() (backend.ObjectNames, error) {
+	wasmcloud.UseLinkName(c.link, interfaces...)
+	names, err := c.container.ListObjects()
+	if err != nil {
+		return nil, err
+	}
+	return linkedObjectNames{link: c.link, names: names}, nil
+}
+
+func (c linkedContainer) GetData(name string, start, end uint64) (io.ReadCloser, error) {
+	wasmcloud.UseLinkName(c.link, interfaces...)
+	return c.container.GetData(name, start, end)
+}
+
+func (c linkedContainer) WriteData(name string) (io.WriteCloser, error) {
+	wasmcloud.UseLinkName(c.link, interfaces...)
+	return c.container.WriteData(name)
+}
+
+func (c linkedContainer) Close() {
+	c.container.Close()
+}
+
+// linkedObjectNames reads the object names with the link name of their
+// container.
+type linkedObjectNames struct {
+	link  string
+	names backend.ObjectNames
+}
+
+func (n linkedObjectNames) Read(count uint64) ([]string, bool, error) {
+	wasmcloud.UseLinkName(n.link, interfaces...)
+	return n.names.Read(count)
+}
+
+func (n linkedObjectNames) Close() {
+	n.names.Close()
+}
@@ -0,0 +1,108 @@
+package wasiblob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/url"
+)
+
+// maxFormValueBytes bounds the non-file parts kept in memory by
+// [UploadMultipart], as http.Request.ParseMultipartForm does.
+const maxFormValueBytes = 10 << 20
+
+// ErrFormTooLarge is returned by [UploadMultipart] when the form values exceed
+// 10 MiB.
+var ErrFormTooLarge = errors.New("wasiblob: multipart form values too large")
+
+// UploadedFile is a file part written by [UploadMultipart].
+type UploadedFile struct {
+	// Field is the form field name of the part.
+	Field string
+	// Filename is the file name sent by the client, it must not be trusted
+	// as an object name.
+	Filename    string
+	ContentType string
+	// Object is the name of the object the part was written to.
+	Object string
+	Size   int64
+}
+
+// MultipartForm is the outcome of [UploadMultipart].
+type MultipartForm struct {
+	// Value holds the values of the parts without a file name.
+	Value url.Values
+	// Files are the uploaded file parts, in order.
+	Files []UploadedFile
+}
+
+// UploadMultipart streams the file parts of the multipart body read by mr
+// into objects of c with an [Uploader] each, without buffering whole parts.
+// name returns the object name of a part, or "" to skip it, e.g.
+//
+//	mr, err := r.MultipartReader()
+//	// ...
+//	form, err := wasiblob.UploadMultipart(r.Context(), c, mr, func(p *multipart.Part) string {
+//		return "uploads/" + wasirand.Token(16)
+//	}, opts)
+//
+// opts apply to every file, MaxSize bounds the size of each. If an upload
+// fails, the objects written by the call are deleted.
+func UploadMultipart(ctx context.Context, c *Container, mr *multipart.Reader, name func(p *multipart.Part) string, opts UploadOptions) (*MultipartForm, error) {
+	form := &MultipartForm{Value: url.Values{}}
+	remaining := int64(maxFormValueBytes)
+	for {
+		p, err := mr.NextPart()
+		if err == io.EOF {
+			return form, nil
+		}
+		if err != nil {
+			return nil, form.abort(c, fmt.Errorf("failed to read multipart body: %w", err))
+		}
+
+		if p.FileName() == "" {
+			value, err := io.ReadAll(io.LimitReader(p, remaining+1))
+			if err != nil {
+				return nil, form.abort(c, fmt.Errorf("failed to read multipart body: %w", err))
+			}
+			if remaining -= int64(len(value)); remaining < 0 {
+				return nil, form.abort(c, ErrFormTooLarge)
+			}
+			form.Value.Add(p.FormName(), string(value))
+			continue
+		}
+
+		object := name(p)
+		if object == "" {
+			continue
+		}
+		n, err := NewUploader(c, object, opts).Upload(ctx, p)
+		if err != nil {
+			return nil, form.abort(c, err)
+		}
+		form.Files = append(form.Files, UploadedFile{
+			Field:       p.FormName(),
+			Filename:    p.FileName(),
+			ContentType: p.Header.Get("Content-Type"),
+			Object:      object,
+			Size:        n,
+		})
+	}
+}
+
+// abort deletes the uploaded files and returns err.
+func (f *MultipartForm) abort(c *Container, err error) error {
+	if len(f.Files) == 0 {
+		return err
+	}
+	names := make([]string, len(f.Files))
+	for i, file := range f.Files {
+		names[i] = file.Object
+	}
+	if deleteErr := c.DeleteObjects(names...); deleteErr != nil {
+		err = errors.Join(err, deleteErr)
+	}
+	return err
+}
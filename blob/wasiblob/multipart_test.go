@@ -0,0 +1,89 @@
+//go:build !wasm
+
+package wasiblob
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"mime/multipart"
+	"slices"
+	"testing"
+)
+
+func multipartBody(t *testing.T, files map[string]string, values ...string) *multipart.Reader {
+	t.Helper()
+	var b bytes.Buffer
+	w := multipart.NewWriter(&b)
+	for i := 0; i+1 < len(values); i += 2 {
+		w.WriteField(values[i], values[i+1])
+	}
+	for _, name := range []string{"a.txt", "b.txt", "skip.txt"} {
+		if data, ok := files[name]; ok {
+			part, err := w.CreateFormFile("file", name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			part.Write([]byte(data))
+		}
+	}
+	w.Close()
+	return multipart.NewReader(&b, w.Boundary())
+}
+
+func uploadName(p *multipart.Part) string {
+	if p.FileName() == "skip.txt" {
+		return ""
+	}
+	return "uploads/" + p.FileName()
+}
+
+func TestUploadMultipart(t *testing.T) {
+	fakeContainers("multipart", nil)
+	c, err := Open("multipart")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	mr := multipartBody(t, map[string]string{"a.txt": "alpha", "b.txt": "bravo!", "skip.txt": "skipped"}, "title", "holiday")
+	form, err := UploadMultipart(context.Background(), c, mr, uploadName, UploadOptions{ChunkSize: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := form.Value.Get("title"); got != "holiday" {
+		t.Errorf("expected: %v, got: %v", "holiday", got)
+	}
+	want := []UploadedFile{
+		{Field: "file", Filename: "a.txt", ContentType: "application/octet-stream", Object: "uploads/a.txt", Size: 5},
+		{Field: "file", Filename: "b.txt", ContentType: "application/octet-stream", Object: "uploads/b.txt", Size: 6},
+	}
+	if !slices.Equal(form.Files, want) {
+		t.Errorf("expected: %v, got: %v", want, form.Files)
+	}
+	if data, err := c.Get("uploads/b.txt"); err != nil || string(data) != "bravo!" {
+		t.Errorf("expected: %q, got: %q (%v)", "bravo!", data, err)
+	}
+	if names, _ := c.List(); len(names) != 2 {
+		t.Errorf("expected: 2 objects, got: %v", names)
+	}
+}
+
+func TestUploadMultipartAborts(t *testing.T) {
+	fakeContainers("multipart-abort", nil)
+	c, err := Open("multipart-abort")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	mr := multipartBody(t, map[string]string{"a.txt": "alpha", "b.txt": "too large"})
+	_, err = UploadMultipart(context.Background(), c, mr, uploadName, UploadOptions{MaxSize: 5})
+	if !errors.Is(err, ErrTooLarge) {
+		t.Errorf("expected: %v, got: %v", ErrTooLarge, err)
+	}
+	if names, _ := c.List(); len(names) != 0 {
+		t.Errorf("expected the uploaded files to be deleted, got: %v", names)
+	}
+}
@@ -0,0 +1,47 @@
+package wasiblob
+
+import (
+	"context"
+	"fmt"
+
+	"go.wasmcloud.dev/component/wasmcloud"
+)
+
+// ObjectID identifies an object across containers.
+type ObjectID struct {
+	Container string
+	Name      string
+}
+
+func (id ObjectID) String() string {
+	return id.Container + "/" + id.Name
+}
+
+// CopyObject copies src to dst, overwriting dst if it exists.
+// The copy is performed by the host, the data does not pass through the component.
+func CopyObject(src, dst ObjectID) error {
+	return CopyObjectContext(context.Background(), src, dst)
+}
+
+// CopyObjectContext copies src to dst within the link selected by
+// wasmcloud.WithLinkName, see [OpenContext].
+func CopyObjectContext(ctx context.Context, src, dst ObjectID) error {
+	if err := relayObject(wasmcloud.LinkName(ctx), src, dst, false); err != nil {
+		return fmt.Errorf("failed to copy %s to %s: %w", src, dst, err)
+	}
+	return nil
+}
+
+// MoveObject moves src to dst, overwriting dst if it exists.
+func MoveObject(src, dst ObjectID) error {
+	return MoveObjectContext(context.Background(), src, dst)
+}
+
+// MoveObjectContext moves src to dst within the link selected by
+// wasmcloud.WithLinkName, see [OpenContext].
+func MoveObjectContext(ctx context.Context, src, dst ObjectID) error {
+	if err := relayObject(wasmcloud.LinkName(ctx), src, dst, true); err != nil {
+		return fmt.Errorf("failed to move %s to %s: %w", src, dst, err)
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package wasiblob
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// spliceSize is the maximum number of bytes moved per splice call.
+const spliceSize = 1 << 20
+
+// ErrClosed is returned when using a closed [Reader] or [Writer].
+var ErrClosed = errors.New("wasiblob: closed")
+
+// Reader streams the contents of an object from the host.
+type Reader struct {
+	mu     sync.Mutex
+	r      io.ReadCloser
+	closed bool
+}
+
+// NewReader returns a Reader over the contents of the named object.
+func (c *Container) NewReader(name string) (*Reader, error) {
+	return c.NewRangeReader(name, 0, -1)
+}
+
+// NewRangeReader returns a Reader over length bytes of the named object,
+// starting at offset. A negative length reads until the end of the object.
+func (c *Container) NewRangeReader(name string, offset, length int64) (*Reader, error) {
+	if offset < 0 {
+		return nil, fmt.Errorf("wasiblob: negative offset %d", offset)
+	}
+	if length < 0 {
+		// NOTE: get-data takes an inclusive end offset, the object size bounds it
+		info, err := c.Stat(name)
+		if err != nil {
+			return nil, err
+		}
+		length = max(info.Size-offset, 0)
+	}
+	if length == 0 {
+		return &Reader{closed: true}, nil
+	}
+
+	r, err := c.container.GetData(name, uint64(offset), uint64(offset+length-1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %q: %w", name, err)
+	}
+	return &Reader{r: r}, nil
+}
+
+// Read reads from the object, waiting through io/wasipoll for data.
+func (r *Reader) Read(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return 0, io.EOF
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	return r.r.Read(p)
+}
+
+// WriteTo implements io.WriterTo, splicing the data on the host when w is
+// a [Writer] so it does not pass through the component.
+func (r *Reader) WriteTo(w io.Writer) (int64, error) {
+	if bw, ok := w.(*Writer); ok {
+		return bw.splice(r)
+	}
+	return io.Copy(w, struct{ io.Reader }{r})
+}
+
+// Close releases the host stream.
+func (r *Reader) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+	return r.r.Close()
+}
+
+// Writer streams the contents of an object to the host. The object is only
+// complete once Close returned without error.
+type Writer struct {
+	mu     sync.Mutex
+	w      io.WriteCloser
+	closed bool
+}
+
+// NewWriter returns a Writer creating or replacing the named object.
+func (c *Container) NewWriter(name string) (*Writer, error) {
+	w, err := c.container.WriteData(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write %q: %w", name, err)
+	}
+	return &Writer{w: w}, nil
+}
+
+// Write writes p to the object, waiting through io/wasipoll for the host to
+// accept it.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return 0, ErrClosed
+	}
+	return w.w.Write(p)
+}
+
+// ReadFrom implements io.ReaderFrom, splicing the data on the host when r is
+// a [Reader].
+func (w *Writer) ReadFrom(r io.Reader) (int64, error) {
+	if br, ok := r.(*Reader); ok {
+		return w.splice(br)
+	}
+	return io.Copy(struct{ io.Writer }{w}, r)
+}
+
+// Close flushes the written data and finishes the object.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	return w.w.Close()
+}
+
+func (w *Writer) splice(r *Reader) (int64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if w.closed {
+		return 0, ErrClosed
+	}
+	if r.closed {
+		return 0, nil
+	}
+
+	hw, wok := w.w.(*hostWriter)
+	hr, rok := r.r.(*hostReader)
+	if !wok || !rok {
+		return io.Copy(struct{ io.Writer }{w.w}, struct{ io.Reader }{r.r})
+	}
+	return hw.splice(hr)
+}
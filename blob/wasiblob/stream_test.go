@@ -0,0 +1,151 @@
+//go:build !wasm
+
+package wasiblob
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"go.wasmcloud.dev/component/internal/fakehost"
+)
+
+func TestRangeReader(t *testing.T) {
+	fakeContainers("ranges", map[string]string{"alphabet": "abcdefghijklmnopqrstuvwxyz"})
+	c, err := Open("ranges")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	tests := map[string]struct {
+		offset, length int64
+		want           string
+		err            bool
+	}{
+		"full":        {offset: 0, length: -1, want: "abcdefghijklmnopqrstuvwxyz"},
+		"suffix":      {offset: 20, length: -1, want: "uvwxyz"},
+		"range":       {offset: 3, length: 5, want: "defgh"},
+		"empty":       {offset: 3, length: 0, want: ""},
+		"past end":    {offset: 30, length: -1, want: ""},
+		"negative":    {offset: -1, length: 1, err: true},
+		"past length": {offset: 30, length: 2, err: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			handles := openHandles()
+
+			r, err := c.NewRangeReader("alphabet", tt.offset, tt.length)
+			if tt.err {
+				if err == nil {
+					t.Error("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			data, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("expected: %v, got: %v", tt.want, string(data))
+			}
+
+			if err := r.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if got := openHandles(); got != handles {
+				t.Errorf("expected %v open handles, got: %v", handles, got)
+			}
+		})
+	}
+}
+
+func TestWriter(t *testing.T) {
+	fakeContainers("uploads", map[string]string{})
+	c, err := Open("uploads")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	handles := openHandles()
+
+	w, err := c.NewWriter("big")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := bytes.Repeat([]byte("0123456789"), 100)
+	if _, err := io.Copy(w, bytes.NewReader(want)); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := c.Has("big"); ok {
+		t.Error("expected object to be incomplete before Close")
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("more")); err != ErrClosed {
+		t.Errorf("expected: %v, got: %v", ErrClosed, err)
+	}
+	if got := openHandles(); got != handles {
+		t.Errorf("expected %v open handles, got: %v", handles, got)
+	}
+
+	got, err := c.Get("big")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("expected %v bytes, got: %v", len(want), len(got))
+	}
+}
+
+func TestSplice(t *testing.T) {
+	fakeContainers("from", map[string]string{"object": "spliced through the host"})
+	fakeContainers("to", map[string]string{})
+
+	from, err := Open("from")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer from.Close()
+	to, err := Open("to")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer to.Close()
+
+	r, err := from.NewReader("object")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	w, err := to.NewWriter("object")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	splices := fakehost.Splices
+	n, err := io.Copy(w, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(len("spliced through the host")) {
+		t.Errorf("expected: %v, got: %v", len("spliced through the host"), n)
+	}
+	if fakehost.Splices == splices {
+		t.Error("expected the copy to splice")
+	}
+
+	if data, err := to.Get("object"); err != nil || string(data) != "spliced through the host" {
+		t.Errorf("expected: %v, got: %v (%v)", "spliced through the host", string(data), err)
+	}
+}
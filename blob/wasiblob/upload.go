@@ -0,0 +1,109 @@
+package wasiblob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"go.wasmcloud.dev/component/rand/wasirand"
+)
+
+// DefaultUploadChunkSize is the chunk size of an [Uploader] when none is set.
+const DefaultUploadChunkSize = 64 << 10
+
+// ErrTooLarge is returned by [Uploader.Upload] when the data exceeds
+// [UploadOptions.MaxSize].
+var ErrTooLarge = errors.New("wasiblob: upload exceeds maximum size")
+
+// UploadOptions configures an [Uploader].
+type UploadOptions struct {
+	// ChunkSize is the number of bytes buffered before handing them to the
+	// host, bounding the memory used by the upload. Defaults to [DefaultUploadChunkSize].
+	ChunkSize int
+	// MaxSize aborts the upload with [ErrTooLarge] once more bytes are read,
+	// zero means unlimited.
+	MaxSize int64
+	// Progress, if set, is called with the total number of bytes written after
+	// every chunk.
+	Progress func(written int64)
+}
+
+// Uploader streams arbitrarily large data into an object.
+//
+// The data is written to a staging object next to the destination, which is
+// moved into place once complete. A failed or canceled upload deletes the
+// staging object and leaves any existing object at the destination untouched.
+type Uploader struct {
+	c    *Container
+	name string
+	opts UploadOptions
+}
+
+// NewUploader returns an Uploader writing the object name in c.
+func NewUploader(c *Container, name string, opts UploadOptions) *Uploader {
+	if opts.ChunkSize <= 0 {
+		opts.ChunkSize = DefaultUploadChunkSize
+	}
+	return &Uploader{c: c, name: name, opts: opts}
+}
+
+// Upload copies r into the object, returning the number of bytes written.
+// It is typically fed an incoming request body:
+//
+//	n, err := wasiblob.NewUploader(c, "videos/"+id, opts).Upload(r.Context(), r.Body)
+func (u *Uploader) Upload(ctx context.Context, r io.Reader) (int64, error) {
+	staging := u.name + ".upload-" + wasirand.Token(8)
+
+	written, err := u.write(ctx, staging, r)
+	if err == nil {
+		err = relayObject(u.c.link,
+			ObjectID{Container: u.c.Name(), Name: staging},
+			ObjectID{Container: u.c.Name(), Name: u.name},
+			true,
+		)
+	}
+	if err != nil {
+		if deleteErr := u.c.Delete(staging); deleteErr != nil {
+			err = errors.Join(err, deleteErr)
+		}
+		return written, fmt.Errorf("failed to upload %q: %w", u.name, err)
+	}
+	return written, nil
+}
+
+func (u *Uploader) write(ctx context.Context, name string, r io.Reader) (int64, error) {
+	w, err := u.c.NewWriter(name)
+	if err != nil {
+		return 0, err
+	}
+
+	var written int64
+	buf := make([]byte, u.opts.ChunkSize)
+	for {
+		if err := ctx.Err(); err != nil {
+			return written, errors.Join(err, w.Close())
+		}
+
+		n, readErr := io.ReadFull(r, buf)
+		if u.opts.MaxSize > 0 && written+int64(n) > u.opts.MaxSize {
+			return written, errors.Join(ErrTooLarge, w.Close())
+		}
+		if n > 0 {
+			if _, err := w.Write(buf[:n]); err != nil {
+				return written, errors.Join(err, w.Close())
+			}
+			written += int64(n)
+			if u.opts.Progress != nil {
+				u.opts.Progress(written)
+			}
+		}
+
+		switch {
+		case readErr == io.EOF || readErr == io.ErrUnexpectedEOF:
+			return written, w.Close()
+		case readErr != nil:
+			return written, errors.Join(readErr, w.Close())
+		}
+	}
+}
@@ -0,0 +1,101 @@
+//go:build !wasm
+
+package wasiblob
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"testing"
+)
+
+type failingReader struct{ err error }
+
+func (r failingReader) Read([]byte) (int, error) { return 0, r.err }
+
+func TestUploader(t *testing.T) {
+	errRead := errors.New("connection reset")
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := map[string]struct {
+		ctx      context.Context
+		body     io.Reader
+		opts     UploadOptions
+		want     string
+		progress []int64
+		err      error
+	}{
+		"chunked": {
+			body:     bytes.NewReader([]byte("0123456789")),
+			opts:     UploadOptions{ChunkSize: 4},
+			want:     "0123456789",
+			progress: []int64{4, 8, 10},
+		},
+		"exact chunks": {
+			body:     bytes.NewReader([]byte("01234567")),
+			opts:     UploadOptions{ChunkSize: 4},
+			want:     "01234567",
+			progress: []int64{4, 8},
+		},
+		"empty": {
+			body: bytes.NewReader(nil),
+			want: "",
+		},
+		"max size": {
+			body:     bytes.NewReader([]byte("0123456789")),
+			opts:     UploadOptions{ChunkSize: 4, MaxSize: 6},
+			want:     "previous",
+			progress: []int64{4},
+			err:      ErrTooLarge,
+		},
+		"read error": {
+			body:     io.MultiReader(bytes.NewReader([]byte("0123")), failingReader{errRead}),
+			opts:     UploadOptions{ChunkSize: 2},
+			want:     "previous",
+			progress: []int64{2, 4},
+			err:      errRead,
+		},
+		"canceled": {
+			ctx:  canceled,
+			body: bytes.NewReader([]byte("0123")),
+			want: "previous",
+			err:  context.Canceled,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			fakeContainers(name, map[string]string{"object": "previous"})
+			c, err := Open(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer c.Close()
+
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+
+			var progress []int64
+			tt.opts.Progress = func(written int64) { progress = append(progress, written) }
+			_, err = NewUploader(c, "object", tt.opts).Upload(ctx, tt.body)
+			if !errors.Is(err, tt.err) {
+				t.Errorf("expected: %v, got: %v", tt.err, err)
+			}
+			if !slices.Equal(progress, tt.progress) {
+				t.Errorf("expected progress: %v, got: %v", tt.progress, progress)
+			}
+
+			if data, err := c.Get("object"); err != nil || string(data) != tt.want {
+				t.Errorf("expected: %q, got: %q (%v)", tt.want, string(data), err)
+			}
+			if names, _ := c.List(); !slices.Equal(names, []string{"object"}) {
+				t.Errorf("expected staging object to be removed, got: %v", names)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+//go:build !wasm
+
+package wasiblobtest
+
+import (
+	"time"
+
+	"go.wasmcloud.dev/component/blob/wasiblob"
+	"go.wasmcloud.dev/component/internal/fakehost"
+	"go.wasmcloud.dev/component/internal/memory"
+	"go.wasmcloud.dev/component/time/wasiclock"
+)
+
+// MemoryContainer is a wasiblob.Container kept in memory, independent of the
+// host and of a componenttest.Host. Timestamps are read from
+// wasiclock.NowFunc.
+type MemoryContainer struct {
+	*wasiblob.Container
+	mem *memory.Container
+}
+
+// NewMemoryContainer returns an empty MemoryContainer.
+//
+// wasiblob.CopyObject and wasiblob.MoveObject accept its name, the data of
+// the object passes through the component.
+func NewMemoryContainer() *MemoryContainer {
+	name := fakehost.Containers.Name("wasiblobtest-")
+	mem := memory.NewContainer(name, nil)
+	fakehost.Containers.Set(name, mem)
+
+	c, err := wasiblob.Open(name)
+	if err != nil {
+		panic(err)
+	}
+	return &MemoryContainer{Container: c, mem: mem}
+}
+
+// Close releases the container, its name is no longer known to wasiblob.
+func (c *MemoryContainer) Close() error {
+	fakehost.Containers.Remove(c.Name())
+	return c.Container.Close()
+}
+
+// PutWithTTL creates or replaces the named object, see [MemoryContainer.Expire].
+func (c *MemoryContainer) PutWithTTL(name string, data []byte, ttl time.Duration) error {
+	if err := c.Put(name, data); err != nil {
+		return err
+	}
+	c.Expire(name, ttl)
+	return nil
+}
+
+// Expire deletes the named object once ttl passed, as a lifecycle rule of a
+// host bucket would. Time is read from wasiclock.NowFunc, replace it to expire
+// objects deterministically. Writing the object clears its expiry.
+func (c *MemoryContainer) Expire(name string, ttl time.Duration) {
+	c.mem.Expire(name, wasiclock.NowFunc().Add(ttl))
+}
+
+// Objects returns a copy of the objects of the container.
+func (c *MemoryContainer) Objects() map[string][]byte {
+	return c.mem.Snapshot()
+}
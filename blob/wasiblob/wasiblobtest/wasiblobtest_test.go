@@ -0,0 +1,73 @@
+//go:build !wasm
+
+package wasiblobtest
+
+import (
+	"io/fs"
+	"slices"
+	"testing"
+	"time"
+
+	"go.wasmcloud.dev/component/blob/wasiblob"
+	"go.wasmcloud.dev/component/time/wasiclock"
+)
+
+func TestMemoryContainer(t *testing.T) {
+	c := NewMemoryContainer()
+	defer c.Close()
+
+	if err := c.Put("docs/a.txt", []byte("hello")); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := c.Get("docs/a.txt"); err != nil || string(data) != "hello" {
+		t.Errorf("expected: %v, got: %q %v", "hello", data, err)
+	}
+	if info, err := c.Stat("docs/a.txt"); err != nil || info.Size != 5 || info.Container != c.Name() {
+		t.Errorf("unexpected info: %+v %v", info, err)
+	}
+	if data, err := fs.ReadFile(wasiblob.FS(c.Container), "docs/a.txt"); err != nil || string(data) != "hello" {
+		t.Errorf("expected: %v, got: %q %v", "hello", data, err)
+	}
+
+	dst := NewMemoryContainer()
+	defer dst.Close()
+	if err := wasiblob.MoveObject(wasiblob.ObjectID{Container: c.Name(), Name: "docs/a.txt"}, wasiblob.ObjectID{Container: dst.Name(), Name: "b.txt"}); err != nil {
+		t.Fatal(err)
+	}
+	if names, err := c.List(); err != nil || len(names) != 0 {
+		t.Errorf("expected the source to be moved, got: %v %v", names, err)
+	}
+	if got := string(dst.Objects()["b.txt"]); got != "hello" {
+		t.Errorf("expected: %v, got: %v", "hello", got)
+	}
+}
+
+func TestMemoryContainerTTL(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	defer func(f func() time.Time) { wasiclock.NowFunc = f }(wasiclock.NowFunc)
+	wasiclock.NowFunc = func() time.Time { return now }
+
+	c := NewMemoryContainer()
+	defer c.Close()
+	if err := c.PutWithTTL("tmp", []byte("x"), time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Put("kept", []byte("y")); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := c.Stat("tmp"); err != nil || !info.CreatedAt.Equal(now) {
+		t.Errorf("expected: %v, got: %v %v", now, info.CreatedAt, err)
+	}
+
+	now = now.Add(time.Hour)
+	names, err := c.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"kept"}; !slices.Equal(names, want) {
+		t.Errorf("expected: %v, got: %v", want, names)
+	}
+	if ok, _ := c.Has("tmp"); ok {
+		t.Error("expected the object to expire")
+	}
+}
@@ -0,0 +1,163 @@
+package cbor
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ContentType is the media type of CBOR payloads, e.g. of HTTP bodies and
+// messages.
+const ContentType = "application/cbor"
+
+// Marshaler is a type encoding itself as CBOR, writing its fields with the
+// methods of the Encoder, e.g. as a map keyed by field names.
+//
+// NOTE: the types of the values are implemented in code instead of found with
+// reflection, which is slow and partial under TinyGo
+type Marshaler interface {
+	MarshalCBOR(e *Encoder) error
+}
+
+// Unmarshaler is a type decoding itself from CBOR, reading the data items
+// written by its MarshalCBOR method.
+type Unmarshaler interface {
+	UnmarshalCBOR(d *Decoder) error
+}
+
+// Marshal returns the CBOR encoding of v, a [Marshaler] or any value accepted
+// by [Encoder.Value].
+func Marshal(v any) ([]byte, error) {
+	e := &Encoder{}
+	if err := e.Value(v); err != nil {
+		return nil, err
+	}
+	return e.Bytes(), nil
+}
+
+// Unmarshal decodes the single CBOR data item of data into v, an [Unmarshaler]
+// or a pointer to an any, a bool, an int, int64, uint64, float64, string,
+// []byte, []string, map[string]string or map[string]any.
+func Unmarshal(data []byte, v any) error {
+	d := NewDecoder(data)
+	if err := d.Decode(v); err != nil {
+		return err
+	}
+	if d.More() {
+		return errors.New("cbor: data after the top-level data item")
+	}
+	return nil
+}
+
+// Decode reads the next data item into v, like [Unmarshal].
+func (d *Decoder) Decode(v any) (err error) {
+	switch v := v.(type) {
+	case Unmarshaler:
+		return v.UnmarshalCBOR(d)
+	case *any:
+		*v, err = d.Value()
+	case *bool:
+		*v, err = d.Bool()
+	case *int:
+		var n int64
+		n, err = d.Int()
+		*v = int(n)
+	case *int64:
+		*v, err = d.Int()
+	case *uint64:
+		*v, err = d.Uint()
+	case *float64:
+		*v, err = d.Float()
+	case *string:
+		*v, err = d.String()
+	case *[]byte:
+		var b []byte
+		b, err = d.ByteString()
+		*v = bytes.Clone(b)
+	case *[]string:
+		var n int
+		if n, err = d.Array(); err != nil {
+			return err
+		}
+		*v = make([]string, n)
+		for i := range *v {
+			if (*v)[i], err = d.String(); err != nil {
+				return err
+			}
+		}
+	case *map[string]string:
+		var n int
+		if n, err = d.Map(); err != nil {
+			return err
+		}
+		*v = make(map[string]string, n)
+		for range n {
+			var k string
+			if k, err = d.String(); err != nil {
+				return err
+			}
+			if (*v)[k], err = d.String(); err != nil {
+				return err
+			}
+		}
+	case *map[string]any:
+		var n int
+		if n, err = d.Map(); err != nil {
+			return err
+		}
+		*v = make(map[string]any, n)
+		for range n {
+			var k string
+			if k, err = d.String(); err != nil {
+				return err
+			}
+			if (*v)[k], err = d.Value(); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("cbor: unsupported type %T", v)
+	}
+	return err
+}
+
+// EncodeSlice writes s as an array of its elements.
+func EncodeSlice[T Marshaler](e *Encoder, s []T) error {
+	e.Array(len(s))
+	for _, v := range s {
+		if err := v.MarshalCBOR(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecodeSlice reads an array of elements decoded by their UnmarshalCBOR
+// method.
+func DecodeSlice[T any, P interface {
+	*T
+	Unmarshaler
+}](d *Decoder) ([]T, error) {
+	n, err := d.Array()
+	if err != nil {
+		return nil, err
+	}
+	s := make([]T, n)
+	for i := range s {
+		if err := P(&s[i]).UnmarshalCBOR(d); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// sortedKeys returns the keys of m in order, for a deterministic encoding.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
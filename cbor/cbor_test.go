@@ -0,0 +1,224 @@
+package cbor
+
+import (
+	"encoding/hex"
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestAppendixA checks the examples of RFC 8949 Appendix A that this encoder
+// produces, and decodes them back.
+func TestAppendixA(t *testing.T) {
+	tests := []struct {
+		value any
+		hex   string
+	}{
+		{uint64(0), "00"},
+		{uint64(23), "17"},
+		{uint64(24), "1818"},
+		{uint64(1000), "1903e8"},
+		{uint64(1000000), "1a000f4240"},
+		{uint64(1000000000000), "1b000000e8d4a51000"},
+		{uint64(18446744073709551615), "1bffffffffffffffff"},
+		{int64(-1), "20"},
+		{int64(-1000), "3903e7"},
+		{int64(math.MinInt64), "3b7fffffffffffffff"},
+		{float64(100000.0), "fa47c35000"},
+		{float64(1.1), "fb3ff199999999999a"},
+		{float64(-4.1), "fbc010666666666666"},
+		{math.Inf(1), "fa7f800000"},
+		{false, "f4"},
+		{true, "f5"},
+		{nil, "f6"},
+		{[]byte{}, "40"},
+		{[]byte{1, 2, 3, 4}, "4401020304"},
+		{"", "60"},
+		{"a", "6161"},
+		{"ü", "62c3bc"},
+		{"水", "63e6b0b4"},
+		{[]any{}, "80"},
+		{[]any{uint64(1), []any{uint64(2), uint64(3)}, []any{uint64(4), uint64(5)}}, "8301820203820405"},
+		{map[string]any{}, "a0"},
+		{map[string]any{"a": uint64(1), "b": []any{uint64(2), uint64(3)}}, "a26161016162820203"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.hex, func(t *testing.T) {
+			data, err := Marshal(tt.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := hex.EncodeToString(data); got != tt.hex {
+				t.Errorf("expected: %v, got: %v", tt.hex, got)
+			}
+
+			var v any
+			if err := Unmarshal(data, &v); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(v, tt.value) {
+				t.Errorf("expected: %#v, got: %#v", tt.value, v)
+			}
+		})
+	}
+}
+
+func TestDecodeFloats(t *testing.T) {
+	tests := map[string]float64{
+		"f90000":             0,
+		"f98000":             math.Copysign(0, -1),
+		"f93c00":             1,
+		"f93e00":             1.5,
+		"f97bff":             65504,
+		"f90001":             5.960464477539063e-8,
+		"f90400":             0.00006103515625,
+		"f9c400":             -4,
+		"f97c00":             math.Inf(1),
+		"fa7f7fffff":         3.4028234663852886e+38,
+		"fb7e37e43c8800759c": 1e300,
+		"1903e8":             1000,
+		"3903e7":             -1000,
+	}
+	for in, want := range tests {
+		data, _ := hex.DecodeString(in)
+		got, err := NewDecoder(data).Float()
+		if err != nil || got != want || math.Signbit(got) != math.Signbit(want) {
+			t.Errorf("%s: expected: %v, got: %v, %v", in, want, got, err)
+		}
+	}
+
+	data, _ := hex.DecodeString("f97e00")
+	if got, err := NewDecoder(data).Float(); err != nil || !math.IsNaN(got) {
+		t.Errorf("expected: NaN, got: %v, %v", got, err)
+	}
+}
+
+type point struct {
+	X, Y int64
+	Tags []string
+}
+
+func (p *point) MarshalCBOR(e *Encoder) error {
+	e.Array(3)
+	e.Int(p.X)
+	e.Int(p.Y)
+	return e.Value(p.Tags)
+}
+
+func (p *point) UnmarshalCBOR(d *Decoder) error {
+	n, err := d.Array()
+	if err != nil {
+		return err
+	}
+	if n != 3 {
+		return errors.New("expected 3 elements")
+	}
+	if p.X, err = d.Int(); err != nil {
+		return err
+	}
+	if p.Y, err = d.Int(); err != nil {
+		return err
+	}
+	return d.Decode(&p.Tags)
+}
+
+func TestMarshaler(t *testing.T) {
+	want := []*point{{X: 1, Y: -2, Tags: []string{"a"}}, {X: 300, Y: 0, Tags: []string{}}}
+
+	e := NewEncoder(nil)
+	if err := EncodeSlice(e, want); err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(e.Bytes()); got != "828301218161618319012c0080" {
+		t.Errorf("unexpected encoding: %v", got)
+	}
+
+	got, err := DecodeSlice[point](NewDecoder(e.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range want {
+		if !reflect.DeepEqual(&got[i], want[i]) {
+			t.Errorf("expected: %+v, got: %+v", want[i], got[i])
+		}
+	}
+
+	var p point
+	if err := Unmarshal(e.Bytes(), &p); err == nil {
+		t.Error("expected an error decoding an array of points into a point")
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	tests := map[string]struct {
+		hex    string
+		target any
+		err    string
+	}{
+		"empty":         {hex: "", target: new(any), err: "cbor: unexpected end of data"},
+		"truncated":     {hex: "1903", target: new(any), err: "cbor: unexpected end of data"},
+		"long string":   {hex: "7a7fffffff61", target: new(string), err: "cbor: unexpected end of data"},
+		"long array":    {hex: "9bffffffffffffffff", target: new(any), err: "cbor: unexpected end of data"},
+		"long map":      {hex: "a36161", target: new(any), err: "cbor: unexpected end of data"},
+		"indefinite":    {hex: "9fff", target: new(any), err: "cbor: indefinite-length items are not supported"},
+		"type":          {hex: "6161", target: new(int64), err: "cbor: expected integer, got text string"},
+		"overflow":      {hex: "1bffffffffffffffff", target: new(int64), err: "cbor: integer overflows int64"},
+		"invalid utf-8": {hex: "61ff", target: new(string), err: "cbor: invalid UTF-8 in text string"},
+		"trailing":      {hex: "0000", target: new(any), err: "cbor: data after the top-level data item"},
+		"map key":       {hex: "a10101", target: new(any), err: "cbor: expected text string, got unsigned integer"},
+		"deep":          {hex: strings.Repeat("81", 100) + "00", target: new(any), err: "cbor: data items nested too deeply"},
+		"unsupported":   {hex: "00", target: new(int32), err: "cbor: unsupported type *int32"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			data, _ := hex.DecodeString(tt.hex)
+			err := Unmarshal(data, tt.target)
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("expected: %v, got: %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestSkip(t *testing.T) {
+	// {"a": [1, {"b": h'00'}], "c": 1(1363896240)}, "next"
+	data, _ := hex.DecodeString("a2616182" + "01a1616241" + "00" + "6163c11a514b67b0" + "646e657874")
+	d := NewDecoder(data)
+	if err := d.Skip(); err != nil {
+		t.Fatal(err)
+	}
+	if s, err := d.String(); err != nil || s != "next" || d.More() {
+		t.Errorf("expected: %v, got: %v, %v", "next", s, err)
+	}
+}
+
+func TestValueTypes(t *testing.T) {
+	data, err := Marshal(map[string]any{
+		"ints":    []any{int8(-1), uint16(2), 3},
+		"strings": []string{"x"},
+		"labels":  map[string]string{"k": "v"},
+		"float":   float32(0.5),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]any
+	if err := Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"ints":    []any{int64(-1), uint64(2), uint64(3)},
+		"strings": []any{"x"},
+		"labels":  map[string]any{"k": "v"},
+		"float":   0.5,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected: %#v, got: %#v", want, got)
+	}
+
+	if _, err := Marshal(struct{}{}); err == nil || err.Error() != "cbor: unsupported type struct {}" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,390 @@
+package cbor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"unicode/utf8"
+)
+
+// maxDepth is the maximum nesting of the arrays, maps and tags skipped or
+// decoded into an any.
+const maxDepth = 64
+
+// ErrUnexpectedEnd is returned decoding truncated data.
+var ErrUnexpectedEnd = errors.New("cbor: unexpected end of data")
+
+// TypeError is returned decoding a data item into a value of another type.
+type TypeError struct {
+	// Expected is the expected type, e.g. "text string".
+	Expected string
+	// Got is the major type of the data item, e.g. "array".
+	Got string
+}
+
+func (e *TypeError) Error() string {
+	return "cbor: expected " + e.Expected + ", got " + e.Got
+}
+
+var majorNames = [...]string{"unsigned integer", "negative integer", "byte string", "text string", "array", "map", "tag", "simple value or float"}
+
+// Decoder reads the CBOR data items of a buffer. Its methods read the items of
+// an [Unmarshaler] in order, like they were written by an [Encoder].
+//
+// Indefinite-length items are not supported.
+type Decoder struct {
+	data []byte
+	off  int
+}
+
+// NewDecoder returns a Decoder reading data.
+func NewDecoder(data []byte) *Decoder {
+	return &Decoder{data: data}
+}
+
+// More reports whether there are data items left.
+func (d *Decoder) More() bool {
+	return d.off < len(d.data)
+}
+
+// peek returns the major type of the next data item.
+func (d *Decoder) peek() (byte, error) {
+	if d.off >= len(d.data) {
+		return 0, ErrUnexpectedEnd
+	}
+	return d.data[d.off] >> 5, nil
+}
+
+// head reads the initial byte and the argument of a data item, info is the
+// additional information of the initial byte.
+func (d *Decoder) head() (major, info byte, n uint64, err error) {
+	if d.off >= len(d.data) {
+		return 0, 0, 0, ErrUnexpectedEnd
+	}
+	major, info = d.data[d.off]>>5, d.data[d.off]&0x1f
+	d.off++
+
+	size := 0
+	switch {
+	case info < 24:
+		return major, info, uint64(info), nil
+	case info == 24:
+		size = 1
+	case info == 25:
+		size = 2
+	case info == 26:
+		size = 4
+	case info == 27:
+		size = 8
+	case info == 31:
+		return 0, 0, 0, errors.New("cbor: indefinite-length items are not supported")
+	default:
+		return 0, 0, 0, fmt.Errorf("cbor: invalid additional information %d", info)
+	}
+	if len(d.data)-d.off < size {
+		return 0, 0, 0, ErrUnexpectedEnd
+	}
+	b := d.data[d.off : d.off+size]
+	d.off += size
+	switch size {
+	case 1:
+		n = uint64(b[0])
+	case 2:
+		n = uint64(binary.BigEndian.Uint16(b))
+	case 4:
+		n = uint64(binary.BigEndian.Uint32(b))
+	default:
+		n = binary.BigEndian.Uint64(b)
+	}
+	return major, info, n, nil
+}
+
+// expect reads the head of a data item of the major type.
+func (d *Decoder) expect(major byte, name string) (uint64, error) {
+	m, _, n, err := d.head()
+	if err != nil {
+		return 0, err
+	}
+	if m != major {
+		return 0, &TypeError{Expected: name, Got: majorNames[m]}
+	}
+	return n, nil
+}
+
+// length reads the head of a data item of the major type, whose length is
+// bounded by the remaining data, each element taking at least a byte.
+func (d *Decoder) length(major byte, name string) (int, error) {
+	n, err := d.expect(major, name)
+	if err != nil {
+		return 0, err
+	}
+	if n > uint64(len(d.data)-d.off) {
+		return 0, ErrUnexpectedEnd
+	}
+	return int(n), nil
+}
+
+// Uint reads an unsigned integer.
+func (d *Decoder) Uint() (uint64, error) {
+	return d.expect(majorUint, "unsigned integer")
+}
+
+// Int reads a signed integer.
+func (d *Decoder) Int() (int64, error) {
+	major, _, n, err := d.head()
+	if err != nil {
+		return 0, err
+	}
+	switch major {
+	case majorUint:
+		if n > math.MaxInt64 {
+			return 0, errors.New("cbor: integer overflows int64")
+		}
+		return int64(n), nil
+	case majorNegInt:
+		if n > math.MaxInt64 {
+			return 0, errors.New("cbor: integer overflows int64")
+		}
+		return -1 - int64(n), nil
+	}
+	return 0, &TypeError{Expected: "integer", Got: majorNames[major]}
+}
+
+// Float reads a floating-point number of any precision, or an integer.
+func (d *Decoder) Float() (float64, error) {
+	major, err := d.peek()
+	if err != nil {
+		return 0, err
+	}
+	if major == majorUint {
+		n, err := d.Uint()
+		return float64(n), err
+	}
+	if major == majorNegInt {
+		_, _, n, err := d.head()
+		return -1 - float64(n), err
+	}
+
+	major, info, n, err := d.head()
+	if err != nil {
+		return 0, err
+	}
+	if major != majorSimple || info < floatHalf || info > floatDouble {
+		return 0, &TypeError{Expected: "float", Got: majorNames[major]}
+	}
+	switch info {
+	case floatHalf:
+		return halfToFloat(uint16(n)), nil
+	case floatSingle:
+		return float64(math.Float32frombits(uint32(n))), nil
+	}
+	return math.Float64frombits(n), nil
+}
+
+// halfToFloat converts an IEEE 754 half-precision float.
+func halfToFloat(h uint16) float64 {
+	exp, mant := int(h>>10&0x1f), float64(h&0x3ff)
+	var f float64
+	switch exp {
+	case 0:
+		f = math.Ldexp(mant, -24)
+	case 0x1f:
+		if mant == 0 {
+			f = math.Inf(1)
+		} else {
+			f = math.NaN()
+		}
+	default:
+		f = math.Ldexp(mant+1024, exp-25)
+	}
+	if h&0x8000 != 0 {
+		return -f
+	}
+	return f
+}
+
+// Bool reads a boolean.
+func (d *Decoder) Bool() (bool, error) {
+	major, info, _, err := d.head()
+	if err != nil {
+		return false, err
+	}
+	if major == majorSimple {
+		switch info {
+		case simpleFalse:
+			return false, nil
+		case simpleTrue:
+			return true, nil
+		}
+	}
+	return false, &TypeError{Expected: "bool", Got: majorNames[major]}
+}
+
+// Null reads null or undefined if it is the next data item, and reports
+// whether it did.
+func (d *Decoder) Null() bool {
+	if d.off < len(d.data) {
+		if b := d.data[d.off]; b == majorSimple<<5|simpleNull || b == majorSimple<<5|simpleUndef {
+			d.off++
+			return true
+		}
+	}
+	return false
+}
+
+// ByteString reads a byte string, the returned slice aliases the data of the
+// Decoder.
+func (d *Decoder) ByteString() ([]byte, error) {
+	n, err := d.length(majorBytes, "byte string")
+	if err != nil {
+		return nil, err
+	}
+	b := d.data[d.off : d.off+n : d.off+n]
+	d.off += n
+	return b, nil
+}
+
+// String reads a text string.
+func (d *Decoder) String() (string, error) {
+	n, err := d.length(majorText, "text string")
+	if err != nil {
+		return "", err
+	}
+	b := d.data[d.off : d.off+n]
+	if !utf8.Valid(b) {
+		return "", errors.New("cbor: invalid UTF-8 in text string")
+	}
+	d.off += n
+	return string(b), nil
+}
+
+// Array reads the header of an array and returns its number of elements.
+func (d *Decoder) Array() (int, error) {
+	return d.length(majorArray, "array")
+}
+
+// Map reads the header of a map and returns its number of key and value
+// pairs.
+func (d *Decoder) Map() (int, error) {
+	n, err := d.expect(majorMap, "map")
+	if err != nil {
+		return 0, err
+	}
+	if n > uint64(len(d.data)-d.off)/2 {
+		return 0, ErrUnexpectedEnd
+	}
+	return int(n), nil
+}
+
+// Tag reads the tag number of the following data item.
+func (d *Decoder) Tag() (uint64, error) {
+	return d.expect(majorTag, "tag")
+}
+
+// Skip skips the next data item, e.g. the value of an unknown map key.
+func (d *Decoder) Skip() error {
+	return d.skip(0)
+}
+
+func (d *Decoder) skip(depth int) error {
+	if depth > maxDepth {
+		return errors.New("cbor: data items nested too deeply")
+	}
+	major, _, n, err := d.head()
+	if err != nil {
+		return err
+	}
+	switch major {
+	case majorBytes, majorText:
+		if n > uint64(len(d.data)-d.off) {
+			return ErrUnexpectedEnd
+		}
+		d.off += int(n)
+	case majorArray, majorMap:
+		if major == majorMap {
+			n *= 2
+		}
+		if n > uint64(len(d.data)-d.off) {
+			return ErrUnexpectedEnd
+		}
+		for ; n > 0; n-- {
+			if err := d.skip(depth + 1); err != nil {
+				return err
+			}
+		}
+	case majorTag:
+		return d.skip(depth + 1)
+	}
+	return nil
+}
+
+// Value reads the next data item into an any: a uint64 or int64 for integers,
+// float64, string, []byte, bool, nil, []any or map[string]any. Tags are
+// dropped, maps with other keys than text strings are rejected.
+func (d *Decoder) Value() (any, error) {
+	return d.value(0)
+}
+
+func (d *Decoder) value(depth int) (any, error) {
+	if depth > maxDepth {
+		return nil, errors.New("cbor: data items nested too deeply")
+	}
+	major, err := d.peek()
+	if err != nil {
+		return nil, err
+	}
+	switch major {
+	case majorUint:
+		return d.Uint()
+	case majorNegInt:
+		return d.Int()
+	case majorBytes:
+		b, err := d.ByteString()
+		return bytes.Clone(b), err
+	case majorText:
+		return d.String()
+	case majorArray:
+		n, err := d.Array()
+		if err != nil {
+			return nil, err
+		}
+		v := make([]any, n)
+		for i := range v {
+			if v[i], err = d.value(depth + 1); err != nil {
+				return nil, err
+			}
+		}
+		return v, nil
+	case majorMap:
+		n, err := d.Map()
+		if err != nil {
+			return nil, err
+		}
+		v := make(map[string]any, n)
+		for range n {
+			k, err := d.String()
+			if err != nil {
+				return nil, err
+			}
+			if v[k], err = d.value(depth + 1); err != nil {
+				return nil, err
+			}
+		}
+		return v, nil
+	case majorTag:
+		if _, err := d.Tag(); err != nil {
+			return nil, err
+		}
+		return d.value(depth + 1)
+	}
+
+	if d.Null() {
+		return nil, nil
+	}
+	if info := d.data[d.off] & 0x1f; info == simpleFalse || info == simpleTrue {
+		return d.Bool()
+	}
+	return d.Float()
+}
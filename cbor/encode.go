@@ -0,0 +1,202 @@
+package cbor
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// major types of RFC 8949
+const (
+	majorUint   = 0
+	majorNegInt = 1
+	majorBytes  = 2
+	majorText   = 3
+	majorArray  = 4
+	majorMap    = 5
+	majorTag    = 6
+	majorSimple = 7
+)
+
+// simple values and float encodings of major type 7
+const (
+	simpleFalse = 20
+	simpleTrue  = 21
+	simpleNull  = 22
+	simpleUndef = 23
+	floatHalf   = 25
+	floatSingle = 26
+	floatDouble = 27
+)
+
+// Encoder appends CBOR data items to a buffer. Its methods write the items of
+// a [Marshaler] in order, arrays and maps are written as a header followed by
+// their elements, or key and value pairs.
+type Encoder struct {
+	buf []byte
+}
+
+// NewEncoder returns an Encoder appending to buf, which may be nil.
+func NewEncoder(buf []byte) *Encoder {
+	return &Encoder{buf: buf}
+}
+
+// Bytes returns the encoded data items.
+func (e *Encoder) Bytes() []byte {
+	return e.buf
+}
+
+// head appends the initial byte and argument of a data item, in the shortest
+// form.
+func (e *Encoder) head(major byte, n uint64) {
+	major <<= 5
+	switch {
+	case n < 24:
+		e.buf = append(e.buf, major|byte(n))
+	case n <= math.MaxUint8:
+		e.buf = append(e.buf, major|24, byte(n))
+	case n <= math.MaxUint16:
+		e.buf = binary.BigEndian.AppendUint16(append(e.buf, major|25), uint16(n))
+	case n <= math.MaxUint32:
+		e.buf = binary.BigEndian.AppendUint32(append(e.buf, major|26), uint32(n))
+	default:
+		e.buf = binary.BigEndian.AppendUint64(append(e.buf, major|27), n)
+	}
+}
+
+// Uint writes an unsigned integer.
+func (e *Encoder) Uint(n uint64) {
+	e.head(majorUint, n)
+}
+
+// Int writes a signed integer.
+func (e *Encoder) Int(n int64) {
+	if n < 0 {
+		e.head(majorNegInt, uint64(-1-n))
+		return
+	}
+	e.head(majorUint, uint64(n))
+}
+
+// Float writes a floating-point number, as a single-precision float if that
+// is lossless.
+func (e *Encoder) Float(f float64) {
+	if f32 := float32(f); float64(f32) == f || f != f {
+		e.buf = binary.BigEndian.AppendUint32(append(e.buf, majorSimple<<5|floatSingle), math.Float32bits(f32))
+		return
+	}
+	e.buf = binary.BigEndian.AppendUint64(append(e.buf, majorSimple<<5|floatDouble), math.Float64bits(f))
+}
+
+// Bool writes a boolean.
+func (e *Encoder) Bool(b bool) {
+	if b {
+		e.buf = append(e.buf, majorSimple<<5|simpleTrue)
+		return
+	}
+	e.buf = append(e.buf, majorSimple<<5|simpleFalse)
+}
+
+// Null writes null.
+func (e *Encoder) Null() {
+	e.buf = append(e.buf, majorSimple<<5|simpleNull)
+}
+
+// ByteString writes a byte string.
+func (e *Encoder) ByteString(b []byte) {
+	e.head(majorBytes, uint64(len(b)))
+	e.buf = append(e.buf, b...)
+}
+
+// String writes a text string, s must be valid UTF-8.
+func (e *Encoder) String(s string) {
+	e.head(majorText, uint64(len(s)))
+	e.buf = append(e.buf, s...)
+}
+
+// Array writes the header of an array of n elements.
+func (e *Encoder) Array(n int) {
+	e.head(majorArray, uint64(n))
+}
+
+// Map writes the header of a map of n key and value pairs.
+func (e *Encoder) Map(n int) {
+	e.head(majorMap, uint64(n))
+}
+
+// Tag writes the tag number of the following data item, e.g. 1 for an epoch
+// time.
+func (e *Encoder) Tag(n uint64) {
+	e.head(majorTag, n)
+}
+
+// Value writes v, which is a [Marshaler], nil, a bool, an integer, a float, a
+// string, a []byte, or a []any or map[string]any of those. Maps are written in
+// the order of their keys.
+func (e *Encoder) Value(v any) error {
+	switch v := v.(type) {
+	case Marshaler:
+		return v.MarshalCBOR(e)
+	case nil:
+		e.Null()
+	case bool:
+		e.Bool(v)
+	case int:
+		e.Int(int64(v))
+	case int8:
+		e.Int(int64(v))
+	case int16:
+		e.Int(int64(v))
+	case int32:
+		e.Int(int64(v))
+	case int64:
+		e.Int(v)
+	case uint:
+		e.Uint(uint64(v))
+	case uint8:
+		e.Uint(uint64(v))
+	case uint16:
+		e.Uint(uint64(v))
+	case uint32:
+		e.Uint(uint64(v))
+	case uint64:
+		e.Uint(v)
+	case float32:
+		e.Float(float64(v))
+	case float64:
+		e.Float(v)
+	case string:
+		e.String(v)
+	case []byte:
+		e.ByteString(v)
+	case []string:
+		e.Array(len(v))
+		for _, s := range v {
+			e.String(s)
+		}
+	case []any:
+		e.Array(len(v))
+		for _, elem := range v {
+			if err := e.Value(elem); err != nil {
+				return err
+			}
+		}
+	case map[string]string:
+		e.Map(len(v))
+		for _, k := range sortedKeys(v) {
+			e.String(k)
+			e.String(v[k])
+		}
+	case map[string]any:
+		e.Map(len(v))
+		for _, k := range sortedKeys(v) {
+			e.String(k)
+			if err := e.Value(v[k]); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("cbor: unsupported type %T", v)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package wasicmd
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.wasmcloud.dev/component/cli/wasienv"
+)
+
+// ParseFlags parses the `wasi:cli/environment` arguments into fs, e.g.
+// flag.CommandLine. Flags missing from the arguments are then looked up in the
+// environment, flag "listen-addr" with envPrefix "APP_" reads APP_LISTEN_ADDR.
+//
+// Usage goes to [wasienv.Stdout] when help is requested and to
+// [wasienv.Stderr] otherwise, the fs error handling policy applies as usual.
+func ParseFlags(fs *flag.FlagSet, envPrefix string) error {
+	args := wasienv.Args()
+	if len(args) > 0 {
+		// the program name
+		args = args[1:]
+	}
+
+	if isHelp(fs, args) {
+		fs.SetOutput(wasienv.Stdout())
+	} else {
+		fs.SetOutput(wasienv.Stderr())
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	var err error
+	fs.VisitAll(func(f *flag.Flag) {
+		if err != nil || set[f.Name] {
+			return
+		}
+		name := EnvName(envPrefix, f.Name)
+		value, ok := wasienv.LookupEnv(name)
+		if !ok {
+			return
+		}
+		if serr := fs.Set(f.Name, value); serr != nil {
+			err = fmt.Errorf("invalid value %q for environment variable %s: %s", value, name, serr)
+		}
+	})
+	if err != nil {
+		fmt.Fprintln(fs.Output(), err)
+		fs.Usage()
+		switch fs.ErrorHandling() {
+		case flag.ExitOnError:
+			os.Exit(2)
+		case flag.PanicOnError:
+			panic(err)
+		}
+	}
+	return err
+}
+
+// EnvName returns the environment variable consulted by [ParseFlags] for a flag.
+func EnvName(prefix, flag string) string {
+	return prefix + strings.ToUpper(strings.ReplaceAll(flag, "-", "_"))
+}
+
+// isHelp reports whether args request help, scanning the leading flags
+// like flag.FlagSet.Parse does.
+func isHelp(fs *flag.FlagSet, args []string) bool {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" || len(arg) < 2 || arg[0] != '-' {
+			return false
+		}
+		name, _, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		f := fs.Lookup(name)
+		if f == nil {
+			return name == "h" || name == "help"
+		}
+		if b, ok := f.Value.(interface{ IsBoolFlag() bool }); !hasValue && (!ok || !b.IsBoolFlag()) {
+			// the value is the next argument
+			i++
+		}
+	}
+	return false
+}
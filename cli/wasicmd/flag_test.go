@@ -0,0 +1,114 @@
+package wasicmd
+
+import (
+	"flag"
+	"strings"
+	"testing"
+	_ "unsafe"
+
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+)
+
+var (
+	args []string
+	env  [][2]string
+)
+
+func TestParseFlags(t *testing.T) {
+	// NOTE: wasienv reads the environment once, all cases share it
+	env = [][2]string{
+		{"APP_LISTEN_ADDR", ":9090"},
+		{"APP_VERBOSE", "true"},
+		{"BAD_PORT", "http"},
+	}
+
+	tests := map[string]struct {
+		args    []string
+		prefix  string
+		addr    string
+		port    int
+		verbose bool
+		stdout  string
+		stderr  string
+		err     bool
+	}{
+		"defaults": {
+			args: []string{"cmd"},
+			addr: ":8080",
+			port: 80,
+		},
+		"args": {
+			args: []string{"cmd", "-listen-addr", ":1234", "--port=81", "-verbose", "rest"},
+			addr: ":1234", port: 81, verbose: true,
+		},
+		"env": {
+			args:   []string{"cmd", "-port", "82"},
+			prefix: "APP_",
+			addr:   ":9090", port: 82, verbose: true,
+		},
+		"args over env": {
+			args:   []string{"cmd", "-listen-addr", ":1"},
+			prefix: "APP_",
+			addr:   ":1", port: 80, verbose: true,
+		},
+		"help": {
+			args:   []string{"cmd", "-port", "82", "-h"},
+			stdout: "-listen-addr",
+			err:    true,
+		},
+		"invalid arg": {
+			args:   []string{"cmd", "-port", "http"},
+			stderr: "invalid value",
+			err:    true,
+		},
+		"invalid env": {
+			args:   []string{"cmd"},
+			prefix: "BAD_",
+			stderr: "BAD_PORT",
+			err:    true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			args = tt.args
+			outputs[1].Reset()
+			outputs[2].Reset()
+
+			fs := flag.NewFlagSet("cmd", flag.ContinueOnError)
+			addr := fs.String("listen-addr", ":8080", "address to listen on")
+			port := fs.Int("port", 80, "port")
+			verbose := fs.Bool("verbose", false, "verbose output")
+
+			err := ParseFlags(fs, tt.prefix)
+			if tt.err {
+				if err == nil {
+					t.Error("expected error")
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			} else if *addr != tt.addr || *port != tt.port || *verbose != tt.verbose {
+				t.Errorf("expected: %v %v %v, got: %v %v %v", tt.addr, tt.port, tt.verbose, *addr, *port, *verbose)
+			}
+
+			if stdout := outputs[1].String(); !strings.Contains(stdout, tt.stdout) || (tt.stdout == "" && stdout != "") {
+				t.Errorf("unexpected stdout: %q", stdout)
+			}
+			if stderr := outputs[2].String(); !strings.Contains(stderr, tt.stderr) || (tt.stderr == "" && stderr != "") {
+				t.Errorf("unexpected stderr: %q", stderr)
+			}
+		})
+	}
+}
+
+// stub wasi:cli/environment
+//
+//go:linkname wasmimport_GetArguments go.wasmcloud.dev/component/gen/wasi/cli/environment.wasmimport_GetArguments
+func wasmimport_GetArguments(result *cm.List[string]) {
+	*result = cm.ToList(args)
+}
+
+//go:linkname wasmimport_GetEnvironment go.wasmcloud.dev/component/gen/wasi/cli/environment.wasmimport_GetEnvironment
+func wasmimport_GetEnvironment(result *cm.List[[2]string]) {
+	*result = cm.ToList(env)
+}
@@ -0,0 +1,62 @@
+package wasicmd
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"go.wasmcloud.dev/component/cli/wasienv"
+	"go.wasmcloud.dev/component/export"
+	"go.wasmcloud.dev/component/gen/wasi/cli/exit"
+	"go.wasmcloud.dev/component/tasks"
+)
+
+// Run runs fn as the body of a command component and exits through
+// `wasi:cli/exit` with its status. A panic in fn is printed to stderr along
+// with its stack trace and exits with status 2, like a Go program would.
+//
+// The tasks deferred with tasks.Defer, then the [export.OnShutdown] hooks, are
+// called before exiting, a failing task or hook fails the command.
+//
+// It is meant to be the only call in main(), which TinyGo invokes from its
+// `wasi:cli/run` export:
+//
+//	func main() {
+//		wasicmd.Run(func(ctx context.Context) int {
+//			fmt.Fprintln(wasienv.Stdout(), "hello")
+//			return 0
+//		})
+//	}
+//
+// NOTE: `wasi:cli/exit@0.2.0` only distinguishes success from failure, every
+// nonzero status is reported to the host as a failure.
+func Run(fn func(ctx context.Context) int) {
+	code := run(fn)
+	if err := export.Shutdown(context.Background()); err != nil {
+		fmt.Fprintf(wasienv.Stderr(), "shutdown hooks failed: %s\n", err)
+		code = max(code, 1)
+	}
+	exit.Exit(cm.BoolResult(code != 0))
+}
+
+func run(fn func(ctx context.Context) int) (code int) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctx, queue := tasks.NewContext(ctx)
+	defer func() {
+		if err := queue.Run(ctx); err != nil {
+			fmt.Fprintf(wasienv.Stderr(), "deferred tasks failed: %s\n", err)
+			code = max(code, 1)
+		}
+	}()
+
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Fprintf(wasienv.Stderr(), "panic: %v\n\n%s", r, debug.Stack())
+			code = 2
+		}
+	}()
+
+	return fn(ctx)
+}
@@ -0,0 +1,146 @@
+package wasicmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"go.wasmcloud.dev/component/export"
+	"go.wasmcloud.dev/component/gen/wasi/io/streams"
+)
+
+var (
+	// outputs is indexed by stream handle, 1 for stdout and 2 for stderr
+	outputs [3]bytes.Buffer
+	status  *bool
+)
+
+func TestRun(t *testing.T) {
+	tests := map[string]struct {
+		fn     func(ctx context.Context) int
+		failed bool
+		stderr string
+	}{
+		"success": {fn: func(context.Context) int { return 0 }},
+		"failure": {fn: func(context.Context) int { return 3 }, failed: true},
+		"panic": {
+			fn:     func(context.Context) int { panic("boom") },
+			failed: true,
+			stderr: "panic: boom",
+		},
+		"canceled after return": {
+			fn: func(ctx context.Context) int {
+				if ctx.Err() != nil {
+					return 1
+				}
+				return 0
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			outputs[2].Reset()
+			status = nil
+
+			Run(tt.fn)
+
+			if status == nil {
+				t.Fatal("expected wasi:cli/exit to be called")
+			}
+			if *status != tt.failed {
+				t.Errorf("expected: %v, got: %v", tt.failed, *status)
+			}
+			if stderr := outputs[2].String(); !strings.Contains(stderr, tt.stderr) {
+				t.Errorf("expected stderr to contain %q, got: %q", tt.stderr, stderr)
+			}
+		})
+	}
+}
+
+func TestRunShutdown(t *testing.T) {
+	var calls []string
+	remove := export.OnShutdown(func(ctx context.Context) error {
+		calls = append(calls, "flush")
+		return errors.New("export failed")
+	})
+	defer remove()
+
+	outputs[2].Reset()
+	status = nil
+	Run(func(context.Context) int {
+		calls = append(calls, "run")
+		return 0
+	})
+
+	if !slices.Equal(calls, []string{"run", "flush"}) {
+		t.Errorf("expected: %v, got: %v", []string{"run", "flush"}, calls)
+	}
+	if status == nil || !*status {
+		t.Error("expected a failing shutdown hook to fail the command")
+	}
+	if stderr := outputs[2].String(); !strings.Contains(stderr, "export failed") {
+		t.Errorf("expected stderr to contain %q, got: %q", "export failed", stderr)
+	}
+}
+
+// stub wasi:cli/exit, recording the status instead of exiting
+//
+//go:linkname wasmimport_Exit go.wasmcloud.dev/component/gen/wasi/cli/exit.wasmimport_Exit
+func wasmimport_Exit(status0 uint32) {
+	failed := status0 != 0
+	status = &failed
+}
+
+// stub wasi:cli/stdout and wasi:cli/stderr, captured in outputs
+//
+//go:linkname wasmimport_GetStdout go.wasmcloud.dev/component/gen/wasi/cli/stdout.wasmimport_GetStdout
+func wasmimport_GetStdout() uint32 {
+	return 1
+}
+
+//go:linkname wasmimport_GetStderr go.wasmcloud.dev/component/gen/wasi/cli/stderr.wasmimport_GetStderr
+func wasmimport_GetStderr() uint32 {
+	return 2
+}
+
+//go:linkname wasmimport_OutputStreamSubscribe go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_OutputStreamSubscribe
+func wasmimport_OutputStreamSubscribe(self0 uint32) uint32 {
+	return 1
+}
+
+//go:linkname wasmimport_OutputStreamCheckWrite go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_OutputStreamCheckWrite
+func wasmimport_OutputStreamCheckWrite(self0 uint32, result *cm.Result[uint64, uint64, streams.StreamError]) {
+	*result = cm.OK[cm.Result[uint64, uint64, streams.StreamError]](4096)
+}
+
+//go:linkname wasmimport_OutputStreamWrite go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_OutputStreamWrite
+func wasmimport_OutputStreamWrite(self0 uint32, contents0 *uint8, contents1 uint32, result *cm.Result[streams.StreamError, struct{}, streams.StreamError]) {
+	outputs[self0].Write(unsafe.Slice(contents0, contents1))
+	*result = cm.OK[cm.Result[streams.StreamError, struct{}, streams.StreamError]](struct{}{})
+}
+
+//go:linkname wasmimport_OutputStreamFlush go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_OutputStreamFlush
+func wasmimport_OutputStreamFlush(self0 uint32, result *cm.Result[streams.StreamError, struct{}, streams.StreamError]) {
+	*result = cm.OK[cm.Result[streams.StreamError, struct{}, streams.StreamError]](struct{}{})
+}
+
+//go:linkname wasmimport_ErrorToDebugString go.wasmcloud.dev/component/gen/wasi/io/error.wasmimport_ErrorToDebugString
+func wasmimport_ErrorToDebugString(self0 uint32, result *string) {
+	*result = "stub error"
+}
+
+//go:linkname wasmimport_ErrorResourceDrop go.wasmcloud.dev/component/gen/wasi/io/error.wasmimport_ErrorResourceDrop
+func wasmimport_ErrorResourceDrop(self0 uint32) {}
+
+// stub wasi:io/poll, outputs are always writable
+//
+//go:linkname wasmimport_Poll go.wasmcloud.dev/component/gen/wasi/io/poll.wasmimport_Poll
+func wasmimport_Poll(in0 *uint32, in1 uint32, result *cm.List[uint32]) {
+	*result = cm.ToList([]uint32{0})
+}
@@ -0,0 +1,39 @@
+package wasienv
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"go.wasmcloud.dev/component/internal/decode"
+)
+
+// Decode sets the fields of the struct pointed to by v from environment
+// variables, following the `env` struct tags:
+//
+//	type Config struct {
+//		Addr    string        `env:"ADDR" default:":8080"`
+//		Timeout time.Duration `env:"TIMEOUT"`
+//		Token   string        `env:"TOKEN,required"`
+//		Tags    []string      `env:"TAGS"` // comma separated
+//		DB      DBConfig      `env:"DB_"`  // prefixes nested fields
+//	}
+//
+// Supported field types are strings, booleans, integers, floats,
+// time.Duration, slices of those, and encoding.TextUnmarshaler implementations.
+// Fields without a tag are left untouched.
+func Decode(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("wasienv: Decode requires a non-nil struct pointer")
+	}
+
+	d := decode.Decoder{
+		Tag:    "env",
+		Lookup: LookupEnv,
+		Errorf: func(format string, args ...any) error {
+			return fmt.Errorf("wasienv: "+format, args...)
+		},
+	}
+	return d.Struct(rv.Elem(), "")
+}
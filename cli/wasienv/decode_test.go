@@ -0,0 +1,82 @@
+package wasienv
+
+import (
+	"log/slog"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type dbConfig struct {
+	MaxConns int `env:"MAX_CONNS"`
+}
+
+type config struct {
+	Addr    string        `env:"ADDR" default:":8080"`
+	Region  string        `env:"REGION" default:"local"`
+	Timeout time.Duration `env:"TIMEOUT"`
+	Token   string        `env:"TOKEN,required"`
+	Tags    []string      `env:"TAGS"`
+	Ports   []uint16      `env:"PORTS"`
+	Debug   bool          `env:"DEBUG"`
+	Ratio   float64       `env:"RATIO"`
+	Level   slog.Level    `env:"LEVEL"`
+	DB      dbConfig      `env:"DB_"`
+	Ignored string
+}
+
+func TestDecode(t *testing.T) {
+	tests := map[string]struct {
+		v    any
+		want any
+		err  bool
+	}{
+		"config": {
+			v: &config{Ignored: "kept"},
+			want: &config{
+				Addr:    "0.0.0.0:9000",
+				Region:  "local",
+				Timeout: 90 * time.Second,
+				Token:   "secret",
+				Tags:    []string{"a", "b", "c"},
+				Ports:   []uint16{80, 443},
+				Debug:   true,
+				Ratio:   0.5,
+				Level:   slog.LevelWarn,
+				DB:      dbConfig{MaxConns: 16},
+				Ignored: "kept",
+			},
+		},
+		"required": {
+			v: &struct {
+				Missing string `env:"MISSING,required"`
+			}{},
+			err: true,
+		},
+		"invalid": {
+			v: &struct {
+				N int `env:"BAD_INT"`
+			}{},
+			err: true,
+		},
+		"not a pointer": {v: config{}, err: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := Decode(tt.v)
+			if tt.err {
+				if err == nil {
+					t.Error("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(tt.v, tt.want) {
+				t.Errorf("expected: %+v, got: %+v", tt.want, tt.v)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package wasienv
+
+import (
+	"sync"
+
+	"go.wasmcloud.dev/component/gen/wasi/cli/environment"
+)
+
+var (
+	environOnce sync.Once
+	environMap  map[string]string
+)
+
+// environ reads the environment once, `wasi:cli/environment` is fixed for the
+// instance lifetime.
+func environ() map[string]string {
+	environOnce.Do(func() {
+		environMap = make(map[string]string)
+		for _, kv := range environment.GetEnvironment().Slice() {
+			environMap[kv[0]] = kv[1]
+		}
+	})
+	return environMap
+}
+
+// Environ returns the environment from `wasi:cli/environment`, in the
+// "key=value" form of os.Environ.
+func Environ() []string {
+	env := environment.GetEnvironment().Slice()
+	vars := make([]string, 0, len(env))
+	for _, kv := range env {
+		vars = append(vars, kv[0]+"="+kv[1])
+	}
+	return vars
+}
+
+// Getenv returns the value of the environment variable named by key, or an
+// empty string if it is not set.
+func Getenv(key string) string {
+	return environ()[key]
+}
+
+// LookupEnv returns the value of the environment variable named by key and
+// whether it is set.
+func LookupEnv(key string) (string, bool) {
+	value, ok := environ()[key]
+	return value, ok
+}
+
+// Args returns the arguments from `wasi:cli/environment`, starting with the
+// program name when the host provides one.
+func Args() []string {
+	return append([]string(nil), environment.GetArguments().Slice()...)
+}
+
+// InitialCwd returns the initial working directory and whether the host set one.
+func InitialCwd() (string, bool) {
+	cwd := environment.InitialCWD()
+	if cwd.None() {
+		return "", false
+	}
+	return *cwd.Some(), true
+}
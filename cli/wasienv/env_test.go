@@ -0,0 +1,83 @@
+package wasienv
+
+import (
+	"slices"
+	"testing"
+	_ "unsafe"
+
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+)
+
+var env = [][2]string{
+	{"ADDR", "0.0.0.0:9000"},
+	{"TIMEOUT", "1m30s"},
+	{"TOKEN", "secret"},
+	{"TAGS", "a, b,c"},
+	{"PORTS", "80,443"},
+	{"DEBUG", "true"},
+	{"DB_MAX_CONNS", "0x10"},
+	{"RATIO", "0.5"},
+	{"LEVEL", "warn"},
+	{"BAD_INT", "ten"},
+	{"EMPTY", ""},
+}
+
+func TestEnv(t *testing.T) {
+	tests := map[string]struct {
+		key   string
+		value string
+		ok    bool
+	}{
+		"set":   {key: "TOKEN", value: "secret", ok: true},
+		"empty": {key: "EMPTY", value: "", ok: true},
+		"unset": {key: "MISSING", value: "", ok: false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			value, ok := LookupEnv(tt.key)
+			if value != tt.value || ok != tt.ok {
+				t.Errorf("expected: %v %v, got: %v %v", tt.value, tt.ok, value, ok)
+			}
+			if got := Getenv(tt.key); got != tt.value {
+				t.Errorf("expected: %v, got: %v", tt.value, got)
+			}
+		})
+	}
+
+	if vars := Environ(); !slices.Contains(vars, "TOKEN=secret") || len(vars) != len(env) {
+		t.Errorf("unexpected environ: %v", vars)
+	}
+}
+
+func TestArgs(t *testing.T) {
+	want := []string{"component", "-v"}
+	if got := Args(); !slices.Equal(got, want) {
+		t.Errorf("expected: %v, got: %v", want, got)
+	}
+
+	if cwd, ok := InitialCwd(); !ok || cwd != "/srv" {
+		t.Errorf("expected: %v, got: %v %v", "/srv", cwd, ok)
+	}
+}
+
+// stub wasi:cli/environment
+//
+//go:linkname wasmimport_GetEnvironment go.wasmcloud.dev/component/gen/wasi/cli/environment.wasmimport_GetEnvironment
+func wasmimport_GetEnvironment(result *cm.List[[2]string]) {
+	*result = cm.ToList(env)
+}
+
+// stub wasi:cli/environment
+//
+//go:linkname wasmimport_GetArguments go.wasmcloud.dev/component/gen/wasi/cli/environment.wasmimport_GetArguments
+func wasmimport_GetArguments(result *cm.List[string]) {
+	*result = cm.ToList([]string{"component", "-v"})
+}
+
+// stub wasi:cli/environment
+//
+//go:linkname wasmimport_InitialCWD go.wasmcloud.dev/component/gen/wasi/cli/environment.wasmimport_InitialCWD
+func wasmimport_InitialCWD(result *cm.Option[string]) {
+	*result = cm.Some("/srv")
+}
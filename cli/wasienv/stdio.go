@@ -0,0 +1,133 @@
+package wasienv
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"go.wasmcloud.dev/component/gen/wasi/cli/stderr"
+	"go.wasmcloud.dev/component/gen/wasi/cli/stdin"
+	"go.wasmcloud.dev/component/gen/wasi/cli/stdout"
+	"go.wasmcloud.dev/component/gen/wasi/io/streams"
+	"go.wasmcloud.dev/component/io/wasipoll"
+)
+
+var (
+	stdinOnce    sync.Once
+	stdinReader  *streamReader
+	stdoutOnce   sync.Once
+	stdoutWriter *streamWriter
+	stderrOnce   sync.Once
+	stderrWriter *streamWriter
+)
+
+// Stdin returns a reader over the `wasi:cli/stdin` stream.
+// Reads wait through io/wasipoll, letting other goroutines run meanwhile.
+func Stdin() io.Reader {
+	stdinOnce.Do(func() { stdinReader = newStreamReader(stdin.GetStdin()) })
+	return stdinReader
+}
+
+// Stdout returns a writer over the `wasi:cli/stdout` stream.
+func Stdout() io.Writer {
+	stdoutOnce.Do(func() { stdoutWriter = newStreamWriter(stdout.GetStdout()) })
+	return stdoutWriter
+}
+
+// Stderr returns a writer over the `wasi:cli/stderr` stream.
+func Stderr() io.Writer {
+	stderrOnce.Do(func() { stderrWriter = newStreamWriter(stderr.GetStderr()) })
+	return stderrWriter
+}
+
+type streamReader struct {
+	mu       sync.Mutex
+	stream   streams.InputStream
+	pollable wasipoll.Pollable
+}
+
+func newStreamReader(stream streams.InputStream) *streamReader {
+	return &streamReader{stream: stream, pollable: stream.Subscribe()}
+}
+
+func (r *streamReader) Read(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	for {
+		res := r.stream.Read(uint64(len(p)))
+		if res.IsErr() {
+			if res.Err().Closed() {
+				return 0, io.EOF
+			}
+			return 0, streamError(res.Err())
+		}
+
+		if data := res.OK().Slice(); len(data) > 0 {
+			return copy(p, data), nil
+		}
+		wasipoll.Wait(r.pollable)
+	}
+}
+
+type streamWriter struct {
+	mu       sync.Mutex
+	stream   streams.OutputStream
+	pollable wasipoll.Pollable
+}
+
+func newStreamWriter(stream streams.OutputStream) *streamWriter {
+	return &streamWriter{stream: stream, pollable: stream.Subscribe()}
+}
+
+func (w *streamWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var n int
+	for n < len(p) {
+		res := w.stream.CheckWrite()
+		if res.IsErr() {
+			if res.Err().Closed() {
+				return n, io.ErrClosedPipe
+			}
+			return n, streamError(res.Err())
+		}
+
+		budget := *res.OK()
+		if budget == 0 {
+			wasipoll.Wait(w.pollable)
+			continue
+		}
+
+		chunk := p[n:]
+		if uint64(len(chunk)) > budget {
+			chunk = chunk[:budget]
+		}
+
+		if res := w.stream.Write(cm.ToList(chunk)); res.IsErr() {
+			if res.Err().Closed() {
+				return n, io.ErrClosedPipe
+			}
+			return n, streamError(res.Err())
+		}
+		n += len(chunk)
+	}
+
+	if res := w.stream.Flush(); res.IsErr() && !res.Err().Closed() {
+		return n, streamError(res.Err())
+	}
+	return n, nil
+}
+
+func streamError(err *streams.StreamError) error {
+	ioErr := err.LastOperationFailed()
+	defer ioErr.ResourceDrop()
+
+	return fmt.Errorf("stream operation failed: %s", ioErr.ToDebugString())
+}
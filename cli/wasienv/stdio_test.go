@@ -0,0 +1,120 @@
+package wasienv
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+	"unsafe"
+
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"go.wasmcloud.dev/component/gen/wasi/io/streams"
+)
+
+var (
+	stdinData = []byte("hello\nworld\n")
+	// outputs is indexed by stream handle, 1 for stdout and 2 for stderr
+	outputs [3]bytes.Buffer
+)
+
+func TestStdin(t *testing.T) {
+	data, err := io.ReadAll(Stdin())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "hello\nworld\n"; string(data) != want {
+		t.Errorf("expected: %q, got: %q", want, data)
+	}
+}
+
+func TestStdout(t *testing.T) {
+	tests := map[string]struct {
+		writer func() io.Writer
+		stream int
+	}{
+		"stdout": {writer: Stdout, stream: 1},
+		"stderr": {writer: Stderr, stream: 2},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			fmt.Fprintf(tt.writer(), "hello %s", name)
+			if want, got := "hello "+name, outputs[tt.stream].String(); got != want {
+				t.Errorf("expected: %q, got: %q", want, got)
+			}
+		})
+	}
+}
+
+// stub wasi:cli/stdin, reading stdinData
+//
+//go:linkname wasmimport_GetStdin go.wasmcloud.dev/component/gen/wasi/cli/stdin.wasmimport_GetStdin
+func wasmimport_GetStdin() uint32 {
+	return 0
+}
+
+//go:linkname wasmimport_InputStreamSubscribe go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_InputStreamSubscribe
+func wasmimport_InputStreamSubscribe(self0 uint32) uint32 {
+	return 0
+}
+
+// stub wasi:io/streams, returning at most 4 bytes per read
+//
+//go:linkname wasmimport_InputStreamRead go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_InputStreamRead
+func wasmimport_InputStreamRead(self0 uint32, len0 uint64, result *cm.Result[cm.List[uint8], cm.List[uint8], streams.StreamError]) {
+	if len(stdinData) == 0 {
+		*result = cm.Err[cm.Result[cm.List[uint8], cm.List[uint8], streams.StreamError]](streams.StreamErrorClosed())
+		return
+	}
+	n := min(uint64(len(stdinData)), len0, 4)
+	*result = cm.OK[cm.Result[cm.List[uint8], cm.List[uint8], streams.StreamError]](cm.ToList(stdinData[:n]))
+	stdinData = stdinData[n:]
+}
+
+// stub wasi:cli/stdout and wasi:cli/stderr, captured in outputs
+//
+//go:linkname wasmimport_GetStdout go.wasmcloud.dev/component/gen/wasi/cli/stdout.wasmimport_GetStdout
+func wasmimport_GetStdout() uint32 {
+	return 1
+}
+
+//go:linkname wasmimport_GetStderr go.wasmcloud.dev/component/gen/wasi/cli/stderr.wasmimport_GetStderr
+func wasmimport_GetStderr() uint32 {
+	return 2
+}
+
+//go:linkname wasmimport_OutputStreamSubscribe go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_OutputStreamSubscribe
+func wasmimport_OutputStreamSubscribe(self0 uint32) uint32 {
+	return 1
+}
+
+//go:linkname wasmimport_OutputStreamCheckWrite go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_OutputStreamCheckWrite
+func wasmimport_OutputStreamCheckWrite(self0 uint32, result *cm.Result[uint64, uint64, streams.StreamError]) {
+	*result = cm.OK[cm.Result[uint64, uint64, streams.StreamError]](4)
+}
+
+//go:linkname wasmimport_OutputStreamWrite go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_OutputStreamWrite
+func wasmimport_OutputStreamWrite(self0 uint32, contents0 *uint8, contents1 uint32, result *cm.Result[streams.StreamError, struct{}, streams.StreamError]) {
+	outputs[self0].Write(unsafe.Slice(contents0, contents1))
+	*result = cm.OK[cm.Result[streams.StreamError, struct{}, streams.StreamError]](struct{}{})
+}
+
+//go:linkname wasmimport_OutputStreamFlush go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_OutputStreamFlush
+func wasmimport_OutputStreamFlush(self0 uint32, result *cm.Result[streams.StreamError, struct{}, streams.StreamError]) {
+	*result = cm.OK[cm.Result[streams.StreamError, struct{}, streams.StreamError]](struct{}{})
+}
+
+//go:linkname wasmimport_ErrorToDebugString go.wasmcloud.dev/component/gen/wasi/io/error.wasmimport_ErrorToDebugString
+func wasmimport_ErrorToDebugString(self0 uint32, result *string) {
+	*result = "stub error"
+}
+
+//go:linkname wasmimport_ErrorResourceDrop go.wasmcloud.dev/component/gen/wasi/io/error.wasmimport_ErrorResourceDrop
+func wasmimport_ErrorResourceDrop(self0 uint32) {}
+
+// stub wasi:io/poll, every stream is always ready
+//
+//go:linkname wasmimport_Poll go.wasmcloud.dev/component/gen/wasi/io/poll.wasmimport_Poll
+func wasmimport_Poll(in0 *uint32, in1 uint32, result *cm.List[uint32]) {
+	*result = cm.ToList([]uint32{0})
+}
@@ -0,0 +1,68 @@
+package wasienv
+
+import (
+	"errors"
+
+	terminalstderr "go.wasmcloud.dev/component/gen/wasi/cli/terminal-stderr"
+	terminalstdin "go.wasmcloud.dev/component/gen/wasi/cli/terminal-stdin"
+	terminalstdout "go.wasmcloud.dev/component/gen/wasi/cli/terminal-stdout"
+)
+
+// StdStream identifies one of the `wasi:cli` stdio streams.
+type StdStream int
+
+const (
+	StdinStream StdStream = iota
+	StdoutStream
+	StderrStream
+)
+
+// IsTerminal reports whether the host connected the stream to a terminal,
+// through `wasi:cli/terminal-stdin`, `terminal-stdout` and `terminal-stderr`.
+func IsTerminal(stream StdStream) bool {
+	switch stream {
+	case StdinStream:
+		t := terminalstdin.GetTerminalStdin()
+		if t.None() {
+			return false
+		}
+		t.Some().ResourceDrop()
+	case StdoutStream:
+		t := terminalstdout.GetTerminalStdout()
+		if t.None() {
+			return false
+		}
+		t.Some().ResourceDrop()
+	case StderrStream:
+		t := terminalstderr.GetTerminalStderr()
+		if t.None() {
+			return false
+		}
+		t.Some().ResourceDrop()
+	default:
+		return false
+	}
+	return true
+}
+
+// ColorEnabled reports whether output to the stream should be colored: the
+// stream is a terminal, NO_COLOR is unset and TERM is not "dumb".
+func ColorEnabled(stream StdStream) bool {
+	if _, ok := LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	return Getenv("TERM") != "dumb" && IsTerminal(stream)
+}
+
+// ErrRawModeUnsupported is returned by the default [MakeRaw].
+var ErrRawModeUnsupported = errors.New("wasienv: terminal raw mode is not supported by wasi:cli")
+
+// MakeRaw puts the stdin terminal into raw mode, returning a function
+// restoring the previous mode.
+//
+// NOTE: `wasi:cli/terminal-*` only exposes terminal presence, there is no way
+// to change the line discipline. Hosts offering one through a custom import
+// can install it here, REPLs fall back to line-buffered input otherwise.
+var MakeRaw = func() (restore func() error, err error) {
+	return nil, ErrRawModeUnsupported
+}
@@ -0,0 +1,68 @@
+package wasienv
+
+import (
+	"testing"
+	_ "unsafe"
+
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+	terminalinput "go.wasmcloud.dev/component/gen/wasi/cli/terminal-input"
+	terminaloutput "go.wasmcloud.dev/component/gen/wasi/cli/terminal-output"
+)
+
+func TestIsTerminal(t *testing.T) {
+	tests := map[string]struct {
+		stream StdStream
+		want   bool
+	}{
+		"stdin":   {stream: StdinStream, want: true},
+		"stdout":  {stream: StdoutStream, want: true},
+		"stderr":  {stream: StderrStream, want: false},
+		"unknown": {stream: StdStream(42), want: false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := IsTerminal(tt.stream); got != tt.want {
+				t.Errorf("expected: %v, got: %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestColorEnabled(t *testing.T) {
+	if !ColorEnabled(StdoutStream) {
+		t.Error("expected colors on a terminal")
+	}
+	if ColorEnabled(StderrStream) {
+		t.Error("expected no colors off a terminal")
+	}
+}
+
+func TestMakeRaw(t *testing.T) {
+	if _, err := MakeRaw(); err != ErrRawModeUnsupported {
+		t.Errorf("expected: %v, got: %v", ErrRawModeUnsupported, err)
+	}
+}
+
+// stub wasi:cli/terminal-*, stdin and stdout are terminals
+//
+//go:linkname wasmimport_GetTerminalStdin go.wasmcloud.dev/component/gen/wasi/cli/terminal-stdin.wasmimport_GetTerminalStdin
+func wasmimport_GetTerminalStdin(result *cm.Option[terminalinput.TerminalInput]) {
+	*result = cm.Some(terminalinput.TerminalInput(1))
+}
+
+//go:linkname wasmimport_GetTerminalStdout go.wasmcloud.dev/component/gen/wasi/cli/terminal-stdout.wasmimport_GetTerminalStdout
+func wasmimport_GetTerminalStdout(result *cm.Option[terminaloutput.TerminalOutput]) {
+	*result = cm.Some(terminaloutput.TerminalOutput(1))
+}
+
+//go:linkname wasmimport_GetTerminalStderr go.wasmcloud.dev/component/gen/wasi/cli/terminal-stderr.wasmimport_GetTerminalStderr
+func wasmimport_GetTerminalStderr(result *cm.Option[terminaloutput.TerminalOutput]) {
+	*result = cm.None[terminaloutput.TerminalOutput]()
+}
+
+//go:linkname wasmimport_TerminalInputResourceDrop go.wasmcloud.dev/component/gen/wasi/cli/terminal-input.wasmimport_TerminalInputResourceDrop
+func wasmimport_TerminalInputResourceDrop(self0 uint32) {}
+
+//go:linkname wasmimport_TerminalOutputResourceDrop go.wasmcloud.dev/component/gen/wasi/cli/terminal-output.wasmimport_TerminalOutputResourceDrop
+func wasmimport_TerminalOutputResourceDrop(self0 uint32) {}
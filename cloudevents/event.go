@@ -0,0 +1,249 @@
+package cloudevents
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"strings"
+	"time"
+
+	"go.wasmcloud.dev/component/rand/wasirand"
+	"go.wasmcloud.dev/component/time/wasiclock"
+)
+
+const (
+	// SpecVersion is the CloudEvents specification version implemented.
+	SpecVersion = "1.0"
+	// ContentType is the media type of structured mode JSON events.
+	ContentType = "application/cloudevents+json"
+)
+
+// Event is a CloudEvents v1.0 event.
+type Event struct {
+	// ID, Source, SpecVersion and Type are the required context attributes.
+	ID          string
+	Source      string
+	SpecVersion string
+	Type        string
+
+	// DataContentType, DataSchema, Subject and Time are optional.
+	DataContentType string
+	DataSchema      string
+	Subject         string
+	Time            time.Time
+
+	// Extensions holds extension context attributes, keyed by lowercase name.
+	// Values are strings, booleans, JSON numbers or base64 encoded bytes.
+	Extensions map[string]any
+
+	// Data is the raw event payload, encoded as DataContentType.
+	Data []byte
+}
+
+// New returns an event of the given type and source, with a random UUID and
+// the current time of wasiclock.NowFunc.
+func New(source, typ string) Event {
+	return Event{
+		ID:          wasirand.UUIDv4(),
+		Source:      source,
+		SpecVersion: SpecVersion,
+		Type:        typ,
+		Time:        wasiclock.NowFunc(),
+	}
+}
+
+// SetData sets the payload. Byte slices and strings are used as is, other
+// values are encoded as JSON. An empty contentType defaults to
+// "application/json".
+func (e *Event) SetData(contentType string, v any) error {
+	if contentType == "" {
+		contentType = "application/json"
+	}
+
+	switch v := v.(type) {
+	case []byte:
+		e.Data = v
+	case string:
+		e.Data = []byte(v)
+	default:
+		if !isJSON(contentType) {
+			return fmt.Errorf("cloudevents: cannot encode %T as %s", v, contentType)
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("failed to encode data: %w", err)
+		}
+		e.Data = data
+	}
+	e.DataContentType = contentType
+	return nil
+}
+
+// DataAs decodes a JSON payload into v.
+func (e Event) DataAs(v any) error {
+	if e.DataContentType != "" && !isJSON(e.DataContentType) {
+		return fmt.Errorf("cloudevents: cannot decode %s data as JSON", e.DataContentType)
+	}
+	if err := json.Unmarshal(e.Data, v); err != nil {
+		return fmt.Errorf("failed to decode data: %w", err)
+	}
+	return nil
+}
+
+// Validate checks the required attributes and extension names.
+func (e Event) Validate() error {
+	var errs []error
+	for name, value := range map[string]string{"id": e.ID, "source": e.Source, "type": e.Type} {
+		if value == "" {
+			errs = append(errs, fmt.Errorf("cloudevents: missing %s", name))
+		}
+	}
+	if e.SpecVersion != SpecVersion {
+		errs = append(errs, fmt.Errorf("cloudevents: unsupported specversion %q", e.SpecVersion))
+	}
+	for name := range e.Extensions {
+		if !validName(name) || isContextAttribute(name) {
+			errs = append(errs, fmt.Errorf("cloudevents: invalid extension name %q", name))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// MarshalJSON encodes the event in the structured mode JSON format.
+func (e Event) MarshalJSON() ([]byte, error) {
+	m := make(map[string]any, len(e.Extensions)+8)
+	for name, value := range e.Extensions {
+		m[name] = value
+	}
+	m["specversion"] = e.SpecVersion
+	m["id"] = e.ID
+	m["source"] = e.Source
+	m["type"] = e.Type
+	setString(m, "datacontenttype", e.DataContentType)
+	setString(m, "dataschema", e.DataSchema)
+	setString(m, "subject", e.Subject)
+	if !e.Time.IsZero() {
+		m["time"] = e.Time.UTC().Format(time.RFC3339Nano)
+	}
+
+	switch {
+	case e.Data == nil:
+	case (e.DataContentType == "" || isJSON(e.DataContentType)) && json.Valid(e.Data):
+		m["data"] = json.RawMessage(e.Data)
+	case strings.HasPrefix(e.DataContentType, "text/"):
+		m["data"] = string(e.Data)
+	default:
+		m["data_base64"] = base64.StdEncoding.EncodeToString(e.Data)
+	}
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON decodes an event in the structured mode JSON format.
+func (e *Event) UnmarshalJSON(b []byte) error {
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(b, &m); err != nil {
+		return fmt.Errorf("failed to decode event: %w", err)
+	}
+
+	*e = Event{}
+	for name, raw := range m {
+		var err error
+		switch name {
+		case "specversion":
+			err = json.Unmarshal(raw, &e.SpecVersion)
+		case "id":
+			err = json.Unmarshal(raw, &e.ID)
+		case "source":
+			err = json.Unmarshal(raw, &e.Source)
+		case "type":
+			err = json.Unmarshal(raw, &e.Type)
+		case "datacontenttype":
+			err = json.Unmarshal(raw, &e.DataContentType)
+		case "dataschema":
+			err = json.Unmarshal(raw, &e.DataSchema)
+		case "subject":
+			err = json.Unmarshal(raw, &e.Subject)
+		case "time":
+			var s string
+			if err = json.Unmarshal(raw, &s); err == nil {
+				e.Time, err = time.Parse(time.RFC3339Nano, s)
+			}
+		case "data", "data_base64":
+			// decoded below, once datacontenttype is known
+		default:
+			var value any
+			decoder := json.NewDecoder(bytes.NewReader(raw))
+			decoder.UseNumber()
+			if err = decoder.Decode(&value); err == nil {
+				if e.Extensions == nil {
+					e.Extensions = map[string]any{}
+				}
+				e.Extensions[name] = value
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("failed to decode %s: %w", name, err)
+		}
+	}
+
+	if raw, ok := m["data_base64"]; ok {
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return fmt.Errorf("failed to decode data_base64: %w", err)
+		}
+		data, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return fmt.Errorf("failed to decode data_base64: %w", err)
+		}
+		e.Data = data
+	} else if raw, ok := m["data"]; ok {
+		var s string
+		jsonData := e.DataContentType == "" || isJSON(e.DataContentType)
+		if !jsonData && json.Unmarshal(raw, &s) == nil {
+			e.Data = []byte(s)
+		} else {
+			e.Data = []byte(raw)
+		}
+	}
+	return nil
+}
+
+func setString(m map[string]any, name, value string) {
+	if value != "" {
+		m[name] = value
+	}
+}
+
+// isJSON reports whether the media type is JSON, including "+json" suffixes.
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || mediaType == "text/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// validName reports whether name is a valid attribute name, lowercase ASCII
+// letters and digits.
+func validName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, c := range name {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+func isContextAttribute(name string) bool {
+	switch name {
+	case "specversion", "id", "source", "type", "datacontenttype", "dataschema", "subject", "time", "data":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,137 @@
+package cloudevents
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"go.wasmcloud.dev/component/time/wasiclock"
+)
+
+func testEvent() Event {
+	return Event{
+		ID:          "A234-1234-1234",
+		Source:      "https://github.com/cloudevents/spec/pull",
+		SpecVersion: SpecVersion,
+		Type:        "com.github.pull_request.opened",
+		Subject:     "123",
+		Time:        time.Date(2018, 4, 5, 17, 31, 0, 0, time.UTC),
+	}
+}
+
+func TestEventJSON(t *testing.T) {
+	tests := map[string]struct {
+		contentType string
+		data        []byte
+		extensions  map[string]any
+		key         string
+	}{
+		"json":         {contentType: "application/json", data: []byte(`{"a":1}`), key: "data"},
+		"json suffix":  {contentType: "application/vnd.api+json", data: []byte(`[1,2]`), key: "data"},
+		"default":      {data: []byte(`"text"`), key: "data"},
+		"text":         {contentType: "text/plain", data: []byte("hello"), key: "data"},
+		"binary":       {contentType: "application/octet-stream", data: []byte{0, 1, 2}, key: "data_base64"},
+		"invalid json": {contentType: "application/json", data: []byte("{"), key: "data_base64"},
+		"no data":      {},
+		"extensions":   {extensions: map[string]any{"comexampleext": "value", "traceparent": "00-abc"}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			e := testEvent()
+			e.DataContentType = tt.contentType
+			e.Data = tt.data
+			e.Extensions = tt.extensions
+
+			b, err := json.Marshal(e)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var fields map[string]any
+			if err := json.Unmarshal(b, &fields); err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := fields[tt.key]; tt.key != "" && !ok {
+				t.Errorf("expected %q in %s", tt.key, b)
+			}
+
+			var got Event
+			if err := json.Unmarshal(b, &got); err != nil {
+				t.Fatal(err)
+			}
+			if err := got.Validate(); err != nil {
+				t.Fatal(err)
+			}
+			if string(got.Data) != string(tt.data) {
+				t.Errorf("expected: %q, got: %q", tt.data, got.Data)
+			}
+			if !got.Time.Equal(e.Time) || got.ID != e.ID || got.Subject != e.Subject || got.DataContentType != e.DataContentType {
+				t.Errorf("expected: %+v, got: %+v", e, got)
+			}
+			for k, v := range tt.extensions {
+				if got.Extensions[k] != v {
+					t.Errorf("expected %s: %v, got: %v", k, v, got.Extensions[k])
+				}
+			}
+		})
+	}
+}
+
+func TestEventValidate(t *testing.T) {
+	tests := map[string]struct {
+		modify func(*Event)
+		err    bool
+	}{
+		"valid":          {modify: func(*Event) {}},
+		"missing id":     {modify: func(e *Event) { e.ID = "" }, err: true},
+		"missing source": {modify: func(e *Event) { e.Source = "" }, err: true},
+		"missing type":   {modify: func(e *Event) { e.Type = "" }, err: true},
+		"specversion":    {modify: func(e *Event) { e.SpecVersion = "0.3" }, err: true},
+		"extension case": {modify: func(e *Event) { e.Extensions = map[string]any{"Upper": "x"} }, err: true},
+		"extension name": {modify: func(e *Event) { e.Extensions = map[string]any{"subject": "x"} }, err: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			e := testEvent()
+			tt.modify(&e)
+			if err := e.Validate(); (err != nil) != tt.err {
+				t.Errorf("expected error: %v, got: %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestEventData(t *testing.T) {
+	prev := wasiclock.NowFunc
+	wasiclock.NowFunc = func() time.Time { return time.Unix(1700000000, 0) }
+	t.Cleanup(func() { wasiclock.NowFunc = prev })
+
+	e := New("/orders", "order.created")
+	if e.ID == "" || e.SpecVersion != SpecVersion || !e.Time.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("expected populated event, got: %+v", e)
+	}
+
+	type order struct {
+		ID int `json:"id"`
+	}
+	if err := e.SetData("", order{ID: 7}); err != nil {
+		t.Fatal(err)
+	}
+	if e.DataContentType != "application/json" {
+		t.Errorf("expected: %v, got: %v", "application/json", e.DataContentType)
+	}
+
+	var got order
+	if err := e.DataAs(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != 7 {
+		t.Errorf("expected: %v, got: %v", 7, got.ID)
+	}
+
+	if err := e.SetData("text/plain", order{}); err == nil {
+		t.Error("expected error encoding a struct as text/plain")
+	}
+}
@@ -0,0 +1,241 @@
+package cloudevents
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Mode selects how an event is carried by a HTTP message.
+type Mode int
+
+const (
+	// Structured carries the whole event as an "application/cloudevents+json" body.
+	Structured Mode = iota
+	// Binary carries the context attributes as "ce-" headers and the data as the body.
+	Binary
+)
+
+// headerPrefix prefixes binary mode attribute headers.
+const headerPrefix = "Ce-"
+
+// MaxBodySize bounds the request bodies read by [FromRequest].
+const MaxBodySize = 4 << 20
+
+// ErrNotEvent is returned when decoding a HTTP message carrying no event.
+var ErrNotEvent = errors.New("cloudevents: not a CloudEvent")
+
+// WriteHTTP encodes e into h using mode and returns the body to send.
+func WriteHTTP(h http.Header, e Event, mode Mode) ([]byte, error) {
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+
+	if mode == Structured {
+		body, err := json.Marshal(e)
+		if err != nil {
+			return nil, err
+		}
+		h.Set("Content-Type", ContentType)
+		return body, nil
+	}
+
+	h.Set(headerPrefix+"Specversion", e.SpecVersion)
+	h.Set(headerPrefix+"Id", encodeHeader(e.ID))
+	h.Set(headerPrefix+"Source", encodeHeader(e.Source))
+	h.Set(headerPrefix+"Type", encodeHeader(e.Type))
+	if e.DataSchema != "" {
+		h.Set(headerPrefix+"Dataschema", encodeHeader(e.DataSchema))
+	}
+	if e.Subject != "" {
+		h.Set(headerPrefix+"Subject", encodeHeader(e.Subject))
+	}
+	if !e.Time.IsZero() {
+		h.Set(headerPrefix+"Time", e.Time.UTC().Format(time.RFC3339Nano))
+	}
+	for name, value := range e.Extensions {
+		h.Set(headerPrefix+name, encodeHeader(extensionString(value)))
+	}
+	if e.DataContentType != "" {
+		h.Set("Content-Type", e.DataContentType)
+	}
+	return e.Data, nil
+}
+
+// ReadHTTP decodes the event carried by h and body, detecting the mode from
+// the Content-Type. It returns [ErrNotEvent] for messages in neither mode.
+func ReadHTTP(h http.Header, body []byte) (Event, error) {
+	mediaType, _, _ := mime.ParseMediaType(h.Get("Content-Type"))
+	if mediaType == ContentType {
+		var e Event
+		if err := json.Unmarshal(body, &e); err != nil {
+			return Event{}, err
+		}
+		return e, e.Validate()
+	}
+
+	if h.Get(headerPrefix+"Specversion") == "" {
+		return Event{}, ErrNotEvent
+	}
+
+	e := Event{DataContentType: h.Get("Content-Type"), Data: body}
+	for key, values := range h {
+		name, ok := strings.CutPrefix(http.CanonicalHeaderKey(key), headerPrefix)
+		if !ok || len(values) == 0 {
+			continue
+		}
+		value, err := decodeHeader(values[0])
+		if err != nil {
+			return Event{}, fmt.Errorf("failed to decode %s: %w", key, err)
+		}
+
+		switch name = strings.ToLower(name); name {
+		case "specversion":
+			e.SpecVersion = value
+		case "id":
+			e.ID = value
+		case "source":
+			e.Source = value
+		case "type":
+			e.Type = value
+		case "dataschema":
+			e.DataSchema = value
+		case "subject":
+			e.Subject = value
+		case "time":
+			if e.Time, err = time.Parse(time.RFC3339Nano, value); err != nil {
+				return Event{}, fmt.Errorf("failed to decode %s: %w", key, err)
+			}
+		default:
+			if e.Extensions == nil {
+				e.Extensions = map[string]any{}
+			}
+			e.Extensions[name] = value
+		}
+	}
+	return e, e.Validate()
+}
+
+// NewRequest returns a POST request to url carrying e, for use with e.g. the
+// wasihttp transport.
+func NewRequest(ctx context.Context, url string, e Event, mode Mode) (*http.Request, error) {
+	h := http.Header{}
+	body, err := WriteHTTP(h, e, mode)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range h {
+		req.Header[key] = values
+	}
+	return req, nil
+}
+
+// FromRequest decodes the event carried by r, reading at most [MaxBodySize]
+// bytes of body.
+func FromRequest(r *http.Request) (Event, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, MaxBodySize+1))
+	if err != nil {
+		return Event{}, fmt.Errorf("failed to read body: %w", err)
+	}
+	if len(body) > MaxBodySize {
+		return Event{}, fmt.Errorf("cloudevents: body exceeds %d bytes", MaxBodySize)
+	}
+	return ReadHTTP(r.Header, body)
+}
+
+// WriteResponse replies with e using mode and the given status code.
+func WriteResponse(w http.ResponseWriter, status int, e Event, mode Mode) error {
+	body, err := WriteHTTP(w.Header(), e, mode)
+	if err != nil {
+		return err
+	}
+	w.WriteHeader(status)
+	_, err = w.Write(body)
+	return err
+}
+
+// Handler returns a http.Handler decoding events from requests in either mode.
+// Requests carrying no valid event are answered with 400 Bad Request, events
+// fn fails to handle with 500 Internal Server Error and others with 202 Accepted.
+func Handler(fn func(ctx context.Context, e Event) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		e, err := FromRequest(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := fn(r.Context(), e); err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
+
+// extensionString returns the canonical string encoding of an extension value.
+func extensionString(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []byte:
+		return base64.StdEncoding.EncodeToString(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// encodeHeader percent-encodes space, '"', '%' and bytes outside of printable
+// ASCII, as required for binary mode header values.
+func encodeHeader(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c <= ' ' || c >= 0x7f || c == '"' || c == '%' {
+			fmt.Fprintf(&b, "%%%02X", c)
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+func decodeHeader(s string) (string, error) {
+	if !strings.Contains(s, "%") {
+		return s, nil
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '%' {
+			b.WriteByte(s[i])
+			continue
+		}
+		if i+2 >= len(s) {
+			return "", fmt.Errorf("invalid percent encoding in %q", s)
+		}
+		c, err := strconv.ParseUint(s[i+1:i+3], 16, 8)
+		if err != nil {
+			return "", fmt.Errorf("invalid percent encoding in %q", s)
+		}
+		b.WriteByte(byte(c))
+		i += 2
+	}
+	return b.String(), nil
+}
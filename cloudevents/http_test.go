@@ -0,0 +1,101 @@
+package cloudevents
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHTTP(t *testing.T) {
+	tests := map[string]struct {
+		mode        Mode
+		contentType string
+	}{
+		"structured": {mode: Structured, contentType: ContentType},
+		"binary":     {mode: Binary, contentType: "application/json"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			e := testEvent()
+			e.Subject = "100% \"quoted\" ünïcode"
+			e.Extensions = map[string]any{"traceparent": "00-abc-01"}
+			if err := e.SetData("application/json", map[string]int{"n": 1}); err != nil {
+				t.Fatal(err)
+			}
+
+			req, err := NewRequest(context.Background(), "http://example.com/events", e, tt.mode)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := req.Header.Get("Content-Type"); got != tt.contentType {
+				t.Errorf("expected: %v, got: %v", tt.contentType, got)
+			}
+			if want := "100%25%20%22quoted%22%20%C3%BCn%C3%AFcode"; tt.mode == Binary && req.Header.Get("Ce-Subject") != want {
+				t.Errorf("expected: %v, got: %v", want, req.Header.Get("Ce-Subject"))
+			}
+
+			got, err := FromRequest(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Subject != e.Subject || got.ID != e.ID || string(got.Data) != string(e.Data) || got.Extensions["traceparent"] != "00-abc-01" {
+				t.Errorf("expected: %+v, got: %+v", e, got)
+			}
+		})
+	}
+}
+
+func TestReadHTTPNotEvent(t *testing.T) {
+	h := http.Header{"Content-Type": {"application/json"}}
+	if _, err := ReadHTTP(h, []byte(`{}`)); !errors.Is(err, ErrNotEvent) {
+		t.Errorf("expected: %v, got: %v", ErrNotEvent, err)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	h := Handler(func(ctx context.Context, e Event) error {
+		if e.Type == "fail" {
+			return errors.New("failed")
+		}
+		return nil
+	})
+
+	valid := testEvent()
+	failing := testEvent()
+	failing.Type = "fail"
+
+	tests := map[string]struct {
+		event  *Event
+		status int
+	}{
+		"accepted": {event: &valid, status: http.StatusAccepted},
+		"failed":   {event: &failing, status: http.StatusInternalServerError},
+		"no event": {status: http.StatusBadRequest},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var req *http.Request
+			if tt.event != nil {
+				var err error
+				if req, err = NewRequest(context.Background(), "/", *tt.event, Binary); err != nil {
+					t.Fatal(err)
+				}
+			} else {
+				req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader("plain"))
+			}
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != tt.status {
+				body, _ := io.ReadAll(rec.Body)
+				t.Errorf("expected: %v, got: %v (%s)", tt.status, rec.Code, body)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package cloudevents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go.wasmcloud.dev/component/messaging"
+)
+
+// NOTE: `wasmcloud:messaging@0.2.0` carries no headers, events are always
+// sent in structured mode over messaging.
+
+// FromMessage decodes the structured mode event carried by msg.
+func FromMessage(msg *messaging.Message) (Event, error) {
+	var e Event
+	if err := json.Unmarshal(msg.Data, &e); err != nil {
+		return Event{}, fmt.Errorf("failed to decode event from %q: %w", msg.Subject, err)
+	}
+	return e, e.Validate()
+}
+
+// NewMessage returns a message to subject carrying e in structured mode.
+func NewMessage(subject string, e Event) (*messaging.Message, error) {
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	return &messaging.Message{Subject: subject, Data: data}, nil
+}
+
+// Publish publishes e to subject in structured mode.
+func Publish(ctx context.Context, subject string, e Event) error {
+	msg, err := NewMessage(subject, e)
+	if err != nil {
+		return err
+	}
+	return messaging.Publish(ctx, msg.Subject, msg.Data, nil)
+}
+
+// MessageHandler adapts fn to a messaging.HandlerFunc, decoding the event of
+// every message. Messages carrying no valid event are reported to the host.
+func MessageHandler(fn func(ctx context.Context, e Event) error) messaging.HandlerFunc {
+	return func(ctx context.Context, msg *messaging.Message) error {
+		e, err := FromMessage(msg)
+		if err != nil {
+			return err
+		}
+		return fn(ctx, e)
+	}
+}
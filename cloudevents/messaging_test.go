@@ -0,0 +1,37 @@
+package cloudevents
+
+import (
+	"context"
+	"testing"
+
+	"go.wasmcloud.dev/component/messaging"
+)
+
+func TestMessage(t *testing.T) {
+	e := testEvent()
+	e.Data = []byte(`{"ok":true}`)
+
+	msg, err := NewMessage("events.pull", e)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got Event
+	h := MessageHandler(func(ctx context.Context, e Event) error {
+		got = e
+		return nil
+	})
+	if err := h(context.Background(), msg); err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != e.ID || string(got.Data) != string(e.Data) {
+		t.Errorf("expected: %+v, got: %+v", e, got)
+	}
+
+	if err := h(context.Background(), &messaging.Message{Subject: "events.pull", Data: []byte("not json")}); err == nil {
+		t.Error("expected error")
+	}
+	if _, err := NewMessage("events.pull", Event{}); err == nil {
+		t.Error("expected error")
+	}
+}
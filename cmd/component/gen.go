@@ -0,0 +1,354 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	component "go.wasmcloud.dev/component"
+)
+
+// witBindgen is the wit-bindgen-go command, run at the version required by
+// the go.mod of the project.
+var witBindgen = []string{"go", "run", "github.com/bytecodealliance/wasm-tools-go/cmd/wit-bindgen-go"}
+
+const genUsage = `Usage: component gen [flags]
+
+Generates the bindings of a WIT world with wit-bindgen-go, meant to be run by
+go generate:
+
+  //go:generate go run go.wasmcloud.dev/component/cmd/component gen
+
+The SDK WIT packages in the deps directory are replaced with the ones of the
+SDK version of the project first. Generated packages of interfaces the SDK has
+bindings for are removed, and their imports point to the SDK packages instead,
+so the bindings share the types of the SDK adapters.
+
+The exports of the world not served by the SDK are registered in the init
+function of the -stubs file, which is only written if it does not exist. It is
+meant to be edited, the init functions of the SDK adapters registering the
+SDK exports run before it. -merge generates a world including the world, the
+SDK imports and the given SDK exports instead.
+
+With -invokers, the imported functions of the interfaces not provided by the
+SDK are registered with the wrpc package in the -invokers file, rewritten on
+every run, so they are called with wrpc.Invoke.
+
+Unless -shims=false, the bindings are rewritten by "component shims" to build
+with the Go wasip1 toolchain as well.
+
+Flags:
+`
+
+func runGen(args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("gen", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), genUsage)
+		flags.PrintDefaults()
+	}
+	wit := flags.String("wit", "wit", "WIT directory")
+	world := flags.String("world", "", "WIT world, it defaults to the only world of the WIT directory")
+	out := flags.String("out", "gen", "output directory")
+	sync := flags.Bool("sync", true, "replace the SDK WIT packages in the deps directory")
+	merge := flags.String("merge", "", "comma separated SDK exports, http or messaging, merged with the world and the SDK imports into a \"<world>-merged\" world")
+	invokers := flags.String("invokers", "", "file of the wrpc invokers of the imported functions not provided by the SDK, rewritten on every run")
+	shims := flags.Bool("shims", true, "rewrite the bindings to build with the Go wasip1 toolchain, see `component shims -h`")
+	stubs := flags.String("stubs", "exports.go", "file of the registration stubs of the exports not served by the SDK, written if missing")
+	if len(args) > 0 && isHelpFlag(args[0]) {
+		flags.SetOutput(stdout)
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 0 {
+		flags.Usage()
+		return errUsage
+	}
+
+	if *sync {
+		if err := syncSDKWIT(filepath.Join(*wit, "deps")); err != nil {
+			return fmt.Errorf("failed to sync the SDK WIT: %w", err)
+		}
+	}
+
+	if *world == "" {
+		var err error
+		if *world, err = findWorld(*wit); err != nil {
+			return err
+		}
+	}
+
+	if *merge != "" {
+		var err error
+		if *world, err = mergeWorld(*wit, *world, strings.Split(*merge, ",")); err != nil {
+			return err
+		}
+	}
+
+	root, err := packageRoot(*out)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command(witBindgen[0], append(witBindgen[1:], "generate", "--world", *world, "--out", *out, "--package-root", root, *wit)...)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to run wit-bindgen-go: %w", err)
+	}
+
+	sdk, err := sdkPackages()
+	if err != nil {
+		return err
+	}
+	if err := useSDKPackages(*out, root, sdk); err != nil {
+		return err
+	}
+
+	if *invokers != "" {
+		im := imports{}
+		fns, err := findImports(*out, root, im)
+		if err != nil {
+			return err
+		}
+		if err := writeInvokers(*invokers, packageName(filepath.Dir(*invokers)), fns, im); err != nil {
+			return err
+		}
+	}
+
+	if *shims {
+		if _, err := writeShims(*out); err != nil {
+			return fmt.Errorf("failed to write the shims: %w", err)
+		}
+	}
+
+	if *stubs == "" {
+		return nil
+	}
+	im := imports{}
+	exports, err := findExports(*out, root, im)
+	if err != nil {
+		return err
+	}
+	written, err := writeStubs(*stubs, packageName(filepath.Dir(*stubs)), exports, im)
+	if written {
+		fmt.Fprintf(stdout, "wrote the registration stubs of %d exports to %s\n", len(exports), *stubs)
+	}
+	return err
+}
+
+// syncSDKWIT replaces the SDK WIT packages in deps with the ones of the SDK,
+// other packages are left as they are.
+func syncSDKWIT(deps string) error {
+	entries, err := fs.ReadDir(component.Wit, "wit/deps")
+	if err != nil {
+		return err
+	}
+	names := []string{"component"}
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	for _, name := range names {
+		if err := os.RemoveAll(filepath.Join(deps, name)); err != nil {
+			return err
+		}
+	}
+	return copySDKWIT(deps)
+}
+
+var worldPattern = regexp.MustCompile(`(?m)^\s*world\s+([a-z][a-z0-9-]*)\s*\{`)
+
+// findWorld returns the only world declared in the WIT files of dir, besides
+// the merged one.
+func findWorld(dir string) (string, error) {
+	names, err := filepath.Glob(filepath.Join(dir, "*.wit"))
+	if err != nil {
+		return "", err
+	}
+
+	var worlds []string
+	for _, name := range names {
+		if filepath.Base(name) == mergedFile {
+			continue
+		}
+		buf, err := os.ReadFile(name)
+		if err != nil {
+			return "", err
+		}
+		for _, m := range worldPattern.FindAllSubmatch(buf, -1) {
+			worlds = append(worlds, string(m[1]))
+		}
+	}
+	if len(worlds) != 1 {
+		return "", fmt.Errorf("found %d worlds in %s, set -world", len(worlds), dir)
+	}
+	return worlds[0], nil
+}
+
+// packageRoot returns the Go import path of the out directory.
+func packageRoot(out string) (string, error) {
+	abs, err := filepath.Abs(out)
+	if err != nil {
+		return "", err
+	}
+
+	for dir := filepath.Dir(abs); ; dir = filepath.Dir(dir) {
+		buf, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+		if err == nil {
+			module, err := modulePath(buf)
+			if err != nil {
+				return "", err
+			}
+			rel, err := filepath.Rel(dir, abs)
+			if err != nil {
+				return "", err
+			}
+			return path.Join(module, filepath.ToSlash(rel)), nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+		if filepath.Dir(dir) == dir {
+			return "", fmt.Errorf("%s is not in a Go module", out)
+		}
+	}
+}
+
+func modulePath(gomod []byte) (string, error) {
+	s := bufio.NewScanner(bytes.NewReader(gomod))
+	for s.Scan() {
+		if rest, ok := strings.CutPrefix(strings.TrimSpace(s.Text()), "module"); ok {
+			if module, err := strconv.Unquote(strings.TrimSpace(rest)); err == nil {
+				return module, nil
+			}
+			return strings.TrimSpace(rest), nil
+		}
+	}
+	return "", errors.New("go.mod has no module directive")
+}
+
+// sdkPackages returns the packages of the SDK bindings, relative to the SDK
+// gen directory, at the SDK version of the project.
+func sdkPackages() (map[string]bool, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("go", "list", "-f", "{{.ImportPath}}", sdkModule+"/gen/...")
+	cmd.Stderr = &stderr
+	buf, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the SDK bindings: %w: %s", err, stderr.Bytes())
+	}
+
+	pkgs := map[string]bool{}
+	for _, pkg := range strings.Fields(string(buf)) {
+		pkgs[strings.TrimPrefix(pkg, sdkModule+"/gen/")] = true
+	}
+	return pkgs, nil
+}
+
+// useSDKPackages removes the generated packages of out the SDK has bindings
+// for, and rewrites their imports to the SDK packages.
+func useSDKPackages(out, root string, sdk map[string]bool) error {
+	var files []string
+	replaced := map[string]string{}
+	err := filepath.WalkDir(out, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(out, name)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		switch {
+		case d.IsDir() && sdk[rel]:
+			replaced[path.Join(root, rel)] = path.Join(sdkModule, "gen", rel)
+			if err := os.RemoveAll(name); err != nil {
+				return err
+			}
+			return fs.SkipDir
+		case !d.IsDir() && strings.HasSuffix(name, ".go"):
+			files = append(files, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, name := range files {
+		if err := rewriteImports(name, replaced); err != nil {
+			return err
+		}
+	}
+	return removeEmptyDirs(out)
+}
+
+func rewriteImports(name string, replaced map[string]string) error {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, name, nil, parser.ParseComments)
+	if err != nil {
+		return err
+	}
+
+	changed := false
+	for _, spec := range f.Imports {
+		pkg, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return err
+		}
+		if sdkPkg, ok := replaced[pkg]; ok {
+			spec.Path.Value = strconv.Quote(sdkPkg)
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, f); err != nil {
+		return err
+	}
+	return os.WriteFile(name, buf.Bytes(), 0o644)
+}
+
+// removeEmptyDirs removes the directories of root left empty, e.g. by the
+// removal of all interfaces of a WIT package.
+func removeEmptyDirs(root string) error {
+	var dirs []string
+	err := filepath.WalkDir(root, func(name string, d fs.DirEntry, err error) error {
+		if err == nil && d.IsDir() && name != root {
+			dirs = append(dirs, name)
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	// NOTE: children are walked after their parents
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if entries, err := os.ReadDir(dirs[i]); err == nil && len(entries) == 0 {
+			if err := os.Remove(dirs[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		if err := writeFile(filepath.Join(dir, name), []byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestUseSDKPackages(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"wasi/io/streams/streams.wit.go": "package streams\n",
+		"wasi/io/poll/poll.wit.go":       "package poll\n",
+		"example/app/greeter/greeter.wit.go": `package greeter
+
+import (
+	// streams are shared with the SDK
+	"example.com/app/gen/wasi/io/streams"
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+)
+
+var _ cm.List[uint8]
+var _ streams.InputStream
+`,
+	})
+
+	if err := useSDKPackages(dir, "example.com/app/gen", map[string]bool{"wasi/io/streams": true, "wasi/io/poll": true}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "wasi")); !os.IsNotExist(err) {
+		t.Errorf("expected the SDK packages to be removed, got: %v", err)
+	}
+	buf, err := os.ReadFile(filepath.Join(dir, "example/app/greeter/greeter.wit.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"go.wasmcloud.dev/component/gen/wasi/io/streams"`, "// streams are shared with the SDK"} {
+		if !strings.Contains(string(buf), want) {
+			t.Errorf("expected %s in:\n%s", want, buf)
+		}
+	}
+}
+
+func TestSyncSDKWIT(t *testing.T) {
+	deps := t.TempDir()
+	writeFiles(t, deps, map[string]string{
+		"http/stale.wit":    "package wasi:http@0.1.0;\n",
+		"custom/custom.wit": "package my:custom;\n",
+	})
+
+	if err := syncSDKWIT(deps); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, want := range map[string]bool{
+		"http/stale.wit":      false,
+		"http/types.wit":      true,
+		"component/world.wit": true,
+		"custom/custom.wit":   true,
+		"keyvalue/store.wit":  true,
+	} {
+		if _, err := os.Stat(filepath.Join(deps, name)); (err == nil) != want {
+			t.Errorf("%s: expected to exist: %v, got: %v", name, want, err)
+		}
+	}
+}
+
+func TestFindWorld(t *testing.T) {
+	tests := map[string]struct {
+		files map[string]string
+		want  string
+	}{
+		"single": {files: map[string]string{"world.wit": "package a:b;\n\nworld app {\n  export run;\n}\n"}, want: "app"},
+		"none":   {files: map[string]string{"types.wit": "package a:b;\n\ninterface types {}\n"}},
+		"many":   {files: map[string]string{"a.wit": "world a {}\n", "b.wit": "world b {}\n"}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tt.files)
+
+			got, err := findWorld(dir)
+			if (err == nil) != (tt.want != "") || got != tt.want {
+				t.Errorf("expected: %q, got: %q %v", tt.want, got, err)
+			}
+		})
+	}
+}
+
+func TestPackageRoot(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"go.mod": "module \"example.com/app\"\n\ngo 1.22.5\n"})
+
+	got, err := packageRoot(filepath.Join(dir, "internal", "gen"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "example.com/app/internal/gen"; got != want {
+		t.Errorf("expected: %v, got: %v", want, got)
+	}
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// maxInvokerParams is the number of parameters of the wrpc.Func and
+// wrpc.Proc adapters.
+const maxInvokerParams = 3
+
+var (
+	importedInterfacePattern = regexp.MustCompile(`represents the imported interface "([^"]+)"`)
+	importedFunctionPattern  = regexp.MustCompile(`represents the imported function "([^"]+)"`)
+)
+
+// imported is an imported function of the generated bindings.
+type imported struct {
+	// instance is the imported interface, e.g.
+	// "wasmcloud:example/greeter@0.1.0".
+	instance string
+	// fn is the WIT name of the function, e.g. "greet".
+	fn string
+	// pkg is the import path of the bindings.
+	pkg string
+	// name is the Go name of the function.
+	name    string
+	params  int
+	results int
+}
+
+// findImports returns the imported functions of the interfaces of the
+// bindings in out, whose import path is root. Resource methods are not
+// functions of the interface, they are not returned.
+func findImports(out, root string, im imports) ([]imported, error) {
+	var fns []imported
+	err := filepath.WalkDir(out, func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(name, ".wit.go") {
+			return err
+		}
+		rel, err := filepath.Rel(out, filepath.Dir(name))
+		if err != nil {
+			return err
+		}
+
+		f, err := parser.ParseFile(token.NewFileSet(), name, nil, parser.ParseComments)
+		if err != nil {
+			return err
+		}
+		m := importedInterfacePattern.FindStringSubmatch(f.Doc.Text())
+		if m == nil {
+			return nil
+		}
+		instance := m[1]
+
+		pkg := path.Join(root, filepath.ToSlash(rel))
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil || !fn.Name.IsExported() {
+				continue
+			}
+			m := importedFunctionPattern.FindStringSubmatch(fn.Doc.Text())
+			if m == nil {
+				continue
+			}
+			im.alias(pkg, f.Name.Name)
+			fns = append(fns, imported{
+				instance: instance,
+				fn:       m[1],
+				pkg:      pkg,
+				name:     fn.Name.Name,
+				params:   fieldCount(fn.Type.Params),
+				results:  fieldCount(fn.Type.Results),
+			})
+		}
+		return nil
+	})
+	slices.SortFunc(fns, func(a, b imported) int {
+		return strings.Compare(a.instance+"#"+a.fn, b.instance+"#"+b.fn)
+	})
+	return fns, err
+}
+
+func fieldCount(fields *ast.FieldList) int {
+	if fields == nil {
+		return 0
+	}
+	n := 0
+	for _, field := range fields.List {
+		n += max(len(field.Names), 1)
+	}
+	return n
+}
+
+// writeInvokers writes the registration of the wrpc invokers of fns to name,
+// in package pkg. Unlike the stubs, the file is generated and rewritten.
+func writeInvokers(name, pkg string, fns []imported, im imports) error {
+	const wrpcPkg = sdkModule + "/wasmcloud/wrpc"
+	registry := im.alias(wrpcPkg, "wrpc")
+
+	var registered, skipped []string
+	used := map[string]bool{}
+	for _, fn := range fns {
+		adapter := "Func"
+		switch {
+		case fn.results == 0:
+			adapter = "Proc"
+		case fn.results > 1:
+			skipped = append(skipped, fmt.Sprintf("%s#%s has %d results", fn.instance, fn.fn, fn.results))
+			continue
+		}
+		if fn.params > maxInvokerParams {
+			skipped = append(skipped, fmt.Sprintf("%s#%s has %d parameters", fn.instance, fn.fn, fn.params))
+			continue
+		}
+		used[wrpcPkg], used[fn.pkg] = true, true
+		registered = append(registered, fmt.Sprintf("%s.Register(%q, %q, %s.%s%d(%s.%s))", registry, fn.instance, fn.fn, registry, adapter, fn.params, im[fn.pkg], fn.name))
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by component gen. DO NOT EDIT.\n\npackage %s\n", pkg)
+	if len(used) > 0 {
+		b.WriteString("\nimport (\n")
+		pkgs := make([]string, 0, len(used))
+		for p := range used {
+			pkgs = append(pkgs, p)
+		}
+		slices.Sort(pkgs)
+		for _, p := range pkgs {
+			fmt.Fprintf(&b, "\t%s %q\n", im[p], p)
+		}
+		b.WriteString(")\n")
+	}
+
+	for _, s := range skipped {
+		fmt.Fprintf(&b, "\n// NOTE: %s, it has no wrpc invoker\n", s)
+	}
+	if len(registered) > 0 {
+		b.WriteString("\nfunc init() {\n")
+		for _, s := range registered {
+			fmt.Fprintf(&b, "\t%s\n", s)
+		}
+		b.WriteString("}\n")
+	}
+
+	buf, err := format.Source(b.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to format invokers: %w", err)
+	}
+	return os.WriteFile(name, buf, 0o644)
+}
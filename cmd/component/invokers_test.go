@@ -0,0 +1,67 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteInvokers(t *testing.T) {
+	out := t.TempDir()
+	writeFiles(t, out, map[string]string{
+		"example/greeter/greeter/greeter.wit.go": `// Code generated by wit-bindgen-go. DO NOT EDIT.
+
+// Package greeter represents the imported interface "example:greeter/greeter@0.1.0".
+package greeter
+
+// Counter represents the imported resource "example:greeter/greeter@0.1.0#counter".
+type Counter uint32
+
+// Get represents the imported method "get".
+func (self Counter) Get() (result uint32) { return }
+
+// Greet represents the imported function "greet".
+func Greet(name string) (result string) { return }
+
+// Notify represents the imported function "notify".
+func Notify(a, b string) {}
+
+// Wide represents the imported function "wide".
+func Wide(a, b, c, d uint32) (result uint32) { return }
+
+func wasmimport_Greet(name0 *uint8, name1 uint32, result *string)
+`,
+		"example/greeter/types/types.wit.go": "// Package types represents the imported interface \"example:greeter/types@0.1.0\".\npackage types\n\ntype Name string\n",
+	})
+
+	im := imports{}
+	fns, err := findImports(out, "example.com/app/gen", im)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fns) != 3 {
+		t.Fatalf("expected: %v, got: %v", 3, len(fns))
+	}
+
+	name := filepath.Join(t.TempDir(), "invokers.go")
+	if err := writeInvokers(name, "main", fns, im); err != nil {
+		t.Fatal(err)
+	}
+	buf, _ := os.ReadFile(name)
+	for _, want := range []string{
+		"// Code generated by component gen. DO NOT EDIT.",
+		`greeter "example.com/app/gen/example/greeter/greeter"`,
+		`wrpc "go.wasmcloud.dev/component/wasmcloud/wrpc"`,
+		`wrpc.Register("example:greeter/greeter@0.1.0", "greet", wrpc.Func1(greeter.Greet))`,
+		`wrpc.Register("example:greeter/greeter@0.1.0", "notify", wrpc.Proc2(greeter.Notify))`,
+		"// NOTE: example:greeter/greeter@0.1.0#wide has 4 parameters, it has no wrpc invoker",
+	} {
+		if !strings.Contains(string(buf), want) {
+			t.Errorf("expected %q in:\n%s", want, buf)
+		}
+	}
+	if strings.Contains(string(buf), "types") {
+		t.Errorf("expected the packages without functions not to be imported, got:\n%s", buf)
+	}
+}
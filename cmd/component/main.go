@@ -0,0 +1,56 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+const usage = `Usage: component <command> [arguments]
+
+Commands:
+  new   scaffold a component project
+  gen   generate the bindings of a WIT world
+  shims rewrite bindings to build with the Go wasip1 toolchain
+
+Run "component <command> -h" for the flags of a command.
+`
+
+func main() {
+	err := run(os.Args[1:], os.Stdout, os.Stderr)
+	switch {
+	case err == nil:
+	case errors.Is(err, flag.ErrHelp):
+	case errors.Is(err, errUsage):
+		os.Exit(2)
+	default:
+		fmt.Fprintf(os.Stderr, "component: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// errUsage is returned for invalid command lines, after printing the usage.
+var errUsage = errors.New("invalid usage")
+
+func run(args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return errUsage
+	}
+
+	switch args[0] {
+	case "new":
+		return runNew(args[1:], stdout, stderr)
+	case "gen":
+		return runGen(args[1:], stdout, stderr)
+	case "shims":
+		return runShims(args[1:], stdout, stderr)
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(stdout, usage)
+		return nil
+	}
+	fmt.Fprintf(stderr, "component: unknown command %q\n\n%s", args[0], usage)
+	return errUsage
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"errors"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	for name, k := range kinds {
+		t.Run(name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "my-"+name)
+			if err := run([]string{"new", "-generate=false", "-sdk", "v0.1.0", name, dir}, io.Discard, io.Discard); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, file := range []string{"go.mod", "tools.go", "Makefile", "wasmcloud.toml", ".gitignore", "wit/deps/component/world.wit", "wit/deps/http/types.wit"} {
+				if _, err := os.Stat(filepath.Join(dir, file)); err != nil {
+					t.Errorf("expected %s: %s", file, err)
+				}
+			}
+			if _, err := parser.ParseFile(token.NewFileSet(), filepath.Join(dir, "main.go"), nil, 0); err != nil {
+				t.Errorf("expected main.go to parse: %s", err)
+			}
+
+			world, _ := os.ReadFile(filepath.Join(dir, "wit", "world.wit"))
+			for _, want := range []string{"package example:my-" + name + ";", "world my-" + name + " {", "export " + k.export + ";"} {
+				if !strings.Contains(string(world), want) {
+					t.Errorf("expected %q in world, got: %s", want, world)
+				}
+			}
+			mod, _ := os.ReadFile(filepath.Join(dir, "go.mod"))
+			if want := "go.wasmcloud.dev/component v0.1.0"; !strings.Contains(string(mod), want) {
+				t.Errorf("expected %q in go.mod, got: %s", want, mod)
+			}
+		})
+	}
+}
+
+func TestNewReplace(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "app")
+	if err := run([]string{"new", "-generate=false", "-sdk", "../..", "-module", "example.com/app", "http", dir}, io.Discard, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+
+	mod, _ := os.ReadFile(filepath.Join(dir, "go.mod"))
+	sdk, _ := filepath.Abs("../..")
+	for _, want := range []string{"module example.com/app\n", "replace go.wasmcloud.dev/component => " + sdk + "\n"} {
+		if !strings.Contains(string(mod), want) {
+			t.Errorf("expected %q in go.mod, got: %s", want, mod)
+		}
+	}
+}
+
+func TestNewInvalid(t *testing.T) {
+	nonEmpty := t.TempDir()
+	os.WriteFile(filepath.Join(nonEmpty, "main.go"), nil, 0o644)
+
+	tests := map[string]struct {
+		args  []string
+		usage bool
+	}{
+		"no command":   {args: nil, usage: true},
+		"unknown":      {args: []string{"old"}, usage: true},
+		"missing dir":  {args: []string{"new", "http"}, usage: true},
+		"unknown kind": {args: []string{"new", "ftp", t.TempDir()}, usage: true},
+		"not empty":    {args: []string{"new", "-sdk", "v0.1.0", "http", nonEmpty}},
+		"no version":   {args: []string{"new", "http", filepath.Join(t.TempDir(), "app")}},
+		"bad name":     {args: []string{"new", "-sdk", "v0.1.0", "http", filepath.Join(t.TempDir(), "1st")}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := run(tt.args, io.Discard, io.Discard)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if errors.Is(err, errUsage) != tt.usage {
+				t.Errorf("expected usage error: %v, got: %v", tt.usage, err)
+			}
+		})
+	}
+}
+
+func TestWITName(t *testing.T) {
+	tests := map[string]string{
+		"hello":       "hello",
+		"Hello_World": "hello-world",
+		"my.app-v2":   "my-app-v2",
+		"app-2":       "",
+		"1st":         "",
+		"---":         "",
+	}
+
+	for s, want := range tests {
+		if got := witName(s); got != want {
+			t.Errorf("%s: expected: %q, got: %q", s, want, got)
+		}
+	}
+}
@@ -0,0 +1,274 @@
+package main
+
+import (
+	"embed"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"text/template"
+
+	component "go.wasmcloud.dev/component"
+)
+
+// witBindgenVersion is the wasm-tools-go version required by scaffolded
+// projects.
+//
+// NOTE: it must match the go.mod of the SDK, so the generated bindings match
+// the ones of the SDK.
+const witBindgenVersion = "v0.2.0"
+
+const sdkModule = "go.wasmcloud.dev/component"
+
+//go:embed templates
+var templates embed.FS
+
+// kind is a kind of component scaffolded by `component new`.
+type kind struct {
+	// export is the interface exported by the world.
+	export string
+	// reactor reports whether the component is called through its exports
+	// instead of running `wasi:cli/run`.
+	reactor bool
+}
+
+var kinds = map[string]kind{
+	"http":      {export: "wasi:http/incoming-handler@0.2.0", reactor: true},
+	"cli":       {export: "wasi:cli/run@0.2.0"},
+	"messaging": {export: "wasmcloud:messaging/handler@0.2.0", reactor: true},
+}
+
+// project are the template values of a scaffolded project.
+type project struct {
+	Name              string
+	Module            string
+	WITPackage        string
+	World             string
+	Export            string
+	Reactor           bool
+	Adapter           string
+	EnvPrefix         string
+	SDKVersion        string
+	SDKReplace        string
+	WitBindgenVersion string
+}
+
+// files maps the files of a project to their templates.
+var files = map[string]string{
+	"go.mod":         "common/go.mod.tmpl",
+	"tools.go":       "common/tools.go.tmpl",
+	"Makefile":       "common/Makefile.tmpl",
+	"wasmcloud.toml": "common/wasmcloud.toml.tmpl",
+	".gitignore":     "common/gitignore.tmpl",
+	"wit/world.wit":  "common/world.wit.tmpl",
+	"main.go":        "%s/main.go.tmpl",
+}
+
+const newUsage = `Usage: component new [flags] <http|cli|messaging> <directory>
+
+Scaffolds a component project in directory: the WIT world importing the SDK
+interfaces, main.go wired to the SDK adapters, a Makefile for TinyGo and Go
+builds and, unless -generate=false, the bindings of the world.
+
+Flags:
+`
+
+func runNew(args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("new", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), newUsage)
+		flags.PrintDefaults()
+	}
+	module := flags.String("module", "", "Go module path, it defaults to the project name")
+	world := flags.String("world", "", "WIT world name, it defaults to the project name")
+	witPackage := flags.String("package", "", `WIT package name, it defaults to "example:<name>"`)
+	sdk := flags.String("sdk", "", "SDK version or local directory, it defaults to the version of this tool")
+	generate := flags.Bool("generate", true, "run `go mod tidy` and `go generate` in the project")
+	if len(args) > 0 && isHelpFlag(args[0]) {
+		flags.SetOutput(stdout)
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return errUsage
+	}
+
+	k, ok := kinds[flags.Arg(0)]
+	if !ok {
+		fmt.Fprintf(stderr, "component: unknown component kind %q\n\n", flags.Arg(0))
+		flags.Usage()
+		return errUsage
+	}
+	dir := flags.Arg(1)
+
+	name := witName(filepath.Base(dir))
+	if name == "" {
+		return fmt.Errorf("cannot derive a WIT name from %q, set -world and -package", dir)
+	}
+
+	p := project{
+		Name:              strings.ReplaceAll(name, "-", "_"),
+		Module:            *module,
+		WITPackage:        *witPackage,
+		World:             *world,
+		Export:            k.export,
+		Reactor:           k.reactor,
+		Adapter:           "wasi_snapshot_preview1.command.wasm",
+		EnvPrefix:         strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_",
+		WitBindgenVersion: witBindgenVersion,
+	}
+	if p.Module == "" {
+		p.Module = name
+	}
+	if p.WITPackage == "" {
+		p.WITPackage = "example:" + name
+	}
+	if p.World == "" {
+		p.World = name
+	}
+	if k.reactor {
+		p.Adapter = "wasi_snapshot_preview1.reactor.wasm"
+	}
+
+	var err error
+	if p.SDKVersion, p.SDKReplace, err = sdkRequirement(*sdk); err != nil {
+		return err
+	}
+
+	if err := scaffold(dir, flags.Arg(0), p); err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "created %s component %s in %s\n", flags.Arg(0), p.World, dir)
+
+	if !*generate {
+		return nil
+	}
+	for _, args := range [][]string{{"mod", "tidy"}, {"generate", "./..."}} {
+		cmd := exec.Command("go", args...)
+		cmd.Dir = dir
+		cmd.Stdout = stdout
+		cmd.Stderr = stderr
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("failed to run `go %s`: %w", strings.Join(args, " "), err)
+		}
+	}
+	return nil
+}
+
+func isHelpFlag(arg string) bool {
+	switch arg {
+	case "-h", "-help", "--h", "--help":
+		return true
+	}
+	return false
+}
+
+// witName returns s as a WIT identifier, i.e. lowercase words separated by
+// dashes, or "" if it has none.
+func witName(s string) string {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < '0' || r > '9')
+	})
+	// NOTE: WIT words start with a letter
+	if len(words) == 0 || words[0][0] <= '9' {
+		return ""
+	}
+	for _, word := range words[1:] {
+		if word[0] <= '9' {
+			return ""
+		}
+	}
+	return strings.Join(words, "-")
+}
+
+// sdkRequirement returns the SDK version required by a project and the
+// replacement of the module, if sdk is a directory. An empty sdk is the
+// version of this tool.
+func sdkRequirement(sdk string) (version, replace string, err error) {
+	if sdk == "" {
+		info, ok := debug.ReadBuildInfo()
+		if !ok || info.Main.Path != sdkModule || info.Main.Version == "" || info.Main.Version == "(devel)" {
+			return "", "", errors.New("the SDK version of this build is unknown, set -sdk to a version or a local directory")
+		}
+		return info.Main.Version, "", nil
+	}
+	if strings.HasPrefix(sdk, "v") {
+		return sdk, "", nil
+	}
+
+	abs, err := filepath.Abs(sdk)
+	if err != nil {
+		return "", "", err
+	}
+	if _, err := os.Stat(filepath.Join(abs, "go.mod")); err != nil {
+		return "", "", fmt.Errorf("-sdk is neither a version nor a module directory: %w", err)
+	}
+	return "v0.0.0-00010101000000-000000000000", abs, nil
+}
+
+// scaffold writes the project files and the SDK WIT to dir, which must not
+// exist or be empty.
+func scaffold(dir, kind string, p project) error {
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) > 0 {
+		return fmt.Errorf("%s is not empty", dir)
+	}
+
+	for name, tmpl := range files {
+		if strings.Contains(tmpl, "%s") {
+			tmpl = fmt.Sprintf(tmpl, kind)
+		}
+		t, err := template.ParseFS(templates, path.Join("templates", tmpl))
+		if err != nil {
+			return err
+		}
+
+		var b strings.Builder
+		if err := t.Execute(&b, p); err != nil {
+			return fmt.Errorf("failed to render %s: %w", name, err)
+		}
+		if err := writeFile(filepath.Join(dir, name), []byte(b.String())); err != nil {
+			return err
+		}
+	}
+	return copySDKWIT(filepath.Join(dir, "wit", "deps"))
+}
+
+// copySDKWIT copies the WIT dependencies of the SDK to deps, along with the
+// `wasmcloud:component` package itself.
+func copySDKWIT(deps string) error {
+	world, err := component.Wit.ReadFile("wit/world.wit")
+	if err != nil {
+		return err
+	}
+	if err := writeFile(filepath.Join(deps, "component", "world.wit"), world); err != nil {
+		return err
+	}
+
+	return fs.WalkDir(component.Wit, "wit/deps", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		buf, err := component.Wit.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		return writeFile(filepath.Join(deps, filepath.FromSlash(strings.TrimPrefix(name, "wit/deps/"))), buf)
+	})
+}
+
+func writeFile(name string, buf []byte) error {
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(name, buf, 0o644)
+}
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const shimsUsage = `Usage: component shims [directory]
+
+Rewrites the bindings generated by wit-bindgen-go in directory, "gen" by
+default, so they build with the Go wasip1 toolchain as well as TinyGo.
+
+The go:wasmimport and go:wasmexport functions of the bindings pass pointers to
+Go types, e.g. *cm.List or *cm.Result, which only TinyGo accepts. They are
+rewritten to pass unsafe.Pointer instead, and the exports call the generated
+function through a trampoline converting the pointers back. The canonical ABI
+of the functions is unchanged, so the component is the same when adapted from
+wasip1 with the preview1 adapter.
+`
+
+func runShims(args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("shims", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), shimsUsage)
+	}
+	if len(args) > 0 && isHelpFlag(args[0]) {
+		flags.SetOutput(stdout)
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 1 {
+		flags.Usage()
+		return errUsage
+	}
+	dir := "gen"
+	if flags.NArg() == 1 {
+		dir = flags.Arg(0)
+	}
+
+	n, err := writeShims(dir)
+	if n > 0 {
+		fmt.Fprintf(stdout, "rewrote %d functions in %s\n", n, dir)
+	}
+	return err
+}
+
+// writeShims rewrites the Wasm imports and exports of the bindings in dir
+// passing pointers, and returns the number of rewritten functions.
+func writeShims(dir string) (int, error) {
+	total := 0
+	err := filepath.WalkDir(dir, func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(name, ".wit.go") {
+			return err
+		}
+		n, err := shimFile(name)
+		total += n
+		return err
+	})
+	return total, err
+}
+
+func shimFile(name string) (int, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, name, nil, parser.ParseComments)
+	if err != nil {
+		return 0, err
+	}
+
+	var decls []ast.Decl
+	imports := map[string][]int{}
+	n := 0
+	for _, decl := range f.Decls {
+		decls = append(decls, decl)
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil {
+			continue
+		}
+		switch {
+		case fn.Body == nil && hasDirective(fn, "//go:wasmimport"):
+			if pointers := shimFields(fn.Type.Params); len(pointers) > 0 || len(shimFields(fn.Type.Results)) > 0 {
+				imports[fn.Name.Name] = pointers
+				n++
+			}
+		case fn.Body != nil && hasDirective(fn, "//go:wasmexport"):
+			if trampoline := shimExport(fn); trampoline != nil {
+				decls = append(decls, trampoline)
+				n++
+			}
+		}
+	}
+	if n == 0 {
+		return 0, nil
+	}
+	f.Decls = decls
+
+	// NOTE: the import calls are rewritten after all declarations, the
+	// wrappers may precede the imports they call
+	ast.Inspect(f, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		if id, ok := call.Fun.(*ast.Ident); ok {
+			for _, i := range imports[id.Name] {
+				call.Args[i] = unsafePointer(call.Args[i])
+			}
+		}
+		return true
+	})
+	addImport(f, "unsafe")
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, f); err != nil {
+		return 0, err
+	}
+	// NOTE: the trampolines have no position, separate them from the exports
+	src, err := format.Source(bytes.ReplaceAll(buf.Bytes(), []byte("}\nfunc "), []byte("}\n\nfunc ")))
+	if err != nil {
+		return 0, err
+	}
+	return n, os.WriteFile(name, src, 0o644)
+}
+
+func hasDirective(fn *ast.FuncDecl, directive string) bool {
+	if fn.Doc == nil {
+		return false
+	}
+	for _, c := range fn.Doc.List {
+		if strings.HasPrefix(c.Text, directive+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// shimFields replaces the pointer types of fields with unsafe.Pointer and
+// returns the indexes of the replaced values.
+func shimFields(fields *ast.FieldList) []int {
+	if fields == nil {
+		return nil
+	}
+	var pointers []int
+	i := 0
+	for _, field := range fields.List {
+		names := max(len(field.Names), 1)
+		if _, ok := field.Type.(*ast.StarExpr); ok {
+			field.Type = unsafePointerType()
+			for j := range names {
+				pointers = append(pointers, i+j)
+			}
+		}
+		i += names
+	}
+	return pointers
+}
+
+// shimExport rewrites an export passing pointers to call trampoline, the
+// generated function renamed, and returns trampoline.
+func shimExport(fn *ast.FuncDecl) *ast.FuncDecl {
+	typ := &ast.FuncType{Params: copyFields(fn.Type.Params), Results: copyFields(fn.Type.Results)}
+	params, results := shimFields(typ.Params), shimFields(typ.Results)
+	if len(params) == 0 && len(results) == 0 {
+		return nil
+	}
+
+	trampoline := &ast.FuncDecl{
+		Name: ast.NewIdent(fn.Name.Name + "_trampoline"),
+		Type: fn.Type,
+		Body: fn.Body,
+	}
+
+	var args []ast.Expr
+	for _, field := range fn.Type.Params.List {
+		_, pointer := field.Type.(*ast.StarExpr)
+		for _, name := range field.Names {
+			var arg ast.Expr = ast.NewIdent(name.Name)
+			if pointer {
+				arg = &ast.CallExpr{Fun: &ast.ParenExpr{X: field.Type}, Args: []ast.Expr{arg}}
+			}
+			args = append(args, arg)
+		}
+	}
+	var call ast.Expr = &ast.CallExpr{Fun: ast.NewIdent(trampoline.Name.Name), Args: args}
+
+	var body ast.Stmt = &ast.ExprStmt{X: call}
+	if typ.Results != nil && len(typ.Results.List) > 0 {
+		// NOTE: exports return a single value, if any
+		for _, field := range typ.Results.List {
+			field.Names = nil
+		}
+		if len(results) > 0 {
+			call = unsafePointer(call)
+		}
+		body = &ast.ReturnStmt{Results: []ast.Expr{call}}
+	}
+
+	fn.Type = typ
+	fn.Body = &ast.BlockStmt{List: []ast.Stmt{body}}
+	return trampoline
+}
+
+func copyFields(fields *ast.FieldList) *ast.FieldList {
+	if fields == nil {
+		return nil
+	}
+	c := &ast.FieldList{}
+	for _, field := range fields.List {
+		f := *field
+		f.Names = append([]*ast.Ident(nil), field.Names...)
+		c.List = append(c.List, &f)
+	}
+	return c
+}
+
+func unsafePointerType() ast.Expr {
+	return &ast.SelectorExpr{X: ast.NewIdent("unsafe"), Sel: ast.NewIdent("Pointer")}
+}
+
+func unsafePointer(x ast.Expr) ast.Expr {
+	return &ast.CallExpr{Fun: unsafePointerType(), Args: []ast.Expr{x}}
+}
+
+// addImport adds the import of pkg to f, unless it is imported.
+func addImport(f *ast.File, pkg string) {
+	for _, spec := range f.Imports {
+		if path, _ := strconv.Unquote(spec.Path.Value); path == pkg {
+			return
+		}
+	}
+
+	spec := &ast.ImportSpec{Path: &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(pkg)}}
+	f.Imports = append(f.Imports, spec)
+	for _, decl := range f.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.IMPORT {
+			gen.Specs = append([]ast.Spec{spec}, gen.Specs...)
+			if !gen.Lparen.IsValid() {
+				gen.Lparen = gen.Pos()
+			}
+			return
+		}
+	}
+	// NOTE: positioned after the package clause, so the comments of the
+	// declarations stay in place
+	spec.Path.ValuePos = f.Name.End()
+	f.Decls = append([]ast.Decl{&ast.GenDecl{TokPos: f.Name.End(), Tok: token.IMPORT, Specs: []ast.Spec{spec}}}, f.Decls...)
+}
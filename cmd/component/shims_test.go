@@ -0,0 +1,87 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteShims(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"app/greeter/greeter.wit.go": `// Code generated by wit-bindgen-go. DO NOT EDIT.
+
+// Package greeter represents the interface "my:app/greeter".
+package greeter
+
+import (
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+)
+
+// Greet represents the imported function "greet".
+//
+//go:nosplit
+func Greet(name string) (result cm.Result[string, string, string]) {
+	name0, name1 := cm.LowerString(name)
+	wasmimport_Greet((*uint8)(name0), (uint32)(name1), &result)
+	return
+}
+
+//go:wasmimport my:app/greeter greet
+//go:noescape
+func wasmimport_Greet(name0 *uint8, name1 uint32, result *cm.Result[string, string, string])
+
+//go:wasmimport my:app/greeter count
+//go:noescape
+func wasmimport_Count() (result0 uint32)
+
+//go:wasmexport my:app/greeter#handle
+//export my:app/greeter#handle
+func wasmexport_Handle(msg0 *uint8, msg1 uint32) (result *cm.Result[string, struct{}, string]) {
+	result_ := Exports.Handle(cm.LiftString[string](msg0, msg1))
+	result = &result_
+	return
+}
+
+//go:wasmexport my:app/greeter#run
+//export my:app/greeter#run
+func wasmexport_Run() (result0 uint32) {
+	return
+}
+`,
+		"app/greeter/greeter.exports.go": "package greeter\n\nvar Exports struct {\n\tGreet func(s *string)\n}\n",
+	})
+
+	n, err := writeShims(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Errorf("expected: %v, got: %v", 2, n)
+	}
+
+	buf, _ := os.ReadFile(filepath.Join(dir, "app/greeter/greeter.wit.go"))
+	for _, want := range []string{
+		"\t\"unsafe\"\n",
+		"wasmimport_Greet(unsafe.Pointer((*uint8)(name0)), (uint32)(name1), unsafe.Pointer(&result))",
+		"func wasmimport_Greet(name0 unsafe.Pointer, name1 uint32, result unsafe.Pointer)\n",
+		"func wasmimport_Count() (result0 uint32)\n",
+		"//export my:app/greeter#handle\nfunc wasmexport_Handle(msg0 unsafe.Pointer, msg1 uint32) unsafe.Pointer {\n\treturn unsafe.Pointer(wasmexport_Handle_trampoline((*uint8)(msg0), msg1))\n}\n",
+		"\nfunc wasmexport_Handle_trampoline(msg0 *uint8, msg1 uint32) (result *cm.Result[string, struct{}, string]) {\n",
+		"func wasmexport_Run() (result0 uint32) {\n\treturn\n}\n",
+	} {
+		if !strings.Contains(string(buf), want) {
+			t.Errorf("expected %q in:\n%s", want, buf)
+		}
+	}
+
+	exports, _ := os.ReadFile(filepath.Join(dir, "app/greeter/greeter.exports.go"))
+	if strings.Contains(string(exports), "unsafe") {
+		t.Errorf("expected the exports file to be left as it is, got:\n%s", exports)
+	}
+
+	if n, err := writeShims(dir); err != nil || n != 0 {
+		t.Errorf("expected the shims to be written once, got: %v %v", n, err)
+	}
+}
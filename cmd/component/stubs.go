@@ -0,0 +1,355 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// sdkExports are the SDK exports merged with -merge, they are registered by
+// the SDK adapters.
+var sdkExports = map[string]string{
+	"http":      "wasi:http/incoming-handler@0.2.0",
+	"messaging": "wasmcloud:messaging/handler@0.2.0",
+}
+
+// mergedFile is the WIT file of the world written by mergeWorld.
+const mergedFile = "merged.wit"
+
+var packagePattern = regexp.MustCompile(`(?m)^\s*package\s+([^;\s]+)\s*;`)
+
+// mergeWorld writes a world to the WIT directory including world, the SDK
+// imports and the SDK exports, and returns its name.
+func mergeWorld(wit, world string, exports []string) (string, error) {
+	names, err := filepath.Glob(filepath.Join(wit, "*.wit"))
+	if err != nil {
+		return "", err
+	}
+
+	pkg := ""
+	for _, name := range names {
+		buf, err := os.ReadFile(name)
+		if err != nil {
+			return "", err
+		}
+		if m := packagePattern.FindSubmatch(buf); m != nil {
+			pkg = string(m[1])
+			break
+		}
+	}
+	if pkg == "" {
+		return "", fmt.Errorf("found no WIT package in %s", wit)
+	}
+
+	merged := world + "-merged"
+	var b strings.Builder
+	fmt.Fprintf(&b, "// Code generated by component gen. DO NOT EDIT.\n\npackage %s;\n\nworld %s {\n", pkg, merged)
+	fmt.Fprintf(&b, "  include %s;\n  include wasmcloud:component/imports;\n", world)
+	for _, name := range exports {
+		export, ok := sdkExports[name]
+		if !ok {
+			return "", fmt.Errorf("unknown SDK export %q, it is one of http and messaging", name)
+		}
+		fmt.Fprintf(&b, "  export %s;\n", export)
+	}
+	b.WriteString("}\n")
+
+	return merged, os.WriteFile(filepath.Join(wit, mergedFile), []byte(b.String()), 0o644)
+}
+
+// export is an exported function of the generated bindings.
+type export struct {
+	// name is the name of the export in the export registry, e.g.
+	// "my:app/greeter#greet".
+	name string
+	// pkg is the import path of the bindings.
+	pkg string
+	// field is the path of the function in the Exports variable of pkg.
+	field []string
+	// typ is the function type, its types are qualified by the aliases of
+	// imports.
+	typ *ast.FuncType
+}
+
+// imports assigns unique aliases to the packages imported by the stubs.
+type imports map[string]string
+
+func (im imports) alias(pkg, name string) string {
+	if alias, ok := im[pkg]; ok {
+		return alias
+	}
+	alias := name
+	for i := 2; slices.Contains(im.aliases(), alias); i++ {
+		alias = name + strconv.Itoa(i)
+	}
+	im[pkg] = alias
+	return alias
+}
+
+func (im imports) aliases() []string {
+	var aliases []string
+	for _, alias := range im {
+		aliases = append(aliases, alias)
+	}
+	return aliases
+}
+
+// findExports returns the exported functions of the bindings in out, whose
+// import path is root.
+func findExports(out, root string, im imports) ([]export, error) {
+	var exports []export
+	err := filepath.WalkDir(out, func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(name, ".exports.go") {
+			return err
+		}
+		rel, err := filepath.Rel(out, filepath.Dir(name))
+		if err != nil {
+			return err
+		}
+
+		f, err := parser.ParseFile(token.NewFileSet(), name, nil, parser.ParseComments)
+		if err != nil {
+			return err
+		}
+		fileImports := map[string]string{}
+		for _, spec := range f.Imports {
+			pkg, _ := strconv.Unquote(spec.Path.Value)
+			local := path.Base(pkg)
+			if spec.Name != nil {
+				local = spec.Name.Name
+			}
+			fileImports[local] = pkg
+		}
+
+		pkg := path.Join(root, filepath.ToSlash(rel))
+		q := qualifier{pkg: pkg, name: f.Name.Name, fileImports: fileImports, imports: im}
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.VAR {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				vs := spec.(*ast.ValueSpec)
+				if len(vs.Names) != 1 || vs.Names[0].Name != "Exports" {
+					continue
+				}
+				doc := vs.Doc
+				if doc == nil {
+					doc = gen.Doc
+				}
+				iface := pkg
+				if m := interfacePattern.FindStringSubmatch(doc.Text()); m != nil {
+					iface = m[1]
+				}
+				if st, ok := vs.Type.(*ast.StructType); ok {
+					exports = append(exports, q.functions(st, nil, iface, "")...)
+				}
+			}
+		}
+		return nil
+	})
+	return exports, err
+}
+
+// qualifier qualifies the types of an exports file of the pkg bindings.
+type qualifier struct {
+	pkg, name   string
+	fileImports map[string]string
+	imports     imports
+}
+
+func (q qualifier) functions(st *ast.StructType, prefix []string, iface, resource string) []export {
+	var exports []export
+	for _, field := range st.Fields.List {
+		for _, name := range field.Names {
+			path := append(slices.Clone(prefix), name.Name)
+			switch typ := field.Type.(type) {
+			case *ast.FuncType:
+				q.imports.alias(q.pkg, q.name)
+				exports = append(exports, export{
+					name:  exportName(iface, resource, path, field.Doc.Text()),
+					pkg:   q.pkg,
+					field: path,
+					typ:   q.qualify(typ).(*ast.FuncType),
+				})
+			case *ast.StructType:
+				// NOTE: resources are structs of their constructor, methods and destructor
+				resource := strings.Join(path, ".")
+				if m := resourcePattern.FindStringSubmatch(field.Doc.Text()); m != nil {
+					resource = m[1]
+				}
+				exports = append(exports, q.functions(typ, path, iface, resource)...)
+			}
+		}
+	}
+	return exports
+}
+
+var (
+	interfacePattern = regexp.MustCompile(`caller-defined exports from "([^"]+)"`)
+	resourcePattern  = regexp.MustCompile(`caller-defined exports for resource "[^"#]*#([^"]+)"`)
+	functionPattern  = regexp.MustCompile(`caller-defined, exported (function|method|static function) "([^"]+)"`)
+	adminPattern     = regexp.MustCompile(`caller-defined, exported (constructor|destructor) for resource`)
+)
+
+// exportName returns the name of the export of a function of the bindings
+// in the export registry, i.e. its Wasm export name, from the doc comment
+// written by wit-bindgen-go, or the Go path of the function if it has none.
+func exportName(iface, resource string, path []string, doc string) string {
+	if m := functionPattern.FindStringSubmatch(doc); m != nil {
+		switch m[1] {
+		case "method":
+			return iface + "#[method]" + resource + "." + m[2]
+		case "static function":
+			return iface + "#[static]" + resource + "." + m[2]
+		}
+		return iface + "#" + m[2]
+	}
+	if m := adminPattern.FindStringSubmatch(doc); m != nil {
+		if m[1] == "constructor" {
+			return iface + "#[constructor]" + resource
+		}
+		return iface + "#[dtor]" + resource
+	}
+	return iface + "#" + strings.Join(path, ".")
+}
+
+func (q qualifier) qualify(expr ast.Expr) ast.Expr {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if token.IsExported(e.Name) {
+			return &ast.SelectorExpr{X: ast.NewIdent(q.imports.alias(q.pkg, q.name)), Sel: ast.NewIdent(e.Name)}
+		}
+	case *ast.SelectorExpr:
+		if x, ok := e.X.(*ast.Ident); ok {
+			if pkg, ok := q.fileImports[x.Name]; ok {
+				e.X = ast.NewIdent(q.imports.alias(pkg, x.Name))
+			}
+		}
+	case *ast.StarExpr:
+		e.X = q.qualify(e.X)
+	case *ast.ArrayType:
+		e.Elt = q.qualify(e.Elt)
+	case *ast.MapType:
+		e.Key = q.qualify(e.Key)
+		e.Value = q.qualify(e.Value)
+	case *ast.ChanType:
+		e.Value = q.qualify(e.Value)
+	case *ast.Ellipsis:
+		e.Elt = q.qualify(e.Elt)
+	case *ast.IndexExpr:
+		e.X = q.qualify(e.X)
+		e.Index = q.qualify(e.Index)
+	case *ast.IndexListExpr:
+		e.X = q.qualify(e.X)
+		for i := range e.Indices {
+			e.Indices[i] = q.qualify(e.Indices[i])
+		}
+	case *ast.FuncType:
+		q.fields(e.Params)
+		q.fields(e.Results)
+	case *ast.StructType:
+		q.fields(e.Fields)
+	}
+	return expr
+}
+
+func (q qualifier) fields(fields *ast.FieldList) {
+	if fields == nil {
+		return
+	}
+	for _, field := range fields.List {
+		field.Type = q.qualify(field.Type)
+	}
+}
+
+// writeStubs writes the registration stubs of exports to name, in package
+// pkg. An existing file is left as it is, it holds the implementations.
+func writeStubs(name, pkg string, exports []export, im imports) (bool, error) {
+	if len(exports) == 0 {
+		return false, nil
+	}
+	if _, err := os.Stat(name); err == nil {
+		return false, nil
+	}
+
+	registry := im.alias(sdkModule+"/export", "export")
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "package %s\n\nimport (\n", pkg)
+	pkgs := make([]string, 0, len(im))
+	for p := range im {
+		pkgs = append(pkgs, p)
+	}
+	slices.Sort(pkgs)
+	for _, p := range pkgs {
+		fmt.Fprintf(&b, "\t%s %q\n", im[p], p)
+	}
+	b.WriteString(")\n\n")
+
+	b.WriteString("// NOTE: the SDK exports, e.g. `wasi:http/incoming-handler`, are registered by\n")
+	b.WriteString("// the SDK adapters, registering them again panics.\n")
+	b.WriteString("func init() {\n")
+	for i, e := range exports {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "\t%s.Bind(%q, &%s.Exports.%s)\n", registry, e.name, im[e.pkg], strings.Join(e.field, "."))
+		fmt.Fprintf(&b, "\t%s.Register(%q, %s)\n", registry, e.name, stubName(im[e.pkg], e.field))
+	}
+	b.WriteString("}\n")
+
+	for _, e := range exports {
+		fmt.Fprintf(&b, "\nfunc %s", stubName(im[e.pkg], e.field))
+		sig := *e.typ
+		sig.Func = token.NoPos
+		var typ bytes.Buffer
+		if err := printer.Fprint(&typ, token.NewFileSet(), &sig); err != nil {
+			return false, err
+		}
+		b.Write(bytes.TrimPrefix(typ.Bytes(), []byte("func")))
+		fmt.Fprintf(&b, " {\n\tpanic(%q)\n}\n", "not implemented: "+im[e.pkg]+".Exports."+strings.Join(e.field, "."))
+	}
+
+	buf, err := format.Source(b.Bytes())
+	if err != nil {
+		return false, fmt.Errorf("failed to format stubs: %w", err)
+	}
+	return true, os.WriteFile(name, buf, 0o644)
+}
+
+// stubName returns the name of the stub of an export, e.g. greeterGreet.
+func stubName(alias string, field []string) string {
+	r := []rune(alias + strings.Join(field, ""))
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
+
+// packageName returns the name of the Go package in dir, or main if there is
+// none.
+func packageName(dir string) string {
+	names, _ := filepath.Glob(filepath.Join(dir, "*.go"))
+	for _, name := range names {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(token.NewFileSet(), name, nil, parser.PackageClauseOnly)
+		if err == nil {
+			return f.Name.Name
+		}
+	}
+	return "main"
+}
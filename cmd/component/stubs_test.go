@@ -0,0 +1,106 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMergeWorld(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"world.wit": "package my:app;\n\nworld app {\n  export greeter;\n}\n"})
+
+	world, err := mergeWorld(dir, "app", []string{"http", "messaging"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if world != "app-merged" {
+		t.Errorf("expected: %v, got: %v", "app-merged", world)
+	}
+
+	buf, _ := os.ReadFile(filepath.Join(dir, mergedFile))
+	for _, want := range []string{"package my:app;", "world app-merged {", "include app;", "include wasmcloud:component/imports;", "export wasi:http/incoming-handler@0.2.0;", "export wasmcloud:messaging/handler@0.2.0;"} {
+		if !strings.Contains(string(buf), want) {
+			t.Errorf("expected %q in:\n%s", want, buf)
+		}
+	}
+	if got, err := findWorld(dir); err != nil || got != "app" {
+		t.Errorf("expected the merged world to be skipped, got: %q %v", got, err)
+	}
+
+	if _, err := mergeWorld(dir, "app", []string{"ftp"}); err == nil {
+		t.Error("expected an unknown export to fail")
+	}
+}
+
+func TestWriteStubs(t *testing.T) {
+	out := t.TempDir()
+	writeFiles(t, out, map[string]string{
+		"my/app/greeter/greeter.exports.go": `package greeter
+
+import (
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"go.wasmcloud.dev/component/gen/wasi/io/streams"
+)
+
+// Exports represents the caller-defined exports from "my:app/greeter".
+var Exports struct {
+	// Greet represents the caller-defined, exported function "greet".
+	Greet func(name string, opts cm.Option[Options]) (result cm.Result[string, Greeting, string])
+	Stream func(s streams.OutputStream)
+	// Counter represents the caller-defined exports for resource "my:app/greeter#counter".
+	Counter struct {
+		// Constructor represents the caller-defined, exported constructor for resource "counter".
+		Constructor func(start uint32) (result Counter)
+		// Destructor represents the caller-defined, exported destructor for resource "counter".
+		Destructor  func(self cm.Rep)
+		// Get represents the caller-defined, exported method "get".
+		Get func(self cm.Rep) (result uint32)
+	}
+}
+`,
+		"other/app/greeter/greeter.exports.go": "package greeter\n\nvar Exports struct {\n\tGreet func()\n}\n",
+	})
+
+	im := imports{}
+	exports, err := findExports(out, "example.com/app/gen", im)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(exports) != 6 {
+		t.Fatalf("expected: %v, got: %v", 6, len(exports))
+	}
+
+	name := filepath.Join(t.TempDir(), "exports.go")
+	if written, err := writeStubs(name, "main", exports, im); err != nil || !written {
+		t.Fatalf("expected the stubs to be written, got: %v %v", written, err)
+	}
+	buf, _ := os.ReadFile(name)
+	for _, want := range []string{
+		`greeter "example.com/app/gen/my/app/greeter"`,
+		`greeter2 "example.com/app/gen/other/app/greeter"`,
+		`cm "github.com/bytecodealliance/wasm-tools-go/cm"`,
+		`export "go.wasmcloud.dev/component/export"`,
+		`export.Bind("my:app/greeter#greet", &greeter.Exports.Greet)`,
+		`export.Register("my:app/greeter#greet", greeterGreet)`,
+		`export.Register("my:app/greeter#Stream", greeterStream)`,
+		`export.Bind("my:app/greeter#[constructor]counter", &greeter.Exports.Counter.Constructor)`,
+		`export.Register("my:app/greeter#[dtor]counter", greeterCounterDestructor)`,
+		`export.Register("my:app/greeter#[method]counter.get", greeterCounterGet)`,
+		`export.Register("example.com/app/gen/other/app/greeter#Greet", greeter2Greet)`,
+		"func greeterGreet(name string, opts cm.Option[greeter.Options]) (result cm.Result[string, greeter.Greeting, string]) {",
+		"func greeterStream(s streams.OutputStream) {",
+		"func greeterCounterConstructor(start uint32) (result greeter.Counter) {",
+		`panic("not implemented: greeter.Exports.Greet")`,
+	} {
+		if !strings.Contains(string(buf), want) {
+			t.Errorf("expected %q in:\n%s", want, buf)
+		}
+	}
+
+	os.WriteFile(name, []byte("package main\n"), 0o644)
+	if written, err := writeStubs(name, "main", exports, im); err != nil || written {
+		t.Errorf("expected an existing file to be kept, got: %v %v", written, err)
+	}
+}
@@ -0,0 +1,33 @@
+//go:build !wasm
+
+package componenttest
+
+import (
+	"go.wasmcloud.dev/component/internal/memory"
+)
+
+// Blobstore is an in-memory `wasi:blobstore` host.
+type Blobstore struct {
+	bs *memory.Blobstore
+}
+
+// NewBlobstore returns a Blobstore without containers.
+func NewBlobstore() *Blobstore {
+	return &Blobstore{bs: memory.NewBlobstore()}
+}
+
+// CreateContainer creates the named container with a copy of objects,
+// replacing its contents if it exists.
+func (bs *Blobstore) CreateContainer(name string, objects map[string][]byte) {
+	bs.bs.Put(name, objects)
+}
+
+// Container returns a copy of the objects of the named container, or nil if
+// it does not exist.
+func (bs *Blobstore) Container(name string) map[string][]byte {
+	c, ok := bs.bs.Lookup(name)
+	if !ok {
+		return nil
+	}
+	return c.Snapshot()
+}
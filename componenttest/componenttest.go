@@ -0,0 +1,61 @@
+//go:build !wasm
+
+package componenttest
+
+import (
+	"net/http"
+	"testing"
+
+	"go.wasmcloud.dev/component/internal/fakehost"
+	"go.wasmcloud.dev/component/net/wasihttp/wasihttptest"
+)
+
+// Host is a set of in-memory host interfaces, serving the wasikv, wasiblob,
+// messaging and wasihttp.Transport adapters of the component under test.
+type Host struct {
+	KeyValue  *KeyValue
+	Blobstore *Blobstore
+	Messaging *Messaging
+	// HTTP serves the outgoing requests of wasihttp.Transport, they fail
+	// while it is nil.
+	HTTP http.Handler
+}
+
+// New returns an empty Host, installed until the end of t. Tests using a Host
+// must not run in parallel.
+func New(t testing.TB) *Host {
+	h := &Host{
+		KeyValue:  NewKeyValue(),
+		Blobstore: NewBlobstore(),
+		Messaging: NewMessaging(),
+	}
+
+	prevKeyValue, prevBlobstore, prevMessaging, prevOutgoing := fakehost.KeyValue, fakehost.Blobstore, fakehost.Messaging, wasihttptest.Outgoing
+	fakehost.KeyValue = keyValue{h.KeyValue}
+	fakehost.Blobstore = h.Blobstore.bs
+	fakehost.Messaging = messagingBroker{h.Messaging}
+	wasihttptest.Outgoing = outgoing{h}
+	t.Cleanup(func() {
+		fakehost.KeyValue, fakehost.Blobstore, fakehost.Messaging, wasihttptest.Outgoing = prevKeyValue, prevBlobstore, prevMessaging, prevOutgoing
+	})
+	return h
+}
+
+// outgoing serves the requests of the Transport with Host.HTTP.
+type outgoing struct {
+	host *Host
+}
+
+func (o outgoing) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if o.host.HTTP == nil {
+		// NOTE: the request fails like a connection refused by the server
+		panic(http.ErrAbortHandler)
+	}
+	o.host.HTTP.ServeHTTP(w, r)
+}
+
+// LinkName returns the link name of the last call to iface, e.g.
+// "wasi:keyvalue/store", as selected with wasmcloud.WithLinkName.
+func (h *Host) LinkName(iface string) string {
+	return fakehost.LinkName(iface)
+}
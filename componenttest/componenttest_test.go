@@ -0,0 +1,251 @@
+//go:build !wasm
+
+package componenttest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"go.wasmcloud.dev/component/blob/wasiblob"
+	"go.wasmcloud.dev/component/kv/wasikv"
+	"go.wasmcloud.dev/component/messaging"
+	"go.wasmcloud.dev/component/net/wasihttp"
+	"go.wasmcloud.dev/component/net/wasihttp/middleware"
+	"go.wasmcloud.dev/component/wasmcloud"
+)
+
+func TestKeyValue(t *testing.T) {
+	h := New(t)
+	h.KeyValue.CreateBucket("default", map[string][]byte{"existing": []byte("value")})
+
+	if _, err := wasikv.Open("missing"); !errors.Is(err, wasikv.ErrNoSuchStore) {
+		t.Errorf("expected: %v, got: %v", wasikv.ErrNoSuchStore, err)
+	}
+
+	b, err := wasikv.Open("default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+
+	if value, err := b.Get("existing"); err != nil || string(value) != "value" {
+		t.Errorf("expected: %v, got: %q %v", "value", value, err)
+	}
+	if err := b.Set("new", []byte("fresh")); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := b.Incr("hits", 2); err != nil || n != 2 {
+		t.Errorf("expected: %v, got: %v %v", 2, n, err)
+	}
+	keys, err := b.Keys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"existing", "hits", "new"}; !slices.Equal(keys, want) {
+		t.Errorf("expected: %v, got: %v", want, keys)
+	}
+	if got := string(h.KeyValue.Bucket("default")["new"]); got != "fresh" {
+		t.Errorf("expected: %v, got: %v", "fresh", got)
+	}
+}
+
+func TestBlobstore(t *testing.T) {
+	h := New(t)
+	h.Blobstore.CreateContainer("uploads", map[string][]byte{"a.txt": []byte("hello")})
+
+	c, err := wasiblob.Open("uploads")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if data, err := c.Get("a.txt"); err != nil || string(data) != "hello" {
+		t.Errorf("expected: %v, got: %q %v", "hello", data, err)
+	}
+	if err := c.Put("b.txt", []byte("world")); err != nil {
+		t.Fatal(err)
+	}
+	if err := wasiblob.MoveObject(wasiblob.ObjectID{Container: "uploads", Name: "a.txt"}, wasiblob.ObjectID{Container: "uploads", Name: "c.txt"}); err != nil {
+		t.Fatal(err)
+	}
+	names, err := c.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	slices.Sort(names)
+	if want := []string{"b.txt", "c.txt"}; !slices.Equal(names, want) {
+		t.Errorf("expected: %v, got: %v", want, names)
+	}
+	if got := string(h.Blobstore.Container("uploads")["b.txt"]); got != "world" {
+		t.Errorf("expected: %v, got: %v", "world", got)
+	}
+
+	if _, err := wasiblob.Open("missing"); err == nil {
+		t.Error("expected opening a missing container to fail")
+	}
+}
+
+func TestMessaging(t *testing.T) {
+	h := New(t)
+	h.Messaging.HandleRequest("echo.*", func(_ context.Context, msg *messaging.Message) ([]byte, error) {
+		return msg.Data, nil
+	})
+
+	messaging.HandleRequest(func(ctx context.Context, msg *messaging.Message) ([]byte, error) {
+		resp, err := messaging.Request(ctx, "echo.upper", msg.Data)
+		if err != nil {
+			return nil, err
+		}
+		return append(resp.Data, '!'), nil
+	})
+
+	if err := h.Messaging.Deliver(&messaging.Message{Subject: "in", Data: []byte("hi"), ReplyTo: "_INBOX.1"}); err != nil {
+		t.Fatal(err)
+	}
+	want := []messaging.Message{{Subject: "_INBOX.1", Data: []byte("hi!")}}
+	if got := h.Messaging.Published(); len(got) != 1 || got[0].Subject != want[0].Subject || string(got[0].Data) != string(want[0].Data) {
+		t.Errorf("expected: %v, got: %v", want, got)
+	}
+
+	if _, err := messaging.Request(context.Background(), "nobody", nil); !errors.Is(err, messaging.ErrNoResponders) {
+		t.Errorf("expected: %v, got: %v", messaging.ErrNoResponders, err)
+	}
+}
+
+func TestHTTP(t *testing.T) {
+	h := New(t)
+	client := &http.Client{Transport: &wasihttp.Transport{}}
+
+	if _, err := client.Get("http://example.com/"); err == nil {
+		t.Error("expected a request without Host.HTTP to fail")
+	}
+
+	h.HTTP = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+		io.WriteString(w, r.Host+r.URL.Path)
+	})
+	resp, err := client.Get("http://example.com/tea")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusTeapot || string(body) != "example.com/tea" {
+		t.Errorf("unexpected response: %v %q", resp.Status, body)
+	}
+}
+
+func TestHTTPRequestID(t *testing.T) {
+	h := New(t)
+	client := &http.Client{Transport: &wasihttp.Transport{}}
+
+	var forwarded []string
+	h.HTTP = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		forwarded = append(forwarded, r.Header.Get(middleware.RequestIDHeader))
+	})
+	handler := middleware.RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, id := range []string{"", "other"} {
+			req, _ := http.NewRequestWithContext(r.Context(), http.MethodGet, "http://example.com/", nil)
+			if id != "" {
+				req.Header.Set(middleware.RequestIDHeader, id)
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+		}
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set(middleware.RequestIDHeader, "req-1")
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+	if want := []string{"req-1", "other"}; !slices.Equal(forwarded, want) {
+		t.Errorf("expected: %v, got: %v", want, forwarded)
+	}
+}
+
+func TestLinkName(t *testing.T) {
+	h := New(t)
+	h.KeyValue.CreateBucket("default", nil)
+	h.Blobstore.CreateContainer("uploads", nil)
+	h.HTTP = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	backup := wasmcloud.WithLinkName(context.Background(), "backup")
+
+	primary, err := wasikv.Open("default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer primary.Close()
+	secondary, err := wasikv.OpenContext(backup, "default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer secondary.Close()
+
+	for _, tt := range []struct {
+		bucket *wasikv.Bucket
+		want   string
+	}{
+		{secondary, "backup"},
+		{primary, wasmcloud.DefaultLinkName},
+		{secondary, "backup"},
+	} {
+		if err := tt.bucket.Set("key", nil); err != nil {
+			t.Fatal(err)
+		}
+		if got := h.LinkName("wasi:keyvalue/store"); got != tt.want {
+			t.Errorf("expected: %v, got: %v", tt.want, got)
+		}
+	}
+
+	c, err := wasiblob.OpenContext(backup, "uploads")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	if _, err := c.List(); err != nil {
+		t.Fatal(err)
+	}
+	if got := h.LinkName("wasi:blobstore/container"); got != "backup" {
+		t.Errorf("expected: %v, got: %v", "backup", got)
+	}
+
+	if err := messaging.Publish(backup, "events", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := h.LinkName("wasmcloud:messaging/consumer"); got != "backup" {
+		t.Errorf("expected: %v, got: %v", "backup", got)
+	}
+
+	req, _ := http.NewRequestWithContext(backup, http.MethodGet, "http://example.com/", nil)
+	resp, err := (&wasihttp.Transport{}).RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if got := h.LinkName("wasi:http/outgoing-handler"); got != "backup" {
+		t.Errorf("expected: %v, got: %v", "backup", got)
+	}
+}
+
+func TestNewRestores(t *testing.T) {
+	t.Run("installed", func(t *testing.T) {
+		h := New(t)
+		h.KeyValue.CreateBucket("scoped", nil)
+		if _, err := wasikv.Open("scoped"); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	New(t)
+	if _, err := wasikv.Open("scoped"); !errors.Is(err, wasikv.ErrNoSuchStore) {
+		t.Errorf("expected: %v, got: %v", wasikv.ErrNoSuchStore, err)
+	}
+}
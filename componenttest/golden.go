@@ -0,0 +1,248 @@
+//go:build !wasm
+
+package componenttest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"unicode/utf8"
+)
+
+// RecordEnv is the environment variable making a [Golden] record, e.g.
+// `COMPONENTTEST_RECORD=1 go test ./...`.
+const RecordEnv = "COMPONENTTEST_RECORD"
+
+// redacted replaces the values of redacted headers in golden files.
+const redacted = "REDACTED"
+
+// Golden is a [Host] HTTP handler recording the outgoing requests of the
+// component and the upstream responses to a golden file, and replaying them
+// otherwise, so tests of components calling external APIs are hermetic.
+//
+// Requests are replayed by method, URL and body, identical requests replay
+// their recorded responses in order. Requests without a recorded response
+// fail the test.
+type Golden struct {
+	// Upstream serves the requests while recording, it defaults to
+	// http.DefaultTransport.
+	Upstream http.RoundTripper
+	// Redact are the headers recorded as "REDACTED", they default to the
+	// Authorization, Proxy-Authorization, Cookie and Set-Cookie headers.
+	Redact []string
+
+	t      testing.TB
+	path   string
+	record bool
+
+	mu           sync.Mutex
+	interactions []interaction
+	replayed     []bool
+}
+
+type interaction struct {
+	Request  message `json:"request"`
+	Response message `json:"response"`
+}
+
+type message struct {
+	Method string      `json:"method,omitempty"`
+	URL    string      `json:"url,omitempty"`
+	Status int         `json:"status,omitempty"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+	// Base64 reports whether Body is base64 encoded, i.e. not UTF-8.
+	Base64 bool `json:"base64,omitempty"`
+}
+
+func (m *message) setBody(body []byte) {
+	if utf8.Valid(body) {
+		m.Body = string(body)
+		return
+	}
+	m.Body = base64.StdEncoding.EncodeToString(body)
+	m.Base64 = true
+}
+
+func (m *message) body() ([]byte, error) {
+	if !m.Base64 {
+		return []byte(m.Body), nil
+	}
+	return base64.StdEncoding.DecodeString(m.Body)
+}
+
+// NewGolden returns a Golden of the file at path. It records when
+// [RecordEnv] is set, writing the file at the end of t unless t failed, and
+// replays the file otherwise.
+func NewGolden(t testing.TB, path string) *Golden {
+	t.Helper()
+
+	g := &Golden{
+		Redact: []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"},
+		t:      t,
+		path:   path,
+		record: os.Getenv(RecordEnv) != "",
+	}
+	if g.record {
+		g.interactions = []interaction{}
+		t.Cleanup(g.save)
+		return g
+	}
+
+	buf, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("componenttest: golden file %s does not exist, record it with %s=1", path, RecordEnv)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(buf, &g.interactions); err != nil {
+		t.Fatalf("componenttest: failed to decode golden file %s: %s", path, err)
+	}
+	g.replayed = make([]bool, len(g.interactions))
+	return g
+}
+
+// Recording reports whether g records the requests.
+func (g *Golden) Recording() bool {
+	return g.record
+}
+
+func (g *Golden) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body []byte
+	var err error
+	if r.Body != nil {
+		body, err = io.ReadAll(r.Body)
+	}
+	if err != nil {
+		g.t.Errorf("componenttest: failed to read request body: %s", err)
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	req := message{Method: r.Method, URL: r.URL.String(), Header: g.redact(r.Header)}
+	req.setBody(body)
+
+	if g.record {
+		g.forward(w, r, req, body)
+		return
+	}
+
+	resp, ok := g.replay(req)
+	if !ok {
+		g.t.Errorf("componenttest: no recorded response to %s %s in %s", req.Method, req.URL, g.path)
+		http.Error(w, "no recorded response", http.StatusNotImplemented)
+		return
+	}
+	respBody, err := resp.body()
+	if err != nil {
+		g.t.Errorf("componenttest: invalid response body of %s %s in %s: %s", req.Method, req.URL, g.path, err)
+		http.Error(w, err.Error(), http.StatusNotImplemented)
+		return
+	}
+	for key, values := range resp.Header {
+		w.Header()[key] = values
+	}
+	w.WriteHeader(resp.Status)
+	w.Write(respBody)
+}
+
+// forward sends r upstream and records the response.
+func (g *Golden) forward(w http.ResponseWriter, r *http.Request, req message, body []byte) {
+	upstream := g.Upstream
+	if upstream == nil {
+		upstream = http.DefaultTransport
+	}
+
+	out := r.Clone(r.Context())
+	out.RequestURI = ""
+	out.Body = io.NopCloser(bytes.NewReader(body))
+	out.ContentLength = int64(len(body))
+
+	res, err := upstream.RoundTrip(out)
+	if err != nil {
+		g.t.Errorf("componenttest: failed to record %s %s: %s", req.Method, req.URL, err)
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer res.Body.Close()
+
+	respBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		g.t.Errorf("componenttest: failed to record %s %s: %s", req.Method, req.URL, err)
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	resp := message{Status: res.StatusCode, Header: g.redact(res.Header)}
+	resp.setBody(respBody)
+
+	g.mu.Lock()
+	g.interactions = append(g.interactions, interaction{Request: req, Response: resp})
+	g.mu.Unlock()
+
+	for key, values := range res.Header {
+		w.Header()[key] = values
+	}
+	w.WriteHeader(res.StatusCode)
+	w.Write(respBody)
+}
+
+// replay returns the first response to req not replayed yet.
+func (g *Golden) replay(req message) (message, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for i, recorded := range g.interactions {
+		if g.replayed[i] || recorded.Request.Method != req.Method || recorded.Request.URL != req.URL || recorded.Request.Body != req.Body || recorded.Request.Base64 != req.Base64 {
+			continue
+		}
+		g.replayed[i] = true
+		return recorded.Response, true
+	}
+	return message{}, false
+}
+
+func (g *Golden) redact(header http.Header) http.Header {
+	if len(header) == 0 {
+		return nil
+	}
+	header = header.Clone()
+	for _, key := range g.Redact {
+		if values := header.Values(key); len(values) > 0 {
+			header[http.CanonicalHeaderKey(key)] = []string{redacted}
+		}
+	}
+	return header
+}
+
+func (g *Golden) save() {
+	if g.t.Failed() {
+		g.t.Logf("componenttest: not writing golden file %s of a failed test", g.path)
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	buf, err := json.MarshalIndent(g.interactions, "", "  ")
+	if err != nil {
+		g.t.Errorf("componenttest: failed to encode golden file %s: %s", g.path, err)
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(g.path), 0o755); err != nil {
+		g.t.Errorf("componenttest: failed to write golden file %s: %s", g.path, err)
+		return
+	}
+	if err := os.WriteFile(g.path, append(buf, '\n'), 0o644); err != nil {
+		g.t.Errorf("componenttest: failed to write golden file %s: %s", g.path, err)
+	}
+}
@@ -0,0 +1,82 @@
+//go:build !wasm
+
+package componenttest
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.wasmcloud.dev/component/net/wasihttp"
+)
+
+func TestGolden(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testdata", "api.json")
+
+	calls := 0
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Set-Cookie", "session=secret")
+		w.Write([]byte(r.Method + " " + r.URL.Path + " " + string(body) + " " + strings.Repeat("!", calls)))
+	}))
+	defer upstream.Close()
+
+	send := func(t *testing.T, method, path, body string) string {
+		req, err := http.NewRequest(method, upstream.URL+path, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Authorization", "Bearer secret")
+		resp, err := (&http.Client{Transport: &wasihttp.Transport{}}).Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		got, _ := io.ReadAll(resp.Body)
+		return string(got)
+	}
+
+	want := []string{"GET /a  !", "POST /b body !!", "GET /a  !!!"}
+	requests := func(t *testing.T) []string {
+		return []string{send(t, http.MethodGet, "/a", ""), send(t, http.MethodPost, "/b", "body"), send(t, http.MethodGet, "/a", "")}
+	}
+
+	t.Run("record", func(t *testing.T) {
+		t.Setenv(RecordEnv, "1")
+		g := NewGolden(t, path)
+		New(t).HTTP = g
+
+		if !g.Recording() {
+			t.Fatal("expected to record")
+		}
+		for i, got := range requests(t) {
+			if got != want[i] {
+				t.Errorf("expected: %q, got: %q", want[i], got)
+			}
+		}
+	})
+
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(buf), "secret") {
+		t.Errorf("expected redacted headers, got: %s", buf)
+	}
+
+	upstream.Close()
+	t.Run("replay", func(t *testing.T) {
+		New(t).HTTP = NewGolden(t, path)
+
+		for i, got := range requests(t) {
+			if got != want[i] {
+				t.Errorf("expected: %q, got: %q", want[i], got)
+			}
+		}
+	})
+}
@@ -0,0 +1,65 @@
+//go:build !wasm
+
+package componenttest
+
+import (
+	"sync"
+
+	"go.wasmcloud.dev/component/internal/backend"
+	"go.wasmcloud.dev/component/internal/fakehost"
+	"go.wasmcloud.dev/component/internal/memory"
+)
+
+// KeyValue is an in-memory `wasi:keyvalue` host. Opening a bucket that was
+// not created fails with wasikv.ErrNoSuchStore.
+type KeyValue struct {
+	mu      sync.Mutex
+	buckets map[string]*memory.Bucket
+}
+
+// NewKeyValue returns a KeyValue without buckets.
+func NewKeyValue() *KeyValue {
+	return &KeyValue{buckets: map[string]*memory.Bucket{}}
+}
+
+// CreateBucket creates the named bucket with a copy of data, replacing its
+// contents if it exists.
+func (kv *KeyValue) CreateBucket(name string, data map[string][]byte) {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+
+	if b, ok := kv.buckets[name]; ok {
+		b.Replace(data)
+		return
+	}
+	kv.buckets[name] = memory.NewBucket(data)
+}
+
+// Bucket returns a copy of the contents of the named bucket, or nil if it
+// does not exist. Counters are stored as decimal strings.
+func (kv *KeyValue) Bucket(name string) map[string][]byte {
+	kv.mu.Lock()
+	b, ok := kv.buckets[name]
+	kv.mu.Unlock()
+
+	if !ok {
+		return nil
+	}
+	return b.Snapshot()
+}
+
+// keyValue adapts KeyValue to the wasikv backend.
+type keyValue struct {
+	kv *KeyValue
+}
+
+func (s keyValue) Open(identifier string) (backend.Bucket, error) {
+	s.kv.mu.Lock()
+	defer s.kv.mu.Unlock()
+
+	b, ok := s.kv.buckets[identifier]
+	if !ok {
+		return nil, fakehost.ErrNoSuchStore
+	}
+	return b, nil
+}
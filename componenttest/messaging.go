@@ -0,0 +1,113 @@
+//go:build !wasm
+
+package componenttest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"go.wasmcloud.dev/component/gen/wasmcloud/messaging/handler"
+	"go.wasmcloud.dev/component/gen/wasmcloud/messaging/types"
+	"go.wasmcloud.dev/component/internal/backend"
+	"go.wasmcloud.dev/component/messaging"
+)
+
+// Messaging is an in-memory `wasmcloud:messaging` broker. Published messages
+// are recorded, requests are answered by the responders registered with
+// [Messaging.HandleRequest].
+type Messaging struct {
+	mu         sync.Mutex
+	published  []messaging.Message
+	responders []responder
+}
+
+type responder struct {
+	pattern string
+	handler messaging.RequestHandlerFunc
+}
+
+// NewMessaging returns a Messaging without responders.
+func NewMessaging() *Messaging {
+	return &Messaging{}
+}
+
+// Published returns the messages published so far, in order.
+func (m *Messaging) Published() []messaging.Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.published)
+}
+
+// HandleRequest answers requests to subjects matching pattern, see
+// messaging.MatchSubject, with the result of h. Responders are tried in the
+// order they were registered, requests without a matching responder fail
+// with messaging.ErrNoResponders.
+func (m *Messaging) HandleRequest(pattern string, h messaging.RequestHandlerFunc) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.responders = append(m.responders, responder{pattern: pattern, handler: h})
+}
+
+// Deliver delivers msg to the exported `wasmcloud:messaging/handler`, i.e.
+// the handler registered with messaging.Handle, and returns the error it
+// reported to the host.
+func (m *Messaging) Deliver(msg *messaging.Message) error {
+	replyTo := cm.None[string]()
+	if msg.ReplyTo != "" {
+		replyTo = cm.Some(msg.ReplyTo)
+	}
+	res := handler.Exports.HandleMessage(types.BrokerMessage{
+		Subject: msg.Subject,
+		Body:    cm.ToList(msg.Data),
+		ReplyTo: replyTo,
+	})
+	if res.IsErr() {
+		return errors.New(*res.Err())
+	}
+	return nil
+}
+
+// messagingBroker adapts Messaging to the messaging backend.
+type messagingBroker struct {
+	m *Messaging
+}
+
+func (b messagingBroker) Publish(msg backend.Message) error {
+	b.m.mu.Lock()
+	defer b.m.mu.Unlock()
+
+	b.m.published = append(b.m.published, messaging.Message{
+		Subject: msg.Subject,
+		Data:    slices.Clone(msg.Body),
+		ReplyTo: msg.ReplyTo,
+	})
+	return nil
+}
+
+func (b messagingBroker) Request(subject string, body []byte, timeout time.Duration) (backend.Message, error) {
+	b.m.mu.Lock()
+	var h messaging.RequestHandlerFunc
+	for _, r := range b.m.responders {
+		if messaging.MatchSubject(r.pattern, subject) {
+			h = r.handler
+			break
+		}
+	}
+	b.m.mu.Unlock()
+
+	if h == nil {
+		return backend.Message{}, messaging.ErrNoResponders
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	data, err := h(ctx, &messaging.Message{Subject: subject, Data: slices.Clone(body)})
+	if err != nil {
+		return backend.Message{}, err
+	}
+	return backend.Message{Subject: subject, Body: data}, nil
+}
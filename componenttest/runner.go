@@ -0,0 +1,282 @@
+//go:build wasmtime && !wasm
+
+package componenttest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go/version"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Runner builds a component and serves it with `wasmtime serve`, so tests send
+// real requests through the `wasi:http` implementation of wasmtime instead of
+// the fakes of a [Host]. It is only available with the `wasmtime` build tag,
+// running it requires `wasmtime` and the tools of the [Toolchain] on the PATH.
+//
+// NOTE: wasmtime-go does not implement the component model, the component is
+// served by the wasmtime CLI instead. Only the interfaces provided by
+// `wasmtime serve` are available to the component, e.g. not `wasi:keyvalue`.
+type Runner struct {
+	// Package is the directory of the main package of the component.
+	Package string
+	// WITPackage is the WIT package passed to TinyGo, it defaults to the
+	// "wit" directory of Package.
+	WITPackage string
+	// World is the WIT world of the component.
+	World string
+	// Toolchain builds the component, it defaults to [TinyGo].
+	Toolchain Toolchain
+	// Adapter is the `wasi_snapshot_preview1` adapter of [Go] builds, it
+	// defaults to the WASI_PREVIEW1_ADAPTER environment variable.
+	Adapter string
+	// Build replaces the build of the Toolchain. The returned command must
+	// write the component to output.
+	Build func(ctx context.Context, output string) *exec.Cmd
+	// Args are additional `wasmtime serve` flags, e.g. "-Sinherit-network".
+	Args []string
+	// StartTimeout bounds the build and the startup of wasmtime, it defaults
+	// to two minutes.
+	StartTimeout time.Duration
+}
+
+// Toolchain is a toolchain building components.
+type Toolchain string
+
+const (
+	// TinyGo builds a wasip2 component with `tinygo build -target=wasip2`.
+	TinyGo Toolchain = "tinygo"
+	// Go builds a wasip1 module with `GOOS=wasip1 GOARCH=wasm go build`,
+	// made a component by `wasm-tools component embed` and `wasm-tools
+	// component new` with the preview1 adapter.
+	//
+	// NOTE: Go exports functions with `go:wasmexport` since Go 1.24
+	Go Toolchain = "go"
+)
+
+// Toolchains are the toolchains run by [Runner.Matrix].
+var Toolchains = []Toolchain{TinyGo, Go}
+
+// minGoVersion is the Go version [Go] builds require.
+const minGoVersion = "go1.24"
+
+// Instance is a component served by a [Runner].
+type Instance struct {
+	// URL is the base URL of the instance, e.g. "http://127.0.0.1:8080".
+	URL string
+	// Client sends requests to the instance.
+	Client *http.Client
+}
+
+// Start builds the component and serves it until the end of t, failing t
+// when either step fails.
+func (r *Runner) Start(t testing.TB) *Instance {
+	t.Helper()
+
+	timeout := r.StartTimeout
+	if timeout <= 0 {
+		timeout = 2 * time.Minute
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	component := filepath.Join(t.TempDir(), "component.wasm")
+	for _, cmd := range r.buildCommands(ctx, component) {
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("failed to build component with %s: %s\n%s", r.toolchain(), err, out)
+		}
+	}
+
+	addr, err := freeAddr()
+	if err != nil {
+		t.Fatalf("failed to pick an address: %s", err)
+	}
+
+	args := append([]string{"serve", "-Scli", "--addr", addr}, r.Args...)
+	cmd := exec.Command("wasmtime", append(args, component)...)
+	var output syncBuffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("failed to start wasmtime: %s", err)
+	}
+
+	var waitErr error
+	exited := make(chan struct{})
+	go func() {
+		waitErr = cmd.Wait()
+		close(exited)
+	}()
+	t.Cleanup(func() {
+		cmd.Process.Kill()
+		<-exited
+		if t.Failed() {
+			t.Logf("wasmtime output:\n%s", output.String())
+		}
+	})
+
+	if err := waitListening(ctx, addr, exited); err != nil {
+		if errors.Is(err, errExited) && waitErr != nil {
+			err = fmt.Errorf("%w: %w", err, waitErr)
+		}
+		t.Fatalf("failed to serve component: %s\n%s", err, output.String())
+	}
+	return &Instance{
+		URL:    "http://" + addr,
+		Client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Matrix runs fn in a subtest for each of [Toolchains], with the component
+// built by the toolchain. Toolchains whose tools are not installed are
+// skipped.
+func (r *Runner) Matrix(t *testing.T, fn func(t *testing.T, instance *Instance)) {
+	t.Helper()
+
+	for _, toolchain := range Toolchains {
+		t.Run(string(toolchain), func(t *testing.T) {
+			r := *r
+			r.Toolchain = toolchain
+			if err := r.available(); err != nil {
+				t.Skip(err)
+			}
+			fn(t, r.Start(t))
+		})
+	}
+}
+
+func (r *Runner) toolchain() Toolchain {
+	if r.Toolchain == "" {
+		return TinyGo
+	}
+	return r.Toolchain
+}
+
+func (r *Runner) adapter() string {
+	if r.Adapter != "" {
+		return r.Adapter
+	}
+	return os.Getenv("WASI_PREVIEW1_ADAPTER")
+}
+
+// available returns an error if the tools of the build are not installed.
+func (r *Runner) available() error {
+	tools := []string{"wasmtime"}
+	switch r.toolchain() {
+	case TinyGo:
+		tools = append(tools, "tinygo")
+	case Go:
+		tools = append(tools, "go", "wasm-tools")
+	}
+	for _, tool := range tools {
+		if _, err := exec.LookPath(tool); err != nil {
+			return fmt.Errorf("%s is not installed", tool)
+		}
+	}
+	if r.Build != nil || r.toolchain() != Go {
+		return nil
+	}
+
+	if r.adapter() == "" {
+		return errors.New("the preview1 adapter is not set, set WASI_PREVIEW1_ADAPTER")
+	}
+	out, err := exec.Command("go", "env", "GOVERSION").Output()
+	if err != nil {
+		return fmt.Errorf("failed to get the Go version: %w", err)
+	}
+	if v := strings.TrimSpace(string(out)); version.Compare(v, minGoVersion) < 0 {
+		return fmt.Errorf("%s builds require %s, got %s", Go, minGoVersion, v)
+	}
+	return nil
+}
+
+func (r *Runner) buildCommands(ctx context.Context, output string) []*exec.Cmd {
+	if r.Build != nil {
+		return []*exec.Cmd{r.Build(ctx, output)}
+	}
+
+	witPackage := r.WITPackage
+	if witPackage == "" {
+		witPackage = filepath.Join(r.Package, "wit")
+	}
+	witPackage, _ = filepath.Abs(witPackage)
+
+	if r.toolchain() == TinyGo {
+		cmd := exec.CommandContext(ctx, "tinygo", "build", "-target=wasip2",
+			"--wit-package", witPackage, "--wit-world", r.World,
+			"-o", output, ".")
+		cmd.Dir = r.Package
+		cmd.Env = os.Environ()
+		return []*exec.Cmd{cmd}
+	}
+
+	core := strings.TrimSuffix(output, ".wasm") + ".core.wasm"
+	embedded := strings.TrimSuffix(output, ".wasm") + ".embed.wasm"
+	build := exec.CommandContext(ctx, "go", "build", "-buildmode=c-shared", "-o", core, ".")
+	build.Dir = r.Package
+	build.Env = append(os.Environ(), "GOOS=wasip1", "GOARCH=wasm")
+	return []*exec.Cmd{
+		build,
+		exec.CommandContext(ctx, "wasm-tools", "component", "embed", "--world", r.World, witPackage, core, "-o", embedded),
+		exec.CommandContext(ctx, "wasm-tools", "component", "new", "--adapt", "wasi_snapshot_preview1="+r.adapter(), embedded, "-o", output),
+	}
+}
+
+// freeAddr returns a local address that was free when it was picked.
+func freeAddr() (string, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+	defer l.Close()
+	return l.Addr().String(), nil
+}
+
+var errExited = errors.New("wasmtime exited before listening")
+
+// waitListening waits until addr accepts connections, or wasmtime exited.
+func waitListening(ctx context.Context, addr string, exited <-chan struct{}) error {
+	for {
+		conn, err := net.DialTimeout("tcp", addr, time.Second)
+		if err == nil {
+			return conn.Close()
+		}
+
+		select {
+		case <-exited:
+			return errExited
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+}
+
+// syncBuffer collects the output of wasmtime, it is written concurrently to
+// being read.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
@@ -0,0 +1,29 @@
+//go:build wasmtime
+
+package componenttest
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRunner(t *testing.T) {
+	r := &Runner{Package: "testdata/echo", World: "echo"}
+	r.Matrix(t, func(t *testing.T, instance *Instance) {
+		resp, err := instance.Client.Post(instance.URL+"/", "text/plain", strings.NewReader("hello"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+
+		body, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode != http.StatusOK || string(body) != "hello" {
+			t.Errorf("unexpected response: %v %q", resp.Status, body)
+		}
+		if got := resp.Header.Get("X-Method"); got != http.MethodPost {
+			t.Errorf("expected: %v, got: %v", http.MethodPost, got)
+		}
+	})
+}
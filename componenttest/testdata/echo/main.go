@@ -0,0 +1,17 @@
+package main
+
+import (
+	"io"
+	"net/http"
+
+	"go.wasmcloud.dev/component/net/wasihttp"
+)
+
+func init() {
+	wasihttp.HandleFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Method", r.Method)
+		io.Copy(w, r.Body)
+	})
+}
+
+func main() {}
@@ -0,0 +1,103 @@
+package concurrent
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Group runs functions bound by host calls concurrently, e.g. the requests of
+// an HTTP fan-out, and collects their errors, like golang.org/x/sync/errgroup.
+//
+// Components are single-threaded, the functions run as goroutines which
+// interleave while waiting on host I/O: the SDK packages wait on their
+// pollables through wasipoll.DefaultPoller, which polls the pollables of every
+// waiting goroutine in a single `wasi:io/poll.poll` call. Hence:
+//
+//   - CPU-bound functions do not run in parallel, each runs until it waits on
+//     host I/O or returns.
+//   - Synchronous host calls, e.g. of `wasi:keyvalue` or the blocking stream
+//     reads of request bodies, block every goroutine until they return.
+//
+// A Group must not be copied after first use.
+type Group struct {
+	cancel context.CancelCauseFunc
+	wg     sync.WaitGroup
+	sem    chan struct{}
+
+	mu   sync.Mutex
+	errs []error
+	// failed is set once a function returned an error, errors caused by
+	// the cancellation of the context are dropped then
+	failed bool
+}
+
+// WithContext returns a Group and a context derived from ctx, canceled once a
+// function of the Group returns an error, or once Wait returns.
+func WithContext(ctx context.Context) (*Group, context.Context) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	return &Group{cancel: cancel}, ctx
+}
+
+// SetLimit limits the number of functions running at once to n, e.g. the
+// requests in flight. Go blocks while the limit is reached. A negative n
+// removes the limit. It must not be called while functions are running.
+func (g *Group) SetLimit(n int) {
+	if n < 0 {
+		g.sem = nil
+		return
+	}
+	g.sem = make(chan struct{}, n)
+}
+
+// Go calls fn in a new goroutine.
+func (g *Group) Go(fn func() error) {
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+	g.wg.Add(1)
+	go func() {
+		defer g.done()
+		if err := fn(); err != nil {
+			g.fail(err)
+		}
+	}()
+}
+
+func (g *Group) done() {
+	if g.sem != nil {
+		<-g.sem
+	}
+	g.wg.Done()
+}
+
+// fail records err, and cancels the context of the Group on the first error.
+func (g *Group) fail(err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.failed && g.cancel != nil && errors.Is(err, context.Canceled) {
+		return
+	}
+	g.errs = append(g.errs, err)
+	if !g.failed {
+		g.failed = true
+		if g.cancel != nil {
+			g.cancel(err)
+		}
+	}
+}
+
+// Wait waits for the functions to return, and returns their errors joined.
+// Errors wrapping context.Canceled, returned once the context of the Group
+// was canceled by the first error, are dropped.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	if g.cancel != nil {
+		g.cancel(nil)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return errors.Join(g.errs...)
+}
@@ -0,0 +1,105 @@
+package concurrent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestGroup(t *testing.T) {
+	var g Group
+	var mu sync.Mutex
+	var got []int
+	for i := range 5 {
+		g.Go(func() error {
+			mu.Lock()
+			defer mu.Unlock()
+			got = append(got, i)
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	slices.Sort(got)
+	if want := []int{0, 1, 2, 3, 4}; !slices.Equal(got, want) {
+		t.Errorf("expected: %v, got: %v", want, got)
+	}
+}
+
+func TestGroupErrors(t *testing.T) {
+	var g Group
+	errA, errB := errors.New("a"), errors.New("b")
+	g.Go(func() error { return errA })
+	g.Go(func() error { return nil })
+	g.Go(func() error { return errB })
+
+	err := g.Wait()
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Errorf("expected both errors, got: %v", err)
+	}
+}
+
+func TestGroupCancel(t *testing.T) {
+	g, ctx := WithContext(context.Background())
+	errFailed := errors.New("failed")
+	started := make(chan struct{})
+	g.Go(func() error {
+		close(started)
+		// NOTE: like a host call waiting on a pollable and the context
+		<-ctx.Done()
+		return fmt.Errorf("request aborted: %w", ctx.Err())
+	})
+	g.Go(func() error {
+		<-started
+		return errFailed
+	})
+
+	err := g.Wait()
+	if !errors.Is(err, errFailed) || errors.Is(err, context.Canceled) {
+		t.Errorf("expected only the first error, got: %v", err)
+	}
+	if !errors.Is(context.Cause(ctx), errFailed) {
+		t.Errorf("expected the context to be canceled by: %v, got: %v", errFailed, context.Cause(ctx))
+	}
+
+	g, ctx = WithContext(context.Background())
+	if err := g.Wait(); err != nil || ctx.Err() == nil {
+		t.Errorf("expected the context to be canceled once Wait returns, got: %v %v", err, ctx.Err())
+	}
+}
+
+func TestGroupLimit(t *testing.T) {
+	var g Group
+	g.SetLimit(2)
+
+	var mu sync.Mutex
+	running, peak := 0, 0
+	release := make(chan struct{})
+	go func() {
+		for range 6 {
+			release <- struct{}{}
+		}
+	}()
+	for range 6 {
+		g.Go(func() error {
+			mu.Lock()
+			running++
+			peak = max(peak, running)
+			mu.Unlock()
+
+			<-release
+			mu.Lock()
+			running--
+			mu.Unlock()
+			return nil
+		})
+	}
+	g.Wait()
+	if peak > 2 {
+		t.Errorf("expected at most 2 running functions, got: %d", peak)
+	}
+}
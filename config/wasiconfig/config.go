@@ -0,0 +1,91 @@
+package wasiconfig
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"go.wasmcloud.dev/component/gen/wasi/config/runtime"
+	"go.wasmcloud.dev/component/internal/decode"
+)
+
+// ErrNotFound is returned by [Get] when the key is not set.
+var ErrNotFound = errors.New("wasiconfig: key not found")
+
+// NOTE: wasmCloud serves the component's named config and link config through
+// `wasi:config/runtime`, there is no separate interface to read from.
+
+// Get returns the runtime config value at key, or [ErrNotFound] if not set.
+func Get(key string) (string, error) {
+	res := runtime.Get(key)
+	if res.IsErr() {
+		return "", fmt.Errorf("failed to get %s: %s", key, configError(res.Err()))
+	}
+
+	value := res.OK()
+	if value.None() {
+		return "", ErrNotFound
+	}
+	return *value.Some(), nil
+}
+
+// GetAll returns all runtime config values.
+func GetAll() (map[string]string, error) {
+	res := runtime.GetAll()
+	if res.IsErr() {
+		return nil, fmt.Errorf("failed to get config: %s", configError(res.Err()))
+	}
+
+	pairs := res.OK().Slice()
+	values := make(map[string]string, len(pairs))
+	for _, kv := range pairs {
+		values[kv[0]] = kv[1]
+	}
+	return values, nil
+}
+
+// Load sets the fields of the struct pointed to by v from runtime config,
+// following the `config` struct tags:
+//
+//	type Config struct {
+//		Addr    string        `config:"addr" default:":8080"`
+//		Timeout time.Duration `config:"timeout" default:"5s"`
+//		MaxBody ByteSize      `config:"max_body" default:"1MiB"`
+//		Token   string        `config:"token,required"`
+//		Tags    []string      `config:"tags"` // comma separated
+//		DB      DBConfig      `config:"db_"`  // prefixes nested fields
+//	}
+//
+// Supported field types are strings, booleans, integers, floats,
+// time.Duration, [ByteSize], slices of those, and encoding.TextUnmarshaler
+// implementations. Fields without a tag are left untouched.
+func Load(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("wasiconfig: Load requires a non-nil struct pointer")
+	}
+
+	values, err := GetAll()
+	if err != nil {
+		return err
+	}
+
+	d := decode.Decoder{
+		Tag: "config",
+		Lookup: func(key string) (string, bool) {
+			value, ok := values[key]
+			return value, ok
+		},
+		Errorf: func(format string, args ...any) error {
+			return fmt.Errorf("wasiconfig: "+format, args...)
+		},
+	}
+	return d.Struct(rv.Elem(), "")
+}
+
+func configError(err *runtime.ConfigError) string {
+	if msg := err.Upstream(); msg != nil {
+		return *msg
+	}
+	return *err.IO()
+}
@@ -0,0 +1,119 @@
+//go:build !wasm
+
+package wasiconfig
+
+import (
+	"errors"
+	"log/slog"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.wasmcloud.dev/component/internal/fakehost"
+)
+
+func TestGet(t *testing.T) {
+	fakehost.Config.Values = map[string]string{"addr": ":9000"}
+	fakehost.Config.Errors = map[string]string{"broken": "vault sealed"}
+	defer func() { fakehost.Config.Errors = nil }()
+
+	if value, err := Get("addr"); err != nil || value != ":9000" {
+		t.Errorf("expected: %v, got: %q %v", ":9000", value, err)
+	}
+	if _, err := Get("missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected: %v, got: %v", ErrNotFound, err)
+	}
+	if _, err := Get("broken"); err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("expected upstream error, got: %v", err)
+	}
+
+	all, err := GetAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]string{"addr": ":9000"}; !reflect.DeepEqual(all, want) {
+		t.Errorf("expected: %v, got: %v", want, all)
+	}
+}
+
+type dbConfig struct {
+	Host     string `config:"host" default:"localhost"`
+	MaxConns int    `config:"max_conns"`
+}
+
+type config struct {
+	Addr    string        `config:"addr" default:":8080"`
+	Timeout time.Duration `config:"timeout" default:"5s"`
+	MaxBody ByteSize      `config:"max_body" default:"1MiB"`
+	Token   string        `config:"token,required"`
+	Tags    []string      `config:"tags"`
+	Level   slog.Level    `config:"level"`
+	DB      dbConfig      `config:"db_"`
+	Ignored string
+}
+
+func TestLoad(t *testing.T) {
+	tests := map[string]struct {
+		config map[string]string
+		v      any
+		want   any
+		err    bool
+	}{
+		"config": {
+			config: map[string]string{
+				"addr":         "0.0.0.0:9000",
+				"token":        "secret",
+				"max_body":     "64KB",
+				"tags":         "a, b",
+				"level":        "warn",
+				"db_max_conns": "16",
+			},
+			v: &config{Ignored: "kept"},
+			want: &config{
+				Addr:    "0.0.0.0:9000",
+				Timeout: 5 * time.Second,
+				MaxBody: 64 * KB,
+				Token:   "secret",
+				Tags:    []string{"a", "b"},
+				Level:   slog.LevelWarn,
+				DB:      dbConfig{Host: "localhost", MaxConns: 16},
+				Ignored: "kept",
+			},
+		},
+		"required": {
+			config: map[string]string{},
+			v:      &config{},
+			err:    true,
+		},
+		"invalid duration": {
+			config: map[string]string{"token": "secret", "timeout": "soon"},
+			v:      &config{},
+			err:    true,
+		},
+		"invalid size": {
+			config: map[string]string{"token": "secret", "max_body": "lots"},
+			v:      &config{},
+			err:    true,
+		},
+		"not a pointer": {v: config{}, err: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			fakehost.Config.Values = tt.config
+			err := Load(tt.v)
+			if tt.err {
+				if err == nil {
+					t.Error("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(tt.v, tt.want) {
+				t.Errorf("expected: %+v, got: %+v", tt.want, tt.v)
+			}
+		})
+	}
+}
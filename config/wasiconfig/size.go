@@ -0,0 +1,94 @@
+package wasiconfig
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ByteSize is a size in bytes, decoded from values such as "512", "64KB" or
+// "1.5MiB". Decimal units (KB, MB, ...) are powers of 1000 and binary units
+// (KiB, MiB, ...) powers of 1024, a bare K, M, G or T is binary.
+type ByteSize int64
+
+const (
+	B   ByteSize = 1
+	KiB ByteSize = 1 << (10 * iota)
+	MiB
+	GiB
+	TiB
+)
+
+const (
+	KB ByteSize = 1000
+	MB          = 1000 * KB
+	GB          = 1000 * MB
+	TB          = 1000 * GB
+)
+
+var byteUnits = map[string]ByteSize{
+	"":    B,
+	"b":   B,
+	"k":   KiB,
+	"kb":  KB,
+	"kib": KiB,
+	"m":   MiB,
+	"mb":  MB,
+	"mib": MiB,
+	"g":   GiB,
+	"gb":  GB,
+	"gib": GiB,
+	"t":   TiB,
+	"tb":  TB,
+	"tib": TiB,
+}
+
+// ParseByteSize parses a size such as "64KB" or "1.5MiB".
+func ParseByteSize(s string) (ByteSize, error) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i < 0 {
+		i = len(s)
+	}
+
+	n, err := strconv.ParseFloat(s[:i], 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	unit, ok := byteUnits[strings.ToLower(strings.TrimSpace(s[i:]))]
+	if !ok {
+		return 0, fmt.Errorf("invalid size unit in %q", s)
+	}
+
+	size := n * float64(unit)
+	if size > math.MaxInt64 {
+		return 0, fmt.Errorf("size %q overflows", s)
+	}
+	return ByteSize(size), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *ByteSize) UnmarshalText(text []byte) error {
+	size, err := ParseByteSize(string(text))
+	if err != nil {
+		return err
+	}
+	*s = size
+	return nil
+}
+
+// String formats the size in the largest binary unit dividing it exactly.
+func (s ByteSize) String() string {
+	for _, u := range []struct {
+		size ByteSize
+		name string
+	}{{TiB, "TiB"}, {GiB, "GiB"}, {MiB, "MiB"}, {KiB, "KiB"}} {
+		if s != 0 && s%u.size == 0 {
+			return strconv.FormatInt(int64(s/u.size), 10) + u.name
+		}
+	}
+	return strconv.FormatInt(int64(s), 10) + "B"
+}
@@ -0,0 +1,49 @@
+package wasiconfig
+
+import "testing"
+
+func TestParseByteSize(t *testing.T) {
+	tests := map[string]struct {
+		s    string
+		want ByteSize
+		err  bool
+	}{
+		"bytes":        {s: "512", want: 512},
+		"bytes unit":   {s: "512B", want: 512},
+		"decimal":      {s: "64KB", want: 64000},
+		"binary":       {s: "64KiB", want: 64 * 1024},
+		"short binary": {s: "2M", want: 2 << 20},
+		"fraction":     {s: "1.5MiB", want: 3 << 19},
+		"space":        {s: "10 gb", want: 10 * GB},
+		"unknown unit": {s: "10PB", err: true},
+		"no number":    {s: "MiB", err: true},
+		"negative":     {s: "-1KB", err: true},
+		"overflow":     {s: "99999999TiB", err: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseByteSize(tt.s)
+			if (err != nil) != tt.err {
+				t.Fatalf("expected error: %v, got: %v", tt.err, err)
+			}
+			if got != tt.want {
+				t.Errorf("expected: %v, got: %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestByteSizeString(t *testing.T) {
+	for size, want := range map[ByteSize]string{
+		0:        "0B",
+		512:      "512B",
+		64 * KiB: "64KiB",
+		1500:     "1500B",
+		3 * GiB:  "3GiB",
+	} {
+		if got := size.String(); got != want {
+			t.Errorf("expected: %v, got: %v", want, got)
+		}
+	}
+}
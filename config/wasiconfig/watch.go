@@ -0,0 +1,92 @@
+package wasiconfig
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"time"
+
+	"go.wasmcloud.dev/component/time/wasiclock"
+)
+
+// DefaultWatchInterval is the polling interval used when [Watcher.Interval] is not set.
+const DefaultWatchInterval = 30 * time.Second
+
+// sleep waits for d or until ctx is done.
+var sleep = func(ctx context.Context, d time.Duration) error {
+	timer := wasiclock.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Watcher polls runtime config for changes.
+//
+// NOTE: `wasi:config/runtime` has no change notification, config is polled
+// on a `wasi:clocks` timer.
+type Watcher struct {
+	// Keys are the watched keys, all keys are watched if empty.
+	Keys []string
+	// Interval is the polling interval, defaults to [DefaultWatchInterval].
+	Interval time.Duration
+	// OnError is called with errors reading config after the first read,
+	// polling continues with the last values.
+	OnError func(error)
+}
+
+// Watch is shorthand for a [Watcher] polling keys at [DefaultWatchInterval].
+func Watch(ctx context.Context, keys []string, onChange func(values map[string]string)) error {
+	w := &Watcher{Keys: keys}
+	return w.Run(ctx, onChange)
+}
+
+// Run calls onChange with the current values of the watched keys, and again
+// whenever one of them is set, changed or unset, until ctx is done. Keys which
+// are not set are missing from values. It returns the error of the first read
+// or the context error.
+func (w *Watcher) Run(ctx context.Context, onChange func(values map[string]string)) error {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	last, err := w.values()
+	if err != nil {
+		return err
+	}
+	onChange(maps.Clone(last))
+
+	for {
+		if err := sleep(ctx, interval); err != nil {
+			return err
+		}
+
+		values, err := w.values()
+		if err != nil {
+			if w.OnError != nil {
+				w.OnError(err)
+			}
+			continue
+		}
+		if !maps.Equal(values, last) {
+			last = values
+			onChange(maps.Clone(last))
+		}
+	}
+}
+
+func (w *Watcher) values() (map[string]string, error) {
+	values, err := GetAll()
+	if err != nil || len(w.Keys) == 0 {
+		return values, err
+	}
+	maps.DeleteFunc(values, func(key, _ string) bool {
+		return !slices.Contains(w.Keys, key)
+	})
+	return values, nil
+}
@@ -0,0 +1,83 @@
+//go:build !wasm
+
+package wasiconfig
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.wasmcloud.dev/component/internal/fakehost"
+)
+
+func TestWatch(t *testing.T) {
+	defer func(orig func(context.Context, time.Duration) error) { sleep = orig }(sleep)
+
+	fakehost.Config.Values = map[string]string{"flag": "off", "other": "a"}
+	fakehost.Config.Down = false
+	defer func() { fakehost.Config.Down = false }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// each poll applies the next update, canceling once all are applied
+	updates := []func(){
+		func() { fakehost.Config.Values["other"] = "b" },
+		func() { fakehost.Config.Values["flag"] = "on" },
+		func() { fakehost.Config.Down = true },
+		func() { fakehost.Config.Down = false },
+		func() { delete(fakehost.Config.Values, "flag") },
+	}
+	var intervals []time.Duration
+	sleep = func(ctx context.Context, d time.Duration) error {
+		intervals = append(intervals, d)
+		if len(updates) == 0 {
+			cancel()
+			return ctx.Err()
+		}
+		updates[0]()
+		updates = updates[1:]
+		return nil
+	}
+
+	var got []map[string]string
+	var errs []error
+	w := &Watcher{
+		Keys:     []string{"flag", "missing"},
+		Interval: time.Minute,
+		OnError:  func(err error) { errs = append(errs, err) },
+	}
+	err := w.Run(ctx, func(values map[string]string) {
+		got = append(got, values)
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected: %v, got: %v", context.Canceled, err)
+	}
+
+	want := []map[string]string{{"flag": "off"}, {"flag": "on"}, {}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected: %v, got: %v", want, got)
+	}
+	if len(errs) != 1 {
+		t.Errorf("expected: %v, got: %v", 1, errs)
+	}
+	for _, d := range intervals {
+		if d != time.Minute {
+			t.Errorf("expected: %v, got: %v", time.Minute, d)
+		}
+	}
+}
+
+func TestWatchInitialError(t *testing.T) {
+	fakehost.Config.Down = true
+	defer func() { fakehost.Config.Down = false }()
+
+	err := Watch(context.Background(), nil, func(map[string]string) {
+		t.Error("unexpected change")
+	})
+	if err == nil {
+		t.Error("expected error")
+	}
+}
@@ -0,0 +1,105 @@
+package export
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// registry holds the exports by name, e.g.
+// "wasi:http/incoming-handler@0.2.0#handle".
+var registry = struct {
+	sync.Mutex
+	exports map[string]*entry
+}{exports: map[string]*entry{}}
+
+type entry struct {
+	fn    any
+	bound bool
+	// set assigns fn to the function variable of the bindings.
+	set func(fn any) error
+}
+
+// Register registers fn as the implementation of the export name, e.g.
+// "wasi:http/incoming-handler@0.2.0#handle". fn is assigned to the function
+// of the bindings bound to name with [Bind], either before or after. It must
+// be called in an init function.
+//
+// Register panics if name is registered twice, e.g. by an SDK adapter and a
+// custom implementation of the same export, instead of silently overriding
+// the first registration.
+func Register(name string, fn any) {
+	if fn == nil {
+		panic(fmt.Sprintf("export: nil function registered for %q", name))
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	e := registry.exports[name]
+	if e == nil {
+		e = &entry{}
+		registry.exports[name] = e
+	}
+	if e.fn != nil {
+		panic(fmt.Sprintf("export: multiple registrations for %q", name))
+	}
+	e.fn = fn
+	if e.set != nil {
+		e.assign()
+	}
+}
+
+// Bind binds the export name to the function variable of the bindings at ptr,
+// e.g. &incominghandler.Exports.Handle, which is set to the function
+// registered for name.
+//
+// Bind panics if name is bound twice, or if the registered function is not a F.
+func Bind[F any](name string, ptr *F) {
+	registry.Lock()
+	defer registry.Unlock()
+
+	e := registry.exports[name]
+	if e == nil {
+		e = &entry{}
+		registry.exports[name] = e
+	}
+	if e.bound {
+		panic(fmt.Sprintf("export: multiple bindings for %q", name))
+	}
+	e.bound = true
+	// NOTE: a type assertion instead of reflection, which TinyGo only
+	// partially implements
+	e.set = func(fn any) error {
+		f, ok := fn.(F)
+		if !ok {
+			return fmt.Errorf("export: %q is a %T, registered function is a %T", name, *ptr, fn)
+		}
+		*ptr = f
+		return nil
+	}
+	if e.fn != nil {
+		e.assign()
+	}
+}
+
+func (e *entry) assign() {
+	if err := e.set(e.fn); err != nil {
+		panic(err.Error())
+	}
+}
+
+// Registered returns the sorted names of the registered exports.
+func Registered() []string {
+	registry.Lock()
+	defer registry.Unlock()
+
+	var names []string
+	for name, e := range registry.exports {
+		if e.fn != nil {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
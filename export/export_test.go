@@ -0,0 +1,82 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestRegister(t *testing.T) {
+	var greet func(string) string
+	Bind("test:app/greeter#greet", &greet)
+	Register("test:app/greeter#greet", func(name string) string { return "hello " + name })
+	if greet == nil || greet("world") != "hello world" {
+		t.Fatal("expected the registered function to be bound")
+	}
+
+	// NOTE: registered before it is bound
+	Register("test:app/greeter#count", func() uint32 { return 42 })
+	var count func() uint32
+	Bind("test:app/greeter#count", &count)
+	if count == nil || count() != 42 {
+		t.Fatal("expected the registered function to be bound")
+	}
+
+	if got := Registered(); !slices.Contains(got, "test:app/greeter#greet") || !slices.Contains(got, "test:app/greeter#count") {
+		t.Errorf("unexpected registered exports: %v", got)
+	}
+}
+
+func TestRegisterPanics(t *testing.T) {
+	var handle func()
+	Bind("test:app/conflict#handle", &handle)
+	Register("test:app/conflict#handle", func() {})
+
+	var mismatch func(string)
+	Bind("test:app/conflict#mismatch", &mismatch)
+
+	for _, tt := range []struct {
+		name string
+		fn   func()
+		want string
+	}{
+		{"register twice", func() { Register("test:app/conflict#handle", func() {}) }, `export: multiple registrations for "test:app/conflict#handle"`},
+		{"bind twice", func() { Bind("test:app/conflict#handle", &handle) }, `export: multiple bindings for "test:app/conflict#handle"`},
+		{"nil function", func() { Register("test:app/conflict#nil", nil) }, `export: nil function registered for "test:app/conflict#nil"`},
+		{"type mismatch", func() { Register("test:app/conflict#mismatch", func() {}) }, `export: "test:app/conflict#mismatch" is a func(string), registered function is a func()`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if got := fmt.Sprint(recover()); got != tt.want {
+					t.Errorf("expected: %v, got: %v", tt.want, got)
+				}
+			}()
+			tt.fn()
+		})
+	}
+}
+
+func TestShutdown(t *testing.T) {
+	var calls []string
+	hook := func(name string, err error) func(context.Context) error {
+		return func(context.Context) error {
+			calls = append(calls, name)
+			return err
+		}
+	}
+	errFirst, errLast := errors.New("first"), errors.New("last")
+	defer OnShutdown(hook("first", errFirst))()
+	removed := OnShutdown(hook("removed", nil))
+	defer OnShutdown(hook("last", errLast))()
+	removed()
+
+	err := Shutdown(context.Background())
+	if want := []string{"last", "first"}; !slices.Equal(calls, want) {
+		t.Errorf("expected: %v, got: %v", want, calls)
+	}
+	if !errors.Is(err, errFirst) || !errors.Is(err, errLast) {
+		t.Errorf("expected the errors of both hooks, got: %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+)
+
+// shutdown holds the hooks registered with [OnShutdown].
+var shutdown struct {
+	sync.Mutex
+	hooks []*hook
+}
+
+type hook struct {
+	fn func(ctx context.Context) error
+}
+
+// OnShutdown registers fn to be called once the host completes an invocation
+// of an export, e.g. after the response to a `wasi:http` request was
+// finished, a `wasmcloud:messaging` message was handled, or before a command
+// run by wasicmd.Run exits. It is the flush point of buffered work, e.g. of
+// telemetry exporters, batched writes or log writers:
+//
+//	export.OnShutdown(tracer.Flush)
+//
+// The hooks are called in reverse order of registration, like deferred
+// functions. remove unregisters fn.
+func OnShutdown(fn func(ctx context.Context) error) (remove func()) {
+	h := &hook{fn: fn}
+
+	shutdown.Lock()
+	defer shutdown.Unlock()
+	shutdown.hooks = append(shutdown.hooks, h)
+
+	return func() {
+		shutdown.Lock()
+		defer shutdown.Unlock()
+		shutdown.hooks = slices.DeleteFunc(shutdown.hooks, func(v *hook) bool { return v == h })
+	}
+}
+
+// Shutdown calls the hooks registered with [OnShutdown] and returns their
+// errors joined. It is called by the SDK adapters at the end of each
+// invocation, custom exports call it before returning to the host.
+func Shutdown(ctx context.Context) error {
+	shutdown.Lock()
+	hooks := slices.Clone(shutdown.hooks)
+	shutdown.Unlock()
+
+	var errs []error
+	for i := len(hooks) - 1; i >= 0; i-- {
+		if err := hooks[i].fn(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
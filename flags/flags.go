@@ -0,0 +1,158 @@
+package flags
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	"go.wasmcloud.dev/component/config/wasiconfig"
+	"go.wasmcloud.dev/component/kv/wasikv"
+)
+
+// DefaultConfigPrefix prefixes the runtime config keys read by [Default],
+// e.g. "flag_new-checkout".
+const DefaultConfigPrefix = "flag_"
+
+// Source looks up raw flag values by name.
+type Source interface {
+	// Lookup returns the value of the named flag and whether it is set.
+	Lookup(name string) (string, bool, error)
+}
+
+// SourceFunc adapts a function to a [Source].
+type SourceFunc func(name string) (string, bool, error)
+
+func (f SourceFunc) Lookup(name string) (string, bool, error) {
+	return f(name)
+}
+
+// Config returns a Source reading flags from `wasi:config/runtime` at prefix + name.
+func Config(prefix string) Source {
+	return SourceFunc(func(name string) (string, bool, error) {
+		value, err := wasiconfig.Get(prefix + name)
+		if errors.Is(err, wasiconfig.ErrNotFound) {
+			return "", false, nil
+		}
+		return value, err == nil, err
+	})
+}
+
+// Bucket returns a Source reading flags from a `wasi:keyvalue` bucket at prefix + name,
+// letting flags change without touching the component's config.
+func Bucket(bucket *wasikv.Bucket, prefix string) Source {
+	return SourceFunc(func(name string) (string, bool, error) {
+		value, err := bucket.Get(prefix + name)
+		if errors.Is(err, wasikv.ErrNotFound) {
+			return "", false, nil
+		}
+		return string(value), err == nil, err
+	})
+}
+
+// Set evaluates flags from a Source.
+//
+// Flag values are booleans ("true", "off", "1", ...) or rollout percentages
+// such as "25%". Unset flags, unparsable values and lookup errors evaluate to
+// the default given by the caller, reported to OnError if set.
+type Set struct {
+	source Source
+
+	// OnError is called with lookup and parsing errors.
+	OnError func(name string, err error)
+}
+
+// New returns a Set evaluating flags from source.
+func New(source Source) *Set {
+	return &Set{source: source}
+}
+
+// Default is the Set used by the package level functions, reading flags from
+// runtime config at [DefaultConfigPrefix].
+var Default = New(Config(DefaultConfigPrefix))
+
+// Bool reports whether the named flag is enabled on [Default].
+func Bool(name string, def bool) bool {
+	return Default.Bool(name, def)
+}
+
+// Enabled reports whether the named flag is enabled for key on [Default].
+func Enabled(name, key string, def bool) bool {
+	return Default.Enabled(name, key, def)
+}
+
+// Bool reports whether the named flag is enabled. Partial rollouts have no key
+// to be evaluated for and are only enabled at 100%, use [Set.Enabled] instead.
+func (s *Set) Bool(name string, def bool) bool {
+	pct, ok := s.percent(name)
+	if !ok {
+		return def
+	}
+	return pct >= 100
+}
+
+// Enabled reports whether the named flag is enabled for key, e.g. a user or
+// tenant ID. Keys are hashed together with the flag name into stable buckets,
+// so a key enabled at 10% stays enabled as the rollout grows and different
+// flags roll out to different keys.
+func (s *Set) Enabled(name, key string, def bool) bool {
+	pct, ok := s.percent(name)
+	if !ok {
+		return def
+	}
+	return rolloutBucket(name, key) < pct*100
+}
+
+// percent returns the rollout percentage of the named flag, booleans are 0% or 100%.
+func (s *Set) percent(name string) (float64, bool) {
+	value, ok, err := s.source.Lookup(name)
+	if err != nil {
+		s.error(name, fmt.Errorf("failed to look up flag %s: %w", name, err))
+		return 0, false
+	}
+	if !ok {
+		return 0, false
+	}
+
+	pct, err := parse(value)
+	if err != nil {
+		s.error(name, fmt.Errorf("failed to parse flag %s: %w", name, err))
+		return 0, false
+	}
+	return pct, true
+}
+
+func (s *Set) error(name string, err error) {
+	if s.OnError != nil {
+		s.OnError(name, err)
+	}
+}
+
+func parse(value string) (float64, error) {
+	value = strings.TrimSpace(value)
+	if n, ok := strings.CutSuffix(value, "%"); ok {
+		pct, err := strconv.ParseFloat(strings.TrimSpace(n), 64)
+		if err != nil || pct < 0 || pct > 100 {
+			return 0, fmt.Errorf("invalid percentage %q", value)
+		}
+		return pct, nil
+	}
+
+	switch strings.ToLower(value) {
+	case "1", "t", "true", "on", "yes", "enabled":
+		return 100, nil
+	case "0", "f", "false", "off", "no", "disabled":
+		return 0, nil
+	}
+	return 0, fmt.Errorf("invalid value %q", value)
+}
+
+// rolloutBucket hashes name and key into [0, 10000).
+func rolloutBucket(name, key string) float64 {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	h.Write([]byte{0})
+	h.Write([]byte(key))
+	return float64(h.Sum64() % 10000)
+}
@@ -0,0 +1,144 @@
+//go:build !wasm
+
+package flags
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"go.wasmcloud.dev/component/internal/fakehost"
+)
+
+func mapSource(values map[string]string) Source {
+	return SourceFunc(func(name string) (string, bool, error) {
+		if name == "broken" {
+			return "", false, errors.New("unavailable")
+		}
+		value, ok := values[name]
+		return value, ok, nil
+	})
+}
+
+func TestBool(t *testing.T) {
+	s := New(mapSource(map[string]string{
+		"on":      "true",
+		"off":     "off",
+		"full":    "100%",
+		"partial": "50%",
+		"invalid": "maybe",
+	}))
+
+	var errs []string
+	s.OnError = func(name string, err error) { errs = append(errs, name) }
+
+	tests := map[string]struct {
+		def  bool
+		want bool
+	}{
+		"on":      {want: true},
+		"off":     {def: true, want: false},
+		"full":    {want: true},
+		"partial": {def: true, want: false},
+		"invalid": {def: true, want: true},
+		"missing": {def: true, want: true},
+		"broken":  {def: true, want: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := s.Bool(name, tt.def); got != tt.want {
+				t.Errorf("expected: %v, got: %v", tt.want, got)
+			}
+		})
+	}
+	if len(errs) != 2 {
+		t.Errorf("expected errors for invalid and broken, got: %v", errs)
+	}
+}
+
+func TestEnabled(t *testing.T) {
+	s := New(mapSource(map[string]string{
+		"none": "0%",
+		"all":  "100%",
+		"ten":  "10%",
+		"half": "50%",
+	}))
+
+	count := func(name string) int {
+		n := 0
+		for i := range 10000 {
+			if s.Enabled(name, fmt.Sprintf("user-%d", i), false) {
+				n++
+			}
+		}
+		return n
+	}
+
+	for name, want := range map[string][2]int{
+		"none": {0, 0},
+		"all":  {10000, 10000},
+		"ten":  {800, 1200},
+		"half": {4700, 5300},
+	} {
+		if got := count(name); got < want[0] || got > want[1] {
+			t.Errorf("%s: expected between %d and %d, got: %d", name, want[0], want[1], got)
+		}
+	}
+
+	// keys enabled at 10% stay enabled at 50%
+	grown := New(mapSource(map[string]string{"ten": "50%"}))
+	for i := range 1000 {
+		key := fmt.Sprintf("user-%d", i)
+		if s.Enabled("ten", key, false) && !grown.Enabled("ten", key, false) {
+			t.Fatalf("expected %s to stay enabled", key)
+		}
+	}
+	if !s.Enabled("missing", "user-1", true) {
+		t.Error("expected default for missing flag")
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := map[string]struct {
+		value string
+		want  float64
+		err   bool
+	}{
+		"true":     {value: "true", want: 100},
+		"on":       {value: "ON", want: 100},
+		"false":    {value: "0", want: 0},
+		"percent":  {value: "12.5%", want: 12.5},
+		"spaces":   {value: " 25 % ", want: 25},
+		"over 100": {value: "101%", err: true},
+		"negative": {value: "-1%", err: true},
+		"word":     {value: "maybe", err: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := parse(tt.value)
+			if (err != nil) != tt.err {
+				t.Fatalf("expected error: %v, got: %v", tt.err, err)
+			}
+			if got != tt.want {
+				t.Errorf("expected: %v, got: %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDefault(t *testing.T) {
+	fakehost.Config.Values = map[string]string{"flag_new-checkout": "on"}
+	t.Cleanup(func() { fakehost.Config.Values = nil })
+
+	if !Bool("new-checkout", false) {
+		t.Error("expected new-checkout to be enabled")
+	}
+	if Bool("old-checkout", false) {
+		t.Error("expected old-checkout to default to disabled")
+	}
+	if !Enabled("new-checkout", "user-1", false) {
+		t.Error("expected new-checkout to be enabled for user-1")
+	}
+}
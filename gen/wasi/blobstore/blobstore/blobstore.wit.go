@@ -0,0 +1,121 @@
+// Code generated by wit-bindgen-go. DO NOT EDIT.
+
+// Package blobstore represents the imported interface "wasi:blobstore/blobstore@0.2.0-draft".
+//
+// wasi-cloud Blobstore service definition
+package blobstore
+
+import (
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"go.wasmcloud.dev/component/gen/wasi/blobstore/container"
+	"go.wasmcloud.dev/component/gen/wasi/blobstore/types"
+	"unsafe"
+)
+
+// CreateContainer represents the imported function "create-container".
+//
+// creates a new empty container
+//
+//	create-container: func(name: container-name) -> result<container, error>
+//
+//go:nosplit
+func CreateContainer(name types.ContainerName) (result cm.Result[string, container.Container, types.Error]) {
+	name0, name1 := cm.LowerString(name)
+	wasmimport_CreateContainer(unsafe.Pointer((*uint8)(name0)), (uint32)(name1), unsafe.Pointer(&result))
+	return
+}
+
+//go:wasmimport wasi:blobstore/blobstore@0.2.0-draft create-container
+//go:noescape
+func wasmimport_CreateContainer(name0 unsafe.Pointer, name1 uint32, result unsafe.Pointer)
+
+// GetContainer represents the imported function "get-container".
+//
+// retrieves a container by name
+//
+//	get-container: func(name: container-name) -> result<container, error>
+//
+//go:nosplit
+func GetContainer(name types.ContainerName) (result cm.Result[string, container.Container, types.Error]) {
+	name0, name1 := cm.LowerString(name)
+	wasmimport_GetContainer(unsafe.Pointer((*uint8)(name0)), (uint32)(name1), unsafe.Pointer(&result))
+	return
+}
+
+//go:wasmimport wasi:blobstore/blobstore@0.2.0-draft get-container
+//go:noescape
+func wasmimport_GetContainer(name0 unsafe.Pointer, name1 uint32, result unsafe.Pointer)
+
+// DeleteContainer represents the imported function "delete-container".
+//
+// deletes a container and all objects within it
+//
+//	delete-container: func(name: container-name) -> result<_, error>
+//
+//go:nosplit
+func DeleteContainer(name types.ContainerName) (result cm.Result[types.Error, struct{}, types.Error]) {
+	name0, name1 := cm.LowerString(name)
+	wasmimport_DeleteContainer(unsafe.Pointer((*uint8)(name0)), (uint32)(name1), unsafe.Pointer(&result))
+	return
+}
+
+//go:wasmimport wasi:blobstore/blobstore@0.2.0-draft delete-container
+//go:noescape
+func wasmimport_DeleteContainer(name0 unsafe.Pointer, name1 uint32, result unsafe.Pointer)
+
+// ContainerExists represents the imported function "container-exists".
+//
+// returns true if the container exists
+//
+//	container-exists: func(name: container-name) -> result<bool, error>
+//
+//go:nosplit
+func ContainerExists(name types.ContainerName) (result cm.Result[string, bool, types.Error]) {
+	name0, name1 := cm.LowerString(name)
+	wasmimport_ContainerExists(unsafe.Pointer((*uint8)(name0)), (uint32)(name1), unsafe.Pointer(&result))
+	return
+}
+
+//go:wasmimport wasi:blobstore/blobstore@0.2.0-draft container-exists
+//go:noescape
+func wasmimport_ContainerExists(name0 unsafe.Pointer, name1 uint32, result unsafe.Pointer)
+
+// CopyObject represents the imported function "copy-object".
+//
+// copies (duplicates) an object, to the same or a different container.
+// returns an error if the target container does not exist.
+// overwrites destination object if it already existed.
+//
+//	copy-object: func(src: object-id, dest: object-id) -> result<_, error>
+//
+//go:nosplit
+func CopyObject(src types.ObjectID, dest types.ObjectID) (result cm.Result[types.Error, struct{}, types.Error]) {
+	src0, src1, src2, src3 := lower_ObjectID(src)
+	dest0, dest1, dest2, dest3 := lower_ObjectID(dest)
+	wasmimport_CopyObject(unsafe.Pointer((*uint8)(src0)), (uint32)(src1), unsafe.Pointer((*uint8)(src2)), (uint32)(src3), unsafe.Pointer((*uint8)(dest0)), (uint32)(dest1), unsafe.Pointer((*uint8)(dest2)), (uint32)(dest3), unsafe.Pointer(&result))
+	return
+}
+
+//go:wasmimport wasi:blobstore/blobstore@0.2.0-draft copy-object
+//go:noescape
+func wasmimport_CopyObject(src0 unsafe.Pointer, src1 uint32, src2 unsafe.Pointer, src3 uint32, dest0 unsafe.Pointer, dest1 uint32, dest2 unsafe.Pointer, dest3 uint32, result unsafe.Pointer)
+
+// MoveObject represents the imported function "move-object".
+//
+// moves or renames an object, to the same or a different container
+// returns an error if the destination container does not exist.
+// overwrites destination object if it already existed.
+//
+//	move-object: func(src: object-id, dest: object-id) -> result<_, error>
+//
+//go:nosplit
+func MoveObject(src types.ObjectID, dest types.ObjectID) (result cm.Result[types.Error, struct{}, types.Error]) {
+	src0, src1, src2, src3 := lower_ObjectID(src)
+	dest0, dest1, dest2, dest3 := lower_ObjectID(dest)
+	wasmimport_MoveObject(unsafe.Pointer((*uint8)(src0)), (uint32)(src1), unsafe.Pointer((*uint8)(src2)), (uint32)(src3), unsafe.Pointer((*uint8)(dest0)), (uint32)(dest1), unsafe.Pointer((*uint8)(dest2)), (uint32)(dest3), unsafe.Pointer(&result))
+	return
+}
+
+//go:wasmimport wasi:blobstore/blobstore@0.2.0-draft move-object
+//go:noescape
+func wasmimport_MoveObject(src0 unsafe.Pointer, src1 uint32, src2 unsafe.Pointer, src3 uint32, dest0 unsafe.Pointer, dest1 uint32, dest2 unsafe.Pointer, dest3 uint32, result unsafe.Pointer)
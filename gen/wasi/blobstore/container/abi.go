@@ -0,0 +1,29 @@
+// Code generated by wit-bindgen-go. DO NOT EDIT.
+
+package container
+
+import (
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"go.wasmcloud.dev/component/gen/wasi/blobstore/types"
+	"unsafe"
+)
+
+// ContainerMetadataShape is used for storage in variant or result types.
+type ContainerMetadataShape struct {
+	shape [unsafe.Sizeof(types.ContainerMetadata{})]byte
+}
+
+// ObjectMetadataShape is used for storage in variant or result types.
+type ObjectMetadataShape struct {
+	shape [unsafe.Sizeof(types.ObjectMetadata{})]byte
+}
+
+// TupleListObjectNameBoolShape is used for storage in variant or result types.
+type TupleListObjectNameBoolShape struct {
+	shape [unsafe.Sizeof(cm.Tuple[cm.List[types.ObjectName], bool]{})]byte
+}
+
+// TupleU64BoolShape is used for storage in variant or result types.
+type TupleU64BoolShape struct {
+	shape [unsafe.Sizeof(cm.Tuple[uint64, bool]{})]byte
+}
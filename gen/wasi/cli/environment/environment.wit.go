@@ -5,6 +5,7 @@ package environment
 
 import (
 	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"unsafe"
 )
 
 // GetEnvironment represents the imported function "get-environment".
@@ -22,13 +23,13 @@ import (
 //
 //go:nosplit
 func GetEnvironment() (result cm.List[[2]string]) {
-	wasmimport_GetEnvironment(&result)
+	wasmimport_GetEnvironment(unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:cli/environment@0.2.0 get-environment
 //go:noescape
-func wasmimport_GetEnvironment(result *cm.List[[2]string])
+func wasmimport_GetEnvironment(result unsafe.Pointer)
 
 // GetArguments represents the imported function "get-arguments".
 //
@@ -38,13 +39,13 @@ func wasmimport_GetEnvironment(result *cm.List[[2]string])
 //
 //go:nosplit
 func GetArguments() (result cm.List[string]) {
-	wasmimport_GetArguments(&result)
+	wasmimport_GetArguments(unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:cli/environment@0.2.0 get-arguments
 //go:noescape
-func wasmimport_GetArguments(result *cm.List[string])
+func wasmimport_GetArguments(result unsafe.Pointer)
 
 // InitialCWD represents the imported function "initial-cwd".
 //
@@ -55,10 +56,10 @@ func wasmimport_GetArguments(result *cm.List[string])
 //
 //go:nosplit
 func InitialCWD() (result cm.Option[string]) {
-	wasmimport_InitialCWD(&result)
+	wasmimport_InitialCWD(unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:cli/environment@0.2.0 initial-cwd
 //go:noescape
-func wasmimport_InitialCWD(result *cm.Option[string])
+func wasmimport_InitialCWD(result unsafe.Pointer)
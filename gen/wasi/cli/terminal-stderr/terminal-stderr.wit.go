@@ -9,6 +9,7 @@ package terminalstderr
 import (
 	"github.com/bytecodealliance/wasm-tools-go/cm"
 	terminaloutput "go.wasmcloud.dev/component/gen/wasi/cli/terminal-output"
+	"unsafe"
 )
 
 // GetTerminalStderr represents the imported function "get-terminal-stderr".
@@ -20,10 +21,10 @@ import (
 //
 //go:nosplit
 func GetTerminalStderr() (result cm.Option[terminaloutput.TerminalOutput]) {
-	wasmimport_GetTerminalStderr(&result)
+	wasmimport_GetTerminalStderr(unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:cli/terminal-stderr@0.2.0 get-terminal-stderr
 //go:noescape
-func wasmimport_GetTerminalStderr(result *cm.Option[terminaloutput.TerminalOutput])
+func wasmimport_GetTerminalStderr(result unsafe.Pointer)
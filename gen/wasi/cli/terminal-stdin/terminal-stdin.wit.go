@@ -9,6 +9,7 @@ package terminalstdin
 import (
 	"github.com/bytecodealliance/wasm-tools-go/cm"
 	terminalinput "go.wasmcloud.dev/component/gen/wasi/cli/terminal-input"
+	"unsafe"
 )
 
 // GetTerminalStdin represents the imported function "get-terminal-stdin".
@@ -20,10 +21,10 @@ import (
 //
 //go:nosplit
 func GetTerminalStdin() (result cm.Option[terminalinput.TerminalInput]) {
-	wasmimport_GetTerminalStdin(&result)
+	wasmimport_GetTerminalStdin(unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:cli/terminal-stdin@0.2.0 get-terminal-stdin
 //go:noescape
-func wasmimport_GetTerminalStdin(result *cm.Option[terminalinput.TerminalInput])
+func wasmimport_GetTerminalStdin(result unsafe.Pointer)
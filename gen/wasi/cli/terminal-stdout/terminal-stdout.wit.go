@@ -9,6 +9,7 @@ package terminalstdout
 import (
 	"github.com/bytecodealliance/wasm-tools-go/cm"
 	terminaloutput "go.wasmcloud.dev/component/gen/wasi/cli/terminal-output"
+	"unsafe"
 )
 
 // GetTerminalStdout represents the imported function "get-terminal-stdout".
@@ -20,10 +21,10 @@ import (
 //
 //go:nosplit
 func GetTerminalStdout() (result cm.Option[terminaloutput.TerminalOutput]) {
-	wasmimport_GetTerminalStdout(&result)
+	wasmimport_GetTerminalStdout(unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:cli/terminal-stdout@0.2.0 get-terminal-stdout
 //go:noescape
-func wasmimport_GetTerminalStdout(result *cm.Option[terminaloutput.TerminalOutput])
+func wasmimport_GetTerminalStdout(result unsafe.Pointer)
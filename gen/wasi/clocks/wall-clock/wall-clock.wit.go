@@ -18,6 +18,8 @@
 // It is intended for reporting the current date and time for humans.
 package wallclock
 
+import "unsafe"
+
 // DateTime represents the record "wasi:clocks/wall-clock@0.2.0#datetime".
 //
 // A time and date in seconds plus nanoseconds.
@@ -51,13 +53,13 @@ type DateTime struct {
 //
 //go:nosplit
 func Now() (result DateTime) {
-	wasmimport_Now(&result)
+	wasmimport_Now(unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:clocks/wall-clock@0.2.0 now
 //go:noescape
-func wasmimport_Now(result *DateTime)
+func wasmimport_Now(result unsafe.Pointer)
 
 // Resolution represents the imported function "resolution".
 //
@@ -69,10 +71,10 @@ func wasmimport_Now(result *DateTime)
 //
 //go:nosplit
 func Resolution() (result DateTime) {
-	wasmimport_Resolution(&result)
+	wasmimport_Resolution(unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:clocks/wall-clock@0.2.0 resolution
 //go:noescape
-func wasmimport_Resolution(result *DateTime)
+func wasmimport_Resolution(result unsafe.Pointer)
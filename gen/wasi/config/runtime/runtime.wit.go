@@ -5,6 +5,7 @@ package runtime
 
 import (
 	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"unsafe"
 )
 
 // ConfigError represents the variant "wasi:config/runtime@0.2.0-draft#config-error".
@@ -59,13 +60,13 @@ func (self *ConfigError) IO() *string {
 //go:nosplit
 func Get(key string) (result cm.Result[OptionStringShape, cm.Option[string], ConfigError]) {
 	key0, key1 := cm.LowerString(key)
-	wasmimport_Get((*uint8)(key0), (uint32)(key1), &result)
+	wasmimport_Get(unsafe.Pointer((*uint8)(key0)), (uint32)(key1), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:config/runtime@0.2.0-draft get
 //go:noescape
-func wasmimport_Get(key0 *uint8, key1 uint32, result *cm.Result[OptionStringShape, cm.Option[string], ConfigError])
+func wasmimport_Get(key0 unsafe.Pointer, key1 uint32, result unsafe.Pointer)
 
 // GetAll represents the imported function "get-all".
 //
@@ -75,10 +76,10 @@ func wasmimport_Get(key0 *uint8, key1 uint32, result *cm.Result[OptionStringShap
 //
 //go:nosplit
 func GetAll() (result cm.Result[ConfigErrorShape, cm.List[[2]string], ConfigError]) {
-	wasmimport_GetAll(&result)
+	wasmimport_GetAll(unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:config/runtime@0.2.0-draft get-all
 //go:noescape
-func wasmimport_GetAll(result *cm.Result[ConfigErrorShape, cm.List[[2]string], ConfigError])
+func wasmimport_GetAll(result unsafe.Pointer)
@@ -6,6 +6,7 @@ package preopens
 import (
 	"github.com/bytecodealliance/wasm-tools-go/cm"
 	"go.wasmcloud.dev/component/gen/wasi/filesystem/types"
+	"unsafe"
 )
 
 // GetDirectories represents the imported function "get-directories".
@@ -16,10 +17,10 @@ import (
 //
 //go:nosplit
 func GetDirectories() (result cm.List[cm.Tuple[types.Descriptor, string]]) {
-	wasmimport_GetDirectories(&result)
+	wasmimport_GetDirectories(unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:filesystem/preopens@0.2.0 get-directories
 //go:noescape
-func wasmimport_GetDirectories(result *cm.List[cm.Tuple[types.Descriptor, string]])
+func wasmimport_GetDirectories(result unsafe.Pointer)
@@ -33,6 +33,7 @@ import (
 	wallclock "go.wasmcloud.dev/component/gen/wasi/clocks/wall-clock"
 	ioerror "go.wasmcloud.dev/component/gen/wasi/io/error"
 	"go.wasmcloud.dev/component/gen/wasi/io/streams"
+	"unsafe"
 )
 
 // FileSize represents the u64 "wasi:filesystem/types@0.2.0#filesize".
@@ -638,13 +639,13 @@ func (self Descriptor) Advise(offset FileSize, length FileSize, advice Advice) (
 	offset0 := (uint64)(offset)
 	length0 := (uint64)(length)
 	advice0 := (uint32)(advice)
-	wasmimport_DescriptorAdvise((uint32)(self0), (uint64)(offset0), (uint64)(length0), (uint32)(advice0), &result)
+	wasmimport_DescriptorAdvise((uint32)(self0), (uint64)(offset0), (uint64)(length0), (uint32)(advice0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:filesystem/types@0.2.0 [method]descriptor.advise
 //go:noescape
-func wasmimport_DescriptorAdvise(self0 uint32, offset0 uint64, length0 uint64, advice0 uint32, result *cm.Result[ErrorCode, struct{}, ErrorCode])
+func wasmimport_DescriptorAdvise(self0 uint32, offset0 uint64, length0 uint64, advice0 uint32, result unsafe.Pointer)
 
 // AppendViaStream represents the imported method "append-via-stream".
 //
@@ -660,13 +661,13 @@ func wasmimport_DescriptorAdvise(self0 uint32, offset0 uint64, length0 uint64, a
 //go:nosplit
 func (self Descriptor) AppendViaStream() (result cm.Result[streams.OutputStream, streams.OutputStream, ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_DescriptorAppendViaStream((uint32)(self0), &result)
+	wasmimport_DescriptorAppendViaStream((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:filesystem/types@0.2.0 [method]descriptor.append-via-stream
 //go:noescape
-func wasmimport_DescriptorAppendViaStream(self0 uint32, result *cm.Result[streams.OutputStream, streams.OutputStream, ErrorCode])
+func wasmimport_DescriptorAppendViaStream(self0 uint32, result unsafe.Pointer)
 
 // CreateDirectoryAt represents the imported method "create-directory-at".
 //
@@ -680,13 +681,13 @@ func wasmimport_DescriptorAppendViaStream(self0 uint32, result *cm.Result[stream
 func (self Descriptor) CreateDirectoryAt(path string) (result cm.Result[ErrorCode, struct{}, ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
 	path0, path1 := cm.LowerString(path)
-	wasmimport_DescriptorCreateDirectoryAt((uint32)(self0), (*uint8)(path0), (uint32)(path1), &result)
+	wasmimport_DescriptorCreateDirectoryAt((uint32)(self0), unsafe.Pointer((*uint8)(path0)), (uint32)(path1), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:filesystem/types@0.2.0 [method]descriptor.create-directory-at
 //go:noescape
-func wasmimport_DescriptorCreateDirectoryAt(self0 uint32, path0 *uint8, path1 uint32, result *cm.Result[ErrorCode, struct{}, ErrorCode])
+func wasmimport_DescriptorCreateDirectoryAt(self0 uint32, path0 unsafe.Pointer, path1 uint32, result unsafe.Pointer)
 
 // GetFlags represents the imported method "get-flags".
 //
@@ -702,13 +703,13 @@ func wasmimport_DescriptorCreateDirectoryAt(self0 uint32, path0 *uint8, path1 ui
 //go:nosplit
 func (self Descriptor) GetFlags() (result cm.Result[DescriptorFlags, DescriptorFlags, ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_DescriptorGetFlags((uint32)(self0), &result)
+	wasmimport_DescriptorGetFlags((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:filesystem/types@0.2.0 [method]descriptor.get-flags
 //go:noescape
-func wasmimport_DescriptorGetFlags(self0 uint32, result *cm.Result[DescriptorFlags, DescriptorFlags, ErrorCode])
+func wasmimport_DescriptorGetFlags(self0 uint32, result unsafe.Pointer)
 
 // GetType represents the imported method "get-type".
 //
@@ -728,13 +729,13 @@ func wasmimport_DescriptorGetFlags(self0 uint32, result *cm.Result[DescriptorFla
 //go:nosplit
 func (self Descriptor) GetType() (result cm.Result[DescriptorType, DescriptorType, ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_DescriptorGetType((uint32)(self0), &result)
+	wasmimport_DescriptorGetType((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:filesystem/types@0.2.0 [method]descriptor.get-type
 //go:noescape
-func wasmimport_DescriptorGetType(self0 uint32, result *cm.Result[DescriptorType, DescriptorType, ErrorCode])
+func wasmimport_DescriptorGetType(self0 uint32, result unsafe.Pointer)
 
 // IsSameObject represents the imported method "is-same-object".
 //
@@ -776,13 +777,13 @@ func (self Descriptor) LinkAt(oldPathFlags PathFlags, oldPath string, newDescrip
 	oldPath0, oldPath1 := cm.LowerString(oldPath)
 	newDescriptor0 := cm.Reinterpret[uint32](newDescriptor)
 	newPath0, newPath1 := cm.LowerString(newPath)
-	wasmimport_DescriptorLinkAt((uint32)(self0), (uint32)(oldPathFlags0), (*uint8)(oldPath0), (uint32)(oldPath1), (uint32)(newDescriptor0), (*uint8)(newPath0), (uint32)(newPath1), &result)
+	wasmimport_DescriptorLinkAt((uint32)(self0), (uint32)(oldPathFlags0), unsafe.Pointer((*uint8)(oldPath0)), (uint32)(oldPath1), (uint32)(newDescriptor0), unsafe.Pointer((*uint8)(newPath0)), (uint32)(newPath1), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:filesystem/types@0.2.0 [method]descriptor.link-at
 //go:noescape
-func wasmimport_DescriptorLinkAt(self0 uint32, oldPathFlags0 uint32, oldPath0 *uint8, oldPath1 uint32, newDescriptor0 uint32, newPath0 *uint8, newPath1 uint32, result *cm.Result[ErrorCode, struct{}, ErrorCode])
+func wasmimport_DescriptorLinkAt(self0 uint32, oldPathFlags0 uint32, oldPath0 unsafe.Pointer, oldPath1 uint32, newDescriptor0 uint32, newPath0 unsafe.Pointer, newPath1 uint32, result unsafe.Pointer)
 
 // MetadataHash represents the imported method "metadata-hash".
 //
@@ -811,13 +812,13 @@ func wasmimport_DescriptorLinkAt(self0 uint32, oldPathFlags0 uint32, oldPath0 *u
 //go:nosplit
 func (self Descriptor) MetadataHash() (result cm.Result[MetadataHashValueShape, MetadataHashValue, ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_DescriptorMetadataHash((uint32)(self0), &result)
+	wasmimport_DescriptorMetadataHash((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:filesystem/types@0.2.0 [method]descriptor.metadata-hash
 //go:noescape
-func wasmimport_DescriptorMetadataHash(self0 uint32, result *cm.Result[MetadataHashValueShape, MetadataHashValue, ErrorCode])
+func wasmimport_DescriptorMetadataHash(self0 uint32, result unsafe.Pointer)
 
 // MetadataHashAt represents the imported method "metadata-hash-at".
 //
@@ -834,13 +835,13 @@ func (self Descriptor) MetadataHashAt(pathFlags PathFlags, path string) (result
 	self0 := cm.Reinterpret[uint32](self)
 	pathFlags0 := (uint32)(pathFlags)
 	path0, path1 := cm.LowerString(path)
-	wasmimport_DescriptorMetadataHashAt((uint32)(self0), (uint32)(pathFlags0), (*uint8)(path0), (uint32)(path1), &result)
+	wasmimport_DescriptorMetadataHashAt((uint32)(self0), (uint32)(pathFlags0), unsafe.Pointer((*uint8)(path0)), (uint32)(path1), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:filesystem/types@0.2.0 [method]descriptor.metadata-hash-at
 //go:noescape
-func wasmimport_DescriptorMetadataHashAt(self0 uint32, pathFlags0 uint32, path0 *uint8, path1 uint32, result *cm.Result[MetadataHashValueShape, MetadataHashValue, ErrorCode])
+func wasmimport_DescriptorMetadataHashAt(self0 uint32, pathFlags0 uint32, path0 unsafe.Pointer, path1 uint32, result unsafe.Pointer)
 
 // OpenAt represents the imported method "open-at".
 //
@@ -873,13 +874,13 @@ func (self Descriptor) OpenAt(pathFlags PathFlags, path string, openFlags OpenFl
 	path0, path1 := cm.LowerString(path)
 	openFlags0 := (uint32)(openFlags)
 	flags0 := (uint32)(flags)
-	wasmimport_DescriptorOpenAt((uint32)(self0), (uint32)(pathFlags0), (*uint8)(path0), (uint32)(path1), (uint32)(openFlags0), (uint32)(flags0), &result)
+	wasmimport_DescriptorOpenAt((uint32)(self0), (uint32)(pathFlags0), unsafe.Pointer((*uint8)(path0)), (uint32)(path1), (uint32)(openFlags0), (uint32)(flags0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:filesystem/types@0.2.0 [method]descriptor.open-at
 //go:noescape
-func wasmimport_DescriptorOpenAt(self0 uint32, pathFlags0 uint32, path0 *uint8, path1 uint32, openFlags0 uint32, flags0 uint32, result *cm.Result[Descriptor, Descriptor, ErrorCode])
+func wasmimport_DescriptorOpenAt(self0 uint32, pathFlags0 uint32, path0 unsafe.Pointer, path1 uint32, openFlags0 uint32, flags0 uint32, result unsafe.Pointer)
 
 // Read represents the imported method "read".
 //
@@ -903,13 +904,13 @@ func (self Descriptor) Read(length FileSize, offset FileSize) (result cm.Result[
 	self0 := cm.Reinterpret[uint32](self)
 	length0 := (uint64)(length)
 	offset0 := (uint64)(offset)
-	wasmimport_DescriptorRead((uint32)(self0), (uint64)(length0), (uint64)(offset0), &result)
+	wasmimport_DescriptorRead((uint32)(self0), (uint64)(length0), (uint64)(offset0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:filesystem/types@0.2.0 [method]descriptor.read
 //go:noescape
-func wasmimport_DescriptorRead(self0 uint32, length0 uint64, offset0 uint64, result *cm.Result[TupleListU8BoolShape, cm.Tuple[cm.List[uint8], bool], ErrorCode])
+func wasmimport_DescriptorRead(self0 uint32, length0 uint64, offset0 uint64, result unsafe.Pointer)
 
 // ReadDirectory represents the imported method "read-directory".
 //
@@ -928,13 +929,13 @@ func wasmimport_DescriptorRead(self0 uint32, length0 uint64, offset0 uint64, res
 //go:nosplit
 func (self Descriptor) ReadDirectory() (result cm.Result[DirectoryEntryStream, DirectoryEntryStream, ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_DescriptorReadDirectory((uint32)(self0), &result)
+	wasmimport_DescriptorReadDirectory((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:filesystem/types@0.2.0 [method]descriptor.read-directory
 //go:noescape
-func wasmimport_DescriptorReadDirectory(self0 uint32, result *cm.Result[DirectoryEntryStream, DirectoryEntryStream, ErrorCode])
+func wasmimport_DescriptorReadDirectory(self0 uint32, result unsafe.Pointer)
 
 // ReadViaStream represents the imported method "read-via-stream".
 //
@@ -953,13 +954,13 @@ func wasmimport_DescriptorReadDirectory(self0 uint32, result *cm.Result[Director
 func (self Descriptor) ReadViaStream(offset FileSize) (result cm.Result[streams.InputStream, streams.InputStream, ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
 	offset0 := (uint64)(offset)
-	wasmimport_DescriptorReadViaStream((uint32)(self0), (uint64)(offset0), &result)
+	wasmimport_DescriptorReadViaStream((uint32)(self0), (uint64)(offset0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:filesystem/types@0.2.0 [method]descriptor.read-via-stream
 //go:noescape
-func wasmimport_DescriptorReadViaStream(self0 uint32, offset0 uint64, result *cm.Result[streams.InputStream, streams.InputStream, ErrorCode])
+func wasmimport_DescriptorReadViaStream(self0 uint32, offset0 uint64, result unsafe.Pointer)
 
 // ReadLinkAt represents the imported method "readlink-at".
 //
@@ -976,13 +977,13 @@ func wasmimport_DescriptorReadViaStream(self0 uint32, offset0 uint64, result *cm
 func (self Descriptor) ReadLinkAt(path string) (result cm.Result[string, string, ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
 	path0, path1 := cm.LowerString(path)
-	wasmimport_DescriptorReadLinkAt((uint32)(self0), (*uint8)(path0), (uint32)(path1), &result)
+	wasmimport_DescriptorReadLinkAt((uint32)(self0), unsafe.Pointer((*uint8)(path0)), (uint32)(path1), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:filesystem/types@0.2.0 [method]descriptor.readlink-at
 //go:noescape
-func wasmimport_DescriptorReadLinkAt(self0 uint32, path0 *uint8, path1 uint32, result *cm.Result[string, string, ErrorCode])
+func wasmimport_DescriptorReadLinkAt(self0 uint32, path0 unsafe.Pointer, path1 uint32, result unsafe.Pointer)
 
 // RemoveDirectoryAt represents the imported method "remove-directory-at".
 //
@@ -998,13 +999,13 @@ func wasmimport_DescriptorReadLinkAt(self0 uint32, path0 *uint8, path1 uint32, r
 func (self Descriptor) RemoveDirectoryAt(path string) (result cm.Result[ErrorCode, struct{}, ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
 	path0, path1 := cm.LowerString(path)
-	wasmimport_DescriptorRemoveDirectoryAt((uint32)(self0), (*uint8)(path0), (uint32)(path1), &result)
+	wasmimport_DescriptorRemoveDirectoryAt((uint32)(self0), unsafe.Pointer((*uint8)(path0)), (uint32)(path1), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:filesystem/types@0.2.0 [method]descriptor.remove-directory-at
 //go:noescape
-func wasmimport_DescriptorRemoveDirectoryAt(self0 uint32, path0 *uint8, path1 uint32, result *cm.Result[ErrorCode, struct{}, ErrorCode])
+func wasmimport_DescriptorRemoveDirectoryAt(self0 uint32, path0 unsafe.Pointer, path1 uint32, result unsafe.Pointer)
 
 // RenameAt represents the imported method "rename-at".
 //
@@ -1021,13 +1022,13 @@ func (self Descriptor) RenameAt(oldPath string, newDescriptor Descriptor, newPat
 	oldPath0, oldPath1 := cm.LowerString(oldPath)
 	newDescriptor0 := cm.Reinterpret[uint32](newDescriptor)
 	newPath0, newPath1 := cm.LowerString(newPath)
-	wasmimport_DescriptorRenameAt((uint32)(self0), (*uint8)(oldPath0), (uint32)(oldPath1), (uint32)(newDescriptor0), (*uint8)(newPath0), (uint32)(newPath1), &result)
+	wasmimport_DescriptorRenameAt((uint32)(self0), unsafe.Pointer((*uint8)(oldPath0)), (uint32)(oldPath1), (uint32)(newDescriptor0), unsafe.Pointer((*uint8)(newPath0)), (uint32)(newPath1), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:filesystem/types@0.2.0 [method]descriptor.rename-at
 //go:noescape
-func wasmimport_DescriptorRenameAt(self0 uint32, oldPath0 *uint8, oldPath1 uint32, newDescriptor0 uint32, newPath0 *uint8, newPath1 uint32, result *cm.Result[ErrorCode, struct{}, ErrorCode])
+func wasmimport_DescriptorRenameAt(self0 uint32, oldPath0 unsafe.Pointer, oldPath1 uint32, newDescriptor0 uint32, newPath0 unsafe.Pointer, newPath1 uint32, result unsafe.Pointer)
 
 // SetSize represents the imported method "set-size".
 //
@@ -1042,13 +1043,13 @@ func wasmimport_DescriptorRenameAt(self0 uint32, oldPath0 *uint8, oldPath1 uint3
 func (self Descriptor) SetSize(size FileSize) (result cm.Result[ErrorCode, struct{}, ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
 	size0 := (uint64)(size)
-	wasmimport_DescriptorSetSize((uint32)(self0), (uint64)(size0), &result)
+	wasmimport_DescriptorSetSize((uint32)(self0), (uint64)(size0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:filesystem/types@0.2.0 [method]descriptor.set-size
 //go:noescape
-func wasmimport_DescriptorSetSize(self0 uint32, size0 uint64, result *cm.Result[ErrorCode, struct{}, ErrorCode])
+func wasmimport_DescriptorSetSize(self0 uint32, size0 uint64, result unsafe.Pointer)
 
 // SetTimes represents the imported method "set-times".
 //
@@ -1066,13 +1067,13 @@ func (self Descriptor) SetTimes(dataAccessTimestamp NewTimestamp, dataModificati
 	self0 := cm.Reinterpret[uint32](self)
 	dataAccessTimestamp0, dataAccessTimestamp1, dataAccessTimestamp2 := lower_NewTimestamp(dataAccessTimestamp)
 	dataModificationTimestamp0, dataModificationTimestamp1, dataModificationTimestamp2 := lower_NewTimestamp(dataModificationTimestamp)
-	wasmimport_DescriptorSetTimes((uint32)(self0), (uint32)(dataAccessTimestamp0), (uint64)(dataAccessTimestamp1), (uint32)(dataAccessTimestamp2), (uint32)(dataModificationTimestamp0), (uint64)(dataModificationTimestamp1), (uint32)(dataModificationTimestamp2), &result)
+	wasmimport_DescriptorSetTimes((uint32)(self0), (uint32)(dataAccessTimestamp0), (uint64)(dataAccessTimestamp1), (uint32)(dataAccessTimestamp2), (uint32)(dataModificationTimestamp0), (uint64)(dataModificationTimestamp1), (uint32)(dataModificationTimestamp2), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:filesystem/types@0.2.0 [method]descriptor.set-times
 //go:noescape
-func wasmimport_DescriptorSetTimes(self0 uint32, dataAccessTimestamp0 uint32, dataAccessTimestamp1 uint64, dataAccessTimestamp2 uint32, dataModificationTimestamp0 uint32, dataModificationTimestamp1 uint64, dataModificationTimestamp2 uint32, result *cm.Result[ErrorCode, struct{}, ErrorCode])
+func wasmimport_DescriptorSetTimes(self0 uint32, dataAccessTimestamp0 uint32, dataAccessTimestamp1 uint64, dataAccessTimestamp2 uint32, dataModificationTimestamp0 uint32, dataModificationTimestamp1 uint64, dataModificationTimestamp2 uint32, result unsafe.Pointer)
 
 // SetTimesAt represents the imported method "set-times-at".
 //
@@ -1093,13 +1094,13 @@ func (self Descriptor) SetTimesAt(pathFlags PathFlags, path string, dataAccessTi
 	path0, path1 := cm.LowerString(path)
 	dataAccessTimestamp0, dataAccessTimestamp1, dataAccessTimestamp2 := lower_NewTimestamp(dataAccessTimestamp)
 	dataModificationTimestamp0, dataModificationTimestamp1, dataModificationTimestamp2 := lower_NewTimestamp(dataModificationTimestamp)
-	wasmimport_DescriptorSetTimesAt((uint32)(self0), (uint32)(pathFlags0), (*uint8)(path0), (uint32)(path1), (uint32)(dataAccessTimestamp0), (uint64)(dataAccessTimestamp1), (uint32)(dataAccessTimestamp2), (uint32)(dataModificationTimestamp0), (uint64)(dataModificationTimestamp1), (uint32)(dataModificationTimestamp2), &result)
+	wasmimport_DescriptorSetTimesAt((uint32)(self0), (uint32)(pathFlags0), unsafe.Pointer((*uint8)(path0)), (uint32)(path1), (uint32)(dataAccessTimestamp0), (uint64)(dataAccessTimestamp1), (uint32)(dataAccessTimestamp2), (uint32)(dataModificationTimestamp0), (uint64)(dataModificationTimestamp1), (uint32)(dataModificationTimestamp2), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:filesystem/types@0.2.0 [method]descriptor.set-times-at
 //go:noescape
-func wasmimport_DescriptorSetTimesAt(self0 uint32, pathFlags0 uint32, path0 *uint8, path1 uint32, dataAccessTimestamp0 uint32, dataAccessTimestamp1 uint64, dataAccessTimestamp2 uint32, dataModificationTimestamp0 uint32, dataModificationTimestamp1 uint64, dataModificationTimestamp2 uint32, result *cm.Result[ErrorCode, struct{}, ErrorCode])
+func wasmimport_DescriptorSetTimesAt(self0 uint32, pathFlags0 uint32, path0 unsafe.Pointer, path1 uint32, dataAccessTimestamp0 uint32, dataAccessTimestamp1 uint64, dataAccessTimestamp2 uint32, dataModificationTimestamp0 uint32, dataModificationTimestamp1 uint64, dataModificationTimestamp2 uint32, result unsafe.Pointer)
 
 // Stat represents the imported method "stat".
 //
@@ -1118,13 +1119,13 @@ func wasmimport_DescriptorSetTimesAt(self0 uint32, pathFlags0 uint32, path0 *uin
 //go:nosplit
 func (self Descriptor) Stat() (result cm.Result[DescriptorStatShape, DescriptorStat, ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_DescriptorStat((uint32)(self0), &result)
+	wasmimport_DescriptorStat((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:filesystem/types@0.2.0 [method]descriptor.stat
 //go:noescape
-func wasmimport_DescriptorStat(self0 uint32, result *cm.Result[DescriptorStatShape, DescriptorStat, ErrorCode])
+func wasmimport_DescriptorStat(self0 uint32, result unsafe.Pointer)
 
 // StatAt represents the imported method "stat-at".
 //
@@ -1144,13 +1145,13 @@ func (self Descriptor) StatAt(pathFlags PathFlags, path string) (result cm.Resul
 	self0 := cm.Reinterpret[uint32](self)
 	pathFlags0 := (uint32)(pathFlags)
 	path0, path1 := cm.LowerString(path)
-	wasmimport_DescriptorStatAt((uint32)(self0), (uint32)(pathFlags0), (*uint8)(path0), (uint32)(path1), &result)
+	wasmimport_DescriptorStatAt((uint32)(self0), (uint32)(pathFlags0), unsafe.Pointer((*uint8)(path0)), (uint32)(path1), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:filesystem/types@0.2.0 [method]descriptor.stat-at
 //go:noescape
-func wasmimport_DescriptorStatAt(self0 uint32, pathFlags0 uint32, path0 *uint8, path1 uint32, result *cm.Result[DescriptorStatShape, DescriptorStat, ErrorCode])
+func wasmimport_DescriptorStatAt(self0 uint32, pathFlags0 uint32, path0 unsafe.Pointer, path1 uint32, result unsafe.Pointer)
 
 // SymlinkAt represents the imported method "symlink-at".
 //
@@ -1168,13 +1169,13 @@ func (self Descriptor) SymlinkAt(oldPath string, newPath string) (result cm.Resu
 	self0 := cm.Reinterpret[uint32](self)
 	oldPath0, oldPath1 := cm.LowerString(oldPath)
 	newPath0, newPath1 := cm.LowerString(newPath)
-	wasmimport_DescriptorSymlinkAt((uint32)(self0), (*uint8)(oldPath0), (uint32)(oldPath1), (*uint8)(newPath0), (uint32)(newPath1), &result)
+	wasmimport_DescriptorSymlinkAt((uint32)(self0), unsafe.Pointer((*uint8)(oldPath0)), (uint32)(oldPath1), unsafe.Pointer((*uint8)(newPath0)), (uint32)(newPath1), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:filesystem/types@0.2.0 [method]descriptor.symlink-at
 //go:noescape
-func wasmimport_DescriptorSymlinkAt(self0 uint32, oldPath0 *uint8, oldPath1 uint32, newPath0 *uint8, newPath1 uint32, result *cm.Result[ErrorCode, struct{}, ErrorCode])
+func wasmimport_DescriptorSymlinkAt(self0 uint32, oldPath0 unsafe.Pointer, oldPath1 uint32, newPath0 unsafe.Pointer, newPath1 uint32, result unsafe.Pointer)
 
 // Sync represents the imported method "sync".
 //
@@ -1190,13 +1191,13 @@ func wasmimport_DescriptorSymlinkAt(self0 uint32, oldPath0 *uint8, oldPath1 uint
 //go:nosplit
 func (self Descriptor) Sync() (result cm.Result[ErrorCode, struct{}, ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_DescriptorSync((uint32)(self0), &result)
+	wasmimport_DescriptorSync((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:filesystem/types@0.2.0 [method]descriptor.sync
 //go:noescape
-func wasmimport_DescriptorSync(self0 uint32, result *cm.Result[ErrorCode, struct{}, ErrorCode])
+func wasmimport_DescriptorSync(self0 uint32, result unsafe.Pointer)
 
 // SyncData represents the imported method "sync-data".
 //
@@ -1212,13 +1213,13 @@ func wasmimport_DescriptorSync(self0 uint32, result *cm.Result[ErrorCode, struct
 //go:nosplit
 func (self Descriptor) SyncData() (result cm.Result[ErrorCode, struct{}, ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_DescriptorSyncData((uint32)(self0), &result)
+	wasmimport_DescriptorSyncData((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:filesystem/types@0.2.0 [method]descriptor.sync-data
 //go:noescape
-func wasmimport_DescriptorSyncData(self0 uint32, result *cm.Result[ErrorCode, struct{}, ErrorCode])
+func wasmimport_DescriptorSyncData(self0 uint32, result unsafe.Pointer)
 
 // UnlinkFileAt represents the imported method "unlink-file-at".
 //
@@ -1233,13 +1234,13 @@ func wasmimport_DescriptorSyncData(self0 uint32, result *cm.Result[ErrorCode, st
 func (self Descriptor) UnlinkFileAt(path string) (result cm.Result[ErrorCode, struct{}, ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
 	path0, path1 := cm.LowerString(path)
-	wasmimport_DescriptorUnlinkFileAt((uint32)(self0), (*uint8)(path0), (uint32)(path1), &result)
+	wasmimport_DescriptorUnlinkFileAt((uint32)(self0), unsafe.Pointer((*uint8)(path0)), (uint32)(path1), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:filesystem/types@0.2.0 [method]descriptor.unlink-file-at
 //go:noescape
-func wasmimport_DescriptorUnlinkFileAt(self0 uint32, path0 *uint8, path1 uint32, result *cm.Result[ErrorCode, struct{}, ErrorCode])
+func wasmimport_DescriptorUnlinkFileAt(self0 uint32, path0 unsafe.Pointer, path1 uint32, result unsafe.Pointer)
 
 // Write represents the imported method "write".
 //
@@ -1260,13 +1261,13 @@ func (self Descriptor) Write(buffer cm.List[uint8], offset FileSize) (result cm.
 	self0 := cm.Reinterpret[uint32](self)
 	buffer0, buffer1 := cm.LowerList(buffer)
 	offset0 := (uint64)(offset)
-	wasmimport_DescriptorWrite((uint32)(self0), (*uint8)(buffer0), (uint32)(buffer1), (uint64)(offset0), &result)
+	wasmimport_DescriptorWrite((uint32)(self0), unsafe.Pointer((*uint8)(buffer0)), (uint32)(buffer1), (uint64)(offset0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:filesystem/types@0.2.0 [method]descriptor.write
 //go:noescape
-func wasmimport_DescriptorWrite(self0 uint32, buffer0 *uint8, buffer1 uint32, offset0 uint64, result *cm.Result[uint64, FileSize, ErrorCode])
+func wasmimport_DescriptorWrite(self0 uint32, buffer0 unsafe.Pointer, buffer1 uint32, offset0 uint64, result unsafe.Pointer)
 
 // WriteViaStream represents the imported method "write-via-stream".
 //
@@ -1283,13 +1284,13 @@ func wasmimport_DescriptorWrite(self0 uint32, buffer0 *uint8, buffer1 uint32, of
 func (self Descriptor) WriteViaStream(offset FileSize) (result cm.Result[streams.OutputStream, streams.OutputStream, ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
 	offset0 := (uint64)(offset)
-	wasmimport_DescriptorWriteViaStream((uint32)(self0), (uint64)(offset0), &result)
+	wasmimport_DescriptorWriteViaStream((uint32)(self0), (uint64)(offset0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:filesystem/types@0.2.0 [method]descriptor.write-via-stream
 //go:noescape
-func wasmimport_DescriptorWriteViaStream(self0 uint32, offset0 uint64, result *cm.Result[streams.OutputStream, streams.OutputStream, ErrorCode])
+func wasmimport_DescriptorWriteViaStream(self0 uint32, offset0 uint64, result unsafe.Pointer)
 
 // DirectoryEntryStream represents the imported resource "wasi:filesystem/types@0.2.0#directory-entry-stream".
 //
@@ -1322,13 +1323,13 @@ func wasmimport_DirectoryEntryStreamResourceDrop(self0 uint32)
 //go:nosplit
 func (self DirectoryEntryStream) ReadDirectoryEntry() (result cm.Result[OptionDirectoryEntryShape, cm.Option[DirectoryEntry], ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_DirectoryEntryStreamReadDirectoryEntry((uint32)(self0), &result)
+	wasmimport_DirectoryEntryStreamReadDirectoryEntry((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:filesystem/types@0.2.0 [method]directory-entry-stream.read-directory-entry
 //go:noescape
-func wasmimport_DirectoryEntryStreamReadDirectoryEntry(self0 uint32, result *cm.Result[OptionDirectoryEntryShape, cm.Option[DirectoryEntry], ErrorCode])
+func wasmimport_DirectoryEntryStreamReadDirectoryEntry(self0 uint32, result unsafe.Pointer)
 
 // FilesystemErrorCode represents the imported function "filesystem-error-code".
 //
@@ -1348,10 +1349,10 @@ func wasmimport_DirectoryEntryStreamReadDirectoryEntry(self0 uint32, result *cm.
 //go:nosplit
 func FilesystemErrorCode(err ioerror.Error) (result cm.Option[ErrorCode]) {
 	err0 := cm.Reinterpret[uint32](err)
-	wasmimport_FilesystemErrorCode((uint32)(err0), &result)
+	wasmimport_FilesystemErrorCode((uint32)(err0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:filesystem/types@0.2.0 filesystem-error-code
 //go:noescape
-func wasmimport_FilesystemErrorCode(err0 uint32, result *cm.Option[ErrorCode])
+func wasmimport_FilesystemErrorCode(err0 uint32, result unsafe.Pointer)
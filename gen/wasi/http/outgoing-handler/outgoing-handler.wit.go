@@ -9,6 +9,7 @@ package outgoinghandler
 import (
 	"github.com/bytecodealliance/wasm-tools-go/cm"
 	"go.wasmcloud.dev/component/gen/wasi/http/types"
+	"unsafe"
 )
 
 // Handle represents the imported function "handle".
@@ -31,10 +32,10 @@ import (
 func Handle(request types.OutgoingRequest, options cm.Option[types.RequestOptions]) (result cm.Result[ErrorCodeShape, types.FutureIncomingResponse, types.ErrorCode]) {
 	request0 := cm.Reinterpret[uint32](request)
 	options0, options1 := lower_OptionRequestOptions(options)
-	wasmimport_Handle((uint32)(request0), (uint32)(options0), (uint32)(options1), &result)
+	wasmimport_Handle((uint32)(request0), (uint32)(options0), (uint32)(options1), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:http/outgoing-handler@0.2.0 handle
 //go:noescape
-func wasmimport_Handle(request0 uint32, options0 uint32, options1 uint32, result *cm.Result[ErrorCodeShape, types.FutureIncomingResponse, types.ErrorCode])
+func wasmimport_Handle(request0 uint32, options0 uint32, options1 uint32, result unsafe.Pointer)
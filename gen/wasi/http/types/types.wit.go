@@ -13,6 +13,7 @@ import (
 	ioerror "go.wasmcloud.dev/component/gen/wasi/io/error"
 	"go.wasmcloud.dev/component/gen/wasi/io/poll"
 	"go.wasmcloud.dev/component/gen/wasi/io/streams"
+	"unsafe"
 )
 
 // Method represents the variant "wasi:http/types@0.2.0#method".
@@ -820,13 +821,13 @@ func wasmimport_NewFields() (result0 uint32)
 //go:nosplit
 func FieldsFromList(entries cm.List[cm.Tuple[FieldKey, FieldValue]]) (result cm.Result[Fields, Fields, HeaderError]) {
 	entries0, entries1 := cm.LowerList(entries)
-	wasmimport_FieldsFromList((*cm.Tuple[FieldKey, FieldValue])(entries0), (uint32)(entries1), &result)
+	wasmimport_FieldsFromList(unsafe.Pointer((*cm.Tuple[FieldKey, FieldValue])(entries0)), (uint32)(entries1), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:http/types@0.2.0 [static]fields.from-list
 //go:noescape
-func wasmimport_FieldsFromList(entries0 *cm.Tuple[FieldKey, FieldValue], entries1 uint32, result *cm.Result[Fields, Fields, HeaderError])
+func wasmimport_FieldsFromList(entries0 unsafe.Pointer, entries1 uint32, result unsafe.Pointer)
 
 // Append represents the imported method "append".
 //
@@ -842,13 +843,13 @@ func (self Fields) Append(name FieldKey, value FieldValue) (result cm.Result[Hea
 	self0 := cm.Reinterpret[uint32](self)
 	name0, name1 := cm.LowerString(name)
 	value0, value1 := cm.LowerList(value)
-	wasmimport_FieldsAppend((uint32)(self0), (*uint8)(name0), (uint32)(name1), (*uint8)(value0), (uint32)(value1), &result)
+	wasmimport_FieldsAppend((uint32)(self0), unsafe.Pointer((*uint8)(name0)), (uint32)(name1), unsafe.Pointer((*uint8)(value0)), (uint32)(value1), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:http/types@0.2.0 [method]fields.append
 //go:noescape
-func wasmimport_FieldsAppend(self0 uint32, name0 *uint8, name1 uint32, value0 *uint8, value1 uint32, result *cm.Result[HeaderError, struct{}, HeaderError])
+func wasmimport_FieldsAppend(self0 uint32, name0 unsafe.Pointer, name1 uint32, value0 unsafe.Pointer, value1 uint32, result unsafe.Pointer)
 
 // Clone represents the imported method "clone".
 //
@@ -883,13 +884,13 @@ func wasmimport_FieldsClone(self0 uint32) (result0 uint32)
 func (self Fields) Delete(name FieldKey) (result cm.Result[HeaderError, struct{}, HeaderError]) {
 	self0 := cm.Reinterpret[uint32](self)
 	name0, name1 := cm.LowerString(name)
-	wasmimport_FieldsDelete((uint32)(self0), (*uint8)(name0), (uint32)(name1), &result)
+	wasmimport_FieldsDelete((uint32)(self0), unsafe.Pointer((*uint8)(name0)), (uint32)(name1), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:http/types@0.2.0 [method]fields.delete
 //go:noescape
-func wasmimport_FieldsDelete(self0 uint32, name0 *uint8, name1 uint32, result *cm.Result[HeaderError, struct{}, HeaderError])
+func wasmimport_FieldsDelete(self0 uint32, name0 unsafe.Pointer, name1 uint32, result unsafe.Pointer)
 
 // Entries represents the imported method "entries".
 //
@@ -905,13 +906,13 @@ func wasmimport_FieldsDelete(self0 uint32, name0 *uint8, name1 uint32, result *c
 //go:nosplit
 func (self Fields) Entries() (result cm.List[cm.Tuple[FieldKey, FieldValue]]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_FieldsEntries((uint32)(self0), &result)
+	wasmimport_FieldsEntries((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:http/types@0.2.0 [method]fields.entries
 //go:noescape
-func wasmimport_FieldsEntries(self0 uint32, result *cm.List[cm.Tuple[FieldKey, FieldValue]])
+func wasmimport_FieldsEntries(self0 uint32, result unsafe.Pointer)
 
 // Get represents the imported method "get".
 //
@@ -926,13 +927,13 @@ func wasmimport_FieldsEntries(self0 uint32, result *cm.List[cm.Tuple[FieldKey, F
 func (self Fields) Get(name FieldKey) (result cm.List[FieldValue]) {
 	self0 := cm.Reinterpret[uint32](self)
 	name0, name1 := cm.LowerString(name)
-	wasmimport_FieldsGet((uint32)(self0), (*uint8)(name0), (uint32)(name1), &result)
+	wasmimport_FieldsGet((uint32)(self0), unsafe.Pointer((*uint8)(name0)), (uint32)(name1), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:http/types@0.2.0 [method]fields.get
 //go:noescape
-func wasmimport_FieldsGet(self0 uint32, name0 *uint8, name1 uint32, result *cm.List[FieldValue])
+func wasmimport_FieldsGet(self0 uint32, name0 unsafe.Pointer, name1 uint32, result unsafe.Pointer)
 
 // Has represents the imported method "has".
 //
@@ -945,14 +946,14 @@ func wasmimport_FieldsGet(self0 uint32, name0 *uint8, name1 uint32, result *cm.L
 func (self Fields) Has(name FieldKey) (result bool) {
 	self0 := cm.Reinterpret[uint32](self)
 	name0, name1 := cm.LowerString(name)
-	result0 := wasmimport_FieldsHas((uint32)(self0), (*uint8)(name0), (uint32)(name1))
+	result0 := wasmimport_FieldsHas((uint32)(self0), unsafe.Pointer((*uint8)(name0)), (uint32)(name1))
 	result = cm.U32ToBool((uint32)(result0))
 	return
 }
 
 //go:wasmimport wasi:http/types@0.2.0 [method]fields.has
 //go:noescape
-func wasmimport_FieldsHas(self0 uint32, name0 *uint8, name1 uint32) (result0 uint32)
+func wasmimport_FieldsHas(self0 uint32, name0 unsafe.Pointer, name1 uint32) (result0 uint32)
 
 // Set represents the imported method "set".
 //
@@ -968,13 +969,13 @@ func (self Fields) Set(name FieldKey, value cm.List[FieldValue]) (result cm.Resu
 	self0 := cm.Reinterpret[uint32](self)
 	name0, name1 := cm.LowerString(name)
 	value0, value1 := cm.LowerList(value)
-	wasmimport_FieldsSet((uint32)(self0), (*uint8)(name0), (uint32)(name1), (*FieldValue)(value0), (uint32)(value1), &result)
+	wasmimport_FieldsSet((uint32)(self0), unsafe.Pointer((*uint8)(name0)), (uint32)(name1), unsafe.Pointer((*FieldValue)(value0)), (uint32)(value1), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:http/types@0.2.0 [method]fields.set
 //go:noescape
-func wasmimport_FieldsSet(self0 uint32, name0 *uint8, name1 uint32, value0 *FieldValue, value1 uint32, result *cm.Result[HeaderError, struct{}, HeaderError])
+func wasmimport_FieldsSet(self0 uint32, name0 unsafe.Pointer, name1 uint32, value0 unsafe.Pointer, value1 uint32, result unsafe.Pointer)
 
 // IncomingRequest represents the imported resource "wasi:http/types@0.2.0#incoming-request".
 //
@@ -1007,13 +1008,13 @@ func wasmimport_IncomingRequestResourceDrop(self0 uint32)
 //go:nosplit
 func (self IncomingRequest) Authority() (result cm.Option[string]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_IncomingRequestAuthority((uint32)(self0), &result)
+	wasmimport_IncomingRequestAuthority((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:http/types@0.2.0 [method]incoming-request.authority
 //go:noescape
-func wasmimport_IncomingRequestAuthority(self0 uint32, result *cm.Option[string])
+func wasmimport_IncomingRequestAuthority(self0 uint32, result unsafe.Pointer)
 
 // Consume represents the imported method "consume".
 //
@@ -1025,13 +1026,13 @@ func wasmimport_IncomingRequestAuthority(self0 uint32, result *cm.Option[string]
 //go:nosplit
 func (self IncomingRequest) Consume() (result cm.Result[IncomingBody, IncomingBody, struct{}]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_IncomingRequestConsume((uint32)(self0), &result)
+	wasmimport_IncomingRequestConsume((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:http/types@0.2.0 [method]incoming-request.consume
 //go:noescape
-func wasmimport_IncomingRequestConsume(self0 uint32, result *cm.Result[IncomingBody, IncomingBody, struct{}])
+func wasmimport_IncomingRequestConsume(self0 uint32, result unsafe.Pointer)
 
 // Headers represents the imported method "headers".
 //
@@ -1067,13 +1068,13 @@ func wasmimport_IncomingRequestHeaders(self0 uint32) (result0 uint32)
 //go:nosplit
 func (self IncomingRequest) Method() (result Method) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_IncomingRequestMethod((uint32)(self0), &result)
+	wasmimport_IncomingRequestMethod((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:http/types@0.2.0 [method]incoming-request.method
 //go:noescape
-func wasmimport_IncomingRequestMethod(self0 uint32, result *Method)
+func wasmimport_IncomingRequestMethod(self0 uint32, result unsafe.Pointer)
 
 // PathWithQuery represents the imported method "path-with-query".
 //
@@ -1084,13 +1085,13 @@ func wasmimport_IncomingRequestMethod(self0 uint32, result *Method)
 //go:nosplit
 func (self IncomingRequest) PathWithQuery() (result cm.Option[string]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_IncomingRequestPathWithQuery((uint32)(self0), &result)
+	wasmimport_IncomingRequestPathWithQuery((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:http/types@0.2.0 [method]incoming-request.path-with-query
 //go:noescape
-func wasmimport_IncomingRequestPathWithQuery(self0 uint32, result *cm.Option[string])
+func wasmimport_IncomingRequestPathWithQuery(self0 uint32, result unsafe.Pointer)
 
 // Scheme represents the imported method "scheme".
 //
@@ -1101,13 +1102,13 @@ func wasmimport_IncomingRequestPathWithQuery(self0 uint32, result *cm.Option[str
 //go:nosplit
 func (self IncomingRequest) Scheme() (result cm.Option[Scheme]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_IncomingRequestScheme((uint32)(self0), &result)
+	wasmimport_IncomingRequestScheme((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:http/types@0.2.0 [method]incoming-request.scheme
 //go:noescape
-func wasmimport_IncomingRequestScheme(self0 uint32, result *cm.Option[Scheme])
+func wasmimport_IncomingRequestScheme(self0 uint32, result unsafe.Pointer)
 
 // OutgoingRequest represents the imported resource "wasi:http/types@0.2.0#outgoing-request".
 //
@@ -1169,13 +1170,13 @@ func wasmimport_NewOutgoingRequest(headers0 uint32) (result0 uint32)
 //go:nosplit
 func (self OutgoingRequest) Authority() (result cm.Option[string]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_OutgoingRequestAuthority((uint32)(self0), &result)
+	wasmimport_OutgoingRequestAuthority((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:http/types@0.2.0 [method]outgoing-request.authority
 //go:noescape
-func wasmimport_OutgoingRequestAuthority(self0 uint32, result *cm.Option[string])
+func wasmimport_OutgoingRequestAuthority(self0 uint32, result unsafe.Pointer)
 
 // Body represents the imported method "body".
 //
@@ -1191,13 +1192,13 @@ func wasmimport_OutgoingRequestAuthority(self0 uint32, result *cm.Option[string]
 //go:nosplit
 func (self OutgoingRequest) Body() (result cm.Result[OutgoingBody, OutgoingBody, struct{}]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_OutgoingRequestBody((uint32)(self0), &result)
+	wasmimport_OutgoingRequestBody((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:http/types@0.2.0 [method]outgoing-request.body
 //go:noescape
-func wasmimport_OutgoingRequestBody(self0 uint32, result *cm.Result[OutgoingBody, OutgoingBody, struct{}])
+func wasmimport_OutgoingRequestBody(self0 uint32, result unsafe.Pointer)
 
 // Headers represents the imported method "headers".
 //
@@ -1233,13 +1234,13 @@ func wasmimport_OutgoingRequestHeaders(self0 uint32) (result0 uint32)
 //go:nosplit
 func (self OutgoingRequest) Method() (result Method) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_OutgoingRequestMethod((uint32)(self0), &result)
+	wasmimport_OutgoingRequestMethod((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:http/types@0.2.0 [method]outgoing-request.method
 //go:noescape
-func wasmimport_OutgoingRequestMethod(self0 uint32, result *Method)
+func wasmimport_OutgoingRequestMethod(self0 uint32, result unsafe.Pointer)
 
 // PathWithQuery represents the imported method "path-with-query".
 //
@@ -1251,13 +1252,13 @@ func wasmimport_OutgoingRequestMethod(self0 uint32, result *Method)
 //go:nosplit
 func (self OutgoingRequest) PathWithQuery() (result cm.Option[string]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_OutgoingRequestPathWithQuery((uint32)(self0), &result)
+	wasmimport_OutgoingRequestPathWithQuery((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:http/types@0.2.0 [method]outgoing-request.path-with-query
 //go:noescape
-func wasmimport_OutgoingRequestPathWithQuery(self0 uint32, result *cm.Option[string])
+func wasmimport_OutgoingRequestPathWithQuery(self0 uint32, result unsafe.Pointer)
 
 // Scheme represents the imported method "scheme".
 //
@@ -1269,13 +1270,13 @@ func wasmimport_OutgoingRequestPathWithQuery(self0 uint32, result *cm.Option[str
 //go:nosplit
 func (self OutgoingRequest) Scheme() (result cm.Option[Scheme]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_OutgoingRequestScheme((uint32)(self0), &result)
+	wasmimport_OutgoingRequestScheme((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:http/types@0.2.0 [method]outgoing-request.scheme
 //go:noescape
-func wasmimport_OutgoingRequestScheme(self0 uint32, result *cm.Option[Scheme])
+func wasmimport_OutgoingRequestScheme(self0 uint32, result unsafe.Pointer)
 
 // SetAuthority represents the imported method "set-authority".
 //
@@ -1290,14 +1291,14 @@ func wasmimport_OutgoingRequestScheme(self0 uint32, result *cm.Option[Scheme])
 func (self OutgoingRequest) SetAuthority(authority cm.Option[string]) (result cm.BoolResult) {
 	self0 := cm.Reinterpret[uint32](self)
 	authority0, authority1, authority2 := lower_OptionString(authority)
-	result0 := wasmimport_OutgoingRequestSetAuthority((uint32)(self0), (uint32)(authority0), (*uint8)(authority1), (uint32)(authority2))
+	result0 := wasmimport_OutgoingRequestSetAuthority((uint32)(self0), (uint32)(authority0), unsafe.Pointer((*uint8)(authority1)), (uint32)(authority2))
 	result = (cm.BoolResult)(cm.U32ToBool((uint32)(result0)))
 	return
 }
 
 //go:wasmimport wasi:http/types@0.2.0 [method]outgoing-request.set-authority
 //go:noescape
-func wasmimport_OutgoingRequestSetAuthority(self0 uint32, authority0 uint32, authority1 *uint8, authority2 uint32) (result0 uint32)
+func wasmimport_OutgoingRequestSetAuthority(self0 uint32, authority0 uint32, authority1 unsafe.Pointer, authority2 uint32) (result0 uint32)
 
 // SetMethod represents the imported method "set-method".
 //
@@ -1310,14 +1311,14 @@ func wasmimport_OutgoingRequestSetAuthority(self0 uint32, authority0 uint32, aut
 func (self OutgoingRequest) SetMethod(method Method) (result cm.BoolResult) {
 	self0 := cm.Reinterpret[uint32](self)
 	method0, method1, method2 := lower_Method(method)
-	result0 := wasmimport_OutgoingRequestSetMethod((uint32)(self0), (uint32)(method0), (*uint8)(method1), (uint32)(method2))
+	result0 := wasmimport_OutgoingRequestSetMethod((uint32)(self0), (uint32)(method0), unsafe.Pointer((*uint8)(method1)), (uint32)(method2))
 	result = (cm.BoolResult)(cm.U32ToBool((uint32)(result0)))
 	return
 }
 
 //go:wasmimport wasi:http/types@0.2.0 [method]outgoing-request.set-method
 //go:noescape
-func wasmimport_OutgoingRequestSetMethod(self0 uint32, method0 uint32, method1 *uint8, method2 uint32) (result0 uint32)
+func wasmimport_OutgoingRequestSetMethod(self0 uint32, method0 uint32, method1 unsafe.Pointer, method2 uint32) (result0 uint32)
 
 // SetPathWithQuery represents the imported method "set-path-with-query".
 //
@@ -1331,14 +1332,14 @@ func wasmimport_OutgoingRequestSetMethod(self0 uint32, method0 uint32, method1 *
 func (self OutgoingRequest) SetPathWithQuery(pathWithQuery cm.Option[string]) (result cm.BoolResult) {
 	self0 := cm.Reinterpret[uint32](self)
 	pathWithQuery0, pathWithQuery1, pathWithQuery2 := lower_OptionString(pathWithQuery)
-	result0 := wasmimport_OutgoingRequestSetPathWithQuery((uint32)(self0), (uint32)(pathWithQuery0), (*uint8)(pathWithQuery1), (uint32)(pathWithQuery2))
+	result0 := wasmimport_OutgoingRequestSetPathWithQuery((uint32)(self0), (uint32)(pathWithQuery0), unsafe.Pointer((*uint8)(pathWithQuery1)), (uint32)(pathWithQuery2))
 	result = (cm.BoolResult)(cm.U32ToBool((uint32)(result0)))
 	return
 }
 
 //go:wasmimport wasi:http/types@0.2.0 [method]outgoing-request.set-path-with-query
 //go:noescape
-func wasmimport_OutgoingRequestSetPathWithQuery(self0 uint32, pathWithQuery0 uint32, pathWithQuery1 *uint8, pathWithQuery2 uint32) (result0 uint32)
+func wasmimport_OutgoingRequestSetPathWithQuery(self0 uint32, pathWithQuery0 uint32, pathWithQuery1 unsafe.Pointer, pathWithQuery2 uint32) (result0 uint32)
 
 // SetScheme represents the imported method "set-scheme".
 //
@@ -1352,14 +1353,14 @@ func wasmimport_OutgoingRequestSetPathWithQuery(self0 uint32, pathWithQuery0 uin
 func (self OutgoingRequest) SetScheme(scheme cm.Option[Scheme]) (result cm.BoolResult) {
 	self0 := cm.Reinterpret[uint32](self)
 	scheme0, scheme1, scheme2, scheme3 := lower_OptionScheme(scheme)
-	result0 := wasmimport_OutgoingRequestSetScheme((uint32)(self0), (uint32)(scheme0), (uint32)(scheme1), (*uint8)(scheme2), (uint32)(scheme3))
+	result0 := wasmimport_OutgoingRequestSetScheme((uint32)(self0), (uint32)(scheme0), (uint32)(scheme1), unsafe.Pointer((*uint8)(scheme2)), (uint32)(scheme3))
 	result = (cm.BoolResult)(cm.U32ToBool((uint32)(result0)))
 	return
 }
 
 //go:wasmimport wasi:http/types@0.2.0 [method]outgoing-request.set-scheme
 //go:noescape
-func wasmimport_OutgoingRequestSetScheme(self0 uint32, scheme0 uint32, scheme1 uint32, scheme2 *uint8, scheme3 uint32) (result0 uint32)
+func wasmimport_OutgoingRequestSetScheme(self0 uint32, scheme0 uint32, scheme1 uint32, scheme2 unsafe.Pointer, scheme3 uint32) (result0 uint32)
 
 // RequestOptions represents the imported resource "wasi:http/types@0.2.0#request-options".
 //
@@ -1415,13 +1416,13 @@ func wasmimport_NewRequestOptions() (result0 uint32)
 //go:nosplit
 func (self RequestOptions) BetweenBytesTimeout() (result cm.Option[monotonicclock.Duration]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_RequestOptionsBetweenBytesTimeout((uint32)(self0), &result)
+	wasmimport_RequestOptionsBetweenBytesTimeout((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:http/types@0.2.0 [method]request-options.between-bytes-timeout
 //go:noescape
-func wasmimport_RequestOptionsBetweenBytesTimeout(self0 uint32, result *cm.Option[monotonicclock.Duration])
+func wasmimport_RequestOptionsBetweenBytesTimeout(self0 uint32, result unsafe.Pointer)
 
 // ConnectTimeout represents the imported method "connect-timeout".
 //
@@ -1432,13 +1433,13 @@ func wasmimport_RequestOptionsBetweenBytesTimeout(self0 uint32, result *cm.Optio
 //go:nosplit
 func (self RequestOptions) ConnectTimeout() (result cm.Option[monotonicclock.Duration]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_RequestOptionsConnectTimeout((uint32)(self0), &result)
+	wasmimport_RequestOptionsConnectTimeout((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:http/types@0.2.0 [method]request-options.connect-timeout
 //go:noescape
-func wasmimport_RequestOptionsConnectTimeout(self0 uint32, result *cm.Option[monotonicclock.Duration])
+func wasmimport_RequestOptionsConnectTimeout(self0 uint32, result unsafe.Pointer)
 
 // FirstByteTimeout represents the imported method "first-byte-timeout".
 //
@@ -1449,13 +1450,13 @@ func wasmimport_RequestOptionsConnectTimeout(self0 uint32, result *cm.Option[mon
 //go:nosplit
 func (self RequestOptions) FirstByteTimeout() (result cm.Option[monotonicclock.Duration]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_RequestOptionsFirstByteTimeout((uint32)(self0), &result)
+	wasmimport_RequestOptionsFirstByteTimeout((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:http/types@0.2.0 [method]request-options.first-byte-timeout
 //go:noescape
-func wasmimport_RequestOptionsFirstByteTimeout(self0 uint32, result *cm.Option[monotonicclock.Duration])
+func wasmimport_RequestOptionsFirstByteTimeout(self0 uint32, result unsafe.Pointer)
 
 // SetBetweenBytesTimeout represents the imported method "set-between-bytes-timeout".
 //
@@ -1610,13 +1611,13 @@ func wasmimport_IncomingResponseResourceDrop(self0 uint32)
 //go:nosplit
 func (self IncomingResponse) Consume() (result cm.Result[IncomingBody, IncomingBody, struct{}]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_IncomingResponseConsume((uint32)(self0), &result)
+	wasmimport_IncomingResponseConsume((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:http/types@0.2.0 [method]incoming-response.consume
 //go:noescape
-func wasmimport_IncomingResponseConsume(self0 uint32, result *cm.Result[IncomingBody, IncomingBody, struct{}])
+func wasmimport_IncomingResponseConsume(self0 uint32, result unsafe.Pointer)
 
 // Headers represents the imported method "headers".
 //
@@ -1731,13 +1732,13 @@ func wasmimport_IncomingBodyFinish(this0 uint32) (result0 uint32)
 //go:nosplit
 func (self IncomingBody) Stream() (result cm.Result[streams.InputStream, streams.InputStream, struct{}]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_IncomingBodyStream((uint32)(self0), &result)
+	wasmimport_IncomingBodyStream((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:http/types@0.2.0 [method]incoming-body.stream
 //go:noescape
-func wasmimport_IncomingBodyStream(self0 uint32, result *cm.Result[streams.InputStream, streams.InputStream, struct{}])
+func wasmimport_IncomingBodyStream(self0 uint32, result unsafe.Pointer)
 
 // FutureTrailers represents the imported resource "wasi:http/types@0.2.0#future-trailers".
 //
@@ -1792,13 +1793,13 @@ func wasmimport_FutureTrailersResourceDrop(self0 uint32)
 //go:nosplit
 func (self FutureTrailers) Get() (result cm.Option[cm.Result[cm.Result[ErrorCodeShape, cm.Option[Fields], ErrorCode], cm.Result[ErrorCodeShape, cm.Option[Fields], ErrorCode], struct{}]]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_FutureTrailersGet((uint32)(self0), &result)
+	wasmimport_FutureTrailersGet((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:http/types@0.2.0 [method]future-trailers.get
 //go:noescape
-func wasmimport_FutureTrailersGet(self0 uint32, result *cm.Option[cm.Result[cm.Result[ErrorCodeShape, cm.Option[Fields], ErrorCode], cm.Result[ErrorCodeShape, cm.Option[Fields], ErrorCode], struct{}]])
+func wasmimport_FutureTrailersGet(self0 uint32, result unsafe.Pointer)
 
 // Subscribe represents the imported method "subscribe".
 //
@@ -1877,13 +1878,13 @@ func wasmimport_NewOutgoingResponse(headers0 uint32) (result0 uint32)
 //go:nosplit
 func (self OutgoingResponse) Body() (result cm.Result[OutgoingBody, OutgoingBody, struct{}]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_OutgoingResponseBody((uint32)(self0), &result)
+	wasmimport_OutgoingResponseBody((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:http/types@0.2.0 [method]outgoing-response.body
 //go:noescape
-func wasmimport_OutgoingResponseBody(self0 uint32, result *cm.Result[OutgoingBody, OutgoingBody, struct{}])
+func wasmimport_OutgoingResponseBody(self0 uint32, result unsafe.Pointer)
 
 // Headers represents the imported method "headers".
 //
@@ -2004,13 +2005,13 @@ func wasmimport_OutgoingBodyResourceDrop(self0 uint32)
 func OutgoingBodyFinish(this OutgoingBody, trailers cm.Option[Fields]) (result cm.Result[ErrorCode, struct{}, ErrorCode]) {
 	this0 := cm.Reinterpret[uint32](this)
 	trailers0, trailers1 := lower_OptionTrailers(trailers)
-	wasmimport_OutgoingBodyFinish((uint32)(this0), (uint32)(trailers0), (uint32)(trailers1), &result)
+	wasmimport_OutgoingBodyFinish((uint32)(this0), (uint32)(trailers0), (uint32)(trailers1), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:http/types@0.2.0 [static]outgoing-body.finish
 //go:noescape
-func wasmimport_OutgoingBodyFinish(this0 uint32, trailers0 uint32, trailers1 uint32, result *cm.Result[ErrorCode, struct{}, ErrorCode])
+func wasmimport_OutgoingBodyFinish(this0 uint32, trailers0 uint32, trailers1 uint32, result unsafe.Pointer)
 
 // Write represents the imported method "write".
 //
@@ -2029,13 +2030,13 @@ func wasmimport_OutgoingBodyFinish(this0 uint32, trailers0 uint32, trailers1 uin
 //go:nosplit
 func (self OutgoingBody) Write() (result cm.Result[streams.OutputStream, streams.OutputStream, struct{}]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_OutgoingBodyWrite((uint32)(self0), &result)
+	wasmimport_OutgoingBodyWrite((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:http/types@0.2.0 [method]outgoing-body.write
 //go:noescape
-func wasmimport_OutgoingBodyWrite(self0 uint32, result *cm.Result[streams.OutputStream, streams.OutputStream, struct{}])
+func wasmimport_OutgoingBodyWrite(self0 uint32, result unsafe.Pointer)
 
 // FutureIncomingResponse represents the imported resource "wasi:http/types@0.2.0#future-incoming-response".
 //
@@ -2085,13 +2086,13 @@ func wasmimport_FutureIncomingResponseResourceDrop(self0 uint32)
 //go:nosplit
 func (self FutureIncomingResponse) Get() (result cm.Option[cm.Result[cm.Result[ErrorCodeShape, IncomingResponse, ErrorCode], cm.Result[ErrorCodeShape, IncomingResponse, ErrorCode], struct{}]]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_FutureIncomingResponseGet((uint32)(self0), &result)
+	wasmimport_FutureIncomingResponseGet((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:http/types@0.2.0 [method]future-incoming-response.get
 //go:noescape
-func wasmimport_FutureIncomingResponseGet(self0 uint32, result *cm.Option[cm.Result[cm.Result[ErrorCodeShape, IncomingResponse, ErrorCode], cm.Result[ErrorCodeShape, IncomingResponse, ErrorCode], struct{}]])
+func wasmimport_FutureIncomingResponseGet(self0 uint32, result unsafe.Pointer)
 
 // Subscribe represents the imported method "subscribe".
 //
@@ -2132,10 +2133,10 @@ func wasmimport_FutureIncomingResponseSubscribe(self0 uint32) (result0 uint32)
 //go:nosplit
 func HTTPErrorCode(err ioerror.Error) (result cm.Option[ErrorCode]) {
 	err0 := cm.Reinterpret[uint32](err)
-	wasmimport_HTTPErrorCode((uint32)(err0), &result)
+	wasmimport_HTTPErrorCode((uint32)(err0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:http/types@0.2.0 http-error-code
 //go:noescape
-func wasmimport_HTTPErrorCode(err0 uint32, result *cm.Option[ErrorCode])
+func wasmimport_HTTPErrorCode(err0 uint32, result unsafe.Pointer)
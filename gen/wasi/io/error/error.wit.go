@@ -5,6 +5,7 @@ package ioerror
 
 import (
 	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"unsafe"
 )
 
 // Error represents the imported resource "wasi:io/error@0.2.0#error".
@@ -62,10 +63,10 @@ func wasmimport_ErrorResourceDrop(self0 uint32)
 //go:nosplit
 func (self Error) ToDebugString() (result string) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_ErrorToDebugString((uint32)(self0), &result)
+	wasmimport_ErrorToDebugString((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:io/error@0.2.0 [method]error.to-debug-string
 //go:noescape
-func wasmimport_ErrorToDebugString(self0 uint32, result *string)
+func wasmimport_ErrorToDebugString(self0 uint32, result unsafe.Pointer)
@@ -8,6 +8,7 @@ package poll
 
 import (
 	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"unsafe"
 )
 
 // Pollable represents the imported resource "wasi:io/poll@0.2.0#pollable".
@@ -99,10 +100,10 @@ func wasmimport_PollableReady(self0 uint32) (result0 uint32)
 //go:nosplit
 func Poll(in cm.List[Pollable]) (result cm.List[uint32]) {
 	in0, in1 := cm.LowerList(in)
-	wasmimport_Poll((*Pollable)(in0), (uint32)(in1), &result)
+	wasmimport_Poll(unsafe.Pointer((*Pollable)(in0)), (uint32)(in1), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:io/poll@0.2.0 poll
 //go:noescape
-func wasmimport_Poll(in0 *Pollable, in1 uint32, result *cm.List[uint32])
+func wasmimport_Poll(in0 unsafe.Pointer, in1 uint32, result unsafe.Pointer)
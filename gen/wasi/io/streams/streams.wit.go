@@ -13,6 +13,7 @@ import (
 	"github.com/bytecodealliance/wasm-tools-go/cm"
 	ioerror "go.wasmcloud.dev/component/gen/wasi/io/error"
 	"go.wasmcloud.dev/component/gen/wasi/io/poll"
+	"unsafe"
 )
 
 // StreamError represents the imported variant "wasi:io/streams@0.2.0#stream-error".
@@ -94,13 +95,13 @@ func wasmimport_InputStreamResourceDrop(self0 uint32)
 func (self InputStream) BlockingRead(len_ uint64) (result cm.Result[cm.List[uint8], cm.List[uint8], StreamError]) {
 	self0 := cm.Reinterpret[uint32](self)
 	len0 := (uint64)(len_)
-	wasmimport_InputStreamBlockingRead((uint32)(self0), (uint64)(len0), &result)
+	wasmimport_InputStreamBlockingRead((uint32)(self0), (uint64)(len0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:io/streams@0.2.0 [method]input-stream.blocking-read
 //go:noescape
-func wasmimport_InputStreamBlockingRead(self0 uint32, len0 uint64, result *cm.Result[cm.List[uint8], cm.List[uint8], StreamError])
+func wasmimport_InputStreamBlockingRead(self0 uint32, len0 uint64, result unsafe.Pointer)
 
 // BlockingSkip represents the imported method "blocking-skip".
 //
@@ -113,13 +114,13 @@ func wasmimport_InputStreamBlockingRead(self0 uint32, len0 uint64, result *cm.Re
 func (self InputStream) BlockingSkip(len_ uint64) (result cm.Result[uint64, uint64, StreamError]) {
 	self0 := cm.Reinterpret[uint32](self)
 	len0 := (uint64)(len_)
-	wasmimport_InputStreamBlockingSkip((uint32)(self0), (uint64)(len0), &result)
+	wasmimport_InputStreamBlockingSkip((uint32)(self0), (uint64)(len0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:io/streams@0.2.0 [method]input-stream.blocking-skip
 //go:noescape
-func wasmimport_InputStreamBlockingSkip(self0 uint32, len0 uint64, result *cm.Result[uint64, uint64, StreamError])
+func wasmimport_InputStreamBlockingSkip(self0 uint32, len0 uint64, result unsafe.Pointer)
 
 // Read represents the imported method "read".
 //
@@ -156,13 +157,13 @@ func wasmimport_InputStreamBlockingSkip(self0 uint32, len0 uint64, result *cm.Re
 func (self InputStream) Read(len_ uint64) (result cm.Result[cm.List[uint8], cm.List[uint8], StreamError]) {
 	self0 := cm.Reinterpret[uint32](self)
 	len0 := (uint64)(len_)
-	wasmimport_InputStreamRead((uint32)(self0), (uint64)(len0), &result)
+	wasmimport_InputStreamRead((uint32)(self0), (uint64)(len0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:io/streams@0.2.0 [method]input-stream.read
 //go:noescape
-func wasmimport_InputStreamRead(self0 uint32, len0 uint64, result *cm.Result[cm.List[uint8], cm.List[uint8], StreamError])
+func wasmimport_InputStreamRead(self0 uint32, len0 uint64, result unsafe.Pointer)
 
 // Skip represents the imported method "skip".
 //
@@ -177,13 +178,13 @@ func wasmimport_InputStreamRead(self0 uint32, len0 uint64, result *cm.Result[cm.
 func (self InputStream) Skip(len_ uint64) (result cm.Result[uint64, uint64, StreamError]) {
 	self0 := cm.Reinterpret[uint32](self)
 	len0 := (uint64)(len_)
-	wasmimport_InputStreamSkip((uint32)(self0), (uint64)(len0), &result)
+	wasmimport_InputStreamSkip((uint32)(self0), (uint64)(len0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:io/streams@0.2.0 [method]input-stream.skip
 //go:noescape
-func wasmimport_InputStreamSkip(self0 uint32, len0 uint64, result *cm.Result[uint64, uint64, StreamError])
+func wasmimport_InputStreamSkip(self0 uint32, len0 uint64, result unsafe.Pointer)
 
 // Subscribe represents the imported method "subscribe".
 //
@@ -247,13 +248,13 @@ func wasmimport_OutputStreamResourceDrop(self0 uint32)
 //go:nosplit
 func (self OutputStream) BlockingFlush() (result cm.Result[StreamError, struct{}, StreamError]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_OutputStreamBlockingFlush((uint32)(self0), &result)
+	wasmimport_OutputStreamBlockingFlush((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:io/streams@0.2.0 [method]output-stream.blocking-flush
 //go:noescape
-func wasmimport_OutputStreamBlockingFlush(self0 uint32, result *cm.Result[StreamError, struct{}, StreamError])
+func wasmimport_OutputStreamBlockingFlush(self0 uint32, result unsafe.Pointer)
 
 // BlockingSplice represents the imported method "blocking-splice".
 //
@@ -270,13 +271,13 @@ func (self OutputStream) BlockingSplice(src InputStream, len_ uint64) (result cm
 	self0 := cm.Reinterpret[uint32](self)
 	src0 := cm.Reinterpret[uint32](src)
 	len0 := (uint64)(len_)
-	wasmimport_OutputStreamBlockingSplice((uint32)(self0), (uint32)(src0), (uint64)(len0), &result)
+	wasmimport_OutputStreamBlockingSplice((uint32)(self0), (uint32)(src0), (uint64)(len0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:io/streams@0.2.0 [method]output-stream.blocking-splice
 //go:noescape
-func wasmimport_OutputStreamBlockingSplice(self0 uint32, src0 uint32, len0 uint64, result *cm.Result[uint64, uint64, StreamError])
+func wasmimport_OutputStreamBlockingSplice(self0 uint32, src0 uint32, len0 uint64, result unsafe.Pointer)
 
 // BlockingWriteAndFlush represents the imported method "blocking-write-and-flush".
 //
@@ -309,13 +310,13 @@ func wasmimport_OutputStreamBlockingSplice(self0 uint32, src0 uint32, len0 uint6
 func (self OutputStream) BlockingWriteAndFlush(contents cm.List[uint8]) (result cm.Result[StreamError, struct{}, StreamError]) {
 	self0 := cm.Reinterpret[uint32](self)
 	contents0, contents1 := cm.LowerList(contents)
-	wasmimport_OutputStreamBlockingWriteAndFlush((uint32)(self0), (*uint8)(contents0), (uint32)(contents1), &result)
+	wasmimport_OutputStreamBlockingWriteAndFlush((uint32)(self0), unsafe.Pointer((*uint8)(contents0)), (uint32)(contents1), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:io/streams@0.2.0 [method]output-stream.blocking-write-and-flush
 //go:noescape
-func wasmimport_OutputStreamBlockingWriteAndFlush(self0 uint32, contents0 *uint8, contents1 uint32, result *cm.Result[StreamError, struct{}, StreamError])
+func wasmimport_OutputStreamBlockingWriteAndFlush(self0 uint32, contents0 unsafe.Pointer, contents1 uint32, result unsafe.Pointer)
 
 // BlockingWriteZeroesAndFlush represents the imported method "blocking-write-zeroes-and-flush".
 //
@@ -348,13 +349,13 @@ func wasmimport_OutputStreamBlockingWriteAndFlush(self0 uint32, contents0 *uint8
 func (self OutputStream) BlockingWriteZeroesAndFlush(len_ uint64) (result cm.Result[StreamError, struct{}, StreamError]) {
 	self0 := cm.Reinterpret[uint32](self)
 	len0 := (uint64)(len_)
-	wasmimport_OutputStreamBlockingWriteZeroesAndFlush((uint32)(self0), (uint64)(len0), &result)
+	wasmimport_OutputStreamBlockingWriteZeroesAndFlush((uint32)(self0), (uint64)(len0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:io/streams@0.2.0 [method]output-stream.blocking-write-zeroes-and-flush
 //go:noescape
-func wasmimport_OutputStreamBlockingWriteZeroesAndFlush(self0 uint32, len0 uint64, result *cm.Result[StreamError, struct{}, StreamError])
+func wasmimport_OutputStreamBlockingWriteZeroesAndFlush(self0 uint32, len0 uint64, result unsafe.Pointer)
 
 // CheckWrite represents the imported method "check-write".
 //
@@ -373,13 +374,13 @@ func wasmimport_OutputStreamBlockingWriteZeroesAndFlush(self0 uint32, len0 uint6
 //go:nosplit
 func (self OutputStream) CheckWrite() (result cm.Result[uint64, uint64, StreamError]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_OutputStreamCheckWrite((uint32)(self0), &result)
+	wasmimport_OutputStreamCheckWrite((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:io/streams@0.2.0 [method]output-stream.check-write
 //go:noescape
-func wasmimport_OutputStreamCheckWrite(self0 uint32, result *cm.Result[uint64, uint64, StreamError])
+func wasmimport_OutputStreamCheckWrite(self0 uint32, result unsafe.Pointer)
 
 // Flush represents the imported method "flush".
 //
@@ -399,13 +400,13 @@ func wasmimport_OutputStreamCheckWrite(self0 uint32, result *cm.Result[uint64, u
 //go:nosplit
 func (self OutputStream) Flush() (result cm.Result[StreamError, struct{}, StreamError]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_OutputStreamFlush((uint32)(self0), &result)
+	wasmimport_OutputStreamFlush((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:io/streams@0.2.0 [method]output-stream.flush
 //go:noescape
-func wasmimport_OutputStreamFlush(self0 uint32, result *cm.Result[StreamError, struct{}, StreamError])
+func wasmimport_OutputStreamFlush(self0 uint32, result unsafe.Pointer)
 
 // Splice represents the imported method "splice".
 //
@@ -430,13 +431,13 @@ func (self OutputStream) Splice(src InputStream, len_ uint64) (result cm.Result[
 	self0 := cm.Reinterpret[uint32](self)
 	src0 := cm.Reinterpret[uint32](src)
 	len0 := (uint64)(len_)
-	wasmimport_OutputStreamSplice((uint32)(self0), (uint32)(src0), (uint64)(len0), &result)
+	wasmimport_OutputStreamSplice((uint32)(self0), (uint32)(src0), (uint64)(len0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:io/streams@0.2.0 [method]output-stream.splice
 //go:noescape
-func wasmimport_OutputStreamSplice(self0 uint32, src0 uint32, len0 uint64, result *cm.Result[uint64, uint64, StreamError])
+func wasmimport_OutputStreamSplice(self0 uint32, src0 uint32, len0 uint64, result unsafe.Pointer)
 
 // Subscribe represents the imported method "subscribe".
 //
@@ -487,13 +488,13 @@ func wasmimport_OutputStreamSubscribe(self0 uint32) (result0 uint32)
 func (self OutputStream) Write(contents cm.List[uint8]) (result cm.Result[StreamError, struct{}, StreamError]) {
 	self0 := cm.Reinterpret[uint32](self)
 	contents0, contents1 := cm.LowerList(contents)
-	wasmimport_OutputStreamWrite((uint32)(self0), (*uint8)(contents0), (uint32)(contents1), &result)
+	wasmimport_OutputStreamWrite((uint32)(self0), unsafe.Pointer((*uint8)(contents0)), (uint32)(contents1), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:io/streams@0.2.0 [method]output-stream.write
 //go:noescape
-func wasmimport_OutputStreamWrite(self0 uint32, contents0 *uint8, contents1 uint32, result *cm.Result[StreamError, struct{}, StreamError])
+func wasmimport_OutputStreamWrite(self0 uint32, contents0 unsafe.Pointer, contents1 uint32, result unsafe.Pointer)
 
 // WriteZeroes represents the imported method "write-zeroes".
 //
@@ -510,10 +511,10 @@ func wasmimport_OutputStreamWrite(self0 uint32, contents0 *uint8, contents1 uint
 func (self OutputStream) WriteZeroes(len_ uint64) (result cm.Result[StreamError, struct{}, StreamError]) {
 	self0 := cm.Reinterpret[uint32](self)
 	len0 := (uint64)(len_)
-	wasmimport_OutputStreamWriteZeroes((uint32)(self0), (uint64)(len0), &result)
+	wasmimport_OutputStreamWriteZeroes((uint32)(self0), (uint64)(len0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:io/streams@0.2.0 [method]output-stream.write-zeroes
 //go:noescape
-func wasmimport_OutputStreamWriteZeroes(self0 uint32, len0 uint64, result *cm.Result[StreamError, struct{}, StreamError])
+func wasmimport_OutputStreamWriteZeroes(self0 uint32, len0 uint64, result unsafe.Pointer)
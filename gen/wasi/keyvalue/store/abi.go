@@ -0,0 +1,33 @@
+// Code generated by wit-bindgen-go. DO NOT EDIT.
+
+package store
+
+import (
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"unsafe"
+)
+
+// ErrorShape is used for storage in variant or result types.
+type ErrorShape struct {
+	shape [unsafe.Sizeof(Error{})]byte
+}
+
+// OptionListU8Shape is used for storage in variant or result types.
+type OptionListU8Shape struct {
+	shape [unsafe.Sizeof(cm.Option[cm.List[uint8]]{})]byte
+}
+
+// KeyResponseShape is used for storage in variant or result types.
+type KeyResponseShape struct {
+	shape [unsafe.Sizeof(KeyResponse{})]byte
+}
+
+func lower_OptionU64(v cm.Option[uint64]) (f0 uint32, f1 uint64) {
+	some := v.Some()
+	if some != nil {
+		f0 = 1
+		v1 := (uint64)(*some)
+		f1 = (uint64)(v1)
+	}
+	return
+}
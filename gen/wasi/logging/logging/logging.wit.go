@@ -8,6 +8,7 @@ package logging
 
 import (
 	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"unsafe"
 )
 
 // Level represents the enum "wasi:logging/logging#level".
@@ -77,10 +78,10 @@ func Log(level Level, context string, message string) {
 	level0 := (uint32)(level)
 	context0, context1 := cm.LowerString(context)
 	message0, message1 := cm.LowerString(message)
-	wasmimport_Log((uint32)(level0), (*uint8)(context0), (uint32)(context1), (*uint8)(message0), (uint32)(message1))
+	wasmimport_Log((uint32)(level0), unsafe.Pointer((*uint8)(context0)), (uint32)(context1), unsafe.Pointer((*uint8)(message0)), (uint32)(message1))
 	return
 }
 
 //go:wasmimport wasi:logging/logging log
 //go:noescape
-func wasmimport_Log(level0 uint32, context0 *uint8, context1 uint32, message0 *uint8, message1 uint32)
+func wasmimport_Log(level0 uint32, context0 unsafe.Pointer, context1 uint32, message0 unsafe.Pointer, message1 uint32)
@@ -8,6 +8,8 @@
 // Windows.
 package insecureseed
 
+import "unsafe"
+
 // InsecureSeed represents the imported function "insecure-seed".
 //
 // Return a 128-bit value that may contain a pseudo-random value.
@@ -32,10 +34,10 @@ package insecureseed
 //
 //go:nosplit
 func InsecureSeed() (result [2]uint64) {
-	wasmimport_InsecureSeed(&result)
+	wasmimport_InsecureSeed(unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:random/insecure-seed@0.2.0 insecure-seed
 //go:noescape
-func wasmimport_InsecureSeed(result *[2]uint64)
+func wasmimport_InsecureSeed(result unsafe.Pointer)
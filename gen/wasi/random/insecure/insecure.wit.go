@@ -10,6 +10,7 @@ package insecure
 
 import (
 	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"unsafe"
 )
 
 // GetInsecureRandomBytes represents the imported function "get-insecure-random-bytes".
@@ -28,13 +29,13 @@ import (
 //go:nosplit
 func GetInsecureRandomBytes(len_ uint64) (result cm.List[uint8]) {
 	len0 := (uint64)(len_)
-	wasmimport_GetInsecureRandomBytes((uint64)(len0), &result)
+	wasmimport_GetInsecureRandomBytes((uint64)(len0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:random/insecure@0.2.0 get-insecure-random-bytes
 //go:noescape
-func wasmimport_GetInsecureRandomBytes(len0 uint64, result *cm.List[uint8])
+func wasmimport_GetInsecureRandomBytes(len0 uint64, result unsafe.Pointer)
 
 // GetInsecureRandomU64 represents the imported function "get-insecure-random-u64".
 //
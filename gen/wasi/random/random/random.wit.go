@@ -10,6 +10,7 @@ package random
 
 import (
 	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"unsafe"
 )
 
 // GetRandomBytes represents the imported function "get-random-bytes".
@@ -32,13 +33,13 @@ import (
 //go:nosplit
 func GetRandomBytes(len_ uint64) (result cm.List[uint8]) {
 	len0 := (uint64)(len_)
-	wasmimport_GetRandomBytes((uint64)(len0), &result)
+	wasmimport_GetRandomBytes((uint64)(len0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:random/random@0.2.0 get-random-bytes
 //go:noescape
-func wasmimport_GetRandomBytes(len0 uint64, result *cm.List[uint8])
+func wasmimport_GetRandomBytes(len0 uint64, result unsafe.Pointer)
 
 // GetRandomU64 represents the imported function "get-random-u64".
 //
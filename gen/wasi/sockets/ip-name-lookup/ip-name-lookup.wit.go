@@ -7,6 +7,7 @@ import (
 	"github.com/bytecodealliance/wasm-tools-go/cm"
 	"go.wasmcloud.dev/component/gen/wasi/io/poll"
 	"go.wasmcloud.dev/component/gen/wasi/sockets/network"
+	"unsafe"
 )
 
 // ResolveAddressStream represents the imported resource "wasi:sockets/ip-name-lookup@0.2.0#resolve-address-stream".
@@ -53,13 +54,13 @@ func wasmimport_ResolveAddressStreamResourceDrop(self0 uint32)
 //go:nosplit
 func (self ResolveAddressStream) ResolveNextAddress() (result cm.Result[OptionIPAddressShape, cm.Option[network.IPAddress], network.ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_ResolveAddressStreamResolveNextAddress((uint32)(self0), &result)
+	wasmimport_ResolveAddressStreamResolveNextAddress((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/ip-name-lookup@0.2.0 [method]resolve-address-stream.resolve-next-address
 //go:noescape
-func wasmimport_ResolveAddressStreamResolveNextAddress(self0 uint32, result *cm.Result[OptionIPAddressShape, cm.Option[network.IPAddress], network.ErrorCode])
+func wasmimport_ResolveAddressStreamResolveNextAddress(self0 uint32, result unsafe.Pointer)
 
 // Subscribe represents the imported method "subscribe".
 //
@@ -112,10 +113,10 @@ func wasmimport_ResolveAddressStreamSubscribe(self0 uint32) (result0 uint32)
 func ResolveAddresses(network_ network.Network, name string) (result cm.Result[ResolveAddressStream, ResolveAddressStream, network.ErrorCode]) {
 	network0 := cm.Reinterpret[uint32](network_)
 	name0, name1 := cm.LowerString(name)
-	wasmimport_ResolveAddresses((uint32)(network0), (*uint8)(name0), (uint32)(name1), &result)
+	wasmimport_ResolveAddresses((uint32)(network0), unsafe.Pointer((*uint8)(name0)), (uint32)(name1), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/ip-name-lookup@0.2.0 resolve-addresses
 //go:noescape
-func wasmimport_ResolveAddresses(network0 uint32, name0 *uint8, name1 uint32, result *cm.Result[ResolveAddressStream, ResolveAddressStream, network.ErrorCode])
+func wasmimport_ResolveAddresses(network0 uint32, name0 unsafe.Pointer, name1 uint32, result unsafe.Pointer)
@@ -7,6 +7,7 @@ import (
 	"github.com/bytecodealliance/wasm-tools-go/cm"
 	"go.wasmcloud.dev/component/gen/wasi/sockets/network"
 	"go.wasmcloud.dev/component/gen/wasi/sockets/tcp"
+	"unsafe"
 )
 
 // CreateTCPSocket represents the imported function "create-tcp-socket".
@@ -43,10 +44,10 @@ import (
 //go:nosplit
 func CreateTCPSocket(addressFamily network.IPAddressFamily) (result cm.Result[tcp.TCPSocket, tcp.TCPSocket, network.ErrorCode]) {
 	addressFamily0 := (uint32)(addressFamily)
-	wasmimport_CreateTCPSocket((uint32)(addressFamily0), &result)
+	wasmimport_CreateTCPSocket((uint32)(addressFamily0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/tcp-create-socket@0.2.0 create-tcp-socket
 //go:noescape
-func wasmimport_CreateTCPSocket(addressFamily0 uint32, result *cm.Result[tcp.TCPSocket, tcp.TCPSocket, network.ErrorCode])
+func wasmimport_CreateTCPSocket(addressFamily0 uint32, result unsafe.Pointer)
@@ -9,6 +9,7 @@ import (
 	"go.wasmcloud.dev/component/gen/wasi/io/poll"
 	"go.wasmcloud.dev/component/gen/wasi/io/streams"
 	"go.wasmcloud.dev/component/gen/wasi/sockets/network"
+	"unsafe"
 )
 
 // ShutdownType represents the enum "wasi:sockets/tcp@0.2.0#shutdown-type".
@@ -122,13 +123,13 @@ func wasmimport_TCPSocketResourceDrop(self0 uint32)
 //go:nosplit
 func (self TCPSocket) Accept() (result cm.Result[TupleTCPSocketInputStreamOutputStreamShape, cm.Tuple3[TCPSocket, streams.InputStream, streams.OutputStream], network.ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_TCPSocketAccept((uint32)(self0), &result)
+	wasmimport_TCPSocketAccept((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/tcp@0.2.0 [method]tcp-socket.accept
 //go:noescape
-func wasmimport_TCPSocketAccept(self0 uint32, result *cm.Result[TupleTCPSocketInputStreamOutputStreamShape, cm.Tuple3[TCPSocket, streams.InputStream, streams.OutputStream], network.ErrorCode])
+func wasmimport_TCPSocketAccept(self0 uint32, result unsafe.Pointer)
 
 // AddressFamily represents the imported method "address-family".
 //
@@ -157,13 +158,13 @@ func wasmimport_TCPSocketAddressFamily(self0 uint32) (result0 uint32)
 //go:nosplit
 func (self TCPSocket) FinishBind() (result cm.Result[network.ErrorCode, struct{}, network.ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_TCPSocketFinishBind((uint32)(self0), &result)
+	wasmimport_TCPSocketFinishBind((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/tcp@0.2.0 [method]tcp-socket.finish-bind
 //go:noescape
-func wasmimport_TCPSocketFinishBind(self0 uint32, result *cm.Result[network.ErrorCode, struct{}, network.ErrorCode])
+func wasmimport_TCPSocketFinishBind(self0 uint32, result unsafe.Pointer)
 
 // FinishConnect represents the imported method "finish-connect".
 //
@@ -172,13 +173,13 @@ func wasmimport_TCPSocketFinishBind(self0 uint32, result *cm.Result[network.Erro
 //go:nosplit
 func (self TCPSocket) FinishConnect() (result cm.Result[TupleInputStreamOutputStreamShape, cm.Tuple[streams.InputStream, streams.OutputStream], network.ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_TCPSocketFinishConnect((uint32)(self0), &result)
+	wasmimport_TCPSocketFinishConnect((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/tcp@0.2.0 [method]tcp-socket.finish-connect
 //go:noescape
-func wasmimport_TCPSocketFinishConnect(self0 uint32, result *cm.Result[TupleInputStreamOutputStreamShape, cm.Tuple[streams.InputStream, streams.OutputStream], network.ErrorCode])
+func wasmimport_TCPSocketFinishConnect(self0 uint32, result unsafe.Pointer)
 
 // FinishListen represents the imported method "finish-listen".
 //
@@ -187,13 +188,13 @@ func wasmimport_TCPSocketFinishConnect(self0 uint32, result *cm.Result[TupleInpu
 //go:nosplit
 func (self TCPSocket) FinishListen() (result cm.Result[network.ErrorCode, struct{}, network.ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_TCPSocketFinishListen((uint32)(self0), &result)
+	wasmimport_TCPSocketFinishListen((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/tcp@0.2.0 [method]tcp-socket.finish-listen
 //go:noescape
-func wasmimport_TCPSocketFinishListen(self0 uint32, result *cm.Result[network.ErrorCode, struct{}, network.ErrorCode])
+func wasmimport_TCPSocketFinishListen(self0 uint32, result unsafe.Pointer)
 
 // HopLimit represents the imported method "hop-limit".
 //
@@ -209,13 +210,13 @@ func wasmimport_TCPSocketFinishListen(self0 uint32, result *cm.Result[network.Er
 //go:nosplit
 func (self TCPSocket) HopLimit() (result cm.Result[uint8, uint8, network.ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_TCPSocketHopLimit((uint32)(self0), &result)
+	wasmimport_TCPSocketHopLimit((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/tcp@0.2.0 [method]tcp-socket.hop-limit
 //go:noescape
-func wasmimport_TCPSocketHopLimit(self0 uint32, result *cm.Result[uint8, uint8, network.ErrorCode])
+func wasmimport_TCPSocketHopLimit(self0 uint32, result unsafe.Pointer)
 
 // IsListening represents the imported method "is-listening".
 //
@@ -257,13 +258,13 @@ func wasmimport_TCPSocketIsListening(self0 uint32) (result0 uint32)
 //go:nosplit
 func (self TCPSocket) KeepAliveCount() (result cm.Result[uint32, uint32, network.ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_TCPSocketKeepAliveCount((uint32)(self0), &result)
+	wasmimport_TCPSocketKeepAliveCount((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/tcp@0.2.0 [method]tcp-socket.keep-alive-count
 //go:noescape
-func wasmimport_TCPSocketKeepAliveCount(self0 uint32, result *cm.Result[uint32, uint32, network.ErrorCode])
+func wasmimport_TCPSocketKeepAliveCount(self0 uint32, result unsafe.Pointer)
 
 // KeepAliveEnabled represents the imported method "keep-alive-enabled".
 //
@@ -283,13 +284,13 @@ func wasmimport_TCPSocketKeepAliveCount(self0 uint32, result *cm.Result[uint32,
 //go:nosplit
 func (self TCPSocket) KeepAliveEnabled() (result cm.Result[bool, bool, network.ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_TCPSocketKeepAliveEnabled((uint32)(self0), &result)
+	wasmimport_TCPSocketKeepAliveEnabled((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/tcp@0.2.0 [method]tcp-socket.keep-alive-enabled
 //go:noescape
-func wasmimport_TCPSocketKeepAliveEnabled(self0 uint32, result *cm.Result[bool, bool, network.ErrorCode])
+func wasmimport_TCPSocketKeepAliveEnabled(self0 uint32, result unsafe.Pointer)
 
 // KeepAliveIdleTime represents the imported method "keep-alive-idle-time".
 //
@@ -312,13 +313,13 @@ func wasmimport_TCPSocketKeepAliveEnabled(self0 uint32, result *cm.Result[bool,
 //go:nosplit
 func (self TCPSocket) KeepAliveIdleTime() (result cm.Result[uint64, monotonicclock.Duration, network.ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_TCPSocketKeepAliveIdleTime((uint32)(self0), &result)
+	wasmimport_TCPSocketKeepAliveIdleTime((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/tcp@0.2.0 [method]tcp-socket.keep-alive-idle-time
 //go:noescape
-func wasmimport_TCPSocketKeepAliveIdleTime(self0 uint32, result *cm.Result[uint64, monotonicclock.Duration, network.ErrorCode])
+func wasmimport_TCPSocketKeepAliveIdleTime(self0 uint32, result unsafe.Pointer)
 
 // KeepAliveInterval represents the imported method "keep-alive-interval".
 //
@@ -340,13 +341,13 @@ func wasmimport_TCPSocketKeepAliveIdleTime(self0 uint32, result *cm.Result[uint6
 //go:nosplit
 func (self TCPSocket) KeepAliveInterval() (result cm.Result[uint64, monotonicclock.Duration, network.ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_TCPSocketKeepAliveInterval((uint32)(self0), &result)
+	wasmimport_TCPSocketKeepAliveInterval((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/tcp@0.2.0 [method]tcp-socket.keep-alive-interval
 //go:noescape
-func wasmimport_TCPSocketKeepAliveInterval(self0 uint32, result *cm.Result[uint64, monotonicclock.Duration, network.ErrorCode])
+func wasmimport_TCPSocketKeepAliveInterval(self0 uint32, result unsafe.Pointer)
 
 // LocalAddress represents the imported method "local-address".
 //
@@ -373,13 +374,13 @@ func wasmimport_TCPSocketKeepAliveInterval(self0 uint32, result *cm.Result[uint6
 //go:nosplit
 func (self TCPSocket) LocalAddress() (result cm.Result[IPSocketAddressShape, network.IPSocketAddress, network.ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_TCPSocketLocalAddress((uint32)(self0), &result)
+	wasmimport_TCPSocketLocalAddress((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/tcp@0.2.0 [method]tcp-socket.local-address
 //go:noescape
-func wasmimport_TCPSocketLocalAddress(self0 uint32, result *cm.Result[IPSocketAddressShape, network.IPSocketAddress, network.ErrorCode])
+func wasmimport_TCPSocketLocalAddress(self0 uint32, result unsafe.Pointer)
 
 // ReceiveBufferSize represents the imported method "receive-buffer-size".
 //
@@ -401,13 +402,13 @@ func wasmimport_TCPSocketLocalAddress(self0 uint32, result *cm.Result[IPSocketAd
 //go:nosplit
 func (self TCPSocket) ReceiveBufferSize() (result cm.Result[uint64, uint64, network.ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_TCPSocketReceiveBufferSize((uint32)(self0), &result)
+	wasmimport_TCPSocketReceiveBufferSize((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/tcp@0.2.0 [method]tcp-socket.receive-buffer-size
 //go:noescape
-func wasmimport_TCPSocketReceiveBufferSize(self0 uint32, result *cm.Result[uint64, uint64, network.ErrorCode])
+func wasmimport_TCPSocketReceiveBufferSize(self0 uint32, result unsafe.Pointer)
 
 // RemoteAddress represents the imported method "remote-address".
 //
@@ -427,13 +428,13 @@ func wasmimport_TCPSocketReceiveBufferSize(self0 uint32, result *cm.Result[uint6
 //go:nosplit
 func (self TCPSocket) RemoteAddress() (result cm.Result[IPSocketAddressShape, network.IPSocketAddress, network.ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_TCPSocketRemoteAddress((uint32)(self0), &result)
+	wasmimport_TCPSocketRemoteAddress((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/tcp@0.2.0 [method]tcp-socket.remote-address
 //go:noescape
-func wasmimport_TCPSocketRemoteAddress(self0 uint32, result *cm.Result[IPSocketAddressShape, network.IPSocketAddress, network.ErrorCode])
+func wasmimport_TCPSocketRemoteAddress(self0 uint32, result unsafe.Pointer)
 
 // SendBufferSize represents the imported method "send-buffer-size".
 //
@@ -442,13 +443,13 @@ func wasmimport_TCPSocketRemoteAddress(self0 uint32, result *cm.Result[IPSocketA
 //go:nosplit
 func (self TCPSocket) SendBufferSize() (result cm.Result[uint64, uint64, network.ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_TCPSocketSendBufferSize((uint32)(self0), &result)
+	wasmimport_TCPSocketSendBufferSize((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/tcp@0.2.0 [method]tcp-socket.send-buffer-size
 //go:noescape
-func wasmimport_TCPSocketSendBufferSize(self0 uint32, result *cm.Result[uint64, uint64, network.ErrorCode])
+func wasmimport_TCPSocketSendBufferSize(self0 uint32, result unsafe.Pointer)
 
 // SetHopLimit represents the imported method "set-hop-limit".
 //
@@ -458,13 +459,13 @@ func wasmimport_TCPSocketSendBufferSize(self0 uint32, result *cm.Result[uint64,
 func (self TCPSocket) SetHopLimit(value uint8) (result cm.Result[network.ErrorCode, struct{}, network.ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
 	value0 := (uint32)(value)
-	wasmimport_TCPSocketSetHopLimit((uint32)(self0), (uint32)(value0), &result)
+	wasmimport_TCPSocketSetHopLimit((uint32)(self0), (uint32)(value0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/tcp@0.2.0 [method]tcp-socket.set-hop-limit
 //go:noescape
-func wasmimport_TCPSocketSetHopLimit(self0 uint32, value0 uint32, result *cm.Result[network.ErrorCode, struct{}, network.ErrorCode])
+func wasmimport_TCPSocketSetHopLimit(self0 uint32, value0 uint32, result unsafe.Pointer)
 
 // SetKeepAliveCount represents the imported method "set-keep-alive-count".
 //
@@ -474,13 +475,13 @@ func wasmimport_TCPSocketSetHopLimit(self0 uint32, value0 uint32, result *cm.Res
 func (self TCPSocket) SetKeepAliveCount(value uint32) (result cm.Result[network.ErrorCode, struct{}, network.ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
 	value0 := (uint32)(value)
-	wasmimport_TCPSocketSetKeepAliveCount((uint32)(self0), (uint32)(value0), &result)
+	wasmimport_TCPSocketSetKeepAliveCount((uint32)(self0), (uint32)(value0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/tcp@0.2.0 [method]tcp-socket.set-keep-alive-count
 //go:noescape
-func wasmimport_TCPSocketSetKeepAliveCount(self0 uint32, value0 uint32, result *cm.Result[network.ErrorCode, struct{}, network.ErrorCode])
+func wasmimport_TCPSocketSetKeepAliveCount(self0 uint32, value0 uint32, result unsafe.Pointer)
 
 // SetKeepAliveEnabled represents the imported method "set-keep-alive-enabled".
 //
@@ -490,13 +491,13 @@ func wasmimport_TCPSocketSetKeepAliveCount(self0 uint32, value0 uint32, result *
 func (self TCPSocket) SetKeepAliveEnabled(value bool) (result cm.Result[network.ErrorCode, struct{}, network.ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
 	value0 := cm.BoolToU32(value)
-	wasmimport_TCPSocketSetKeepAliveEnabled((uint32)(self0), (uint32)(value0), &result)
+	wasmimport_TCPSocketSetKeepAliveEnabled((uint32)(self0), (uint32)(value0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/tcp@0.2.0 [method]tcp-socket.set-keep-alive-enabled
 //go:noescape
-func wasmimport_TCPSocketSetKeepAliveEnabled(self0 uint32, value0 uint32, result *cm.Result[network.ErrorCode, struct{}, network.ErrorCode])
+func wasmimport_TCPSocketSetKeepAliveEnabled(self0 uint32, value0 uint32, result unsafe.Pointer)
 
 // SetKeepAliveIdleTime represents the imported method "set-keep-alive-idle-time".
 //
@@ -506,13 +507,13 @@ func wasmimport_TCPSocketSetKeepAliveEnabled(self0 uint32, value0 uint32, result
 func (self TCPSocket) SetKeepAliveIdleTime(value monotonicclock.Duration) (result cm.Result[network.ErrorCode, struct{}, network.ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
 	value0 := (uint64)(value)
-	wasmimport_TCPSocketSetKeepAliveIdleTime((uint32)(self0), (uint64)(value0), &result)
+	wasmimport_TCPSocketSetKeepAliveIdleTime((uint32)(self0), (uint64)(value0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/tcp@0.2.0 [method]tcp-socket.set-keep-alive-idle-time
 //go:noescape
-func wasmimport_TCPSocketSetKeepAliveIdleTime(self0 uint32, value0 uint64, result *cm.Result[network.ErrorCode, struct{}, network.ErrorCode])
+func wasmimport_TCPSocketSetKeepAliveIdleTime(self0 uint32, value0 uint64, result unsafe.Pointer)
 
 // SetKeepAliveInterval represents the imported method "set-keep-alive-interval".
 //
@@ -522,13 +523,13 @@ func wasmimport_TCPSocketSetKeepAliveIdleTime(self0 uint32, value0 uint64, resul
 func (self TCPSocket) SetKeepAliveInterval(value monotonicclock.Duration) (result cm.Result[network.ErrorCode, struct{}, network.ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
 	value0 := (uint64)(value)
-	wasmimport_TCPSocketSetKeepAliveInterval((uint32)(self0), (uint64)(value0), &result)
+	wasmimport_TCPSocketSetKeepAliveInterval((uint32)(self0), (uint64)(value0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/tcp@0.2.0 [method]tcp-socket.set-keep-alive-interval
 //go:noescape
-func wasmimport_TCPSocketSetKeepAliveInterval(self0 uint32, value0 uint64, result *cm.Result[network.ErrorCode, struct{}, network.ErrorCode])
+func wasmimport_TCPSocketSetKeepAliveInterval(self0 uint32, value0 uint64, result unsafe.Pointer)
 
 // SetListenBacklogSize represents the imported method "set-listen-backlog-size".
 //
@@ -551,13 +552,13 @@ func wasmimport_TCPSocketSetKeepAliveInterval(self0 uint32, value0 uint64, resul
 func (self TCPSocket) SetListenBacklogSize(value uint64) (result cm.Result[network.ErrorCode, struct{}, network.ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
 	value0 := (uint64)(value)
-	wasmimport_TCPSocketSetListenBacklogSize((uint32)(self0), (uint64)(value0), &result)
+	wasmimport_TCPSocketSetListenBacklogSize((uint32)(self0), (uint64)(value0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/tcp@0.2.0 [method]tcp-socket.set-listen-backlog-size
 //go:noescape
-func wasmimport_TCPSocketSetListenBacklogSize(self0 uint32, value0 uint64, result *cm.Result[network.ErrorCode, struct{}, network.ErrorCode])
+func wasmimport_TCPSocketSetListenBacklogSize(self0 uint32, value0 uint64, result unsafe.Pointer)
 
 // SetReceiveBufferSize represents the imported method "set-receive-buffer-size".
 //
@@ -567,13 +568,13 @@ func wasmimport_TCPSocketSetListenBacklogSize(self0 uint32, value0 uint64, resul
 func (self TCPSocket) SetReceiveBufferSize(value uint64) (result cm.Result[network.ErrorCode, struct{}, network.ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
 	value0 := (uint64)(value)
-	wasmimport_TCPSocketSetReceiveBufferSize((uint32)(self0), (uint64)(value0), &result)
+	wasmimport_TCPSocketSetReceiveBufferSize((uint32)(self0), (uint64)(value0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/tcp@0.2.0 [method]tcp-socket.set-receive-buffer-size
 //go:noescape
-func wasmimport_TCPSocketSetReceiveBufferSize(self0 uint32, value0 uint64, result *cm.Result[network.ErrorCode, struct{}, network.ErrorCode])
+func wasmimport_TCPSocketSetReceiveBufferSize(self0 uint32, value0 uint64, result unsafe.Pointer)
 
 // SetSendBufferSize represents the imported method "set-send-buffer-size".
 //
@@ -583,13 +584,13 @@ func wasmimport_TCPSocketSetReceiveBufferSize(self0 uint32, value0 uint64, resul
 func (self TCPSocket) SetSendBufferSize(value uint64) (result cm.Result[network.ErrorCode, struct{}, network.ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
 	value0 := (uint64)(value)
-	wasmimport_TCPSocketSetSendBufferSize((uint32)(self0), (uint64)(value0), &result)
+	wasmimport_TCPSocketSetSendBufferSize((uint32)(self0), (uint64)(value0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/tcp@0.2.0 [method]tcp-socket.set-send-buffer-size
 //go:noescape
-func wasmimport_TCPSocketSetSendBufferSize(self0 uint32, value0 uint64, result *cm.Result[network.ErrorCode, struct{}, network.ErrorCode])
+func wasmimport_TCPSocketSetSendBufferSize(self0 uint32, value0 uint64, result unsafe.Pointer)
 
 // Shutdown represents the imported method "shutdown".
 //
@@ -623,13 +624,13 @@ func wasmimport_TCPSocketSetSendBufferSize(self0 uint32, value0 uint64, result *
 func (self TCPSocket) Shutdown(shutdownType ShutdownType) (result cm.Result[network.ErrorCode, struct{}, network.ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
 	shutdownType0 := (uint32)(shutdownType)
-	wasmimport_TCPSocketShutdown((uint32)(self0), (uint32)(shutdownType0), &result)
+	wasmimport_TCPSocketShutdown((uint32)(self0), (uint32)(shutdownType0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/tcp@0.2.0 [method]tcp-socket.shutdown
 //go:noescape
-func wasmimport_TCPSocketShutdown(self0 uint32, shutdownType0 uint32, result *cm.Result[network.ErrorCode, struct{}, network.ErrorCode])
+func wasmimport_TCPSocketShutdown(self0 uint32, shutdownType0 uint32, result unsafe.Pointer)
 
 // StartBind represents the imported method "start-bind".
 //
@@ -689,13 +690,13 @@ func (self TCPSocket) StartBind(network_ network.Network, localAddress network.I
 	self0 := cm.Reinterpret[uint32](self)
 	network0 := cm.Reinterpret[uint32](network_)
 	localAddress0, localAddress1, localAddress2, localAddress3, localAddress4, localAddress5, localAddress6, localAddress7, localAddress8, localAddress9, localAddress10, localAddress11 := lower_IPSocketAddress(localAddress)
-	wasmimport_TCPSocketStartBind((uint32)(self0), (uint32)(network0), (uint32)(localAddress0), (uint32)(localAddress1), (uint32)(localAddress2), (uint32)(localAddress3), (uint32)(localAddress4), (uint32)(localAddress5), (uint32)(localAddress6), (uint32)(localAddress7), (uint32)(localAddress8), (uint32)(localAddress9), (uint32)(localAddress10), (uint32)(localAddress11), &result)
+	wasmimport_TCPSocketStartBind((uint32)(self0), (uint32)(network0), (uint32)(localAddress0), (uint32)(localAddress1), (uint32)(localAddress2), (uint32)(localAddress3), (uint32)(localAddress4), (uint32)(localAddress5), (uint32)(localAddress6), (uint32)(localAddress7), (uint32)(localAddress8), (uint32)(localAddress9), (uint32)(localAddress10), (uint32)(localAddress11), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/tcp@0.2.0 [method]tcp-socket.start-bind
 //go:noescape
-func wasmimport_TCPSocketStartBind(self0 uint32, network0 uint32, localAddress0 uint32, localAddress1 uint32, localAddress2 uint32, localAddress3 uint32, localAddress4 uint32, localAddress5 uint32, localAddress6 uint32, localAddress7 uint32, localAddress8 uint32, localAddress9 uint32, localAddress10 uint32, localAddress11 uint32, result *cm.Result[network.ErrorCode, struct{}, network.ErrorCode])
+func wasmimport_TCPSocketStartBind(self0 uint32, network0 uint32, localAddress0 uint32, localAddress1 uint32, localAddress2 uint32, localAddress3 uint32, localAddress4 uint32, localAddress5 uint32, localAddress6 uint32, localAddress7 uint32, localAddress8 uint32, localAddress9 uint32, localAddress10 uint32, localAddress11 uint32, result unsafe.Pointer)
 
 // StartConnect represents the imported method "start-connect".
 //
@@ -761,13 +762,13 @@ func (self TCPSocket) StartConnect(network_ network.Network, remoteAddress netwo
 	self0 := cm.Reinterpret[uint32](self)
 	network0 := cm.Reinterpret[uint32](network_)
 	remoteAddress0, remoteAddress1, remoteAddress2, remoteAddress3, remoteAddress4, remoteAddress5, remoteAddress6, remoteAddress7, remoteAddress8, remoteAddress9, remoteAddress10, remoteAddress11 := lower_IPSocketAddress(remoteAddress)
-	wasmimport_TCPSocketStartConnect((uint32)(self0), (uint32)(network0), (uint32)(remoteAddress0), (uint32)(remoteAddress1), (uint32)(remoteAddress2), (uint32)(remoteAddress3), (uint32)(remoteAddress4), (uint32)(remoteAddress5), (uint32)(remoteAddress6), (uint32)(remoteAddress7), (uint32)(remoteAddress8), (uint32)(remoteAddress9), (uint32)(remoteAddress10), (uint32)(remoteAddress11), &result)
+	wasmimport_TCPSocketStartConnect((uint32)(self0), (uint32)(network0), (uint32)(remoteAddress0), (uint32)(remoteAddress1), (uint32)(remoteAddress2), (uint32)(remoteAddress3), (uint32)(remoteAddress4), (uint32)(remoteAddress5), (uint32)(remoteAddress6), (uint32)(remoteAddress7), (uint32)(remoteAddress8), (uint32)(remoteAddress9), (uint32)(remoteAddress10), (uint32)(remoteAddress11), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/tcp@0.2.0 [method]tcp-socket.start-connect
 //go:noescape
-func wasmimport_TCPSocketStartConnect(self0 uint32, network0 uint32, remoteAddress0 uint32, remoteAddress1 uint32, remoteAddress2 uint32, remoteAddress3 uint32, remoteAddress4 uint32, remoteAddress5 uint32, remoteAddress6 uint32, remoteAddress7 uint32, remoteAddress8 uint32, remoteAddress9 uint32, remoteAddress10 uint32, remoteAddress11 uint32, result *cm.Result[network.ErrorCode, struct{}, network.ErrorCode])
+func wasmimport_TCPSocketStartConnect(self0 uint32, network0 uint32, remoteAddress0 uint32, remoteAddress1 uint32, remoteAddress2 uint32, remoteAddress3 uint32, remoteAddress4 uint32, remoteAddress5 uint32, remoteAddress6 uint32, remoteAddress7 uint32, remoteAddress8 uint32, remoteAddress9 uint32, remoteAddress10 uint32, remoteAddress11 uint32, result unsafe.Pointer)
 
 // StartListen represents the imported method "start-listen".
 //
@@ -805,13 +806,13 @@ func wasmimport_TCPSocketStartConnect(self0 uint32, network0 uint32, remoteAddre
 //go:nosplit
 func (self TCPSocket) StartListen() (result cm.Result[network.ErrorCode, struct{}, network.ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_TCPSocketStartListen((uint32)(self0), &result)
+	wasmimport_TCPSocketStartListen((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/tcp@0.2.0 [method]tcp-socket.start-listen
 //go:noescape
-func wasmimport_TCPSocketStartListen(self0 uint32, result *cm.Result[network.ErrorCode, struct{}, network.ErrorCode])
+func wasmimport_TCPSocketStartListen(self0 uint32, result unsafe.Pointer)
 
 // Subscribe represents the imported method "subscribe".
 //
@@ -7,6 +7,7 @@ import (
 	"github.com/bytecodealliance/wasm-tools-go/cm"
 	"go.wasmcloud.dev/component/gen/wasi/sockets/network"
 	"go.wasmcloud.dev/component/gen/wasi/sockets/udp"
+	"unsafe"
 )
 
 // CreateUDPSocket represents the imported function "create-udp-socket".
@@ -43,10 +44,10 @@ import (
 //go:nosplit
 func CreateUDPSocket(addressFamily network.IPAddressFamily) (result cm.Result[udp.UDPSocket, udp.UDPSocket, network.ErrorCode]) {
 	addressFamily0 := (uint32)(addressFamily)
-	wasmimport_CreateUDPSocket((uint32)(addressFamily0), &result)
+	wasmimport_CreateUDPSocket((uint32)(addressFamily0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/udp-create-socket@0.2.0 create-udp-socket
 //go:noescape
-func wasmimport_CreateUDPSocket(addressFamily0 uint32, result *cm.Result[udp.UDPSocket, udp.UDPSocket, network.ErrorCode])
+func wasmimport_CreateUDPSocket(addressFamily0 uint32, result unsafe.Pointer)
@@ -7,6 +7,7 @@ import (
 	"github.com/bytecodealliance/wasm-tools-go/cm"
 	"go.wasmcloud.dev/component/gen/wasi/io/poll"
 	"go.wasmcloud.dev/component/gen/wasi/sockets/network"
+	"unsafe"
 )
 
 // IncomingDatagram represents the record "wasi:sockets/udp@0.2.0#incoming-datagram".
@@ -105,13 +106,13 @@ func wasmimport_UDPSocketAddressFamily(self0 uint32) (result0 uint32)
 //go:nosplit
 func (self UDPSocket) FinishBind() (result cm.Result[network.ErrorCode, struct{}, network.ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_UDPSocketFinishBind((uint32)(self0), &result)
+	wasmimport_UDPSocketFinishBind((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/udp@0.2.0 [method]udp-socket.finish-bind
 //go:noescape
-func wasmimport_UDPSocketFinishBind(self0 uint32, result *cm.Result[network.ErrorCode, struct{}, network.ErrorCode])
+func wasmimport_UDPSocketFinishBind(self0 uint32, result unsafe.Pointer)
 
 // LocalAddress represents the imported method "local-address".
 //
@@ -138,13 +139,13 @@ func wasmimport_UDPSocketFinishBind(self0 uint32, result *cm.Result[network.Erro
 //go:nosplit
 func (self UDPSocket) LocalAddress() (result cm.Result[IPSocketAddressShape, network.IPSocketAddress, network.ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_UDPSocketLocalAddress((uint32)(self0), &result)
+	wasmimport_UDPSocketLocalAddress((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/udp@0.2.0 [method]udp-socket.local-address
 //go:noescape
-func wasmimport_UDPSocketLocalAddress(self0 uint32, result *cm.Result[IPSocketAddressShape, network.IPSocketAddress, network.ErrorCode])
+func wasmimport_UDPSocketLocalAddress(self0 uint32, result unsafe.Pointer)
 
 // ReceiveBufferSize represents the imported method "receive-buffer-size".
 //
@@ -166,13 +167,13 @@ func wasmimport_UDPSocketLocalAddress(self0 uint32, result *cm.Result[IPSocketAd
 //go:nosplit
 func (self UDPSocket) ReceiveBufferSize() (result cm.Result[uint64, uint64, network.ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_UDPSocketReceiveBufferSize((uint32)(self0), &result)
+	wasmimport_UDPSocketReceiveBufferSize((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/udp@0.2.0 [method]udp-socket.receive-buffer-size
 //go:noescape
-func wasmimport_UDPSocketReceiveBufferSize(self0 uint32, result *cm.Result[uint64, uint64, network.ErrorCode])
+func wasmimport_UDPSocketReceiveBufferSize(self0 uint32, result unsafe.Pointer)
 
 // RemoteAddress represents the imported method "remote-address".
 //
@@ -192,13 +193,13 @@ func wasmimport_UDPSocketReceiveBufferSize(self0 uint32, result *cm.Result[uint6
 //go:nosplit
 func (self UDPSocket) RemoteAddress() (result cm.Result[IPSocketAddressShape, network.IPSocketAddress, network.ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_UDPSocketRemoteAddress((uint32)(self0), &result)
+	wasmimport_UDPSocketRemoteAddress((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/udp@0.2.0 [method]udp-socket.remote-address
 //go:noescape
-func wasmimport_UDPSocketRemoteAddress(self0 uint32, result *cm.Result[IPSocketAddressShape, network.IPSocketAddress, network.ErrorCode])
+func wasmimport_UDPSocketRemoteAddress(self0 uint32, result unsafe.Pointer)
 
 // SendBufferSize represents the imported method "send-buffer-size".
 //
@@ -207,13 +208,13 @@ func wasmimport_UDPSocketRemoteAddress(self0 uint32, result *cm.Result[IPSocketA
 //go:nosplit
 func (self UDPSocket) SendBufferSize() (result cm.Result[uint64, uint64, network.ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_UDPSocketSendBufferSize((uint32)(self0), &result)
+	wasmimport_UDPSocketSendBufferSize((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/udp@0.2.0 [method]udp-socket.send-buffer-size
 //go:noescape
-func wasmimport_UDPSocketSendBufferSize(self0 uint32, result *cm.Result[uint64, uint64, network.ErrorCode])
+func wasmimport_UDPSocketSendBufferSize(self0 uint32, result unsafe.Pointer)
 
 // SetReceiveBufferSize represents the imported method "set-receive-buffer-size".
 //
@@ -223,13 +224,13 @@ func wasmimport_UDPSocketSendBufferSize(self0 uint32, result *cm.Result[uint64,
 func (self UDPSocket) SetReceiveBufferSize(value uint64) (result cm.Result[network.ErrorCode, struct{}, network.ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
 	value0 := (uint64)(value)
-	wasmimport_UDPSocketSetReceiveBufferSize((uint32)(self0), (uint64)(value0), &result)
+	wasmimport_UDPSocketSetReceiveBufferSize((uint32)(self0), (uint64)(value0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/udp@0.2.0 [method]udp-socket.set-receive-buffer-size
 //go:noescape
-func wasmimport_UDPSocketSetReceiveBufferSize(self0 uint32, value0 uint64, result *cm.Result[network.ErrorCode, struct{}, network.ErrorCode])
+func wasmimport_UDPSocketSetReceiveBufferSize(self0 uint32, value0 uint64, result unsafe.Pointer)
 
 // SetSendBufferSize represents the imported method "set-send-buffer-size".
 //
@@ -239,13 +240,13 @@ func wasmimport_UDPSocketSetReceiveBufferSize(self0 uint32, value0 uint64, resul
 func (self UDPSocket) SetSendBufferSize(value uint64) (result cm.Result[network.ErrorCode, struct{}, network.ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
 	value0 := (uint64)(value)
-	wasmimport_UDPSocketSetSendBufferSize((uint32)(self0), (uint64)(value0), &result)
+	wasmimport_UDPSocketSetSendBufferSize((uint32)(self0), (uint64)(value0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/udp@0.2.0 [method]udp-socket.set-send-buffer-size
 //go:noescape
-func wasmimport_UDPSocketSetSendBufferSize(self0 uint32, value0 uint64, result *cm.Result[network.ErrorCode, struct{}, network.ErrorCode])
+func wasmimport_UDPSocketSetSendBufferSize(self0 uint32, value0 uint64, result unsafe.Pointer)
 
 // SetUnicastHopLimit represents the imported method "set-unicast-hop-limit".
 //
@@ -255,13 +256,13 @@ func wasmimport_UDPSocketSetSendBufferSize(self0 uint32, value0 uint64, result *
 func (self UDPSocket) SetUnicastHopLimit(value uint8) (result cm.Result[network.ErrorCode, struct{}, network.ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
 	value0 := (uint32)(value)
-	wasmimport_UDPSocketSetUnicastHopLimit((uint32)(self0), (uint32)(value0), &result)
+	wasmimport_UDPSocketSetUnicastHopLimit((uint32)(self0), (uint32)(value0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/udp@0.2.0 [method]udp-socket.set-unicast-hop-limit
 //go:noescape
-func wasmimport_UDPSocketSetUnicastHopLimit(self0 uint32, value0 uint32, result *cm.Result[network.ErrorCode, struct{}, network.ErrorCode])
+func wasmimport_UDPSocketSetUnicastHopLimit(self0 uint32, value0 uint32, result unsafe.Pointer)
 
 // StartBind represents the imported method "start-bind".
 //
@@ -305,13 +306,13 @@ func (self UDPSocket) StartBind(network_ network.Network, localAddress network.I
 	self0 := cm.Reinterpret[uint32](self)
 	network0 := cm.Reinterpret[uint32](network_)
 	localAddress0, localAddress1, localAddress2, localAddress3, localAddress4, localAddress5, localAddress6, localAddress7, localAddress8, localAddress9, localAddress10, localAddress11 := lower_IPSocketAddress(localAddress)
-	wasmimport_UDPSocketStartBind((uint32)(self0), (uint32)(network0), (uint32)(localAddress0), (uint32)(localAddress1), (uint32)(localAddress2), (uint32)(localAddress3), (uint32)(localAddress4), (uint32)(localAddress5), (uint32)(localAddress6), (uint32)(localAddress7), (uint32)(localAddress8), (uint32)(localAddress9), (uint32)(localAddress10), (uint32)(localAddress11), &result)
+	wasmimport_UDPSocketStartBind((uint32)(self0), (uint32)(network0), (uint32)(localAddress0), (uint32)(localAddress1), (uint32)(localAddress2), (uint32)(localAddress3), (uint32)(localAddress4), (uint32)(localAddress5), (uint32)(localAddress6), (uint32)(localAddress7), (uint32)(localAddress8), (uint32)(localAddress9), (uint32)(localAddress10), (uint32)(localAddress11), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/udp@0.2.0 [method]udp-socket.start-bind
 //go:noescape
-func wasmimport_UDPSocketStartBind(self0 uint32, network0 uint32, localAddress0 uint32, localAddress1 uint32, localAddress2 uint32, localAddress3 uint32, localAddress4 uint32, localAddress5 uint32, localAddress6 uint32, localAddress7 uint32, localAddress8 uint32, localAddress9 uint32, localAddress10 uint32, localAddress11 uint32, result *cm.Result[network.ErrorCode, struct{}, network.ErrorCode])
+func wasmimport_UDPSocketStartBind(self0 uint32, network0 uint32, localAddress0 uint32, localAddress1 uint32, localAddress2 uint32, localAddress3 uint32, localAddress4 uint32, localAddress5 uint32, localAddress6 uint32, localAddress7 uint32, localAddress8 uint32, localAddress9 uint32, localAddress10 uint32, localAddress11 uint32, result unsafe.Pointer)
 
 // Stream represents the imported method "stream".
 //
@@ -373,13 +374,13 @@ func wasmimport_UDPSocketStartBind(self0 uint32, network0 uint32, localAddress0
 func (self UDPSocket) Stream(remoteAddress cm.Option[network.IPSocketAddress]) (result cm.Result[TupleIncomingDatagramStreamOutgoingDatagramStreamShape, cm.Tuple[IncomingDatagramStream, OutgoingDatagramStream], network.ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
 	remoteAddress0, remoteAddress1, remoteAddress2, remoteAddress3, remoteAddress4, remoteAddress5, remoteAddress6, remoteAddress7, remoteAddress8, remoteAddress9, remoteAddress10, remoteAddress11, remoteAddress12 := lower_OptionIPSocketAddress(remoteAddress)
-	wasmimport_UDPSocketStream((uint32)(self0), (uint32)(remoteAddress0), (uint32)(remoteAddress1), (uint32)(remoteAddress2), (uint32)(remoteAddress3), (uint32)(remoteAddress4), (uint32)(remoteAddress5), (uint32)(remoteAddress6), (uint32)(remoteAddress7), (uint32)(remoteAddress8), (uint32)(remoteAddress9), (uint32)(remoteAddress10), (uint32)(remoteAddress11), (uint32)(remoteAddress12), &result)
+	wasmimport_UDPSocketStream((uint32)(self0), (uint32)(remoteAddress0), (uint32)(remoteAddress1), (uint32)(remoteAddress2), (uint32)(remoteAddress3), (uint32)(remoteAddress4), (uint32)(remoteAddress5), (uint32)(remoteAddress6), (uint32)(remoteAddress7), (uint32)(remoteAddress8), (uint32)(remoteAddress9), (uint32)(remoteAddress10), (uint32)(remoteAddress11), (uint32)(remoteAddress12), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/udp@0.2.0 [method]udp-socket.stream
 //go:noescape
-func wasmimport_UDPSocketStream(self0 uint32, remoteAddress0 uint32, remoteAddress1 uint32, remoteAddress2 uint32, remoteAddress3 uint32, remoteAddress4 uint32, remoteAddress5 uint32, remoteAddress6 uint32, remoteAddress7 uint32, remoteAddress8 uint32, remoteAddress9 uint32, remoteAddress10 uint32, remoteAddress11 uint32, remoteAddress12 uint32, result *cm.Result[TupleIncomingDatagramStreamOutgoingDatagramStreamShape, cm.Tuple[IncomingDatagramStream, OutgoingDatagramStream], network.ErrorCode])
+func wasmimport_UDPSocketStream(self0 uint32, remoteAddress0 uint32, remoteAddress1 uint32, remoteAddress2 uint32, remoteAddress3 uint32, remoteAddress4 uint32, remoteAddress5 uint32, remoteAddress6 uint32, remoteAddress7 uint32, remoteAddress8 uint32, remoteAddress9 uint32, remoteAddress10 uint32, remoteAddress11 uint32, remoteAddress12 uint32, result unsafe.Pointer)
 
 // Subscribe represents the imported method "subscribe".
 //
@@ -416,13 +417,13 @@ func wasmimport_UDPSocketSubscribe(self0 uint32) (result0 uint32)
 //go:nosplit
 func (self UDPSocket) UnicastHopLimit() (result cm.Result[uint8, uint8, network.ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_UDPSocketUnicastHopLimit((uint32)(self0), &result)
+	wasmimport_UDPSocketUnicastHopLimit((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/udp@0.2.0 [method]udp-socket.unicast-hop-limit
 //go:noescape
-func wasmimport_UDPSocketUnicastHopLimit(self0 uint32, result *cm.Result[uint8, uint8, network.ErrorCode])
+func wasmimport_UDPSocketUnicastHopLimit(self0 uint32, result unsafe.Pointer)
 
 // IncomingDatagramStream represents the imported resource "wasi:sockets/udp@0.2.0#incoming-datagram-stream".
 //
@@ -478,13 +479,13 @@ func wasmimport_IncomingDatagramStreamResourceDrop(self0 uint32)
 func (self IncomingDatagramStream) Receive(maxResults uint64) (result cm.Result[cm.List[IncomingDatagram], cm.List[IncomingDatagram], network.ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
 	maxResults0 := (uint64)(maxResults)
-	wasmimport_IncomingDatagramStreamReceive((uint32)(self0), (uint64)(maxResults0), &result)
+	wasmimport_IncomingDatagramStreamReceive((uint32)(self0), (uint64)(maxResults0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/udp@0.2.0 [method]incoming-datagram-stream.receive
 //go:noescape
-func wasmimport_IncomingDatagramStreamReceive(self0 uint32, maxResults0 uint64, result *cm.Result[cm.List[IncomingDatagram], cm.List[IncomingDatagram], network.ErrorCode])
+func wasmimport_IncomingDatagramStreamReceive(self0 uint32, maxResults0 uint64, result unsafe.Pointer)
 
 // Subscribe represents the imported method "subscribe".
 //
@@ -546,13 +547,13 @@ func wasmimport_OutgoingDatagramStreamResourceDrop(self0 uint32)
 //go:nosplit
 func (self OutgoingDatagramStream) CheckSend() (result cm.Result[uint64, uint64, network.ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
-	wasmimport_OutgoingDatagramStreamCheckSend((uint32)(self0), &result)
+	wasmimport_OutgoingDatagramStreamCheckSend((uint32)(self0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/udp@0.2.0 [method]outgoing-datagram-stream.check-send
 //go:noescape
-func wasmimport_OutgoingDatagramStreamCheckSend(self0 uint32, result *cm.Result[uint64, uint64, network.ErrorCode])
+func wasmimport_OutgoingDatagramStreamCheckSend(self0 uint32, result unsafe.Pointer)
 
 // Send represents the imported method "send".
 //
@@ -611,13 +612,13 @@ func wasmimport_OutgoingDatagramStreamCheckSend(self0 uint32, result *cm.Result[
 func (self OutgoingDatagramStream) Send(datagrams cm.List[OutgoingDatagram]) (result cm.Result[uint64, uint64, network.ErrorCode]) {
 	self0 := cm.Reinterpret[uint32](self)
 	datagrams0, datagrams1 := cm.LowerList(datagrams)
-	wasmimport_OutgoingDatagramStreamSend((uint32)(self0), (*OutgoingDatagram)(datagrams0), (uint32)(datagrams1), &result)
+	wasmimport_OutgoingDatagramStreamSend((uint32)(self0), unsafe.Pointer((*OutgoingDatagram)(datagrams0)), (uint32)(datagrams1), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasi:sockets/udp@0.2.0 [method]outgoing-datagram-stream.send
 //go:noescape
-func wasmimport_OutgoingDatagramStreamSend(self0 uint32, datagrams0 *OutgoingDatagram, datagrams1 uint32, result *cm.Result[uint64, uint64, network.ErrorCode])
+func wasmimport_OutgoingDatagramStreamSend(self0 uint32, datagrams0 unsafe.Pointer, datagrams1 uint32, result unsafe.Pointer)
 
 // Subscribe represents the imported method "subscribe".
 //
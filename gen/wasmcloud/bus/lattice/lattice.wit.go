@@ -5,6 +5,7 @@ package lattice
 
 import (
 	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"unsafe"
 )
 
 // CallTargetInterface represents the imported resource "wasmcloud:bus/lattice@1.0.0#call-target-interface".
@@ -38,14 +39,14 @@ func NewCallTargetInterface(namespace string, package_ string, interface_ string
 	namespace0, namespace1 := cm.LowerString(namespace)
 	package0, package1 := cm.LowerString(package_)
 	interface0, interface1 := cm.LowerString(interface_)
-	result0 := wasmimport_NewCallTargetInterface((*uint8)(namespace0), (uint32)(namespace1), (*uint8)(package0), (uint32)(package1), (*uint8)(interface0), (uint32)(interface1))
+	result0 := wasmimport_NewCallTargetInterface(unsafe.Pointer((*uint8)(namespace0)), (uint32)(namespace1), unsafe.Pointer((*uint8)(package0)), (uint32)(package1), unsafe.Pointer((*uint8)(interface0)), (uint32)(interface1))
 	result = cm.Reinterpret[CallTargetInterface]((uint32)(result0))
 	return
 }
 
 //go:wasmimport wasmcloud:bus/lattice@1.0.0 [constructor]call-target-interface
 //go:noescape
-func wasmimport_NewCallTargetInterface(namespace0 *uint8, namespace1 uint32, package0 *uint8, package1 uint32, interface0 *uint8, interface1 uint32) (result0 uint32)
+func wasmimport_NewCallTargetInterface(namespace0 unsafe.Pointer, namespace1 uint32, package0 unsafe.Pointer, package1 uint32, interface0 unsafe.Pointer, interface1 uint32) (result0 uint32)
 
 // SetLinkName represents the imported function "set-link-name".
 //
@@ -61,10 +62,10 @@ func wasmimport_NewCallTargetInterface(namespace0 *uint8, namespace1 uint32, pac
 func SetLinkName(name string, interfaces cm.List[CallTargetInterface]) {
 	name0, name1 := cm.LowerString(name)
 	interfaces0, interfaces1 := cm.LowerList(interfaces)
-	wasmimport_SetLinkName((*uint8)(name0), (uint32)(name1), (*CallTargetInterface)(interfaces0), (uint32)(interfaces1))
+	wasmimport_SetLinkName(unsafe.Pointer((*uint8)(name0)), (uint32)(name1), unsafe.Pointer((*CallTargetInterface)(interfaces0)), (uint32)(interfaces1))
 	return
 }
 
 //go:wasmimport wasmcloud:bus/lattice@1.0.0 set-link-name
 //go:noescape
-func wasmimport_SetLinkName(name0 *uint8, name1 uint32, interfaces0 *CallTargetInterface, interfaces1 uint32)
+func wasmimport_SetLinkName(name0 unsafe.Pointer, name1 uint32, interfaces0 unsafe.Pointer, interfaces1 uint32)
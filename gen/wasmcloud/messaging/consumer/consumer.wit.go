@@ -6,6 +6,7 @@ package consumer
 import (
 	"github.com/bytecodealliance/wasm-tools-go/cm"
 	"go.wasmcloud.dev/component/gen/wasmcloud/messaging/types"
+	"unsafe"
 )
 
 // Request represents the imported function "request".
@@ -20,13 +21,13 @@ func Request(subject string, body cm.List[uint8], timeoutMs uint32) (result cm.R
 	subject0, subject1 := cm.LowerString(subject)
 	body0, body1 := cm.LowerList(body)
 	timeoutMs0 := (uint32)(timeoutMs)
-	wasmimport_Request((*uint8)(subject0), (uint32)(subject1), (*uint8)(body0), (uint32)(body1), (uint32)(timeoutMs0), &result)
+	wasmimport_Request(unsafe.Pointer((*uint8)(subject0)), (uint32)(subject1), unsafe.Pointer((*uint8)(body0)), (uint32)(body1), (uint32)(timeoutMs0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasmcloud:messaging/consumer@0.2.0 request
 //go:noescape
-func wasmimport_Request(subject0 *uint8, subject1 uint32, body0 *uint8, body1 uint32, timeoutMs0 uint32, result *cm.Result[BrokerMessageShape, types.BrokerMessage, string])
+func wasmimport_Request(subject0 unsafe.Pointer, subject1 uint32, body0 unsafe.Pointer, body1 uint32, timeoutMs0 uint32, result unsafe.Pointer)
 
 // Publish represents the imported function "publish".
 //
@@ -37,10 +38,10 @@ func wasmimport_Request(subject0 *uint8, subject1 uint32, body0 *uint8, body1 ui
 //go:nosplit
 func Publish(msg types.BrokerMessage) (result cm.Result[string, struct{}, string]) {
 	msg0, msg1, msg2, msg3, msg4, msg5, msg6 := lower_BrokerMessage(msg)
-	wasmimport_Publish((*uint8)(msg0), (uint32)(msg1), (*uint8)(msg2), (uint32)(msg3), (uint32)(msg4), (*uint8)(msg5), (uint32)(msg6), &result)
+	wasmimport_Publish(unsafe.Pointer((*uint8)(msg0)), (uint32)(msg1), unsafe.Pointer((*uint8)(msg2)), (uint32)(msg3), (uint32)(msg4), unsafe.Pointer((*uint8)(msg5)), (uint32)(msg6), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasmcloud:messaging/consumer@0.2.0 publish
 //go:noescape
-func wasmimport_Publish(msg0 *uint8, msg1 uint32, msg2 *uint8, msg3 uint32, msg4 uint32, msg5 *uint8, msg6 uint32, result *cm.Result[string, struct{}, string])
+func wasmimport_Publish(msg0 unsafe.Pointer, msg1 uint32, msg2 unsafe.Pointer, msg3 uint32, msg4 uint32, msg5 unsafe.Pointer, msg6 uint32, result unsafe.Pointer)
@@ -5,11 +5,16 @@ package handler
 
 import (
 	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"unsafe"
 )
 
 //go:wasmexport wasmcloud:messaging/handler@0.2.0#handle-message
 //export wasmcloud:messaging/handler@0.2.0#handle-message
-func wasmexport_HandleMessage(msg0 *uint8, msg1 uint32, msg2 *uint8, msg3 uint32, msg4 uint32, msg5 *uint8, msg6 uint32) (result *cm.Result[string, struct{}, string]) {
+func wasmexport_HandleMessage(msg0 unsafe.Pointer, msg1 uint32, msg2 unsafe.Pointer, msg3 uint32, msg4 uint32, msg5 unsafe.Pointer, msg6 uint32) unsafe.Pointer {
+	return unsafe.Pointer(wasmexport_HandleMessage_trampoline((*uint8)(msg0), msg1, (*uint8)(msg2), msg3, msg4, (*uint8)(msg5), msg6))
+}
+
+func wasmexport_HandleMessage_trampoline(msg0 *uint8, msg1 uint32, msg2 *uint8, msg3 uint32, msg4 uint32, msg5 *uint8, msg6 uint32) (result *cm.Result[string, struct{}, string]) {
 	msg := lift_BrokerMessage((*uint8)(msg0), (uint32)(msg1), (*uint8)(msg2), (uint32)(msg3), (uint32)(msg4), (*uint8)(msg5), (uint32)(msg6))
 	result_ := Exports.HandleMessage(msg)
 	result = &result_
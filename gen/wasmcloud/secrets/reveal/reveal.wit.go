@@ -6,6 +6,7 @@ package reveal
 import (
 	"github.com/bytecodealliance/wasm-tools-go/cm"
 	"go.wasmcloud.dev/component/gen/wasmcloud/secrets/store"
+	"unsafe"
 )
 
 // Reveal represents the imported function "reveal".
@@ -18,10 +19,10 @@ import (
 //go:nosplit
 func Reveal(s store.Secret) (result store.SecretValue) {
 	s0 := cm.Reinterpret[uint32](s)
-	wasmimport_Reveal((uint32)(s0), &result)
+	wasmimport_Reveal((uint32)(s0), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasmcloud:secrets/reveal@0.1.0-draft reveal
 //go:noescape
-func wasmimport_Reveal(s0 uint32, result *store.SecretValue)
+func wasmimport_Reveal(s0 uint32, result unsafe.Pointer)
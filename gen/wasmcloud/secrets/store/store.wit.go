@@ -5,6 +5,7 @@ package store
 
 import (
 	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"unsafe"
 )
 
 // SecretsError represents the variant "wasmcloud:secrets/store@0.1.0-draft#secrets-error".
@@ -135,10 +136,10 @@ func wasmimport_SecretResourceDrop(self0 uint32)
 //go:nosplit
 func Get(key string) (result cm.Result[SecretsErrorShape, Secret, SecretsError]) {
 	key0, key1 := cm.LowerString(key)
-	wasmimport_Get((*uint8)(key0), (uint32)(key1), &result)
+	wasmimport_Get(unsafe.Pointer((*uint8)(key0)), (uint32)(key1), unsafe.Pointer(&result))
 	return
 }
 
 //go:wasmimport wasmcloud:secrets/store@0.1.0-draft get
 //go:noescape
-func wasmimport_Get(key0 *uint8, key1 uint32, result *cm.Result[SecretsErrorShape, Secret, SecretsError])
+func wasmimport_Get(key0 unsafe.Pointer, key1 uint32, result unsafe.Pointer)
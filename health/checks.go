@@ -0,0 +1,105 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.wasmcloud.dev/component/config/wasiconfig"
+	"go.wasmcloud.dev/component/kv/wasikv"
+	"go.wasmcloud.dev/component/time/wasiclock"
+)
+
+// probeKey is the key looked up by [Bucket], it does not need to exist.
+const probeKey = "health/probe"
+
+// Config returns a check reporting down unless every key of the runtime
+// config is set.
+func Config(keys ...string) CheckFunc {
+	return func(ctx context.Context) error {
+		for _, key := range keys {
+			_, err := wasiconfig.Get(key)
+			if errors.Is(err, wasiconfig.ErrNotFound) {
+				return fmt.Errorf("missing config key %q", key)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// Bucket returns a check opening the key-value bucket identifier, with the
+// link name of the context, and looking up a key.
+func Bucket(identifier string) CheckFunc {
+	return func(ctx context.Context) error {
+		b, err := wasikv.OpenContext(ctx, identifier)
+		if err != nil {
+			return err
+		}
+		defer b.Close()
+
+		if _, err := b.Exists(probeKey); err != nil {
+			return fmt.Errorf("failed to reach bucket %q: %w", identifier, err)
+		}
+		return nil
+	}
+}
+
+// Messages tracks the messages processed by a handler, see the Health
+// middleware of go.wasmcloud.dev/component/messaging/middleware.
+type Messages struct {
+	maxAge time.Duration
+
+	mu    sync.Mutex
+	since time.Time
+	last  time.Time
+	err   error
+}
+
+// NewMessages returns a Messages whose [Messages.Check] reports down if no
+// message is processed within maxAge, or never if maxAge is zero.
+func NewMessages(maxAge time.Duration) *Messages {
+	return &Messages{maxAge: maxAge, since: wasiclock.NowFunc()}
+}
+
+// Observe records a processed message and the error of its handler.
+func (m *Messages) Observe(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.last, m.err = wasiclock.NowFunc(), err
+}
+
+// Last returns the time the last message was processed, zero if none was.
+func (m *Messages) Last() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.last
+}
+
+// Check reports down if the last message failed, or if no message was
+// processed within the max age.
+func (m *Messages) Check(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.err != nil {
+		return fmt.Errorf("last message failed: %w", m.err)
+	}
+	if m.maxAge <= 0 {
+		return nil
+	}
+	last := m.last
+	if last.IsZero() {
+		last = m.since
+	}
+	if age := wasiclock.NowFunc().Sub(last); age > m.maxAge {
+		return fmt.Errorf("no message processed in %s", age.Truncate(time.Second))
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+//go:build !wasm
+
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.wasmcloud.dev/component/internal/fakehost"
+	"go.wasmcloud.dev/component/internal/memory"
+	"go.wasmcloud.dev/component/time/wasiclock"
+)
+
+func TestConfig(t *testing.T) {
+	fakehost.Config.Values = map[string]string{"url": "nats://localhost:4222"}
+	t.Cleanup(func() { fakehost.Config.Values = nil })
+
+	if err := Config("url")(context.Background()); err != nil {
+		t.Errorf("expected: %v, got: %v", nil, err)
+	}
+	want := `missing config key "token"`
+	if err := Config("url", "token")(context.Background()); err == nil || err.Error() != want {
+		t.Errorf("expected: %v, got: %v", want, err)
+	}
+}
+
+func TestBucket(t *testing.T) {
+	fakehost.Buckets.Set("default", memory.NewBucket(nil))
+	t.Cleanup(func() { fakehost.Buckets.Remove("default") })
+
+	if err := Bucket("default")(context.Background()); err != nil {
+		t.Errorf("expected: %v, got: %v", nil, err)
+	}
+	if err := Bucket("missing")(context.Background()); err == nil {
+		t.Errorf("expected: error, got: %v", err)
+	}
+}
+
+func TestMessages(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	prev := wasiclock.NowFunc
+	wasiclock.NowFunc = func() time.Time { return now }
+	t.Cleanup(func() { wasiclock.NowFunc = prev })
+
+	m := NewMessages(time.Minute)
+	steps := []struct {
+		advance time.Duration
+		observe bool
+		err     error
+		want    string
+	}{
+		{want: ""},
+		{advance: 2 * time.Minute, want: "no message processed in 2m0s"},
+		{observe: true, want: ""},
+		{advance: 30 * time.Second, observe: true, err: errors.New("bad payload"), want: "last message failed: bad payload"},
+		{observe: true, want: ""},
+		{advance: 90 * time.Second, want: "no message processed in 1m30s"},
+	}
+	for i, step := range steps {
+		now = now.Add(step.advance)
+		if step.observe {
+			m.Observe(step.err)
+		}
+		got := ""
+		if err := m.Check(context.Background()); err != nil {
+			got = err.Error()
+		}
+		if got != step.want {
+			t.Errorf("step %d: expected: %q, got: %q", i, step.want, got)
+		}
+	}
+	if got := m.Last(); !got.Equal(now.Add(-90 * time.Second)) {
+		t.Errorf("expected: %v, got: %v", now.Add(-90*time.Second), got)
+	}
+}
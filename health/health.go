@@ -0,0 +1,110 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// NOTE: `wasi:http` is the only interface the wasmCloud host probes, there is
+// no dedicated health interface to export. The checks are served by [Handler]
+// and [Route] instead.
+
+// CheckFunc reports the status of a subsystem, e.g. a reachable key-value
+// store. It returns nil if the subsystem is healthy.
+type CheckFunc func(ctx context.Context) error
+
+// Status is the status of a check or of a whole [Report].
+type Status string
+
+const (
+	StatusUp   Status = "up"
+	StatusDown Status = "down"
+)
+
+// Result is the outcome of a check.
+type Result struct {
+	Status Status `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Report is the outcome of the registered checks, it is up if every check is.
+type Report struct {
+	Status Status            `json:"status"`
+	Checks map[string]Result `json:"checks,omitempty"`
+}
+
+var registry = struct {
+	sync.RWMutex
+	checks map[string]CheckFunc
+}{checks: map[string]CheckFunc{}}
+
+// Register registers check under name, e.g. "kv". It is typically called in
+// an init function.
+//
+// Register panics if name is registered twice.
+func Register(name string, check CheckFunc) {
+	if check == nil {
+		panic(fmt.Sprintf("health: nil check registered for %q", name))
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	if _, ok := registry.checks[name]; ok {
+		panic(fmt.Sprintf("health: multiple registrations for %q", name))
+	}
+	registry.checks[name] = check
+}
+
+// Registered returns the sorted names of the registered checks.
+func Registered() []string {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	names := make([]string, 0, len(registry.checks))
+	for name := range registry.checks {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Check runs the registered checks in the order of their names. A panicking
+// check is reported down.
+func Check(ctx context.Context) Report {
+	registry.RLock()
+	names := make([]string, 0, len(registry.checks))
+	checks := make(map[string]CheckFunc, len(registry.checks))
+	for name, check := range registry.checks {
+		names = append(names, name)
+		checks[name] = check
+	}
+	registry.RUnlock()
+	slices.Sort(names)
+
+	report := Report{Status: StatusUp, Checks: make(map[string]Result, len(checks))}
+	for _, name := range names {
+		check := checks[name]
+		result := Result{Status: StatusUp}
+		if err := run(ctx, check); err != nil {
+			result = Result{Status: StatusDown, Error: err.Error()}
+			report.Status = StatusDown
+		}
+		report.Checks[name] = result
+	}
+	return report
+}
+
+func run(ctx context.Context, check CheckFunc) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return check(ctx)
+}
@@ -0,0 +1,126 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func withChecks(t *testing.T, checks map[string]CheckFunc) {
+	t.Helper()
+	registry.Lock()
+	prev := registry.checks
+	registry.checks = map[string]CheckFunc{}
+	registry.Unlock()
+	t.Cleanup(func() {
+		registry.Lock()
+		registry.checks = prev
+		registry.Unlock()
+	})
+	for name, check := range checks {
+		Register(name, check)
+	}
+}
+
+func up(context.Context) error { return nil }
+
+func TestCheck(t *testing.T) {
+	tests := map[string]struct {
+		checks map[string]CheckFunc
+		want   Report
+	}{
+		"no checks": {
+			want: Report{Status: StatusUp, Checks: map[string]Result{}},
+		},
+		"up": {
+			checks: map[string]CheckFunc{"config": up, "kv": up},
+			want:   Report{Status: StatusUp, Checks: map[string]Result{"config": {Status: StatusUp}, "kv": {Status: StatusUp}}},
+		},
+		"down": {
+			checks: map[string]CheckFunc{
+				"config": up,
+				"kv":     func(context.Context) error { return errors.New("connection refused") },
+			},
+			want: Report{Status: StatusDown, Checks: map[string]Result{
+				"config": {Status: StatusUp},
+				"kv":     {Status: StatusDown, Error: "connection refused"},
+			}},
+		},
+		"panic": {
+			checks: map[string]CheckFunc{"kv": func(context.Context) error { panic("boom") }},
+			want:   Report{Status: StatusDown, Checks: map[string]Result{"kv": {Status: StatusDown, Error: "panic: boom"}}},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			withChecks(t, tt.checks)
+
+			if got := Check(context.Background()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected: %v, got: %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRegisterTwice(t *testing.T) {
+	withChecks(t, map[string]CheckFunc{"kv": up})
+
+	defer func() {
+		if r := recover(); r != `health: multiple registrations for "kv"` {
+			t.Errorf("expected: multiple registrations panic, got: %v", r)
+		}
+	}()
+	Register("kv", up)
+}
+
+func TestRoute(t *testing.T) {
+	down := false
+	withChecks(t, map[string]CheckFunc{"kv": func(context.Context) error {
+		if down {
+			return errors.New("connection refused")
+		}
+		return nil
+	}})
+
+	h := Route(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+
+	tests := map[string]struct {
+		path   string
+		down   bool
+		status int
+		want   Status
+	}{
+		"live":           {path: LivePath, status: http.StatusOK, want: StatusUp},
+		"live when down": {path: LivePath, down: true, status: http.StatusOK, want: StatusUp},
+		"ready":          {path: ReadyPath, status: http.StatusOK, want: StatusUp},
+		"not ready":      {path: ReadyPath, down: true, status: http.StatusServiceUnavailable, want: StatusDown},
+		"other":          {path: "/orders", status: http.StatusTeapot},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			down = tt.down
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if rec.Code != tt.status {
+				t.Errorf("expected: %v, got: %v", tt.status, rec.Code)
+			}
+			if tt.want == "" {
+				return
+			}
+			var report Report
+			if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+				t.Fatal(err)
+			}
+			if report.Status != tt.want {
+				t.Errorf("expected: %v, got: %v", tt.want, report.Status)
+			}
+		})
+	}
+}
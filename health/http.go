@@ -0,0 +1,48 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+const (
+	// LivePath is the liveness path of [Route], up as long as the component
+	// serves requests.
+	LivePath = "/healthz"
+	// ReadyPath is the readiness path of [Route], reporting the registered
+	// checks.
+	ReadyPath = "/readyz"
+)
+
+// Handler returns a handler running the registered checks and writing the
+// [Report] as JSON, with status 200 if it is up and 503 otherwise.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeReport(w, Check(r.Context()))
+	})
+}
+
+// Route serves [LivePath] and [ReadyPath] and passes other requests to next,
+// e.g. wasihttp.Handle(health.Route(mux)).
+func Route(next http.Handler) http.Handler {
+	ready := Handler()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case LivePath:
+			writeReport(w, Report{Status: StatusUp})
+		case ReadyPath:
+			ready.ServeHTTP(w, r)
+		default:
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+func writeReport(w http.ResponseWriter, report Report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if report.Status != StatusUp {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,83 @@
+package backend
+
+import (
+	"io"
+	"time"
+)
+
+// KeyValue is `wasi:keyvalue/store`, as called by wasikv.
+type KeyValue interface {
+	Open(identifier string) (Bucket, error)
+}
+
+// Bucket is an open `wasi:keyvalue` bucket. Errors are the wasikv sentinel
+// errors, or free-form errors reported by the host.
+type Bucket interface {
+	// Get returns the value of key, ok is false if it does not exist.
+	Get(key string) (value []byte, ok bool, err error)
+	Set(key string, value []byte) error
+	Delete(key string) error
+	Exists(key string) (bool, error)
+	// ListKeys returns a page of keys, and the cursor of the next page or nil
+	// after the last page.
+	ListKeys(cursor *uint64) (keys []string, next *uint64, err error)
+	Increment(key string, delta uint64) (uint64, error)
+	DeleteMany(keys []string) error
+	Close()
+}
+
+// Blobstore is `wasi:blobstore/blobstore`, as called by wasiblob.
+type Blobstore interface {
+	CreateContainer(name string) (Container, error)
+	GetContainer(name string) (Container, error)
+	DeleteContainer(name string) error
+	ContainerExists(name string) (bool, error)
+	CopyObject(srcContainer, srcObject, dstContainer, dstObject string) error
+	MoveObject(srcContainer, srcObject, dstContainer, dstObject string) error
+}
+
+// ObjectInfo describes a blobstore object, CreatedAt is in seconds since the
+// Unix epoch.
+type ObjectInfo struct {
+	Name      string
+	Container string
+	CreatedAt uint64
+	Size      uint64
+}
+
+// Container is an open `wasi:blobstore/container`.
+type Container interface {
+	CreatedAt() (uint64, error)
+	ObjectInfo(name string) (ObjectInfo, error)
+	HasObject(name string) (bool, error)
+	DeleteObject(name string) error
+	DeleteObjects(names []string) error
+	Clear() error
+	ListObjects() (ObjectNames, error)
+	// GetData returns the bytes of the object from start to the inclusive end.
+	GetData(name string, start, end uint64) (io.ReadCloser, error)
+	// WriteData returns a writer creating or replacing the object, the object
+	// is complete once the writer is closed.
+	WriteData(name string) (io.WriteCloser, error)
+	Close()
+}
+
+// ObjectNames is a listing of the object names of a container.
+type ObjectNames interface {
+	// Read returns up to n names, done is set once the listing is exhausted.
+	Read(n uint64) (names []string, done bool, err error)
+	Close()
+}
+
+// Message is a `wasmcloud:messaging` broker message.
+type Message struct {
+	Subject string
+	Body    []byte
+	ReplyTo string
+}
+
+// Messaging is `wasmcloud:messaging/consumer`, as called by messaging.
+type Messaging interface {
+	Publish(msg Message) error
+	Request(subject string, body []byte, timeout time.Duration) (Message, error)
+}
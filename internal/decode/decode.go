@@ -0,0 +1,139 @@
+package decode
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// Decoder sets struct fields from string values, shared by the packages
+// decoding environment variables and runtime config into tagged structs.
+type Decoder struct {
+	// Tag is the struct tag naming the key of a field, e.g. "env".
+	Tag string
+	// Lookup returns the value at key and whether it is set.
+	Lookup func(key string) (string, bool)
+	// Errorf formats decoding errors, prefixing them with the calling package.
+	Errorf func(format string, args ...any) error
+}
+
+// Struct decodes the fields of the struct rv, prefixing keys with prefix.
+// Nested struct fields are decoded with their tag appended to the prefix.
+func (d *Decoder) Struct(rv reflect.Value, prefix string) error {
+	rt := rv.Type()
+	for i := range rt.NumField() {
+		field := rt.Field(i)
+		tag, ok := field.Tag.Lookup(d.Tag)
+		if !ok || !field.IsExported() {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		name = prefix + name
+
+		fv := rv.Field(i)
+		if _, ok := textUnmarshaler(fv); fv.Kind() == reflect.Struct && !ok {
+			if err := d.Struct(fv, name); err != nil {
+				return err
+			}
+			continue
+		}
+
+		value, ok := d.Lookup(name)
+		if !ok {
+			if opts == "required" {
+				return d.Errorf("required %s is not set", name)
+			}
+			if value, ok = field.Tag.Lookup("default"); !ok {
+				continue
+			}
+		}
+
+		if err := Value(fv, value); err != nil {
+			return d.Errorf("failed to decode %s: %s", name, err)
+		}
+	}
+	return nil
+}
+
+// textUnmarshaler returns the encoding.TextUnmarshaler of the address of fv.
+//
+// NOTE: a type assertion instead of reflect.Type.Implements, which older TinyGo
+// releases do not implement for all types
+func textUnmarshaler(fv reflect.Value) (encoding.TextUnmarshaler, bool) {
+	if !fv.CanAddr() {
+		return nil, false
+	}
+	u, ok := fv.Addr().Interface().(encoding.TextUnmarshaler)
+	return u, ok
+}
+
+// Value parses value into fv.
+//
+// Supported types are strings, booleans, integers, floats, time.Duration,
+// comma separated slices of those, and encoding.TextUnmarshaler implementations.
+func Value(fv reflect.Value, value string) error {
+	if u, ok := textUnmarshaler(fv); ok {
+		return u.UnmarshalText([]byte(value))
+	}
+
+	if fv.Type() == durationType {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		fv.SetInt(int64(d))
+		return nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 0, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 0, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetFloat(f)
+	case reflect.Slice:
+		var parts []string
+		if value != "" {
+			parts = strings.Split(value, ",")
+		}
+		slice := reflect.MakeSlice(fv.Type(), len(parts), len(parts))
+		for i, part := range parts {
+			if err := Value(slice.Index(i), strings.TrimSpace(part)); err != nil {
+				return err
+			}
+		}
+		fv.Set(slice)
+	default:
+		return fmt.Errorf("unsupported type %s", fv.Type())
+	}
+	return nil
+}
@@ -0,0 +1,445 @@
+//go:build !wasm
+
+package fakehost
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"unsafe"
+
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"go.wasmcloud.dev/component/gen/wasi/blobstore/container"
+	"go.wasmcloud.dev/component/gen/wasi/blobstore/types"
+	"go.wasmcloud.dev/component/gen/wasi/io/streams"
+	"go.wasmcloud.dev/component/internal/backend"
+)
+
+// Blobstore serves the containers of `wasi:blobstore`, e.g. installed by
+// componenttest. No container exists while it is nil.
+var Blobstore backend.Blobstore
+
+// Containers are opened by name ahead of Blobstore, e.g. registered by
+// wasiblobtest.
+var Containers Registry[backend.Container]
+
+// blobContainer is a `wasi:blobstore/container` resource.
+type blobContainer struct {
+	name string
+	backend.Container
+}
+
+func (*blobContainer) ResourceName() string { return "container" }
+
+// objectNames is a `wasi:blobstore/container.stream-object-names` resource.
+type objectNames struct {
+	backend.ObjectNames
+}
+
+func (*objectNames) ResourceName() string { return "stream-object-names" }
+
+// outgoingValue is a `wasi:blobstore/types.outgoing-value` resource, the
+// object is written once it is finished.
+type outgoingValue struct {
+	buf       bytes.Buffer
+	stream    bool
+	container backend.Container
+	name      string
+}
+
+func (*outgoingValue) ResourceName() string { return "outgoing-value" }
+
+// incomingValue is a `wasi:blobstore/types.incoming-value` resource.
+type incomingValue struct {
+	data []byte
+}
+
+func (*incomingValue) ResourceName() string { return "incoming-value" }
+
+// openContainer opens the container registered under name, or through
+// Blobstore.
+func openContainer(name string) (backend.Container, error) {
+	if c, ok := Containers.Get(name); ok {
+		return c, nil
+	}
+	if Blobstore == nil {
+		return nil, fmt.Errorf("container %q does not exist", name)
+	}
+	return Blobstore.GetContainer(name)
+}
+
+// containerOf returns the container resource of handle.
+func containerOf(handle uint32) *blobContainer {
+	host.Lock()
+	defer host.Unlock()
+	return Resource[*blobContainer](handle)
+}
+
+// blobResult stores the result of a `wasi:blobstore` function.
+func blobResult[Shape, T any](result unsafe.Pointer, v T, err error) {
+	res := (*cm.Result[Shape, T, types.Error])(result)
+	if err != nil {
+		*res = cm.Err[cm.Result[Shape, T, types.Error]](types.Error(err.Error()))
+		return
+	}
+	*res = cm.OK[cm.Result[Shape, T, types.Error]](v)
+}
+
+// addContainer stores the container resource of c, or the error of opening
+// it, in result.
+func addContainer(result unsafe.Pointer, name string, c backend.Container, err error) {
+	if err != nil {
+		blobResult[string, container.Container](result, 0, err)
+		return
+	}
+
+	host.Lock()
+	defer host.Unlock()
+	blobResult[string](result, container.Container(Add(&blobContainer{name: name, Container: c})), nil)
+}
+
+// relayObject copies src to dst through the component-facing interface of the
+// containers, as the containers registered by name are unknown to Blobstore.
+func relayObject(srcContainer, srcObject, dstContainer, dstObject string, move bool) error {
+	src, err := openContainer(srcContainer)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := openContainer(dstContainer)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	info, err := src.ObjectInfo(srcObject)
+	if err != nil {
+		return err
+	}
+	w, err := dst.WriteData(dstObject)
+	if err != nil {
+		return err
+	}
+	if info.Size > 0 {
+		r, err := src.GetData(srcObject, 0, info.Size-1)
+		if err != nil {
+			return errors.Join(err, w.Close())
+		}
+		_, err = io.Copy(w, r)
+		if err := errors.Join(err, r.Close()); err != nil {
+			return errors.Join(err, w.Close())
+		}
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	if move && (srcContainer != dstContainer || srcObject != dstObject) {
+		return src.DeleteObject(srcObject)
+	}
+	return nil
+}
+
+// transferObject copies or moves an object, within Blobstore or through the
+// component-facing interface if a registered container is involved.
+func transferObject(src0 unsafe.Pointer, src1 uint32, src2 unsafe.Pointer, src3 uint32, dest0 unsafe.Pointer, dest1 uint32, dest2 unsafe.Pointer, dest3 uint32, move bool) error {
+	srcContainer, srcObject := str(src0, src1), str(src2, src3)
+	dstContainer, dstObject := str(dest0, dest1), str(dest2, dest3)
+	_, srcOK := Containers.Get(srcContainer)
+	_, dstOK := Containers.Get(dstContainer)
+	switch {
+	case srcOK || dstOK || Blobstore == nil:
+		return relayObject(srcContainer, srcObject, dstContainer, dstObject, move)
+	case move:
+		return Blobstore.MoveObject(srcContainer, srcObject, dstContainer, dstObject)
+	default:
+		return Blobstore.CopyObject(srcContainer, srcObject, dstContainer, dstObject)
+	}
+}
+
+// stub wasi:blobstore/blobstore
+//
+//go:linkname wasmimport_CreateContainer go.wasmcloud.dev/component/gen/wasi/blobstore/blobstore.wasmimport_CreateContainer
+func wasmimport_CreateContainer(name0 unsafe.Pointer, name1 uint32, result unsafe.Pointer) {
+	name := str(name0, name1)
+	if _, ok := Containers.Get(name); ok {
+		addContainer(result, name, nil, fmt.Errorf("container %q already exists", name))
+		return
+	}
+	if Blobstore == nil {
+		addContainer(result, name, nil, errors.New("containers cannot be created"))
+		return
+	}
+	c, err := Blobstore.CreateContainer(name)
+	addContainer(result, name, c, err)
+}
+
+//go:linkname wasmimport_GetContainer go.wasmcloud.dev/component/gen/wasi/blobstore/blobstore.wasmimport_GetContainer
+func wasmimport_GetContainer(name0 unsafe.Pointer, name1 uint32, result unsafe.Pointer) {
+	name := str(name0, name1)
+	c, err := openContainer(name)
+	addContainer(result, name, c, err)
+}
+
+//go:linkname wasmimport_DeleteContainer go.wasmcloud.dev/component/gen/wasi/blobstore/blobstore.wasmimport_DeleteContainer
+func wasmimport_DeleteContainer(name0 unsafe.Pointer, name1 uint32, result unsafe.Pointer) {
+	name := str(name0, name1)
+	var err error
+	if _, ok := Containers.Get(name); ok {
+		Containers.Remove(name)
+	} else if Blobstore == nil {
+		err = fmt.Errorf("container %q does not exist", name)
+	} else {
+		err = Blobstore.DeleteContainer(name)
+	}
+	blobResult[types.Error](result, struct{}{}, err)
+}
+
+//go:linkname wasmimport_ContainerExists go.wasmcloud.dev/component/gen/wasi/blobstore/blobstore.wasmimport_ContainerExists
+func wasmimport_ContainerExists(name0 unsafe.Pointer, name1 uint32, result unsafe.Pointer) {
+	name := str(name0, name1)
+	if _, ok := Containers.Get(name); ok || Blobstore == nil {
+		blobResult[string](result, ok, nil)
+		return
+	}
+	ok, err := Blobstore.ContainerExists(name)
+	blobResult[string](result, ok, err)
+}
+
+//go:linkname wasmimport_CopyObject go.wasmcloud.dev/component/gen/wasi/blobstore/blobstore.wasmimport_CopyObject
+func wasmimport_CopyObject(src0 unsafe.Pointer, src1 uint32, src2 unsafe.Pointer, src3 uint32, dest0 unsafe.Pointer, dest1 uint32, dest2 unsafe.Pointer, dest3 uint32, result unsafe.Pointer) {
+	err := transferObject(src0, src1, src2, src3, dest0, dest1, dest2, dest3, false)
+	blobResult[types.Error](result, struct{}{}, err)
+}
+
+//go:linkname wasmimport_MoveObject go.wasmcloud.dev/component/gen/wasi/blobstore/blobstore.wasmimport_MoveObject
+func wasmimport_MoveObject(src0 unsafe.Pointer, src1 uint32, src2 unsafe.Pointer, src3 uint32, dest0 unsafe.Pointer, dest1 uint32, dest2 unsafe.Pointer, dest3 uint32, result unsafe.Pointer) {
+	err := transferObject(src0, src1, src2, src3, dest0, dest1, dest2, dest3, true)
+	blobResult[types.Error](result, struct{}{}, err)
+}
+
+// stub wasi:blobstore/container
+//
+//go:linkname wasmimport_ContainerResourceDrop go.wasmcloud.dev/component/gen/wasi/blobstore/container.wasmimport_ContainerResourceDrop
+func wasmimport_ContainerResourceDrop(self0 uint32) {
+	containerOf(self0).Close()
+	Drop(self0)
+}
+
+//go:linkname wasmimport_ContainerName go.wasmcloud.dev/component/gen/wasi/blobstore/container.wasmimport_ContainerName
+func wasmimport_ContainerName(self0 uint32, result unsafe.Pointer) {
+	blobResult[string](result, containerOf(self0).name, nil)
+}
+
+//go:linkname wasmimport_ContainerInfo go.wasmcloud.dev/component/gen/wasi/blobstore/container.wasmimport_ContainerInfo
+func wasmimport_ContainerInfo(self0 uint32, result unsafe.Pointer) {
+	c := containerOf(self0)
+	createdAt, err := c.CreatedAt()
+	blobResult[container.ContainerMetadataShape](result, types.ContainerMetadata{
+		Name:      types.ContainerName(c.name),
+		CreatedAt: types.Timestamp(createdAt),
+	}, err)
+}
+
+//go:linkname wasmimport_ContainerObjectInfo go.wasmcloud.dev/component/gen/wasi/blobstore/container.wasmimport_ContainerObjectInfo
+func wasmimport_ContainerObjectInfo(self0 uint32, name0 unsafe.Pointer, name1 uint32, result unsafe.Pointer) {
+	info, err := containerOf(self0).ObjectInfo(str(name0, name1))
+	blobResult[container.ObjectMetadataShape](result, types.ObjectMetadata{
+		Name:      types.ObjectName(info.Name),
+		Container: types.ContainerName(info.Container),
+		CreatedAt: types.Timestamp(info.CreatedAt),
+		Size:      types.ObjectSize(info.Size),
+	}, err)
+}
+
+//go:linkname wasmimport_ContainerHasObject go.wasmcloud.dev/component/gen/wasi/blobstore/container.wasmimport_ContainerHasObject
+func wasmimport_ContainerHasObject(self0 uint32, name0 unsafe.Pointer, name1 uint32, result unsafe.Pointer) {
+	ok, err := containerOf(self0).HasObject(str(name0, name1))
+	blobResult[string](result, ok, err)
+}
+
+//go:linkname wasmimport_ContainerDeleteObject go.wasmcloud.dev/component/gen/wasi/blobstore/container.wasmimport_ContainerDeleteObject
+func wasmimport_ContainerDeleteObject(self0 uint32, name0 unsafe.Pointer, name1 uint32, result unsafe.Pointer) {
+	err := containerOf(self0).DeleteObject(str(name0, name1))
+	blobResult[types.Error](result, struct{}{}, err)
+}
+
+//go:linkname wasmimport_ContainerDeleteObjects go.wasmcloud.dev/component/gen/wasi/blobstore/container.wasmimport_ContainerDeleteObjects
+func wasmimport_ContainerDeleteObjects(self0 uint32, names0 unsafe.Pointer, names1 uint32, result unsafe.Pointer) {
+	var names []string
+	for _, name := range unsafe.Slice((*types.ObjectName)(names0), names1) {
+		names = append(names, string(name))
+	}
+	err := containerOf(self0).DeleteObjects(names)
+	blobResult[types.Error](result, struct{}{}, err)
+}
+
+//go:linkname wasmimport_ContainerClear go.wasmcloud.dev/component/gen/wasi/blobstore/container.wasmimport_ContainerClear
+func wasmimport_ContainerClear(self0 uint32, result unsafe.Pointer) {
+	blobResult[types.Error](result, struct{}{}, containerOf(self0).Clear())
+}
+
+// stub wasi:blobstore/container, the data is read at once and served from
+// the incoming value
+//
+//go:linkname wasmimport_ContainerGetData go.wasmcloud.dev/component/gen/wasi/blobstore/container.wasmimport_ContainerGetData
+func wasmimport_ContainerGetData(self0 uint32, name0 unsafe.Pointer, name1 uint32, start0 uint64, end0 uint64, result unsafe.Pointer) {
+	r, err := containerOf(self0).GetData(str(name0, name1), start0, end0)
+	if err != nil {
+		blobResult[string, types.IncomingValue](result, 0, err)
+		return
+	}
+	data, err := io.ReadAll(r)
+	if err = errors.Join(err, r.Close()); err != nil {
+		blobResult[string, types.IncomingValue](result, 0, err)
+		return
+	}
+
+	host.Lock()
+	defer host.Unlock()
+	blobResult[string](result, types.IncomingValue(Add(&incomingValue{data: data})), nil)
+}
+
+// stub wasi:blobstore/container, the object is written once the value is
+// finished
+//
+//go:linkname wasmimport_ContainerWriteData go.wasmcloud.dev/component/gen/wasi/blobstore/container.wasmimport_ContainerWriteData
+func wasmimport_ContainerWriteData(self0 uint32, name0 unsafe.Pointer, name1 uint32, data0 uint32, result unsafe.Pointer) {
+	host.Lock()
+	defer host.Unlock()
+
+	value := Resource[*outgoingValue](data0)
+	value.container = Resource[*blobContainer](self0).Container
+	value.name = str(name0, name1)
+	blobResult[types.Error](result, struct{}{}, nil)
+}
+
+//go:linkname wasmimport_ContainerListObjects go.wasmcloud.dev/component/gen/wasi/blobstore/container.wasmimport_ContainerListObjects
+func wasmimport_ContainerListObjects(self0 uint32, result unsafe.Pointer) {
+	names, err := containerOf(self0).ListObjects()
+	if err != nil {
+		blobResult[string, container.StreamObjectNames](result, 0, err)
+		return
+	}
+
+	host.Lock()
+	defer host.Unlock()
+	blobResult[string](result, container.StreamObjectNames(Add(&objectNames{names})), nil)
+}
+
+// stub wasi:blobstore/container, reading at most ChunkSize names at once
+//
+//go:linkname wasmimport_StreamObjectNamesReadStreamObjectNames go.wasmcloud.dev/component/gen/wasi/blobstore/container.wasmimport_StreamObjectNamesReadStreamObjectNames
+func wasmimport_StreamObjectNamesReadStreamObjectNames(self0 uint32, len0 uint64, result unsafe.Pointer) {
+	host.Lock()
+	listing := Resource[*objectNames](self0)
+	host.Unlock()
+
+	names, done, err := listing.Read(min(len0, uint64(ChunkSize)))
+	page := make([]types.ObjectName, len(names))
+	for i, name := range names {
+		page[i] = types.ObjectName(name)
+	}
+	blobResult[container.TupleListObjectNameBoolShape](result, cm.Tuple[cm.List[types.ObjectName], bool]{F0: cm.ToList(page), F1: done}, err)
+}
+
+//go:linkname wasmimport_StreamObjectNamesSkipStreamObjectNames go.wasmcloud.dev/component/gen/wasi/blobstore/container.wasmimport_StreamObjectNamesSkipStreamObjectNames
+func wasmimport_StreamObjectNamesSkipStreamObjectNames(self0 uint32, num0 uint64, result unsafe.Pointer) {
+	host.Lock()
+	listing := Resource[*objectNames](self0)
+	host.Unlock()
+
+	names, done, err := listing.Read(num0)
+	blobResult[container.TupleU64BoolShape](result, cm.Tuple[uint64, bool]{F0: uint64(len(names)), F1: done}, err)
+}
+
+//go:linkname wasmimport_StreamObjectNamesResourceDrop go.wasmcloud.dev/component/gen/wasi/blobstore/container.wasmimport_StreamObjectNamesResourceDrop
+func wasmimport_StreamObjectNamesResourceDrop(self0 uint32) {
+	host.Lock()
+	listing := Resource[*objectNames](self0)
+	delete(host.resources, self0)
+	host.Unlock()
+
+	listing.Close()
+}
+
+// stub wasi:blobstore/types
+//
+//go:linkname wasmimport_OutgoingValueNewOutgoingValue go.wasmcloud.dev/component/gen/wasi/blobstore/types.wasmimport_OutgoingValueNewOutgoingValue
+func wasmimport_OutgoingValueNewOutgoingValue() (result0 uint32) {
+	host.Lock()
+	defer host.Unlock()
+	return Add(&outgoingValue{})
+}
+
+//go:linkname wasmimport_OutgoingValueOutgoingValueWriteBody go.wasmcloud.dev/component/gen/wasi/blobstore/types.wasmimport_OutgoingValueOutgoingValueWriteBody
+func wasmimport_OutgoingValueOutgoingValueWriteBody(self0 uint32, result unsafe.Pointer) {
+	host.Lock()
+	defer host.Unlock()
+
+	res := (*cm.Result[streams.OutputStream, streams.OutputStream, struct{}])(result)
+	value := Resource[*outgoingValue](self0)
+	if value.stream {
+		*res = cm.Err[cm.Result[streams.OutputStream, streams.OutputStream, struct{}]](struct{}{})
+		return
+	}
+	value.stream = true
+	*res = cm.OK[cm.Result[streams.OutputStream, streams.OutputStream, struct{}]](streams.OutputStream(Add(&OutputStream{Buffer: &value.buf})))
+}
+
+//go:linkname wasmimport_OutgoingValueFinish go.wasmcloud.dev/component/gen/wasi/blobstore/types.wasmimport_OutgoingValueFinish
+func wasmimport_OutgoingValueFinish(this0 uint32, result unsafe.Pointer) {
+	host.Lock()
+	value := Resource[*outgoingValue](this0)
+	delete(host.resources, this0)
+	host.Unlock()
+
+	if value.container == nil {
+		blobResult[types.Error](result, struct{}{}, errors.New("value is not written to an object"))
+		return
+	}
+	w, err := value.container.WriteData(value.name)
+	if err == nil {
+		_, err = w.Write(value.buf.Bytes())
+		err = errors.Join(err, w.Close())
+	}
+	blobResult[types.Error](result, struct{}{}, err)
+}
+
+//go:linkname wasmimport_OutgoingValueResourceDrop go.wasmcloud.dev/component/gen/wasi/blobstore/types.wasmimport_OutgoingValueResourceDrop
+func wasmimport_OutgoingValueResourceDrop(self0 uint32) {
+	Drop(self0)
+}
+
+//go:linkname wasmimport_IncomingValueIncomingValueConsumeAsync go.wasmcloud.dev/component/gen/wasi/blobstore/types.wasmimport_IncomingValueIncomingValueConsumeAsync
+func wasmimport_IncomingValueIncomingValueConsumeAsync(this0 uint32, result unsafe.Pointer) {
+	host.Lock()
+	defer host.Unlock()
+
+	value := Resource[*incomingValue](this0)
+	delete(host.resources, this0)
+	blobResult[string](result, streams.InputStream(Add(&InputStream{Data: value.data})), nil)
+}
+
+//go:linkname wasmimport_IncomingValueIncomingValueConsumeSync go.wasmcloud.dev/component/gen/wasi/blobstore/types.wasmimport_IncomingValueIncomingValueConsumeSync
+func wasmimport_IncomingValueIncomingValueConsumeSync(this0 uint32, result unsafe.Pointer) {
+	host.Lock()
+	defer host.Unlock()
+
+	value := Resource[*incomingValue](this0)
+	delete(host.resources, this0)
+	blobResult[types.IncomingValueSyncBody](result, types.IncomingValueSyncBody(cm.ToList(value.data)), nil)
+}
+
+//go:linkname wasmimport_IncomingValueSize go.wasmcloud.dev/component/gen/wasi/blobstore/types.wasmimport_IncomingValueSize
+func wasmimport_IncomingValueSize(self0 uint32) (result0 uint64) {
+	host.Lock()
+	defer host.Unlock()
+	return uint64(len(Resource[*incomingValue](self0).data))
+}
+
+//go:linkname wasmimport_IncomingValueResourceDrop go.wasmcloud.dev/component/gen/wasi/blobstore/types.wasmimport_IncomingValueResourceDrop
+func wasmimport_IncomingValueResourceDrop(self0 uint32) {
+	Drop(self0)
+}
@@ -0,0 +1,59 @@
+//go:build !wasm
+
+package fakehost
+
+import (
+	"time"
+	"unsafe"
+
+	wallclock "go.wasmcloud.dev/component/gen/wasi/clocks/wall-clock"
+)
+
+// epoch is the origin of the monotonic clock.
+var epoch = time.Now()
+
+// timer returns a pollable ready once the monotonic clock reaches when.
+func timer(when time.Time) uint32 {
+	host.Lock()
+	defer host.Unlock()
+	return Add(&Pollable{Ready: func() bool {
+		return !time.Now().Before(when)
+	}})
+}
+
+// stub wasi:clocks/monotonic-clock, now is the time since the test binary
+// started
+//
+//go:linkname wasmimport_MonotonicNow go.wasmcloud.dev/component/gen/wasi/clocks/monotonic-clock.wasmimport_Now
+func wasmimport_MonotonicNow() (result0 uint64) {
+	return uint64(time.Since(epoch))
+}
+
+//go:linkname wasmimport_MonotonicResolution go.wasmcloud.dev/component/gen/wasi/clocks/monotonic-clock.wasmimport_Resolution
+func wasmimport_MonotonicResolution() (result0 uint64) {
+	return 1
+}
+
+//go:linkname wasmimport_SubscribeInstant go.wasmcloud.dev/component/gen/wasi/clocks/monotonic-clock.wasmimport_SubscribeInstant
+func wasmimport_SubscribeInstant(when0 uint64) (result0 uint32) {
+	return timer(epoch.Add(time.Duration(when0)))
+}
+
+//go:linkname wasmimport_SubscribeDuration go.wasmcloud.dev/component/gen/wasi/clocks/monotonic-clock.wasmimport_SubscribeDuration
+func wasmimport_SubscribeDuration(when0 uint64) (result0 uint32) {
+	return timer(time.Now().Add(time.Duration(when0)))
+}
+
+// stub wasi:clocks/wall-clock, now is the system time. Tests replace
+// wasiclock.NowFunc to control the time.
+//
+//go:linkname wasmimport_WallClockNow go.wasmcloud.dev/component/gen/wasi/clocks/wall-clock.wasmimport_Now
+func wasmimport_WallClockNow(result unsafe.Pointer) {
+	now := time.Now()
+	*(*wallclock.DateTime)(result) = wallclock.DateTime{Seconds: uint64(now.Unix()), Nanoseconds: uint32(now.Nanosecond())}
+}
+
+//go:linkname wasmimport_WallClockResolution go.wasmcloud.dev/component/gen/wasi/clocks/wall-clock.wasmimport_Resolution
+func wasmimport_WallClockResolution(result unsafe.Pointer) {
+	*(*wallclock.DateTime)(result) = wallclock.DateTime{Nanoseconds: 1}
+}
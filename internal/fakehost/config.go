@@ -0,0 +1,59 @@
+//go:build !wasm
+
+package fakehost
+
+import (
+	"slices"
+	"strings"
+	"unsafe"
+
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"go.wasmcloud.dev/component/gen/wasi/config/runtime"
+)
+
+// Config is the runtime config served by `wasi:config/runtime`. Tests set it
+// before the config is read.
+var Config struct {
+	Values map[string]string
+	// Errors fail the get of their key with an upstream error.
+	Errors map[string]string
+	// Down fails get-all with an IO error.
+	Down bool
+}
+
+// stub wasi:config/runtime, serving Config
+//
+//go:linkname wasmimport_ConfigGet go.wasmcloud.dev/component/gen/wasi/config/runtime.wasmimport_Get
+func wasmimport_ConfigGet(key0 unsafe.Pointer, key1 uint32, result unsafe.Pointer) {
+	res := (*cm.Result[runtime.OptionStringShape, cm.Option[string], runtime.ConfigError])(result)
+	key := str(key0, key1)
+	if msg, ok := Config.Errors[key]; ok {
+		*res = cm.Err[cm.Result[runtime.OptionStringShape, cm.Option[string], runtime.ConfigError]](runtime.ConfigErrorUpstream(msg))
+		return
+	}
+
+	value, ok := Config.Values[key]
+	if !ok {
+		*res = cm.OK[cm.Result[runtime.OptionStringShape, cm.Option[string], runtime.ConfigError]](cm.None[string]())
+		return
+	}
+	*res = cm.OK[cm.Result[runtime.OptionStringShape, cm.Option[string], runtime.ConfigError]](cm.Some(value))
+}
+
+//go:linkname wasmimport_ConfigGetAll go.wasmcloud.dev/component/gen/wasi/config/runtime.wasmimport_GetAll
+func wasmimport_ConfigGetAll(result unsafe.Pointer) {
+	res := (*cm.Result[runtime.ConfigErrorShape, cm.List[[2]string], runtime.ConfigError])(result)
+	if Config.Down {
+		*res = cm.Err[cm.Result[runtime.ConfigErrorShape, cm.List[[2]string], runtime.ConfigError]](runtime.ConfigErrorIO("connection refused"))
+		return
+	}
+
+	var pairs [][2]string
+	for key, value := range Config.Values {
+		pairs = append(pairs, [2]string{key, value})
+	}
+	slices.SortFunc(pairs, func(a, b [2]string) int {
+		return strings.Compare(a[0], b[0])
+	})
+	*res = cm.OK[cm.Result[runtime.ConfigErrorShape, cm.List[[2]string], runtime.ConfigError]](cm.ToList(pairs))
+}
@@ -0,0 +1,154 @@
+//go:build !wasm
+
+// Package fakehost serves the host imports of the SDK to test binaries built
+// outside of `wasm`. The imports are bound to the stubs of this package with
+// go:linkname, the stubs serve the state set by the test support packages,
+// e.g. componenttest, wasikvtest and wasihttptest, and by the tests of the SDK
+// packages.
+//
+// Clocks and random are served from the test binary. Imports not used by the
+// SDK packages panic.
+//
+// NOTE: fakehost must only be imported by test support packages and tests,
+// the stubs would replace the host imports of a `wasm` build otherwise.
+package fakehost
+
+import (
+	"slices"
+	"strconv"
+	"sync"
+)
+
+// host keeps the resources of the fake host by handle.
+var host = struct {
+	sync.Mutex
+	next      uint32
+	resources map[uint32]any
+}{resources: map[uint32]any{}}
+
+// Lock locks the resources, the functions of the package called with the
+// resources held are documented as such.
+func Lock() {
+	host.Lock()
+}
+
+// Unlock unlocks the resources.
+func Unlock() {
+	host.Unlock()
+}
+
+// Add stores a resource and returns its handle, it must be called with the
+// resources held.
+func Add(v any) uint32 {
+	host.next++
+	host.resources[host.next] = v
+	return host.next
+}
+
+// Resource returns the resource of handle, or the zero value if it is not of
+// type T. It must be called with the resources held.
+func Resource[T any](handle uint32) T {
+	v, _ := host.resources[handle].(T)
+	return v
+}
+
+// Alive reports whether handle was not dropped or consumed, it must be called
+// with the resources held.
+func Alive(handle uint32) bool {
+	_, ok := host.resources[handle]
+	return ok
+}
+
+// Delete consumes the resource of handle, it must be called with the
+// resources held.
+func Delete(handle uint32) {
+	delete(host.resources, handle)
+}
+
+// Drop drops the resource of handle.
+func Drop(handle uint32) {
+	host.Lock()
+	defer host.Unlock()
+	delete(host.resources, handle)
+}
+
+// Last returns the handle of the last resource created.
+func Last() uint32 {
+	host.Lock()
+	defer host.Unlock()
+	return host.next
+}
+
+// Live returns the resources created after the handle since which were not
+// dropped, e.g. "input-stream 12", by handle. Resources are named by their
+// ResourceName method.
+func Live(since uint32) []string {
+	host.Lock()
+	defer host.Unlock()
+
+	var handles []uint32
+	for handle := range host.resources {
+		if handle > since {
+			handles = append(handles, handle)
+		}
+	}
+	slices.Sort(handles)
+
+	names := make([]string, len(handles))
+	for i, handle := range handles {
+		name := "resource"
+		if r, ok := host.resources[handle].(interface{ ResourceName() string }); ok {
+			name = r.ResourceName()
+		}
+		names[i] = name + " " + strconv.FormatUint(uint64(handle), 10)
+	}
+	return names
+}
+
+// unsupported returns the panic value of the imports not served.
+func unsupported(name string) string {
+	return "fakehost: " + name + " is not served outside of a wasm build"
+}
+
+// Registry holds values by generated names, it is safe for concurrent use.
+type Registry[T any] struct {
+	mu     sync.Mutex
+	next   int
+	values map[string]T
+}
+
+// Name returns a new name starting with prefix.
+func (r *Registry[T]) Name(prefix string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.next++
+	return prefix + strconv.Itoa(r.next)
+}
+
+// Set registers v under name.
+func (r *Registry[T]) Set(name string, v T) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.values == nil {
+		r.values = map[string]T{}
+	}
+	r.values[name] = v
+}
+
+// Get returns the value registered under name.
+func (r *Registry[T]) Get(name string) (T, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	v, ok := r.values[name]
+	return v, ok
+}
+
+// Remove unregisters name.
+func (r *Registry[T]) Remove(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.values, name)
+}
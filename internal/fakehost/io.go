@@ -0,0 +1,294 @@
+//go:build !wasm
+
+package fakehost
+
+import (
+	"bytes"
+	"slices"
+	"time"
+	"unsafe"
+
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"go.wasmcloud.dev/component/gen/wasi/io/poll"
+	"go.wasmcloud.dev/component/gen/wasi/io/streams"
+)
+
+// ChunkSize is the check-write budget of the output streams, and the most
+// bytes read or spliced at once. Writes beyond the budget trap, like on the
+// host. Tests lower it to exercise the streaming loops with small data.
+var ChunkSize = 64 << 10
+
+// InputStream is a `wasi:io/streams` input stream reading Data, it is closed
+// once drained unless Open is set.
+type InputStream struct {
+	Data []byte
+	// Open keeps the stream open once drained, reads return no data until
+	// more is appended or Open is cleared.
+	Open bool
+}
+
+func (*InputStream) ResourceName() string { return "input-stream" }
+
+// OutputStream is a `wasi:io/streams` output stream writing to Buffer.
+type OutputStream struct {
+	Buffer *bytes.Buffer
+}
+
+func (*OutputStream) ResourceName() string { return "output-stream" }
+
+// Pollable is a `wasi:io/poll` pollable.
+type Pollable struct {
+	// Ready reports whether the pollable is ready, it is ready at once if
+	// nil. It is called with the resources held, each time the pollable is
+	// polled.
+	Ready func() bool
+}
+
+func (*Pollable) ResourceName() string { return "pollable" }
+
+func (p *Pollable) ready() bool {
+	return p == nil || p.Ready == nil || p.Ready()
+}
+
+// pollInterval is the delay between two checks of pending pollables.
+const pollInterval = time.Millisecond
+
+// stub wasi:io/poll
+//
+//go:linkname wasmimport_PollableBlock go.wasmcloud.dev/component/gen/wasi/io/poll.wasmimport_PollableBlock
+func wasmimport_PollableBlock(self0 uint32) {
+	for {
+		host.Lock()
+		ready := Resource[*Pollable](self0).ready()
+		host.Unlock()
+		if ready {
+			return
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+//go:linkname wasmimport_PollableReady go.wasmcloud.dev/component/gen/wasi/io/poll.wasmimport_PollableReady
+func wasmimport_PollableReady(self0 uint32) (result0 uint32) {
+	host.Lock()
+	defer host.Unlock()
+
+	if Resource[*Pollable](self0).ready() {
+		return 1
+	}
+	return 0
+}
+
+// stub wasi:io/poll, returning once a pollable is ready or after
+// pollInterval, to let the Poller observe cancellation like a component
+// woken up by the host
+//
+//go:linkname wasmimport_Poll go.wasmcloud.dev/component/gen/wasi/io/poll.wasmimport_Poll
+func wasmimport_Poll(in0 unsafe.Pointer, in1 uint32, result unsafe.Pointer) {
+	in := unsafe.Slice((*poll.Pollable)(in0), in1)
+	for wait := true; ; wait = false {
+		var ready []uint32
+		host.Lock()
+		for i, handle := range in {
+			if Resource[*Pollable](uint32(handle)).ready() {
+				ready = append(ready, uint32(i))
+			}
+		}
+		host.Unlock()
+		if len(ready) > 0 || !wait {
+			*(*cm.List[uint32])(result) = cm.ToList(ready)
+			return
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+//go:linkname wasmimport_PollableResourceDrop go.wasmcloud.dev/component/gen/wasi/io/poll.wasmimport_PollableResourceDrop
+func wasmimport_PollableResourceDrop(self0 uint32) {
+	Drop(self0)
+}
+
+// stub wasi:io/streams, input streams are readable while they hold data or
+// are closed
+//
+//go:linkname wasmimport_InputStreamRead go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_InputStreamRead
+func wasmimport_InputStreamRead(self0 uint32, len0 uint64, result unsafe.Pointer) {
+	host.Lock()
+	defer host.Unlock()
+
+	res := (*cm.Result[cm.List[uint8], cm.List[uint8], streams.StreamError])(result)
+	stream := Resource[*InputStream](self0)
+	if len(stream.Data) == 0 {
+		*res = cm.Err[cm.Result[cm.List[uint8], cm.List[uint8], streams.StreamError]](streams.StreamErrorClosed())
+		if stream.Open {
+			*res = cm.OK[cm.Result[cm.List[uint8], cm.List[uint8], streams.StreamError]](cm.List[uint8]{})
+		}
+		return
+	}
+	n := min(len0, uint64(len(stream.Data)), uint64(ChunkSize))
+	chunk := slices.Clone(stream.Data[:n])
+	stream.Data = stream.Data[n:]
+	*res = cm.OK[cm.Result[cm.List[uint8], cm.List[uint8], streams.StreamError]](cm.ToList(chunk))
+}
+
+//go:linkname wasmimport_InputStreamBlockingRead go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_InputStreamBlockingRead
+func wasmimport_InputStreamBlockingRead(self0 uint32, len0 uint64, result unsafe.Pointer) {
+	wasmimport_InputStreamRead(self0, len0, result)
+}
+
+//go:linkname wasmimport_InputStreamSkip go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_InputStreamSkip
+func wasmimport_InputStreamSkip(self0 uint32, len0 uint64, result unsafe.Pointer) {
+	host.Lock()
+	defer host.Unlock()
+
+	res := (*cm.Result[uint64, uint64, streams.StreamError])(result)
+	stream := Resource[*InputStream](self0)
+	if len(stream.Data) == 0 {
+		*res = cm.Err[cm.Result[uint64, uint64, streams.StreamError]](streams.StreamErrorClosed())
+		if stream.Open {
+			*res = cm.OK[cm.Result[uint64, uint64, streams.StreamError]](0)
+		}
+		return
+	}
+	n := min(len0, uint64(len(stream.Data)))
+	stream.Data = stream.Data[n:]
+	*res = cm.OK[cm.Result[uint64, uint64, streams.StreamError]](n)
+}
+
+//go:linkname wasmimport_InputStreamBlockingSkip go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_InputStreamBlockingSkip
+func wasmimport_InputStreamBlockingSkip(self0 uint32, len0 uint64, result unsafe.Pointer) {
+	wasmimport_InputStreamSkip(self0, len0, result)
+}
+
+//go:linkname wasmimport_InputStreamSubscribe go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_InputStreamSubscribe
+func wasmimport_InputStreamSubscribe(self0 uint32) (result0 uint32) {
+	host.Lock()
+	defer host.Unlock()
+
+	stream := Resource[*InputStream](self0)
+	return Add(&Pollable{Ready: func() bool {
+		return len(stream.Data) > 0 || !stream.Open
+	}})
+}
+
+//go:linkname wasmimport_InputStreamResourceDrop go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_InputStreamResourceDrop
+func wasmimport_InputStreamResourceDrop(self0 uint32) {
+	Drop(self0)
+}
+
+// stub wasi:io/streams, output streams are always writable, writes beyond
+// the check-write budget trap
+//
+//go:linkname wasmimport_OutputStreamCheckWrite go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_OutputStreamCheckWrite
+func wasmimport_OutputStreamCheckWrite(self0 uint32, result unsafe.Pointer) {
+	*(*cm.Result[uint64, uint64, streams.StreamError])(result) = cm.OK[cm.Result[uint64, uint64, streams.StreamError]](uint64(ChunkSize))
+}
+
+//go:linkname wasmimport_OutputStreamWrite go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_OutputStreamWrite
+func wasmimport_OutputStreamWrite(self0 uint32, contents0 unsafe.Pointer, contents1 uint32, result unsafe.Pointer) {
+	if int(contents1) > ChunkSize {
+		panic("fakehost: write beyond the check-write budget")
+	}
+
+	host.Lock()
+	defer host.Unlock()
+
+	Resource[*OutputStream](self0).Buffer.Write(unsafe.Slice((*uint8)(contents0), contents1))
+	*(*cm.Result[streams.StreamError, struct{}, streams.StreamError])(result) = cm.OK[cm.Result[streams.StreamError, struct{}, streams.StreamError]](struct{}{})
+}
+
+// stub wasi:io/streams, like the host, blocking writes are limited to 4096
+// bytes
+//
+//go:linkname wasmimport_OutputStreamBlockingWriteAndFlush go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_OutputStreamBlockingWriteAndFlush
+func wasmimport_OutputStreamBlockingWriteAndFlush(self0 uint32, contents0 unsafe.Pointer, contents1 uint32, result unsafe.Pointer) {
+	if contents1 > 4096 {
+		panic("fakehost: blocking-write-and-flush of more than 4096 bytes")
+	}
+	wasmimport_OutputStreamWrite(self0, contents0, contents1, result)
+}
+
+//go:linkname wasmimport_OutputStreamWriteZeroes go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_OutputStreamWriteZeroes
+func wasmimport_OutputStreamWriteZeroes(self0 uint32, len0 uint64, result unsafe.Pointer) {
+	if len0 > uint64(ChunkSize) {
+		panic("fakehost: write beyond the check-write budget")
+	}
+
+	host.Lock()
+	defer host.Unlock()
+
+	Resource[*OutputStream](self0).Buffer.Write(make([]byte, len0))
+	*(*cm.Result[streams.StreamError, struct{}, streams.StreamError])(result) = cm.OK[cm.Result[streams.StreamError, struct{}, streams.StreamError]](struct{}{})
+}
+
+//go:linkname wasmimport_OutputStreamBlockingWriteZeroesAndFlush go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_OutputStreamBlockingWriteZeroesAndFlush
+func wasmimport_OutputStreamBlockingWriteZeroesAndFlush(self0 uint32, len0 uint64, result unsafe.Pointer) {
+	wasmimport_OutputStreamWriteZeroes(self0, len0, result)
+}
+
+//go:linkname wasmimport_OutputStreamFlush go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_OutputStreamFlush
+func wasmimport_OutputStreamFlush(self0 uint32, result unsafe.Pointer) {
+	*(*cm.Result[streams.StreamError, struct{}, streams.StreamError])(result) = cm.OK[cm.Result[streams.StreamError, struct{}, streams.StreamError]](struct{}{})
+}
+
+//go:linkname wasmimport_OutputStreamBlockingFlush go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_OutputStreamBlockingFlush
+func wasmimport_OutputStreamBlockingFlush(self0 uint32, result unsafe.Pointer) {
+	wasmimport_OutputStreamFlush(self0, result)
+}
+
+// Splices counts the splice calls, e.g. to check that data was spliced
+// through the host rather than copied through the component.
+var Splices int
+
+// stub wasi:io/streams, splicing moves the data of an input stream to an
+// output stream
+//
+//go:linkname wasmimport_OutputStreamSplice go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_OutputStreamSplice
+func wasmimport_OutputStreamSplice(self0 uint32, src0 uint32, len0 uint64, result unsafe.Pointer) {
+	host.Lock()
+	defer host.Unlock()
+
+	Splices++
+	res := (*cm.Result[uint64, uint64, streams.StreamError])(result)
+	src := Resource[*InputStream](src0)
+	if len(src.Data) == 0 {
+		*res = cm.Err[cm.Result[uint64, uint64, streams.StreamError]](streams.StreamErrorClosed())
+		if src.Open {
+			*res = cm.OK[cm.Result[uint64, uint64, streams.StreamError]](0)
+		}
+		return
+	}
+	n := min(len0, uint64(len(src.Data)), uint64(ChunkSize))
+	Resource[*OutputStream](self0).Buffer.Write(src.Data[:n])
+	src.Data = src.Data[n:]
+	*res = cm.OK[cm.Result[uint64, uint64, streams.StreamError]](n)
+}
+
+//go:linkname wasmimport_OutputStreamBlockingSplice go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_OutputStreamBlockingSplice
+func wasmimport_OutputStreamBlockingSplice(self0 uint32, src0 uint32, len0 uint64, result unsafe.Pointer) {
+	wasmimport_OutputStreamSplice(self0, src0, len0, result)
+}
+
+//go:linkname wasmimport_OutputStreamSubscribe go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_OutputStreamSubscribe
+func wasmimport_OutputStreamSubscribe(self0 uint32) (result0 uint32) {
+	host.Lock()
+	defer host.Unlock()
+	return Add(&Pollable{})
+}
+
+//go:linkname wasmimport_OutputStreamResourceDrop go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_OutputStreamResourceDrop
+func wasmimport_OutputStreamResourceDrop(self0 uint32) {
+	Drop(self0)
+}
+
+// stub wasi:io/error, the streams of the fake host never fail
+//
+//go:linkname wasmimport_ErrorToDebugString go.wasmcloud.dev/component/gen/wasi/io/error.wasmimport_ErrorToDebugString
+func wasmimport_ErrorToDebugString(self0 uint32, result unsafe.Pointer) {
+	*(*string)(result) = "fakehost: stream error"
+}
+
+//go:linkname wasmimport_ErrorResourceDrop go.wasmcloud.dev/component/gen/wasi/io/error.wasmimport_ErrorResourceDrop
+func wasmimport_ErrorResourceDrop(self0 uint32) {
+	Drop(self0)
+}
@@ -0,0 +1,222 @@
+//go:build !wasm
+
+package fakehost
+
+import (
+	"errors"
+	"strings"
+	"unsafe"
+
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"go.wasmcloud.dev/component/gen/wasi/keyvalue/atomics"
+	"go.wasmcloud.dev/component/gen/wasi/keyvalue/batch"
+	"go.wasmcloud.dev/component/gen/wasi/keyvalue/store"
+	"go.wasmcloud.dev/component/internal/backend"
+)
+
+var (
+	// ErrNoSuchStore and ErrAccessDenied are reported as the matching cases of
+	// the `wasi:keyvalue/store` error, other errors as free-form errors.
+	ErrNoSuchStore  = errors.New("no such store")
+	ErrAccessDenied = errors.New("access denied")
+)
+
+// KeyValue opens the buckets of `wasi:keyvalue/store`, e.g. installed by
+// componenttest. No store exists while it is nil.
+var KeyValue backend.KeyValue
+
+// Buckets are opened by name ahead of KeyValue, e.g. registered by wasikvtest.
+var Buckets Registry[backend.Bucket]
+
+// KeyValueFunc adapts a function to a backend.KeyValue.
+type KeyValueFunc func(identifier string) (backend.Bucket, error)
+
+func (f KeyValueFunc) Open(identifier string) (backend.Bucket, error) {
+	return f(identifier)
+}
+
+// bucket is a `wasi:keyvalue/store` bucket resource.
+type bucket struct {
+	backend.Bucket
+}
+
+func (*bucket) ResourceName() string { return "bucket" }
+
+// openBucket opens the bucket registered under identifier, or through
+// KeyValue.
+func openBucket(identifier string) (backend.Bucket, error) {
+	if b, ok := Buckets.Get(identifier); ok {
+		return b, nil
+	}
+	if KeyValue == nil {
+		return nil, ErrNoSuchStore
+	}
+	return KeyValue.Open(identifier)
+}
+
+// bucketOf returns the backend of the bucket resource handle.
+func bucketOf(handle uint32) backend.Bucket {
+	host.Lock()
+	defer host.Unlock()
+	return Resource[*bucket](handle).Bucket
+}
+
+func storeError(err error) store.Error {
+	switch {
+	case errors.Is(err, ErrNoSuchStore):
+		return store.ErrorNoSuchStore()
+	case errors.Is(err, ErrAccessDenied):
+		return store.ErrorAccessDenied()
+	default:
+		return store.ErrorOther(err.Error())
+	}
+}
+
+// str copies a string lowered by the bindings.
+func str(p unsafe.Pointer, n uint32) string {
+	return strings.Clone(unsafe.String((*byte)(p), n))
+}
+
+// bytesOf copies a byte list lowered by the bindings.
+func bytesOf(p unsafe.Pointer, n uint32) []byte {
+	return append([]byte(nil), unsafe.Slice((*byte)(p), n)...)
+}
+
+// unitResult stores the result of a `wasi:keyvalue` function without value.
+func unitResult(result unsafe.Pointer, err error) {
+	res := (*cm.Result[store.Error, struct{}, store.Error])(result)
+	if err != nil {
+		*res = cm.Err[cm.Result[store.Error, struct{}, store.Error]](storeError(err))
+		return
+	}
+	*res = cm.OK[cm.Result[store.Error, struct{}, store.Error]](struct{}{})
+}
+
+// stub wasi:keyvalue/store
+//
+//go:linkname wasmimport_Open go.wasmcloud.dev/component/gen/wasi/keyvalue/store.wasmimport_Open
+func wasmimport_Open(identifier0 unsafe.Pointer, identifier1 uint32, result unsafe.Pointer) {
+	res := (*cm.Result[store.ErrorShape, store.Bucket, store.Error])(result)
+	b, err := openBucket(str(identifier0, identifier1))
+	if err != nil {
+		*res = cm.Err[cm.Result[store.ErrorShape, store.Bucket, store.Error]](storeError(err))
+		return
+	}
+
+	host.Lock()
+	defer host.Unlock()
+	*res = cm.OK[cm.Result[store.ErrorShape, store.Bucket, store.Error]](store.Bucket(Add(&bucket{b})))
+}
+
+//go:linkname wasmimport_BucketResourceDrop go.wasmcloud.dev/component/gen/wasi/keyvalue/store.wasmimport_BucketResourceDrop
+func wasmimport_BucketResourceDrop(self0 uint32) {
+	bucketOf(self0).Close()
+	Drop(self0)
+}
+
+//go:linkname wasmimport_BucketGet go.wasmcloud.dev/component/gen/wasi/keyvalue/store.wasmimport_BucketGet
+func wasmimport_BucketGet(self0 uint32, key0 unsafe.Pointer, key1 uint32, result unsafe.Pointer) {
+	res := (*cm.Result[store.OptionListU8Shape, cm.Option[cm.List[uint8]], store.Error])(result)
+	value, ok, err := bucketOf(self0).Get(str(key0, key1))
+	switch {
+	case err != nil:
+		*res = cm.Err[cm.Result[store.OptionListU8Shape, cm.Option[cm.List[uint8]], store.Error]](storeError(err))
+	case !ok:
+		*res = cm.OK[cm.Result[store.OptionListU8Shape, cm.Option[cm.List[uint8]], store.Error]](cm.None[cm.List[uint8]]())
+	default:
+		*res = cm.OK[cm.Result[store.OptionListU8Shape, cm.Option[cm.List[uint8]], store.Error]](cm.Some(cm.ToList(value)))
+	}
+}
+
+//go:linkname wasmimport_BucketSet go.wasmcloud.dev/component/gen/wasi/keyvalue/store.wasmimport_BucketSet
+func wasmimport_BucketSet(self0 uint32, key0 unsafe.Pointer, key1 uint32, value0 unsafe.Pointer, value1 uint32, result unsafe.Pointer) {
+	unitResult(result, bucketOf(self0).Set(str(key0, key1), bytesOf(value0, value1)))
+}
+
+//go:linkname wasmimport_BucketDelete go.wasmcloud.dev/component/gen/wasi/keyvalue/store.wasmimport_BucketDelete
+func wasmimport_BucketDelete(self0 uint32, key0 unsafe.Pointer, key1 uint32, result unsafe.Pointer) {
+	unitResult(result, bucketOf(self0).Delete(str(key0, key1)))
+}
+
+//go:linkname wasmimport_BucketExists go.wasmcloud.dev/component/gen/wasi/keyvalue/store.wasmimport_BucketExists
+func wasmimport_BucketExists(self0 uint32, key0 unsafe.Pointer, key1 uint32, result unsafe.Pointer) {
+	res := (*cm.Result[store.ErrorShape, bool, store.Error])(result)
+	ok, err := bucketOf(self0).Exists(str(key0, key1))
+	if err != nil {
+		*res = cm.Err[cm.Result[store.ErrorShape, bool, store.Error]](storeError(err))
+		return
+	}
+	*res = cm.OK[cm.Result[store.ErrorShape, bool, store.Error]](ok)
+}
+
+//go:linkname wasmimport_BucketListKeys go.wasmcloud.dev/component/gen/wasi/keyvalue/store.wasmimport_BucketListKeys
+func wasmimport_BucketListKeys(self0 uint32, cursor0 uint32, cursor1 uint64, result unsafe.Pointer) {
+	res := (*cm.Result[store.KeyResponseShape, store.KeyResponse, store.Error])(result)
+	var cursor *uint64
+	if cursor0 == 1 {
+		cursor = &cursor1
+	}
+	keys, next, err := bucketOf(self0).ListKeys(cursor)
+	if err != nil {
+		*res = cm.Err[cm.Result[store.KeyResponseShape, store.KeyResponse, store.Error]](storeError(err))
+		return
+	}
+	page := store.KeyResponse{Keys: cm.ToList(keys), Cursor: cm.None[uint64]()}
+	if next != nil {
+		page.Cursor = cm.Some(*next)
+	}
+	*res = cm.OK[cm.Result[store.KeyResponseShape, store.KeyResponse, store.Error]](page)
+}
+
+// stub wasi:keyvalue/atomics
+//
+//go:linkname wasmimport_Increment go.wasmcloud.dev/component/gen/wasi/keyvalue/atomics.wasmimport_Increment
+func wasmimport_Increment(bucket0 uint32, key0 unsafe.Pointer, key1 uint32, delta0 uint64, result unsafe.Pointer) {
+	res := (*cm.Result[atomics.ErrorShape, uint64, store.Error])(result)
+	n, err := bucketOf(bucket0).Increment(str(key0, key1), delta0)
+	if err != nil {
+		*res = cm.Err[cm.Result[atomics.ErrorShape, uint64, store.Error]](storeError(err))
+		return
+	}
+	*res = cm.OK[cm.Result[atomics.ErrorShape, uint64, store.Error]](n)
+}
+
+// stub wasi:keyvalue/batch, served by the single key functions of the bucket
+//
+//go:linkname wasmimport_GetMany go.wasmcloud.dev/component/gen/wasi/keyvalue/batch.wasmimport_GetMany
+func wasmimport_GetMany(bucket0 uint32, keys0 unsafe.Pointer, keys1 uint32, result unsafe.Pointer) {
+	res := (*cm.Result[batch.ErrorShape, cm.List[cm.Option[cm.Tuple[string, cm.List[uint8]]]], store.Error])(result)
+	b := bucketOf(bucket0)
+	keys := unsafe.Slice((*string)(keys0), keys1)
+	values := make([]cm.Option[cm.Tuple[string, cm.List[uint8]]], len(keys))
+	for i, key := range keys {
+		value, ok, err := b.Get(key)
+		if err != nil {
+			*res = cm.Err[cm.Result[batch.ErrorShape, cm.List[cm.Option[cm.Tuple[string, cm.List[uint8]]]], store.Error]](storeError(err))
+			return
+		}
+		values[i] = cm.None[cm.Tuple[string, cm.List[uint8]]]()
+		if ok {
+			values[i] = cm.Some(cm.Tuple[string, cm.List[uint8]]{F0: strings.Clone(key), F1: cm.ToList(value)})
+		}
+	}
+	*res = cm.OK[cm.Result[batch.ErrorShape, cm.List[cm.Option[cm.Tuple[string, cm.List[uint8]]]], store.Error]](cm.ToList(values))
+}
+
+//go:linkname wasmimport_SetMany go.wasmcloud.dev/component/gen/wasi/keyvalue/batch.wasmimport_SetMany
+func wasmimport_SetMany(bucket0 uint32, keyValues0 unsafe.Pointer, keyValues1 uint32, result unsafe.Pointer) {
+	b := bucketOf(bucket0)
+	for _, kv := range unsafe.Slice((*cm.Tuple[string, cm.List[uint8]])(keyValues0), keyValues1) {
+		if err := b.Set(strings.Clone(kv.F0), append([]byte(nil), kv.F1.Slice()...)); err != nil {
+			unitResult(result, err)
+			return
+		}
+	}
+	unitResult(result, nil)
+}
+
+//go:linkname wasmimport_DeleteMany go.wasmcloud.dev/component/gen/wasi/keyvalue/batch.wasmimport_DeleteMany
+func wasmimport_DeleteMany(bucket0 uint32, keys0 unsafe.Pointer, keys1 uint32, result unsafe.Pointer) {
+	keys := unsafe.Slice((*string)(keys0), keys1)
+	unitResult(result, bucketOf(bucket0).DeleteMany(append([]string(nil), keys...)))
+}
@@ -0,0 +1,60 @@
+//go:build !wasm
+
+package fakehost
+
+import (
+	"unsafe"
+
+	"go.wasmcloud.dev/component/gen/wasmcloud/bus/lattice"
+)
+
+// links are the link names set through `wasmcloud:bus/lattice`, by
+// interface, e.g. "wasi:keyvalue/store". They are kept with the resources.
+var links = map[string]string{}
+
+// callTarget is a `wasmcloud:bus/lattice` call-target-interface resource.
+type callTarget struct {
+	iface string
+}
+
+func (*callTarget) ResourceName() string { return "call-target-interface" }
+
+// LinkName returns the link name last set for iface, e.g.
+// "wasi:keyvalue/store", or "default".
+func LinkName(iface string) string {
+	host.Lock()
+	defer host.Unlock()
+
+	if name, ok := links[iface]; ok {
+		return name
+	}
+	return "default"
+}
+
+// stub wasmcloud:bus/lattice, recording the link names by interface
+//
+//go:linkname wasmimport_NewCallTargetInterface go.wasmcloud.dev/component/gen/wasmcloud/bus/lattice.wasmimport_NewCallTargetInterface
+func wasmimport_NewCallTargetInterface(namespace0 unsafe.Pointer, namespace1 uint32, package0 unsafe.Pointer, package1 uint32, interface0 unsafe.Pointer, interface1 uint32) (result0 uint32) {
+	host.Lock()
+	defer host.Unlock()
+	return Add(&callTarget{iface: str(namespace0, namespace1) + ":" + str(package0, package1) + "/" + str(interface0, interface1)})
+}
+
+// stub wasmcloud:bus/lattice, the interfaces are consumed
+//
+//go:linkname wasmimport_SetLinkName go.wasmcloud.dev/component/gen/wasmcloud/bus/lattice.wasmimport_SetLinkName
+func wasmimport_SetLinkName(name0 unsafe.Pointer, name1 uint32, interfaces0 unsafe.Pointer, interfaces1 uint32) {
+	host.Lock()
+	defer host.Unlock()
+
+	name := str(name0, name1)
+	for _, handle := range unsafe.Slice((*lattice.CallTargetInterface)(interfaces0), interfaces1) {
+		links[Resource[*callTarget](uint32(handle)).iface] = name
+		Delete(uint32(handle))
+	}
+}
+
+//go:linkname wasmimport_CallTargetInterfaceResourceDrop go.wasmcloud.dev/component/gen/wasmcloud/bus/lattice.wasmimport_CallTargetInterfaceResourceDrop
+func wasmimport_CallTargetInterfaceResourceDrop(self0 uint32) {
+	Drop(self0)
+}
@@ -0,0 +1,60 @@
+//go:build !wasm
+
+package fakehost
+
+import (
+	"errors"
+	"time"
+	"unsafe"
+
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"go.wasmcloud.dev/component/gen/wasmcloud/messaging/consumer"
+	"go.wasmcloud.dev/component/gen/wasmcloud/messaging/types"
+	"go.wasmcloud.dev/component/internal/backend"
+)
+
+// Messaging serves `wasmcloud:messaging/consumer`, e.g. installed by
+// componenttest. Publishing fails and requests have no responders while it
+// is nil.
+var Messaging backend.Messaging
+
+// stub wasmcloud:messaging/consumer
+//
+//go:linkname wasmimport_Publish go.wasmcloud.dev/component/gen/wasmcloud/messaging/consumer.wasmimport_Publish
+func wasmimport_Publish(msg0 unsafe.Pointer, msg1 uint32, msg2 unsafe.Pointer, msg3 uint32, msg4 uint32, msg5 unsafe.Pointer, msg6 uint32, result unsafe.Pointer) {
+	res := (*cm.Result[string, struct{}, string])(result)
+	msg := backend.Message{Subject: str(msg0, msg1), Body: bytesOf(msg2, msg3)}
+	if msg4 == 1 {
+		msg.ReplyTo = str(msg5, msg6)
+	}
+
+	err := errors.New("no broker")
+	if Messaging != nil {
+		err = Messaging.Publish(msg)
+	}
+	if err != nil {
+		*res = cm.Err[cm.Result[string, struct{}, string]](err.Error())
+		return
+	}
+	*res = cm.OK[cm.Result[string, struct{}, string]](struct{}{})
+}
+
+//go:linkname wasmimport_Request go.wasmcloud.dev/component/gen/wasmcloud/messaging/consumer.wasmimport_Request
+func wasmimport_Request(subject0 unsafe.Pointer, subject1 uint32, body0 unsafe.Pointer, body1 uint32, timeoutMs0 uint32, result unsafe.Pointer) {
+	res := (*cm.Result[consumer.BrokerMessageShape, types.BrokerMessage, string])(result)
+	if Messaging == nil {
+		*res = cm.Err[cm.Result[consumer.BrokerMessageShape, types.BrokerMessage, string]]("no responders")
+		return
+	}
+
+	reply, err := Messaging.Request(str(subject0, subject1), bytesOf(body0, body1), time.Duration(timeoutMs0)*time.Millisecond)
+	if err != nil {
+		*res = cm.Err[cm.Result[consumer.BrokerMessageShape, types.BrokerMessage, string]](err.Error())
+		return
+	}
+	msg := types.BrokerMessage{Subject: reply.Subject, Body: cm.ToList(reply.Body), ReplyTo: cm.None[string]()}
+	if reply.ReplyTo != "" {
+		msg.ReplyTo = cm.Some(reply.ReplyTo)
+	}
+	*res = cm.OK[cm.Result[consumer.BrokerMessageShape, types.BrokerMessage, string]](msg)
+}
@@ -0,0 +1,46 @@
+//go:build !wasm
+
+package fakehost
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"unsafe"
+
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+)
+
+// stub wasi:random/random, reading from crypto/rand
+//
+//go:linkname wasmimport_GetRandomBytes go.wasmcloud.dev/component/gen/wasi/random/random.wasmimport_GetRandomBytes
+func wasmimport_GetRandomBytes(len0 uint64, result unsafe.Pointer) {
+	b := make([]byte, len0)
+	rand.Read(b)
+	*(*cm.List[uint8])(result) = cm.ToList(b)
+}
+
+//go:linkname wasmimport_GetRandomU64 go.wasmcloud.dev/component/gen/wasi/random/random.wasmimport_GetRandomU64
+func wasmimport_GetRandomU64() (result0 uint64) {
+	var b [8]byte
+	rand.Read(b[:])
+	return binary.LittleEndian.Uint64(b[:])
+}
+
+// stub wasi:random/insecure
+//
+//go:linkname wasmimport_GetInsecureRandomBytes go.wasmcloud.dev/component/gen/wasi/random/insecure.wasmimport_GetInsecureRandomBytes
+func wasmimport_GetInsecureRandomBytes(len0 uint64, result unsafe.Pointer) {
+	wasmimport_GetRandomBytes(len0, result)
+}
+
+//go:linkname wasmimport_GetInsecureRandomU64 go.wasmcloud.dev/component/gen/wasi/random/insecure.wasmimport_GetInsecureRandomU64
+func wasmimport_GetInsecureRandomU64() (result0 uint64) {
+	return wasmimport_GetRandomU64()
+}
+
+// stub wasi:random/insecure-seed
+//
+//go:linkname wasmimport_InsecureSeed go.wasmcloud.dev/component/gen/wasi/random/insecure-seed.wasmimport_InsecureSeed
+func wasmimport_InsecureSeed(result unsafe.Pointer) {
+	*(*[2]uint64)(result) = [2]uint64{wasmimport_GetRandomU64(), wasmimport_GetRandomU64()}
+}
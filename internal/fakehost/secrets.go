@@ -0,0 +1,69 @@
+//go:build !wasm
+
+package fakehost
+
+import (
+	"unsafe"
+
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"go.wasmcloud.dev/component/gen/wasmcloud/secrets/store"
+)
+
+// Secrets are the secrets served by `wasmcloud:secrets`, string values are
+// revealed as strings and byte values as bytes. Tests set it before the
+// secrets are read.
+var Secrets struct {
+	Values map[string]string
+	Bytes  map[string][]byte
+	// Errors fail the get of their key with an upstream error.
+	Errors map[string]string
+}
+
+// secret is a `wasmcloud:secrets/store` secret resource.
+type secret struct {
+	key string
+}
+
+func (*secret) ResourceName() string { return "secret" }
+
+// stub wasmcloud:secrets/store, serving Secrets
+//
+//go:linkname wasmimport_SecretsGet go.wasmcloud.dev/component/gen/wasmcloud/secrets/store.wasmimport_Get
+func wasmimport_SecretsGet(key0 unsafe.Pointer, key1 uint32, result unsafe.Pointer) {
+	res := (*cm.Result[store.SecretsErrorShape, store.Secret, store.SecretsError])(result)
+	key := str(key0, key1)
+	if msg, ok := Secrets.Errors[key]; ok {
+		*res = cm.Err[cm.Result[store.SecretsErrorShape, store.Secret, store.SecretsError]](store.SecretsErrorUpstream(msg))
+		return
+	}
+	_, isString := Secrets.Values[key]
+	_, isBytes := Secrets.Bytes[key]
+	if !isString && !isBytes {
+		*res = cm.Err[cm.Result[store.SecretsErrorShape, store.Secret, store.SecretsError]](store.SecretsErrorNotFound())
+		return
+	}
+
+	host.Lock()
+	defer host.Unlock()
+	*res = cm.OK[cm.Result[store.SecretsErrorShape, store.Secret, store.SecretsError]](store.Secret(Add(&secret{key: key})))
+}
+
+//go:linkname wasmimport_SecretResourceDrop go.wasmcloud.dev/component/gen/wasmcloud/secrets/store.wasmimport_SecretResourceDrop
+func wasmimport_SecretResourceDrop(self0 uint32) {
+	Drop(self0)
+}
+
+// stub wasmcloud:secrets/reveal
+//
+//go:linkname wasmimport_Reveal go.wasmcloud.dev/component/gen/wasmcloud/secrets/reveal.wasmimport_Reveal
+func wasmimport_Reveal(s0 uint32, result unsafe.Pointer) {
+	host.Lock()
+	key := Resource[*secret](s0).key
+	host.Unlock()
+
+	if value, ok := Secrets.Values[key]; ok {
+		*(*store.SecretValue)(result) = store.SecretValueString(value)
+		return
+	}
+	*(*store.SecretValue)(result) = store.SecretValueBytes(cm.ToList(Secrets.Bytes[key]))
+}
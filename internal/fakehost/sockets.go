@@ -0,0 +1,169 @@
+//go:build !wasm
+
+package fakehost
+
+import (
+	"bytes"
+	"net/netip"
+	"unsafe"
+
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"go.wasmcloud.dev/component/gen/wasi/io/streams"
+	"go.wasmcloud.dev/component/gen/wasi/sockets/network"
+	"go.wasmcloud.dev/component/gen/wasi/sockets/tcp"
+)
+
+// TCPListener is a listening `wasi:sockets/tcp` socket on Addr, accepting the
+// connections queued by Connect. Addresses are IPv4.
+type TCPListener struct {
+	Addr    netip.AddrPort
+	pending []*TCPConn
+}
+
+func (*TCPListener) ResourceName() string { return "tcp-socket" }
+
+// Subscribe returns a pollable ready while connections are pending, it must
+// be called with the resources held.
+func (l *TCPListener) Subscribe() *Pollable {
+	return &Pollable{Ready: func() bool { return len(l.pending) > 0 }}
+}
+
+// Connect queues a connection from remote sending input, the input stream of
+// the connection is closed once drained if eof is set.
+func (l *TCPListener) Connect(remote netip.AddrPort, input string, eof bool) *TCPConn {
+	host.Lock()
+	defer host.Unlock()
+
+	c := &TCPConn{
+		remote: remote,
+		input:  &InputStream{Data: []byte(input), Open: !eof},
+		output: &OutputStream{Buffer: &bytes.Buffer{}},
+	}
+	l.pending = append(l.pending, c)
+	return c
+}
+
+// TCPConn is a connection accepted by a TCPListener.
+type TCPConn struct {
+	remote netip.AddrPort
+	input  *InputStream
+	output *OutputStream
+}
+
+// Output returns the data written to the connection.
+func (c *TCPConn) Output() string {
+	host.Lock()
+	defer host.Unlock()
+	return c.output.Buffer.String()
+}
+
+// tcpSocket is a connected `wasi:sockets/tcp` socket.
+type tcpSocket struct {
+	local, remote netip.AddrPort
+}
+
+func (*tcpSocket) ResourceName() string { return "tcp-socket" }
+
+func socketAddress(addr netip.AddrPort) network.IPSocketAddress {
+	return network.IPSocketAddressIPv4(network.IPv4SocketAddress{
+		Port:    addr.Port(),
+		Address: addr.Addr().As4(),
+	})
+}
+
+// stub wasi:sockets/tcp, accepting the connections queued on the listener
+//
+//go:linkname wasmimport_TCPSocketAccept go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketAccept
+func wasmimport_TCPSocketAccept(self0 uint32, result unsafe.Pointer) {
+	host.Lock()
+	defer host.Unlock()
+
+	res := (*cm.Result[tcp.TupleTCPSocketInputStreamOutputStreamShape, cm.Tuple3[tcp.TCPSocket, streams.InputStream, streams.OutputStream], network.ErrorCode])(result)
+	l := Resource[*TCPListener](self0)
+	if len(l.pending) == 0 {
+		*res = cm.Err[cm.Result[tcp.TupleTCPSocketInputStreamOutputStreamShape, cm.Tuple3[tcp.TCPSocket, streams.InputStream, streams.OutputStream], network.ErrorCode]](network.ErrorCodeWouldBlock)
+		return
+	}
+	c := l.pending[0]
+	l.pending = l.pending[1:]
+
+	*res = cm.OK[cm.Result[tcp.TupleTCPSocketInputStreamOutputStreamShape, cm.Tuple3[tcp.TCPSocket, streams.InputStream, streams.OutputStream], network.ErrorCode]](cm.Tuple3[tcp.TCPSocket, streams.InputStream, streams.OutputStream]{
+		F0: tcp.TCPSocket(Add(&tcpSocket{local: l.Addr, remote: c.remote})),
+		F1: streams.InputStream(Add(c.input)),
+		F2: streams.OutputStream(Add(c.output)),
+	})
+}
+
+//go:linkname wasmimport_TCPSocketLocalAddress go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketLocalAddress
+func wasmimport_TCPSocketLocalAddress(self0 uint32, result unsafe.Pointer) {
+	host.Lock()
+	defer host.Unlock()
+
+	var addr netip.AddrPort
+	if s := Resource[*tcpSocket](self0); s != nil {
+		addr = s.local
+	} else if l := Resource[*TCPListener](self0); l != nil {
+		addr = l.Addr
+	}
+	*(*cm.Result[tcp.IPSocketAddressShape, network.IPSocketAddress, network.ErrorCode])(result) = cm.OK[cm.Result[tcp.IPSocketAddressShape, network.IPSocketAddress, network.ErrorCode]](socketAddress(addr))
+}
+
+//go:linkname wasmimport_TCPSocketRemoteAddress go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketRemoteAddress
+func wasmimport_TCPSocketRemoteAddress(self0 uint32, result unsafe.Pointer) {
+	host.Lock()
+	defer host.Unlock()
+
+	res := (*cm.Result[tcp.IPSocketAddressShape, network.IPSocketAddress, network.ErrorCode])(result)
+	s := Resource[*tcpSocket](self0)
+	if s == nil {
+		*res = cm.Err[cm.Result[tcp.IPSocketAddressShape, network.IPSocketAddress, network.ErrorCode]](network.ErrorCodeInvalidState)
+		return
+	}
+	*res = cm.OK[cm.Result[tcp.IPSocketAddressShape, network.IPSocketAddress, network.ErrorCode]](socketAddress(s.remote))
+}
+
+//go:linkname wasmimport_TCPSocketShutdown go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketShutdown
+func wasmimport_TCPSocketShutdown(self0 uint32, shutdownType0 uint32, result unsafe.Pointer) {
+	*(*cm.Result[network.ErrorCode, struct{}, network.ErrorCode])(result) = cm.OK[cm.Result[network.ErrorCode, struct{}, network.ErrorCode]](struct{}{})
+}
+
+// socket options are accepted and ignored
+
+//go:linkname wasmimport_TCPSocketSetKeepAliveEnabled go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketSetKeepAliveEnabled
+func wasmimport_TCPSocketSetKeepAliveEnabled(self0 uint32, value0 uint32, result unsafe.Pointer) {
+	setOption(result)
+}
+
+//go:linkname wasmimport_TCPSocketSetKeepAliveIdleTime go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketSetKeepAliveIdleTime
+func wasmimport_TCPSocketSetKeepAliveIdleTime(self0 uint32, value0 uint64, result unsafe.Pointer) {
+	setOption(result)
+}
+
+//go:linkname wasmimport_TCPSocketSetKeepAliveInterval go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketSetKeepAliveInterval
+func wasmimport_TCPSocketSetKeepAliveInterval(self0 uint32, value0 uint64, result unsafe.Pointer) {
+	setOption(result)
+}
+
+//go:linkname wasmimport_TCPSocketSetKeepAliveCount go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketSetKeepAliveCount
+func wasmimport_TCPSocketSetKeepAliveCount(self0 uint32, value0 uint32, result unsafe.Pointer) {
+	setOption(result)
+}
+
+//go:linkname wasmimport_TCPSocketSetReceiveBufferSize go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketSetReceiveBufferSize
+func wasmimport_TCPSocketSetReceiveBufferSize(self0 uint32, value0 uint64, result unsafe.Pointer) {
+	setOption(result)
+}
+
+//go:linkname wasmimport_TCPSocketSetSendBufferSize go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketSetSendBufferSize
+func wasmimport_TCPSocketSetSendBufferSize(self0 uint32, value0 uint64, result unsafe.Pointer) {
+	setOption(result)
+}
+
+func setOption(result unsafe.Pointer) {
+	*(*cm.Result[network.ErrorCode, struct{}, network.ErrorCode])(result) = cm.OK[cm.Result[network.ErrorCode, struct{}, network.ErrorCode]](struct{}{})
+}
+
+//go:linkname wasmimport_TCPSocketResourceDrop go.wasmcloud.dev/component/gen/wasi/sockets/tcp.wasmimport_TCPSocketResourceDrop
+func wasmimport_TCPSocketResourceDrop(self0 uint32) {
+	Drop(self0)
+}
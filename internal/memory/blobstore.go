@@ -0,0 +1,114 @@
+package memory
+
+import (
+	"fmt"
+	"sync"
+
+	"go.wasmcloud.dev/component/internal/backend"
+)
+
+// Blobstore is an in-memory `wasi:blobstore` host of Containers, safe for
+// concurrent use.
+type Blobstore struct {
+	mu         sync.Mutex
+	containers map[string]*Container
+}
+
+// NewBlobstore returns a Blobstore without containers.
+func NewBlobstore() *Blobstore {
+	return &Blobstore{containers: map[string]*Container{}}
+}
+
+// Put creates the named container with a copy of objects, replacing its
+// contents if it exists.
+func (bs *Blobstore) Put(name string, objects map[string][]byte) {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+
+	if c, ok := bs.containers[name]; ok {
+		c.Replace(objects)
+		return
+	}
+	bs.containers[name] = NewContainer(name, objects)
+}
+
+// Lookup returns the named container.
+func (bs *Blobstore) Lookup(name string) (*Container, bool) {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+
+	c, ok := bs.containers[name]
+	return c, ok
+}
+
+// container returns the named container.
+func (bs *Blobstore) container(name string) (*Container, error) {
+	c, ok := bs.Lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("container %q does not exist", name)
+	}
+	return c, nil
+}
+
+func (bs *Blobstore) CreateContainer(name string) (backend.Container, error) {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+
+	if _, ok := bs.containers[name]; ok {
+		return nil, fmt.Errorf("container %q already exists", name)
+	}
+	c := NewContainer(name, nil)
+	bs.containers[name] = c
+	return c, nil
+}
+
+func (bs *Blobstore) GetContainer(name string) (backend.Container, error) {
+	return bs.container(name)
+}
+
+func (bs *Blobstore) DeleteContainer(name string) error {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+
+	if _, ok := bs.containers[name]; !ok {
+		return fmt.Errorf("container %q does not exist", name)
+	}
+	delete(bs.containers, name)
+	return nil
+}
+
+func (bs *Blobstore) ContainerExists(name string) (bool, error) {
+	_, ok := bs.Lookup(name)
+	return ok, nil
+}
+
+func (bs *Blobstore) CopyObject(srcContainer, srcObject, dstContainer, dstObject string) error {
+	src, err := bs.container(srcContainer)
+	if err != nil {
+		return err
+	}
+	dst, err := bs.container(dstContainer)
+	if err != nil {
+		return err
+	}
+	data, err := src.Load(srcObject)
+	if err != nil {
+		return err
+	}
+	dst.Store(dstObject, data)
+	return nil
+}
+
+func (bs *Blobstore) MoveObject(srcContainer, srcObject, dstContainer, dstObject string) error {
+	if err := bs.CopyObject(srcContainer, srcObject, dstContainer, dstObject); err != nil {
+		return err
+	}
+	if srcContainer == dstContainer && srcObject == dstObject {
+		return nil
+	}
+	src, err := bs.container(srcContainer)
+	if err != nil {
+		return err
+	}
+	return src.DeleteObject(srcObject)
+}
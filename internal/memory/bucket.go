@@ -0,0 +1,210 @@
+package memory
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.wasmcloud.dev/component/time/wasiclock"
+)
+
+// Bucket is an in-memory `wasi:keyvalue` bucket, safe for concurrent use.
+// Counters are stored as decimal strings.
+type Bucket struct {
+	// PageSize is the number of keys listed per call, the cursor is the
+	// offset of the next page in the sorted keys. All keys are listed at
+	// once while it is zero.
+	PageSize int
+
+	mu      sync.Mutex
+	data    map[string][]byte
+	expires map[string]time.Time
+	// writes are applied right after the next read of their key
+	writes map[string][]byte
+}
+
+// NewBucket returns a bucket holding a copy of data.
+func NewBucket(data map[string][]byte) *Bucket {
+	b := &Bucket{}
+	b.Replace(data)
+	return b
+}
+
+// Replace replaces the contents of the bucket with a copy of data.
+func (b *Bucket) Replace(data map[string][]byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.data = clone(data)
+	b.expires = map[string]time.Time{}
+	b.writes = map[string][]byte{}
+}
+
+// Snapshot returns a copy of the live contents of the bucket.
+func (b *Bucket) Snapshot() map[string][]byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.expire()
+	return clone(b.data)
+}
+
+// Expire deletes key once wasiclock.NowFunc reaches at.
+func (b *Bucket) Expire(key string, at time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.expires[key] = at
+}
+
+// WriteAfterRead sets key to value right after its next read, as a writer
+// racing the reader would. A nil value deletes the key.
+func (b *Bucket) WriteAfterRead(key string, value []byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.writes[key] = slices.Clone(value)
+}
+
+// expire deletes the expired keys, it must be called with b.mu held.
+func (b *Bucket) expire() {
+	if len(b.expires) == 0 {
+		return
+	}
+	now := wasiclock.NowFunc()
+	for key, at := range b.expires {
+		if !now.Before(at) {
+			delete(b.data, key)
+			delete(b.expires, key)
+		}
+	}
+}
+
+// set stores value at key, it must be called with b.mu held. Writing a key
+// clears its expiry.
+func (b *Bucket) set(key string, value []byte) {
+	b.data[key] = value
+	delete(b.expires, key)
+}
+
+// read applies the pending write of key, it must be called with b.mu held
+// after reading it.
+func (b *Bucket) read(key string) {
+	value, ok := b.writes[key]
+	if !ok {
+		return
+	}
+	delete(b.writes, key)
+	if value == nil {
+		delete(b.data, key)
+		delete(b.expires, key)
+		return
+	}
+	b.set(key, value)
+}
+
+func (b *Bucket) Get(key string) ([]byte, bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.expire()
+	value, ok := b.data[key]
+	value = slices.Clone(value)
+	b.read(key)
+	return value, ok, nil
+}
+
+func (b *Bucket) Set(key string, value []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.set(key, slices.Clone(value))
+	return nil
+}
+
+func (b *Bucket) Delete(key string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.data, key)
+	delete(b.expires, key)
+	return nil
+}
+
+func (b *Bucket) Exists(key string) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.expire()
+	_, ok := b.data[key]
+	b.read(key)
+	return ok, nil
+}
+
+func (b *Bucket) ListKeys(cursor *uint64) ([]string, *uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.expire()
+	keys := make([]string, 0, len(b.data))
+	for key := range b.data {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	start := 0
+	if cursor != nil {
+		if *cursor > uint64(len(keys)) {
+			return nil, nil, errors.New("invalid cursor")
+		}
+		start = int(*cursor)
+	}
+	end := len(keys)
+	if b.PageSize > 0 {
+		end = min(start+b.PageSize, len(keys))
+	}
+	if end == len(keys) {
+		return keys[start:], nil, nil
+	}
+	next := uint64(end)
+	return keys[start:end], &next, nil
+}
+
+func (b *Bucket) Increment(key string, delta uint64) (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.expire()
+	var n uint64
+	if value, ok := b.data[key]; ok {
+		var err error
+		if n, err = strconv.ParseUint(string(value), 10, 64); err != nil {
+			return 0, fmt.Errorf("value of %q is not a counter", key)
+		}
+	}
+	n += delta
+	b.data[key] = []byte(strconv.FormatUint(n, 10))
+	return n, nil
+}
+
+func (b *Bucket) DeleteMany(keys []string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, key := range keys {
+		delete(b.data, key)
+		delete(b.expires, key)
+	}
+	return nil
+}
+
+func (*Bucket) Close() {}
+
+func clone(data map[string][]byte) map[string][]byte {
+	c := make(map[string][]byte, len(data))
+	for key, value := range data {
+		c[key] = slices.Clone(value)
+	}
+	return c
+}
@@ -0,0 +1,247 @@
+package memory
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"time"
+
+	"go.wasmcloud.dev/component/internal/backend"
+	"go.wasmcloud.dev/component/time/wasiclock"
+)
+
+// Container is an in-memory `wasi:blobstore` container, safe for concurrent
+// use. Timestamps are read from wasiclock.NowFunc.
+type Container struct {
+	mu        sync.Mutex
+	name      string
+	createdAt uint64
+	objects   map[string]object
+	expires   map[string]time.Time
+}
+
+type object struct {
+	data      []byte
+	createdAt uint64
+}
+
+// NewContainer returns the named container holding a copy of objects.
+func NewContainer(name string, objects map[string][]byte) *Container {
+	c := &Container{name: name, createdAt: unixNow()}
+	c.Replace(objects)
+	return c
+}
+
+func unixNow() uint64 {
+	return uint64(wasiclock.NowFunc().Unix())
+}
+
+// Replace replaces the objects of the container with a copy of objects.
+func (c *Container) Replace(objects map[string][]byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := unixNow()
+	c.objects = make(map[string]object, len(objects))
+	for name, data := range objects {
+		c.objects[name] = object{data: slices.Clone(data), createdAt: now}
+	}
+	c.expires = map[string]time.Time{}
+}
+
+// Snapshot returns a copy of the live objects of the container.
+func (c *Container) Snapshot() map[string][]byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.expire()
+	objects := make(map[string][]byte, len(c.objects))
+	for name, o := range c.objects {
+		objects[name] = slices.Clone(o.data)
+	}
+	return objects
+}
+
+// Expire deletes the named object once wasiclock.NowFunc reaches at.
+func (c *Container) Expire(name string, at time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.expires[name] = at
+}
+
+// Load returns a copy of the data of the named object.
+func (c *Container) Load(name string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	o, err := c.object(name)
+	if err != nil {
+		return nil, err
+	}
+	return slices.Clone(o.data), nil
+}
+
+// Store creates or replaces the named object with data.
+func (c *Container) Store(name string, data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.store(name, slices.Clone(data))
+}
+
+// expire deletes the expired objects, it must be called with c.mu held.
+func (c *Container) expire() {
+	if len(c.expires) == 0 {
+		return
+	}
+	now := wasiclock.NowFunc()
+	for name, at := range c.expires {
+		if !now.Before(at) {
+			delete(c.objects, name)
+			delete(c.expires, name)
+		}
+	}
+}
+
+// object returns the named object, it must be called with c.mu held.
+func (c *Container) object(name string) (object, error) {
+	c.expire()
+	o, ok := c.objects[name]
+	if !ok {
+		return object{}, fmt.Errorf("object %q does not exist in container %q", name, c.name)
+	}
+	return o, nil
+}
+
+// store sets the named object, it must be called with c.mu held. Writing an
+// object clears its expiry.
+func (c *Container) store(name string, data []byte) {
+	c.objects[name] = object{data: data, createdAt: unixNow()}
+	delete(c.expires, name)
+}
+
+func (c *Container) CreatedAt() (uint64, error) {
+	return c.createdAt, nil
+}
+
+func (c *Container) ObjectInfo(name string) (backend.ObjectInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	o, err := c.object(name)
+	if err != nil {
+		return backend.ObjectInfo{}, err
+	}
+	return backend.ObjectInfo{
+		Name:      name,
+		Container: c.name,
+		CreatedAt: o.createdAt,
+		Size:      uint64(len(o.data)),
+	}, nil
+}
+
+func (c *Container) HasObject(name string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.expire()
+	_, ok := c.objects[name]
+	return ok, nil
+}
+
+func (c *Container) DeleteObject(name string) error {
+	return c.DeleteObjects([]string{name})
+}
+
+func (c *Container) DeleteObjects(names []string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, name := range names {
+		delete(c.objects, name)
+		delete(c.expires, name)
+	}
+	return nil
+}
+
+func (c *Container) Clear() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clear(c.objects)
+	clear(c.expires)
+	return nil
+}
+
+func (c *Container) ListObjects() (backend.ObjectNames, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.expire()
+	names := make([]string, 0, len(c.objects))
+	for name := range c.objects {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return &objectNames{names: names}, nil
+}
+
+func (c *Container) GetData(name string, start, end uint64) (io.ReadCloser, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	o, err := c.object(name)
+	if err != nil {
+		return nil, err
+	}
+	size := uint64(len(o.data))
+	if start > end || start >= size {
+		return nil, fmt.Errorf("range %d-%d is out of bounds of object %q of size %d", start, end, name, size)
+	}
+	return io.NopCloser(bytes.NewReader(slices.Clone(o.data[start:min(end+1, size)]))), nil
+}
+
+func (c *Container) WriteData(name string) (io.WriteCloser, error) {
+	return &objectWriter{c: c, name: name}, nil
+}
+
+func (*Container) Close() {}
+
+// objectNames is a snapshot of the object names of a container.
+type objectNames struct {
+	names []string
+}
+
+func (l *objectNames) Read(n uint64) ([]string, bool, error) {
+	n = min(n, uint64(len(l.names)))
+	names := l.names[:n]
+	l.names = l.names[n:]
+	return names, len(l.names) == 0, nil
+}
+
+func (*objectNames) Close() {}
+
+// objectWriter buffers the object data, which is stored once it is closed.
+type objectWriter struct {
+	c      *Container
+	name   string
+	buf    bytes.Buffer
+	closed bool
+}
+
+func (w *objectWriter) Write(p []byte) (int, error) {
+	return w.buf.Write(p)
+}
+
+func (w *objectWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+
+	w.c.mu.Lock()
+	defer w.c.mu.Unlock()
+	w.c.store(w.name, w.buf.Bytes())
+	return nil
+}
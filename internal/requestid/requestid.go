@@ -0,0 +1,36 @@
+package requestid
+
+import "context"
+
+// Header carries the request ID of incoming and outgoing requests.
+const Header = "X-Request-Id"
+
+// maxLen bounds the length of the request IDs accepted from clients.
+const maxLen = 128
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying the request ID id.
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID carried by ctx, if any.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// Valid reports whether id is a request ID worth reusing, at most 128
+// printable ASCII characters, so it is safe in logs and headers.
+func Valid(id string) bool {
+	if id == "" || len(id) > maxLen {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,186 @@
+package wasipoll
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"go.wasmcloud.dev/component/gen/wasi/io/poll"
+)
+
+type Pollable = poll.Pollable
+
+// DefaultPoller is the Poller used by the SDK packages waiting on host I/O.
+var DefaultPoller = &Poller{}
+
+// Wait blocks until at least one of the pollables is ready using the DefaultPoller.
+func Wait(pollables ...Pollable) int {
+	return DefaultPoller.Wait(pollables...)
+}
+
+// WaitCancel is like Wait, but returns -1 once cancel is closed, using the DefaultPoller.
+func WaitCancel(cancel <-chan struct{}, pollables ...Pollable) int {
+	return DefaultPoller.WaitCancel(cancel, pollables...)
+}
+
+// yieldRounds is the number of times the driver yields before polling.
+const yieldRounds = 8
+
+type waiter struct {
+	pollables []Pollable
+	ready     int
+	done      chan struct{}
+}
+
+// Poller multiplexes pollables waited on by multiple goroutines into a single
+// `wasi:io/poll.poll` call.
+//
+// A call to `poll` blocks the whole component, so instead of each goroutine
+// blocking on its own pollable, the first goroutine to wait becomes the driver
+// and polls on behalf of every registered waiter. Once its own pollables are
+// ready, the driver hands off to one of the remaining waiters.
+type Poller struct {
+	mu      sync.Mutex
+	waiters []*waiter
+	driving bool
+	handoff chan struct{}
+}
+
+// Wait blocks until at least one of the pollables is ready and returns the
+// index of the first ready pollable. The pollables are borrowed, callers remain
+// responsible for dropping them.
+func (p *Poller) Wait(pollables ...Pollable) int {
+	return p.WaitCancel(nil, pollables...)
+}
+
+// WaitCancel is like Wait, but stops waiting and returns -1 once cancel is closed.
+//
+// Since the component is blocked while the host polls, cancellation is observed
+// when the goroutine closing cancel gets to run, which is after some pollable
+// (e.g. a clock) is ready or before the driver blocks in the host again.
+func (p *Poller) WaitCancel(cancel <-chan struct{}, pollables ...Pollable) int {
+	if len(pollables) == 0 {
+		return -1
+	}
+
+	w := &waiter{
+		pollables: pollables,
+		ready:     -1,
+		done:      make(chan struct{}),
+	}
+
+	p.mu.Lock()
+	if p.handoff == nil {
+		p.handoff = make(chan struct{})
+	}
+	p.waiters = append(p.waiters, w)
+	p.mu.Unlock()
+
+	for {
+		p.mu.Lock()
+		select {
+		case <-w.done:
+			p.mu.Unlock()
+			return w.ready
+		case <-cancel:
+			p.remove(w)
+			p.mu.Unlock()
+			return -1
+		default:
+		}
+
+		if !p.driving {
+			p.driving = true
+			p.mu.Unlock()
+
+			// NOTE: give every runnable goroutine a chance to register its
+			// pollables or cancel its wait before blocking the component in the host.
+			// Goroutines woken up while yielding are queued behind the driver,
+			// yielding more than once lets short wake-up chains settle, e.g. an
+			// HTTP/2 writer woken up by a new request while the reader is polling.
+			for range yieldRounds {
+				runtime.Gosched()
+			}
+
+			select {
+			case <-cancel:
+			default:
+				p.poll()
+			}
+
+			p.mu.Lock()
+			p.driving = false
+			close(p.handoff)
+			p.handoff = make(chan struct{})
+			p.mu.Unlock()
+			continue
+		}
+
+		handoff := p.handoff
+		p.mu.Unlock()
+
+		select {
+		case <-w.done:
+		case <-cancel:
+		case <-handoff:
+		}
+	}
+}
+
+// remove unregisters w, it must be called with p.mu held.
+func (p *Poller) remove(w *waiter) {
+	for i, candidate := range p.waiters {
+		if candidate == w {
+			p.waiters = append(p.waiters[:i], p.waiters[i+1:]...)
+			return
+		}
+	}
+}
+
+// poll performs a single `wasi:io/poll.poll` call over all registered waiters
+// and releases the ones with a ready pollable.
+func (p *Poller) poll() {
+	type entry struct {
+		waiter *waiter
+		index  int
+	}
+
+	p.mu.Lock()
+	var (
+		in      []Pollable
+		entries []entry
+	)
+	for _, w := range p.waiters {
+		for i, pollable := range w.pollables {
+			in = append(in, pollable)
+			entries = append(entries, entry{waiter: w, index: i})
+		}
+	}
+	p.mu.Unlock()
+
+	if len(in) == 0 {
+		return
+	}
+
+	ready := poll.Poll(cm.ToList(in)).Slice()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, idx := range ready {
+		e := entries[idx]
+		if e.waiter.ready < 0 || e.index < e.waiter.ready {
+			e.waiter.ready = e.index
+		}
+	}
+
+	remaining := p.waiters[:0]
+	for _, w := range p.waiters {
+		if w.ready < 0 {
+			remaining = append(remaining, w)
+			continue
+		}
+		close(w.done)
+	}
+	p.waiters = remaining
+}
@@ -0,0 +1,111 @@
+package wasipoll
+
+import (
+	"sync"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+)
+
+var (
+	readyLock sync.Mutex
+	ready     = map[Pollable]bool{}
+)
+
+func setReady(p Pollable) {
+	readyLock.Lock()
+	defer readyLock.Unlock()
+	ready[p] = true
+}
+
+func TestWaitReturnsFirstReady(t *testing.T) {
+	var p Poller
+
+	setReady(2)
+	setReady(3)
+	if got := p.Wait(1, 2, 3); got != 1 {
+		t.Errorf("expected: %v, got: %v", 1, got)
+	}
+}
+
+func TestWaitMultipleWaiters(t *testing.T) {
+	var p Poller
+
+	var wg sync.WaitGroup
+	results := make([]int, 3)
+	for i, pollables := range [][]Pollable{{10}, {11, 12}, {13}} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = p.Wait(pollables...)
+		}()
+	}
+
+	setReady(13)
+	setReady(12)
+	setReady(10)
+	wg.Wait()
+
+	if want := []int{0, 1, 0}; !equal(want, results) {
+		t.Errorf("expected: %v, got: %v", want, results)
+	}
+	if len(p.waiters) != 0 {
+		t.Errorf("expected all waiters to be released, got %d", len(p.waiters))
+	}
+}
+
+func TestWaitCancel(t *testing.T) {
+	var p Poller
+
+	cancel := make(chan struct{})
+	result := make(chan int)
+	go func() {
+		result <- p.WaitCancel(cancel, 20)
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	close(cancel)
+
+	select {
+	case got := <-result:
+		if got != -1 {
+			t.Errorf("expected: %v, got: %v", -1, got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("WaitCancel did not return after cancel")
+	}
+
+	if len(p.waiters) != 0 {
+		t.Errorf("expected canceled waiter to be removed, got %d", len(p.waiters))
+	}
+}
+
+func equal(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// stub wasi:io/poll, reporting the pollables marked by setReady
+//
+//go:linkname wasmimport_Poll go.wasmcloud.dev/component/gen/wasi/io/poll.wasmimport_Poll
+func wasmimport_Poll(in0 *Pollable, in1 uint32, result *cm.List[uint32]) {
+	readyLock.Lock()
+	defer readyLock.Unlock()
+
+	var indices []uint32
+	for i, p := range unsafe.Slice(in0, in1) {
+		if ready[p] {
+			indices = append(indices, uint32(i))
+		}
+	}
+	*result = cm.ToList(indices)
+}
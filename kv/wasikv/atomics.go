@@ -0,0 +1,92 @@
+package wasikv
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrConflict is returned by [Bucket.LocalUpdate] when every attempt lost a
+// race.
+var ErrConflict = errors.New("wasikv: compare-and-swap conflict")
+
+// casLock serializes the compare-and-swap of the component instance.
+var casLock sync.Mutex
+
+// Incr atomically adds delta to the counter at key, returning the new value.
+// A missing key is created with the value delta.
+//
+// Counters are stored in the host representation, read them back with Incr(key, 0)
+// rather than [Bucket.Get].
+func (b *Bucket) Incr(key string, delta uint64) (uint64, error) {
+	n, err := b.bucket.Increment(key, delta)
+	if err != nil {
+		return 0, fmt.Errorf("failed to increment %q: %w", key, err)
+	}
+	return n, nil
+}
+
+// LocalCompareAndSwap sets key to new if its current value equals old, a nil
+// old requiring the key to not exist. It reports whether the swap happened.
+//
+// LocalCompareAndSwap is not atomic across instances:
+// `wasi:keyvalue/atomics@0.2.0-draft` only offers increment, the value is read,
+// compared and set under a lock of the component instance. A write of another
+// instance, replica or the host between the read and the set is overwritten.
+// Coordinate across instances with [Bucket.Incr] or a [Mutex] guarding the key.
+func (b *Bucket) LocalCompareAndSwap(key string, old, new []byte) (bool, error) {
+	casLock.Lock()
+	defer casLock.Unlock()
+
+	current, err := b.Get(key)
+	switch {
+	case errors.Is(err, ErrNotFound):
+		if old != nil {
+			return false, nil
+		}
+	case err != nil:
+		return false, err
+	case old == nil || !bytes.Equal(current, old):
+		return false, nil
+	}
+
+	if err := b.Set(key, new); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// LocalUpdate applies fn to the value at key with [Bucket.LocalCompareAndSwap],
+// retrying up to attempts times when the value changed since it was read. fn
+// receives nil when the key does not exist and must not retain its argument.
+//
+// Like LocalCompareAndSwap, it only excludes the updates of the component
+// instance, concurrent updates of other instances may be lost.
+func (b *Bucket) LocalUpdate(key string, attempts int, fn func(current []byte) ([]byte, error)) error {
+	for range attempts {
+		current, err := b.Get(key)
+		switch {
+		case errors.Is(err, ErrNotFound):
+		case err != nil:
+			return err
+		case current == nil:
+			// an empty value, distinct from a missing key
+			current = []byte{}
+		}
+
+		next, err := fn(current)
+		if err != nil {
+			return err
+		}
+
+		ok, err := b.LocalCompareAndSwap(key, current, next)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+	}
+	return fmt.Errorf("failed to update %q: %w", key, ErrConflict)
+}
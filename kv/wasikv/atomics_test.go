@@ -0,0 +1,129 @@
+//go:build !wasm
+
+package wasikv
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestIncr(t *testing.T) {
+	fakeBucket("counters", map[string][]byte{"invalid": []byte("x")})
+	b, err := Open("counters")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+
+	tests := map[string]struct {
+		key   string
+		delta uint64
+		want  uint64
+		err   bool
+	}{
+		"create":    {key: "hits", delta: 3, want: 3},
+		"increment": {key: "hits", delta: 2, want: 5},
+		"read":      {key: "hits", delta: 0, want: 5},
+		"invalid":   {key: "invalid", delta: 1, err: true},
+	}
+
+	for _, name := range []string{"create", "increment", "read", "invalid"} {
+		tt := tests[name]
+		t.Run(name, func(t *testing.T) {
+			got, err := b.Incr(tt.key, tt.delta)
+			if tt.err {
+				if err == nil {
+					t.Error("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("expected: %v, got: %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestLocalCompareAndSwap(t *testing.T) {
+	fakeBucket("cas", map[string][]byte{"key": []byte("v1"), "empty": {}})
+	b, err := Open("cas")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+
+	tests := map[string]struct {
+		key      string
+		old, new []byte
+		swapped  bool
+	}{
+		"match":          {key: "key", old: []byte("v1"), new: []byte("v2"), swapped: true},
+		"stale":          {key: "key", old: []byte("v1"), new: []byte("v3"), swapped: false},
+		"create":         {key: "new", old: nil, new: []byte("v1"), swapped: true},
+		"already exists": {key: "new", old: nil, new: []byte("v2"), swapped: false},
+		"missing":        {key: "missing", old: []byte("v1"), new: []byte("v2"), swapped: false},
+	}
+
+	for _, name := range []string{"match", "stale", "create", "already exists", "missing"} {
+		tt := tests[name]
+		t.Run(name, func(t *testing.T) {
+			swapped, err := b.LocalCompareAndSwap(tt.key, tt.old, tt.new)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if swapped != tt.swapped {
+				t.Errorf("expected: %v, got: %v", tt.swapped, swapped)
+			}
+		})
+	}
+}
+
+func TestLocalUpdate(t *testing.T) {
+	fakeBucket("update", map[string][]byte{"empty": {}})
+	b, err := Open("update")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+
+	incr := func(current []byte) ([]byte, error) {
+		n, _ := strconv.Atoi(string(current))
+		return []byte(strconv.Itoa(n + 1)), nil
+	}
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := b.LocalUpdate("counter", 100, incr); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if value, _ := b.Get("counter"); string(value) != "10" {
+		t.Errorf("expected: %v, got: %s", 10, value)
+	}
+
+	if err := b.LocalUpdate("empty", 1, incr); err != nil {
+		t.Errorf("expected empty value to be updated, got: %v", err)
+	}
+
+	var writes int
+	conflict := func(current []byte) ([]byte, error) {
+		// a concurrent writer changes the value before every swap
+		writes++
+		b.Set("contended", []byte(strconv.Itoa(writes)))
+		return []byte("mine"), nil
+	}
+	if err := b.LocalUpdate("contended", 3, conflict); !errors.Is(err, ErrConflict) {
+		t.Errorf("expected: %v, got: %v", ErrConflict, err)
+	}
+}
@@ -0,0 +1,107 @@
+package wasikv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.wasmcloud.dev/component/internal/backend"
+	"go.wasmcloud.dev/component/wasmcloud"
+)
+
+var (
+	// ErrNotFound is returned by [Bucket.Get] when the key does not exist.
+	ErrNotFound = errors.New("wasikv: key not found")
+	// ErrNoSuchStore is returned by [Open] when the host does not know the bucket.
+	ErrNoSuchStore = errors.New("wasikv: no such store")
+	// ErrAccessDenied is returned when the component may not access the bucket.
+	ErrAccessDenied = errors.New("wasikv: access denied")
+)
+
+// Bucket is a `wasi:keyvalue/store` bucket.
+type Bucket struct {
+	name   string
+	bucket backend.Bucket
+}
+
+// Open opens the bucket with the given identifier, as linked by the host.
+// An empty identifier selects the default bucket of the link.
+func Open(identifier string) (*Bucket, error) {
+	return OpenContext(context.Background(), identifier)
+}
+
+// OpenContext opens the bucket with the given identifier of the link selected
+// by wasmcloud.WithLinkName, e.g. "backup-kv". The bucket is called through
+// that link until it is closed.
+func OpenContext(ctx context.Context, identifier string) (*Bucket, error) {
+	link := wasmcloud.LinkName(ctx)
+	wasmcloud.UseLinkName(link, interfaces...)
+	bucket, err := openBucket(identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bucket %q: %w", identifier, err)
+	}
+	return &Bucket{name: identifier, bucket: linkedBucket{link: link, bucket: bucket}}, nil
+}
+
+// Name returns the identifier the bucket was opened with.
+func (b *Bucket) Name() string {
+	return b.name
+}
+
+// Close releases the bucket, it must not be used afterwards.
+func (b *Bucket) Close() error {
+	b.bucket.Close()
+	return nil
+}
+
+// Get returns the value stored at key, or [ErrNotFound].
+func (b *Bucket) Get(key string) ([]byte, error) {
+	value, ok, err := b.bucket.Get(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %q: %w", key, err)
+	}
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return value, nil
+}
+
+// Set stores value at key, overwriting any previous value.
+func (b *Bucket) Set(key string, value []byte) error {
+	if err := b.bucket.Set(key, value); err != nil {
+		return fmt.Errorf("failed to set %q: %w", key, err)
+	}
+	return nil
+}
+
+// Delete removes key, it is not an error if the key does not exist.
+func (b *Bucket) Delete(key string) error {
+	if err := b.bucket.Delete(key); err != nil {
+		return fmt.Errorf("failed to delete %q: %w", key, err)
+	}
+	return nil
+}
+
+// Exists reports whether key exists.
+func (b *Bucket) Exists(key string) (bool, error) {
+	ok, err := b.bucket.Exists(key)
+	if err != nil {
+		return false, fmt.Errorf("failed to check %q: %w", key, err)
+	}
+	return ok, nil
+}
+
+// Keys returns every key in the bucket, following the host cursor across pages.
+// The order is unspecified and concurrent writes may or may not be reflected.
+// Use [Bucket.ScanKeys] to avoid holding every key in memory.
+func (b *Bucket) Keys() ([]string, error) {
+	var keys []string
+	s := b.ScanKeys("")
+	for s.Next() {
+		keys = append(keys, s.Key())
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
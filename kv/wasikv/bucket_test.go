@@ -0,0 +1,102 @@
+//go:build !wasm
+
+package wasikv
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestOpen(t *testing.T) {
+	fakeBucket("default", map[string][]byte{})
+
+	tests := map[string]struct {
+		name string
+		err  error
+	}{
+		"default": {name: "default"},
+		"missing": {name: "missing", err: ErrNoSuchStore},
+		"denied":  {name: "denied", err: ErrAccessDenied},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			b, err := Open(tt.name)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected: %v, got: %v", tt.err, err)
+			}
+			if err == nil {
+				defer b.Close()
+				if b.Name() != tt.name {
+					t.Errorf("expected: %v, got: %v", tt.name, b.Name())
+				}
+			}
+		})
+	}
+}
+
+func TestBucket(t *testing.T) {
+	fakeBucket("crud", map[string][]byte{"existing": []byte("value")})
+
+	b, err := Open("crud")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+
+	if value, err := b.Get("existing"); err != nil || string(value) != "value" {
+		t.Errorf("expected: %v, got: %q %v", "value", value, err)
+	}
+	if _, err := b.Get("missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected: %v, got: %v", ErrNotFound, err)
+	}
+
+	if err := b.Set("new", []byte("fresh")); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := b.Exists("new"); err != nil || !ok {
+		t.Errorf("expected key to exist, got: %v %v", ok, err)
+	}
+
+	if err := b.Delete("new"); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := b.Exists("new"); err != nil || ok {
+		t.Errorf("expected key to be deleted, got: %v %v", ok, err)
+	}
+	if err := b.Delete("new"); err != nil {
+		t.Errorf("expected deleting a missing key to succeed, got: %v", err)
+	}
+}
+
+func TestKeys(t *testing.T) {
+	tests := map[string]struct {
+		data map[string][]byte
+		want []string
+	}{
+		"empty":      {data: map[string][]byte{}, want: nil},
+		"single":     {data: map[string][]byte{"a": nil}, want: []string{"a"}},
+		"multi page": {data: map[string][]byte{"a": nil, "b": nil, "c": nil, "d": nil, "e": nil}, want: []string{"a", "b", "c", "d", "e"}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			fakeBucket(name, tt.data)
+			b, err := Open(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer b.Close()
+
+			keys, err := b.Keys()
+			if err != nil {
+				t.Fatal(err)
+			}
+			slices.Sort(keys)
+			if !slices.Equal(keys, tt.want) {
+				t.Errorf("expected: %v, got: %v", tt.want, keys)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package wasikv
+
+import (
+	"errors"
+
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"go.wasmcloud.dev/component/gen/wasi/keyvalue/atomics"
+	"go.wasmcloud.dev/component/gen/wasi/keyvalue/batch"
+	"go.wasmcloud.dev/component/gen/wasi/keyvalue/store"
+	"go.wasmcloud.dev/component/internal/backend"
+)
+
+// openBucket opens the bucket with the given identifier on the host.
+func openBucket(identifier string) (backend.Bucket, error) {
+	return hostKeyValue{}.Open(identifier)
+}
+
+type hostKeyValue struct{}
+
+func (hostKeyValue) Open(identifier string) (backend.Bucket, error) {
+	res := store.Open(identifier)
+	if res.IsErr() {
+		return nil, storeError(res.Err())
+	}
+	return hostBucket{*res.OK()}, nil
+}
+
+// hostBucket is a `wasi:keyvalue/store` bucket resource.
+type hostBucket struct {
+	bucket store.Bucket
+}
+
+func (b hostBucket) Get(key string) ([]byte, bool, error) {
+	res := b.bucket.Get(key)
+	if res.IsErr() {
+		return nil, false, storeError(res.Err())
+	}
+	value := res.OK()
+	if value.None() {
+		return nil, false, nil
+	}
+	return value.Some().Slice(), true, nil
+}
+
+func (b hostBucket) Set(key string, value []byte) error {
+	if res := b.bucket.Set(key, cm.ToList(value)); res.IsErr() {
+		return storeError(res.Err())
+	}
+	return nil
+}
+
+func (b hostBucket) Delete(key string) error {
+	if res := b.bucket.Delete(key); res.IsErr() {
+		return storeError(res.Err())
+	}
+	return nil
+}
+
+func (b hostBucket) Exists(key string) (bool, error) {
+	res := b.bucket.Exists(key)
+	if res.IsErr() {
+		return false, storeError(res.Err())
+	}
+	return *res.OK(), nil
+}
+
+func (b hostBucket) ListKeys(cursor *uint64) ([]string, *uint64, error) {
+	c := cm.None[uint64]()
+	if cursor != nil {
+		c = cm.Some(*cursor)
+	}
+	res := b.bucket.ListKeys(c)
+	if res.IsErr() {
+		return nil, nil, storeError(res.Err())
+	}
+	page := res.OK()
+	return page.Keys.Slice(), page.Cursor.Some(), nil
+}
+
+func (b hostBucket) Increment(key string, delta uint64) (uint64, error) {
+	res := atomics.Increment(b.bucket, key, delta)
+	if res.IsErr() {
+		return 0, storeError(res.Err())
+	}
+	return *res.OK(), nil
+}
+
+func (b hostBucket) DeleteMany(keys []string) error {
+	if res := batch.DeleteMany(b.bucket, cm.ToList(keys)); res.IsErr() {
+		return storeError(res.Err())
+	}
+	return nil
+}
+
+func (b hostBucket) Close() {
+	b.bucket.ResourceDrop()
+}
+
+func storeError(err *store.Error) error {
+	switch {
+	case err.NoSuchStore():
+		return ErrNoSuchStore
+	case err.AccessDenied():
+		return ErrAccessDenied
+	default:
+		return errors.New(*err.Other())
+	}
+}
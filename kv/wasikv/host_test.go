@@ -0,0 +1,61 @@
+//go:build !wasm
+
+package wasikv
+
+import (
+	"sync"
+	"time"
+
+	"go.wasmcloud.dev/component/internal/backend"
+	"go.wasmcloud.dev/component/internal/fakehost"
+	"go.wasmcloud.dev/component/internal/memory"
+)
+
+// pageSize is the number of keys returned per list-keys call.
+const pageSize = 2
+
+// fakeBuckets are served by the host by name, "denied" is not accessible.
+var fakeBuckets = struct {
+	sync.Mutex
+	buckets map[string]*memory.Bucket
+}{buckets: map[string]*memory.Bucket{}}
+
+// fakeBucket sets the contents of the named bucket.
+func fakeBucket(name string, data map[string][]byte) {
+	b := memory.NewBucket(data)
+	b.PageSize = pageSize
+
+	fakeBuckets.Lock()
+	defer fakeBuckets.Unlock()
+	fakeBuckets.buckets[name] = b
+}
+
+// fakeIncrLatency delays the results of increments, as seen by callers racing
+// on a counter across the lattice.
+var fakeIncrLatency time.Duration
+
+// slowBucket delays the results of increments by fakeIncrLatency.
+type slowBucket struct {
+	backend.Bucket
+}
+
+func (b slowBucket) Increment(key string, delta uint64) (uint64, error) {
+	defer time.Sleep(fakeIncrLatency)
+	return b.Bucket.Increment(key, delta)
+}
+
+func init() {
+	fakehost.KeyValue = fakehost.KeyValueFunc(func(identifier string) (backend.Bucket, error) {
+		if identifier == "denied" {
+			return nil, fakehost.ErrAccessDenied
+		}
+
+		fakeBuckets.Lock()
+		defer fakeBuckets.Unlock()
+		b, ok := fakeBuckets.buckets[identifier]
+		if !ok {
+			return nil, fakehost.ErrNoSuchStore
+		}
+		return slowBucket{b}, nil
+	})
+}
@@ -0,0 +1,106 @@
+package wasikv
+
+import (
+	"fmt"
+	"strings"
+)
+
+// deleteBatchSize is the number of keys removed per delete-many call.
+const deleteBatchSize = 100
+
+// KeyScanner iterates over the keys of a [Bucket], fetching pages from the host
+// as needed.
+//
+//	s := bucket.ScanKeys("user:")
+//	for s.Next() {
+//		fmt.Println(s.Key())
+//	}
+//	if err := s.Err(); err != nil {
+//		...
+//	}
+type KeyScanner struct {
+	bucket *Bucket
+	prefix string
+	cursor *uint64
+	page   []string
+	key    string
+	done   bool
+	err    error
+}
+
+// ScanKeys returns a [KeyScanner] over the keys starting with prefix, an empty
+// prefix matches every key. The order is unspecified and concurrent writes may
+// or may not be reflected.
+//
+// NOTE: `wasi:keyvalue@0.2.0-draft` has no server side prefix filter, every
+// key of the bucket is listed and non matching keys are skipped.
+func (b *Bucket) ScanKeys(prefix string) *KeyScanner {
+	return &KeyScanner{bucket: b, prefix: prefix}
+}
+
+// Next advances to the next matching key, it returns false once the keys are
+// exhausted or an error occurred.
+func (s *KeyScanner) Next() bool {
+	for {
+		for len(s.page) > 0 {
+			key := s.page[0]
+			s.page = s.page[1:]
+			if strings.HasPrefix(key, s.prefix) {
+				s.key = key
+				return true
+			}
+		}
+		if s.done || s.err != nil {
+			s.key = ""
+			return false
+		}
+		s.fetch()
+	}
+}
+
+// Key returns the current key.
+func (s *KeyScanner) Key() string {
+	return s.key
+}
+
+// Err returns the error, if any, that stopped the scan.
+func (s *KeyScanner) Err() error {
+	return s.err
+}
+
+func (s *KeyScanner) fetch() {
+	page, cursor, err := s.bucket.bucket.ListKeys(s.cursor)
+	if err != nil {
+		s.err = fmt.Errorf("failed to list keys: %w", err)
+		return
+	}
+	s.page = page
+	s.cursor = cursor
+	s.done = cursor == nil
+}
+
+// DeletePrefix removes every key starting with prefix using
+// `wasi:keyvalue/batch`, returning the number of keys removed.
+// The keys are listed before deleting, so cursors are not invalidated by the
+// removal, keys written meanwhile may be left behind.
+func (b *Bucket) DeletePrefix(prefix string) (int, error) {
+	var keys []string
+	s := b.ScanKeys(prefix)
+	for s.Next() {
+		keys = append(keys, s.Key())
+	}
+	if err := s.Err(); err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for len(keys) > 0 {
+		n := min(len(keys), deleteBatchSize)
+		if err := b.bucket.DeleteMany(keys[:n]); err != nil {
+			return deleted, fmt.Errorf("failed to delete keys with prefix %q: %w", prefix, err)
+		}
+		deleted += n
+		keys = keys[n:]
+	}
+	return deleted, nil
+}
@@ -0,0 +1,81 @@
+//go:build !wasm
+
+package wasikv
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestScanKeys(t *testing.T) {
+	data := map[string][]byte{
+		"user:1": nil, "user:2": nil, "user:3": nil,
+		"group:1": nil, "users": nil, "z": nil,
+	}
+
+	tests := map[string]struct {
+		prefix string
+		want   []string
+	}{
+		"all":       {prefix: "", want: []string{"group:1", "user:1", "user:2", "user:3", "users", "z"}},
+		"prefix":    {prefix: "user:", want: []string{"user:1", "user:2", "user:3"}},
+		"last page": {prefix: "z", want: []string{"z"}},
+		"none":      {prefix: "missing", want: nil},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			fakeBucket(name, data)
+			b, err := Open(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer b.Close()
+
+			var keys []string
+			s := b.ScanKeys(tt.prefix)
+			for s.Next() {
+				keys = append(keys, s.Key())
+			}
+			if err := s.Err(); err != nil {
+				t.Fatal(err)
+			}
+			if s.Next() {
+				t.Error("expected exhausted scanner to stay exhausted")
+			}
+			if !slices.Equal(keys, tt.want) {
+				t.Errorf("expected: %v, got: %v", tt.want, keys)
+			}
+		})
+	}
+}
+
+func TestDeletePrefix(t *testing.T) {
+	data := map[string][]byte{"keep": nil}
+	for i := range deleteBatchSize + 5 {
+		data[fmt.Sprintf("tmp:%03d", i)] = nil
+	}
+	fakeBucket("prefixed", data)
+	b, err := Open("prefixed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+
+	n, err := b.DeletePrefix("tmp:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != deleteBatchSize+5 {
+		t.Errorf("expected: %v, got: %v", deleteBatchSize+5, n)
+	}
+
+	keys, err := b.Keys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"keep"}; !slices.Equal(keys, want) {
+		t.Errorf("expected: %v, got: %v", want, keys)
+	}
+}
@@ -0,0 +1,54 @@
+package wasikv
+
+import (
+	"go.wasmcloud.dev/component/internal/backend"
+	"go.wasmcloud.dev/component/wasmcloud"
+)
+
+// interfaces are the interfaces called by wasikv, routed by link name.
+var interfaces = []string{"wasi:keyvalue/store", "wasi:keyvalue/atomics", "wasi:keyvalue/batch"}
+
+// linkedBucket calls the bucket with the link name it was opened with.
+type linkedBucket struct {
+	link   string
+	bucket backend.Bucket
+}
+
+func (b linkedBucket) Get(key string) ([]byte, bool, error) {
+	wasmcloud.UseLinkName(b.link, interfaces...)
+	return b.bucket.Get(key)
+}
+
+func (b linkedBucket) Set(key string, value []byte) error {
+	wasmcloud.UseLinkName(b.link, interfaces...)
+	return b.bucket.Set(key, value)
+}
+
+func (b linkedBucket) Delete(key string) error {
+	wasmcloud.UseLinkName(b.link, interfaces...)
+	return b.bucket.Delete(key)
+}
+
+func (b linkedBucket) Exists(key string) (bool, error) {
+	wasmcloud.UseLinkName(b.link, interfaces...)
+	return b.bucket.Exists(key)
+}
+
+func (b linkedBucket) ListKeys(cursor *uint64) ([]string, *uint64, error) {
+	wasmcloud.UseLinkName(b.link, interfaces...)
+	return b.bucket.ListKeys(cursor)
+}
+
+func (b linkedBucket) Increment(key string, delta uint64) (uint64, error) {
+	wasmcloud.UseLinkName(b.link, interfaces...)
+	return b.bucket.Increment(key, delta)
+}
+
+func (b linkedBucket) DeleteMany(keys []string) error {
+	wasmcloud.UseLinkName(b.link, interfaces...)
+	return b.bucket.DeleteMany(keys)
+}
+
+func (b linkedBucket) Close() {
+	b.bucket.Close()
+}
@@ -0,0 +1,134 @@
+package wasikv
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.wasmcloud.dev/component/time/wasiclock"
+)
+
+// ErrLockLost is returned by [Mutex.Unlock] when the lease expired and another
+// holder took over.
+var ErrLockLost = errors.New("wasikv: lock lost")
+
+// Mutex is a lease-based lock shared by every component linked to the bucket,
+// e.g. replicas across the lattice.
+//
+// NOTE: `wasi:keyvalue@0.2.0-draft` has no compare-and-swap, the lock is a
+// ticket lock over `wasi:keyvalue/atomics` increments. Waiters take a ticket
+// from the "<key>/ticket" counter and hold the lock once "<key>/serving" reaches
+// it. A holder not releasing within the TTL is skipped by the next ticket in
+// line only, so that the counter moves once per expired lease. Tickets further
+// back skip after a TTL per ticket ahead of them, in case the next ones were
+// abandoned.
+//
+// A lease can expire while the holder still runs, e.g. when paused. The
+// fencing token returned by [Mutex.Lock] increases with every holder, resources
+// guarded by the lock should reject tokens lower than the highest one seen.
+//
+// A Mutex tracks a single lease, goroutines contending for the lock each use
+// their own Mutex.
+type Mutex struct {
+	bucket *Bucket
+	key    string
+	ttl    time.Duration
+
+	// PollInterval is the delay between checks of the serving counter while
+	// waiting, defaults to a tenth of the TTL.
+	PollInterval time.Duration
+
+	token    uint64
+	acquired time.Time
+}
+
+// NewMutex returns a Mutex at key, with leases lasting ttl.
+func NewMutex(bucket *Bucket, key string, ttl time.Duration) *Mutex {
+	return &Mutex{bucket: bucket, key: key, ttl: ttl}
+}
+
+// Lock waits until the lock is held, returning the fencing token of the lease.
+// The lease must be released with [Mutex.Unlock] before the TTL passes.
+func (m *Mutex) Lock(ctx context.Context) (uint64, error) {
+	interval := m.PollInterval
+	if interval <= 0 {
+		interval = max(m.ttl/10, time.Millisecond)
+	}
+
+	for {
+		ticket, err := m.bucket.Incr(m.key+"/ticket", 1)
+		if err != nil {
+			return 0, err
+		}
+
+		ok, err := m.wait(ctx, ticket, interval)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			m.token, m.acquired = ticket, wasiclock.NowFunc()
+			return ticket, nil
+		}
+		// skipped while waiting, take a new ticket
+	}
+}
+
+// wait reports whether the turn of ticket came, or false if it was skipped.
+func (m *Mutex) wait(ctx context.Context, ticket uint64, interval time.Duration) (bool, error) {
+	var (
+		last uint64
+		seen time.Time
+	)
+	for {
+		serving, err := m.bucket.Incr(m.key+"/serving", 0)
+		if err != nil {
+			return false, err
+		}
+		switch {
+		case serving+1 == ticket:
+			return true, nil
+		case serving+1 > ticket:
+			return false, nil
+		}
+
+		now := wasiclock.NowFunc()
+		if seen.IsZero() || serving != last {
+			last, seen = serving, now
+		} else if now.Sub(seen) >= time.Duration(ticket-serving-1)*m.ttl {
+			// the current holder outlived its lease, and no ticket ahead
+			// skipped it
+			if _, err := m.bucket.Incr(m.key+"/serving", 1); err != nil {
+				return false, err
+			}
+			seen = time.Time{}
+			continue
+		}
+
+		timer := wasiclock.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			// NOTE: the ticket stays queued and is skipped once its lease expires
+			return false, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Unlock releases the lease, passing the lock to the next ticket.
+func (m *Mutex) Unlock() error {
+	serving, err := m.bucket.Incr(m.key+"/serving", 0)
+	if err != nil {
+		return err
+	}
+	// NOTE: a holder past its lease may be skipped by the next ticket at any
+	// time, releasing it too would let in two tickets at once
+	if m.token == 0 || serving+1 != m.token || wasiclock.Since(m.acquired) >= m.ttl {
+		m.token = 0
+		return ErrLockLost
+	}
+	m.token = 0
+
+	_, err = m.bucket.Incr(m.key+"/serving", 1)
+	return err
+}
@@ -0,0 +1,180 @@
+//go:build !wasm
+
+package wasikv
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMutex(t *testing.T) {
+	fakeBucket("locks", map[string][]byte{})
+	b, err := Open("locks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+
+	var (
+		wg      sync.WaitGroup
+		holders atomic.Int32
+		tokens  = make(chan uint64, 5)
+	)
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			m := NewMutex(b, "shared", time.Second)
+			m.PollInterval = time.Millisecond
+			token, err := m.Lock(context.Background())
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if n := holders.Add(1); n != 1 {
+				t.Errorf("expected a single holder, got: %v", n)
+			}
+			time.Sleep(2 * time.Millisecond)
+			holders.Add(-1)
+
+			tokens <- token
+			if err := m.Unlock(); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	close(tokens)
+
+	seen := map[uint64]bool{}
+	for token := range tokens {
+		if seen[token] {
+			t.Errorf("expected unique fencing tokens, got %v twice", token)
+		}
+		seen[token] = true
+	}
+}
+
+func TestMutexExpiry(t *testing.T) {
+	fakeBucket("leases", map[string][]byte{})
+	b, err := Open("leases")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+
+	stale := NewMutex(b, "lease", 20*time.Millisecond)
+	first, err := stale.Lock(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewMutex(b, "lease", 20*time.Millisecond)
+	m.PollInterval = time.Millisecond
+	second, err := m.Lock(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second <= first {
+		t.Errorf("expected fencing token above %v, got: %v", first, second)
+	}
+
+	if err := stale.Unlock(); !errors.Is(err, ErrLockLost) {
+		t.Errorf("expected: %v, got: %v", ErrLockLost, err)
+	}
+	if err := m.Unlock(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMutexExpiryRace(t *testing.T) {
+	fakeBucket("race", map[string][]byte{})
+	b, err := Open("race")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+
+	stale := NewMutex(b, "lease", 50*time.Millisecond)
+	first, err := stale.Lock(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// NOTE: the waiters read the stale serving counter before either skips it
+	fakeIncrLatency = 5 * time.Millisecond
+	t.Cleanup(func() { fakeIncrLatency = 0 })
+
+	var (
+		wg      sync.WaitGroup
+		holders atomic.Int32
+		tokens  = make(chan uint64, 3)
+	)
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			m := NewMutex(b, "lease", 50*time.Millisecond)
+			m.PollInterval = time.Millisecond
+			token, err := m.Lock(context.Background())
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if n := holders.Add(1); n != 1 {
+				t.Errorf("expected a single holder, got: %v", n)
+			}
+			time.Sleep(5 * time.Millisecond)
+			holders.Add(-1)
+
+			tokens <- token
+			if err := m.Unlock(); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	close(tokens)
+
+	// the stale lease is skipped once, every waiter keeps its ticket
+	var got []uint64
+	for token := range tokens {
+		got = append(got, token)
+	}
+	slices.Sort(got)
+	if want := []uint64{first + 1, first + 2, first + 3}; !slices.Equal(got, want) {
+		t.Errorf("expected: %v, got: %v", want, got)
+	}
+	if err := stale.Unlock(); !errors.Is(err, ErrLockLost) {
+		t.Errorf("expected: %v, got: %v", ErrLockLost, err)
+	}
+}
+
+func TestMutexCanceled(t *testing.T) {
+	fakeBucket("canceled", map[string][]byte{})
+	b, err := Open("canceled")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+
+	holder := NewMutex(b, "lock", time.Minute)
+	if _, err := holder.Lock(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	m := NewMutex(b, "lock", time.Minute)
+	m.PollInterval = time.Millisecond
+	if _, err := m.Lock(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected: %v, got: %v", context.DeadlineExceeded, err)
+	}
+}
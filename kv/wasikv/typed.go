@@ -0,0 +1,148 @@
+package wasikv
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"go.wasmcloud.dev/component/cbor"
+	"go.wasmcloud.dev/component/singleflight"
+)
+
+// Codec encodes the values of a [Typed] bucket.
+type Codec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+var (
+	// JSON encodes values with encoding/json.
+	JSON Codec = jsonCodec{}
+	// Gob encodes values with encoding/gob.
+	Gob Codec = gobCodec{}
+	// CBOR encodes values with the cbor package, values must implement
+	// cbor.Marshaler and cbor.Unmarshaler, unless they are basic types.
+	CBOR Codec = CodecFuncs{MarshalFunc: cbor.Marshal, UnmarshalFunc: cbor.Unmarshal}
+)
+
+// CodecFuncs adapts a pair of marshal functions to a Codec, e.g. for
+// MessagePack:
+//
+//	wasikv.CodecFuncs{MarshalFunc: msgpack.Marshal, UnmarshalFunc: msgpack.Unmarshal}
+type CodecFuncs struct {
+	MarshalFunc   func(v any) ([]byte, error)
+	UnmarshalFunc func(data []byte, v any) error
+}
+
+func (c CodecFuncs) Marshal(v any) ([]byte, error) {
+	return c.MarshalFunc(v)
+}
+
+func (c CodecFuncs) Unmarshal(data []byte, v any) error {
+	return c.UnmarshalFunc(data, v)
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+type gobCodec struct{}
+
+func (gobCodec) Marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (gobCodec) Unmarshal(data []byte, v any) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+// Typed stores values of type T in a bucket, encoded with a [Codec].
+type Typed[T any] struct {
+	bucket *Bucket
+	codec  Codec
+	loads  singleflight.Group[*T]
+}
+
+// NewTyped returns a Typed view of the bucket, encoding values with codec.
+func NewTyped[T any](bucket *Bucket, codec Codec) *Typed[T] {
+	return &Typed[T]{bucket: bucket, codec: codec}
+}
+
+// Bucket returns the underlying bucket.
+func (t *Typed[T]) Bucket() *Bucket {
+	return t.bucket
+}
+
+// Get decodes the value stored at key, or returns [ErrNotFound].
+func (t *Typed[T]) Get(ctx context.Context, key string) (*T, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	data, err := t.bucket.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	v := new(T)
+	if err := t.codec.Unmarshal(data, v); err != nil {
+		return nil, fmt.Errorf("failed to decode %q: %w", key, err)
+	}
+	return v, nil
+}
+
+// GetOrLoad returns the value stored at key like [Typed.Get]. Missing values
+// are loaded with load and stored, concurrent misses on the same key within
+// the component instance share a single call of load and its value.
+func (t *Typed[T]) GetOrLoad(ctx context.Context, key string, load func(ctx context.Context) (*T, error)) (*T, error) {
+	v, err := t.Get(ctx, key)
+	if !errors.Is(err, ErrNotFound) {
+		return v, err
+	}
+
+	v, err, _ = t.loads.Do(key, func() (*T, error) {
+		v, err := load(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %q: %w", key, err)
+		}
+		if err := t.Set(ctx, key, v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	})
+	return v, err
+}
+
+// Set encodes value and stores it at key.
+func (t *Typed[T]) Set(ctx context.Context, key string, value *T) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	data, err := t.codec.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode %q: %w", key, err)
+	}
+	return t.bucket.Set(key, data)
+}
+
+// Delete removes key.
+func (t *Typed[T]) Delete(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return t.bucket.Delete(key)
+}
@@ -0,0 +1,151 @@
+//go:build !wasm
+
+package wasikv
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"reflect"
+	"testing"
+
+	"go.wasmcloud.dev/component/cbor"
+)
+
+type user struct {
+	Name  string
+	Email string
+	Roles []string
+}
+
+func (u *user) MarshalCBOR(e *cbor.Encoder) error {
+	e.Map(3)
+	e.String("name")
+	e.String(u.Name)
+	e.String("email")
+	e.String(u.Email)
+	e.String("roles")
+	return e.Value(u.Roles)
+}
+
+func (u *user) UnmarshalCBOR(d *cbor.Decoder) error {
+	n, err := d.Map()
+	if err != nil {
+		return err
+	}
+	for range n {
+		k, err := d.String()
+		if err != nil {
+			return err
+		}
+		switch k {
+		case "name":
+			u.Name, err = d.String()
+		case "email":
+			u.Email, err = d.String()
+		case "roles":
+			err = d.Decode(&u.Roles)
+		default:
+			err = d.Skip()
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestTyped(t *testing.T) {
+	tests := map[string]struct {
+		codec Codec
+	}{
+		"json": {codec: JSON},
+		"gob":  {codec: Gob},
+		"cbor": {codec: CBOR},
+		"funcs": {codec: CodecFuncs{
+			MarshalFunc:   xml.Marshal,
+			UnmarshalFunc: xml.Unmarshal,
+		}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			fakeBucket(name, map[string][]byte{"user:invalid": []byte("\x00")})
+			b, err := Open(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer b.Close()
+
+			ctx := context.Background()
+			users := NewTyped[user](b, tt.codec)
+
+			want := &user{Name: "Ferris", Email: "ferris@example.com", Roles: []string{"admin"}}
+			if err := users.Set(ctx, "user:1", want); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := users.Get(ctx, "user:1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("expected: %+v, got: %+v", want, got)
+			}
+
+			if _, err := users.Get(ctx, "user:invalid"); err == nil {
+				t.Error("expected decode error")
+			}
+
+			if err := users.Delete(ctx, "user:1"); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := users.Get(ctx, "user:1"); !errors.Is(err, ErrNotFound) {
+				t.Errorf("expected: %v, got: %v", ErrNotFound, err)
+			}
+
+			canceled, cancel := context.WithCancel(ctx)
+			cancel()
+			if _, err := users.Get(canceled, "user:1"); !errors.Is(err, context.Canceled) {
+				t.Errorf("expected: %v, got: %v", context.Canceled, err)
+			}
+		})
+	}
+}
+
+func TestTypedGetOrLoad(t *testing.T) {
+	fakeBucket("load", map[string][]byte{})
+	b, err := Open("load")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+
+	ctx := context.Background()
+	users := NewTyped[user](b, JSON)
+	loads := 0
+	load := func(ctx context.Context) (*user, error) {
+		loads++
+		return &user{Name: "Ferris"}, nil
+	}
+	for range 2 {
+		got, err := users.GetOrLoad(ctx, "user:1", load)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Name != "Ferris" {
+			t.Errorf("expected: %v, got: %v", "Ferris", got.Name)
+		}
+	}
+	if loads != 1 {
+		t.Errorf("expected: %v, got: %v", 1, loads)
+	}
+
+	failed := errors.New("failed")
+	if _, err := users.GetOrLoad(ctx, "user:2", func(context.Context) (*user, error) { return nil, failed }); !errors.Is(err, failed) {
+		t.Errorf("expected: %v, got: %v", failed, err)
+	}
+	if _, err := users.Get(ctx, "user:2"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected: %v, got: %v", ErrNotFound, err)
+	}
+}
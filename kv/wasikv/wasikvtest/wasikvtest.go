@@ -0,0 +1,61 @@
+//go:build !wasm
+
+package wasikvtest
+
+import (
+	"time"
+
+	"go.wasmcloud.dev/component/internal/fakehost"
+	"go.wasmcloud.dev/component/internal/memory"
+	"go.wasmcloud.dev/component/kv/wasikv"
+	"go.wasmcloud.dev/component/time/wasiclock"
+)
+
+// MemoryBucket is a wasikv.Bucket kept in memory, independent of the host and
+// of a componenttest.Host. Counters are stored as decimal strings.
+type MemoryBucket struct {
+	*wasikv.Bucket
+	mem *memory.Bucket
+}
+
+// NewMemoryBucket returns an empty MemoryBucket.
+func NewMemoryBucket() *MemoryBucket {
+	mem := memory.NewBucket(nil)
+	name := fakehost.Buckets.Name("wasikvtest-")
+	fakehost.Buckets.Set(name, mem)
+	defer fakehost.Buckets.Remove(name)
+
+	b, err := wasikv.Open(name)
+	if err != nil {
+		panic(err)
+	}
+	return &MemoryBucket{Bucket: b, mem: mem}
+}
+
+// SetWithTTL stores value at key, see [MemoryBucket.Expire].
+func (b *MemoryBucket) SetWithTTL(key string, value []byte, ttl time.Duration) error {
+	if err := b.Set(key, value); err != nil {
+		return err
+	}
+	b.Expire(key, ttl)
+	return nil
+}
+
+// Expire deletes key once ttl passed, as a host bucket with a TTL would. Time
+// is read from wasiclock.NowFunc, replace it to expire keys deterministically.
+// Writing the key clears its expiry.
+func (b *MemoryBucket) Expire(key string, ttl time.Duration) {
+	b.mem.Expire(key, wasiclock.NowFunc().Add(ttl))
+}
+
+// InterleaveWrite sets key to value right after its next read, as a writer in
+// another instance racing a read-modify-write would, e.g. to exercise the
+// retries of wasikv.Bucket.LocalUpdate. A nil value deletes the key.
+func (b *MemoryBucket) InterleaveWrite(key string, value []byte) {
+	b.mem.WriteAfterRead(key, value)
+}
+
+// Data returns a copy of the contents of the bucket.
+func (b *MemoryBucket) Data() map[string][]byte {
+	return b.mem.Snapshot()
+}
@@ -0,0 +1,104 @@
+//go:build !wasm
+
+package wasikvtest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.wasmcloud.dev/component/kv/wasikv"
+	"go.wasmcloud.dev/component/time/wasiclock"
+)
+
+func TestMemoryBucket(t *testing.T) {
+	b := NewMemoryBucket()
+	defer b.Close()
+
+	if err := b.Set("a", []byte("1")); err != nil {
+		t.Fatal(err)
+	}
+	if value, err := b.Get("a"); err != nil || string(value) != "1" {
+		t.Errorf("expected: %v, got: %q %v", "1", value, err)
+	}
+	if _, err := b.Get("missing"); !errors.Is(err, wasikv.ErrNotFound) {
+		t.Errorf("expected: %v, got: %v", wasikv.ErrNotFound, err)
+	}
+	if n, err := b.Incr("hits", 3); err != nil || n != 3 {
+		t.Errorf("expected: %v, got: %v %v", 3, n, err)
+	}
+	if got := string(b.Data()["hits"]); got != "3" {
+		t.Errorf("expected: %v, got: %v", "3", got)
+	}
+
+	other := NewMemoryBucket()
+	defer other.Close()
+	if ok, err := other.Exists("a"); err != nil || ok {
+		t.Errorf("expected buckets to be independent, got: %v %v", ok, err)
+	}
+}
+
+func TestMemoryBucketTTL(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	defer func(f func() time.Time) { wasiclock.NowFunc = f }(wasiclock.NowFunc)
+	wasiclock.NowFunc = func() time.Time { return now }
+
+	b := NewMemoryBucket()
+	if err := b.SetWithTTL("session", []byte("x"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.SetWithTTL("rewritten", []byte("x"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Set("rewritten", []byte("y")); err != nil {
+		t.Fatal(err)
+	}
+
+	now = now.Add(59 * time.Second)
+	if ok, _ := b.Exists("session"); !ok {
+		t.Error("expected the key to exist before its TTL")
+	}
+
+	now = now.Add(time.Second)
+	if _, err := b.Get("session"); !errors.Is(err, wasikv.ErrNotFound) {
+		t.Errorf("expected: %v, got: %v", wasikv.ErrNotFound, err)
+	}
+	if ok, _ := b.Exists("rewritten"); !ok {
+		t.Error("expected writing the key to clear its TTL")
+	}
+}
+
+func TestMemoryBucketCAS(t *testing.T) {
+	b := NewMemoryBucket()
+	if err := b.Set("n", []byte("1")); err != nil {
+		t.Fatal(err)
+	}
+
+	calls := 0
+	incr := func(current []byte) ([]byte, error) {
+		calls++
+		return append(current, '+'), nil
+	}
+
+	b.InterleaveWrite("n", []byte("2"))
+	if err := b.LocalUpdate("n", 3, incr); err != nil {
+		t.Fatal(err)
+	}
+	if value, _ := b.Get("n"); string(value) != "2+" || calls != 2 {
+		t.Errorf("expected a retry on top of the interleaved write, got: %q after %d calls", value, calls)
+	}
+
+	b.InterleaveWrite("n", []byte("3"))
+	if err := b.LocalUpdate("n", 1, incr); !errors.Is(err, wasikv.ErrConflict) {
+		t.Errorf("expected: %v, got: %v", wasikv.ErrConflict, err)
+	}
+
+	m := wasikv.NewMutex(b.Bucket, "lock", time.Second)
+	if _, err := m.Lock(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Unlock(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,109 @@
+package wasilog
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"go.wasmcloud.dev/component/internal/requestid"
+	"go.wasmcloud.dev/component/rand/wasirand"
+)
+
+const (
+	// RequestIDHeader carries the request ID, it is reused from incoming
+	// requests and set on responses and outgoing requests.
+	RequestIDHeader = requestid.Header
+
+	// TraceParentHeader is the W3C Trace Context header the trace ID is read from.
+	TraceParentHeader = "Traceparent"
+)
+
+type loggerContextKey struct{}
+
+// NewContext returns a copy of ctx carrying logger.
+func NewContext(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey{}, logger)
+}
+
+// FromContext returns the logger carried by ctx, or [DefaultLogger].
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerContextKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return DefaultLogger
+}
+
+// RequestID returns the request ID set by [RequestLogger] or the
+// middleware.RequestID handler, if any.
+func RequestID(ctx context.Context) string {
+	return requestid.FromContext(ctx)
+}
+
+// RequestLogger returns a handler attaching a child of logger to the request
+// context, retrieved with [FromContext], before passing the request on to next.
+// The child logs the request_id, method, path and, for requests carrying a
+// valid traceparent header, trace_id attributes. The request ID is the one set
+// by the middleware.RequestID handler, taken from the [RequestIDHeader] or
+// generated, and echoed on the response.
+func RequestLogger(logger *slog.Logger, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := requestid.FromContext(r.Context())
+		if id == "" {
+			id = r.Header.Get(RequestIDHeader)
+		}
+		if !requestid.Valid(id) {
+			id = wasirand.UUIDv7()
+		}
+		w.Header().Set(RequestIDHeader, id)
+
+		attrs := []any{
+			slog.String("request_id", id),
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+		}
+		if traceID, ok := parseTraceID(r.Header.Get(TraceParentHeader)); ok {
+			attrs = append(attrs, slog.String("trace_id", traceID))
+		}
+
+		ctx := requestid.NewContext(r.Context(), id)
+		ctx = NewContext(ctx, logger.With(attrs...))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Transport returns a RoundTripper setting the [RequestIDHeader] of outgoing
+// requests made within a [RequestLogger] request before passing them on to
+// next, so the callee logs share the request ID. The wasihttp.Transport sets it
+// already.
+func Transport(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		id := RequestID(r.Context())
+		if id == "" || r.Header.Get(RequestIDHeader) != "" {
+			return next.RoundTrip(r)
+		}
+		r = r.Clone(r.Context())
+		r.Header.Set(RequestIDHeader, id)
+		return next.RoundTrip(r)
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// parseTraceID returns the trace ID of a W3C traceparent header,
+// "version-traceid-parentid-flags".
+func parseTraceID(traceparent string) (string, bool) {
+	parts := strings.Split(strings.TrimSpace(traceparent), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || len(parts[1]) != 32 || len(parts[2]) != 16 {
+		return "", false
+	}
+	traceID := parts[1]
+	if strings.Trim(traceID, "0") == "" || strings.Trim(traceID, "0123456789abcdef") != "" {
+		return "", false
+	}
+	return traceID, true
+}
@@ -0,0 +1,132 @@
+package wasilog
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	_ "unsafe"
+
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+	wallclock "go.wasmcloud.dev/component/gen/wasi/clocks/wall-clock"
+	"go.wasmcloud.dev/component/gen/wasi/logging/logging"
+	"go.wasmcloud.dev/component/internal/requestid"
+)
+
+func TestRequestLogger(t *testing.T) {
+	tests := map[string]struct {
+		header http.Header
+		want   string
+		id     string
+	}{
+		"generated id": {
+			header: http.Header{},
+			want:   `method="GET" path="/orders" test`,
+		},
+		"incoming id": {
+			header: http.Header{RequestIDHeader: {"req-1"}},
+			id:     "req-1",
+			want:   `request_id="req-1" method="GET" path="/orders" test`,
+		},
+		"invalid id": {
+			header: http.Header{RequestIDHeader: {"req 1\x00"}},
+			want:   `method="GET" path="/orders" test`,
+		},
+		"trace": {
+			header: http.Header{
+				RequestIDHeader:   {"req-2"},
+				TraceParentHeader: {"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+			},
+			id:   "req-2",
+			want: `request_id="req-2" method="GET" path="/orders" trace_id="4bf92f3577b34da6a3ce929d0e0e4736" test`,
+		},
+		"invalid trace": {
+			header: http.Header{
+				RequestIDHeader:   {"req-3"},
+				TraceParentHeader: {"00-00000000000000000000000000000000-00f067aa0ba902b7-01"},
+			},
+			id:   "req-3",
+			want: `request_id="req-3" method="GET" path="/orders" test`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got string
+			options := DefaultOptions()
+			options.LoggerFunc = func(_ logging.Level, _ string, msg string) { got = msg }
+
+			var id string
+			handler := RequestLogger(slog.New(options.NewHandler()), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				id = RequestID(r.Context())
+				FromContext(r.Context()).Info("test")
+			}))
+
+			r := httptest.NewRequest(http.MethodGet, "/orders", nil)
+			r.Header = tt.header
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if id == "" || w.Header().Get(RequestIDHeader) != id {
+				t.Errorf("expected response id %q, got: %q", id, w.Header().Get(RequestIDHeader))
+			}
+			if tt.id != "" && id != tt.id {
+				t.Errorf("expected: %v, got: %v", tt.id, id)
+			}
+			if tt.id == "" {
+				// NOTE: generated ids differ per run, only check the remaining attrs
+				got = got[len(`request_id="`+id+`" `):]
+			}
+			if got != tt.want {
+				t.Errorf("expected: %v, got: %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	if FromContext(context.Background()) != DefaultLogger {
+		t.Error("expected DefaultLogger without a logger in the context")
+	}
+	logger := slog.New(DefaultOptions().NewHandler())
+	if FromContext(NewContext(context.Background(), logger)) != logger {
+		t.Error("expected the context logger")
+	}
+}
+
+func TestTransport(t *testing.T) {
+	var got []string
+	transport := Transport(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		got = append(got, r.Header.Get(RequestIDHeader))
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	}))
+
+	ctx := requestid.NewContext(context.Background(), "req-1")
+	for _, r := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "http://a/", nil).WithContext(ctx),
+		httptest.NewRequest(http.MethodGet, "http://a/", nil),
+	} {
+		if _, err := transport.RoundTrip(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if want := []string{"req-1", ""}; len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("expected: %v, got: %v", want, got)
+	}
+}
+
+// stub wasi:clocks/wall-clock
+//
+//go:linkname wasmimport_Now go.wasmcloud.dev/component/gen/wasi/clocks/wall-clock.wasmimport_Now
+func wasmimport_Now(result *wallclock.DateTime) {
+	*result = wallclock.DateTime{Seconds: 1700000000}
+}
+
+// stub wasi:random/random
+//
+//go:linkname wasmimport_GetRandomBytes go.wasmcloud.dev/component/gen/wasi/random/random.wasmimport_GetRandomBytes
+func wasmimport_GetRandomBytes(len0 uint64, result *cm.List[uint8]) {
+	*result = cm.ToList(make([]byte, len0))
+}
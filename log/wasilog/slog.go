@@ -4,18 +4,27 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strings"
 
 	slogcommon "github.com/samber/slog-common"
 	"go.wasmcloud.dev/component/gen/wasi/logging/logging"
 )
 
+// DefaultLogger logs to `wasi:logging`, or to stderr if selected by [OutputEnv]
+// with [ConfigureFromEnv]. Assigning it overrides the selection.
 var DefaultLogger = slog.New(DefaultOptions().NewHandler())
 
 func ContextLogger(wasiContext string) *slog.Logger {
 	return DefaultLogger.With(ContextAttr(wasiContext))
 }
 
+// InstallDefault sets the slog default logger to [ContextLogger], so
+// slog.Info and friends log to `wasi:logging` under wasiContext.
+func InstallDefault(wasiContext string) {
+	slog.SetDefault(ContextLogger(wasiContext))
+}
+
 type contextKey string
 
 func (k contextKey) String() string {
@@ -43,61 +52,90 @@ type WasiLoggingOption struct {
 	ReplaceAttr func(groups []string, a slog.Attr) slog.Attr
 }
 
-type WebassemblyHandler struct {
+// Handler is a slog.Handler writing records to `wasi:logging`.
+type Handler struct {
 	option WasiLoggingOption
 	attrs  []slog.Attr
 	groups []string
 }
 
-var _ slog.Handler = (*WebassemblyHandler)(nil)
+// WebassemblyHandler is the former name of [Handler].
+//
+// Deprecated: use [Handler].
+type WebassemblyHandler = Handler
 
+var _ slog.Handler = (*Handler)(nil)
+
+// wasiLevel maps slog levels to the closest `wasi:logging` level at or below
+// them, levels below debug are trace and levels beyond error are critical.
 func wasiLevel(level slog.Level) logging.Level {
-	switch level {
-	case slog.LevelDebug:
+	switch {
+	case level < slog.LevelDebug:
+		return logging.LevelTrace
+	case level < slog.LevelInfo:
 		return logging.LevelDebug
-	case slog.LevelInfo:
+	case level < slog.LevelWarn:
 		return logging.LevelInfo
-	case slog.LevelWarn:
+	case level < slog.LevelError:
 		return logging.LevelWarn
-	case slog.LevelError:
+	case level < slog.LevelError+4:
 		return logging.LevelError
 	default:
-		return logging.LevelDebug
+		return logging.LevelCritical
+	}
+}
+
+// inlineGroups replaces groups with an empty key by their attributes.
+func inlineGroups(attrs []slog.Attr) []slog.Attr {
+	var output []slog.Attr
+	for _, attr := range attrs {
+		if attr.Value.Kind() != slog.KindGroup {
+			output = append(output, attr)
+			continue
+		}
+		group := inlineGroups(attr.Value.Group())
+		if attr.Key == "" {
+			output = append(output, group...)
+			continue
+		}
+		output = append(output, slog.Attr{Key: attr.Key, Value: slog.GroupValue(group...)})
 	}
+	return output
 }
 
+// unrollGroups flattens group attributes, their keys are prefixed by the
+// group names through ReplaceAttr.
 func unrollGroups(attrs []slog.Attr) []slog.Attr {
 	var output []slog.Attr
-	for i := range attrs {
-		attr := attrs[i]
-		switch attr.Value.Kind() {
-		case slog.KindGroup:
+	for _, attr := range attrs {
+		attr.Value = attr.Value.Resolve()
+		if attr.Value.Kind() == slog.KindGroup {
 			output = append(output, unrollGroups(attr.Value.Group())...)
-		case slog.KindLogValuer:
-			output = append(output, slog.String(attr.Key, attr.Value.LogValuer().LogValue().String()))
-		default:
-			output = append(output, attr)
+			continue
 		}
+		output = append(output, attr)
 	}
 	return output
 }
 
 func wasiConverter(replaceAttr func(groups []string, a slog.Attr) slog.Attr, loggerAttr []slog.Attr, groups []string, record *slog.Record) (string, string) {
-	attrs := slogcommon.AppendRecordAttrsToAttrs(loggerAttr, groups, record)
-	attrs = slogcommon.ReplaceAttrs(replaceAttr, groups, attrs...)
+	// NOTE: record attrs are nested in the handler groups here, ReplaceAttr
+	// sees the groups of each attr without them being passed again
+	attrs := slogcommon.AppendRecordAttrsToAttrs(loggerAttr, slices.Clone(groups), record)
+	attrs = inlineGroups(attrs)
+	attrs = slogcommon.ReplaceAttrs(replaceAttr, nil, attrs...)
 	attrs = slogcommon.RemoveEmptyAttrs(attrs)
 	attrs = unrollGroups(attrs)
 
-	extra := slogcommon.AttrsToString(attrs...)
-	context, ok := extra[string(ContextKey)]
-	if ok {
-		// the context key is moved to the 'Context' field in wasi:logging. remove it from the log message.
-		delete(extra, string(ContextKey))
-	}
-
+	var context string
 	var formattedAttrs []string
-	for k, v := range extra {
-		formattedAttrs = append(formattedAttrs, fmt.Sprintf("%s=%q", k, v))
+	for _, attr := range attrs {
+		if attr.Key == string(ContextKey) {
+			// the context key is moved to the 'Context' field in wasi:logging. remove it from the log message.
+			context = attr.Value.String()
+			continue
+		}
+		formattedAttrs = append(formattedAttrs, fmt.Sprintf("%s=%q", attr.Key, attr.Value.String()))
 	}
 	formattedAttrs = append(formattedAttrs, record.Message)
 
@@ -130,16 +168,16 @@ func DefaultOptions() WasiLoggingOption {
 }
 
 func (o WasiLoggingOption) NewHandler() slog.Handler {
-	return &WebassemblyHandler{
+	return &Handler{
 		option: o,
 	}
 }
 
-func (h *WebassemblyHandler) Enabled(_ context.Context, level slog.Level) bool {
+func (h *Handler) Enabled(_ context.Context, level slog.Level) bool {
 	return level >= h.option.Level.Level()
 }
 
-func (h *WebassemblyHandler) Handle(ctx context.Context, record slog.Record) error {
+func (h *Handler) Handle(ctx context.Context, record slog.Record) error {
 	fromContext := slogcommon.ContextExtractor(ctx, h.option.AttrFromContext)
 	message, logContext := wasiConverter(h.option.ReplaceAttr, append(h.attrs, fromContext...), h.groups, &record)
 
@@ -148,21 +186,21 @@ func (h *WebassemblyHandler) Handle(ctx context.Context, record slog.Record) err
 	return nil
 }
 
-func (h *WebassemblyHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
-	return &WebassemblyHandler{
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &Handler{
 		option: h.option,
 		attrs:  slogcommon.AppendAttrsToGroup(h.groups, h.attrs, attrs...),
 		groups: h.groups,
 	}
 }
 
-func (h *WebassemblyHandler) WithGroup(name string) slog.Handler {
+func (h *Handler) WithGroup(name string) slog.Handler {
 	// https://cs.opensource.google/go/x/exp/+/46b07846:slog/handler.go;l=247
 	if name == "" {
 		return h
 	}
 
-	return &WebassemblyHandler{
+	return &Handler{
 		option: h.option,
 		attrs:  h.attrs,
 		groups: append(h.groups, name),
@@ -4,8 +4,9 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"sync"
 	"testing"
-	_ "unsafe"
+	"unsafe"
 
 	"go.wasmcloud.dev/component/gen/wasi/logging/logging"
 )
@@ -31,6 +32,18 @@ func TestLogLevelMapping(t *testing.T) {
 			wasiLevel: logging.LevelError,
 			slogLevel: slog.LevelError,
 		},
+		"trace": {
+			wasiLevel: logging.LevelTrace,
+			slogLevel: slog.LevelDebug - 4,
+		},
+		"between info and warn": {
+			wasiLevel: logging.LevelInfo,
+			slogLevel: slog.LevelInfo + 2,
+		},
+		"critical": {
+			wasiLevel: logging.LevelCritical,
+			slogLevel: slog.LevelError + 4,
+		},
 	}
 
 	for name, tc := range tt {
@@ -42,6 +55,7 @@ func TestLogLevelMapping(t *testing.T) {
 			}
 			options := DefaultOptions()
 			options.LoggerFunc = output
+			options.Level = slog.LevelDebug - 4
 			logger := slog.New(options.NewHandler())
 			logger.Log(context.TODO(), tc.slogLevel, "test")
 		})
@@ -141,8 +155,83 @@ func TestLogValueMask(t *testing.T) {
 	logger.Info("test", "token", Token("launch-the-nukes-code"))
 }
 
+func TestAttrs(t *testing.T) {
+	tests := map[string]struct {
+		log  func(*slog.Logger)
+		want string
+	}{
+		"ordered": {
+			log:  func(l *slog.Logger) { l.Info("test", "b", 1, "a", 2, "c", "three") },
+			want: `b="1" a="2" c="three" test`,
+		},
+		"group attr": {
+			log: func(l *slog.Logger) {
+				l.Info("test", slog.Group("req", "method", "GET", slog.Group("url", "path", "/")))
+			},
+			want: `req.method="GET" req.url.path="/" test`,
+		},
+		"with group": {
+			log:  func(l *slog.Logger) { l.With("id", 1).WithGroup("db").Info("test", "table", "orders") },
+			want: `id="1" db.table="orders" test`,
+		},
+		"inline group": {
+			log:  func(l *slog.Logger) { l.Info("test", slog.Group("", "a", 1)) },
+			want: `a="1" test`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got string
+			options := DefaultOptions()
+			options.LoggerFunc = func(_ logging.Level, _ string, msg string) { got = msg }
+			tt.log(slog.New(options.NewHandler()))
+			if got != tt.want {
+				t.Errorf("expected: %v, got: %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestInstallDefault(t *testing.T) {
+	defer slog.SetDefault(slog.Default())
+
+	InstallDefault("main")
+	if _, ok := slog.Default().Handler().(*Handler); !ok {
+		t.Errorf("expected: %T, got: %T", &Handler{}, slog.Default().Handler())
+	}
+}
+
 // stub wasi:logging
 //
 //go:linkname wasmimport_Log  go.wasmcloud.dev/component/gen/wasi/logging/logging.wasmimport_Log
 func wasmimport_Log(level0 uint32, context0 *uint8, context1 uint32, message0 *uint8, message1 uint32) {
+	hostLogs.Lock()
+	defer hostLogs.Unlock()
+	hostLogs.entries = append(hostLogs.entries, logEntry{
+		level:   logging.Level(level0),
+		context: unsafe.String(context0, context1),
+		message: unsafe.String(message0, message1),
+	})
+}
+
+type logEntry struct {
+	level   logging.Level
+	context string
+	message string
+}
+
+// hostLogs records the entries logged to the host.
+var hostLogs struct {
+	sync.Mutex
+	entries []logEntry
+}
+
+// takeLogs returns and clears the entries logged to the host.
+func takeLogs() []logEntry {
+	hostLogs.Lock()
+	defer hostLogs.Unlock()
+	entries := hostLogs.entries
+	hostLogs.entries = nil
+	return entries
 }
@@ -0,0 +1,100 @@
+package wasilog
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"log/slog"
+	"runtime/debug"
+	"sync"
+
+	"go.wasmcloud.dev/component/gen/wasi/logging/logging"
+)
+
+// Writer is an io.Writer logging each line written to it to `wasi:logging`,
+// for libraries taking an io.Writer or a *log.Logger to write to.
+//
+// NOTE: os.Stderr cannot be redirected from within a component, output written
+// to it directly keeps going to `wasi:cli/stderr`.
+type Writer struct {
+	mu      sync.Mutex
+	log     wasmLoggerFunc
+	level   logging.Level
+	context string
+	buf     []byte
+}
+
+// NewWriter returns a Writer logging lines at level under wasiContext.
+func NewWriter(wasiContext string, level slog.Level) *Writer {
+	return &Writer{
+		log:     logging.Log,
+		level:   wasiLevel(level),
+		context: wasiContext,
+	}
+}
+
+// Write logs the complete lines in p, a trailing partial line is buffered
+// until completed or flushed.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.emit(w.buf[:i])
+		w.buf = w.buf[i+1:]
+	}
+	// NOTE: reset the buffer once drained, so it does not keep growing
+	if len(w.buf) == 0 {
+		w.buf = nil
+	}
+	return len(p), nil
+}
+
+// Flush logs the buffered partial line, if any.
+func (w *Writer) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.buf) > 0 {
+		w.emit(w.buf)
+		w.buf = nil
+	}
+	return nil
+}
+
+func (w *Writer) emit(line []byte) {
+	line = bytes.TrimSuffix(line, []byte{'\r'})
+	if len(line) == 0 {
+		return
+	}
+	w.log(w.level, w.context, string(line))
+}
+
+// InstallStdLog routes the standard log package output to `wasi:logging` at
+// info level under wasiContext. Timestamps are dropped, the host adds its own.
+//
+// NOTE: [InstallDefault] routes log output through slog already, InstallStdLog
+// is for components not using slog.
+func InstallStdLog(wasiContext string) {
+	log.SetFlags(0)
+	log.SetOutput(NewWriter(wasiContext, slog.LevelInfo))
+}
+
+// LogPanic logs a panic along with its stack trace to `wasi:logging` at
+// critical level under wasiContext before letting it continue, which would
+// otherwise only reach stderr. It must be deferred directly:
+//
+//	defer wasilog.LogPanic("handler")
+func LogPanic(wasiContext string) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	logging.Log(logging.LevelCritical, wasiContext, fmt.Sprintf("panic: %v\n\n%s", r, debug.Stack()))
+	panic(r)
+}
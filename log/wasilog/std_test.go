@@ -0,0 +1,75 @@
+package wasilog
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.wasmcloud.dev/component/gen/wasi/logging/logging"
+)
+
+func TestWriter(t *testing.T) {
+	takeLogs()
+
+	w := NewWriter("lib", slog.LevelWarn)
+	fmt.Fprint(w, "first line\nsecond ")
+	fmt.Fprint(w, "line\r\n\npartial")
+
+	want := []logEntry{
+		{level: logging.LevelWarn, context: "lib", message: "first line"},
+		{level: logging.LevelWarn, context: "lib", message: "second line"},
+	}
+	if got := takeLogs(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected: %v, got: %v", want, got)
+	}
+
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	want = []logEntry{{level: logging.LevelWarn, context: "lib", message: "partial"}}
+	if got := takeLogs(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected: %v, got: %v", want, got)
+	}
+}
+
+func TestInstallStdLog(t *testing.T) {
+	defer func(flags int, w io.Writer) {
+		log.SetFlags(flags)
+		log.SetOutput(w)
+	}(log.Flags(), log.Writer())
+	takeLogs()
+
+	InstallStdLog("stdlib")
+	log.Printf("hello %s", "world")
+
+	want := []logEntry{{level: logging.LevelInfo, context: "stdlib", message: "hello world"}}
+	if got := takeLogs(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected: %v, got: %v", want, got)
+	}
+}
+
+func TestLogPanic(t *testing.T) {
+	takeLogs()
+
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Errorf("expected panic to continue, got: %v", r)
+		}
+		entries := takeLogs()
+		if len(entries) != 1 {
+			t.Fatalf("expected: %v, got: %v", 1, len(entries))
+		}
+		if e := entries[0]; e.level != logging.LevelCritical || e.context != "handler" || !strings.HasPrefix(e.message, "panic: boom\n\ngoroutine") {
+			t.Errorf("unexpected entry: %+v", e)
+		}
+	}()
+
+	func() {
+		defer LogPanic("handler")
+		panic("boom")
+	}()
+}
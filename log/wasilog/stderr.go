@@ -0,0 +1,80 @@
+package wasilog
+
+import (
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"go.wasmcloud.dev/component/cli/wasienv"
+	"go.wasmcloud.dev/component/time/wasiclock"
+)
+
+const (
+	// OutputEnv is the environment variable selecting the output of
+	// [DefaultLogger] with [ConfigureFromEnv], "wasi" for `wasi:logging` (the
+	// default) or "stderr" for [StderrOptions].
+	OutputEnv = "WASILOG_OUTPUT"
+
+	// FormatEnv is the environment variable selecting the stderr format,
+	// "text" (the default) or "json".
+	FormatEnv = "WASILOG_FORMAT"
+)
+
+// StderrOptions configure a slog.Handler writing to stderr, the fallback for
+// hosts not collecting `wasi:logging` output.
+type StderrOptions struct {
+	// JSON selects slog.JSONHandler output over slog.TextHandler.
+	JSON bool
+	// Level is the minimum level logged (default: info).
+	Level slog.Leveler
+	// Writer is the output, defaults to `wasi:cli/stderr`.
+	Writer io.Writer
+}
+
+// NewHandler returns a text or JSON handler, timestamps are read from
+// `wasi:clocks/wall-clock` and written as UTC RFC 3339 like host log lines.
+func (o StderrOptions) NewHandler() slog.Handler {
+	w := o.Writer
+	if w == nil {
+		w = wasienv.Stderr()
+	}
+
+	opts := &slog.HandlerOptions{
+		Level: o.Level,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.String(slog.TimeKey, wasiclock.Now().UTC().Format(time.RFC3339Nano))
+			}
+			return a
+		},
+	}
+	if o.JSON {
+		return slog.NewJSONHandler(w, opts)
+	}
+	return slog.NewTextHandler(w, opts)
+}
+
+// ConfigureFromEnv sets [DefaultLogger] to the output selected by [OutputEnv]
+// and [FormatEnv], typically in an init function.
+//
+// NOTE: the selection is explicit, so that components logging to
+// `wasi:logging` neither read the environment at init nor import
+// `wasi:cli/stderr` and `wasi:clocks/wall-clock`
+func ConfigureFromEnv() {
+	DefaultLogger = slog.New(defaultHandler(wasienv.LookupEnv))
+}
+
+// defaultHandler selects the handler of [DefaultLogger] from [OutputEnv] and [FormatEnv].
+//
+// NOTE: a component linking this package imports `wasi:logging` regardless of
+// the output selected, hosts lacking it need to provide a stub.
+func defaultHandler(lookupEnv func(key string) (string, bool)) slog.Handler {
+	output, _ := lookupEnv(OutputEnv)
+	if !strings.EqualFold(output, "stderr") {
+		return DefaultOptions().NewHandler()
+	}
+
+	format, _ := lookupEnv(FormatEnv)
+	return StderrOptions{JSON: strings.EqualFold(format, "json")}.NewHandler()
+}
@@ -0,0 +1,134 @@
+package wasilog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"go.wasmcloud.dev/component/gen/wasi/io/streams"
+)
+
+func TestStderrHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(StderrOptions{JSON: true, Writer: &buf}.NewHandler())
+	logger.Info("hello", "planet", "Earth")
+
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{"time": "2023-11-14T22:13:20Z", "level": "INFO", "msg": "hello", "planet": "Earth"}
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("%s: expected: %v, got: %v", key, value, got[key])
+		}
+	}
+
+	buf.Reset()
+	logger = slog.New(StderrOptions{Level: slog.LevelWarn, Writer: &buf}.NewHandler())
+	logger.Info("dropped")
+	logger.Warn("kept", "n", 1)
+	if want := "time=2023-11-14T22:13:20Z level=WARN msg=kept n=1\n"; buf.String() != want {
+		t.Errorf("expected: %q, got: %q", want, buf.String())
+	}
+}
+
+func TestDefaultHandler(t *testing.T) {
+	tests := map[string]struct {
+		env  map[string]string
+		want string
+	}{
+		"default": {env: map[string]string{}, want: "*wasilog.Handler"},
+		"wasi":    {env: map[string]string{OutputEnv: "wasi", FormatEnv: "json"}, want: "*wasilog.Handler"},
+		"stderr":  {env: map[string]string{OutputEnv: "stderr"}, want: "*slog.TextHandler"},
+		"json":    {env: map[string]string{OutputEnv: "STDERR", FormatEnv: "json"}, want: "*slog.JSONHandler"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			h := defaultHandler(func(key string) (string, bool) {
+				value, ok := tt.env[key]
+				return value, ok
+			})
+			if got := fmt.Sprintf("%T", h); got != tt.want {
+				t.Errorf("expected: %v, got: %v", tt.want, got)
+			}
+		})
+	}
+
+	// the environment of the stub is empty, selecting wasi:logging
+	prev := DefaultLogger
+	defer func() { DefaultLogger = prev }()
+	ConfigureFromEnv()
+	if got := fmt.Sprintf("%T", DefaultLogger.Handler()); got != "*wasilog.Handler" {
+		t.Errorf("expected: %v, got: %v", "*wasilog.Handler", got)
+	}
+
+	// the stderr handler writes to wasi:cli/stderr by default
+	slog.New(StderrOptions{}.NewHandler()).Info("to stderr")
+	if !strings.Contains(stderrOutput.String(), "msg=\"to stderr\"") {
+		t.Errorf("expected stderr output, got: %q", stderrOutput.String())
+	}
+}
+
+// stderrOutput records writes to the wasi:cli/stderr stream.
+var stderrOutput bytes.Buffer
+
+// stub wasi:cli/environment, empty
+//
+//go:linkname wasmimport_GetEnvironment go.wasmcloud.dev/component/gen/wasi/cli/environment.wasmimport_GetEnvironment
+func wasmimport_GetEnvironment(result *cm.List[[2]string]) {
+	*result = cm.List[[2]string]{}
+}
+
+// stub wasi:cli/stderr
+//
+//go:linkname wasmimport_GetStderr go.wasmcloud.dev/component/gen/wasi/cli/stderr.wasmimport_GetStderr
+func wasmimport_GetStderr() uint32 {
+	return 2
+}
+
+// stub wasi:io/streams, recording stderr writes
+//
+//go:linkname wasmimport_OutputStreamSubscribe go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_OutputStreamSubscribe
+func wasmimport_OutputStreamSubscribe(self0 uint32) uint32 {
+	return 1
+}
+
+//go:linkname wasmimport_OutputStreamCheckWrite go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_OutputStreamCheckWrite
+func wasmimport_OutputStreamCheckWrite(self0 uint32, result *cm.Result[uint64, uint64, streams.StreamError]) {
+	*result = cm.OK[cm.Result[uint64, uint64, streams.StreamError]](4096)
+}
+
+//go:linkname wasmimport_OutputStreamWrite go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_OutputStreamWrite
+func wasmimport_OutputStreamWrite(self0 uint32, contents0 *uint8, contents1 uint32, result *cm.Result[streams.StreamError, struct{}, streams.StreamError]) {
+	stderrOutput.Write(unsafe.Slice(contents0, contents1))
+	*result = cm.OK[cm.Result[streams.StreamError, struct{}, streams.StreamError]](struct{}{})
+}
+
+//go:linkname wasmimport_OutputStreamFlush go.wasmcloud.dev/component/gen/wasi/io/streams.wasmimport_OutputStreamFlush
+func wasmimport_OutputStreamFlush(self0 uint32, result *cm.Result[streams.StreamError, struct{}, streams.StreamError]) {
+	*result = cm.OK[cm.Result[streams.StreamError, struct{}, streams.StreamError]](struct{}{})
+}
+
+// stub wasi:io/error
+//
+//go:linkname wasmimport_ErrorToDebugString go.wasmcloud.dev/component/gen/wasi/io/error.wasmimport_ErrorToDebugString
+func wasmimport_ErrorToDebugString(self0 uint32, result *string) {
+	*result = "stub error"
+}
+
+//go:linkname wasmimport_ErrorResourceDrop go.wasmcloud.dev/component/gen/wasi/io/error.wasmimport_ErrorResourceDrop
+func wasmimport_ErrorResourceDrop(self0 uint32) {}
+
+// stub wasi:io/poll, streams are always ready
+//
+//go:linkname wasmimport_Poll go.wasmcloud.dev/component/gen/wasi/io/poll.wasmimport_Poll
+func wasmimport_Poll(in0 *uint32, in1 uint32, result *cm.List[uint32]) {
+	*result = cm.ToList([]uint32{0})
+}
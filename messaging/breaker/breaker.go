@@ -0,0 +1,40 @@
+package breaker
+
+import (
+	"context"
+
+	"go.wasmcloud.dev/component/messaging"
+	"go.wasmcloud.dev/component/resilience"
+)
+
+// Client publishes and requests through Breaker, failing fast with
+// resilience.ErrOpen while it is open. Timeouts and
+// [messaging.ErrNoResponders] are failures.
+type Client struct {
+	Breaker *resilience.Breaker
+}
+
+// Publish publishes like [messaging.Publish], unless the breaker is open.
+func (c *Client) Publish(ctx context.Context, subject string, data []byte, headers messaging.Header) error {
+	if len(headers) > 0 {
+		return messaging.ErrHeadersUnsupported
+	}
+	done, err := c.Breaker.Allow()
+	if err != nil {
+		return err
+	}
+	err = messaging.Publish(ctx, subject, data, headers)
+	done(err)
+	return err
+}
+
+// Request requests like [messaging.Request], unless the breaker is open.
+func (c *Client) Request(ctx context.Context, subject string, data []byte) (*messaging.Message, error) {
+	done, err := c.Breaker.Allow()
+	if err != nil {
+		return nil, err
+	}
+	msg, err := messaging.Request(ctx, subject, data)
+	done(err)
+	return msg, err
+}
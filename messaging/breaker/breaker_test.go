@@ -0,0 +1,37 @@
+//go:build !wasm
+
+package breaker
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.wasmcloud.dev/component/componenttest"
+	"go.wasmcloud.dev/component/messaging"
+	"go.wasmcloud.dev/component/resilience"
+)
+
+func TestClient(t *testing.T) {
+	h := componenttest.New(t)
+	h.Messaging.HandleRequest("echo", func(_ context.Context, msg *messaging.Message) ([]byte, error) {
+		return msg.Data, nil
+	})
+	c := &Client{Breaker: resilience.NewBreaker("nobody", resilience.BreakerOptions{MinRequests: 2})}
+	ctx := context.Background()
+
+	if err := c.Publish(ctx, "events", nil, messaging.Header{"k": {"v"}}); !errors.Is(err, messaging.ErrHeadersUnsupported) {
+		t.Errorf("expected: %v, got: %v", messaging.ErrHeadersUnsupported, err)
+	}
+	for range 2 {
+		if _, err := c.Request(ctx, "nobody", nil); !errors.Is(err, messaging.ErrNoResponders) {
+			t.Errorf("expected: %v, got: %v", messaging.ErrNoResponders, err)
+		}
+	}
+	if _, err := c.Request(ctx, "echo", nil); !errors.Is(err, resilience.ErrOpen) {
+		t.Errorf("expected: %v, got: %v", resilience.ErrOpen, err)
+	}
+	if err := c.Publish(ctx, "events", nil, nil); !errors.Is(err, resilience.ErrOpen) {
+		t.Errorf("expected: %v, got: %v", resilience.ErrOpen, err)
+	}
+}
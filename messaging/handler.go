@@ -0,0 +1,145 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"go.wasmcloud.dev/component/export"
+	"go.wasmcloud.dev/component/gen/wasmcloud/messaging/handler"
+	"go.wasmcloud.dev/component/gen/wasmcloud/messaging/types"
+	"go.wasmcloud.dev/component/tasks"
+)
+
+// ErrNoReplyTo is returned by [Message.Respond] when the message was not sent
+// as a request.
+var ErrNoReplyTo = errors.New("messaging: message has no reply subject")
+
+// Message is a message received from or sent to a broker.
+type Message struct {
+	Subject string
+	Data    []byte
+	// ReplyTo is the subject responses are published to, empty unless the
+	// message was sent as a request.
+	ReplyTo string
+}
+
+// Respond publishes data to the reply subject of m, with the link selected
+// by wasmcloud.WithLinkName on ctx, typically the context of the handler.
+func (m *Message) Respond(ctx context.Context, data []byte) error {
+	if m.ReplyTo == "" {
+		return ErrNoReplyTo
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return publish(ctx, &Message{Subject: m.ReplyTo, Data: data})
+}
+
+// HandlerFunc handles a message delivered by the `wasmcloud:messaging/handler`
+// export. A returned error is reported to the host.
+type HandlerFunc func(ctx context.Context, msg *Message) error
+
+// RequestHandlerFunc handles a message and returns the response, which is
+// published to the reply subject of requests and dropped otherwise.
+type RequestHandlerFunc func(ctx context.Context, msg *Message) ([]byte, error)
+
+// handle is the function that will be called by the message handler export.
+var handle = defaultHandler
+
+// defaultHandler is a placeholder for returning a useful error to stderr when
+// the handler is not set.
+var defaultHandler = func(context.Context, *Message) error {
+	fmt.Fprintln(os.Stderr, "messaging handler undefined")
+	return errors.New("messaging handler undefined")
+}
+
+// Handle sets the handler function for the messaging trigger.
+// It must be set in an init() function.
+func Handle(h HandlerFunc) {
+	handle = h
+}
+
+// HandleRequest sets a handler function replying to request messages with
+// its result, see [Reply]. It must be set in an init() function.
+func HandleRequest(h RequestHandlerFunc) {
+	handle = Reply(h)
+}
+
+// Reply adapts h to a HandlerFunc publishing its result to the reply subject
+// of requests. Errors are returned as is and no response is sent.
+func Reply(h RequestHandlerFunc) HandlerFunc {
+	return func(ctx context.Context, msg *Message) error {
+		data, err := h(ctx, msg)
+		if err != nil {
+			return err
+		}
+		if msg.ReplyTo == "" {
+			return nil
+		}
+		if err := msg.Respond(ctx, data); err != nil {
+			return fmt.Errorf("failed to respond to %q: %w", msg.ReplyTo, err)
+		}
+		return nil
+	}
+}
+
+func fromBrokerMessage(msg types.BrokerMessage) *Message {
+	m := &Message{
+		Subject: msg.Subject,
+		Data:    msg.Body.Slice(),
+	}
+	if replyTo := msg.ReplyTo.Some(); replyTo != nil {
+		m.ReplyTo = *replyTo
+	}
+	return m
+}
+
+func (m *Message) brokerMessage() types.BrokerMessage {
+	replyTo := cm.None[string]()
+	if m.ReplyTo != "" {
+		replyTo = cm.Some(m.ReplyTo)
+	}
+	return types.BrokerMessage{
+		Subject: m.Subject,
+		Body:    cm.ToList(m.Data),
+		ReplyTo: replyTo,
+	}
+}
+
+// NOTE: broker messages of `wasmcloud:messaging@0.2.0` have no headers, the
+// context carries no wasmcloud.Metadata
+func wasiHandle(msg types.BrokerMessage) cm.Result[string, struct{}, string] {
+	defer shutdown()
+	ctx, queue := tasks.NewContext(context.Background())
+	defer runTasks(ctx, queue)
+	if err := handle(ctx, fromBrokerMessage(msg)); err != nil {
+		return cm.Err[cm.Result[string, struct{}, string]](err.Error())
+	}
+	return cm.OK[cm.Result[string, struct{}, string]](struct{}{})
+}
+
+// runTasks runs the tasks deferred with tasks.Defer while handling a message.
+func runTasks(ctx context.Context, queue *tasks.Queue) {
+	if err := queue.Run(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "deferred tasks failed: %s\n", err)
+	}
+}
+
+// shutdown calls the [export.OnShutdown] hooks once a message was handled.
+func shutdown() {
+	if err := export.Shutdown(context.Background()); err != nil {
+		fmt.Fprintf(os.Stderr, "shutdown hooks failed: %s\n", err)
+	}
+}
+
+// Export is the name of the `wasmcloud:messaging/handler` export registered
+// with the [export] registry.
+const Export = "wasmcloud:messaging/handler@0.2.0#handle-message"
+
+func init() {
+	export.Bind(Export, &handler.Exports.HandleMessage)
+	export.Register(Export, wasiHandle)
+}
@@ -0,0 +1,133 @@
+//go:build !wasm
+
+package messaging
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"go.wasmcloud.dev/component/gen/wasmcloud/messaging/types"
+	"go.wasmcloud.dev/component/internal/fakehost"
+	"go.wasmcloud.dev/component/wasmcloud"
+)
+
+func brokerMessage(subject, body, replyTo string) types.BrokerMessage {
+	msg := types.BrokerMessage{Subject: subject, Body: cm.ToList([]byte(body)), ReplyTo: cm.None[string]()}
+	if replyTo != "" {
+		msg.ReplyTo = cm.Some(replyTo)
+	}
+	return msg
+}
+
+func equalMessage(a, b Message) bool {
+	return a.Subject == b.Subject && string(a.Data) == string(b.Data) && a.ReplyTo == b.ReplyTo
+}
+
+func TestHandle(t *testing.T) {
+	defer Handle(defaultHandler)
+
+	var got *Message
+	Handle(func(ctx context.Context, msg *Message) error {
+		got = msg
+		if string(msg.Data) == "fail" {
+			return errors.New("handler failed")
+		}
+		return nil
+	})
+
+	res := wasiHandle(brokerMessage("orders.created", "order 1", "_INBOX.1"))
+	if res.IsErr() {
+		t.Fatal(*res.Err())
+	}
+	if want := (Message{Subject: "orders.created", Data: []byte("order 1"), ReplyTo: "_INBOX.1"}); !equalMessage(*got, want) {
+		t.Errorf("expected: %v, got: %v", want, *got)
+	}
+
+	res = wasiHandle(brokerMessage("orders.created", "fail", ""))
+	if !res.IsErr() || *res.Err() != "handler failed" {
+		t.Errorf("expected: %v, got: %v", "handler failed", res.Err())
+	}
+}
+
+func TestHandleRequest(t *testing.T) {
+	defer Handle(defaultHandler)
+
+	HandleRequest(func(ctx context.Context, msg *Message) ([]byte, error) {
+		if string(msg.Data) == "fail" {
+			return nil, errors.New("handler failed")
+		}
+		return append([]byte("echo: "), msg.Data...), nil
+	})
+
+	tests := map[string]struct {
+		msg       types.BrokerMessage
+		err       bool
+		published []Message
+	}{
+		"request": {
+			msg:       brokerMessage("echo", "hello", "_INBOX.1"),
+			published: []Message{{Subject: "_INBOX.1", Data: []byte("echo: hello")}},
+		},
+		"no reply subject": {
+			msg: brokerMessage("echo", "hello", ""),
+		},
+		"handler error": {
+			msg: brokerMessage("echo", "fail", "_INBOX.1"),
+			err: true,
+		},
+		"publish error": {
+			msg: brokerMessage("echo", "hello", "fail"),
+			err: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			resetBroker()
+
+			res := wasiHandle(tt.msg)
+			if res.IsErr() != tt.err {
+				t.Errorf("expected error: %v, got: %v", tt.err, res.Err())
+			}
+
+			got := published()
+			if !slices.EqualFunc(got, tt.published, equalMessage) {
+				t.Errorf("expected: %v, got: %v", tt.published, got)
+			}
+		})
+	}
+}
+
+func TestRespond(t *testing.T) {
+	resetBroker()
+
+	msg := &Message{Subject: "ping"}
+	if err := msg.Respond(context.Background(), []byte("pong")); !errors.Is(err, ErrNoReplyTo) {
+		t.Errorf("expected: %v, got: %v", ErrNoReplyTo, err)
+	}
+	if got := published(); len(got) != 0 {
+		t.Errorf("expected nothing published, got: %v", got)
+	}
+
+	msg.ReplyTo = "_INBOX.1"
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := msg.Respond(canceled, []byte("pong")); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected: %v, got: %v", context.Canceled, err)
+	}
+
+	backup := wasmcloud.WithLinkName(context.Background(), "backup-nats")
+	if err := msg.Respond(backup, []byte("pong")); err != nil {
+		t.Fatal(err)
+	}
+	if got := fakehost.LinkName("wasmcloud:messaging/consumer"); got != "backup-nats" {
+		t.Errorf("expected: %v, got: %v", "backup-nats", got)
+	}
+	want := []Message{{Subject: "_INBOX.1", Data: []byte("pong")}}
+	if got := published(); !slices.EqualFunc(got, want, equalMessage) {
+		t.Errorf("expected: %v, got: %v", want, got)
+	}
+}
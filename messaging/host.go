@@ -0,0 +1,40 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"go.wasmcloud.dev/component/gen/wasmcloud/messaging/consumer"
+	"go.wasmcloud.dev/component/internal/backend"
+	"go.wasmcloud.dev/component/wasmcloud"
+)
+
+// consumerHost returns the `wasmcloud:messaging` host, called with the link
+// name selected by wasmcloud.WithLinkName on ctx.
+func consumerHost(ctx context.Context) backend.Messaging {
+	wasmcloud.UseLinkName(wasmcloud.LinkName(ctx), "wasmcloud:messaging/consumer")
+	return hostMessaging{}
+}
+
+type hostMessaging struct{}
+
+func (hostMessaging) Publish(msg backend.Message) error {
+	m := &Message{Subject: msg.Subject, Data: msg.Body, ReplyTo: msg.ReplyTo}
+	if res := consumer.Publish(m.brokerMessage()); res.IsErr() {
+		return errors.New(*res.Err())
+	}
+	return nil
+}
+
+func (hostMessaging) Request(subject string, body []byte, timeout time.Duration) (backend.Message, error) {
+	timeoutMs := uint32(min(max(timeout.Milliseconds(), 1), math.MaxUint32))
+	res := consumer.Request(subject, cm.ToList(body), timeoutMs)
+	if res.IsErr() {
+		return backend.Message{}, requestError(*res.Err())
+	}
+	msg := fromBrokerMessage(*res.OK())
+	return backend.Message{Subject: msg.Subject, Body: msg.Data, ReplyTo: msg.ReplyTo}, nil
+}
@@ -0,0 +1,70 @@
+//go:build !wasm
+
+package messaging
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"go.wasmcloud.dev/component/internal/backend"
+	"go.wasmcloud.dev/component/internal/fakehost"
+)
+
+// broker records the published messages, subject "fail" is rejected.
+var broker struct {
+	sync.Mutex
+	published []Message
+}
+
+func published() []Message {
+	broker.Lock()
+	defer broker.Unlock()
+	return slices.Clone(broker.published)
+}
+
+func resetBroker() {
+	broker.Lock()
+	defer broker.Unlock()
+	broker.published = nil
+}
+
+// lastTimeout is the timeout-ms of the last request.
+var lastTimeout uint32
+
+// fakeBroker serves the broker to the host, requests to "echo" reply with
+// the request body, "nobody" has no responders and "slow" times out.
+type fakeBroker struct{}
+
+func (fakeBroker) Publish(msg backend.Message) error {
+	broker.Lock()
+	defer broker.Unlock()
+
+	if msg.Subject == "fail" {
+		return errors.New("publish rejected")
+	}
+	broker.published = append(broker.published, Message{Subject: msg.Subject, Data: msg.Body, ReplyTo: msg.ReplyTo})
+	return nil
+}
+
+func (fakeBroker) Request(subject string, body []byte, timeout time.Duration) (backend.Message, error) {
+	broker.Lock()
+	defer broker.Unlock()
+
+	lastTimeout = uint32(timeout.Milliseconds())
+	switch subject {
+	case "echo":
+		return backend.Message{Subject: "_INBOX.reply", Body: body}, nil
+	case "nobody":
+		return backend.Message{}, errors.New("no responders")
+	case "slow":
+		return backend.Message{}, errors.New("request timed out")
+	default:
+		return backend.Message{}, errors.New("broker unavailable")
+	}
+}
+
+func init() {
+	fakehost.Messaging = fakeBroker{}
+}
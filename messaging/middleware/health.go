@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"context"
+
+	"go.wasmcloud.dev/component/health"
+	"go.wasmcloud.dev/component/messaging"
+)
+
+// Health returns middleware recording the processed messages in m, e.g.
+// registered with health.Register("messaging", m.Check).
+func Health(m *health.Messages) messaging.Middleware {
+	return func(next messaging.HandlerFunc) messaging.HandlerFunc {
+		return func(ctx context.Context, msg *messaging.Message) error {
+			err := next(ctx, msg)
+			m.Observe(err)
+			return err
+		}
+	}
+}
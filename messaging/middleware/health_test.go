@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.wasmcloud.dev/component/health"
+	"go.wasmcloud.dev/component/messaging"
+)
+
+func TestHealth(t *testing.T) {
+	m := health.NewMessages(0)
+	failing := errors.New("bad payload")
+	h := Health(m)(func(ctx context.Context, msg *messaging.Message) error {
+		if string(msg.Data) == "bad" {
+			return failing
+		}
+		return nil
+	})
+
+	if err := h(context.Background(), &messaging.Message{Data: []byte("bad")}); !errors.Is(err, failing) {
+		t.Errorf("expected: %v, got: %v", failing, err)
+	}
+	if err := m.Check(context.Background()); !errors.Is(err, failing) {
+		t.Errorf("expected: %v, got: %v", failing, err)
+	}
+	if err := h(context.Background(), &messaging.Message{Data: []byte("ok")}); err != nil {
+		t.Errorf("expected: %v, got: %v", nil, err)
+	}
+	if err := m.Check(context.Background()); err != nil {
+		t.Errorf("expected: %v, got: %v", nil, err)
+	}
+	if m.Last().IsZero() {
+		t.Error("expected: last message time, got: zero")
+	}
+}
@@ -0,0 +1,6 @@
+//go:build !wasm
+
+package middleware
+
+// stubs of the host imports
+import _ "go.wasmcloud.dev/component/internal/fakehost"
@@ -0,0 +1,88 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.wasmcloud.dev/component/internal/backend"
+)
+
+// DefaultRequestTimeout bounds [Request] when the context has no deadline.
+const DefaultRequestTimeout = 5 * time.Second
+
+var (
+	// ErrNoResponders is returned by [Request] when no subscriber listens on the subject.
+	ErrNoResponders = errors.New("messaging: no responders")
+	// ErrTimeout is returned by [Request] when no response arrived in time.
+	ErrTimeout = errors.New("messaging: request timed out")
+	// ErrHeadersUnsupported is returned when publishing headers, which
+	// `wasmcloud:messaging@0.2.0` cannot carry.
+	ErrHeadersUnsupported = errors.New("messaging: headers are not supported")
+)
+
+// Header holds message headers, keyed like NATS headers.
+type Header map[string][]string
+
+// Publish sends data to subject without awaiting a response.
+// headers must be empty, see [ErrHeadersUnsupported].
+func Publish(ctx context.Context, subject string, data []byte, headers Header) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if len(headers) > 0 {
+		return ErrHeadersUnsupported
+	}
+	return publish(ctx, &Message{Subject: subject, Data: data})
+}
+
+// Request sends data to subject and waits for the response. The wait is
+// bounded by the context deadline, or [DefaultRequestTimeout] without one.
+//
+// NOTE: the host call blocks, canceling ctx without a deadline does not abort
+// an in-flight request.
+func Request(ctx context.Context, subject string, data []byte) (*Message, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	resp, err := consumerHost(ctx).Request(subject, data, requestTimeout(ctx))
+	if err != nil {
+		if errors.Is(err, ErrTimeout) && ctx.Err() != nil {
+			err = fmt.Errorf("%w: %w", err, ctx.Err())
+		}
+		return nil, fmt.Errorf("failed to request %q: %w", subject, err)
+	}
+	return &Message{Subject: resp.Subject, Data: resp.Body, ReplyTo: resp.ReplyTo}, nil
+}
+
+// requestTimeout converts the context deadline into the host request timeout.
+func requestTimeout(ctx context.Context) time.Duration {
+	if deadline, ok := ctx.Deadline(); ok {
+		return time.Until(deadline)
+	}
+	return DefaultRequestTimeout
+}
+
+// requestError maps the error reported by the host onto the sentinel errors,
+// hosts report request failures as free-form strings.
+func requestError(msg string) error {
+	lower := strings.ToLower(msg)
+	switch {
+	case strings.Contains(lower, "no responders"):
+		return ErrNoResponders
+	case strings.Contains(lower, "timed out"), strings.Contains(lower, "timeout"):
+		return ErrTimeout
+	default:
+		return errors.New(msg)
+	}
+}
+
+func publish(ctx context.Context, msg *Message) error {
+	if err := consumerHost(ctx).Publish(backend.Message{Subject: msg.Subject, Body: msg.Data, ReplyTo: msg.ReplyTo}); err != nil {
+		return fmt.Errorf("failed to publish to %q: %w", msg.Subject, err)
+	}
+	return nil
+}
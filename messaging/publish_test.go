@@ -0,0 +1,105 @@
+//go:build !wasm
+
+package messaging
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestPublish(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := map[string]struct {
+		ctx       context.Context
+		subject   string
+		headers   Header
+		err       error
+		published []Message
+	}{
+		"publish":  {subject: "events", published: []Message{{Subject: "events", Data: []byte("data")}}},
+		"headers":  {subject: "events", headers: Header{"Trace": {"1"}}, err: ErrHeadersUnsupported},
+		"canceled": {ctx: canceled, subject: "events", err: context.Canceled},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			resetBroker()
+
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			if err := Publish(ctx, tt.subject, []byte("data"), tt.headers); !errors.Is(err, tt.err) {
+				t.Errorf("expected: %v, got: %v", tt.err, err)
+			}
+			if got := published(); !slices.EqualFunc(got, tt.published, equalMessage) {
+				t.Errorf("expected: %v, got: %v", tt.published, got)
+			}
+		})
+	}
+
+	if err := Publish(context.Background(), "fail", nil, nil); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestRequest(t *testing.T) {
+	tests := map[string]struct {
+		subject string
+		timeout time.Duration
+		reply   string
+		err     error
+	}{
+		"reply":         {subject: "echo", reply: "ping"},
+		"no responders": {subject: "nobody", err: ErrNoResponders},
+		"timeout":       {subject: "slow", timeout: time.Second, err: ErrTimeout},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+
+			msg, err := Request(ctx, tt.subject, []byte("ping"))
+			if !errors.Is(err, tt.err) {
+				t.Errorf("expected: %v, got: %v", tt.err, err)
+			}
+			if err == nil && string(msg.Data) != tt.reply {
+				t.Errorf("expected: %v, got: %v", tt.reply, string(msg.Data))
+			}
+		})
+	}
+}
+
+func TestRequestTimeout(t *testing.T) {
+	if _, err := Request(context.Background(), "echo", nil); err != nil {
+		t.Fatal(err)
+	}
+	if want := uint32(DefaultRequestTimeout.Milliseconds()); lastTimeout != want {
+		t.Errorf("expected: %v, got: %v", want, lastTimeout)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+	defer cancel()
+	if _, err := Request(ctx, "echo", nil); err != nil {
+		t.Fatal(err)
+	}
+	if lastTimeout == 0 || lastTimeout > 250 {
+		t.Errorf("expected timeout within (0, 250], got: %v", lastTimeout)
+	}
+
+	expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	if _, err := Request(expired, "echo", nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected: %v, got: %v", context.DeadlineExceeded, err)
+	}
+}
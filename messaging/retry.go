@@ -0,0 +1,124 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"go.wasmcloud.dev/component/time/wasiclock"
+)
+
+// RetryOptions configures [Retry].
+type RetryOptions struct {
+	// MaxAttempts is the number of handler invocations per message, including
+	// the first. Defaults to 3.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry. Defaults to 100ms.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between retries. Defaults to 5s.
+	MaxBackoff time.Duration
+	// Multiplier grows the delay after every retry. Defaults to 2.
+	Multiplier float64
+	// DeadLetter, if set, receives messages that failed every attempt or with
+	// a [Permanent] error. The message is acknowledged once it returns nil.
+	DeadLetter func(ctx context.Context, msg *Message, err error) error
+	// Logger defaults to slog.Default().
+	Logger *slog.Logger
+}
+
+// permanentError marks an error that is not worth retrying.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps err so that [Retry] hands the message to the dead letter
+// hook without retrying, e.g. for malformed payloads.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// sleep waits for d or until ctx is done.
+var sleep = func(ctx context.Context, d time.Duration) error {
+	timer := wasiclock.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Retry returns middleware processing messages at least once: a failed
+// handler is retried with exponential backoff, and messages that still fail
+// are passed to the dead letter hook.
+//
+// NOTE: `wasmcloud:messaging@0.2.0` has no explicit ack or nack, a message is
+// acknowledged by returning nil from the handler export. An error is reported
+// to the host, which may redeliver depending on the provider.
+func Retry(opts RetryOptions) Middleware {
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 3
+	}
+	if opts.InitialBackoff <= 0 {
+		opts.InitialBackoff = 100 * time.Millisecond
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = 5 * time.Second
+	}
+	if opts.Multiplier < 1 {
+		opts.Multiplier = 2
+	}
+	if opts.Logger == nil {
+		opts.Logger = slog.Default()
+	}
+
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, msg *Message) error {
+			backoff := opts.InitialBackoff
+
+			var err error
+			for attempt := 1; ; attempt++ {
+				if err = next(ctx, msg); err == nil {
+					return nil
+				}
+
+				var permanent *permanentError
+				if errors.As(err, &permanent) || attempt >= opts.MaxAttempts {
+					break
+				}
+
+				opts.Logger.Warn("retrying message", "subject", msg.Subject, "attempt", attempt, "backoff", backoff, "error", err)
+				if err := sleep(ctx, backoff); err != nil {
+					return err
+				}
+				backoff = min(time.Duration(float64(backoff)*opts.Multiplier), opts.MaxBackoff)
+			}
+
+			if opts.DeadLetter == nil {
+				return err
+			}
+			if dlErr := opts.DeadLetter(ctx, msg, err); dlErr != nil {
+				return errors.Join(err, fmt.Errorf("failed to dead letter message: %w", dlErr))
+			}
+			opts.Logger.Error("dead lettered message", "subject", msg.Subject, "error", err)
+			return nil
+		}
+	}
+}
+
+// DeadLetterTo returns a dead letter hook publishing failed messages to subject.
+func DeadLetterTo(subject string) func(ctx context.Context, msg *Message, err error) error {
+	return func(ctx context.Context, msg *Message, err error) error {
+		return Publish(ctx, subject, msg.Data, nil)
+	}
+}
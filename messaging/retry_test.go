@@ -0,0 +1,142 @@
+//go:build !wasm
+
+package messaging
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestRetry(t *testing.T) {
+	var delays []time.Duration
+	defer func(orig func(context.Context, time.Duration) error) { sleep = orig }(sleep)
+	sleep = func(ctx context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return ctx.Err()
+	}
+
+	errFailed := errors.New("failed")
+
+	tests := map[string]struct {
+		failures   int
+		err        error
+		deadLetter bool
+		attempts   int
+		delays     []time.Duration
+		want       error
+		dead       bool
+	}{
+		"success": {
+			attempts: 1,
+		},
+		"recovers": {
+			failures: 2,
+			err:      errFailed,
+			attempts: 3,
+			delays:   []time.Duration{10 * time.Millisecond, 20 * time.Millisecond},
+		},
+		"exhausted": {
+			failures: 10,
+			err:      errFailed,
+			attempts: 4,
+			delays:   []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 25 * time.Millisecond},
+			want:     errFailed,
+		},
+		"dead letter": {
+			failures:   10,
+			err:        errFailed,
+			deadLetter: true,
+			attempts:   4,
+			delays:     []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 25 * time.Millisecond},
+			dead:       true,
+		},
+		"permanent": {
+			failures:   10,
+			err:        Permanent(errFailed),
+			deadLetter: true,
+			attempts:   1,
+			dead:       true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			delays = nil
+
+			var dead error
+			opts := RetryOptions{
+				MaxAttempts:    4,
+				InitialBackoff: 10 * time.Millisecond,
+				MaxBackoff:     25 * time.Millisecond,
+				Logger:         slog.New(slog.NewTextHandler(io.Discard, nil)),
+			}
+			if tt.deadLetter {
+				opts.DeadLetter = func(ctx context.Context, msg *Message, err error) error {
+					dead = err
+					return nil
+				}
+			}
+
+			attempts := 0
+			h := Retry(opts)(func(context.Context, *Message) error {
+				attempts++
+				if attempts <= tt.failures {
+					return tt.err
+				}
+				return nil
+			})
+
+			if err := h(context.Background(), &Message{Subject: "jobs"}); !errors.Is(err, tt.want) {
+				t.Errorf("expected: %v, got: %v", tt.want, err)
+			}
+			if attempts != tt.attempts {
+				t.Errorf("expected %v attempts, got: %v", tt.attempts, attempts)
+			}
+			if !slices.Equal(delays, tt.delays) {
+				t.Errorf("expected delays: %v, got: %v", tt.delays, delays)
+			}
+			if (dead != nil) != tt.dead || (tt.dead && !errors.Is(dead, errFailed)) {
+				t.Errorf("expected dead letter: %v, got: %v", tt.dead, dead)
+			}
+		})
+	}
+}
+
+func TestRetryCanceled(t *testing.T) {
+	defer func(orig func(context.Context, time.Duration) error) { sleep = orig }(sleep)
+	sleep = func(ctx context.Context, d time.Duration) error { return ctx.Err() }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	h := Retry(RetryOptions{Logger: slog.New(slog.NewTextHandler(io.Discard, nil))})(func(context.Context, *Message) error {
+		cancel()
+		return errors.New("failed")
+	})
+	if err := h(ctx, &Message{Subject: "jobs"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected: %v, got: %v", context.Canceled, err)
+	}
+}
+
+func TestDeadLetterTo(t *testing.T) {
+	resetBroker()
+
+	h := Retry(RetryOptions{
+		MaxAttempts: 1,
+		DeadLetter:  DeadLetterTo("jobs.dead"),
+		Logger:      slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})(func(context.Context, *Message) error {
+		return errors.New("failed")
+	})
+	if err := h(context.Background(), &Message{Subject: "jobs", Data: []byte("job")}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Message{{Subject: "jobs.dead", Data: []byte("job")}}
+	if got := published(); !slices.EqualFunc(got, want, equalMessage) {
+		t.Errorf("expected: %v, got: %v", want, got)
+	}
+}
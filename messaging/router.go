@@ -0,0 +1,178 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ErrNoRoute is returned by [Router.HandleMessage] when no pattern matches the
+// subject and no NotFound handler is set.
+var ErrNoRoute = errors.New("messaging: no route for subject")
+
+// Middleware wraps a HandlerFunc, e.g. to log or recover.
+type Middleware func(next HandlerFunc) HandlerFunc
+
+// Router dispatches messages to handlers by subject, letting a component
+// serve many subscriptions through the single handler export:
+//
+//	r := messaging.NewRouter()
+//	r.Handle("orders.*.created", onCreated)
+//	r.Handle("logs.>", onLog)
+//	messaging.Handle(r.HandleMessage)
+//
+// Patterns are NATS subjects of "."-separated tokens, "*" matches exactly one
+// token and a trailing ">" matches one or more tokens. When several patterns
+// match, the most specific one wins: literal tokens over "*" over ">",
+// compared from the first token.
+type Router struct {
+	// NotFound, if set, handles messages matching no pattern.
+	NotFound HandlerFunc
+
+	mu         sync.RWMutex
+	routes     []route
+	middleware []Middleware
+}
+
+type route struct {
+	pattern []string
+	handler HandlerFunc
+}
+
+// NewRouter returns an empty Router.
+func NewRouter() *Router {
+	return &Router{}
+}
+
+// Handle registers h for subjects matching pattern.
+// It panics if the pattern is invalid or already registered.
+func (r *Router) Handle(pattern string, h HandlerFunc) {
+	tokens, err := parsePattern(pattern)
+	if err != nil {
+		panic(err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.routes {
+		if strings.Join(existing.pattern, ".") == pattern {
+			panic(fmt.Sprintf("messaging: multiple registrations for %q", pattern))
+		}
+	}
+	r.routes = append(r.routes, route{pattern: tokens, handler: h})
+}
+
+// HandleRequest registers h for subjects matching pattern, see [Reply].
+func (r *Router) HandleRequest(pattern string, h RequestHandlerFunc) {
+	r.Handle(pattern, Reply(h))
+}
+
+// Use appends middleware wrapping every handler, including NotFound.
+// The first middleware is the outermost.
+func (r *Router) Use(mw ...Middleware) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.middleware = append(r.middleware, mw...)
+}
+
+// HandleMessage dispatches msg to the handler of the most specific matching
+// pattern, it is a [HandlerFunc].
+func (r *Router) HandleMessage(ctx context.Context, msg *Message) error {
+	r.mu.RLock()
+	h := r.match(msg.Subject)
+	middleware := r.middleware
+	r.mu.RUnlock()
+
+	if h == nil {
+		h = r.NotFound
+	}
+	if h == nil {
+		h = func(context.Context, *Message) error {
+			return fmt.Errorf("%w %q", ErrNoRoute, msg.Subject)
+		}
+	}
+	for i := len(middleware) - 1; i >= 0; i-- {
+		h = middleware[i](h)
+	}
+	return h(ctx, msg)
+}
+
+func (r *Router) match(subject string) HandlerFunc {
+	tokens := strings.Split(subject, ".")
+
+	var best *route
+	for i := range r.routes {
+		candidate := &r.routes[i]
+		if !matchTokens(candidate.pattern, tokens) {
+			continue
+		}
+		if best == nil || moreSpecific(candidate.pattern, best.pattern) {
+			best = candidate
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	return best.handler
+}
+
+// MatchSubject reports whether subject matches the NATS style pattern.
+func MatchSubject(pattern, subject string) bool {
+	tokens, err := parsePattern(pattern)
+	if err != nil {
+		return false
+	}
+	return matchTokens(tokens, strings.Split(subject, "."))
+}
+
+func parsePattern(pattern string) ([]string, error) {
+	tokens := strings.Split(pattern, ".")
+	for i, token := range tokens {
+		switch {
+		case token == "":
+			return nil, fmt.Errorf("messaging: empty token in pattern %q", pattern)
+		case token == ">" && i != len(tokens)-1:
+			return nil, fmt.Errorf("messaging: %q must be the last token in pattern %q", ">", pattern)
+		case token != "*" && token != ">" && strings.ContainsAny(token, "*> \t"):
+			return nil, fmt.Errorf("messaging: invalid token %q in pattern %q", token, pattern)
+		}
+	}
+	return tokens, nil
+}
+
+func matchTokens(pattern, subject []string) bool {
+	for i, token := range pattern {
+		if token == ">" {
+			return len(subject) > i
+		}
+		if i >= len(subject) || (token != "*" && token != subject[i]) {
+			return false
+		}
+	}
+	return len(pattern) == len(subject)
+}
+
+// moreSpecific reports whether pattern a takes precedence over b, both
+// matching the same subject.
+func moreSpecific(a, b []string) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if sa, sb := specificity(a[i]), specificity(b[i]); sa != sb {
+			return sa > sb
+		}
+	}
+	return len(a) > len(b)
+}
+
+func specificity(token string) int {
+	switch token {
+	case ">":
+		return 0
+	case "*":
+		return 1
+	default:
+		return 2
+	}
+}
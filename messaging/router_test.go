@@ -0,0 +1,153 @@
+//go:build !wasm
+
+package messaging
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestRouter(t *testing.T) {
+	var got string
+	handler := func(name string) HandlerFunc {
+		return func(context.Context, *Message) error {
+			got = name
+			return nil
+		}
+	}
+
+	r := NewRouter()
+	r.Handle("orders.created", handler("literal"))
+	r.Handle("orders.*", handler("wildcard"))
+	r.Handle("orders.>", handler("tail"))
+	r.Handle("*.*.created", handler("two wildcards"))
+	r.Handle("events.*.created", handler("events"))
+	r.Handle("logs.>", handler("logs"))
+	r.Handle("metrics.*", handler("metrics"))
+	r.Handle("metrics.>", handler("metrics tail"))
+
+	tests := map[string]struct {
+		subject string
+		want    string
+		err     error
+	}{
+		"literal":             {subject: "orders.created", want: "literal"},
+		"wildcard":            {subject: "orders.deleted", want: "wildcard"},
+		"tail":                {subject: "orders.1.deleted", want: "tail"},
+		"literal over *":      {subject: "events.user.created", want: "events"},
+		"* over >":            {subject: "metrics.cpu", want: "metrics"},
+		"first token decides": {subject: "orders.1.created", want: "tail"},
+		"two wildcards":       {subject: "users.1.created", want: "two wildcards"},
+		"tail deep":           {subject: "logs.app.error.db", want: "logs"},
+		"tail needs a token":  {subject: "logs", err: ErrNoRoute},
+		"no match":            {subject: "traces.span", err: ErrNoRoute},
+		"wildcard one token":  {subject: "events.user.eu.created", err: ErrNoRoute},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got = ""
+			err := r.HandleMessage(context.Background(), &Message{Subject: tt.subject})
+			if !errors.Is(err, tt.err) {
+				t.Errorf("expected: %v, got: %v", tt.err, err)
+			}
+			if got != tt.want {
+				t.Errorf("expected: %v, got: %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRouterMiddleware(t *testing.T) {
+	var calls []string
+	mw := func(name string) Middleware {
+		return func(next HandlerFunc) HandlerFunc {
+			return func(ctx context.Context, msg *Message) error {
+				calls = append(calls, name)
+				return next(ctx, msg)
+			}
+		}
+	}
+
+	r := NewRouter()
+	r.Use(mw("outer"), mw("inner"))
+	r.Handle("a", func(context.Context, *Message) error {
+		calls = append(calls, "handler")
+		return nil
+	})
+	r.NotFound = func(context.Context, *Message) error {
+		calls = append(calls, "not found")
+		return nil
+	}
+
+	for subject, want := range map[string][]string{
+		"a": {"outer", "inner", "handler"},
+		"b": {"outer", "inner", "not found"},
+	} {
+		calls = nil
+		if err := r.HandleMessage(context.Background(), &Message{Subject: subject}); err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(calls, want) {
+			t.Errorf("expected: %v, got: %v", want, calls)
+		}
+	}
+}
+
+func TestRouterHandleRequest(t *testing.T) {
+	resetBroker()
+
+	r := NewRouter()
+	r.HandleRequest("echo.>", func(ctx context.Context, msg *Message) ([]byte, error) {
+		return []byte(msg.Subject), nil
+	})
+	if err := r.HandleMessage(context.Background(), &Message{Subject: "echo.hi", ReplyTo: "_INBOX.2"}); err != nil {
+		t.Fatal(err)
+	}
+	want := []Message{{Subject: "_INBOX.2", Data: []byte("echo.hi")}}
+	if got := published(); !slices.EqualFunc(got, want, equalMessage) {
+		t.Errorf("expected: %v, got: %v", want, got)
+	}
+}
+
+func TestRouterInvalidPattern(t *testing.T) {
+	for _, pattern := range []string{"", "a..b", "a.>.b", "a.b*", "dup"} {
+		t.Run(pattern, func(t *testing.T) {
+			r := NewRouter()
+			r.Handle("dup", func(context.Context, *Message) error { return nil })
+
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected %q to panic", pattern)
+				}
+			}()
+			r.Handle(pattern, func(context.Context, *Message) error { return nil })
+		})
+	}
+}
+
+func TestMatchSubject(t *testing.T) {
+	tests := map[string]struct {
+		pattern, subject string
+		want             bool
+	}{
+		"literal":          {pattern: "a.b", subject: "a.b", want: true},
+		"literal mismatch": {pattern: "a.b", subject: "a.c"},
+		"star":             {pattern: "a.*.c", subject: "a.b.c", want: true},
+		"star too long":    {pattern: "a.*", subject: "a.b.c"},
+		"tail":             {pattern: "a.>", subject: "a.b.c", want: true},
+		"tail empty":       {pattern: "a.>", subject: "a"},
+		"only tail":        {pattern: ">", subject: "a", want: true},
+		"invalid":          {pattern: "a.>.c", subject: "a.b.c"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := MatchSubject(tt.pattern, tt.subject); got != tt.want {
+				t.Errorf("expected: %v, got: %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -1,17 +1,29 @@
 package wasihttp
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"os"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
+	"unsafe"
 
 	"github.com/bytecodealliance/wasm-tools-go/cm"
 	"go.wasmcloud.dev/component/gen/wasi/http/types"
 	"go.wasmcloud.dev/component/gen/wasi/io/streams"
 )
 
-var _ http.ResponseWriter = (*responseOutparamWriter)(nil)
+var (
+	_ http.ResponseWriter = (*responseOutparamWriter)(nil)
+	_ io.ReaderFrom       = (*responseOutparamWriter)(nil)
+	_ http.Flusher        = (*responseOutparamWriter)(nil)
+)
 
 type IncomingRequest = types.IncomingRequest
 
@@ -27,6 +39,20 @@ type responseOutparamWriter struct {
 	headerErr  error
 
 	statuscode int
+	// contentLength is the Content-Length set by the handler, or -1, of
+	// which written bytes were written
+	contentLength int64
+	written       int64
+
+	// head suppresses the body of the response to a HEAD request, the
+	// headers are sent on Close with the Content-Length of the discarded body
+	head bool
+	// wroteHeader is set once the status code was set, or the headers sent
+	wroteHeader bool
+	discarded   int64
+
+	writes chunkWriter
+	hooks  []ResponseHooks
 }
 
 func (row *responseOutparamWriter) Header() http.Header {
@@ -34,55 +60,228 @@ func (row *responseOutparamWriter) Header() http.Header {
 }
 
 func (row *responseOutparamWriter) Write(buf []byte) (int, error) {
+	if row.head {
+		if !row.wroteHeader {
+			row.wroteHeader = true
+			row.writeHeaderHooks()
+		}
+		row.discarded += int64(len(buf))
+		return len(buf), nil
+	}
+
 	// NOTE(lxf): If this is the first write, make sure we set the headers/statuscode
 	row.headerOnce.Do(row.reconcile)
 	if row.headerErr != nil {
 		return 0, row.headerErr
 	}
 
-	contents := cm.ToList(buf)
-	writeResult := row.stream.Write(contents)
-	if writeResult.IsErr() {
-		if writeResult.Err().Closed() {
-			return 0, io.EOF
-		}
+	if len(buf) == 0 {
+		return 0, nil
+	}
+	if !bodyAllowed(row.statuscode) {
+		return 0, http.ErrBodyNotAllowed
+	}
+	// NOTE: like net/http, a write overrunning the Content-Length writes
+	// nothing, and leaves the remaining bytes to be written
+	if row.contentLength >= 0 && row.written+int64(len(buf)) > row.contentLength {
+		return 0, http.ErrContentLength
+	}
 
-		return 0, fmt.Errorf("failed to write to response body's stream: %s", writeResult.Err().LastOperationFailed().ToDebugString())
+	n, err := row.writes.write(*row.stream, buf)
+	row.written += int64(n)
+	row.writeHooks(int64(n))
+	return n, err
+}
+
+// spliceSize is the maximum number of bytes moved per splice call.
+const spliceSize = 1 << 20
+
+// ReadFrom implements io.ReaderFrom, so io.Copy of an incoming body, e.g. the
+// request body, is spliced on the host without passing through the component.
+func (row *responseOutparamWriter) ReadFrom(src io.Reader) (int64, error) {
+	r, ok := src.(*inputStreamReader)
+	if !ok || row.head {
+		return io.Copy(struct{ io.Writer }{row}, src)
 	}
 
-	row.stream.BlockingFlush()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.acquire(); err != nil {
+		return 0, err
+	}
+
+	row.headerOnce.Do(row.reconcile)
+	if row.headerErr != nil {
+		return 0, row.headerErr
+	}
+	if r.finished {
+		return 0, r.trailerErr
+	}
+	if !bodyAllowed(row.statuscode) {
+		return 0, http.ErrBodyNotAllowed
+	}
 
-	return int(contents.Len()), nil
+	var n int64
+	for {
+		size := uint64(spliceSize)
+		if row.contentLength >= 0 {
+			if row.written == row.contentLength {
+				return n, row.overrun(r)
+			}
+			size = min(size, uint64(row.contentLength-row.written))
+		}
+		res := row.stream.BlockingSplice(r.stream, size)
+		if res.IsErr() {
+			if res.Err().Closed() {
+				// NOTE: splice cannot tell which side closed, the reader is assumed to be drained
+				return n, r.finish()
+			}
+			return n, fmt.Errorf("failed to splice to response body's stream: %s", res.Err().LastOperationFailed().ToDebugString())
+		}
+		n += int64(*res.OK())
+		row.written += int64(*res.OK())
+		row.writeHooks(int64(*res.OK()))
+	}
 }
 
+// overrun reads a byte of r once the Content-Length was spliced, it returns
+// http.ErrContentLength unless r was drained. It must be called with r.mu
+// held.
+func (row *responseOutparamWriter) overrun(r *inputStreamReader) error {
+	res := r.stream.BlockingRead(1)
+	if res.IsErr() {
+		if res.Err().Closed() {
+			return r.finish()
+		}
+		return fmt.Errorf("failed to read from InputStream %s", res.Err().LastOperationFailed().ToDebugString())
+	}
+	if res.OK().Len() > 0 {
+		return http.ErrContentLength
+	}
+	return nil
+}
+
+// WriteHeader sets the status code of the response, like with net/http it
+// panics for codes outside of 100-999 and is ignored, with a warning, once the
+// headers were sent. Informational codes other than 101 are ignored, as
+// `wasi:http` has no interim responses.
 func (row *responseOutparamWriter) WriteHeader(statusCode int) {
+	if statusCode < 100 || statusCode > 999 {
+		panic(fmt.Sprintf("invalid WriteHeader code %v", statusCode))
+	}
+	if row.wroteHeader {
+		caller := "unknown"
+		if _, file, line, ok := runtime.Caller(1); ok {
+			caller = file + ":" + strconv.Itoa(line)
+		}
+		fmt.Fprintf(os.Stderr, "http: superfluous response.WriteHeader call from %s\n", caller)
+		return
+	}
+	if statusCode < 200 && statusCode != http.StatusSwitchingProtocols {
+		return
+	}
+	if row.head {
+		row.statuscode, row.wroteHeader = statusCode, true
+		row.writeHeaderHooks()
+		return
+	}
 	row.headerOnce.Do(func() {
 		row.statuscode = statusCode
 		row.reconcile()
 	})
 }
 
-// reconcile headers from go to wasi
-func (row *responseOutparamWriter) reconcileHeaders() error {
-	for key, vals := range row.httpHeaders {
-		fieldVals := []types.FieldValue{}
-		for _, val := range vals {
-			fieldVals = append(fieldVals, types.FieldValue(cm.ToList([]uint8(val))))
+// bodyAllowed reports whether a response with status code may have a body.
+func bodyAllowed(status int) bool {
+	if status >= 100 && status <= 199 {
+		return false
+	}
+	return status != http.StatusNoContent && status != http.StatusNotModified
+}
+
+// Flush implements http.Flusher, sending the response headers if they were not
+// sent yet and flushing the written body, e.g. after each message of a
+// streaming RPC.
+func (row *responseOutparamWriter) Flush() {
+	row.FlushError()
+}
+
+// FlushError flushes like Flush and returns the error of the headers or of the
+// body stream, it is called by http.ResponseController.
+func (row *responseOutparamWriter) FlushError() error {
+	if row.head {
+		return nil
+	}
+	row.headerOnce.Do(row.reconcile)
+	if row.headerErr != nil {
+		return row.headerErr
+	}
+	if res := row.stream.BlockingFlush(); res.IsErr() {
+		if res.Err().Closed() {
+			return io.EOF
 		}
+		return fmt.Errorf("failed to flush response body's stream: %s", res.Err().LastOperationFailed().ToDebugString())
+	}
+	return nil
+}
 
-		if result := row.wasiHeaders.Set(types.FieldKey(key), cm.ToList(fieldVals)); result.IsErr() {
-			return fmt.Errorf("failed to set header %s: %s", key, result.Err())
+// reconcile headers from go to wasi
+func (row *responseOutparamWriter) reconcileHeaders() error {
+	header, trailers := http.Header{}, http.Header{}
+	for k, v := range row.httpHeaders {
+		// NOTE: like net/http, keys prefixed with http.TrailerPrefix are
+		// trailers set before the headers were sent
+		if strings.HasPrefix(k, http.TrailerPrefix) {
+			trailers[k] = v
+			continue
 		}
+		header[k] = v
+	}
+	if err := toWasiHeader(header, row.wasiHeaders); err != nil {
+		return err
 	}
 
 	// NOTE(lxf): once headers are written we clear them out so they can emit http trailers
-	row.httpHeaders = http.Header{}
+	row.httpHeaders = trailers
 
 	return nil
 }
 
+// trailers returns the trailers set by the handler, without their
+// http.TrailerPrefix. Declared trailers without values are not sent.
+func (row *responseOutparamWriter) trailers() http.Header {
+	trailers := http.Header{}
+	for k, v := range row.httpHeaders {
+		if len(v) == 0 {
+			continue
+		}
+		if name, ok := strings.CutPrefix(k, http.TrailerPrefix); ok {
+			k = http.CanonicalHeaderKey(name)
+		}
+		trailers[k] = append(trailers[k], v...)
+	}
+	return trailers
+}
+
 func (row *responseOutparamWriter) reconcile() {
+	// NOTE: the hooks of a response to HEAD were called when its status code
+	// was set
+	if !row.wroteHeader {
+		row.wroteHeader = true
+		row.writeHeaderHooks()
+	}
+	row.contentLength = -1
+	if v := row.httpHeaders.Get("Content-Length"); v != "" {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil && n >= 0 {
+			row.contentLength = n
+		}
+	}
 	if row.headerErr = row.reconcileHeaders(); row.headerErr != nil {
+		// NOTE: the host responds with an error instead of waiting for a
+		// response that is never set
+		msg := row.headerErr.Error()
+		row.wasiHeaders.ResourceDrop()
+		types.ResponseOutparamSet(row.outparam, cm.Err[cm.Result[types.ErrorCodeShape, types.OutgoingResponse, types.ErrorCode]](types.ErrorCodeInternalError(cm.Some(msg))))
 		return
 	}
 
@@ -108,25 +307,26 @@ func (row *responseOutparamWriter) reconcile() {
 }
 
 func (row *responseOutparamWriter) Close() error {
+	if row.head && row.discarded > 0 && row.httpHeaders.Get("Content-Length") == "" {
+		row.httpHeaders.Set("Content-Length", strconv.FormatInt(row.discarded, 10))
+	}
+	// NOTE: handlers writing nothing still send the response headers
+	row.headerOnce.Do(row.reconcile)
+	if row.headerErr != nil {
+		return row.headerErr
+	}
+
 	row.stream.BlockingFlush()
 	row.stream.ResourceDrop()
 
-	var maybeTrailers cm.Option[types.Fields]
-	wasiTrailers := types.NewFields()
-	for key, vals := range row.httpHeaders {
-		fieldVals := []types.FieldValue{}
-		for _, val := range vals {
-			fieldVals = append(fieldVals, types.FieldValue(cm.ToList([]uint8(val))))
-		}
-
-		if result := wasiTrailers.Set(types.FieldKey(key), cm.ToList(fieldVals)); result.IsErr() {
-			return fmt.Errorf("failed to set trailer %s: %s", key, result.Err())
+	maybeTrailers := cm.None[types.Fields]()
+	if trailers := row.trailers(); len(trailers) > 0 {
+		wasiTrailers := types.NewFields()
+		if err := toWasiHeader(trailers, wasiTrailers); err != nil {
+			wasiTrailers.ResourceDrop()
+			return fmt.Errorf("failed to set trailers: %w", err)
 		}
-	}
-	if len(row.httpHeaders) > 0 {
 		maybeTrailers = cm.Some(wasiTrailers)
-	} else {
-		maybeTrailers = cm.None[types.Fields]()
 	}
 
 	res := types.OutgoingBodyFinish(*row.body, maybeTrailers)
@@ -148,10 +348,11 @@ func NewHttpResponseWriter(out types.ResponseOutparam) *responseOutparamWriter {
 	return row
 }
 
-// convert the IncomingRequest to http.Request
+// convert the IncomingRequest to http.Request, closing the body of the request
+// drops ir
 func NewHttpRequest(ir IncomingRequest) (req *http.Request, err error) {
 	method, err := methodToString(ir.Method())
-	if err != nil {
+	if err != nil && (MethodValidation != MethodToken || !validHeaderName(method)) {
 		return nil, err
 	}
 
@@ -165,27 +366,75 @@ func NewHttpRequest(ir IncomingRequest) (req *http.Request, err error) {
 		pathWithQuery = *p.Some()
 	}
 
-	body, trailers, err := NewIncomingBodyTrailer(ir)
+	u, err := requestURL(authority, pathWithQuery)
 	if err != nil {
-		return nil, fmt.Errorf("failed to consume incoming request %s", err)
+		return nil, err
 	}
 
-	url := fmt.Sprintf("http://%s%s", authority, pathWithQuery)
-	req, err = http.NewRequest(method, url, body)
+	header := http.Header{}
+	// NOTE: the headers are a child of the request, dropped before it
+	fields := ir.Headers()
+	toHttpHeader(fields, &header)
+	fields.ResourceDrop()
+
+	// NOTE: created last, the body owns ir once returned
+	body, trailers := newIncomingBody(ir, ir.ResourceDrop)
+	// NOTE: like net/http, the announced trailers are keys without values
+	// until the body was read
+	declareTrailers(header, trailers)
+	req, err = http.NewRequest(method, "", body)
 	if err != nil {
+		body.Close()
 		return nil, err
 	}
+	req.URL = u
+	req.Header = header
 	req.Trailer = trailers
-
-	toHttpHeader(ir.Headers(), &req.Header)
+	req.ContentLength = contentLength(header)
 
 	req.Host = authority
-	req.URL.Host = authority
 	req.RequestURI = pathWithQuery
 
 	return req, nil
 }
 
+// declareTrailers adds the trailers announced by the Trailer field of header
+// to trailers, without values.
+func declareTrailers(header, trailers http.Header) {
+	for _, v := range header.Values("Trailer") {
+		for _, name := range strings.Split(v, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				trailers[http.CanonicalHeaderKey(name)] = nil
+			}
+		}
+	}
+}
+
+// contentLength returns the Content-Length of header, or -1 if the length of
+// the body is unknown, e.g. chunked.
+func contentLength(header http.Header) int64 {
+	n, err := strconv.ParseInt(header.Get("Content-Length"), 10, 64)
+	if err != nil || n < 0 {
+		return -1
+	}
+	return n
+}
+
+// requestURL reconstructs the URL of an incoming request from the authority
+// and the origin-form request target reported by the host.
+func requestURL(authority, pathWithQuery string) (*url.URL, error) {
+	u, err := url.ParseRequestURI(pathWithQuery)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request target %q: %w", pathWithQuery, err)
+	}
+	if u.Scheme != "" || u.Host != "" {
+		return nil, fmt.Errorf("invalid request target %q: not a path", pathWithQuery)
+	}
+	u.Scheme = "http"
+	u.Host = authority
+	return u, nil
+}
+
 func methodToString(m types.Method) (string, error) {
 	if m.Connect() {
 		return "CONNECT", nil
@@ -211,58 +460,338 @@ func methodToString(m types.Method) (string, error) {
 	return "", fmt.Errorf("failed to convert http method")
 }
 
+// toHttpHeader adds the fields of src to dest.
+//
+// NOTE: http.Request.Header is a map read directly by handlers, middlewares
+// and the methods of http.Request, so the fields are converted before the
+// handler is called, for all of them to see the header. Like net/textproto,
+// the values share a single string and the value slices a single array, which
+// takes two allocations rather than two per field.
 func toHttpHeader(src types.Fields, dest *http.Header) {
-	for _, f := range src.Entries().Slice() {
-		key := string(f.F0)
-		value := string(cm.List[uint8](f.F1).Slice())
-		dest.Add(key, value)
+	entries := src.Entries().Slice()
+	if len(entries) == 0 {
+		return
+	}
+
+	size := 0
+	for _, f := range entries {
+		size += int(f.F1.Len())
+	}
+	var b strings.Builder
+	b.Grow(size)
+	for _, f := range entries {
+		b.Write(cm.List[uint8](f.F1).Slice())
+	}
+	data := b.String()
+
+	h := *dest
+	strs := make([]string, len(entries))
+	off := 0
+	for i, f := range entries {
+		key, ok := canonicalKeys[string(f.F0)]
+		if !ok {
+			key = http.CanonicalHeaderKey(string(f.F0))
+		}
+		n := int(f.F1.Len())
+		strs[i] = data[off : off+n]
+		off += n
+
+		if vv, ok := h[key]; ok {
+			h[key] = append(vv, strs[i])
+		} else {
+			// NOTE: capped, so that appending to one field does not
+			// overwrite the next
+			h[key] = strs[i : i+1 : i+1]
+		}
 	}
 }
 
 // convert the IncomingRequest to http.Request
+//
+// The authority is req.Host, or the host of req.URL if empty, like with
+// net/http. The Host field of req.Header is ignored.
 func NewOutgoingHttpRequest(req *http.Request) (types.OutgoingRequest, error) {
+	return newOutgoingRequest(req, "")
+}
+
+// newOutgoingRequest is [NewOutgoingHttpRequest] with the authority of the
+// request, if set, overriding the one of req.
+func newOutgoingRequest(req *http.Request, authority string) (types.OutgoingRequest, error) {
+	if authority == "" {
+		authority = requestAuthority(req)
+	}
+	if authority == "" {
+		return cm.ResourceNone, errors.New("http: no Host in request URL")
+	}
+
+	header := req.Header
+	if _, ok := header["Host"]; ok {
+		header = header.Clone()
+		delete(header, "Host")
+	}
+	// NOTE: like net/http, a known length is sent as Content-Length, the host
+	// chunks bodies of unknown length
+	if n, ok := outgoingContentLength(req); ok && header.Get("Content-Length") == "" {
+		header = header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+		header.Set("Content-Length", strconv.FormatInt(n, 10))
+	}
+
 	headers := types.NewFields()
-	if err := toWasiHeader(req.Header, headers); err != nil {
+	if err := toWasiHeader(header, headers); err != nil {
 		return types.NewOutgoingRequest(headers), err
 	}
 
 	or := types.NewOutgoingRequest(headers)
 
-	or.SetAuthority(cm.Some(req.Host))
+	or.SetAuthority(cm.Some(authority))
 	or.SetMethod(toWasiMethod(req.Method))
-	or.SetPathWithQuery(cm.Some(req.URL.Path + "?" + req.URL.Query().Encode()))
+	or.SetPathWithQuery(cm.Some(req.URL.RequestURI()))
+	or.SetScheme(cm.Some(toWasiScheme(req.URL.Scheme)))
 
-	switch req.URL.Scheme {
+	return or, nil
+}
+
+// requestAuthority returns the authority of an outgoing request, req.Host or
+// the host of req.URL, without the zone of an IPv6 literal, e.g.
+// "[fe80::1]:8080" for "[fe80::1%en0]:8080".
+func requestAuthority(req *http.Request) string {
+	host := req.Host
+	if host == "" && req.URL != nil {
+		host = req.URL.Host
+	}
+	// NOTE: like net/http, the zone only scopes the address of the client
+	if strings.HasPrefix(host, "[") {
+		if i, j := strings.LastIndex(host, "%"), strings.LastIndex(host, "]"); i > 0 && i < j {
+			host = host[:i] + host[j:]
+		}
+	}
+	return host
+}
+
+// outgoingContentLength returns the length of the body of req, if known. A
+// zero ContentLength with a body is unknown, without a body it is only sent
+// for the methods expecting one.
+func outgoingContentLength(req *http.Request) (int64, bool) {
+	switch {
+	case req.ContentLength > 0:
+		return req.ContentLength, true
+	case req.ContentLength < 0 || req.Body != nil && req.Body != http.NoBody:
+		return 0, false
+	}
+	switch req.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		return 0, true
+	}
+	return 0, false
+}
+
+func toWasiScheme(s string) types.Scheme {
+	switch s {
 	case "http":
-		or.SetScheme(cm.Some(types.SchemeHTTP()))
+		return types.SchemeHTTP()
 	case "https":
-		or.SetScheme(cm.Some(types.SchemeHTTPS()))
+		return types.SchemeHTTPS()
 	default:
-		or.SetScheme(cm.Some(types.SchemeOther(req.URL.Scheme)))
+		return types.SchemeOther(s)
 	}
+}
 
-	return or, nil
+// MethodMode selects which methods of incoming requests are passed to the
+// handler.
+type MethodMode int
+
+const (
+	// MethodStrict only passes the methods of RFC 9110 and PATCH, requests of
+	// other methods are dropped.
+	MethodStrict MethodMode = iota
+	// MethodToken also passes extension methods which are RFC 9110 tokens,
+	// e.g. the PROPFIND and REPORT methods of WebDAV and CalDAV.
+	MethodToken
+)
+
+// MethodValidation is the mode of the methods of incoming requests. Defaults to
+// [MethodStrict].
+var MethodValidation = MethodStrict
+
+// HeaderMode selects how header fields that are not RFC 9110 fields are
+// converted to `wasi:http` fields.
+type HeaderMode int
+
+const (
+	// HeaderStrict fails the conversion, the response is replaced with an
+	// internal error and the request of the Transport is not sent.
+	HeaderStrict HeaderMode = iota
+	// HeaderLenient drops the fields with an invalid name or rejected by the
+	// host, e.g. hop-by-hop fields, and replaces the control characters of
+	// the values with spaces.
+	HeaderLenient
+)
+
+// HeaderValidation is the mode of the headers and trailers of the responses of
+// the handler and the requests of the Transport. Defaults to [HeaderStrict].
+var HeaderValidation = HeaderStrict
+
+// commonHeaders are the canonical names of common fields, whose lowercase
+// field names are converted once.
+var commonHeaders = []string{
+	"Accept", "Accept-Encoding", "Accept-Language", "Accept-Ranges",
+	"Access-Control-Allow-Origin", "Age", "Allow", "Authorization",
+	"Cache-Control", "Connection", "Content-Disposition", "Content-Encoding",
+	"Content-Language", "Content-Length", "Content-Location", "Content-Range",
+	"Content-Security-Policy", "Content-Type", "Cookie", "Date", "Etag",
+	"Expires", "Forwarded", "Grpc-Message", "Grpc-Status", "Host",
+	"If-Match", "If-Modified-Since", "If-None-Match", "If-Range",
+	"If-Unmodified-Since", "Last-Modified", "Link", "Location", "Origin",
+	"Range", "Referer", "Retry-After", "Server", "Set-Cookie",
+	"Strict-Transport-Security", "Te", "Trailer", "Traceparent", "Tracestate",
+	"Transfer-Encoding", "Upgrade", "User-Agent", "Vary", "Via",
+	"Www-Authenticate", "X-Content-Type-Options", "X-Forwarded-For",
+	"X-Forwarded-Host", "X-Forwarded-Proto", "X-Request-Id",
+}
+
+// fieldKeys maps the canonical names of commonHeaders to field names, and
+// canonicalKeys the field names back.
+var fieldKeys, canonicalKeys = func() (map[string]types.FieldKey, map[string]string) {
+	fieldKeys := make(map[string]types.FieldKey, len(commonHeaders))
+	canonicalKeys := make(map[string]string, len(commonHeaders))
+	for _, k := range commonHeaders {
+		name := strings.ToLower(k)
+		fieldKeys[k] = types.FieldKey(name)
+		canonicalKeys[name] = k
+	}
+	return fieldKeys, canonicalKeys
+}()
+
+// fieldKey returns the lowercase field name of the header key k.
+func fieldKey(k string) types.FieldKey {
+	if name, ok := fieldKeys[k]; ok {
+		return name
+	}
+	return types.FieldKey(strings.ToLower(k))
+}
+
+// headerKey is a header key of src in toWasiHeader and its field name.
+type headerKey struct {
+	key  string
+	name types.FieldKey
+}
+
+// headerBuffers are the scratch slices of toWasiHeader, reused across calls.
+type headerBuffers struct {
+	keys   []headerKey
+	values []types.FieldValue
 }
 
+var headerBufferPool = sync.Pool{New: func() any { return new(headerBuffers) }}
+
+// toWasiHeader sets the fields of src on dest. Names differing only in case
+// are merged, as field names are case-insensitive.
 func toWasiHeader(src http.Header, dest types.Fields) error {
-	for k, v := range src {
-		key := types.FieldKey(k)
-		fieldVals := []types.FieldValue{}
+	lenient := HeaderValidation == HeaderLenient
+	buf := headerBufferPool.Get().(*headerBuffers)
+	defer func() {
+		// NOTE: the references to src are dropped before reuse
+		clear(buf.keys)
+		clear(buf.values)
+		buf.keys, buf.values = buf.keys[:0], buf.values[:0]
+		headerBufferPool.Put(buf)
+	}()
+
+	for k := range src {
+		if !validHeaderName(k) {
+			if lenient {
+				continue
+			}
+			return fmt.Errorf("invalid header name %q", k)
+		}
+		buf.keys = append(buf.keys, headerKey{key: k, name: fieldKey(k)})
+	}
+	// NOTE: sorted by field name, keeping the keys merged into a field
+	// adjacent, then by key, for the merged values to keep a stable order
+	slices.SortFunc(buf.keys, func(a, b headerKey) int {
+		if c := strings.Compare(string(a.name), string(b.name)); c != 0 {
+			return c
+		}
+		return strings.Compare(a.key, b.key)
+	})
 
-		for _, val := range v {
-			fieldVals = append(fieldVals, types.FieldValue(cm.ToList([]uint8(val))))
+	for i := 0; i < len(buf.keys); {
+		name := buf.keys[i].name
+		values := buf.values[:0]
+		for ; i < len(buf.keys) && buf.keys[i].name == name; i++ {
+			k := buf.keys[i].key
+			for _, val := range src[k] {
+				if !validHeaderValue(val) {
+					if !lenient {
+						return fmt.Errorf("invalid value for header %s", k)
+					}
+					val = sanitizeHeaderValue(val)
+				}
+				// NOTE: the host copies the values during the call, the
+				// bytes of val are never written
+				values = append(values, types.FieldValue(cm.NewList(unsafe.StringData(val), uint(len(val)))))
+			}
 		}
+		buf.values = values
 
-		// TODO(rjindal): check error
-		res := dest.Set(key, cm.ToList(fieldVals))
+		res := dest.Set(name, cm.ToList(values))
 		if res.IsErr() {
-			return fmt.Errorf("failed to set header %s: %s", k, res.Err())
+			// NOTE: copied, so that res does not escape to the heap
+			setErr := *res.Err()
+			if lenient && setErr != types.HeaderErrorImmutable {
+				continue
+			}
+			return fmt.Errorf("failed to set header %s: %s", name, &setErr)
 		}
 	}
 
 	return nil
 }
 
+// validHeaderName reports whether name is an RFC 9110 token, like field names
+// and methods.
+func validHeaderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		case strings.IndexByte("!#$%&'*+-.^_`|~", c) >= 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// validHeaderValue reports whether value is an RFC 9110 field value, i.e.
+// free of control characters other than horizontal tab.
+func validHeaderValue(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if c := value[i]; c < ' ' && c != '\t' || c == 0x7f {
+			return false
+		}
+	}
+	return true
+}
+
+// sanitizeHeaderValue replaces the characters of value not allowed in a field
+// value with spaces.
+func sanitizeHeaderValue(value string) string {
+	b := []byte(value)
+	for i, c := range b {
+		if c < ' ' && c != '\t' || c == 0x7f {
+			b[i] = ' '
+		}
+	}
+	return string(b)
+}
+
 func toWasiMethod(s string) types.Method {
 	switch s {
 	case http.MethodConnect:
@@ -0,0 +1,106 @@
+package wasihttp
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"unsafe"
+
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"go.wasmcloud.dev/component/gen/wasi/http/types"
+)
+
+func TestToHttpHeader(t *testing.T) {
+	src := http.Header{
+		"Accept":     {"text/html"},
+		"Set-Cookie": {"a=1", "b=2"},
+		"X-Custom":   {""},
+		"x-lower":    {"1"},
+	}
+	fields := types.NewFields()
+	defer fields.ResourceDrop()
+	if err := toWasiHeader(src, fields); err != nil {
+		t.Fatal(err)
+	}
+
+	got := http.Header{"Accept": {"*/*"}}
+	toHttpHeader(fields, &got)
+	want := http.Header{
+		"Accept":     {"*/*", "text/html"},
+		"Set-Cookie": {"a=1", "b=2"},
+		"X-Custom":   {""},
+		"X-Lower":    {"1"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected: %v, got: %v", want, got)
+	}
+
+	// NOTE: the values share an array, appending must not overwrite others
+	got.Add("X-Custom", "appended")
+	if !reflect.DeepEqual(got["X-Lower"], []string{"1"}) || !reflect.DeepEqual(got["X-Custom"], []string{"", "appended"}) {
+		t.Errorf("unexpected header after append: %v", got)
+	}
+}
+
+func TestToWasiHeaderAllocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("the race detector allocates")
+	}
+	// NOTE: common fields only, the names of others are lowercased per call
+	header := http.Header{
+		"Accept":          {"application/json"},
+		"Content-Type":    {"application/json"},
+		"Traceparent":     {"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+		"X-Forwarded-For": {"10.0.0.1", "10.0.0.2"},
+	}
+	fields := types.NewFields()
+	defer fields.ResourceDrop()
+
+	// the same calls of the host, whose allocations are not the adapter's
+	names := make([]types.FieldKey, 0, len(header))
+	values := make([][]types.FieldValue, 0, len(header))
+	for k, vv := range header {
+		names = append(names, fieldKey(k))
+		list := make([]types.FieldValue, len(vv))
+		for i, v := range vv {
+			list[i] = types.FieldValue(cm.ToList([]byte(v)))
+		}
+		values = append(values, list)
+	}
+	host := testing.AllocsPerRun(100, func() {
+		for i, name := range names {
+			fields.Set(name, cm.ToList(values[i]))
+		}
+	})
+
+	got := testing.AllocsPerRun(100, func() {
+		if err := toWasiHeader(header, fields); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if got != host {
+		t.Errorf("expected: %v allocations of the host only, got: %v", host, got)
+	}
+}
+
+func TestToWasiHeaderAliasing(t *testing.T) {
+	buf := []byte("text/plain")
+	// NOTE: the value shares the bytes of buf, like the values passed to the
+	// host by toWasiHeader
+	header := http.Header{"Content-Type": {unsafe.String(&buf[0], len(buf))}}
+	fields := types.NewFields()
+	defer fields.ResourceDrop()
+	if err := toWasiHeader(header, fields); err != nil {
+		t.Fatal(err)
+	}
+	if got := string(buf); got != "text/plain" {
+		t.Fatalf("expected the value to be left untouched, got: %q", got)
+	}
+
+	copy(buf, "xxxxxxxxxx")
+	got := http.Header{}
+	toHttpHeader(fields, &got)
+	if want := "text/plain"; got.Get("Content-Type") != want {
+		t.Errorf("expected the host to copy the value: %q, got: %q", want, got.Get("Content-Type"))
+	}
+}
@@ -0,0 +1,157 @@
+//go:build !wasm
+
+package wasihttp
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.wasmcloud.dev/component/componenttest"
+	"go.wasmcloud.dev/component/gen/wasi/http/types"
+	"go.wasmcloud.dev/component/net/wasihttp/wasihttptest"
+)
+
+func BenchmarkResponseWriter(b *testing.B) {
+	benchmarks := map[string]struct {
+		writes int
+		size   int
+	}{
+		"small": {writes: 256, size: 64},
+		"large": {writes: 1, size: 1 << 20},
+	}
+
+	for name, bb := range benchmarks {
+		b.Run(name, func(b *testing.B) {
+			buf := bytes.Repeat([]byte("a"), bb.size)
+			b.SetBytes(int64(bb.writes * bb.size))
+			b.ReportAllocs()
+
+			for range b.N {
+				out, _ := wasihttptest.NewResponseOutparam()
+				w := NewHttpResponseWriter(out)
+				for range bb.writes {
+					if _, err := w.Write(buf); err != nil {
+						b.Fatal(err)
+					}
+				}
+				if err := w.Close(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkResponseWriterCopy(b *testing.B) {
+	body := bytes.Repeat([]byte("a"), 1<<20)
+
+	benchmarks := map[string]func(w *responseOutparamWriter, r io.Reader) (int64, error){
+		"splice": func(w *responseOutparamWriter, r io.Reader) (int64, error) {
+			return io.Copy(w, r)
+		},
+		// NOTE: hiding ReadFrom copies the body through the component
+		"read write": func(w *responseOutparamWriter, r io.Reader) (int64, error) {
+			return io.Copy(struct{ io.Writer }{w}, r)
+		},
+	}
+
+	for name, copyBody := range benchmarks {
+		b.Run(name, func(b *testing.B) {
+			b.SetBytes(int64(len(body)))
+			b.ReportAllocs()
+
+			for range b.N {
+				b.StopTimer()
+				ir := wasihttptest.NewIncomingRequest(httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body)))
+				out, _ := wasihttptest.NewResponseOutparam()
+				b.StartTimer()
+
+				req, err := NewHttpRequest(ir)
+				if err != nil {
+					b.Fatal(err)
+				}
+				w := NewHttpResponseWriter(out)
+				if _, err := copyBody(w, req.Body); err != nil {
+					b.Fatal(err)
+				}
+				if err := w.Close(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkTransport(b *testing.B) {
+	host := componenttest.New(b)
+	host.HTTP = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(w, r.Body)
+	})
+
+	benchmarks := map[string]string{
+		"empty": "",
+		"body":  strings.Repeat("a", 4<<10),
+	}
+
+	for name, body := range benchmarks {
+		b.Run(name, func(b *testing.B) {
+			b.SetBytes(int64(len(body)))
+			b.ReportAllocs()
+
+			for range b.N {
+				req, err := http.NewRequest(http.MethodPost, "http://example.com/echo", strings.NewReader(body))
+				if err != nil {
+					b.Fatal(err)
+				}
+				resp, err := DefaultTransport.RoundTrip(req)
+				if err != nil {
+					b.Fatal(err)
+				}
+				io.Copy(io.Discard, resp.Body)
+				resp.Body.Close()
+			}
+		})
+	}
+}
+
+func BenchmarkHeader(b *testing.B) {
+	header := http.Header{
+		"Accept":          {"application/json"},
+		"Accept-Encoding": {"gzip, deflate"},
+		"Authorization":   {"Bearer " + strings.Repeat("t", 128)},
+		"Content-Type":    {"application/json"},
+		"Traceparent":     {"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+		"User-Agent":      {"wasihttp-bench/1.0"},
+		"X-Forwarded-For": {"10.0.0.1", "10.0.0.2"},
+	}
+
+	b.Run("to wasi", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			fields := types.NewFields()
+			if err := toWasiHeader(header, fields); err != nil {
+				b.Fatal(err)
+			}
+			fields.ResourceDrop()
+		}
+	})
+
+	b.Run("to http", func(b *testing.B) {
+		fields := types.NewFields()
+		defer fields.ResourceDrop()
+		if err := toWasiHeader(header, fields); err != nil {
+			b.Fatal(err)
+		}
+
+		b.ReportAllocs()
+		b.ResetTimer()
+		for range b.N {
+			dest := http.Header{}
+			toHttpHeader(fields, &dest)
+		}
+	})
+}
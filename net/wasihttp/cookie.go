@@ -0,0 +1,42 @@
+package wasihttp
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// SetCookie adds a Set-Cookie header for c to the response, unlike
+// http.SetCookie it returns an error for an invalid cookie instead of dropping
+// it. Cookies without a path are scoped to "/", and SameSite defaults to Lax.
+//
+// NOTE: the host or a proxy in front of it usually terminates TLS, the scheme
+// of the request does not tell whether the client uses https. Secure is always
+// set, cookies meant for plain http must be set with http.SetCookie
+func SetCookie(w http.ResponseWriter, c *http.Cookie) error {
+	cookie := *c
+	if cookie.Path == "" {
+		cookie.Path = "/"
+	}
+	if cookie.SameSite == 0 {
+		cookie.SameSite = http.SameSiteLaxMode
+	}
+	cookie.Secure = true
+	if err := cookie.Valid(); err != nil {
+		return fmt.Errorf("invalid cookie %q: %w", c.Name, err)
+	}
+	// NOTE: added, the Set-Cookie fields of a response are never merged
+	w.Header().Add("Set-Cookie", cookie.String())
+	return nil
+}
+
+// ReadCookies returns the cookies of r named name, in the order sent by the
+// client across all Cookie headers, or all cookies if name is empty.
+func ReadCookies(r *http.Request, name string) []*http.Cookie {
+	var cookies []*http.Cookie
+	for _, c := range r.Cookies() {
+		if name == "" || c.Name == name {
+			cookies = append(cookies, c)
+		}
+	}
+	return cookies
+}
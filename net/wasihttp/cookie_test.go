@@ -0,0 +1,62 @@
+package wasihttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSetCookie(t *testing.T) {
+	tests := map[string]struct {
+		cookie *http.Cookie
+		want   string
+		err    bool
+	}{
+		"defaults": {
+			cookie: &http.Cookie{Name: "a", Value: "1"},
+			want:   "a=1; Path=/; Secure; SameSite=Lax",
+		},
+		"attributes": {
+			cookie: &http.Cookie{Name: "a", Value: "1", Path: "/app", HttpOnly: true, SameSite: http.SameSiteStrictMode, MaxAge: 60},
+			want:   "a=1; Path=/app; Max-Age=60; HttpOnly; Secure; SameSite=Strict",
+		},
+		"same-site none": {
+			cookie: &http.Cookie{Name: "a", Value: "1", SameSite: http.SameSiteNoneMode},
+			want:   "a=1; Path=/; Secure; SameSite=None",
+		},
+		"invalid name": {
+			cookie: &http.Cookie{Name: "a b", Value: "1"},
+			err:    true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			err := SetCookie(rec, tt.cookie)
+			if (err != nil) != tt.err {
+				t.Fatalf("expected error: %v, got: %v", tt.err, err)
+			}
+			if got := rec.Header().Get("Set-Cookie"); got != tt.want {
+				t.Errorf("expected: %q, got: %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestReadCookies(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Add("Cookie", "a=1; b=2")
+	r.Header.Add("Cookie", "a=3")
+
+	var got []string
+	for _, c := range ReadCookies(r, "a") {
+		got = append(got, c.Value)
+	}
+	if len(got) != 2 || got[0] != "1" || got[1] != "3" {
+		t.Errorf("expected: %v, got: %v", []string{"1", "3"}, got)
+	}
+	if n := len(ReadCookies(r, "")); n != 3 {
+		t.Errorf("expected: %v, got: %v", 3, n)
+	}
+}
@@ -0,0 +1,258 @@
+package wasihttp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.wasmcloud.dev/component/time/wasiclock"
+)
+
+// ErrEventSourceClosed is returned by [EventSource.Next] once the source was
+// closed, or the server answered with 204 No Content to stop the stream.
+var ErrEventSourceClosed = errors.New("wasihttp: event source closed")
+
+// Event is an event of a text/event-stream.
+type Event struct {
+	// ID is the last event ID of the stream when the event was dispatched.
+	ID string
+	// Type is the event type, "message" unless set by the stream.
+	Type string
+	// Data are the data lines of the event, joined with "\n".
+	Data string
+}
+
+// EventSource consumes a text/event-stream, reading the events from the
+// response body as they arrive. The stream is reconnected after Retry when it
+// ends or fails, sending the last event ID as Last-Event-ID.
+type EventSource struct {
+	// Header is sent with each request of the stream.
+	Header http.Header
+	// Retry is the delay before reconnecting, it defaults to 3 seconds and is
+	// set by the retry field of the stream.
+	Retry time.Duration
+
+	client *http.Client
+	url    string
+	lastID string
+	closed bool
+
+	body io.ReadCloser
+	r    *bufio.Reader
+	// start is set until the first line of a connection was read, which may
+	// begin with a byte order mark
+	start bool
+	// skipLF is set after a CR, the LF of a CRLF is read with the next line
+	skipLF bool
+}
+
+// NewEventSource returns an EventSource of url, requested with client,
+// DefaultClient if nil. It connects with the first call to Next.
+func NewEventSource(client *http.Client, url string) *EventSource {
+	if client == nil {
+		client = DefaultClient
+	}
+	return &EventSource{
+		Header: http.Header{},
+		Retry:  3 * time.Second,
+		client: client,
+		url:    url,
+	}
+}
+
+// LastEventID returns the last event ID set by the stream.
+func (es *EventSource) LastEventID() string {
+	return es.lastID
+}
+
+// Next returns the next event of the stream, connecting or reconnecting
+// first if needed. A connection is requested with the context of the call
+// to Next making it and lasts as long as it. Failed connections are retried
+// until ctx is done. Responses other than 200 OK with a text/event-stream
+// body are not retried.
+func (es *EventSource) Next(ctx context.Context) (Event, error) {
+	for {
+		if es.closed {
+			return Event{}, ErrEventSourceClosed
+		}
+		if es.r == nil {
+			retry, err := es.connect(ctx)
+			if err != nil && !retry {
+				return Event{}, err
+			}
+			if err != nil {
+				if err := es.wait(ctx); err != nil {
+					return Event{}, err
+				}
+				continue
+			}
+		}
+
+		ev, err := es.read()
+		if err == nil {
+			return ev, nil
+		}
+		es.disconnect()
+		if err := es.wait(ctx); err != nil {
+			return Event{}, err
+		}
+	}
+}
+
+// Close closes the stream, Next returns ErrEventSourceClosed from now on.
+func (es *EventSource) Close() error {
+	es.closed = true
+	if es.body == nil {
+		return nil
+	}
+	err := es.body.Close()
+	es.body, es.r = nil, nil
+	return err
+}
+
+// connect requests the stream, it reports whether a failure is retried.
+func (es *EventSource) connect(ctx context.Context) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, es.url, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+	for k, v := range es.Header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Cache-Control", "no-cache")
+	if es.lastID != "" {
+		req.Header.Set("Last-Event-ID", es.lastID)
+	}
+
+	resp, err := es.client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	if resp.StatusCode == http.StatusNoContent {
+		resp.Body.Close()
+		es.closed = true
+		return false, ErrEventSourceClosed
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if resp.StatusCode != http.StatusOK || mediaType != "text/event-stream" {
+		resp.Body.Close()
+		es.closed = true
+		return false, fmt.Errorf("wasihttp: unexpected event stream response %s %q", resp.Status, resp.Header.Get("Content-Type"))
+	}
+
+	es.body, es.r, es.start, es.skipLF = resp.Body, bufio.NewReader(resp.Body), true, false
+	return false, nil
+}
+
+func (es *EventSource) disconnect() {
+	es.body.Close()
+	es.body, es.r = nil, nil
+}
+
+// wait waits for Retry, or until ctx is done.
+func (es *EventSource) wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if es.Retry <= 0 {
+		return nil
+	}
+	timer := wasiclock.NewTimer(es.Retry)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// read reads the lines of the stream until an event is dispatched. An event
+// cut by the end of the stream is discarded.
+func (es *EventSource) read() (Event, error) {
+	var typ string
+	var data strings.Builder
+	for {
+		line, err := es.readLine()
+		if err != nil {
+			return Event{}, err
+		}
+
+		if len(line) == 0 {
+			if data.Len() == 0 {
+				typ = ""
+				continue
+			}
+			if typ == "" {
+				typ = "message"
+			}
+			return Event{ID: es.lastID, Type: typ, Data: strings.TrimSuffix(data.String(), "\n")}, nil
+		}
+
+		field, value, found := bytes.Cut(line, []byte(":"))
+		if found && len(field) == 0 {
+			// NOTE: comments keep the connection alive
+			continue
+		}
+		value, _ = bytes.CutPrefix(value, []byte(" "))
+		switch string(field) {
+		case "event":
+			typ = string(value)
+		case "data":
+			data.Write(value)
+			data.WriteByte('\n')
+		case "id":
+			if bytes.IndexByte(value, 0) < 0 {
+				es.lastID = string(value)
+			}
+		case "retry":
+			if ms, err := strconv.ParseUint(string(value), 10, 32); err == nil {
+				es.Retry = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+}
+
+// readLine reads a line ended by CRLF, LF or CR, without its end.
+func (es *EventSource) readLine() ([]byte, error) {
+	if es.start {
+		es.start = false
+		if r, _, err := es.r.ReadRune(); err == nil && r != '\ufeff' {
+			es.r.UnreadRune()
+		}
+	}
+
+	var line []byte
+	for {
+		c, err := es.r.ReadByte()
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+		skipLF := es.skipLF
+		es.skipLF = false
+		switch {
+		case c == '\n' && skipLF:
+			continue
+		case c == '\n':
+			return line, nil
+		case c == '\r':
+			// NOTE: not peeking at the next byte, which may not be sent yet
+			es.skipLF = true
+			return line, nil
+		}
+		line = append(line, c)
+	}
+}
@@ -0,0 +1,114 @@
+package wasihttp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func eventStream(body io.Reader) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"text/event-stream; charset=utf-8"}},
+		Body:       io.NopCloser(body),
+	}
+}
+
+func TestEventSource(t *testing.T) {
+	var lastIDs []string
+	client := &http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		if r.Header.Get("Accept") != "text/event-stream" {
+			t.Errorf("unexpected Accept: %v", r.Header.Get("Accept"))
+		}
+		lastIDs = append(lastIDs, r.Header.Get("Last-Event-ID"))
+		switch len(lastIDs) {
+		case 1:
+			return eventStream(strings.NewReader("\ufeff: keep-alive\nretry: 0\n\n" +
+				"data: hello\ndata:  world\n\n" +
+				"event: greeting\r\nid: 1\r\ndata\r\n\r\n" +
+				"id: 2\rdata: cr\r\r" +
+				"data: cut")), nil
+		case 2:
+			return nil, errors.New("connection refused")
+		case 3:
+			return eventStream(strings.NewReader("event: ignored\n\ndata: resumed\n\n")), nil
+		default:
+			return &http.Response{StatusCode: http.StatusNoContent, Body: http.NoBody}, nil
+		}
+	})}
+
+	es := NewEventSource(client, "http://feed/events")
+	want := []Event{
+		{Type: "message", Data: "hello\n world"},
+		{ID: "1", Type: "greeting", Data: ""},
+		{ID: "2", Type: "message", Data: "cr"},
+		{ID: "2", Type: "message", Data: "resumed"},
+	}
+	for _, w := range want {
+		ev, err := es.Next(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ev != w {
+			t.Errorf("expected: %+v, got: %+v", w, ev)
+		}
+	}
+	if _, err := es.Next(context.Background()); !errors.Is(err, ErrEventSourceClosed) {
+		t.Errorf("expected: %v, got: %v", ErrEventSourceClosed, err)
+	}
+	if got := strings.Join(lastIDs, ","); got != ",2,2,2" {
+		t.Errorf("expected: %v, got: %v", ",2,2,2", got)
+	}
+}
+
+func TestEventSourceIncremental(t *testing.T) {
+	pr, pw := io.Pipe()
+	defer pw.Close()
+	client := &http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		return eventStream(pr), nil
+	})}
+
+	es := NewEventSource(client, "http://feed/events")
+	defer es.Close()
+	go io.WriteString(pw, "data: first\n\n")
+	ev, err := es.Next(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ev.Data != "first" {
+		t.Errorf("expected: %v, got: %v", "first", ev.Data)
+	}
+}
+
+func TestEventSourceUnexpectedResponse(t *testing.T) {
+	var requests int
+	client := &http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		requests++
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "200 OK",
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader("{}")),
+		}, nil
+	})}
+
+	es := NewEventSource(client, "http://feed/events")
+	if _, err := es.Next(context.Background()); err == nil || errors.Is(err, ErrEventSourceClosed) {
+		t.Errorf("expected an unexpected response error, got: %v", err)
+	}
+	if _, err := es.Next(context.Background()); !errors.Is(err, ErrEventSourceClosed) {
+		t.Errorf("expected: %v, got: %v", ErrEventSourceClosed, err)
+	}
+	if requests != 1 {
+		t.Errorf("expected: %v, got: %v", 1, requests)
+	}
+}
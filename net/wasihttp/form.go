@@ -0,0 +1,47 @@
+package wasihttp
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// MaxMultipartMemory is the size limit of the multipart bodies parsed by
+// [ParseMultipartForm]. It may be changed in an init function.
+var MaxMultipartMemory int64 = 32 << 20
+
+// NOTE: r.ParseForm and r.MultipartReader read the incoming body stream as it
+// arrives and never seek, they work as is. http.Request.ParseMultipartForm
+// spills the files larger than its memory limit to temporary files, which
+// fail in components without a writable filesystem.
+
+// ParseMultipartForm parses the multipart body of r with r.ParseMultipartForm,
+// keeping the whole form in memory. Bodies larger than [MaxMultipartMemory]
+// are rejected with a [RequestError], instead of spilling files to disk.
+//
+// Uploads too large for memory are streamed part by part from
+// r.MultipartReader instead, see wasiblob.UploadMultipart.
+func ParseMultipartForm(r *http.Request) error {
+	if r.MultipartForm != nil {
+		return nil
+	}
+	r.Body = http.MaxBytesReader(nil, r.Body, MaxMultipartMemory)
+	// NOTE: files are kept in memory as long as the body fits in maxMemory
+	err := r.ParseMultipartForm(MaxMultipartMemory)
+	var (
+		maxBytes *http.MaxBytesError
+		memLimit *MemoryLimitError
+	)
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &memLimit):
+		return &RequestError{Status: http.StatusInsufficientStorage, Err: memLimit}
+	case errors.As(err, &maxBytes):
+		return &RequestError{Status: http.StatusRequestEntityTooLarge, Err: fmt.Errorf("request body is larger than %d bytes", maxBytes.Limit)}
+	case errors.Is(err, http.ErrNotMultipart), errors.Is(err, http.ErrMissingBoundary):
+		return &RequestError{Status: http.StatusUnsupportedMediaType, Err: err}
+	default:
+		return badRequest("malformed multipart body: %w", err)
+	}
+}
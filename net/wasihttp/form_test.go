@@ -0,0 +1,60 @@
+package wasihttp
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseMultipartForm(t *testing.T) {
+	prev := MaxMultipartMemory
+	MaxMultipartMemory = 1 << 10
+	t.Cleanup(func() { MaxMultipartMemory = prev })
+
+	newRequest := func(file string) *http.Request {
+		var b bytes.Buffer
+		w := multipart.NewWriter(&b)
+		w.WriteField("title", "holiday")
+		part, _ := w.CreateFormFile("photo", "beach.jpg")
+		io.WriteString(part, file)
+		w.Close()
+
+		r := httptest.NewRequest(http.MethodPost, "/photos", &b)
+		r.Header.Set("Content-Type", w.FormDataContentType())
+		return r
+	}
+
+	r := newRequest("jpeg")
+	if err := ParseMultipartForm(r); err != nil {
+		t.Fatal(err)
+	}
+	if got := r.FormValue("title"); got != "holiday" {
+		t.Errorf("expected: %v, got: %v", "holiday", got)
+	}
+	f, _, err := r.FormFile("photo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := io.ReadAll(f); string(data) != "jpeg" {
+		t.Errorf("expected: %q, got: %q", "jpeg", data)
+	}
+
+	tests := map[string]struct {
+		r      *http.Request
+		status int
+	}{
+		"too large":     {r: newRequest(strings.Repeat("a", 2<<10)), status: http.StatusRequestEntityTooLarge},
+		"not multipart": {r: httptest.NewRequest(http.MethodPost, "/photos", strings.NewReader("title=holiday")), status: http.StatusUnsupportedMediaType},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := ErrorStatus(ParseMultipartForm(tt.r)); got != tt.status {
+				t.Errorf("expected: %v, got: %v", tt.status, got)
+			}
+		})
+	}
+}
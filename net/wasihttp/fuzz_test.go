@@ -0,0 +1,180 @@
+//go:build !wasm
+
+package wasihttp
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"go.wasmcloud.dev/component/gen/wasi/http/types"
+
+	// stubs of the host imports, fields are served by wasihttptest
+	_ "go.wasmcloud.dev/component/componenttest"
+)
+
+// addHeaderSeeds adds header seeds in the encoding of decodeHeader.
+func addHeaderSeeds(f *testing.F) {
+	for _, seed := range []string{
+		"",
+		"Content-Type: text/plain",
+		"x-a: 1\nX-A: 2\nx-A: 3",
+		"Set-Cookie: a=1\nSet-Cookie: b=2",
+		"X-Empty: ",
+		"X-Tab: a\tb",
+		"X-CRLF: a\r\nInjected: yes",
+		"X-Nul: a\x00b",
+		"X-Obs-Text: caf\xc3\xa9 \xff",
+		"Ключ: значение",
+		"X Space: a",
+		": no name",
+		"x-\x7f: del",
+		"X-Many: v" + strings.Repeat("\nX-Many: v", 999),
+		"X-Long: " + strings.Repeat("a", 1<<16),
+	} {
+		f.Add(seed)
+	}
+}
+
+// decodeHeader decodes "name: value" lines, a line without a colon is a name
+// without value.
+func decodeHeader(data string) http.Header {
+	header := http.Header{}
+	for _, line := range strings.Split(data, "\n") {
+		name, value, _ := strings.Cut(line, ": ")
+		header[name] = append(header[name], value)
+	}
+	return header
+}
+
+func FuzzHeader(f *testing.F) {
+	addHeaderSeeds(f)
+	f.Fuzz(func(t *testing.T, data string) {
+		src := decodeHeader(data)
+
+		fields := types.NewFields()
+		defer fields.ResourceDrop()
+		err := toWasiHeader(src, fields)
+
+		valid := true
+		want := http.Header{}
+		for _, name := range sortedKeys(src) {
+			valid = valid && validHeaderName(name)
+			for _, value := range src[name] {
+				valid = valid && validHeaderValue(value)
+				want.Add(name, value)
+			}
+		}
+		if valid != (err == nil) {
+			t.Fatalf("expected valid: %v, got: %v", valid, err)
+		}
+
+		HeaderValidation = HeaderLenient
+		lenient := types.NewFields()
+		defer lenient.ResourceDrop()
+		if err := toWasiHeader(src, lenient); err != nil {
+			t.Errorf("expected: %v, got: %v", nil, err)
+		}
+		HeaderValidation = HeaderStrict
+		if err != nil {
+			return
+		}
+
+		got := http.Header{}
+		toHttpHeader(fields, &got)
+		for name, values := range want {
+			if !slices.Equal(got[name], values) {
+				t.Errorf("%s: expected: %q, got: %q", name, values, got[name])
+			}
+		}
+		if len(got) != len(want) {
+			t.Errorf("expected: %v, got: %v", want, got)
+		}
+	})
+}
+
+func sortedKeys(h http.Header) []string {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func FuzzMethod(f *testing.F) {
+	for _, seed := range []string{"GET", "get", "PURGE", "", "G\x00T", "MÉTHODE", strings.Repeat("X", 1024)} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, method string) {
+		got, err := methodToString(toWasiMethod(method))
+		if got != method {
+			t.Errorf("expected: %q, got: %q", method, got)
+		}
+		if standard := slices.Contains(knownMethods, method); standard != (err == nil) {
+			t.Errorf("expected standard: %v, got: %v", standard, err)
+		}
+	})
+}
+
+var knownMethods = []string{
+	http.MethodConnect, http.MethodDelete, http.MethodGet, http.MethodHead, http.MethodOptions,
+	http.MethodPatch, http.MethodPost, http.MethodPut, http.MethodTrace,
+}
+
+func FuzzScheme(f *testing.F) {
+	for _, seed := range []string{"http", "https", "HTTP", "", "ws", "h\x00ttp"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, scheme string) {
+		s := toWasiScheme(scheme)
+		switch {
+		case s.HTTP():
+			if scheme != "http" {
+				t.Errorf("expected %q to map to other", scheme)
+			}
+		case s.HTTPS():
+			if scheme != "https" {
+				t.Errorf("expected %q to map to other", scheme)
+			}
+		case s.Other() == nil || *s.Other() != scheme:
+			t.Errorf("expected: %q, got: %v", scheme, s.Other())
+		}
+	})
+}
+
+func FuzzRequestURL(f *testing.F) {
+	for _, seed := range [][2]string{
+		{"localhost", "/"},
+		{"example.com:8080", "/a/b?c=d&c=e"},
+		{"example.com", "*"},
+		{"example.com", "//double/slash"},
+		{"example.com", "/%2F/escaped%20path?q=%zz"},
+		{"example.com", "/%zz"},
+		{"example.com", "http://other/absolute"},
+		{"user@example.com", "/p#fragment"},
+		{"[::1]:80", "/ünïcode?ключ=значение"},
+		{"", ""},
+	} {
+		f.Add(seed[0], seed[1])
+	}
+	f.Fuzz(func(t *testing.T, authority, pathWithQuery string) {
+		u, err := requestURL(authority, pathWithQuery)
+		if err != nil {
+			return
+		}
+		if u.Host != authority || u.Scheme != "http" {
+			t.Errorf("expected: http://%s, got: %s://%s", authority, u.Scheme, u.Host)
+		}
+
+		// the outgoing request target of u must describe the same resource
+		again, err := requestURL(authority, u.RequestURI())
+		if err != nil {
+			t.Fatalf("failed to parse %q reconstructed from %q: %s", u.RequestURI(), pathWithQuery, err)
+		}
+		if again.Path != u.Path || again.RawQuery != u.RawQuery {
+			t.Errorf("expected: %q %q, got: %q %q", u.Path, u.RawQuery, again.Path, again.RawQuery)
+		}
+	})
+}
@@ -0,0 +1,224 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"go.wasmcloud.dev/component/net/wasihttp"
+)
+
+// Request is a GraphQL operation.
+type Request struct {
+	Query         string         `json:"query,omitempty"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+	Extensions    map[string]any `json:"extensions,omitempty"`
+}
+
+// Location is a location of an error in the query.
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// Error is an error of the errors of a response.
+type Error struct {
+	Message    string         `json:"message"`
+	Locations  []Location     `json:"locations,omitempty"`
+	Path       []any          `json:"path,omitempty"`
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
+
+func (e *Error) Error() string {
+	if len(e.Path) == 0 {
+		return "graphql: " + e.Message
+	}
+	path := make([]string, len(e.Path))
+	for i, p := range e.Path {
+		path[i] = fmt.Sprint(p)
+	}
+	return "graphql: " + strings.Join(path, ".") + ": " + e.Message
+}
+
+// Code returns the code of the extensions of e, if any.
+func (e *Error) Code() string {
+	code, _ := e.Extensions["code"].(string)
+	return code
+}
+
+// Errors are the errors of a response, returned by [Client.Do].
+type Errors []*Error
+
+func (errs Errors) Error() string {
+	switch len(errs) {
+	case 0:
+		return "graphql: no errors"
+	case 1:
+		return errs[0].Error()
+	default:
+		return errs[0].Error() + " (and " + strconv.Itoa(len(errs)-1) + " more errors)"
+	}
+}
+
+type response struct {
+	Data   json.RawMessage `json:"data"`
+	Errors Errors          `json:"errors"`
+}
+
+// Client sends GraphQL operations over HTTP POST, as specified by
+// GraphQL-over-HTTP.
+type Client struct {
+	// URL is the endpoint of the GraphQL server.
+	URL string
+	// HTTPClient sends the requests, it defaults to wasihttp.DefaultClient.
+	HTTPClient *http.Client
+	// Header is sent with each request, e.g. Authorization.
+	Header http.Header
+	// PersistedQueries enables automatic persisted queries: operations are
+	// sent with the hash of their query first, and with the query only if
+	// the server does not know it yet. They are disabled once the server
+	// reports not supporting them.
+	PersistedQueries bool
+
+	persistedUnsupported atomic.Bool
+}
+
+// NewClient returns a Client of the endpoint url.
+func NewClient(url string) *Client {
+	return &Client{URL: url}
+}
+
+// Do sends req and decodes the data of the response into data, if not nil,
+// e.g. a *json.RawMessage to decode it later without reflection. The errors
+// of the response are returned as Errors, data is decoded even then, as
+// responses may hold partial data.
+func (c *Client) Do(ctx context.Context, req *Request, data any) error {
+	var resp *response
+	var err error
+	if c.PersistedQueries && !c.persistedUnsupported.Load() && req.Query != "" {
+		resp, err = c.doPersisted(ctx, req)
+	} else {
+		resp, err = c.send(ctx, req)
+	}
+	if err != nil {
+		return err
+	}
+
+	if data != nil && len(resp.Data) > 0 && !bytes.Equal(resp.Data, []byte("null")) {
+		if err := json.Unmarshal(resp.Data, data); err != nil {
+			return fmt.Errorf("failed to decode data: %w", err)
+		}
+	}
+	if len(resp.Errors) > 0 {
+		return resp.Errors
+	}
+	return nil
+}
+
+// doPersisted sends req as an automatic persisted query, with its query only
+// if the server asks for it.
+func (c *Client) doPersisted(ctx context.Context, req *Request) (*response, error) {
+	sum := sha256.Sum256([]byte(req.Query))
+	extensions := map[string]any{}
+	for k, v := range req.Extensions {
+		extensions[k] = v
+	}
+	extensions["persistedQuery"] = map[string]any{"version": 1, "sha256Hash": hex.EncodeToString(sum[:])}
+
+	persisted := *req
+	persisted.Query = ""
+	persisted.Extensions = extensions
+	resp, err := c.send(ctx, &persisted)
+	if err != nil {
+		return nil, err
+	}
+
+	switch persistedError(resp.Errors) {
+	case "PERSISTED_QUERY_NOT_FOUND":
+		persisted.Query = req.Query
+		return c.send(ctx, &persisted)
+	case "PERSISTED_QUERY_NOT_SUPPORTED":
+		c.persistedUnsupported.Store(true)
+		return c.send(ctx, req)
+	default:
+		return resp, nil
+	}
+}
+
+// persistedError returns the code of a persisted query error of errs, Apollo
+// servers set it as the message or the code of the extensions.
+func persistedError(errs Errors) string {
+	for _, err := range errs {
+		switch {
+		case err.Code() == "PERSISTED_QUERY_NOT_FOUND", err.Message == "PersistedQueryNotFound":
+			return "PERSISTED_QUERY_NOT_FOUND"
+		case err.Code() == "PERSISTED_QUERY_NOT_SUPPORTED", err.Message == "PersistedQueryNotSupported":
+			return "PERSISTED_QUERY_NOT_SUPPORTED"
+		}
+	}
+	return ""
+}
+
+// send POSTs req and decodes the response envelope. Responses with errors
+// other than 2xx, sent by GraphQL-over-HTTP servers to invalid requests,
+// are decoded as well.
+func (c *Client) send(ctx context.Context, req *Request) (*response, error) {
+	buf, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL, bytes.NewReader(buf))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	for k, v := range c.Header {
+		httpReq.Header[k] = v
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/graphql-response+json, application/json")
+
+	client := c.HTTPClient
+	if client == nil {
+		client = wasihttp.DefaultClient
+	}
+	httpResp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	ok := httpResp.StatusCode >= 200 && httpResp.StatusCode <= 299
+	mediaType, _, _ := mime.ParseMediaType(httpResp.Header.Get("Content-Type"))
+	if mediaType != "application/json" && mediaType != "application/graphql-response+json" {
+		if !ok {
+			return nil, fmt.Errorf("graphql: unexpected status %s", httpResp.Status)
+		}
+		return nil, fmt.Errorf("graphql: unexpected content type %q", httpResp.Header.Get("Content-Type"))
+	}
+
+	var resp response
+	if err := json.NewDecoder(io.LimitReader(httpResp.Body, wasihttp.MaxJSONBytes)).Decode(&resp); err != nil {
+		if !ok {
+			return nil, fmt.Errorf("graphql: unexpected status %s", httpResp.Status)
+		}
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if !ok && len(resp.Errors) == 0 {
+		return nil, fmt.Errorf("graphql: unexpected status %s", httpResp.Status)
+	}
+	if resp.Data == nil && len(resp.Errors) == 0 {
+		return nil, errors.New("graphql: response has neither data nor errors")
+	}
+	return &resp, nil
+}
@@ -0,0 +1,145 @@
+//go:build !wasm
+
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"go.wasmcloud.dev/component/net/wasihttp"
+	"go.wasmcloud.dev/component/net/wasihttp/wasihttptest"
+)
+
+const heroQuery = `query Hero($episode: String) { hero(episode: $episode) { name } }`
+
+// apqServer is a GraphQL server answering heroQuery, with a store of
+// persisted queries if persisted is set.
+type apqServer struct {
+	persisted bool
+	store     map[string]string
+	requests  []Request
+}
+
+func (s *apqServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	req, err := wasihttp.DecodeJSON[Request](r)
+	if err != nil {
+		http.Error(w, err.Error(), wasihttp.ErrorStatus(err))
+		return
+	}
+	s.requests = append(s.requests, req)
+
+	if ext, ok := req.Extensions["persistedQuery"].(map[string]any); ok {
+		hash, _ := ext["sha256Hash"].(string)
+		switch {
+		case !s.persisted:
+			wasihttp.RespondJSON(w, http.StatusOK, map[string]any{"errors": []map[string]any{{"message": "PersistedQueryNotSupported"}}})
+			return
+		case req.Query != "":
+			s.store[hash] = req.Query
+		case s.store[hash] == "":
+			wasihttp.RespondJSON(w, http.StatusOK, map[string]any{"errors": []map[string]any{{
+				"message":    "PersistedQueryNotFound",
+				"extensions": map[string]any{"code": "PERSISTED_QUERY_NOT_FOUND"},
+			}}})
+			return
+		default:
+			req.Query = s.store[hash]
+		}
+	}
+
+	if req.Query != heroQuery {
+		w.Header().Set("Content-Type", "application/graphql-response+json")
+		wasihttp.RespondJSON(w, http.StatusBadRequest, map[string]any{"errors": []map[string]any{{
+			"message":   "Cannot query field",
+			"locations": []map[string]int{{"line": 1, "column": 3}},
+		}}})
+		return
+	}
+	if req.Variables["episode"] == "EMPIRE" {
+		wasihttp.RespondJSON(w, http.StatusOK, map[string]any{
+			"data":   map[string]any{"hero": nil},
+			"errors": []map[string]any{{"message": "hero not found", "path": []any{"hero"}}},
+		})
+		return
+	}
+	wasihttp.RespondJSON(w, http.StatusOK, map[string]any{"data": map[string]any{"hero": map[string]any{"name": "R2-D2"}}})
+}
+
+type heroData struct {
+	Hero *struct {
+		Name string `json:"name"`
+	} `json:"hero"`
+}
+
+func TestClient(t *testing.T) {
+	defer func() { wasihttptest.Outgoing = nil }()
+	srv := &apqServer{}
+	wasihttptest.Outgoing = srv
+	c := NewClient("http://api/graphql")
+	ctx := context.Background()
+
+	var data heroData
+	if err := c.Do(ctx, &Request{Query: heroQuery, Variables: map[string]any{"episode": "JEDI"}}, &data); err != nil {
+		t.Fatal(err)
+	}
+	if data.Hero == nil || data.Hero.Name != "R2-D2" {
+		t.Errorf("expected: %v, got: %+v", "R2-D2", data.Hero)
+	}
+
+	var errs Errors
+	data = heroData{}
+	err := c.Do(ctx, &Request{Query: heroQuery, Variables: map[string]any{"episode": "EMPIRE"}}, &data)
+	if !errors.As(err, &errs) || len(errs) != 1 || err.Error() != "graphql: hero: hero not found" {
+		t.Errorf("expected: %v, got: %v", "hero not found", err)
+	}
+	if data.Hero != nil {
+		t.Errorf("expected the partial data to be decoded, got: %+v", data.Hero)
+	}
+
+	var raw json.RawMessage
+	err = c.Do(ctx, &Request{Query: "{ villain }"}, &raw)
+	if !errors.As(err, &errs) || errs[0].Locations[0] != (Location{Line: 1, Column: 3}) {
+		t.Errorf("expected the errors of a 400 response, got: %v", err)
+	}
+}
+
+func TestClientPersistedQueries(t *testing.T) {
+	tests := map[string]struct {
+		persisted    bool
+		wantRequests []bool
+	}{
+		// NOTE: whether each request carries the query
+		"supported":   {persisted: true, wantRequests: []bool{false, true, false}},
+		"unsupported": {persisted: false, wantRequests: []bool{false, true, true}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer func() { wasihttptest.Outgoing = nil }()
+			srv := &apqServer{persisted: tt.persisted, store: map[string]string{}}
+			wasihttptest.Outgoing = srv
+			c := &Client{URL: "http://api/graphql", PersistedQueries: true}
+
+			for i := 0; i < 2; i++ {
+				var data heroData
+				if err := c.Do(context.Background(), &Request{Query: heroQuery}, &data); err != nil {
+					t.Fatal(err)
+				}
+				if data.Hero == nil || data.Hero.Name != "R2-D2" {
+					t.Errorf("expected: %v, got: %+v", "R2-D2", data.Hero)
+				}
+			}
+
+			if len(srv.requests) != len(tt.wantRequests) {
+				t.Fatalf("expected: %v requests, got: %v", len(tt.wantRequests), len(srv.requests))
+			}
+			for i, want := range tt.wantRequests {
+				if got := srv.requests[i].Query != ""; got != want {
+					t.Errorf("request %d: expected query: %v, got: %v", i, want, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,280 @@
+package grpcweb
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+const (
+	contentTypeGRPC     = "application/grpc"
+	contentTypeGRPCWeb  = "application/grpc-web"
+	contentTypeGRPCText = "application/grpc-web-text"
+)
+
+// trailerFlag marks the frame carrying the trailers at the end of a gRPC-Web
+// response body.
+const trailerFlag = 0x80
+
+// Handler returns a handler serving gRPC-Web requests, binary or base64 text,
+// with next, a gRPC handler, e.g. of connectrpc.com/connect or the
+// ServeHTTP of a google.golang.org/grpc server. gRPC requests are passed on as
+// HTTP/2 requests, their trailers are sent by the host, other requests are
+// passed on as they are.
+//
+// The responses of next to gRPC-Web requests carry their trailers in a
+// final frame of the body, or in the headers if next wrote no message.
+func Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mediaType, _, _ := strings.Cut(r.Header.Get("Content-Type"), ";")
+		mediaType = strings.TrimSpace(mediaType)
+
+		var text bool
+		var suffix string
+		switch {
+		case hasType(mediaType, contentTypeGRPCText):
+			text, suffix = true, mediaType[len(contentTypeGRPCText):]
+		case hasType(mediaType, contentTypeGRPCWeb):
+			suffix = mediaType[len(contentTypeGRPCWeb):]
+		case hasType(mediaType, contentTypeGRPC):
+			next.ServeHTTP(w, asHTTP2(r))
+			return
+		default:
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		r = asHTTP2(r)
+		r.Header.Set("Content-Type", contentTypeGRPC+suffix)
+		if text {
+			// NOTE: the length of the decoded body is unknown
+			r.Header.Del("Content-Length")
+			r.ContentLength = -1
+			r.Body = &textReader{body: r.Body}
+		}
+
+		if r.Header.Get("Origin") != "" {
+			w.Header().Add("Access-Control-Expose-Headers", "Grpc-Status, Grpc-Message")
+		}
+		rw := &responseWriter{w: w, header: http.Header{}, text: text, suffix: suffix}
+		next.ServeHTTP(rw, r)
+		rw.finish()
+	})
+}
+
+// hasType reports whether mediaType is typ or typ with a codec suffix, e.g.
+// "application/grpc+proto".
+func hasType(mediaType, typ string) bool {
+	rest, ok := strings.CutPrefix(mediaType, typ)
+	return ok && (rest == "" || rest[0] == '+')
+}
+
+// asHTTP2 returns a copy of r announced as an HTTP/2 request, which gRPC
+// handlers require.
+func asHTTP2(r *http.Request) *http.Request {
+	r = r.Clone(r.Context())
+	r.Proto, r.ProtoMajor, r.ProtoMinor = "HTTP/2.0", 2, 0
+	return r
+}
+
+// responseWriter translates the gRPC response of a handler to gRPC-Web.
+type responseWriter struct {
+	w      http.ResponseWriter
+	header http.Header
+	text   bool
+	suffix string
+
+	wroteHeader bool
+	// pending are the bytes of a text response not encoded yet, base64
+	// encodes groups of 3 bytes
+	pending []byte
+}
+
+func (rw *responseWriter) Header() http.Header {
+	return rw.header
+}
+
+func (rw *responseWriter) WriteHeader(statusCode int) {
+	if rw.wroteHeader {
+		return
+	}
+	rw.wroteHeader = true
+
+	h := rw.w.Header()
+	trailers := http.Header{}
+	for k, v := range rw.header {
+		switch {
+		case strings.HasPrefix(k, http.TrailerPrefix):
+			trailers[k] = v
+		case k == "Trailer", k == "Content-Length":
+			// NOTE: the trailers are sent in the body, whose length changes
+		default:
+			h[k] = v
+		}
+	}
+	h.Set("Content-Type", rw.contentType(h.Get("Content-Type")))
+	// NOTE: like the wasihttp response writer, the headers set from now on
+	// are trailers
+	rw.header = trailers
+	rw.w.WriteHeader(statusCode)
+}
+
+// contentType returns the gRPC-Web content type of the response of the gRPC
+// content type typ.
+func (rw *responseWriter) contentType(typ string) string {
+	suffix := rw.suffix
+	if rest, ok := strings.CutPrefix(typ, contentTypeGRPC); ok && (rest == "" || rest[0] == '+') {
+		suffix = rest
+	}
+	if rw.text {
+		return contentTypeGRPCText + suffix
+	}
+	return contentTypeGRPCWeb + suffix
+}
+
+func (rw *responseWriter) Write(p []byte) (int, error) {
+	if !rw.wroteHeader {
+		rw.WriteHeader(http.StatusOK)
+	}
+	return rw.write(p)
+}
+
+func (rw *responseWriter) write(p []byte) (int, error) {
+	if !rw.text {
+		return rw.w.Write(p)
+	}
+
+	buf := append(rw.pending, p...)
+	n := len(buf) / 3 * 3
+	if n > 0 {
+		if _, err := io.WriteString(rw.w, base64.StdEncoding.EncodeToString(buf[:n])); err != nil {
+			return 0, err
+		}
+	}
+	rw.pending = slices.Clone(buf[n:])
+	return len(p), nil
+}
+
+// flushText writes the pending bytes of a text response, padded. Clients
+// decode concatenated padded chunks.
+func (rw *responseWriter) flushText() error {
+	if len(rw.pending) == 0 {
+		return nil
+	}
+	_, err := io.WriteString(rw.w, base64.StdEncoding.EncodeToString(rw.pending))
+	rw.pending = nil
+	return err
+}
+
+// Flush implements http.Flusher, gRPC handlers flush after each message.
+func (rw *responseWriter) Flush() {
+	if !rw.wroteHeader {
+		rw.WriteHeader(http.StatusOK)
+	}
+	if err := rw.flushText(); err != nil {
+		return
+	}
+	http.NewResponseController(rw.w).Flush()
+}
+
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.w
+}
+
+// finish sends the trailers set by the handler, in the headers of a
+// trailers-only response or in the trailer frame.
+func (rw *responseWriter) finish() {
+	trailers := http.Header{}
+	for k, v := range rw.header {
+		if len(v) == 0 {
+			continue
+		}
+		if name, ok := strings.CutPrefix(k, http.TrailerPrefix); ok {
+			k = http.CanonicalHeaderKey(name)
+		}
+		trailers[k] = append(trailers[k], v...)
+	}
+
+	if !rw.wroteHeader {
+		for k, v := range trailers {
+			rw.header[k] = v
+		}
+		rw.WriteHeader(http.StatusOK)
+		return
+	}
+
+	keys := make([]string, 0, len(trailers))
+	for k := range trailers {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	var payload strings.Builder
+	for _, k := range keys {
+		for _, value := range trailers[k] {
+			fmt.Fprintf(&payload, "%s: %s\r\n", strings.ToLower(k), value)
+		}
+	}
+	frame := binary.BigEndian.AppendUint32([]byte{trailerFlag}, uint32(payload.Len()))
+	if _, err := rw.write(append(frame, payload.String()...)); err != nil {
+		return
+	}
+	rw.flushText()
+}
+
+// textReader decodes the base64 body of a gRPC-Web text request, which may
+// be a concatenation of padded chunks.
+type textReader struct {
+	body    io.ReadCloser
+	buf     [4096]byte
+	encoded []byte
+	decoded []byte
+	err     error
+}
+
+func (r *textReader) Read(p []byte) (int, error) {
+	for len(r.decoded) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+
+		n, err := r.body.Read(r.buf[:])
+		for _, c := range r.buf[:n] {
+			if c != '\r' && c != '\n' && c != ' ' && c != '\t' {
+				r.encoded = append(r.encoded, c)
+			}
+		}
+		// NOTE: decoded by groups of 4 characters, padding may end any of
+		// them
+		var group [3]byte
+		i := 0
+		for ; i+4 <= len(r.encoded); i += 4 {
+			m, decodeErr := base64.StdEncoding.Decode(group[:], r.encoded[i:i+4])
+			if decodeErr != nil {
+				r.err = fmt.Errorf("failed to decode grpc-web-text body: %w", decodeErr)
+				break
+			}
+			r.decoded = append(r.decoded, group[:m]...)
+		}
+		r.encoded = append(r.encoded[:0], r.encoded[i:]...)
+
+		switch {
+		case r.err != nil:
+		case err == io.EOF && len(r.encoded) > 0:
+			r.err = io.ErrUnexpectedEOF
+		case err != nil:
+			r.err = err
+		}
+	}
+
+	n := copy(p, r.decoded)
+	r.decoded = r.decoded[n:]
+	return n, nil
+}
+
+func (r *textReader) Close() error {
+	return r.body.Close()
+}
@@ -0,0 +1,174 @@
+package grpcweb
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// frame returns a gRPC message frame of msg.
+func frame(flags byte, msg string) []byte {
+	return append([]byte{flags, 0, 0, 0, byte(len(msg))}, msg...)
+}
+
+// greeter answers each request with the messages "hi" and "ho", like a gRPC
+// server streaming handler.
+var greeter = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if r.ProtoMajor != 2 || err != nil || !bytes.Equal(body, frame(0, "hello")) {
+		w.Header().Set("Grpc-Status", "3")
+		w.Header().Set("Grpc-Message", "bad request: "+r.Proto+" "+r.Header.Get("Content-Type"))
+		return
+	}
+	w.Header().Set("Content-Type", r.Header.Get("Content-Type"))
+	w.WriteHeader(http.StatusOK)
+	for _, msg := range []string{"hi", "ho"} {
+		w.Write(frame(0, msg))
+		w.(http.Flusher).Flush()
+	}
+	w.Header().Set(http.TrailerPrefix+"Grpc-Status", "0")
+	w.Header().Set(http.TrailerPrefix+"Grpc-Message", "ok")
+})
+
+func TestHandler(t *testing.T) {
+	want := slices.Concat(frame(0, "hi"), frame(0, "ho"), frame(trailerFlag, "grpc-message: ok\r\ngrpc-status: 0\r\n"))
+
+	tests := map[string]struct {
+		contentType     string
+		body            []byte
+		wantContentType string
+	}{
+		"binary": {
+			contentType:     "application/grpc-web+proto",
+			body:            frame(0, "hello"),
+			wantContentType: "application/grpc-web+proto",
+		},
+		"binary without codec": {
+			contentType:     "application/grpc-web",
+			body:            frame(0, "hello"),
+			wantContentType: "application/grpc-web",
+		},
+		"text": {
+			contentType:     "application/grpc-web-text",
+			body:            []byte(base64.StdEncoding.EncodeToString(frame(0, "hello"))),
+			wantContentType: "application/grpc-web-text",
+		},
+		"text chunks": {
+			contentType: "application/grpc-web-text+proto",
+			body: []byte(base64.StdEncoding.EncodeToString(frame(0, "hello")[:4]) + "\r\n" +
+				base64.StdEncoding.EncodeToString(frame(0, "hello")[4:])),
+			wantContentType: "application/grpc-web-text+proto",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/greet.v1.Greeter/Greet", bytes.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			rec := httptest.NewRecorder()
+			Handler(greeter).ServeHTTP(rec, req)
+
+			if got := rec.Header().Get("Content-Type"); got != tt.wantContentType {
+				t.Errorf("expected: %v, got: %v", tt.wantContentType, got)
+			}
+			if _, ok := rec.Header()[http.TrailerPrefix+"Grpc-Status"]; ok {
+				t.Errorf("expected the trailers in the body, got: %v", rec.Header())
+			}
+			body := rec.Body.Bytes()
+			if strings.HasPrefix(tt.contentType, contentTypeGRPCText) {
+				var err error
+				if body, err = io.ReadAll(&textReader{body: io.NopCloser(rec.Body)}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if !bytes.Equal(body, want) {
+				t.Errorf("expected: %q, got: %q", want, body)
+			}
+			if !rec.Flushed {
+				t.Errorf("expected the messages to be flushed")
+			}
+		})
+	}
+}
+
+func TestHandlerTrailersOnly(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/greet.v1.Greeter/Greet", strings.NewReader("garbage"))
+	req.Header.Set("Content-Type", "application/grpc-web-text")
+	req.Header.Set("Origin", "https://example.com")
+	rec := httptest.NewRecorder()
+	Handler(greeter).ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("Grpc-Status"); got != "3" {
+		t.Errorf("expected: %v, got: %v", "3", got)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "application/grpc-web-text" {
+		t.Errorf("expected: %v, got: %v", "application/grpc-web-text", got)
+	}
+	if got := rec.Header().Get("Access-Control-Expose-Headers"); got != "Grpc-Status, Grpc-Message" {
+		t.Errorf("expected: %v, got: %v", "Grpc-Status, Grpc-Message", got)
+	}
+	if rec.Body.Len() != 0 {
+		t.Errorf("expected no body, got: %q", rec.Body)
+	}
+}
+
+func TestHandlerPassThrough(t *testing.T) {
+	tests := map[string]struct {
+		contentType string
+		proto       int
+	}{
+		"grpc":  {contentType: "application/grpc+proto", proto: 2},
+		"json":  {contentType: "application/json", proto: 1},
+		"other": {contentType: "application/grpc-webby", proto: 1},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var proto int
+			var contentType string
+			h := Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				proto, contentType = r.ProtoMajor, r.Header.Get("Content-Type")
+				if _, ok := w.(*responseWriter); ok {
+					t.Errorf("expected the response writer to be passed through")
+				}
+			}))
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			req.Header.Set("Content-Type", tt.contentType)
+			h.ServeHTTP(httptest.NewRecorder(), req)
+
+			if proto != tt.proto || contentType != tt.contentType {
+				t.Errorf("expected: %v %v, got: %v %v", tt.proto, tt.contentType, proto, contentType)
+			}
+		})
+	}
+}
+
+func TestTextReader(t *testing.T) {
+	tests := map[string]struct {
+		body    string
+		want    string
+		wantErr bool
+	}{
+		"padded":         {body: "aGVsbG8=", want: "hello"},
+		"concatenated":   {body: "aGk=aG8=", want: "hiho"},
+		"line breaks":    {body: "aGVs\r\nbG8=\n", want: "hello"},
+		"truncated":      {body: "aGVsbG", want: "hel", wantErr: true},
+		"invalid":        {body: "a!==", wantErr: true},
+		"empty":          {body: "", want: ""},
+		"unpadded group": {body: "aGVsbG8h", want: "hello!"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := io.ReadAll(&textReader{body: io.NopCloser(strings.NewReader(tt.body))})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error: %v, got: %v", tt.wantErr, err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("expected: %q, got: %q", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package wasihttp
+
+import (
+	"io"
+	"net/http"
+)
+
+// ResponseHooks observe the response written by a handler, e.g. for metrics,
+// access logs or caching, without wrapping the response writer, which hides
+// its http.Flusher and io.ReaderFrom from the handler.
+type ResponseHooks struct {
+	// WriteHeader, if set, is called once with the status code and the
+	// header of the response, when the handler sets the status code or first
+	// writes the body. The header may still be changed.
+	WriteHeader func(status int, header http.Header)
+	// Write, if set, is called with the number of bytes of each write of the
+	// body, including the bytes spliced from an incoming body. The discarded
+	// body of a response to HEAD is not written.
+	Write func(n int64)
+}
+
+// AddResponseHooks registers hooks on w, a response writer of the wasihttp
+// server, or one wrapping it with an Unwrap method like those supported by
+// http.ResponseController. It reports whether hooks were registered, callers
+// wrap other writers, e.g. of httptest, themselves.
+func AddResponseHooks(w http.ResponseWriter, hooks ResponseHooks) bool {
+	for {
+		switch rw := w.(type) {
+		case *responseOutparamWriter:
+			rw.hooks = append(rw.hooks, hooks)
+			return true
+		case interface{ Unwrap() http.ResponseWriter }:
+			w = rw.Unwrap()
+		default:
+			return false
+		}
+	}
+}
+
+// writeHeaderHooks calls the WriteHeader hooks with the status code.
+func (row *responseOutparamWriter) writeHeaderHooks() {
+	for _, h := range row.hooks {
+		if h.WriteHeader != nil {
+			h.WriteHeader(row.statuscode, row.httpHeaders)
+		}
+	}
+}
+
+// writeHooks calls the Write hooks with n, if positive.
+func (row *responseOutparamWriter) writeHooks(n int64) {
+	if n <= 0 {
+		return
+	}
+	for _, h := range row.hooks {
+		if h.Write != nil {
+			h.Write(n)
+		}
+	}
+}
+
+// WrapResponseWriter returns a writer wrapping w and calling hooks, for
+// writers other than those of the wasihttp server, see [AddResponseHooks].
+// The writer implements http.Flusher and io.ReaderFrom if w does, so that
+// stacked middlewares keep the flush and splice of the server, and unwraps
+// to w for http.ResponseController. The Write hook is called once per
+// ReadFrom, with the number of bytes read.
+func WrapResponseWriter(w http.ResponseWriter, hooks ResponseHooks) http.ResponseWriter {
+	hw := &hookedWriter{ResponseWriter: w, hooks: hooks}
+	_, flusher := w.(http.Flusher)
+	_, readerFrom := w.(io.ReaderFrom)
+	// NOTE: like github.com/felixge/httpsnoop, a type per combination of the
+	// optional interfaces implemented by w
+	switch {
+	case flusher && readerFrom:
+		return struct {
+			*hookedWriter
+			http.Flusher
+			io.ReaderFrom
+		}{hw, hookedFlusher{hw}, hookedReaderFrom{hw}}
+	case flusher:
+		return struct {
+			*hookedWriter
+			http.Flusher
+		}{hw, hookedFlusher{hw}}
+	case readerFrom:
+		return struct {
+			*hookedWriter
+			io.ReaderFrom
+		}{hw, hookedReaderFrom{hw}}
+	}
+	return hw
+}
+
+// hookedWriter calls hooks on the writes to a response writer, the optional
+// interfaces are implemented by hookedFlusher and hookedReaderFrom.
+type hookedWriter struct {
+	http.ResponseWriter
+	hooks       ResponseHooks
+	wroteHeader bool
+}
+
+// writeHeader calls the WriteHeader hook once, with status.
+func (w *hookedWriter) writeHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	if w.hooks.WriteHeader != nil {
+		w.hooks.WriteHeader(status, w.Header())
+	}
+}
+
+func (w *hookedWriter) WriteHeader(statusCode int) {
+	// NOTE: informational responses precede the final one
+	if statusCode >= 200 || statusCode == http.StatusSwitchingProtocols {
+		w.writeHeader(statusCode)
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *hookedWriter) Write(p []byte) (int, error) {
+	w.writeHeader(http.StatusOK)
+	n, err := w.ResponseWriter.Write(p)
+	if n > 0 && w.hooks.Write != nil {
+		w.hooks.Write(int64(n))
+	}
+	return n, err
+}
+
+func (w *hookedWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+type hookedFlusher struct {
+	*hookedWriter
+}
+
+func (w hookedFlusher) Flush() {
+	w.writeHeader(http.StatusOK)
+	w.ResponseWriter.(http.Flusher).Flush()
+}
+
+type hookedReaderFrom struct {
+	*hookedWriter
+}
+
+func (w hookedReaderFrom) ReadFrom(src io.Reader) (int64, error) {
+	w.writeHeader(http.StatusOK)
+	n, err := w.ResponseWriter.(io.ReaderFrom).ReadFrom(src)
+	if n > 0 && w.hooks.Write != nil {
+		w.hooks.Write(n)
+	}
+	return n, err
+}
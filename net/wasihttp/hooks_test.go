@@ -0,0 +1,124 @@
+package wasihttp
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// plainWriter is a response writer without optional interfaces.
+type plainWriter struct {
+	http.ResponseWriter
+}
+
+// readerFromWriter is a response writer implementing io.ReaderFrom only,
+// counting the calls of ReadFrom.
+type readerFromWriter struct {
+	http.ResponseWriter
+	readFrom int
+}
+
+func (w *readerFromWriter) ReadFrom(src io.Reader) (int64, error) {
+	w.readFrom++
+	return io.Copy(w.ResponseWriter, src)
+}
+
+// fullWriter implements http.Flusher and io.ReaderFrom.
+type fullWriter struct {
+	*httptest.ResponseRecorder
+	readFrom int
+}
+
+func (w *fullWriter) ReadFrom(src io.Reader) (int64, error) {
+	w.readFrom++
+	return io.Copy(w.ResponseRecorder, src)
+}
+
+func TestWrapResponseWriter(t *testing.T) {
+	tests := map[string]struct {
+		writer     func(*httptest.ResponseRecorder) http.ResponseWriter
+		flusher    bool
+		readerFrom bool
+	}{
+		"plain": {
+			writer: func(rec *httptest.ResponseRecorder) http.ResponseWriter { return plainWriter{rec} },
+		},
+		"flusher": {
+			writer:  func(rec *httptest.ResponseRecorder) http.ResponseWriter { return rec },
+			flusher: true,
+		},
+		"reader from": {
+			writer: func(rec *httptest.ResponseRecorder) http.ResponseWriter {
+				return &readerFromWriter{ResponseWriter: rec}
+			},
+			readerFrom: true,
+		},
+		"both": {
+			writer:     func(rec *httptest.ResponseRecorder) http.ResponseWriter { return &fullWriter{ResponseRecorder: rec} },
+			flusher:    true,
+			readerFrom: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			inner := tt.writer(rec)
+
+			var statuses []int
+			var written int64
+			w := WrapResponseWriter(inner, ResponseHooks{
+				WriteHeader: func(status int, header http.Header) {
+					statuses = append(statuses, status)
+					header.Set("X-Observed", "1")
+				},
+				Write: func(n int64) { written += n },
+			})
+			if _, ok := w.(http.Flusher); ok != tt.flusher {
+				t.Errorf("expected Flusher: %v, got: %v", tt.flusher, ok)
+			}
+			if _, ok := w.(io.ReaderFrom); ok != tt.readerFrom {
+				t.Errorf("expected ReaderFrom: %v, got: %v", tt.readerFrom, ok)
+			}
+			if u, ok := w.(interface{ Unwrap() http.ResponseWriter }); !ok || u.Unwrap() != inner {
+				t.Error("expected the wrapper to unwrap to the writer")
+			}
+
+			w.WriteHeader(http.StatusCreated)
+			io.WriteString(w, "hello ")
+			// NOTE: not a strings.Reader, io.Copy prefers its WriteTo
+			io.Copy(w, struct{ io.Reader }{strings.NewReader("world")})
+			w.WriteHeader(http.StatusTeapot)
+
+			if len(statuses) != 1 || statuses[0] != http.StatusCreated || written != 11 {
+				t.Errorf("unexpected hooks: %v %v", statuses, written)
+			}
+			if rec.Body.String() != "hello world" || rec.Header().Get("X-Observed") != "1" {
+				t.Errorf("unexpected response: %q %v", rec.Body, rec.Header())
+			}
+			switch inner := inner.(type) {
+			case *readerFromWriter:
+				if inner.readFrom != 1 {
+					t.Errorf("expected ReadFrom to be called once, got: %d", inner.readFrom)
+				}
+			case *fullWriter:
+				if inner.readFrom != 1 {
+					t.Errorf("expected ReadFrom to be called once, got: %d", inner.readFrom)
+				}
+			}
+		})
+	}
+
+	t.Run("flush", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		var status int
+		w := WrapResponseWriter(rec, ResponseHooks{WriteHeader: func(code int, _ http.Header) { status = code }})
+		if err := http.NewResponseController(w).Flush(); err != nil {
+			t.Fatal(err)
+		}
+		if status != http.StatusOK || !rec.Flushed {
+			t.Errorf("unexpected flush: %v %v", status, rec.Flushed)
+		}
+	})
+}
@@ -0,0 +1,309 @@
+package httpcache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.wasmcloud.dev/component/kv/wasikv"
+	"go.wasmcloud.dev/component/singleflight"
+	"go.wasmcloud.dev/component/time/wasiclock"
+)
+
+// Options configures [Middleware].
+type Options struct {
+	// KeyPrefix prefixes the bucket keys of cached responses, defaults to "httpcache:".
+	KeyPrefix string
+	// DefaultTTL caches responses without freshness information, responses
+	// without max-age, s-maxage or Expires are not cached when zero.
+	DefaultTTL time.Duration
+	// MaxBodySize skips caching larger responses, defaults to 1MiB.
+	MaxBodySize int
+	// Logger reports bucket failures, defaults to slog.Default().
+	Logger *slog.Logger
+}
+
+// entry is a cached response.
+type entry struct {
+	// Vary is set on the index entry of a URL, listing the request headers
+	// selecting the variant.
+	Vary    []string    `json:"vary,omitempty"`
+	Status  int         `json:"status,omitempty"`
+	Header  http.Header `json:"header,omitempty"`
+	Body    []byte      `json:"body,omitempty"`
+	Stored  time.Time   `json:"stored"`
+	Expires time.Time   `json:"expires"`
+}
+
+type cache struct {
+	bucket *wasikv.Bucket
+	opts   Options
+	next   http.Handler
+
+	inflight singleflight.Group[*call]
+}
+
+// call is a handler invocation shared by concurrent misses on the same key.
+type call struct {
+	res *entry
+	// header is the request header of the invocation
+	header http.Header
+}
+
+// Middleware returns a handler serving GET responses from the bucket while
+// they are fresh according to Cache-Control, Expires and Vary, so that hits do
+// not invoke next. Concurrent misses on the same key within the component
+// instance share a single invocation of next.
+//
+// Requests with an Authorization header, and responses marked private,
+// no-store or setting cookies, are never cached. Misses are buffered and
+// written out once next returns.
+func Middleware(bucket *wasikv.Bucket, opts Options, next http.Handler) http.Handler {
+	if opts.KeyPrefix == "" {
+		opts.KeyPrefix = "httpcache:"
+	}
+	if opts.MaxBodySize == 0 {
+		opts.MaxBodySize = 1 << 20
+	}
+	if opts.Logger == nil {
+		opts.Logger = slog.Default()
+	}
+	return &cache{bucket: bucket, opts: opts, next: next}
+}
+
+func (c *cache) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet || r.Header.Get("Authorization") != "" {
+		c.next.ServeHTTP(w, r)
+		return
+	}
+
+	reqDirectives := parseCacheControl(r.Header.Get("Cache-Control"))
+	if _, ok := reqDirectives["no-store"]; ok {
+		c.next.ServeHTTP(w, r)
+		return
+	}
+	_, noCache := reqDirectives["no-cache"]
+
+	now := wasiclock.NowFunc()
+	base := c.opts.KeyPrefix + r.Host + r.URL.RequestURI()
+
+	if !noCache {
+		if e := c.lookup(base, r, now); e != nil {
+			writeEntry(w, e, now, "HIT")
+			return
+		}
+	}
+
+	e := c.do(base, r, now)
+	writeEntry(w, e, now, "MISS")
+}
+
+// lookup returns the fresh cached response for r, if any.
+func (c *cache) lookup(base string, r *http.Request, now time.Time) *entry {
+	index := c.get(base)
+	if index == nil {
+		return nil
+	}
+
+	key := variantKey(base, index.Vary, r)
+	e := c.get(key)
+	if e == nil {
+		return nil
+	}
+	if !now.Before(e.Expires) {
+		if err := c.bucket.Delete(key); err != nil {
+			c.opts.Logger.Error("failed to delete stale response", "key", key, "error", err)
+		}
+		return nil
+	}
+	return e
+}
+
+// do invokes next once for concurrent misses on the same URL, storing the
+// response when cacheable.
+func (c *cache) do(base string, r *http.Request, now time.Time) *entry {
+	cl, _, shared := c.inflight.Do(base, func() (*call, error) {
+		return &call{res: c.fetch(base, r, now), header: r.Header}, nil
+	})
+	if shared && !sameVariant(cl.res.Header, cl.header, r.Header) {
+		// the shared response is a different variant
+		return c.fetch(base, r, now)
+	}
+	return cl.res
+}
+
+func (c *cache) fetch(base string, r *http.Request, now time.Time) *entry {
+	rec := &recorder{header: make(http.Header)}
+	c.next.ServeHTTP(rec, r)
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+
+	e := &entry{Status: rec.status, Header: rec.header, Body: rec.body.Bytes(), Stored: now}
+	if ttl, ok := c.ttl(rec, now); ok && rec.body.Len() <= c.opts.MaxBodySize {
+		e.Expires = now.Add(ttl)
+		c.store(base, r, e)
+	}
+	return e
+}
+
+// sameVariant reports whether the requests with headers a and b select the
+// same variant of a response with header h.
+func sameVariant(h, a, b http.Header) bool {
+	for _, name := range varyHeaders(h) {
+		if name == "*" || !slices.Equal(a.Values(name), b.Values(name)) {
+			return false
+		}
+	}
+	return true
+}
+
+// varyHeaders returns the sorted canonical request header names listed by the
+// Vary headers of h.
+func varyHeaders(h http.Header) []string {
+	var vary []string
+	for _, v := range h.Values("Vary") {
+		for _, name := range strings.Split(v, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				vary = append(vary, http.CanonicalHeaderKey(name))
+			}
+		}
+	}
+	slices.Sort(vary)
+	return slices.Compact(vary)
+}
+
+func (c *cache) store(base string, r *http.Request, e *entry) {
+	vary := varyHeaders(e.Header)
+	c.set(base, &entry{Vary: vary, Stored: e.Stored, Expires: e.Expires})
+	c.set(variantKey(base, vary, r), e)
+}
+
+// ttl returns how long the response stays fresh, and whether it may be stored.
+func (c *cache) ttl(rec *recorder, now time.Time) (time.Duration, bool) {
+	switch rec.status {
+	case http.StatusOK, http.StatusNonAuthoritativeInfo, http.StatusNoContent,
+		http.StatusMultipleChoices, http.StatusMovedPermanently, http.StatusPermanentRedirect,
+		http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusGone:
+	default:
+		return 0, false
+	}
+	if rec.header.Get("Set-Cookie") != "" || strings.Contains(rec.header.Get("Vary"), "*") {
+		return 0, false
+	}
+
+	directives := parseCacheControl(rec.header.Get("Cache-Control"))
+	for _, d := range []string{"no-store", "no-cache", "private"} {
+		if _, ok := directives[d]; ok {
+			return 0, false
+		}
+	}
+	for _, d := range []string{"s-maxage", "max-age"} {
+		if v, ok := directives[d]; ok {
+			seconds, err := strconv.Atoi(v)
+			if err != nil || seconds <= 0 {
+				return 0, false
+			}
+			return time.Duration(seconds) * time.Second, true
+		}
+	}
+	if v := rec.header.Get("Expires"); v != "" {
+		expires, err := http.ParseTime(v)
+		if err != nil || !expires.After(now) {
+			return 0, false
+		}
+		return expires.Sub(now), true
+	}
+	return c.opts.DefaultTTL, c.opts.DefaultTTL > 0
+}
+
+func (c *cache) get(key string) *entry {
+	data, err := c.bucket.Get(key)
+	if err != nil {
+		return nil
+	}
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil
+	}
+	return &e
+}
+
+func (c *cache) set(key string, e *entry) {
+	data, err := json.Marshal(e)
+	if err == nil {
+		err = c.bucket.Set(key, data)
+	}
+	if err != nil {
+		c.opts.Logger.Error("failed to store response", "key", key, "error", err)
+	}
+}
+
+// variantKey returns the bucket key of the response to r selected by vary.
+func variantKey(base string, vary []string, r *http.Request) string {
+	h := sha256.New()
+	h.Write([]byte(base))
+	for _, name := range vary {
+		h.Write([]byte{0})
+		h.Write([]byte(name + ":" + strings.Join(r.Header.Values(name), ",")))
+	}
+	return base + "#" + hex.EncodeToString(h.Sum(nil)[:16])
+}
+
+func writeEntry(w http.ResponseWriter, e *entry, now time.Time, status string) {
+	h := w.Header()
+	for name, values := range e.Header {
+		h[name] = slices.Clone(values)
+	}
+	if status == "HIT" {
+		h.Set("Age", strconv.Itoa(int(now.Sub(e.Stored).Seconds())))
+	}
+	h.Set("X-Cache", status)
+	w.WriteHeader(e.Status)
+	w.Write(e.Body)
+}
+
+// parseCacheControl returns the directives of a Cache-Control header, with
+// lowercased names and unquoted values.
+func parseCacheControl(v string) map[string]string {
+	directives := make(map[string]string)
+	for _, part := range strings.Split(v, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		if name == "" {
+			continue
+		}
+		directives[strings.ToLower(name)] = strings.Trim(value, `"`)
+	}
+	return directives
+}
+
+// recorder buffers the response of next.
+type recorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (r *recorder) Header() http.Header {
+	return r.header
+}
+
+func (r *recorder) WriteHeader(statusCode int) {
+	if r.status == 0 {
+		r.status = statusCode
+	}
+}
+
+func (r *recorder) Write(p []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return r.body.Write(p)
+}
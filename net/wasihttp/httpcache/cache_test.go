@@ -0,0 +1,167 @@
+//go:build !wasm
+
+package httpcache
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.wasmcloud.dev/component/kv/wasikv/wasikvtest"
+	"go.wasmcloud.dev/component/time/wasiclock"
+)
+
+var now = time.Unix(1700000000, 0)
+
+func init() {
+	wasiclock.NowFunc = func() time.Time { return now }
+}
+
+func TestMiddleware(t *testing.T) {
+	tests := map[string]struct {
+		header   http.Header
+		request  http.Header
+		method   string
+		status   int
+		advance  time.Duration
+		defTTL   time.Duration
+		wantHits int
+	}{
+		"max-age":          {header: http.Header{"Cache-Control": {"max-age=60"}}, wantHits: 2},
+		"s-maxage":         {header: http.Header{"Cache-Control": {"max-age=0, s-maxage=60"}}, wantHits: 2},
+		"expired":          {header: http.Header{"Cache-Control": {"max-age=60"}}, advance: time.Minute, wantHits: 1},
+		"expires":          {header: http.Header{"Expires": {now.Add(time.Minute).Format(http.TimeFormat)}}, wantHits: 2},
+		"no-store":         {header: http.Header{"Cache-Control": {"no-store, max-age=60"}}},
+		"private":          {header: http.Header{"Cache-Control": {"private, max-age=60"}}},
+		"set-cookie":       {header: http.Header{"Cache-Control": {"max-age=60"}, "Set-Cookie": {"a=b"}}},
+		"vary star":        {header: http.Header{"Cache-Control": {"max-age=60"}, "Vary": {"*"}}},
+		"no freshness":     {header: http.Header{}},
+		"default ttl":      {header: http.Header{}, defTTL: time.Minute, wantHits: 2},
+		"server error":     {header: http.Header{"Cache-Control": {"max-age=60"}}, status: http.StatusInternalServerError},
+		"post":             {header: http.Header{"Cache-Control": {"max-age=60"}}, method: http.MethodPost},
+		"authorization":    {header: http.Header{"Cache-Control": {"max-age=60"}}, request: http.Header{"Authorization": {"Bearer x"}}},
+		"request no-cache": {header: http.Header{"Cache-Control": {"max-age=60"}}, request: http.Header{"Cache-Control": {"no-cache"}}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer func(start time.Time) { now = start }(now)
+
+			var calls int
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				for k, v := range tt.header {
+					w.Header()[k] = v
+				}
+				if tt.status != 0 {
+					w.WriteHeader(tt.status)
+				}
+				fmt.Fprintf(w, "call %d", calls)
+			})
+
+			bucket := wasikvtest.NewMemoryBucket().Bucket
+			h := Middleware(bucket, Options{DefaultTTL: tt.defTTL}, next)
+
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+
+			var hits int
+			for i := range 3 {
+				req := httptest.NewRequest(method, "/resource", nil)
+				for k, v := range tt.request {
+					req.Header[k] = v
+				}
+				rec := httptest.NewRecorder()
+				h.ServeHTTP(rec, req)
+
+				if rec.Header().Get("X-Cache") == "HIT" {
+					hits++
+					// served from the last stored response
+					if want := fmt.Sprintf("call %d", calls); rec.Body.String() != want {
+						t.Errorf("expected: %v, got: %v", want, rec.Body.String())
+					}
+				}
+				if i == 0 {
+					now = now.Add(tt.advance)
+				}
+			}
+			if hits != tt.wantHits {
+				t.Errorf("expected: %v hits, got: %v", tt.wantHits, hits)
+			}
+			if calls != 3-tt.wantHits {
+				t.Errorf("expected: %v calls, got: %v", 3-tt.wantHits, calls)
+			}
+		})
+	}
+}
+
+func TestVary(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Header().Set("Vary", "Accept-Language")
+		w.Write([]byte(r.Header.Get("Accept-Language")))
+	})
+
+	bucket := wasikvtest.NewMemoryBucket().Bucket
+	h := Middleware(bucket, Options{}, next)
+
+	for _, lang := range []string{"en", "de", "en", "de"} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept-Language", lang)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		if rec.Body.String() != lang {
+			t.Errorf("expected: %v, got: %v", lang, rec.Body.String())
+		}
+	}
+}
+
+func TestSingleFlight(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		<-release
+		w.Header().Set("Cache-Control", "no-store")
+		w.Write([]byte("shared"))
+	})
+
+	bucket := wasikvtest.NewMemoryBucket().Bucket
+	h := Middleware(bucket, Options{}, next)
+
+	var wg sync.WaitGroup
+	bodies := make([]string, 5)
+	for i := range bodies {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/slow", nil))
+			bodies[i] = rec.Body.String()
+		}()
+	}
+
+	// wait for the leader to enter next before the others arrive
+	for calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Errorf("expected: %v, got: %v", 1, n)
+	}
+	for _, body := range bodies {
+		if body != "shared" {
+			t.Errorf("expected: %v, got: %v", "shared", body)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package wasihttp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// MaxJSONBytes is the size limit of the bodies decoded by [DecodeJSON]. It
+// may be changed in an init function.
+var MaxJSONBytes int64 = 1 << 20
+
+// RequestError is returned by [DecodeJSON] when the request is invalid, Status
+// is the status of the response, e.g. 400 Bad Request.
+type RequestError struct {
+	Status int
+	Err    error
+}
+
+func (e *RequestError) Error() string { return e.Err.Error() }
+func (e *RequestError) Unwrap() error { return e.Err }
+
+// ErrorStatus returns the status of the response to a request that failed with
+// err, the one of a [RequestError] or 500 Internal Server Error.
+func ErrorStatus(err error) int {
+	var reqErr *RequestError
+	if errors.As(err, &reqErr) {
+		return reqErr.Status
+	}
+	return http.StatusInternalServerError
+}
+
+func badRequest(format string, args ...any) error {
+	return &RequestError{Status: http.StatusBadRequest, Err: fmt.Errorf(format, args...)}
+}
+
+// DecodeJSON decodes the JSON body of r as a T, reading from the body stream
+// without buffering it. Bodies with a Content-Type other than JSON, larger than
+// [MaxJSONBytes], malformed, or holding more than one value, are rejected with a
+// [RequestError].
+func DecodeJSON[T any](r *http.Request) (T, error) {
+	var v T
+	if ct := r.Header.Get("Content-Type"); ct != "" {
+		mediaType, _, err := mime.ParseMediaType(ct)
+		if err != nil || (mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json")) {
+			return v, &RequestError{Status: http.StatusUnsupportedMediaType, Err: fmt.Errorf("content type %q is not JSON", ct)}
+		}
+	}
+
+	d := json.NewDecoder(http.MaxBytesReader(nil, r.Body, MaxJSONBytes))
+	if err := d.Decode(&v); err != nil {
+		return v, decodeError(err)
+	}
+	if err := d.Decode(&struct{}{}); err != io.EOF {
+		var (
+			maxBytes *http.MaxBytesError
+			memLimit *MemoryLimitError
+		)
+		if errors.As(err, &maxBytes) || errors.As(err, &memLimit) {
+			return v, decodeError(err)
+		}
+		return v, badRequest("request body must hold a single JSON value")
+	}
+	return v, nil
+}
+
+func decodeError(err error) error {
+	var (
+		syntax    *json.SyntaxError
+		unmarshal *json.UnmarshalTypeError
+		maxBytes  *http.MaxBytesError
+		memLimit  *MemoryLimitError
+	)
+	switch {
+	case errors.Is(err, io.EOF):
+		return badRequest("request body is empty")
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return badRequest("request body is truncated JSON")
+	case errors.As(err, &syntax):
+		return badRequest("malformed JSON at offset %d: %w", syntax.Offset, err)
+	case errors.As(err, &unmarshal) && unmarshal.Field != "":
+		return badRequest("field %q must be a %s, got a JSON %s", unmarshal.Field, unmarshal.Type, unmarshal.Value)
+	case errors.As(err, &unmarshal):
+		return badRequest("request body must be a %s, got a JSON %s", unmarshal.Type, unmarshal.Value)
+	case errors.As(err, &maxBytes):
+		return &RequestError{Status: http.StatusRequestEntityTooLarge, Err: fmt.Errorf("request body is larger than %d bytes", maxBytes.Limit)}
+	case errors.As(err, &memLimit):
+		return &RequestError{Status: http.StatusInsufficientStorage, Err: memLimit}
+	default:
+		return fmt.Errorf("failed to decode request body: %w", err)
+	}
+}
+
+// RespondJSON writes v as the JSON body of the response with status, encoding
+// it to the response stream. The Content-Type defaults to application/json.
+// The header is only written once v is encoded, so the response can still be
+// replaced if it returns an error.
+func RespondJSON(w http.ResponseWriter, status int, v any) error {
+	defaulted := w.Header().Get("Content-Type") == ""
+	if defaulted {
+		w.Header().Set("Content-Type", "application/json")
+	}
+	if status == http.StatusNoContent || status == http.StatusNotModified {
+		w.WriteHeader(status)
+		return nil
+	}
+
+	lw := &lazyHeaderWriter{w: w, status: status}
+	if err := json.NewEncoder(lw).Encode(v); err != nil {
+		if defaulted && !lw.written {
+			w.Header().Del("Content-Type")
+		}
+		return fmt.Errorf("failed to encode response body: %w", err)
+	}
+	return nil
+}
+
+// lazyHeaderWriter writes the header of the response on the first write.
+type lazyHeaderWriter struct {
+	w       http.ResponseWriter
+	status  int
+	written bool
+}
+
+func (w *lazyHeaderWriter) Write(p []byte) (int, error) {
+	if !w.written {
+		w.w.WriteHeader(w.status)
+		w.written = true
+	}
+	return w.w.Write(p)
+}
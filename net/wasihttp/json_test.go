@@ -0,0 +1,105 @@
+package wasihttp
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type order struct {
+	ID    int      `json:"id"`
+	Items []string `json:"items"`
+}
+
+func TestDecodeJSON(t *testing.T) {
+	prev := MaxJSONBytes
+	MaxJSONBytes = 64
+	t.Cleanup(func() { MaxJSONBytes = prev })
+
+	tests := map[string]struct {
+		contentType string
+		body        string
+		want        order
+		status      int
+		err         string
+	}{
+		"valid":           {contentType: "application/json", body: `{"id":1,"items":["a"]}`, want: order{ID: 1, Items: []string{"a"}}},
+		"charset":         {contentType: "application/json; charset=utf-8", body: `{"id":1}`, want: order{ID: 1}},
+		"suffix":          {contentType: "application/merge-patch+json", body: `{"id":1}`, want: order{ID: 1}},
+		"no content type": {body: `{"id":1}`, want: order{ID: 1}},
+		"not json":        {contentType: "text/plain", body: `{"id":1}`, status: http.StatusUnsupportedMediaType, err: `content type "text/plain" is not JSON`},
+		"empty":           {body: ``, status: http.StatusBadRequest, err: "request body is empty"},
+		"truncated":       {body: `{"id":1`, status: http.StatusBadRequest, err: "request body is truncated JSON"},
+		"malformed":       {body: `{"id":}`, status: http.StatusBadRequest, err: "malformed JSON at offset 7: invalid character '}' looking for beginning of value"},
+		"field type":      {body: `{"id":"1"}`, status: http.StatusBadRequest, err: `field "id" must be a int, got a JSON string`},
+		"body type":       {body: `[1]`, status: http.StatusBadRequest, err: "request body must be a wasihttp.order, got a JSON array"},
+		"two values":      {body: `{"id":1}{"id":2}`, status: http.StatusBadRequest, err: "request body must hold a single JSON value"},
+		"too large":       {body: `{"items":["` + strings.Repeat("a", 64) + `"]}`, status: http.StatusRequestEntityTooLarge, err: "request body is larger than 64 bytes"},
+		"too large after": {body: `{"id":1}` + strings.Repeat(" ", 64), status: http.StatusRequestEntityTooLarge, err: "request body is larger than 64 bytes"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				r.Header.Set("Content-Type", tt.contentType)
+			}
+
+			got, err := DecodeJSON[order](r)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Fatalf("expected: %v, got: %v", tt.err, err)
+				}
+				if status := ErrorStatus(err); status != tt.status {
+					t.Errorf("expected: %v, got: %v", tt.status, status)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.ID != tt.want.ID || strings.Join(got.Items, ",") != strings.Join(tt.want.Items, ",") {
+				t.Errorf("expected: %v, got: %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestErrorStatus(t *testing.T) {
+	if got := ErrorStatus(errors.New("boom")); got != http.StatusInternalServerError {
+		t.Errorf("expected: %v, got: %v", http.StatusInternalServerError, got)
+	}
+}
+
+func TestRespondJSON(t *testing.T) {
+	rec := httptest.NewRecorder()
+	if err := RespondJSON(rec, http.StatusCreated, order{ID: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Code != http.StatusCreated {
+		t.Errorf("expected: %v, got: %v", http.StatusCreated, rec.Code)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "application/json" {
+		t.Errorf("expected: %v, got: %v", "application/json", got)
+	}
+	if want, got := "{\"id\":1,\"items\":null}\n", rec.Body.String(); got != want {
+		t.Errorf("expected: %q, got: %q", want, got)
+	}
+
+	rec = httptest.NewRecorder()
+	rec.Header().Set("Content-Type", "application/problem+json")
+	RespondJSON(rec, http.StatusBadRequest, map[string]string{"title": "bad"})
+	if got := rec.Header().Get("Content-Type"); got != "application/problem+json" {
+		t.Errorf("expected: %v, got: %v", "application/problem+json", got)
+	}
+
+	rec = httptest.NewRecorder()
+	if err := RespondJSON(rec, http.StatusOK, math.Inf(1)); err == nil {
+		t.Error("expected an encoding error")
+	}
+	if rec.Header().Get("Content-Type") != "" || rec.Body.Len() != 0 {
+		t.Errorf("expected the response to be unwritten, got: %v %q", rec.Header(), rec.Body)
+	}
+}
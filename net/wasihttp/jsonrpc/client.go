@@ -0,0 +1,193 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+
+	"go.wasmcloud.dev/component/net/wasihttp"
+)
+
+// Client calls the methods of a JSON-RPC 2.0 server over HTTP.
+type Client struct {
+	// URL is the endpoint the requests are POSTed to
+	URL string
+	// HTTPClient sends the requests, it defaults to wasihttp.DefaultClient
+	HTTPClient *http.Client
+
+	nextID atomic.Int64
+}
+
+// NewClient returns a Client of the endpoint url.
+func NewClient(url string) *Client {
+	return &Client{URL: url}
+}
+
+// BatchElem is a call of a batch. Result and Error are set by
+// [Client.BatchCall] from its response.
+type BatchElem struct {
+	Method string
+	Params any
+	// Result is decoded from the result of the response, if not nil
+	Result any
+	// Error is the *Error of the response, or the error decoding it
+	Error error
+}
+
+// Call calls method with params, by-position in a slice or by-name in a
+// struct or map, and decodes the result of the response into result, if not
+// nil. The error of the response is returned as an *Error.
+func (c *Client) Call(ctx context.Context, method string, params, result any) error {
+	req, err := c.request(method, params, true)
+	if err != nil {
+		return err
+	}
+	var resp response
+	if err := c.send(ctx, req, &resp); err != nil {
+		return err
+	}
+	return decodeResult(&resp, result)
+}
+
+// Notify sends a notification of method with params, which has no response.
+func (c *Client) Notify(ctx context.Context, method string, params any) error {
+	req, err := c.request(method, params, false)
+	if err != nil {
+		return err
+	}
+	return c.send(ctx, req, nil)
+}
+
+// BatchCall sends the calls of batch in a single request. The returned error
+// is the failure of the request, the errors of the calls are set in their
+// Error.
+func (c *Client) BatchCall(ctx context.Context, batch []BatchElem) error {
+	if len(batch) == 0 {
+		return nil
+	}
+
+	reqs := make([]*request, len(batch))
+	index := map[string]int{}
+	for i, elem := range batch {
+		req, err := c.request(elem.Method, elem.Params, true)
+		if err != nil {
+			return err
+		}
+		reqs[i] = req
+		index[string(req.ID)] = i
+	}
+
+	var raw json.RawMessage
+	if err := c.send(ctx, reqs, &raw); err != nil {
+		return err
+	}
+	if raw = bytes.TrimSpace(raw); len(raw) > 0 && raw[0] == '{' {
+		// NOTE: servers answer batches they cannot read with a single error
+		var resp response
+		if err := json.Unmarshal(raw, &resp); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+		if resp.Error != nil {
+			return resp.Error
+		}
+		return errors.New("jsonrpc: batch answered with a single response")
+	}
+	var resps []response
+	if err := json.Unmarshal(raw, &resps); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	answered := make([]bool, len(batch))
+	for _, resp := range resps {
+		i, ok := index[string(resp.ID)]
+		if !ok || answered[i] {
+			continue
+		}
+		answered[i] = true
+		batch[i].Error = decodeResult(&resp, batch[i].Result)
+	}
+	for i := range batch {
+		if !answered[i] {
+			batch[i].Error = errors.New("jsonrpc: missing response")
+		}
+	}
+	return nil
+}
+
+// request returns the request of method with params, with the next ID of c
+// unless it is a notification.
+func (c *Client) request(method string, params any, call bool) (*request, error) {
+	req := &request{JSONRPC: Version, Method: method}
+	if params != nil {
+		buf, err := json.Marshal(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode params: %w", err)
+		}
+		req.Params = buf
+	}
+	if call {
+		req.ID = json.RawMessage(strconv.FormatInt(c.nextID.Add(1), 10))
+	}
+	return req, nil
+}
+
+// send POSTs body and decodes the response into out, the response is
+// discarded if out is nil.
+func (c *Client) send(ctx context.Context, body, out any) error {
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL, bytes.NewReader(buf))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	client := c.HTTPClient
+	if client == nil {
+		client = wasihttp.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("jsonrpc: unexpected status %s", resp.Status)
+	}
+	if out == nil {
+		_, err := io.Copy(io.Discard, resp.Body)
+		return err
+	}
+	if resp.StatusCode == http.StatusNoContent {
+		return errors.New("jsonrpc: missing response")
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, wasihttp.MaxJSONBytes)).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// decodeResult returns the error of resp, or decodes its result into result,
+// if not nil.
+func decodeResult(resp *response, result any) error {
+	if resp.Error != nil {
+		return resp.Error
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(resp.Result, result); err != nil {
+		return fmt.Errorf("failed to decode result: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+//go:build !wasm
+
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.wasmcloud.dev/component/net/wasihttp/wasihttptest"
+)
+
+func TestClient(t *testing.T) {
+	defer func() { wasihttptest.Outgoing = nil }()
+	wasihttptest.Outgoing = newTestServer()
+	c := NewClient("http://rpc/")
+	ctx := context.Background()
+
+	var sum int
+	if err := c.Call(ctx, "sum", []int{1, 2, 3}, &sum); err != nil {
+		t.Fatal(err)
+	}
+	if sum != 6 {
+		t.Errorf("expected: %v, got: %v", 6, sum)
+	}
+
+	var rpcErr *Error
+	err := c.Call(ctx, "greet", map[string]string{}, nil)
+	if !errors.As(err, &rpcErr) || rpcErr.Code != -32000 || rpcErr.Data != "name" {
+		t.Errorf("expected: %v, got: %v", -32000, err)
+	}
+
+	if err := c.Notify(ctx, "sum", []int{1}); err != nil {
+		t.Errorf("expected no error, got: %v", err)
+	}
+
+	var greeting string
+	batch := []BatchElem{
+		{Method: "sum", Params: []int{4, 5}, Result: &sum},
+		{Method: "greet", Params: map[string]string{"name": "wasm"}, Result: &greeting},
+		{Method: "nope"},
+	}
+	if err := c.BatchCall(ctx, batch); err != nil {
+		t.Fatal(err)
+	}
+	if sum != 9 || greeting != "hello wasm" || batch[0].Error != nil || batch[1].Error != nil {
+		t.Errorf("unexpected results: %v %q %v", sum, greeting, batch)
+	}
+	if !errors.As(batch[2].Error, &rpcErr) || rpcErr.Code != CodeMethodNotFound {
+		t.Errorf("expected: %v, got: %v", CodeMethodNotFound, batch[2].Error)
+	}
+}
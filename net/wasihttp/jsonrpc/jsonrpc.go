@@ -0,0 +1,50 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// Version is the JSON-RPC version of the requests and responses.
+const Version = "2.0"
+
+// Error codes defined by the JSON-RPC 2.0 specification. Codes from -32000 to
+// -32099 are reserved for implementation-defined server errors.
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+)
+
+// Error is a JSON-RPC error object. Methods return an *Error to respond with
+// its code, other errors are internal errors. The Client returns the *Error
+// of a response.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    any    `json:"data,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return "jsonrpc: " + e.Message + " (" + strconv.Itoa(e.Code) + ")"
+}
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	// ID is nil for notifications, which have no response
+	ID json.RawMessage `json:"id,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+	ID      json.RawMessage `json:"id"`
+}
+
+// null is the ID of the responses to requests whose ID could not be read.
+var null = json.RawMessage("null")
@@ -0,0 +1,163 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"go.wasmcloud.dev/component/net/wasihttp"
+)
+
+// method calls a registered function with the raw params of a request.
+type method func(ctx context.Context, params json.RawMessage) (any, error)
+
+// Server is an http.Handler serving JSON-RPC 2.0 requests, single or batched,
+// POSTed with a JSON body, with the methods registered with [Register].
+type Server struct {
+	mu      sync.RWMutex
+	methods map[string]method
+}
+
+// NewServer returns a Server without methods.
+func NewServer() *Server {
+	return &Server{methods: map[string]method{}}
+}
+
+// Register registers fn as the method name of s. The params of the requests
+// are decoded as a P, by-position params into a slice or array and by-name
+// params into a struct or map, and the result of fn is encoded as the result
+// of the response.
+//
+// Register panics if name is registered twice.
+func Register[P, R any](s *Server, name string, fn func(ctx context.Context, params P) (R, error)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.methods[name]; ok {
+		panic(fmt.Sprintf("jsonrpc: multiple registrations for %q", name))
+	}
+	s.methods[name] = func(ctx context.Context, raw json.RawMessage) (any, error) {
+		var params P
+		if len(raw) > 0 {
+			if err := json.Unmarshal(raw, &params); err != nil {
+				return nil, &Error{Code: CodeInvalidParams, Message: "invalid params: " + err.Error()}
+			}
+		}
+		return fn(ctx, params)
+	}
+}
+
+// ServeHTTP implements http.Handler. Notifications are not answered, a request
+// of notifications only is answered with 204 No Content. Bodies rejected by
+// wasihttp.DecodeJSON other than malformed JSON are answered with its status.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := wasihttp.DecodeJSON[json.RawMessage](r)
+	if err != nil {
+		if wasihttp.ErrorStatus(err) != http.StatusBadRequest {
+			http.Error(w, err.Error(), wasihttp.ErrorStatus(err))
+			return
+		}
+		wasihttp.RespondJSON(w, http.StatusOK, errorResponse(null, &Error{Code: CodeParseError, Message: "parse error"}))
+		return
+	}
+
+	if body = bytes.TrimSpace(body); len(body) == 0 || body[0] != '[' {
+		if resp := s.serve(r.Context(), body); resp != nil {
+			wasihttp.RespondJSON(w, http.StatusOK, resp)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	var batch []json.RawMessage
+	if err := json.Unmarshal(body, &batch); err != nil || len(batch) == 0 {
+		wasihttp.RespondJSON(w, http.StatusOK, errorResponse(null, &Error{Code: CodeInvalidRequest, Message: "invalid request"}))
+		return
+	}
+	var responses []*response
+	for _, raw := range batch {
+		if resp := s.serve(r.Context(), raw); resp != nil {
+			responses = append(responses, resp)
+		}
+	}
+	if len(responses) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	wasihttp.RespondJSON(w, http.StatusOK, responses)
+}
+
+// serve calls the method of the request raw, and returns its response, nil
+// for notifications.
+func (s *Server) serve(ctx context.Context, raw json.RawMessage) *response {
+	var req request
+	if err := json.Unmarshal(raw, &req); err != nil {
+		return errorResponse(null, &Error{Code: CodeInvalidRequest, Message: "invalid request"})
+	}
+	id := req.ID
+	if !validID(id) {
+		return errorResponse(null, &Error{Code: CodeInvalidRequest, Message: "invalid request id"})
+	}
+	if req.JSONRPC != Version || req.Method == "" {
+		if id == nil {
+			id = null
+		}
+		return errorResponse(id, &Error{Code: CodeInvalidRequest, Message: "invalid request"})
+	}
+
+	s.mu.RLock()
+	m, ok := s.methods[req.Method]
+	s.mu.RUnlock()
+
+	var result any
+	var err error
+	if ok {
+		result, err = m(ctx, req.Params)
+	} else {
+		err = &Error{Code: CodeMethodNotFound, Message: fmt.Sprintf("method %q not found", req.Method)}
+	}
+	if id == nil {
+		return nil
+	}
+	if err != nil {
+		var rpcErr *Error
+		if !errors.As(err, &rpcErr) {
+			rpcErr = &Error{Code: CodeInternalError, Message: err.Error()}
+		}
+		return errorResponse(id, rpcErr)
+	}
+
+	buf, err := json.Marshal(result)
+	if err != nil {
+		return errorResponse(id, &Error{Code: CodeInternalError, Message: fmt.Sprintf("failed to encode result: %s", err)})
+	}
+	return &response{JSONRPC: Version, Result: buf, ID: id}
+}
+
+func errorResponse(id json.RawMessage, err *Error) *response {
+	return &response{JSONRPC: Version, Error: err, ID: id}
+}
+
+// validID reports whether id is absent, a string, a number or null.
+func validID(id json.RawMessage) bool {
+	if id == nil {
+		return true
+	}
+	switch c := id[0]; {
+	case c == '"', c == '-', '0' <= c && c <= '9':
+		return true
+	default:
+		return bytes.Equal(id, null)
+	}
+}
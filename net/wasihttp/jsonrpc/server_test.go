@@ -0,0 +1,154 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type sumParams []int
+
+func newTestServer() *Server {
+	s := NewServer()
+	Register(s, "sum", func(ctx context.Context, params sumParams) (int, error) {
+		sum := 0
+		for _, n := range params {
+			sum += n
+		}
+		return sum, nil
+	})
+	Register(s, "greet", func(ctx context.Context, params struct {
+		Name string `json:"name"`
+	}) (string, error) {
+		if params.Name == "" {
+			return "", &Error{Code: -32000, Message: "name is required", Data: "name"}
+		}
+		return "hello " + params.Name, nil
+	})
+	Register(s, "fail", func(ctx context.Context, params any) (any, error) {
+		return nil, errors.New("boom")
+	})
+	return s
+}
+
+func TestServer(t *testing.T) {
+	tests := map[string]struct {
+		body       string
+		wantStatus int
+		want       string
+	}{
+		"by-position params": {
+			body:       `{"jsonrpc":"2.0","method":"sum","params":[1,2,3],"id":1}`,
+			wantStatus: http.StatusOK,
+			want:       `{"jsonrpc":"2.0","result":6,"id":1}`,
+		},
+		"by-name params": {
+			body:       `{"jsonrpc":"2.0","method":"greet","params":{"name":"wasm"},"id":"a"}`,
+			wantStatus: http.StatusOK,
+			want:       `{"jsonrpc":"2.0","result":"hello wasm","id":"a"}`,
+		},
+		"method error": {
+			body:       `{"jsonrpc":"2.0","method":"greet","params":{},"id":2}`,
+			wantStatus: http.StatusOK,
+			want:       `{"jsonrpc":"2.0","error":{"code":-32000,"message":"name is required","data":"name"},"id":2}`,
+		},
+		"internal error": {
+			body:       `{"jsonrpc":"2.0","method":"fail","id":3}`,
+			wantStatus: http.StatusOK,
+			want:       `{"jsonrpc":"2.0","error":{"code":-32603,"message":"boom"},"id":3}`,
+		},
+		"method not found": {
+			body:       `{"jsonrpc":"2.0","method":"nope","id":null}`,
+			wantStatus: http.StatusOK,
+			want:       `{"jsonrpc":"2.0","error":{"code":-32601,"message":"method \"nope\" not found"},"id":null}`,
+		},
+		"parse error": {
+			body:       `{"jsonrpc":"2.0","method"`,
+			wantStatus: http.StatusOK,
+			want:       `{"jsonrpc":"2.0","error":{"code":-32700,"message":"parse error"},"id":null}`,
+		},
+		"invalid version": {
+			body:       `{"jsonrpc":"1.0","method":"sum","id":4}`,
+			wantStatus: http.StatusOK,
+			want:       `{"jsonrpc":"2.0","error":{"code":-32600,"message":"invalid request"},"id":4}`,
+		},
+		"invalid id": {
+			body:       `{"jsonrpc":"2.0","method":"sum","id":{}}`,
+			wantStatus: http.StatusOK,
+			want:       `{"jsonrpc":"2.0","error":{"code":-32600,"message":"invalid request id"},"id":null}`,
+		},
+		"notification": {
+			body:       `{"jsonrpc":"2.0","method":"sum","params":[1]}`,
+			wantStatus: http.StatusNoContent,
+		},
+		"empty batch": {
+			body:       `[]`,
+			wantStatus: http.StatusOK,
+			want:       `{"jsonrpc":"2.0","error":{"code":-32600,"message":"invalid request"},"id":null}`,
+		},
+		"batch": {
+			body: `[{"jsonrpc":"2.0","method":"sum","params":[1,2],"id":1},` +
+				`{"jsonrpc":"2.0","method":"sum","params":[3]},` +
+				`1,` +
+				`{"jsonrpc":"2.0","method":"sum","params":{"a":1},"id":2}]`,
+			wantStatus: http.StatusOK,
+			want: `[{"jsonrpc":"2.0","result":3,"id":1},` +
+				`{"jsonrpc":"2.0","error":{"code":-32600,"message":"invalid request"},"id":null},` +
+				`{"jsonrpc":"2.0","error":{"code":-32602,"message":"invalid params: json: cannot unmarshal object into Go value of type jsonrpc.sumParams"},"id":2}]`,
+		},
+		"batch of notifications": {
+			body:       `[{"jsonrpc":"2.0","method":"sum"},{"jsonrpc":"2.0","method":"nope"}]`,
+			wantStatus: http.StatusNoContent,
+		},
+	}
+	s := newTestServer()
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			s.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected: %v, got: %v", tt.wantStatus, rec.Code)
+			}
+			if got := strings.TrimSpace(rec.Body.String()); got != tt.want {
+				t.Errorf("expected: %v, got: %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestServerHTTPErrors(t *testing.T) {
+	s := newTestServer()
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusMethodNotAllowed || rec.Header().Get("Allow") != http.MethodPost {
+		t.Errorf("expected: %v, got: %v %v", http.StatusMethodNotAllowed, rec.Code, rec.Header())
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "text/plain")
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("expected: %v, got: %v", http.StatusUnsupportedMediaType, rec.Code)
+	}
+}
+
+func TestRegisterTwice(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected a panic")
+		}
+	}()
+	s := newTestServer()
+	Register(s, "sum", func(ctx context.Context, params json.RawMessage) (int, error) {
+		return 0, nil
+	})
+}
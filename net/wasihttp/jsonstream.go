@@ -0,0 +1,137 @@
+package wasihttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	// jsonStreamFlushBytes is the default size of the buffered records
+	// flushed at once by a JSONStreamWriter.
+	jsonStreamFlushBytes = 32 << 10
+	// jsonStreamFlushInterval is the default delay of the buffered records.
+	jsonStreamFlushInterval = time.Second
+)
+
+// JSONStreamWriter encodes records to the response stream as they are
+// produced, for exports or reports larger than fits in memory. Records are
+// buffered and flushed to the host once FlushBytes are buffered, or once
+// FlushInterval passed since the last flush, so that the client receives them
+// incrementally without a host call for each record.
+//
+// The header of the response is written with the first flush, the status
+// defaults to 200 OK. A JSONStreamWriter is not safe for concurrent use.
+type JSONStreamWriter struct {
+	// FlushBytes is the size of the buffered records flushed at once, 32 KiB
+	// by default.
+	FlushBytes int
+	// FlushInterval is the longest delay of a buffered record, 1 second by
+	// default. It is checked when a record is encoded.
+	FlushInterval time.Duration
+
+	w     http.ResponseWriter
+	buf   bytes.Buffer
+	enc   *json.Encoder
+	array bool
+	count int
+	last  time.Time
+	err   error
+}
+
+// NewNDJSONWriter returns a JSONStreamWriter encoding each record as a line of
+// newline-delimited JSON. The Content-Type defaults to application/x-ndjson.
+func NewNDJSONWriter(w http.ResponseWriter) *JSONStreamWriter {
+	return newJSONStreamWriter(w, "application/x-ndjson", false)
+}
+
+// NewJSONArrayWriter returns a JSONStreamWriter encoding the records as the
+// elements of a JSON array, closed by [JSONStreamWriter.Close]. The
+// Content-Type defaults to application/json.
+func NewJSONArrayWriter(w http.ResponseWriter) *JSONStreamWriter {
+	return newJSONStreamWriter(w, "application/json", true)
+}
+
+func newJSONStreamWriter(w http.ResponseWriter, contentType string, array bool) *JSONStreamWriter {
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", contentType)
+	}
+	sw := &JSONStreamWriter{
+		FlushBytes:    jsonStreamFlushBytes,
+		FlushInterval: jsonStreamFlushInterval,
+		w:             w,
+		array:         array,
+		last:          time.Now(),
+	}
+	sw.enc = json.NewEncoder(&sw.buf)
+	return sw
+}
+
+// Encode buffers v as the next record, and flushes the buffered records if
+// needed. A value failing to encode is skipped, the stream stays valid. Errors
+// writing to the response stream are returned by any later call.
+func (sw *JSONStreamWriter) Encode(v any) error {
+	if sw.err != nil {
+		return sw.err
+	}
+
+	n := sw.buf.Len()
+	if sw.array {
+		if sw.count == 0 {
+			sw.buf.WriteByte('[')
+		} else {
+			sw.buf.WriteByte(',')
+		}
+	}
+	if err := sw.enc.Encode(v); err != nil {
+		sw.buf.Truncate(n)
+		return fmt.Errorf("failed to encode record: %w", err)
+	}
+	sw.count++
+
+	if sw.buf.Len() >= sw.FlushBytes || time.Since(sw.last) >= sw.FlushInterval {
+		return sw.Flush()
+	}
+	return nil
+}
+
+// Count returns the number of records encoded.
+func (sw *JSONStreamWriter) Count() int {
+	return sw.count
+}
+
+// Flush writes the buffered records to the response stream and flushes it.
+func (sw *JSONStreamWriter) Flush() error {
+	if sw.err != nil {
+		return sw.err
+	}
+	if sw.buf.Len() > 0 {
+		if _, err := sw.w.Write(sw.buf.Bytes()); err != nil {
+			sw.err = fmt.Errorf("failed to write records: %w", err)
+			return sw.err
+		}
+		sw.buf.Reset()
+	}
+	if err := http.NewResponseController(sw.w).Flush(); err != nil && err != http.ErrNotSupported {
+		sw.err = fmt.Errorf("failed to flush records: %w", err)
+		return sw.err
+	}
+	sw.last = time.Now()
+	return nil
+}
+
+// Close ends the JSON array, if any, and flushes the buffered records. It
+// does not close the response, which ends once the handler returns.
+func (sw *JSONStreamWriter) Close() error {
+	if sw.array && sw.err == nil {
+		if sw.count == 0 {
+			sw.buf.WriteByte('[')
+		}
+		sw.buf.WriteString("]\n")
+		// NOTE: the array is closed once, a second Close only flushes
+		sw.array = false
+	}
+	return sw.Flush()
+}
@@ -0,0 +1,79 @@
+package wasihttp
+
+import (
+	"encoding/json"
+	"math"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestJSONStreamWriter(t *testing.T) {
+	tests := map[string]struct {
+		array       bool
+		records     []any
+		want        string
+		contentType string
+	}{
+		"ndjson":      {records: []any{order{ID: 1}, math.Inf(1), order{ID: 2}}, want: "{\"id\":1,\"items\":null}\n{\"id\":2,\"items\":null}\n", contentType: "application/x-ndjson"},
+		"ndjson none": {want: "", contentType: "application/x-ndjson"},
+		"array":       {array: true, records: []any{order{ID: 1}, math.Inf(1), order{ID: 2}}, want: "[{\"id\":1,\"items\":null}\n,{\"id\":2,\"items\":null}\n]\n", contentType: "application/json"},
+		"array none":  {array: true, want: "[]\n", contentType: "application/json"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			var sw *JSONStreamWriter
+			if tt.array {
+				sw = NewJSONArrayWriter(rec)
+			} else {
+				sw = NewNDJSONWriter(rec)
+			}
+			for _, v := range tt.records {
+				sw.Encode(v)
+			}
+			if err := sw.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if got := rec.Body.String(); got != tt.want {
+				t.Errorf("expected: %q, got: %q", tt.want, got)
+			}
+			if got := rec.Header().Get("Content-Type"); got != tt.contentType {
+				t.Errorf("expected: %v, got: %v", tt.contentType, got)
+			}
+			if tt.array && !json.Valid(rec.Body.Bytes()) {
+				t.Errorf("expected a valid JSON array, got: %s", rec.Body)
+			}
+		})
+	}
+}
+
+func TestJSONStreamWriterFlush(t *testing.T) {
+	rec := httptest.NewRecorder()
+	sw := NewJSONArrayWriter(rec)
+	sw.FlushBytes = 90
+	for i := range 3 {
+		if err := sw.Encode(order{ID: i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if rec.Flushed || rec.Body.Len() != 0 {
+		t.Errorf("expected the records to be buffered, got: %q", rec.Body)
+	}
+	if err := sw.Encode(order{ID: 3, Items: []string{"a"}}); err != nil {
+		t.Fatal(err)
+	}
+	if !rec.Flushed || rec.Body.Len() < 90 {
+		t.Errorf("expected the records to be flushed, got: %q", rec.Body)
+	}
+
+	rec = httptest.NewRecorder()
+	sw = NewNDJSONWriter(rec)
+	sw.FlushInterval = 0
+	sw.Encode(order{ID: 1})
+	if !rec.Flushed {
+		t.Error("expected the record to be flushed after the interval")
+	}
+	if sw.Count() != 1 {
+		t.Errorf("expected: %v, got: %v", 1, sw.Count())
+	}
+}
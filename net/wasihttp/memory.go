@@ -0,0 +1,128 @@
+package wasihttp
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync/atomic"
+)
+
+// MemoryLimitError is returned when a request exceeds the budget of
+// [LimitMemory].
+type MemoryLimitError struct {
+	Limit int64
+}
+
+func (e *MemoryLimitError) Error() string {
+	return fmt.Sprintf("request exceeded its memory budget of %d bytes", e.Limit)
+}
+
+// MemoryBudget accounts the bytes buffered in component memory while handling
+// a request.
+type MemoryBudget struct {
+	limit    int64
+	used     atomic.Int64
+	exceeded atomic.Bool
+}
+
+// Used returns the number of bytes accounted so far.
+func (b *MemoryBudget) Used() int64 {
+	return b.used.Load()
+}
+
+// Exceeded reports whether a reservation failed.
+func (b *MemoryBudget) Exceeded() bool {
+	return b.exceeded.Load()
+}
+
+// Reserve accounts n more bytes, failing with a [RequestError] of status 507
+// Insufficient Storage wrapping a [MemoryLimitError] once the budget is
+// exceeded.
+func (b *MemoryBudget) Reserve(n int64) error {
+	if b.used.Add(n) > b.limit {
+		b.exceeded.Store(true)
+		return &RequestError{Status: http.StatusInsufficientStorage, Err: &MemoryLimitError{Limit: b.limit}}
+	}
+	return nil
+}
+
+// Release returns n bytes to the budget, once the buffer holding them is
+// dropped.
+func (b *MemoryBudget) Release(n int64) {
+	b.used.Add(-n)
+}
+
+type memoryBudgetKey struct{}
+
+// MemoryBudgetFromContext returns the budget of the request, if it is handled
+// by [LimitMemory].
+func MemoryBudgetFromContext(ctx context.Context) (*MemoryBudget, bool) {
+	b, ok := ctx.Value(memoryBudgetKey{}).(*MemoryBudget)
+	return b, ok
+}
+
+// ReserveMemory accounts n bytes buffered by a handler or middleware against
+// the budget of the request, if any, see [MemoryBudget.Reserve].
+func ReserveMemory(ctx context.Context, n int64) error {
+	if b, ok := MemoryBudgetFromContext(ctx); ok {
+		return b.Reserve(n)
+	}
+	return nil
+}
+
+// LimitMemory returns a handler accounting the bytes buffered by next against
+// a budget of limit bytes per request: every byte read from the request body,
+// which covers [DecodeJSON] and [ParseMultipartForm], and the reservations
+// made with [ReserveMemory], e.g. by middleware buffering responses.
+//
+// Once the budget is exceeded, body reads and reservations fail with a
+// [RequestError] of status 507 Insufficient Storage and the request is logged
+// to stderr. If next returns without writing a response, the 507 response is
+// written for it.
+//
+// Body bytes are accounted when read, as the handler may keep them, handlers
+// streaming the body call [MemoryBudget.Release] for the bytes they drop.
+func LimitMemory(limit int64, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b := &MemoryBudget{limit: limit}
+		r = r.WithContext(context.WithValue(r.Context(), memoryBudgetKey{}, b))
+		if r.Body != nil && r.Body != http.NoBody {
+			r.Body = &budgetedBody{ReadCloser: r.Body, budget: b}
+		}
+
+		wroteHeader := false
+		w = WrapResponseWriter(w, ResponseHooks{
+			WriteHeader: func(int, http.Header) { wroteHeader = true },
+		})
+		next.ServeHTTP(w, r)
+
+		if !b.Exceeded() {
+			return
+		}
+		fmt.Fprintf(os.Stderr, "http: %s %s exceeded its memory budget of %d bytes, %d bytes requested\n", r.Method, r.URL.Path, limit, b.Used())
+		if !wroteHeader {
+			http.Error(w, http.StatusText(http.StatusInsufficientStorage), http.StatusInsufficientStorage)
+		}
+	})
+}
+
+// budgetedBody accounts the bytes read from a request body.
+type budgetedBody struct {
+	io.ReadCloser
+	budget *MemoryBudget
+	err    error
+}
+
+func (b *budgetedBody) Read(p []byte) (int, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+	n, err := b.ReadCloser.Read(p)
+	if rerr := b.budget.Reserve(int64(n)); rerr != nil {
+		b.err = rerr
+		return n, rerr
+	}
+	return n, err
+}
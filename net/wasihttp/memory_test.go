@@ -0,0 +1,77 @@
+package wasihttp
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLimitMemory(t *testing.T) {
+	type payload struct {
+		Name string `json:"name"`
+	}
+
+	h := LimitMemory(64, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/json":
+			v, err := DecodeJSON[payload](r)
+			if err != nil {
+				http.Error(w, err.Error(), ErrorStatus(err))
+				return
+			}
+			io.WriteString(w, v.Name)
+		case "/silent":
+			io.ReadAll(r.Body)
+		case "/reserve":
+			err := ReserveMemory(r.Context(), 128)
+			var limitErr *MemoryLimitError
+			if !errors.As(err, &limitErr) || limitErr.Limit != 64 {
+				t.Errorf("expected a MemoryLimitError, got: %v", err)
+			}
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+
+	for _, tc := range []struct {
+		path   string
+		body   string
+		status int
+	}{
+		{"/json", `{"name":"gopher"}`, http.StatusOK},
+		{"/json", `{"name":"` + strings.Repeat("x", 100) + `"}`, http.StatusInsufficientStorage},
+		{"/silent", strings.Repeat("x", 100), http.StatusInsufficientStorage},
+		{"/reserve", "", http.StatusAccepted},
+	} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, tc.path, strings.NewReader(tc.body)))
+		if rec.Code != tc.status {
+			t.Errorf("%s: expected: %v, got: %v", tc.path, tc.status, rec.Code)
+		}
+	}
+}
+
+func TestMemoryBudget(t *testing.T) {
+	b := &MemoryBudget{limit: 10}
+	if err := b.Reserve(8); err != nil {
+		t.Fatal(err)
+	}
+	b.Release(4)
+	if err := b.Reserve(6); err != nil {
+		t.Fatal(err)
+	}
+	if b.Exceeded() {
+		t.Error("expected the budget not to be exceeded")
+	}
+	if err := b.Reserve(1); ErrorStatus(err) != http.StatusInsufficientStorage {
+		t.Errorf("expected: %v, got: %v", http.StatusInsufficientStorage, err)
+	}
+	if !b.Exceeded() {
+		t.Error("expected the budget to be exceeded")
+	}
+	if err := ReserveMemory(httptest.NewRequest(http.MethodGet, "/", nil).Context(), 1<<40); err != nil {
+		t.Errorf("expected no budget outside LimitMemory, got: %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// MethodOverrideHeader carries the method of a POST request sent by a client
+// behind a proxy only passing GET and POST.
+const MethodOverrideHeader = "X-HTTP-Method-Override"
+
+// MethodOverride returns a handler passing POST requests with a
+// [MethodOverrideHeader] naming one of methods on to next with that method.
+// methods defaults to PUT, PATCH and DELETE, other overrides are ignored.
+func MethodOverride(next http.Handler, methods ...string) http.Handler {
+	if len(methods) == 0 {
+		methods = []string{http.MethodPut, http.MethodPatch, http.MethodDelete}
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method := strings.ToUpper(strings.TrimSpace(r.Header.Get(MethodOverrideHeader)))
+		if r.Method != http.MethodPost || !slices.Contains(methods, method) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		r = r.Clone(r.Context())
+		r.Method = method
+		r.Header.Del(MethodOverrideHeader)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// allowMethods are the methods listed by [Options], in order.
+var allowMethods = []string{
+	http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut,
+	http.MethodPatch, http.MethodDelete, http.MethodOptions,
+}
+
+// Options returns a handler answering OPTIONS requests to the routes of mux
+// with a 204 listing the methods of the route in the Allow header. Requests
+// matching a pattern of mux accepting OPTIONS, e.g. "OPTIONS /items" or
+// "/items", and requests to paths without routes are passed on to mux.
+func Options(mux *http.ServeMux) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodOptions || r.URL.Path == "*" {
+			mux.ServeHTTP(w, r)
+			return
+		}
+		if _, pattern := mux.Handler(r); pattern != "" {
+			mux.ServeHTTP(w, r)
+			return
+		}
+
+		var allow []string
+		for _, method := range allowMethods {
+			probe := r.Clone(r.Context())
+			probe.Method = method
+			if _, pattern := mux.Handler(probe); pattern != "" || method == http.MethodOptions {
+				allow = append(allow, method)
+			}
+		}
+		if len(allow) == 1 {
+			mux.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Set("Allow", strings.Join(allow, ", "))
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
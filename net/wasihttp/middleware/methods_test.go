@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMethodOverride(t *testing.T) {
+	tests := map[string]struct {
+		method   string
+		override string
+		want     string
+	}{
+		"delete":    {method: http.MethodPost, override: "delete", want: http.MethodDelete},
+		"patch":     {method: http.MethodPost, override: http.MethodPatch, want: http.MethodPatch},
+		"no header": {method: http.MethodPost, want: http.MethodPost},
+		"not post":  {method: http.MethodGet, override: http.MethodDelete, want: http.MethodGet},
+		"forbidden": {method: http.MethodPost, override: http.MethodConnect, want: http.MethodPost},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got, header string
+			h := MethodOverride(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got, header = r.Method, r.Header.Get(MethodOverrideHeader)
+			}))
+			r := httptest.NewRequest(tt.method, "/", nil)
+			if tt.override != "" {
+				r.Header.Set(MethodOverrideHeader, tt.override)
+			}
+			h.ServeHTTP(httptest.NewRecorder(), r)
+			if got != tt.want {
+				t.Errorf("expected: %v, got: %v", tt.want, got)
+			}
+			if got != tt.method && header != "" {
+				t.Errorf("expected the header to be removed, got: %q", header)
+			}
+		})
+	}
+}
+
+func TestOptions(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /items", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("POST /items", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("DELETE /items/{id}", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("OPTIONS /custom", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	h := Options(mux)
+
+	tests := map[string]struct {
+		path   string
+		status int
+		allow  string
+	}{
+		"items":   {path: "/items", status: http.StatusNoContent, allow: "GET, HEAD, POST, OPTIONS"},
+		"item":    {path: "/items/1", status: http.StatusNoContent, allow: "DELETE, OPTIONS"},
+		"custom":  {path: "/custom", status: http.StatusTeapot},
+		"unknown": {path: "/unknown", status: http.StatusNotFound},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodOptions, tt.path, nil))
+			if rec.Code != tt.status {
+				t.Errorf("expected: %v, got: %v", tt.status, rec.Code)
+			}
+			if got := rec.Header().Get("Allow"); got != tt.allow {
+				t.Errorf("expected: %q, got: %q", tt.allow, got)
+			}
+		})
+	}
+}
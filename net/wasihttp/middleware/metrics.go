@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"net/http"
+
+	"go.wasmcloud.dev/component/telemetry/wasitel"
+	"go.wasmcloud.dev/component/time/wasiclock"
+)
+
+// DurationBounds are the bucket bounds of the request duration histogram, in
+// seconds, as recommended by the semantic conventions.
+var DurationBounds = []float64{0.005, 0.01, 0.025, 0.05, 0.075, 0.1, 0.25, 0.5, 0.75, 1, 2.5, 5, 7.5, 10}
+
+// Metrics returns a handler recording the number, duration and concurrency of
+// requests passed on to next in meter:
+//
+//   - http.server.request.count, a counter of handled requests
+//   - http.server.request.duration, a histogram of the handling time in seconds
+//   - http.server.active_requests, the number of requests being handled
+func Metrics(meter *wasitel.Meter, next http.Handler) http.Handler {
+	requests := meter.Counter("http.server.request.count", "Number of HTTP server requests.", "{request}")
+	duration := meter.Histogram("http.server.request.duration", "Duration of HTTP server requests.", "s", DurationBounds...)
+	active := meter.UpDownCounter("http.server.active_requests", "Number of active HTTP server requests.", "{request}")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attrs := []wasitel.Attribute{
+			wasitel.String("http.request.method", requestMethod(r)),
+			wasitel.String("url.scheme", scheme(r)),
+		}
+		active.Add(1, attrs...)
+		start := wasiclock.NowFunc()
+
+		sw := &statusWriter{ResponseWriter: w}
+		defer func() {
+			err := recover()
+			status := sw.status()
+			if err != nil {
+				status = http.StatusInternalServerError
+			}
+			active.Add(-1, attrs...)
+
+			attrs := append(attrs, wasitel.Int("http.response.status_code", status))
+			requests.Add(1, attrs...)
+			duration.Record(wasiclock.Since(start).Seconds(), attrs...)
+
+			if err != nil {
+				panic(err)
+			}
+		}()
+
+		next.ServeHTTP(sw, r)
+	})
+}
@@ -0,0 +1,174 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"go.wasmcloud.dev/component/telemetry/wasitel"
+)
+
+// recorder is a SpanExporter keeping the exported spans.
+type recorder struct {
+	mu    sync.Mutex
+	spans []wasitel.SpanData
+}
+
+func (r *recorder) ExportSpans(_ context.Context, _ []wasitel.Attribute, spans []wasitel.SpanData) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.spans = append(r.spans, spans...)
+	return nil
+}
+
+func attribute(attrs []wasitel.Attribute, key string) any {
+	for _, attr := range attrs {
+		if attr.Key == key {
+			return attr.Value
+		}
+	}
+	return nil
+}
+
+// handler responds with the status of the "status" query parameter, and
+// panics on /panic.
+var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/panic" {
+		panic("boom")
+	}
+	switch r.URL.Query().Get("status") {
+	case "404":
+		http.NotFound(w, r)
+	case "500":
+		http.Error(w, "oops", http.StatusInternalServerError)
+	default:
+		w.Write([]byte(wasitel.SpanFromContext(r.Context()).SpanContext().TraceID.String()))
+	}
+})
+
+func serve(h http.Handler, req *http.Request) (rec *httptest.ResponseRecorder) {
+	rec = httptest.NewRecorder()
+	defer func() { recover() }()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestTrace(t *testing.T) {
+	exporter := &recorder{}
+	tracer := wasitel.NewTracer(exporter, wasitel.TracerOptions{})
+	h := Trace(tracer, handler)
+
+	parent, _ := wasitel.ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	req := httptest.NewRequest("GET", "http://orders.local/orders?limit=1", nil)
+	req.Header.Set("User-Agent", "test")
+	req = req.WithContext(wasitel.ContextWithRemoteSpanContext(req.Context(), parent))
+	if rec := serve(h, req); rec.Body.String() != parent.TraceID.String() {
+		t.Errorf("expected the handler to run in the span, got: %q", rec.Body.String())
+	}
+	serve(h, httptest.NewRequest("PURGE", "/orders?status=404", nil))
+	serve(h, httptest.NewRequest("POST", "/orders?status=500", nil))
+	serve(h, httptest.NewRequest("GET", "/panic", nil))
+
+	if err := tracer.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(exporter.spans) != 4 {
+		t.Fatalf("expected: %v, got: %v", 4, len(exporter.spans))
+	}
+
+	span := exporter.spans[0]
+	if span.Name != "GET" || span.Kind != wasitel.SpanKindServer || span.Parent != parent.SpanID {
+		t.Errorf("unexpected span: %+v", span)
+	}
+	for key, want := range map[string]any{
+		"http.request.method":       "GET",
+		"url.scheme":                "http",
+		"url.path":                  "/orders",
+		"url.query":                 "limit=1",
+		"server.address":            "orders.local",
+		"user_agent.original":       "test",
+		"network.protocol.version":  "1.1",
+		"http.response.status_code": int64(200),
+	} {
+		if got := attribute(span.Attributes, key); got != want {
+			t.Errorf("expected %s: %v, got: %v", key, want, got)
+		}
+	}
+
+	tests := map[string]struct {
+		span   wasitel.SpanData
+		name   string
+		status wasitel.StatusCode
+	}{
+		"client error": {span: exporter.spans[1], name: "_OTHER", status: wasitel.StatusUnset},
+		"server error": {span: exporter.spans[2], name: "POST", status: wasitel.StatusError},
+		"panic":        {span: exporter.spans[3], name: "GET", status: wasitel.StatusError},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if tt.span.Name != tt.name || tt.span.Status.Code != tt.status {
+				t.Errorf("unexpected span: %+v", tt.span)
+			}
+		})
+	}
+	if got := attribute(exporter.spans[1].Attributes, "http.request.method_original"); got != "PURGE" {
+		t.Errorf("expected: %v, got: %v", "PURGE", got)
+	}
+}
+
+func TestTraceFlush(t *testing.T) {
+	tracer := wasitel.NewTracer(&recorder{}, wasitel.TracerOptions{})
+	h := Trace(tracer, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("one"))
+		w.(http.Flusher).Flush()
+	}))
+
+	rec := serve(h, httptest.NewRequest("GET", "/stream", nil))
+	if !rec.Flushed {
+		t.Errorf("expected the response to be flushed")
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	meter := wasitel.NewMeter(wasitel.MetricExporterFunc(func(context.Context, []wasitel.Attribute, []wasitel.MetricData) error {
+		return errors.New("unexpected export")
+	}), wasitel.MeterOptions{})
+	h := Metrics(meter, handler)
+
+	serve(h, httptest.NewRequest("GET", "/orders", nil))
+	serve(h, httptest.NewRequest("GET", "/orders", nil))
+	serve(h, httptest.NewRequest("GET", "/orders?status=404", nil))
+	serve(h, httptest.NewRequest("GET", "/panic", nil))
+
+	metrics := map[string]wasitel.MetricData{}
+	for _, m := range meter.Collect() {
+		metrics[m.Name] = m
+	}
+
+	counts := map[int64]float64{}
+	for _, p := range metrics["http.server.request.count"].Points {
+		counts[attribute(p.Attributes, "http.response.status_code").(int64)] = p.Value
+	}
+	if want := map[int64]float64{200: 2, 404: 1, 500: 1}; len(counts) != len(want) || counts[200] != 2 || counts[404] != 1 || counts[500] != 1 {
+		t.Errorf("expected: %v, got: %v", want, counts)
+	}
+
+	var observed uint64
+	for _, p := range metrics["http.server.request.duration"].Points {
+		observed += p.Count
+		if p.Sum <= 0 || len(p.Bounds) != len(DurationBounds) {
+			t.Errorf("unexpected point: %+v", p)
+		}
+	}
+	if observed != 4 {
+		t.Errorf("expected: %v, got: %v", 4, observed)
+	}
+
+	active := metrics["http.server.active_requests"]
+	if len(active.Points) != 1 || active.Points[0].Value != 0 {
+		t.Errorf("expected no active requests, got: %+v", active.Points)
+	}
+}
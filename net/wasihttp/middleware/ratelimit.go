@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.wasmcloud.dev/component/kv/wasikv"
+	"go.wasmcloud.dev/component/time/wasiclock"
+)
+
+// RateLimit returns a handler allowing limit requests per window and key,
+// e.g. a client identifier taken from a header, counted in bucket so the
+// limit holds across the instances and replicas of the component. Rejected
+// requests are answered with 429 Too Many Requests and a Retry-After header,
+// see [KeyValueRateLimitStore].
+func RateLimit(bucket *wasikv.Bucket, key func(*http.Request) string, limit int, window time.Duration, next http.Handler) http.Handler {
+	store := NewKeyValueRateLimitStore(bucket, limit, window)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if retryAfter, ok := store.Take(key(r)); !ok {
+			seconds := int64(math.Ceil(retryAfter.Seconds()))
+			w.Header().Set("Retry-After", strconv.FormatInt(seconds, 10))
+			http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// KeyValueRateLimitStore is a wasihttp.RateLimitStore counting the requests
+// of a sliding window with wasikv counters, e.g. for wasihttp.RateLimitByKey.
+// The count of the previous window is weighted by its overlap with the sliding
+// window, rejected requests are not counted.
+//
+// NOTE: `wasi:keyvalue@0.2.0-draft` has no expiry, the counters of a window
+// are deleted by the first request of the window after next. Keys that stop
+// sending requests leave up to two counters behind.
+type KeyValueRateLimitStore struct {
+	bucket *wasikv.Bucket
+	limit  int
+	window time.Duration
+}
+
+// NewKeyValueRateLimitStore returns a store allowing limit requests per window
+// and key, counted in bucket.
+func NewKeyValueRateLimitStore(bucket *wasikv.Bucket, limit int, window time.Duration) *KeyValueRateLimitStore {
+	return &KeyValueRateLimitStore{bucket: bucket, limit: limit, window: window}
+}
+
+func (s *KeyValueRateLimitStore) counter(key string, window int64) string {
+	return "ratelimit:" + key + ":" + strconv.FormatInt(window, 10)
+}
+
+// Take counts a request of key, unless it exceeds the limit. Requests are let
+// through when the bucket fails, an unavailable store must not take the
+// component down.
+//
+// NOTE: the count is checked before it is incremented, concurrent requests of
+// other instances may exceed the limit by the requests in flight
+func (s *KeyValueRateLimitStore) Take(key string) (time.Duration, bool) {
+	now := wasiclock.NowFunc()
+	window := now.UnixNano() / int64(s.window)
+	elapsed := float64(now.UnixNano()%int64(s.window)) / float64(s.window)
+
+	previous, err := s.bucket.Incr(s.counter(key, window-1), 0)
+	if err != nil {
+		return 0, true
+	}
+	current, err := s.bucket.Incr(s.counter(key, window), 0)
+	if err != nil {
+		return 0, true
+	}
+
+	limit := float64(s.limit)
+	if float64(previous)*(1-elapsed)+float64(current+1) > limit {
+		// NOTE: the request fits once the previous window overlaps the
+		// sliding window little enough, or in the next window
+		remaining := 1 - elapsed
+		if free := limit - float64(current+1); free >= 0 && previous > 0 {
+			remaining = 1 - free/float64(previous) - elapsed
+		}
+		return time.Duration(remaining * float64(s.window)), false
+	}
+
+	if n, err := s.bucket.Incr(s.counter(key, window), 1); err == nil && n == 1 {
+		s.bucket.Delete(s.counter(key, window-2))
+	}
+	return 0, true
+}
@@ -0,0 +1,77 @@
+//go:build !wasm
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.wasmcloud.dev/component/internal/fakehost"
+	"go.wasmcloud.dev/component/internal/memory"
+	"go.wasmcloud.dev/component/kv/wasikv"
+	"go.wasmcloud.dev/component/time/wasiclock"
+)
+
+func memoryBucket(t *testing.T) (*wasikv.Bucket, *memory.Bucket) {
+	t.Helper()
+	mem := memory.NewBucket(nil)
+	name := fakehost.Buckets.Name("ratelimit-")
+	fakehost.Buckets.Set(name, mem)
+	defer fakehost.Buckets.Remove(name)
+
+	b, err := wasikv.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b, mem
+}
+
+func TestRateLimit(t *testing.T) {
+	now := time.Unix(600, 0)
+	prev := wasiclock.NowFunc
+	wasiclock.NowFunc = func() time.Time { return now }
+	t.Cleanup(func() { wasiclock.NowFunc = prev })
+
+	bucket, mem := memoryBucket(t)
+	h := RateLimit(bucket, func(r *http.Request) string { return r.Header.Get("X-Client") }, 2, time.Minute, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	steps := []struct {
+		advance    time.Duration
+		client     string
+		status     int
+		retryAfter string
+	}{
+		{client: "a", status: http.StatusOK},
+		{client: "a", status: http.StatusOK},
+		{client: "a", status: http.StatusTooManyRequests, retryAfter: "60"},
+		{client: "b", status: http.StatusOK},
+		// 2 requests in the previous window, weighted by 3/4
+		{advance: 75 * time.Second, client: "a", status: http.StatusTooManyRequests, retryAfter: "15"},
+		// weighted by 1/4
+		{advance: 30 * time.Second, client: "a", status: http.StatusOK},
+		{advance: 60 * time.Second, client: "a", status: http.StatusOK},
+	}
+	for i, step := range steps {
+		now = now.Add(step.advance)
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("X-Client", step.client)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, r)
+
+		if rec.Code != step.status {
+			t.Errorf("step %d: expected: %v, got: %v", i, step.status, rec.Code)
+		}
+		if got := rec.Header().Get("Retry-After"); got != step.retryAfter {
+			t.Errorf("step %d: expected Retry-After: %q, got: %q", i, step.retryAfter, got)
+		}
+	}
+
+	// NOTE: the counters of window 10 are deleted in window 12
+	for key := range mem.Snapshot() {
+		if key == "ratelimit:a:10" {
+			t.Errorf("expected %s to be deleted", key)
+		}
+	}
+}
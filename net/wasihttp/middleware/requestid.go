@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"go.wasmcloud.dev/component/internal/requestid"
+	"go.wasmcloud.dev/component/rand/wasirand"
+)
+
+// RequestIDHeader carries the request ID, it is reused from incoming requests
+// and set on responses and outgoing requests.
+const RequestIDHeader = requestid.Header
+
+// RequestID returns a handler attaching the request ID to the request context
+// before passing the request on to next. The ID is taken from the
+// [RequestIDHeader], unless it is missing or longer than 128 printable ASCII
+// characters, or generated as a UUIDv7. It is echoed on the response, logged
+// by wasilog.RequestLogger and forwarded by the wasihttp.Transport.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !requestid.Valid(id) {
+			id = wasirand.UUIDv7()
+		}
+		w.Header().Set(RequestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(requestid.NewContext(r.Context(), id)))
+	})
+}
+
+// RequestIDFromContext returns the request ID set by [RequestID], if any.
+func RequestIDFromContext(ctx context.Context) string {
+	return requestid.FromContext(ctx)
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRequestID(t *testing.T) {
+	tests := map[string]struct {
+		header string
+		want   string
+	}{
+		"incoming":  {header: "req-1", want: "req-1"},
+		"generated": {},
+		"invalid":   {header: "req 1"},
+		"too long":  {header: strings.Repeat("a", 129)},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got string
+			h := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = RequestIDFromContext(r.Context())
+			}))
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				r.Header.Set(RequestIDHeader, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, r)
+
+			if tt.want != "" && got != tt.want {
+				t.Errorf("expected: %v, got: %v", tt.want, got)
+			}
+			// NOTE: generated ids are UUIDv7
+			if tt.want == "" && (len(got) != 36 || got[14] != '7') {
+				t.Errorf("expected a UUIDv7, got: %v", got)
+			}
+			if echoed := rec.Header().Get(RequestIDHeader); echoed != got {
+				t.Errorf("expected: %v, got: %v", got, echoed)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"go.wasmcloud.dev/component/telemetry/wasitel"
+)
+
+// Trace returns a handler starting a server span for every request passed on
+// to next. The span is a child of the trace context extracted by the
+// `wasihttp` server, and is active in the request context.
+func Trace(tracer *wasitel.Tracer, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method := requestMethod(r)
+		ctx, span := tracer.Start(r.Context(), method, wasitel.SpanKindServer, requestAttributes(r, method)...)
+
+		sw := &statusWriter{ResponseWriter: w}
+		defer func() {
+			if err := recover(); err != nil {
+				span.RecordError(fmt.Errorf("panic: %v", err))
+				span.End()
+				panic(err)
+			}
+			status := sw.status()
+			span.SetAttributes(wasitel.Int("http.response.status_code", status))
+			// NOTE: 4xx are client errors, which are not errors of the server span
+			if status >= http.StatusInternalServerError {
+				span.SetStatus(wasitel.StatusError, "")
+			}
+			span.End()
+		}()
+
+		next.ServeHTTP(sw, r.WithContext(ctx))
+	})
+}
+
+// requestAttributes returns the OpenTelemetry semantic convention attributes
+// of an incoming request.
+func requestAttributes(r *http.Request, method string) []wasitel.Attribute {
+	attrs := []wasitel.Attribute{
+		wasitel.String("http.request.method", method),
+		wasitel.String("url.scheme", scheme(r)),
+		wasitel.String("url.path", r.URL.Path),
+		wasitel.String("network.protocol.version", strconv.Itoa(r.ProtoMajor)+"."+strconv.Itoa(r.ProtoMinor)),
+	}
+	if method != r.Method {
+		attrs = append(attrs, wasitel.String("http.request.method_original", r.Method))
+	}
+	if r.URL.RawQuery != "" {
+		attrs = append(attrs, wasitel.String("url.query", r.URL.RawQuery))
+	}
+	if host := r.Host; host != "" {
+		attrs = append(attrs, wasitel.String("server.address", host))
+	}
+	if ua := r.UserAgent(); ua != "" {
+		attrs = append(attrs, wasitel.String("user_agent.original", ua))
+	}
+	return attrs
+}
+
+// knownMethods are the methods reported as is, per the semantic conventions.
+var knownMethods = map[string]bool{
+	http.MethodConnect: true,
+	http.MethodDelete:  true,
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodPatch:   true,
+	http.MethodPost:    true,
+	http.MethodPut:     true,
+	http.MethodTrace:   true,
+}
+
+// requestMethod returns the method of r, or "_OTHER" for unknown methods to
+// bound the span name and metric cardinality.
+func requestMethod(r *http.Request) string {
+	if knownMethods[r.Method] {
+		return r.Method
+	}
+	return "_OTHER"
+}
+
+func scheme(r *http.Request) string {
+	if r.URL.Scheme != "" {
+		return r.URL.Scheme
+	}
+	if r.TLS != nil {
+		return "https"
+	}
+	return "http"
+}
+
+// statusWriter records the response status code.
+type statusWriter struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+func (w *statusWriter) status() int {
+	if w.statusCode == 0 {
+		return http.StatusOK
+	}
+	return w.statusCode
+}
+
+func (w *statusWriter) WriteHeader(statusCode int) {
+	if w.statusCode == 0 {
+		w.statusCode = statusCode
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *statusWriter) Write(p []byte) (int, error) {
+	if w.statusCode == 0 {
+		w.statusCode = http.StatusOK
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush implements http.Flusher, so streaming handlers, e.g. of Connect
+// RPCs, flush through the middleware.
+func (w *statusWriter) Flush() {
+	if w.statusCode == 0 {
+		w.statusCode = http.StatusOK
+	}
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
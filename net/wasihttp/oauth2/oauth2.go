@@ -0,0 +1,289 @@
+package oauth2
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.wasmcloud.dev/component/kv/wasikv"
+	"go.wasmcloud.dev/component/net/wasihttp"
+	"go.wasmcloud.dev/component/secrets"
+	"go.wasmcloud.dev/component/time/wasiclock"
+)
+
+// expiryDelta is subtracted from the expiry of tokens, so they are not used
+// right before expiring.
+const expiryDelta = 10 * time.Second
+
+// maxTokenResponseBytes is the size limit of token responses.
+const maxTokenResponseBytes = 1 << 20
+
+// Token is an OAuth 2.0 access token.
+type Token struct {
+	AccessToken string `json:"access_token"`
+	// TokenType is the type of the token, "Bearer" if empty.
+	TokenType string `json:"token_type,omitempty"`
+	// Expiry is the expiry time of the token, zero if it does not expire.
+	Expiry time.Time `json:"expiry,omitempty"`
+}
+
+// Valid reports whether t is set and does not expire within 10 seconds.
+func (t *Token) Valid() bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+	return t.Expiry.IsZero() || wasiclock.NowFunc().Add(expiryDelta).Before(t.Expiry)
+}
+
+// Type returns the type of the token for the Authorization header.
+func (t *Token) Type() string {
+	if t.TokenType == "" || strings.EqualFold(t.TokenType, "bearer") {
+		return "Bearer"
+	}
+	return t.TokenType
+}
+
+// RetrieveError is returned when the token endpoint rejects a request.
+type RetrieveError struct {
+	StatusCode int
+	// ErrorCode is the error of the response, e.g. "invalid_client".
+	ErrorCode        string
+	ErrorDescription string
+}
+
+func (e *RetrieveError) Error() string {
+	msg := "oauth2: token request failed with status " + strconv.Itoa(e.StatusCode)
+	if e.ErrorCode != "" {
+		msg += ": " + e.ErrorCode
+	}
+	if e.ErrorDescription != "" {
+		msg += ": " + e.ErrorDescription
+	}
+	return msg
+}
+
+// ClientCredentials configures a [TokenSource] of the client credentials
+// grant, RFC 6749 section 4.4.
+type ClientCredentials struct {
+	ClientID     string
+	ClientSecret secrets.Secret
+	TokenURL     string
+	Scopes       []string
+	// EndpointParams are sent with the token requests, e.g. an audience.
+	EndpointParams url.Values
+	// AuthInParams sends the client credentials in the request body instead
+	// of the Authorization header, for endpoints not supporting the latter.
+	AuthInParams bool
+	// HTTPClient sends the token requests, it defaults to
+	// wasihttp.DefaultClient.
+	HTTPClient *http.Client
+
+	// Bucket stores the tokens, if set, so the instances of the component
+	// share them instead of requesting one each.
+	//
+	// NOTE: the tokens are stored as they are, the bucket should be as
+	// private as the client secret.
+	Bucket *wasikv.Bucket
+	// Key is the bucket key of the token, defaults to "oauth2:" and a hash of
+	// the token URL, client ID and scopes.
+	Key string
+	// Logger reports bucket failures, defaults to slog.Default().
+	Logger *slog.Logger
+}
+
+// TokenSource returns the tokens of a [ClientCredentials] grant, requesting
+// a new one once the current one expires. Concurrent calls share a request.
+type TokenSource struct {
+	config ClientCredentials
+
+	mu    sync.Mutex
+	token *Token
+}
+
+// NewTokenSource returns a TokenSource of config.
+func NewTokenSource(config ClientCredentials) *TokenSource {
+	if config.HTTPClient == nil {
+		config.HTTPClient = wasihttp.DefaultClient
+	}
+	if config.Key == "" {
+		sum := sha256.Sum256([]byte(config.TokenURL + "\x00" + config.ClientID + "\x00" + strings.Join(config.Scopes, " ")))
+		config.Key = "oauth2:" + hex.EncodeToString(sum[:16])
+	}
+	if config.Logger == nil {
+		config.Logger = slog.Default()
+	}
+	return &TokenSource{config: config}
+}
+
+// Token returns a valid token, from memory, the bucket or the token endpoint.
+func (ts *TokenSource) Token(ctx context.Context) (*Token, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.token.Valid() {
+		return ts.token, nil
+	}
+	if token := ts.stored(); token.Valid() {
+		ts.token = token
+		return token, nil
+	}
+
+	token, err := ts.retrieve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ts.token = token
+	ts.store(token)
+	return token, nil
+}
+
+// stored returns the token of the bucket, if any.
+func (ts *TokenSource) stored() *Token {
+	if ts.config.Bucket == nil {
+		return nil
+	}
+	buf, err := ts.config.Bucket.Get(ts.config.Key)
+	if err != nil {
+		if !errors.Is(err, wasikv.ErrNotFound) {
+			ts.config.Logger.Error("failed to get stored token", "key", ts.config.Key, "error", err)
+		}
+		return nil
+	}
+	var token Token
+	if err := json.Unmarshal(buf, &token); err != nil {
+		ts.config.Logger.Error("failed to decode stored token", "key", ts.config.Key, "error", err)
+		return nil
+	}
+	return &token
+}
+
+func (ts *TokenSource) store(token *Token) {
+	if ts.config.Bucket == nil {
+		return
+	}
+	buf, err := json.Marshal(token)
+	if err != nil {
+		ts.config.Logger.Error("failed to encode token", "error", err)
+		return
+	}
+	if err := ts.config.Bucket.Set(ts.config.Key, buf); err != nil {
+		ts.config.Logger.Error("failed to store token", "key", ts.config.Key, "error", err)
+	}
+}
+
+// tokenResponse is the response of the token endpoint, RFC 6749 section 5.
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	// ExpiresIn is a number, some endpoints send it as a string
+	ExpiresIn        json.Number `json:"expires_in"`
+	Error            string      `json:"error"`
+	ErrorDescription string      `json:"error_description"`
+}
+
+// retrieve requests a token from the token endpoint.
+func (ts *TokenSource) retrieve(ctx context.Context) (*Token, error) {
+	c := &ts.config
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(c.Scopes) > 0 {
+		form.Set("scope", strings.Join(c.Scopes, " "))
+	}
+	for k, v := range c.EndpointParams {
+		form[k] = v
+	}
+	if c.AuthInParams {
+		form.Set("client_id", c.ClientID)
+		form.Set("client_secret", c.ClientSecret.Reveal())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if !c.AuthInParams {
+		// NOTE: RFC 6749 section 2.3.1 form-encodes the credentials first
+		req.SetBasicAuth(url.QueryEscape(c.ClientID), url.QueryEscape(c.ClientSecret.Reveal()))
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxTokenResponseBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token response: %w", err)
+	}
+	var tr tokenResponse
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType == "application/json" {
+		if err := json.Unmarshal(body, &tr); err != nil && resp.StatusCode == http.StatusOK {
+			return nil, fmt.Errorf("failed to decode token response: %w", err)
+		}
+	} else if resp.StatusCode == http.StatusOK {
+		// NOTE: GitHub and other early endpoints answer form encoded tokens
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode token response: %w", err)
+		}
+		tr = tokenResponse{AccessToken: values.Get("access_token"), TokenType: values.Get("token_type"), ExpiresIn: json.Number(values.Get("expires_in")), Error: values.Get("error")}
+	}
+
+	if resp.StatusCode != http.StatusOK || tr.Error != "" {
+		return nil, &RetrieveError{StatusCode: resp.StatusCode, ErrorCode: tr.Error, ErrorDescription: tr.ErrorDescription}
+	}
+	if tr.AccessToken == "" {
+		return nil, errors.New("oauth2: token response has no access_token")
+	}
+
+	token := &Token{AccessToken: tr.AccessToken, TokenType: tr.TokenType}
+	if tr.ExpiresIn != "" {
+		seconds, err := tr.ExpiresIn.Int64()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode token response: invalid expires_in %q", tr.ExpiresIn)
+		}
+		if seconds > 0 {
+			token.Expiry = wasiclock.NowFunc().Add(time.Duration(seconds) * time.Second)
+		}
+	}
+	return token, nil
+}
+
+// Transport returns a RoundTripper setting the Authorization header of the
+// requests to a token of ts before passing them on to next, e.g. a
+// wasihttp.Transport.
+func Transport(ts *TokenSource, next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		token, err := ts.Token(r.Context())
+		if err != nil {
+			if r.Body != nil {
+				r.Body.Close()
+			}
+			return nil, err
+		}
+		// NOTE: RoundTrippers must not modify the request
+		r = r.Clone(r.Context())
+		r.Header.Set("Authorization", token.Type()+" "+token.AccessToken)
+		return next.RoundTrip(r)
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
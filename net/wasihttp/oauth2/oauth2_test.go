@@ -0,0 +1,145 @@
+//go:build !wasm
+
+package oauth2
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"go.wasmcloud.dev/component/componenttest"
+	"go.wasmcloud.dev/component/kv/wasikv"
+	"go.wasmcloud.dev/component/net/wasihttp"
+	"go.wasmcloud.dev/component/secrets"
+	"go.wasmcloud.dev/component/time/wasiclock"
+)
+
+// fakeNow points wasiclock.NowFunc at the returned time for the duration of
+// the test.
+func fakeNow(t *testing.T) *time.Time {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	prev := wasiclock.NowFunc
+	wasiclock.NowFunc = func() time.Time { return now }
+	t.Cleanup(func() { wasiclock.NowFunc = prev })
+	return &now
+}
+
+// tokenEndpoint issues the tokens "token-1", "token-2"... valid for an hour
+// to the client "app", and serves the API, requiring the last one.
+func tokenEndpoint(t *testing.T, h *componenttest.Host) *int {
+	var issued int
+	h.HTTP = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/token" {
+			if got, want := r.Header.Get("Authorization"), "Bearer token-"+string(rune('0'+issued)); got != want {
+				t.Errorf("expected: %v, got: %v", want, got)
+			}
+			io.WriteString(w, "ok")
+			return
+		}
+
+		id, secret, _ := r.BasicAuth()
+		id, _ = url.QueryUnescape(id)
+		secret, _ = url.QueryUnescape(secret)
+		if r.PostFormValue("client_id") != "" {
+			id, secret = r.PostFormValue("client_id"), r.PostFormValue("client_secret")
+		}
+		if r.PostFormValue("grant_type") != "client_credentials" || id != "app" || secret != "s3cr=t" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			io.WriteString(w, `{"error":"invalid_client","error_description":"unknown client"}`)
+			return
+		}
+		if got := r.PostFormValue("scope"); got != "read write" {
+			t.Errorf("expected: %v, got: %v", "read write", got)
+		}
+		issued++
+		w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+		io.WriteString(w, `{"access_token":"token-`+string(rune('0'+issued))+`","token_type":"bearer","expires_in":"3600"}`)
+	})
+	return &issued
+}
+
+func config(authInParams bool) ClientCredentials {
+	return ClientCredentials{
+		ClientID:     "app",
+		ClientSecret: secrets.New([]byte("s3cr=t")),
+		TokenURL:     "http://auth/token",
+		Scopes:       []string{"read", "write"},
+		AuthInParams: authInParams,
+	}
+}
+
+func TestTransport(t *testing.T) {
+	for name, authInParams := range map[string]bool{"header": false, "params": true} {
+		t.Run(name, func(t *testing.T) {
+			h := componenttest.New(t)
+			now := fakeNow(t)
+			issued := tokenEndpoint(t, h)
+			client := &http.Client{Transport: Transport(NewTokenSource(config(authInParams)), &wasihttp.Transport{})}
+
+			for i, advance := range []time.Duration{0, 30 * time.Minute, 30 * time.Minute} {
+				*now = now.Add(advance)
+				req, _ := http.NewRequest(http.MethodGet, "http://api/orders", nil)
+				resp, err := client.Do(req)
+				if err != nil {
+					t.Fatal(err)
+				}
+				resp.Body.Close()
+				if req.Header.Get("Authorization") != "" {
+					t.Errorf("expected the request to be left as is")
+				}
+				if want := []int{1, 1, 2}[i]; *issued != want {
+					t.Errorf("expected: %v tokens, got: %v", want, *issued)
+				}
+			}
+		})
+	}
+}
+
+func TestTokenSourceBucket(t *testing.T) {
+	h := componenttest.New(t)
+	fakeNow(t)
+	issued := tokenEndpoint(t, h)
+	h.KeyValue.CreateBucket("default", nil)
+	bucket, err := wasikv.Open("default")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config(false)
+	cfg.Bucket = bucket
+	for range 2 {
+		// NOTE: each source stands for an instance of the component
+		token, err := NewTokenSource(cfg).Token(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token.AccessToken != "token-1" {
+			t.Errorf("expected: %v, got: %v", "token-1", token.AccessToken)
+		}
+	}
+	if *issued != 1 {
+		t.Errorf("expected the token to be shared through the bucket, got: %v tokens", *issued)
+	}
+}
+
+func TestTokenSourceError(t *testing.T) {
+	h := componenttest.New(t)
+	tokenEndpoint(t, h)
+	cfg := config(false)
+	cfg.ClientSecret = secrets.New([]byte("wrong"))
+
+	var retrieveErr *RetrieveError
+	_, err := NewTokenSource(cfg).Token(context.Background())
+	if !errors.As(err, &retrieveErr) || retrieveErr.StatusCode != http.StatusUnauthorized || retrieveErr.ErrorCode != "invalid_client" {
+		t.Errorf("expected: %v, got: %v", "invalid_client", err)
+	}
+	if !strings.Contains(err.Error(), "unknown client") {
+		t.Errorf("expected the error description, got: %v", err)
+	}
+}
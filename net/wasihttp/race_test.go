@@ -0,0 +1,6 @@
+//go:build race
+
+package wasihttp
+
+// raceEnabled is set under the race detector, whose instrumentation allocates.
+const raceEnabled = true
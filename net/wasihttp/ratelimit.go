@@ -0,0 +1,116 @@
+package wasihttp
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.wasmcloud.dev/component/time/wasiclock"
+)
+
+// RateLimit returns a handler rejecting requests with 429 Too Many Requests
+// once l is exhausted, other requests are passed on to next.
+func RateLimit(l *wasiclock.Limiter, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if retryAfter, ok := take(l); !ok {
+			tooManyRequests(w, retryAfter)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RateLimitStore holds the token buckets of [RateLimitByKey].
+type RateLimitStore interface {
+	// Take consumes a token from the bucket of key, returning false and the
+	// time until a token is available when the bucket is empty.
+	Take(key string) (retryAfter time.Duration, ok bool)
+}
+
+// RateLimitByKey returns a handler limiting requests per key, e.g. a client
+// identifier taken from a header. `wasi:http` does not expose the remote
+// address, so the key has to be derived from the request itself.
+func RateLimitByKey(store RateLimitStore, key func(*http.Request) string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if retryAfter, ok := store.Take(key(r)); !ok {
+			tooManyRequests(w, retryAfter)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// maxIdleBuckets is the number of buckets after which full buckets are evicted.
+const maxIdleBuckets = 1024
+
+// MemoryRateLimitStore is a RateLimitStore keeping a [wasiclock.Limiter] per
+// key in component memory.
+//
+// NOTE: buckets only live as long as the component instance, hosts creating an
+// instance per request need a store shared across instances.
+type MemoryRateLimitStore struct {
+	limit wasiclock.Limit
+	burst int
+
+	mu       sync.Mutex
+	limiters map[string]*wasiclock.Limiter
+}
+
+var _ RateLimitStore = (*MemoryRateLimitStore)(nil)
+
+// NewMemoryRateLimitStore returns a store allowing each key events up to rate
+// r, with bursts of at most b requests.
+func NewMemoryRateLimitStore(r wasiclock.Limit, b int) *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		limit:    r,
+		burst:    b,
+		limiters: make(map[string]*wasiclock.Limiter),
+	}
+}
+
+func (s *MemoryRateLimitStore) Take(key string) (time.Duration, bool) {
+	s.mu.Lock()
+	l, ok := s.limiters[key]
+	if !ok {
+		if len(s.limiters) >= maxIdleBuckets {
+			s.evict()
+		}
+		l = wasiclock.NewLimiter(s.limit, s.burst)
+		s.limiters[key] = l
+	}
+	s.mu.Unlock()
+
+	return take(l)
+}
+
+// evict drops the buckets which refilled completely, a new limiter for those
+// keys behaves the same. It must be called with s.mu held.
+func (s *MemoryRateLimitStore) evict() {
+	for key, l := range s.limiters {
+		if l.Tokens() >= float64(s.burst) {
+			delete(s.limiters, key)
+		}
+	}
+}
+
+func take(l *wasiclock.Limiter) (time.Duration, bool) {
+	r := l.Reserve()
+	if !r.OK() {
+		return time.Duration(math.MaxInt64), false
+	}
+	if delay := r.Delay(); delay > 0 {
+		r.Cancel()
+		return delay, false
+	}
+	return 0, true
+}
+
+func tooManyRequests(w http.ResponseWriter, retryAfter time.Duration) {
+	if retryAfter < time.Duration(math.MaxInt64) {
+		seconds := int64(math.Ceil(retryAfter.Seconds()))
+		w.Header().Set("Retry-After", strconv.FormatInt(seconds, 10))
+	}
+	http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+}
@@ -1,20 +1,44 @@
 package wasihttp
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"strconv"
 	"time"
 
 	"github.com/bytecodealliance/wasm-tools-go/cm"
 	monotonicclock "go.wasmcloud.dev/component/gen/wasi/clocks/monotonic-clock"
 	outgoinghandler "go.wasmcloud.dev/component/gen/wasi/http/outgoing-handler"
 	"go.wasmcloud.dev/component/gen/wasi/http/types"
+	"go.wasmcloud.dev/component/internal/requestid"
+	"go.wasmcloud.dev/component/io/wasipoll"
+	"go.wasmcloud.dev/component/telemetry/wasitel"
+	"go.wasmcloud.dev/component/wasmcloud"
 )
 
 // Transport implements http.RoundTripper
 type Transport struct {
 	ConnectTimeout time.Duration
+	// MaxResponseBodySize limits the size of response bodies, reading past
+	// it fails with a *ResponseBodyTooLargeError. Responses announcing a
+	// larger Content-Length fail at once. Zero means no limit.
+	MaxResponseBodySize int64
+	// ResponseBodyTimeout bounds reading a response body, from the time its
+	// headers were received, reading past it fails with a
+	// *ResponseBodyTimeoutError. Zero means no timeout.
+	ResponseBodyTimeout time.Duration
+	// Authority, if set, is the authority of every request instead of the
+	// Host of the request or of its URL, e.g. to address one virtual host
+	// whatever the URL of the requests.
+	Authority string
+	// Tracer starts a client span per request, it defaults to the tracer of
+	// the active span of the request context. Requests are not traced
+	// without either.
+	Tracer *wasitel.Tracer
 }
 
 var _ http.RoundTripper = (*Transport)(nil)
@@ -27,58 +51,170 @@ var (
 	DefaultClient = &http.Client{Transport: DefaultTransport}
 )
 
-func (r *Transport) requestOptions() types.RequestOptions {
+func (r *Transport) requestOptions(ctx context.Context) types.RequestOptions {
+	connectTimeout := r.ConnectTimeout
+
 	options := types.NewRequestOptions()
-	options.SetConnectTimeout(cm.Some(monotonicclock.Duration(r.ConnectTimeout)))
+	// NOTE: the context deadline is handed to the host, bounding the
+	// request until the response headers are received
+	if deadline, ok := ctx.Deadline(); ok {
+		remaining := max(time.Until(deadline), 0)
+		if connectTimeout == 0 || remaining < connectTimeout {
+			connectTimeout = remaining
+		}
+		options.SetFirstByteTimeout(cm.Some(monotonicclock.Duration(remaining)))
+	}
+	if connectTimeout > 0 {
+		options.SetConnectTimeout(cm.Some(monotonicclock.Duration(connectTimeout)))
+	}
 	return options
 }
 
+// injectTraceContext returns a shallow copy of req carrying the trace context,
+// baggage and request ID of its context, or req if there is none.
+func injectTraceContext(req *http.Request) *http.Request {
+	ctx := req.Context()
+	id := requestid.FromContext(ctx)
+	if id != "" && req.Header.Get(requestid.Header) != "" {
+		id = ""
+	}
+	if !wasitel.SpanContextFromContext(ctx).IsValid() && len(wasitel.BaggageFromContext(ctx)) == 0 && id == "" {
+		return req
+	}
+	out := *req
+	out.Header = req.Header.Clone()
+	if out.Header == nil {
+		out.Header = http.Header{}
+	}
+	wasitel.Inject(ctx, out.Header)
+	if id != "" {
+		out.Header.Set(requestid.Header, id)
+	}
+	return &out
+}
+
+// RoundTrip implements http.RoundTripper. The request context deadline and
+// cancellation are honored until the response headers are received. The W3C
+// trace context, baggage and request ID of the request context are propagated.
 func (r *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
-	or, err := NewOutgoingHttpRequest(req)
+	ctx := req.Context()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	tracer := r.Tracer
+	if tracer == nil {
+		tracer = wasitel.SpanFromContext(ctx).Tracer()
+	}
+	if tracer == nil {
+		return r.roundTrip(injectTraceContext(req))
+	}
+
+	// NOTE: the span ends once the response headers are received, reading
+	// the body is not part of it
+	ctx, span := tracer.Start(ctx, req.Method, wasitel.SpanKindClient, clientAttributes(req)...)
+	defer span.End()
+
+	resp, err := r.roundTrip(injectTraceContext(req.WithContext(ctx)))
+	if err != nil {
+		var reqErr *requestError
+		errorType := "_OTHER"
+		if errors.As(err, &reqErr) {
+			errorType = reqErr.code
+		}
+		span.SetAttributes(wasitel.String("error.type", errorType))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	span.SetAttributes(wasitel.Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetAttributes(wasitel.String("error.type", strconv.Itoa(resp.StatusCode)))
+		span.SetStatus(wasitel.StatusError, "")
+	}
+	return resp, nil
+}
+
+// clientAttributes returns the OpenTelemetry semantic convention attributes
+// of an outgoing request.
+func clientAttributes(req *http.Request) []wasitel.Attribute {
+	u := *req.URL
+	u.User = nil
+
+	port := u.Port()
+	if port == "" {
+		port = "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
+	}
+	n, _ := strconv.Atoi(port)
+
+	return []wasitel.Attribute{
+		wasitel.String("http.request.method", req.Method),
+		wasitel.String("url.full", u.String()),
+		wasitel.String("server.address", u.Hostname()),
+		wasitel.Int("server.port", n),
+	}
+}
+
+func (r *Transport) roundTrip(req *http.Request) (*http.Response, error) {
+	wasmcloud.UseLinkName(wasmcloud.LinkName(req.Context()), "wasi:http/outgoing-handler")
+	ctx := req.Context()
+
+	or, err := newOutgoingRequest(req, r.Authority)
 	if err != nil {
 		return nil, err
 	}
 
-	var adaptedBody io.WriteCloser
-	var body types.OutgoingBody
+	var body *outputStreamReader
 	if req.Body != nil {
 		bodyRes := or.Body()
 		if bodyRes.IsErr() {
 			return nil, fmt.Errorf("failed to acquire resource handle to request body: %s", bodyRes.Err())
 		}
 
-		body = *bodyRes.OK()
-
-		adaptedBody, err = NewOutgoingBody(body)
+		adaptedBody, err := NewOutgoingBody(*bodyRes.OK())
 		if err != nil {
 			return nil, fmt.Errorf("failed to adapt body: %s", err)
 		}
+		body = adaptedBody.(*outputStreamReader)
 	}
 
-	handleResp := outgoinghandler.Handle(or, cm.Some(r.requestOptions()))
+	handleResp := outgoinghandler.Handle(or, cm.Some(r.requestOptions(ctx)))
 	if handleResp.Err() != nil {
-		return nil, fmt.Errorf("%v", handleResp.Err())
+		return nil, &requestError{code: errorCodeName(handleResp.Err())}
 	}
 
-	if adaptedBody != nil {
-		if _, err := io.Copy(adaptedBody, req.Body); err != nil {
-			return nil, fmt.Errorf("failed to copy body: %s", err)
+	if body != nil {
+		// NOTE: the body is streamed, each write is flushed to the host
+		_, err := io.Copy(body, req.Body)
+		req.Body.Close()
+		if err != nil {
+			body.Close()
+		} else {
+			// NOTE: the trailers are complete once the body was read
+			err = body.finish(req.Trailer)
 		}
-
-		trailers := types.NewFields()
-		if err := toWasiHeader(req.Trailer, trailers); err != nil {
-			return nil, err
+		if err != nil {
+			handleResp.OK().ResourceDrop()
+			return nil, fmt.Errorf("failed to send body: %w", err)
 		}
-		types.OutgoingBodyFinish(body, cm.Some(trailers))
 	}
 
 	top := *handleResp.OK()
 	// wait until resp is returned
 	subscription := top.Subscribe()
-	subscription.Block()
+	ready := wasipoll.WaitCancel(ctx.Done(), subscription)
 	subscription.ResourceDrop()
+	if ready < 0 {
+		// dropping the future cancels the outgoing request
+		top.ResourceDrop()
+		return nil, ctx.Err()
+	}
 
 	pollableOption := top.Get()
+	top.ResourceDrop()
 	if pollableOption.None() {
 		return nil, fmt.Errorf("incoming resp is None")
 	}
@@ -90,20 +226,188 @@ func (r *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
 
 	resultOption := pollableResult.OK()
 	if resultOption.IsErr() {
-		return nil, fmt.Errorf("%v", resultOption.Err())
+		return nil, &requestError{code: errorCodeName(resultOption.Err())}
 	}
 
 	incomingBodyTrailer := *resultOption.OK()
-	respBody, trailers, err := NewIncomingBodyTrailer(incomingBodyTrailer)
-	if err != nil {
-		return nil, fmt.Errorf("failed to consume incoming request %s", err)
-	}
+	respBody, trailers := newIncomingBody(incomingBodyTrailer, incomingBodyTrailer.ResourceDrop)
 
 	resp := &http.Response{
+		Status:     strconv.Itoa(int(incomingBodyTrailer.Status())) + " " + http.StatusText(int(incomingBodyTrailer.Status())),
 		StatusCode: int(incomingBodyTrailer.Status()),
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{},
 		Body:       respBody,
 		Trailer:    trailers,
+		Request:    req,
+	}
+	// NOTE: repeated fields, e.g. Set-Cookie, are kept as separate values
+	fields := incomingBodyTrailer.Headers()
+	toHttpHeader(fields, &resp.Header)
+	fields.ResourceDrop()
+	resp.ContentLength = contentLength(resp.Header)
+	// NOTE: like net/http, the announced trailers are keys without values
+	// until the body was read
+	declareTrailers(resp.Header, trailers)
+
+	if r.ResponseBodyTimeout > 0 {
+		respBody.deadline = time.Now().Add(r.ResponseBodyTimeout)
+		respBody.deadlineErr = &ResponseBodyTimeoutError{Timeout: r.ResponseBodyTimeout}
+	}
+	return r.limitBody(resp)
+}
+
+// ResponseBodyTooLargeError is returned reading a response body larger than
+// Transport.MaxResponseBodySize.
+type ResponseBodyTooLargeError struct {
+	// Limit is the maximum size of the body.
+	Limit int64
+}
+
+func (e *ResponseBodyTooLargeError) Error() string {
+	return "response body too large: exceeds " + strconv.FormatInt(e.Limit, 10) + " bytes"
+}
+
+// ResponseBodyTimeoutError is returned reading a response body for longer
+// than Transport.ResponseBodyTimeout.
+type ResponseBodyTimeoutError struct {
+	Timeout time.Duration
+}
+
+func (e *ResponseBodyTimeoutError) Error() string {
+	return "response body not read within " + e.Timeout.String()
+}
+
+// Is reports whether target is os.ErrDeadlineExceeded, like the errors of
+// deadlines of net.Conn.
+func (e *ResponseBodyTimeoutError) Is(target error) bool {
+	return target == os.ErrDeadlineExceeded
+}
+
+// limitBody applies the response body limits of the Transport to resp.
+//
+// NOTE: bodies of the host enforce the timeout themselves, waiting on a clock
+// pollable, the timeout of other bodies is checked between reads
+func (r *Transport) limitBody(resp *http.Response) (*http.Response, error) {
+	if r.MaxResponseBodySize > 0 && resp.ContentLength > r.MaxResponseBodySize {
+		resp.Body.Close()
+		return nil, &ResponseBodyTooLargeError{Limit: r.MaxResponseBodySize}
+	}
+	if r.MaxResponseBodySize <= 0 && r.ResponseBodyTimeout <= 0 {
+		return resp, nil
 	}
 
+	body := &limitedBody{body: resp.Body, limit: r.MaxResponseBodySize, remaining: r.MaxResponseBodySize}
+	if r.ResponseBodyTimeout > 0 {
+		body.deadline = time.Now().Add(r.ResponseBodyTimeout)
+		body.timeout = r.ResponseBodyTimeout
+	}
+	resp.Body = body
 	return resp, nil
 }
+
+// limitedBody is a response body limited in size and time.
+type limitedBody struct {
+	body io.ReadCloser
+	// limit is the maximum size, if positive, of which remaining bytes are
+	// left to read
+	limit     int64
+	remaining int64
+	deadline  time.Time
+	timeout   time.Duration
+	err       error
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+	if !b.deadline.IsZero() && !time.Now().Before(b.deadline) {
+		b.err = &ResponseBodyTimeoutError{Timeout: b.timeout}
+		return 0, b.err
+	}
+	if b.limit <= 0 {
+		return b.body.Read(p)
+	}
+
+	// NOTE: one more byte than remaining is read to tell a body of exactly
+	// the limit from a larger one
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.body.Read(p)
+	if int64(n) <= b.remaining {
+		b.remaining -= int64(n)
+		return n, err
+	}
+	n = int(b.remaining)
+	b.remaining = 0
+	b.err = &ResponseBodyTooLargeError{Limit: b.limit}
+	return n, b.err
+}
+
+func (b *limitedBody) Close() error {
+	return b.body.Close()
+}
+
+// requestError is a `wasi:http/types.error-code` returned by the host for an
+// outgoing request.
+type requestError struct {
+	code string
+}
+
+func (e *requestError) Error() string {
+	return "outgoing request failed: " + e.code
+}
+
+// errorCodeNames are the `wasi:http/types.error-code` case names, by tag.
+var errorCodeNames = [...]string{
+	"DNS-timeout",
+	"DNS-error",
+	"destination-not-found",
+	"destination-unavailable",
+	"destination-IP-prohibited",
+	"destination-IP-unroutable",
+	"connection-refused",
+	"connection-terminated",
+	"connection-timeout",
+	"connection-read-timeout",
+	"connection-write-timeout",
+	"connection-limit-reached",
+	"TLS-protocol-error",
+	"TLS-certificate-error",
+	"TLS-alert-received",
+	"HTTP-request-denied",
+	"HTTP-request-length-required",
+	"HTTP-request-body-size",
+	"HTTP-request-method-invalid",
+	"HTTP-request-URI-invalid",
+	"HTTP-request-URI-too-long",
+	"HTTP-request-header-section-size",
+	"HTTP-request-header-size",
+	"HTTP-request-trailer-section-size",
+	"HTTP-request-trailer-size",
+	"HTTP-response-incomplete",
+	"HTTP-response-header-section-size",
+	"HTTP-response-header-size",
+	"HTTP-response-body-size",
+	"HTTP-response-trailer-section-size",
+	"HTTP-response-trailer-size",
+	"HTTP-response-transfer-coding",
+	"HTTP-response-content-coding",
+	"HTTP-response-timeout",
+	"HTTP-upgrade-failed",
+	"HTTP-protocol-error",
+	"loop-detected",
+	"configuration-error",
+	"internal-error",
+}
+
+func errorCodeName(code *types.ErrorCode) string {
+	if tag := int(code.Tag()); tag < len(errorCodeNames) {
+		return errorCodeNames[tag]
+	}
+	return "unknown"
+}
@@ -0,0 +1,123 @@
+package wasihttp
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Router routes requests by method and path, answering HEAD requests with
+// the GET handler of a path and requests of other methods with a 405 listing
+// the allowed methods:
+//
+//	r := wasihttp.NewRouter()
+//	r.HandleFunc("GET /items/{id}", getItem)
+//	r.HandleFunc("DELETE /items/{id}", deleteItem)
+//	wasihttp.Handle(r)
+//
+// Paths are matched by an [http.ServeMux], with the same wildcards and
+// precedence. The body of a response to HEAD is discarded at the stream layer
+// by the `wasi:http` handler, keeping the Content-Length of the GET response.
+type Router struct {
+	mu     sync.RWMutex
+	mux    *http.ServeMux
+	routes map[string]*route
+}
+
+// route holds the handlers of a path by method, any serves the methods
+// without a handler of their own.
+type route struct {
+	router  *Router
+	methods map[string]http.Handler
+	any     http.Handler
+}
+
+// NewRouter returns an empty Router.
+func NewRouter() *Router {
+	return &Router{mux: http.NewServeMux(), routes: map[string]*route{}}
+}
+
+// Handle registers h for pattern, "[METHOD ]PATH" as accepted by
+// [http.ServeMux], e.g. "GET /items/{id}". A pattern without method matches
+// every method not registered for the same path.
+// It panics if the pattern is invalid or already registered.
+func (r *Router) Handle(pattern string, h http.Handler) {
+	var method, path string
+	switch fields := strings.Fields(pattern); len(fields) {
+	case 1:
+		path = fields[0]
+	case 2:
+		method, path = fields[0], fields[1]
+	}
+	if path == "" || method != "" && !validHeaderName(method) {
+		panic(fmt.Sprintf("wasihttp: invalid pattern %q", pattern))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rt, ok := r.routes[path]
+	if !ok {
+		rt = &route{router: r, methods: map[string]http.Handler{}}
+		// NOTE: ServeMux panics on invalid paths, before the route is recorded
+		r.mux.Handle(path, rt)
+		r.routes[path] = rt
+	}
+	switch _, dup := rt.methods[method]; {
+	case method == "" && rt.any != nil, method != "" && dup:
+		panic(fmt.Sprintf("wasihttp: multiple registrations for %q", pattern))
+	case method == "":
+		rt.any = h
+	default:
+		rt.methods[method] = h
+	}
+}
+
+// HandleFunc registers h for pattern, see [Router.Handle].
+func (r *Router) HandleFunc(pattern string, h func(http.ResponseWriter, *http.Request)) {
+	r.Handle(pattern, http.HandlerFunc(h))
+}
+
+// ServeHTTP dispatches the request to the handler of its method and path.
+func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mux.ServeHTTP(w, req)
+}
+
+func (rt *route) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	h, allow := rt.handler(req.Method)
+	if h == nil {
+		w.Header().Set("Allow", strings.Join(allow, ", "))
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	h.ServeHTTP(w, req)
+}
+
+// handler returns the handler of method, or the allowed methods if there is
+// none.
+func (rt *route) handler(method string) (http.Handler, []string) {
+	rt.router.mu.RLock()
+	defer rt.router.mu.RUnlock()
+
+	if h, ok := rt.methods[method]; ok {
+		return h, nil
+	}
+	if h, ok := rt.methods[http.MethodGet]; ok && method == http.MethodHead {
+		return h, nil
+	}
+	if rt.any != nil {
+		return rt.any, nil
+	}
+
+	allow := make([]string, 0, len(rt.methods)+1)
+	for m := range rt.methods {
+		allow = append(allow, m)
+	}
+	if _, ok := rt.methods[http.MethodGet]; ok && !slices.Contains(allow, http.MethodHead) {
+		allow = append(allow, http.MethodHead)
+	}
+	slices.Sort(allow)
+	return nil, allow
+}
@@ -0,0 +1,67 @@
+package wasihttp
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRouter(t *testing.T) {
+	r := NewRouter()
+	for _, pattern := range []string{"GET /items", "POST /items", "DELETE /items/{id}", "/any", "PUT /any"} {
+		r.HandleFunc(pattern, func(w http.ResponseWriter, req *http.Request) {
+			io.WriteString(w, pattern)
+		})
+	}
+
+	tests := map[string]struct {
+		method string
+		path   string
+		status int
+		allow  string
+		body   string
+	}{
+		"get":         {method: http.MethodGet, path: "/items", status: http.StatusOK, body: "GET /items"},
+		"post":        {method: http.MethodPost, path: "/items", status: http.StatusOK, body: "POST /items"},
+		"head":        {method: http.MethodHead, path: "/items", status: http.StatusOK, body: "GET /items"},
+		"wildcard":    {method: http.MethodDelete, path: "/items/1", status: http.StatusOK, body: "DELETE /items/{id}"},
+		"not allowed": {method: http.MethodPut, path: "/items", status: http.StatusMethodNotAllowed, allow: "GET, HEAD, POST", body: "Method Not Allowed\n"},
+		"no head":     {method: http.MethodHead, path: "/items/1", status: http.StatusMethodNotAllowed, allow: "DELETE", body: "Method Not Allowed\n"},
+		"method":      {method: http.MethodPut, path: "/any", status: http.StatusOK, body: "PUT /any"},
+		"any method":  {method: http.MethodPatch, path: "/any", status: http.StatusOK, body: "/any"},
+		"not found":   {method: http.MethodGet, path: "/absent", status: http.StatusNotFound, body: "404 page not found\n"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+			if rec.Code != tt.status {
+				t.Errorf("expected: %v, got: %v", tt.status, rec.Code)
+			}
+			if got := rec.Header().Get("Allow"); got != tt.allow {
+				t.Errorf("expected: %q, got: %q", tt.allow, got)
+			}
+			if got := rec.Body.String(); got != tt.body {
+				t.Errorf("expected: %q, got: %q", tt.body, got)
+			}
+		})
+	}
+}
+
+func TestRouterInvalidPattern(t *testing.T) {
+	for _, pattern := range []string{"", "GET", "G(T /x", "GET /x y", "GET /dup", "/{"} {
+		t.Run(pattern, func(t *testing.T) {
+			r := NewRouter()
+			r.HandleFunc("GET /dup", func(http.ResponseWriter, *http.Request) {})
+
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected %q to panic", pattern)
+				}
+			}()
+			r.HandleFunc(pattern, func(http.ResponseWriter, *http.Request) {})
+		})
+	}
+}
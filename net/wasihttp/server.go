@@ -1,12 +1,18 @@
 package wasihttp
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"os"
 
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"go.wasmcloud.dev/component/export"
 	incominghandler "go.wasmcloud.dev/component/gen/wasi/http/incoming-handler"
 	"go.wasmcloud.dev/component/gen/wasi/http/types"
+	"go.wasmcloud.dev/component/tasks"
+	"go.wasmcloud.dev/component/telemetry/wasitel"
+	"go.wasmcloud.dev/component/wasmcloud"
 )
 
 // handler is the function that will be called by the http server.
@@ -29,19 +35,60 @@ func HandleFunc(h http.HandlerFunc) {
 }
 
 func wasiHandle(request types.IncomingRequest, responseOut types.ResponseOutparam) {
+	// NOTE: deferred first, the hooks run once the response was finished
+	defer shutdown()
+
 	httpReq, err := NewHttpRequest(request)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "failed to convert wasi/http/types.IncomingRequest to http.Request: %s\n", err)
+		request.ResourceDrop()
+		// NOTE: the host responds with an error instead of waiting for a
+		// response that is never set
+		types.ResponseOutparamSet(responseOut, cm.Err[cm.Result[types.ErrorCodeShape, types.OutgoingResponse, types.ErrorCode]](types.ErrorCodeInternalError(cm.Some(err.Error()))))
 		return
 	}
+	// NOTE: the incoming trace context is extracted even without a tracer, so
+	// that it is forwarded by the Transport
+	ctx := wasitel.Extract(httpReq.Context(), httpReq.Header)
+	ctx, queue := tasks.NewContext(wasmcloud.NewContext(ctx, wasmcloud.MetadataFromHeader(httpReq.Header)))
+	httpReq = httpReq.WithContext(ctx)
+	// NOTE: the deferred tasks run once the response was finished and the
+	// request dropped
+	defer runTasks(ctx, queue)
+	// NOTE: closing the body drops the request, whether the handler read
+	// the body or not
 	defer httpReq.Body.Close()
 
 	httpRes := NewHttpResponseWriter(responseOut)
-	defer httpRes.Close()
+	httpRes.head = httpReq.Method == http.MethodHead
+	defer func() {
+		if err := httpRes.Close(); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to write response: %s\n", err)
+		}
+	}()
 
 	handler(httpRes, httpReq)
 }
 
+// runTasks runs the tasks deferred with tasks.Defer while handling a request.
+func runTasks(ctx context.Context, queue *tasks.Queue) {
+	if err := queue.Run(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "deferred tasks failed: %s\n", err)
+	}
+}
+
+// shutdown calls the [export.OnShutdown] hooks once a request was handled.
+func shutdown() {
+	if err := export.Shutdown(context.Background()); err != nil {
+		fmt.Fprintf(os.Stderr, "shutdown hooks failed: %s\n", err)
+	}
+}
+
+// Export is the name of the `wasi:http/incoming-handler` export registered
+// with the [export] registry.
+const Export = "wasi:http/incoming-handler@0.2.0#handle"
+
 func init() {
-	incominghandler.Exports.Handle = wasiHandle
+	export.Bind(Export, &incominghandler.Exports.Handle)
+	export.Register(Export, wasiHandle)
 }
@@ -0,0 +1,349 @@
+package sessions
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"go.wasmcloud.dev/component/kv/wasikv"
+	"go.wasmcloud.dev/component/rand/wasirand"
+	"go.wasmcloud.dev/component/time/wasiclock"
+)
+
+// Options configures [Middleware].
+type Options struct {
+	// Secret signs the session IDs stored in cookies, it is required.
+	Secret []byte
+
+	// CookieName defaults to "session".
+	CookieName string
+	// KeyPrefix prefixes the bucket keys of sessions, defaults to "session:".
+	KeyPrefix string
+
+	// IdleTimeout expires sessions not seen for the duration, defaults to 30 minutes.
+	IdleTimeout time.Duration
+	// MaxAge expires sessions this long after creation, defaults to 24 hours.
+	MaxAge time.Duration
+
+	// Cookie attributes, HttpOnly is always set.
+	Path     string
+	Domain   string
+	Secure   bool
+	SameSite http.SameSite
+
+	// Logger reports failures to save sessions once the response is committed,
+	// defaults to slog.Default().
+	Logger *slog.Logger
+}
+
+type record struct {
+	Values   map[string]string `json:"values"`
+	Created  time.Time         `json:"created"`
+	LastSeen time.Time         `json:"last_seen"`
+}
+
+// Session holds the values of a client session. Sessions are only persisted,
+// and the cookie only set, once a value is stored.
+type Session struct {
+	mu        sync.Mutex
+	id        string
+	record    record
+	dirty     bool
+	renewed   bool
+	destroyed bool
+	// previous is the ID to delete after Renew or Destroy
+	previous string
+}
+
+// ID returns the session ID, empty for a session not persisted yet.
+func (s *Session) ID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.id
+}
+
+// Get returns the value stored at key.
+func (s *Session) Get(key string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.record.Values[key]
+}
+
+// Set stores value at key.
+func (s *Session) Set(key, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.record.Values == nil {
+		s.record.Values = make(map[string]string)
+	}
+	s.record.Values[key] = value
+	s.dirty = true
+	if s.destroyed {
+		s.destroyed = false
+		s.renewed = true
+	}
+}
+
+// Delete removes key.
+func (s *Session) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.record.Values, key)
+	s.dirty = true
+}
+
+// Renew moves the session to a new ID, keeping its values. Call it when the
+// privilege level changes, e.g. on login, to prevent session fixation.
+func (s *Session) Renew() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.renewed = true
+	s.dirty = true
+}
+
+// Destroy deletes the session and expires the cookie.
+func (s *Session) Destroy() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.record.Values = nil
+	s.destroyed = true
+	s.dirty = true
+}
+
+type contextKey struct{}
+
+// FromContext returns the session of a request served through [Middleware],
+// or nil.
+func FromContext(r *http.Request) *Session {
+	s, _ := r.Context().Value(contextKey{}).(*Session)
+	return s
+}
+
+type manager struct {
+	bucket *wasikv.Bucket
+	opts   Options
+}
+
+// Middleware returns a handler loading the session of each request from the
+// bucket, available through [FromContext], and saving it before the response
+// headers are written.
+//
+// NOTE: `wasi:keyvalue` has no expiry, records of expired sessions are deleted
+// when presented again and otherwise stay in the bucket.
+func Middleware(bucket *wasikv.Bucket, opts Options, next http.Handler) http.Handler {
+	if len(opts.Secret) == 0 {
+		panic("sessions: Options.Secret is required")
+	}
+	if opts.CookieName == "" {
+		opts.CookieName = "session"
+	}
+	if opts.KeyPrefix == "" {
+		opts.KeyPrefix = "session:"
+	}
+	if opts.IdleTimeout == 0 {
+		opts.IdleTimeout = 30 * time.Minute
+	}
+	if opts.MaxAge == 0 {
+		opts.MaxAge = 24 * time.Hour
+	}
+	if opts.Path == "" {
+		opts.Path = "/"
+	}
+	if opts.Logger == nil {
+		opts.Logger = slog.Default()
+	}
+	m := &manager{bucket: bucket, opts: opts}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s, err := m.load(r)
+		if err != nil {
+			http.Error(w, "failed to load session", http.StatusInternalServerError)
+			return
+		}
+
+		sw := &sessionWriter{ResponseWriter: w, manager: m, session: s}
+		next.ServeHTTP(sw, r.WithContext(context.WithValue(r.Context(), contextKey{}, s)))
+		sw.commit()
+	})
+}
+
+func (m *manager) load(r *http.Request) (*Session, error) {
+	now := wasiclock.NowFunc()
+	s := &Session{record: record{Created: now, LastSeen: now}}
+
+	cookie, err := r.Cookie(m.opts.CookieName)
+	if err != nil {
+		return s, nil
+	}
+	id, ok := m.verify(cookie.Value)
+	if !ok {
+		return s, nil
+	}
+
+	data, err := m.bucket.Get(m.opts.KeyPrefix + id)
+	if errors.Is(err, wasikv.ErrNotFound) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var rec record
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return s, nil
+	}
+	if now.Sub(rec.LastSeen) > m.opts.IdleTimeout || now.Sub(rec.Created) > m.opts.MaxAge {
+		s.previous = id
+		s.dirty = true
+		return s, nil
+	}
+
+	s.id = id
+	s.record = rec
+	// refresh the idle deadline without a write on every request
+	if now.Sub(rec.LastSeen) > m.opts.IdleTimeout/10 {
+		s.record.LastSeen = now
+		s.dirty = true
+	}
+	return s, nil
+}
+
+// save persists s, setting the cookie in h when it changed, it must be called
+// with s.mu held.
+func (m *manager) save(s *Session, h http.Header) error {
+	if !s.dirty {
+		return nil
+	}
+	s.dirty = false
+
+	if s.destroyed || len(s.record.Values) == 0 && s.id == "" {
+		if s.id != "" {
+			s.previous, s.id = s.id, ""
+		}
+		if s.previous != "" {
+			if err := m.bucket.Delete(m.opts.KeyPrefix + s.previous); err != nil {
+				return err
+			}
+			s.previous = ""
+			if h != nil {
+				m.setCookie(h, "", -1)
+			}
+		}
+		return nil
+	}
+
+	now := wasiclock.NowFunc()
+	if s.id == "" || s.renewed {
+		if h == nil {
+			return errors.New("session ID changed after the response headers were written")
+		}
+		if s.id != "" {
+			s.previous = s.id
+		}
+		s.id = wasirand.Token(32)
+		s.renewed = false
+		m.setCookie(h, m.sign(s.id), int(s.record.Created.Add(m.opts.MaxAge).Sub(now).Seconds()))
+	}
+	s.record.LastSeen = now
+
+	data, err := json.Marshal(s.record)
+	if err != nil {
+		return err
+	}
+	if err := m.bucket.Set(m.opts.KeyPrefix+s.id, data); err != nil {
+		return err
+	}
+	if s.previous != "" {
+		if err := m.bucket.Delete(m.opts.KeyPrefix + s.previous); err != nil {
+			return err
+		}
+		s.previous = ""
+	}
+	return nil
+}
+
+func (m *manager) setCookie(h http.Header, value string, maxAge int) {
+	cookie := &http.Cookie{
+		Name:     m.opts.CookieName,
+		Value:    value,
+		Path:     m.opts.Path,
+		Domain:   m.opts.Domain,
+		MaxAge:   maxAge,
+		Secure:   m.opts.Secure,
+		HttpOnly: true,
+		SameSite: m.opts.SameSite,
+	}
+	h.Add("Set-Cookie", cookie.String())
+}
+
+func (m *manager) sign(id string) string {
+	mac := hmac.New(sha256.New, m.opts.Secret)
+	mac.Write([]byte(id))
+	return id + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (m *manager) verify(value string) (string, bool) {
+	id, _, ok := strings.Cut(value, ".")
+	if !ok {
+		return "", false
+	}
+	return id, hmac.Equal([]byte(m.sign(id)), []byte(value))
+}
+
+// sessionWriter saves the session before the response headers are sent.
+type sessionWriter struct {
+	http.ResponseWriter
+	manager   *manager
+	session   *Session
+	committed bool
+}
+
+func (w *sessionWriter) commit() {
+	var h http.Header
+	if !w.committed {
+		w.committed = true
+		h = w.Header()
+	}
+
+	w.session.mu.Lock()
+	defer w.session.mu.Unlock()
+
+	if err := w.manager.save(w.session, h); err != nil {
+		w.manager.opts.Logger.Error("failed to save session", "error", err)
+	}
+}
+
+func (w *sessionWriter) WriteHeader(statusCode int) {
+	if !w.committed {
+		w.commit()
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *sessionWriter) Write(p []byte) (int, error) {
+	if !w.committed {
+		w.commit()
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush implements http.Flusher, the session is saved before the headers are
+// flushed.
+func (w *sessionWriter) Flush() {
+	if !w.committed {
+		w.commit()
+	}
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+func (w *sessionWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
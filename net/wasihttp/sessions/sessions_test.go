@@ -0,0 +1,134 @@
+//go:build !wasm
+
+package sessions
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.wasmcloud.dev/component/kv/wasikv/wasikvtest"
+	"go.wasmcloud.dev/component/time/wasiclock"
+)
+
+var now = time.Unix(1700000000, 0)
+
+func init() {
+	wasiclock.NowFunc = func() time.Time { return now }
+}
+
+// handler stores the "set" query parameter as the user, renews or destroys the
+// session on request, and writes the current user.
+var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	s := FromContext(r)
+	switch {
+	case r.URL.Query().Has("set"):
+		s.Set("user", r.URL.Query().Get("set"))
+	case r.URL.Query().Has("renew"):
+		s.Renew()
+	case r.URL.Query().Has("destroy"):
+		s.Destroy()
+	}
+	w.Write([]byte(s.Get("user")))
+})
+
+func newTestHandler() http.Handler {
+	return Middleware(wasikvtest.NewMemoryBucket().Bucket, Options{
+		Secret:      []byte("secret"),
+		IdleTimeout: time.Minute,
+		MaxAge:      time.Hour,
+	}, handler)
+}
+
+// do serves target with the cookie, returning the body and the new cookie.
+func do(h http.Handler, target string, cookie *http.Cookie) (string, *http.Cookie) {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	for _, c := range rec.Result().Cookies() {
+		return rec.Body.String(), c
+	}
+	return rec.Body.String(), cookie
+}
+
+func TestSessions(t *testing.T) {
+	h := newTestHandler()
+
+	if body, cookie := do(h, "/", nil); body != "" || cookie != nil {
+		t.Fatalf("expected no session without values, got: %q %v", body, cookie)
+	}
+
+	_, cookie := do(h, "/?set=ferris", nil)
+	if cookie == nil || !cookie.HttpOnly || cookie.MaxAge != 3600 {
+		t.Fatalf("expected a session cookie, got: %v", cookie)
+	}
+	if body, _ := do(h, "/", cookie); body != "ferris" {
+		t.Errorf("expected: %v, got: %v", "ferris", body)
+	}
+
+	tampered := *cookie
+	tampered.Value = strings.Replace(cookie.Value, ".", "x.", 1)
+	if body, _ := do(h, "/", &tampered); body != "" {
+		t.Errorf("expected a tampered cookie to be rejected, got: %q", body)
+	}
+
+	body, renewed := do(h, "/?renew", cookie)
+	if body != "ferris" || renewed.Value == cookie.Value {
+		t.Errorf("expected a new ID keeping the values, got: %q %v", body, renewed)
+	}
+	if body, _ := do(h, "/", cookie); body != "" {
+		t.Errorf("expected the previous ID to be invalid, got: %q", body)
+	}
+
+	_, destroyed := do(h, "/?destroy", renewed)
+	if destroyed.MaxAge >= 0 {
+		t.Errorf("expected the cookie to expire, got: %v", destroyed)
+	}
+	if body, _ := do(h, "/", renewed); body != "" {
+		t.Errorf("expected the session to be destroyed, got: %q", body)
+	}
+}
+
+func every(d time.Duration, n int) []time.Duration {
+	steps := make([]time.Duration, n)
+	for i := range steps {
+		steps[i] = d
+	}
+	return steps
+}
+
+func TestSessionsExpiry(t *testing.T) {
+	defer func(start time.Time) { now = start }(now)
+	h := newTestHandler()
+
+	tests := map[string]struct {
+		// steps are the delays between requests
+		steps []time.Duration
+		want  string
+	}{
+		"active":   {steps: []time.Duration{30 * time.Second, 30 * time.Second, 30 * time.Second}, want: "ferris"},
+		"idle":     {steps: []time.Duration{2 * time.Minute}, want: ""},
+		"absolute": {steps: every(50*time.Second, 80), want: ""},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, cookie := do(h, "/?set=ferris", nil)
+
+			var body string
+			for _, step := range tt.steps {
+				now = now.Add(step)
+				body, _ = do(h, "/", cookie)
+			}
+			if body != tt.want {
+				t.Errorf("expected: %q, got: %q", tt.want, body)
+			}
+		})
+	}
+}
@@ -0,0 +1,308 @@
+package sigv4
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"go.wasmcloud.dev/component/config/wasiconfig"
+	"go.wasmcloud.dev/component/secrets"
+	"go.wasmcloud.dev/component/time/wasiclock"
+)
+
+// The keys of the credentials read by [LoadCredentials], the access key ID may
+// be set in the runtime config or as a secret.
+const (
+	AccessKeyIDKey     = "aws_access_key_id"
+	SecretAccessKeyKey = "aws_secret_access_key"
+	SessionTokenKey    = "aws_session_token"
+)
+
+// UnsignedPayload is the payload hash of requests whose body is not signed.
+const UnsignedPayload = "UNSIGNED-PAYLOAD"
+
+const (
+	algorithm  = "AWS4-HMAC-SHA256"
+	timeFormat = "20060102T150405Z"
+)
+
+// emptyHash is the SHA-256 of an empty payload.
+var emptyHash = hex.EncodeToString(sha256.New().Sum(nil))
+
+// Credentials are AWS credentials, the session token is only set for
+// temporary credentials.
+type Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey secrets.Secret
+	SessionToken    secrets.Secret
+}
+
+// LoadCredentials returns the credentials set at [AccessKeyIDKey],
+// [SecretAccessKeyKey] and, optionally, [SessionTokenKey]. The secret access
+// key and session token are read from `wasmcloud:secrets`, the access key ID
+// from `wasi:config/runtime` or from `wasmcloud:secrets` if it is not set
+// there.
+func LoadCredentials() (Credentials, error) {
+	id, err := wasiconfig.Get(AccessKeyIDKey)
+	if errors.Is(err, wasiconfig.ErrNotFound) {
+		var secret secrets.Secret
+		if secret, err = secrets.Get(AccessKeyIDKey); err == nil {
+			id = secret.Reveal()
+		}
+	}
+	if err != nil {
+		return Credentials{}, fmt.Errorf("failed to load %s: %w", AccessKeyIDKey, err)
+	}
+
+	key, err := secrets.Get(SecretAccessKeyKey)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("failed to load %s: %w", SecretAccessKeyKey, err)
+	}
+	token, err := secrets.Get(SessionTokenKey)
+	if err != nil && !errors.Is(err, secrets.ErrNotFound) {
+		return Credentials{}, fmt.Errorf("failed to load %s: %w", SessionTokenKey, err)
+	}
+	return Credentials{AccessKeyID: id, SecretAccessKey: key, SessionToken: token}, nil
+}
+
+// Signer signs requests with AWS Signature Version 4.
+type Signer struct {
+	Credentials Credentials
+	// Region is the region of the service, e.g. "us-east-1". S3 compatible
+	// stores usually accept any region, "auto" for some.
+	Region string
+	// Service is the signing name of the service, e.g. "s3".
+	Service string
+	// UnsignedPayload sends the bodies unsigned, without buffering them to
+	// hash them. S3 accepts unsigned payloads over HTTPS.
+	UnsignedPayload bool
+}
+
+// Sign signs req at t, setting its Authorization header. The body is read
+// to hash it, unless UnsignedPayload is set or the payload hash is set in
+// the X-Amz-Content-Sha256 header, and replaced with a copy.
+//
+// The X-Amz-Content-Sha256 header is only set for S3, which requires it.
+func (s *Signer) Sign(req *http.Request, t time.Time) error {
+	payloadHash, err := s.payloadHash(req)
+	if err != nil {
+		return err
+	}
+
+	amzDate := t.UTC().Format(timeFormat)
+	req.Header.Set("X-Amz-Date", amzDate)
+	if s.Service == "s3" {
+		req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	}
+	if !s.Credentials.SessionToken.IsZero() {
+		req.Header.Set("X-Amz-Security-Token", s.Credentials.SessionToken.Reveal())
+	}
+	req.Header.Del("Authorization")
+
+	headers, signedHeaders := canonicalHeaders(req)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI(req.URL, s.Service != "s3"),
+		canonicalQuery(req.URL),
+		headers,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{amzDate[:8], s.Region, s.Service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{algorithm, amzDate, scope, hashHex([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.Credentials.SecretAccessKey.Reveal()), amzDate[:8])
+	for _, part := range []string{s.Region, s.Service, "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		algorithm, s.Credentials.AccessKeyID, scope, signedHeaders, signature))
+	return nil
+}
+
+// payloadHash returns the hex SHA-256 of the body of req, read through
+// GetBody if set, or buffered otherwise.
+func (s *Signer) payloadHash(req *http.Request) (string, error) {
+	if hash := req.Header.Get("X-Amz-Content-Sha256"); hash != "" {
+		return hash, nil
+	}
+	if s.UnsignedPayload {
+		return UnsignedPayload, nil
+	}
+	if req.Body == nil || req.Body == http.NoBody {
+		return emptyHash, nil
+	}
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return "", fmt.Errorf("failed to get body: %w", err)
+		}
+		defer body.Close()
+
+		h := sha256.New()
+		if _, err := io.Copy(h, body); err != nil {
+			return "", fmt.Errorf("failed to hash body: %w", err)
+		}
+		return hex.EncodeToString(h.Sum(nil)), nil
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return "", fmt.Errorf("failed to read body: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(buf))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(buf)), nil
+	}
+	req.ContentLength = int64(len(buf))
+	return hashHex(buf), nil
+}
+
+// Transport returns a RoundTripper signing the requests with s at the time
+// of `wasi:clocks/wall-clock` before passing them on to next, e.g. a
+// wasihttp.Transport.
+func Transport(s *Signer, next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		// NOTE: RoundTrippers must not modify the request
+		r = r.Clone(r.Context())
+		if err := s.Sign(r, wasiclock.NowFunc()); err != nil {
+			if r.Body != nil {
+				r.Body.Close()
+			}
+			return nil, fmt.Errorf("failed to sign request: %w", err)
+		}
+		return next.RoundTrip(r)
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// unsignedHeaders are not signed, as they may be changed on the way.
+var unsignedHeaders = map[string]bool{
+	"authorization":     true,
+	"user-agent":        true,
+	"x-amzn-trace-id":   true,
+	"expect":            true,
+	"connection":        true,
+	"transfer-encoding": true,
+	"traceparent":       true,
+	"tracestate":        true,
+}
+
+// canonicalHeaders returns the canonical headers of req and the list of their
+// names.
+func canonicalHeaders(req *http.Request) (string, string) {
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+	values := map[string]string{"host": host}
+	for k, v := range req.Header {
+		name := strings.ToLower(k)
+		if unsignedHeaders[name] || name == "host" {
+			continue
+		}
+		trimmed := make([]string, len(v))
+		for i, value := range v {
+			trimmed[i] = strings.Join(strings.Fields(value), " ")
+		}
+		values[name] = strings.Join(trimmed, ",")
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString(name)
+		b.WriteByte(':')
+		b.WriteString(values[name])
+		b.WriteByte('\n')
+	}
+	return b.String(), strings.Join(names, ";")
+}
+
+// canonicalURI returns the URI encoded path of u, encoded twice for services
+// other than S3.
+func canonicalURI(u *url.URL, twice bool) string {
+	path := u.Path
+	if path == "" {
+		return "/"
+	}
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = uriEncode(segment)
+		if twice {
+			segments[i] = uriEncode(segments[i])
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// canonicalQuery returns the URI encoded query parameters of u, sorted by
+// name and value.
+func canonicalQuery(u *url.URL) string {
+	var params [][2]string
+	for k, values := range u.Query() {
+		for _, v := range values {
+			params = append(params, [2]string{uriEncode(k), uriEncode(v)})
+		}
+	}
+	slices.SortFunc(params, func(a, b [2]string) int {
+		if c := strings.Compare(a[0], b[0]); c != 0 {
+			return c
+		}
+		return strings.Compare(a[1], b[1])
+	})
+	encoded := make([]string, len(params))
+	for i, p := range params {
+		encoded[i] = p[0] + "=" + p[1]
+	}
+	return strings.Join(encoded, "&")
+}
+
+// uriEncode percent-encodes the bytes of s other than the unreserved
+// characters of RFC 3986, as SigV4 requires.
+func uriEncode(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+func hashHex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
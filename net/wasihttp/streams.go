@@ -5,10 +5,13 @@ import (
 	"io"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/bytecodealliance/wasm-tools-go/cm"
+	monotonicclock "go.wasmcloud.dev/component/gen/wasi/clocks/monotonic-clock"
 	"go.wasmcloud.dev/component/gen/wasi/http/types"
 	"go.wasmcloud.dev/component/gen/wasi/io/streams"
+	"go.wasmcloud.dev/component/io/wasipoll"
 )
 
 type BodyConsumer interface {
@@ -16,50 +19,129 @@ type BodyConsumer interface {
 	Headers() (result types.Fields)
 }
 
+// inputStreamReader reads an incoming body, which is consumed on the first
+// read.
 type inputStreamReader struct {
-	consumer    BodyConsumer
-	body        types.IncomingBody
-	stream      streams.InputStream
-	trailerLock sync.Mutex
-	trailers    http.Header
-	trailerOnce sync.Once
-	dropOnce    sync.Once
+	consumer BodyConsumer
+	trailers http.Header
+	// release, if set, drops the consumer on Close
+	release func()
+	// deadline, if set, bounds the reads, which then wait on the stream and a
+	// clock pollable instead of blocking, and fail with deadlineErr past it
+	deadline    time.Time
+	deadlineErr error
+
+	mu sync.Mutex
+	// acquired is set once the body and its stream were taken from the
+	// consumer, err is the error taking them
+	acquired bool
+	err      error
+	body     types.IncomingBody
+	stream   streams.InputStream
+	// finished is set once the body was finished and the trailers read, the
+	// body and the stream are released by then
+	finished   bool
+	trailerErr error
+	closed     bool
+}
+
+// acquire takes the body and its stream from the consumer. It must be called
+// with r.mu held.
+func (r *inputStreamReader) acquire() error {
+	if r.closed {
+		return http.ErrBodyReadAfterClose
+	}
+	if r.acquired {
+		return r.err
+	}
+	r.acquired = true
+
+	consumeResult := r.consumer.Consume()
+	if consumeResult.IsErr() {
+		r.err = fmt.Errorf("failed to consume incoming body: %v", *consumeResult.Err())
+		return r.err
+	}
+	body := *consumeResult.OK()
+	streamResult := body.Stream()
+	if streamResult.IsErr() {
+		body.ResourceDrop()
+		r.err = fmt.Errorf("failed to consume incoming body's stream: %v", *streamResult.Err())
+		return r.err
+	}
+	r.body, r.stream = body, *streamResult.OK()
+	return nil
 }
 
+// Close drops the body and its stream unless they were drained, e.g. when a
+// handler responds without reading the request body, and releases the
+// consumer.
 func (r *inputStreamReader) Close() error {
-	r.dropOnce.Do(func() {
-		r.stream.ResourceDrop()
-	})
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+	if r.acquired && r.err == nil && !r.finished {
+		// NOTE: the stream is a child of the body, it is dropped first
+		r.stream.ResourceDrop()
+		r.body.ResourceDrop()
+	}
+	if r.release != nil {
+		r.release()
+	}
 	return nil
 }
 
-func (r *inputStreamReader) parseTrailers() {
-	r.trailerLock.Lock()
-	defer r.trailerLock.Unlock()
+// TrailerError is returned instead of io.EOF at the end of an incoming body
+// whose trailers were not received, the body itself was read in full.
+type TrailerError struct {
+	// Code is the `wasi:http/types.error-code` reported by the host, e.g.
+	// "HTTP-protocol-error".
+	Code string
+}
+
+func (e *TrailerError) Error() string {
+	return "failed to receive trailers: " + e.Code
+}
 
-	// if we got this far, then we release ownership from body, otherwise it is our responsibility to drop it
-	r.dropOnce.Do(func() {})
+// finish finishes the drained body and reads the trailers, waiting for them
+// if needed. It must be called with r.mu held.
+func (r *inputStreamReader) finish() error {
+	if r.finished {
+		return r.trailerErr
+	}
+	r.finished = true
 
 	r.stream.ResourceDrop()
 	futureTrailers := types.IncomingBodyFinish(r.body)
+	defer futureTrailers.ResourceDrop()
 
 	trailersResult := futureTrailers.Get()
-
-	// unroll the future
 	if trailersResult.None() {
-		return
+		pollable := futureTrailers.Subscribe()
+		pollable.Block()
+		pollable.ResourceDrop()
+		trailersResult = futureTrailers.Get()
 	}
-	if trailersResult.Some().IsErr() {
-		return
+
+	// unroll the future
+	switch {
+	case trailersResult.None():
+		r.trailerErr = &TrailerError{Code: "not ready"}
+	case trailersResult.Some().IsErr():
+		r.trailerErr = &TrailerError{Code: "already taken"}
+	case trailersResult.Some().OK().IsErr():
+		r.trailerErr = &TrailerError{Code: errorCodeName(trailersResult.Some().OK().Err())}
 	}
-	if trailersResult.Some().OK().IsErr() {
-		return
+	if r.trailerErr != nil {
+		return r.trailerErr
 	}
-	maybeWasiTrailers := trailersResult.Some().OK().OK()
 
+	maybeWasiTrailers := trailersResult.Some().OK().OK()
 	if maybeWasiTrailers.None() {
-		return
+		return nil
 	}
 
 	wasiTrailers := maybeWasiTrailers.Some()
@@ -68,14 +150,43 @@ func (r *inputStreamReader) parseTrailers() {
 	}
 
 	wasiTrailers.ResourceDrop()
+	return nil
+}
+
+// eof returns the error at the end of the finished body.
+func (r *inputStreamReader) eof() error {
+	if r.trailerErr != nil {
+		return r.trailerErr
+	}
+	return io.EOF
 }
 
 func (r *inputStreamReader) Read(p []byte) (n int, err error) {
-	readResult := r.stream.BlockingRead(uint64(len(p)))
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.acquire(); err != nil {
+		return 0, err
+	}
+	if r.finished {
+		return 0, r.eof()
+	}
+
+	var readResult cm.Result[cm.List[uint8], cm.List[uint8], streams.StreamError]
+	if r.deadline.IsZero() {
+		readResult = r.stream.BlockingRead(uint64(len(p)))
+	} else {
+		if err := r.wait(); err != nil {
+			return 0, err
+		}
+		readResult = r.stream.Read(uint64(len(p)))
+	}
 	if readResult.IsErr() {
 		readErr := readResult.Err()
 		if readErr.Closed() {
-			r.trailerOnce.Do(r.parseTrailers)
+			if err := r.finish(); err != nil {
+				return 0, err
+			}
 			return 0, io.EOF
 		}
 		return 0, fmt.Errorf("failed to read from InputStream %s", readErr.LastOperationFailed().ToDebugString())
@@ -86,28 +197,52 @@ func (r *inputStreamReader) Read(p []byte) (n int, err error) {
 	return int(readList.Len()), nil
 }
 
-func NewIncomingBodyTrailer(consumer BodyConsumer) (io.ReadCloser, http.Header, error) {
-	trailers := http.Header{}
-	consumeResult := consumer.Consume()
-	if consumeResult.IsErr() {
-		return nil, nil, fmt.Errorf("failed to consume incoming request %s", *consumeResult.Err())
+// wait waits for the stream to become readable, racing it against a
+// `wasi:clocks/monotonic-clock` pollable expiring at the deadline. It must be
+// called with r.mu held.
+func (r *inputStreamReader) wait() error {
+	timeout := time.Until(r.deadline)
+	if timeout <= 0 {
+		return r.deadlineErr
 	}
-	body := *consumeResult.OK()
-	streamResult := body.Stream()
-	if streamResult.IsErr() {
-		return nil, nil, fmt.Errorf("failed to consume incoming requests's stream %s", streamResult.Err())
+
+	pollable := r.stream.Subscribe()
+	defer pollable.ResourceDrop()
+	timer := monotonicclock.SubscribeDuration(monotonicclock.Duration(timeout))
+	defer timer.ResourceDrop()
+
+	if wasipoll.Wait(pollable, timer) == 1 {
+		return r.deadlineErr
 	}
+	return nil
+}
+
+// NewIncomingBodyTrailer returns the body of consumer and its trailers, set
+// once the body was read to EOF. The body is consumed on the first read,
+// errors consuming it are returned by Read.
+func NewIncomingBodyTrailer(consumer BodyConsumer) (io.ReadCloser, http.Header, error) {
+	body, trailers := newIncomingBody(consumer, nil)
+	return body, trailers, nil
+}
+
+// newIncomingBody returns the body of consumer like [NewIncomingBodyTrailer],
+// closing it calls release, which drops the consumer.
+//
+// NOTE: not an interface assertion on consumer, which would link the drop
+// of every resource of the bindings
+func newIncomingBody(consumer BodyConsumer, release func()) (*inputStreamReader, http.Header) {
+	trailers := http.Header{}
 	return &inputStreamReader{
 		consumer: consumer,
 		trailers: trailers,
-		body:     body,
-		stream:   *streamResult.OK(),
-	}, trailers, nil
+		release:  release,
+	}, trailers
 }
 
 type outputStreamReader struct {
 	body   types.OutgoingBody
 	stream streams.OutputStream
+	writes chunkWriter
 }
 
 func NewOutgoingBody(body types.OutgoingBody) (io.WriteCloser, error) {
@@ -128,14 +263,98 @@ func (r *outputStreamReader) Close() error {
 }
 
 func (r *outputStreamReader) Write(p []byte) (n int, err error) {
-	contents := cm.ToList(p)
-	writeResult := r.stream.BlockingWriteAndFlush(contents)
-	if writeResult.IsErr() {
-		if writeResult.Err().Closed() {
-			return 0, io.EOF
+	return r.writes.write(r.stream, p)
+}
+
+// bounds of the chunk size of a chunkWriter, and the flush latencies halving
+// or doubling it
+const (
+	minWriteSize = 4096
+	maxWriteSize = 1 << 20
+	fastFlush    = time.Millisecond
+	slowFlush    = 10 * time.Millisecond
+)
+
+// chunkWriter writes to an output stream in chunks bounded by the
+// check-write budget of the host, flushing each. The chunk size starts at
+// minWriteSize and doubles while flushes are fast, up to maxWriteSize, and
+// halves when they are slow, so large bodies take few calls on hosts with
+// large buffers without stalling hosts with small ones.
+type chunkWriter struct {
+	size int
+}
+
+func (c *chunkWriter) write(stream streams.OutputStream, p []byte) (n int, err error) {
+	if c.size == 0 {
+		c.size = minWriteSize
+	}
+	for n < len(p) {
+		checkResult := stream.CheckWrite()
+		if checkResult.IsErr() {
+			return n, writeError(checkResult.Err())
+		}
+		budget := *checkResult.OK()
+		if budget == 0 {
+			pollable := stream.Subscribe()
+			wasipoll.Wait(pollable)
+			pollable.ResourceDrop()
+			continue
+		}
+
+		chunk := p[n:min(n+c.size, len(p))]
+		if uint64(len(chunk)) > budget {
+			chunk = chunk[:budget]
+		}
+		if writeResult := stream.Write(cm.ToList(chunk)); writeResult.IsErr() {
+			return n, writeError(writeResult.Err())
 		}
+		start := time.Now()
+		if flushResult := stream.BlockingFlush(); flushResult.IsErr() {
+			return n, writeError(flushResult.Err())
+		}
+		c.size = nextWriteSize(c.size, len(chunk), time.Since(start))
+		n += len(chunk)
+	}
+	return n, nil
+}
 
-		return 0, fmt.Errorf("failed to write to response body's stream: %s", writeResult.Err().LastOperationFailed().ToDebugString())
+// nextWriteSize returns the size of the chunk following one of n bytes, of
+// at most size, flushed in latency. The size only grows after full chunks,
+// which were not cut by the check-write budget or the end of the data.
+func nextWriteSize(size, n int, latency time.Duration) int {
+	switch {
+	case latency >= slowFlush:
+		return max(size/2, minWriteSize)
+	case latency < fastFlush && n == size:
+		return min(size*2, maxWriteSize)
 	}
-	return len(p), nil
+	return size
+}
+
+func writeError(err *streams.StreamError) error {
+	if err.Closed() {
+		return io.EOF
+	}
+	return fmt.Errorf("failed to write to response body's stream: %s", err.LastOperationFailed().ToDebugString())
+}
+
+// finish drops the stream, which must precede finishing the body, and
+// finishes the body with trailers, if any.
+func (r *outputStreamReader) finish(trailers http.Header) error {
+	r.stream.ResourceDrop()
+
+	maybeTrailers := cm.None[types.Fields]()
+	if len(trailers) > 0 {
+		wasiTrailers := types.NewFields()
+		if err := toWasiHeader(trailers, wasiTrailers); err != nil {
+			wasiTrailers.ResourceDrop()
+			r.body.ResourceDrop()
+			return err
+		}
+		maybeTrailers = cm.Some(wasiTrailers)
+	}
+	if res := types.OutgoingBodyFinish(r.body, maybeTrailers); res.IsErr() {
+		return &requestError{code: errorCodeName(res.Err())}
+	}
+	return nil
 }
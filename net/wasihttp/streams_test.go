@@ -0,0 +1,29 @@
+package wasihttp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextWriteSize(t *testing.T) {
+	tests := map[string]struct {
+		size, n int
+		latency time.Duration
+		want    int
+	}{
+		"fast full chunk":    {size: 4096, n: 4096, latency: 0, want: 8192},
+		"fast partial chunk": {size: 4096, n: 100, latency: 0, want: 4096},
+		"fast at max":        {size: maxWriteSize, n: maxWriteSize, latency: 0, want: maxWriteSize},
+		"moderate":           {size: 16384, n: 16384, latency: 5 * time.Millisecond, want: 16384},
+		"slow":               {size: 16384, n: 16384, latency: 20 * time.Millisecond, want: 8192},
+		"slow at min":        {size: minWriteSize, n: minWriteSize, latency: time.Second, want: minWriteSize},
+		"slow partial chunk": {size: 65536, n: 10, latency: slowFlush, want: 32768},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := nextWriteSize(tc.size, tc.n, tc.latency); got != tc.want {
+				t.Errorf("expected: %v, got: %v", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package wasihttp
+
+import (
+	"bufio"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"net/http"
+	"path"
+)
+
+// renderBufferSize is the size of the writes of [RenderHTML] to the response,
+// templates write many small chunks, each flushed to the host otherwise.
+const renderBufferSize = 4096
+
+// RenderHTML executes tmpl with data into the response, with the Content-Type
+// defaulting to text/html. The output is streamed in chunks of 4 KiB, so the
+// response can still be replaced if execution fails within the first chunk.
+//
+// NOTE: TinyGo does not implement reflect.Value.Call, templates executed in
+// TinyGo components may only access the fields of structs, maps and slices of
+// data, calling methods or functions of a template.FuncMap panics.
+func RenderHTML(w http.ResponseWriter, tmpl *template.Template, data any) error {
+	defaulted := w.Header().Get("Content-Type") == ""
+	if defaulted {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	}
+
+	cw := &countingWriter{w: w}
+	bw := bufio.NewWriterSize(cw, renderBufferSize)
+	if err := tmpl.Execute(bw, data); err != nil {
+		if defaulted && cw.n == 0 {
+			w.Header().Del("Content-Type")
+		}
+		return fmt.Errorf("failed to render template %s: %w", tmpl.Name(), err)
+	}
+	return bw.Flush()
+}
+
+// countingWriter counts the bytes written to w.
+type countingWriter struct {
+	w http.ResponseWriter
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.n += int64(n)
+	return n, err
+}
+
+// Templates are the html/template pages of a fs.FS, e.g. an embed.FS, each
+// parsed with the shared layouts.
+type Templates struct {
+	pages map[string]*template.Template
+}
+
+// ParseTemplates parses the files of fsys matching the pages pattern, each
+// with the files matching the layouts pattern, if any. A page is named by its
+// file name, e.g. "index.html", and executes the first layout, which renders
+// the blocks defined by the page, or itself without layouts.
+func ParseTemplates(fsys fs.FS, layouts, pages string) (*Templates, error) {
+	var root *template.Template
+	if layouts != "" {
+		names, err := fs.Glob(fsys, layouts)
+		if err != nil {
+			return nil, err
+		}
+		if len(names) == 0 {
+			return nil, fmt.Errorf("no layouts match %q", layouts)
+		}
+		root, err = template.New(path.Base(names[0])).ParseFS(fsys, layouts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	names, err := fs.Glob(fsys, pages)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no pages match %q", pages)
+	}
+	t := &Templates{pages: make(map[string]*template.Template, len(names))}
+	for _, name := range names {
+		page := template.New(path.Base(name))
+		if root != nil {
+			if page, err = root.Clone(); err != nil {
+				return nil, err
+			}
+		}
+		if page, err = page.ParseFS(fsys, name); err != nil {
+			return nil, err
+		}
+		t.pages[path.Base(name)] = page
+	}
+	return t, nil
+}
+
+// Render renders the page with data into the response, like [RenderHTML].
+func (t *Templates) Render(w http.ResponseWriter, page string, data any) error {
+	tmpl, ok := t.pages[page]
+	if !ok {
+		return fmt.Errorf("no page %q", page)
+	}
+	return RenderHTML(w, tmpl, data)
+}
@@ -0,0 +1,109 @@
+package wasihttp
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// page holds the data of the test templates, accessed through fields and map
+// keys only, like in TinyGo components.
+type page struct {
+	Title string
+	Items []string
+	Meta  map[string]string
+}
+
+func TestRenderHTML(t *testing.T) {
+	tmpl := template.Must(template.New("list").Parse(`<h1>{{.Title}}</h1>{{range .Items}}<li>{{.}}</li>{{end}}{{.Meta.lang}}`))
+
+	rec := httptest.NewRecorder()
+	err := RenderHTML(rec, tmpl, page{Title: "<b>", Items: []string{"a", "b"}, Meta: map[string]string{"lang": "en"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "<h1>&lt;b&gt;</h1><li>a</li><li>b</li>en"; rec.Body.String() != want {
+		t.Errorf("expected: %q, got: %q", want, rec.Body)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "text/html; charset=utf-8" {
+		t.Errorf("expected: %v, got: %v", "text/html; charset=utf-8", got)
+	}
+
+	t.Run("large", func(t *testing.T) {
+		items := make([]string, 1000)
+		for i := range items {
+			items[i] = "item"
+		}
+		rec := httptest.NewRecorder()
+		if err := RenderHTML(rec, tmpl, page{Items: items}); err != nil {
+			t.Fatal(err)
+		}
+		if want := "<h1></h1>" + strings.Repeat("<li>item</li>", 1000); rec.Body.String() != want {
+			t.Errorf("expected: %d bytes, got: %d", len(want), rec.Body.Len())
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		tmpl := template.Must(template.New("missing").Parse(`{{.Missing}}`))
+		rec := httptest.NewRecorder()
+		if err := RenderHTML(rec, tmpl, page{}); err == nil {
+			t.Fatal("expected an error")
+		}
+		if rec.Body.Len() != 0 || rec.Header().Get("Content-Type") != "" {
+			t.Errorf("expected an unwritten response, got: %q %v", rec.Body, rec.Header())
+		}
+	})
+}
+
+func TestTemplates(t *testing.T) {
+	fsys := fstest.MapFS{
+		"layouts/base.html":  {Data: []byte(`<title>{{block "title" .}}Site{{end}}</title>{{template "content" .}}`)},
+		"layouts/nav.html":   {Data: []byte(`{{define "nav"}}<nav></nav>{{end}}`)},
+		"pages/index.html":   {Data: []byte(`{{define "content"}}{{template "nav"}}{{.Title}}{{end}}`)},
+		"pages/about.html":   {Data: []byte(`{{define "title"}}About{{end}}{{define "content"}}about{{end}}`)},
+		"partials/card.html": {Data: []byte(`<div>{{.Title}}</div>`)},
+	}
+
+	tmpls, err := ParseTemplates(fsys, "layouts/*.html", "pages/*.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range map[string]string{
+		"index.html": "<title>Site</title><nav></nav>home",
+		"about.html": "<title>About</title>about",
+	} {
+		rec := httptest.NewRecorder()
+		if err := tmpls.Render(rec, name, page{Title: "home"}); err != nil {
+			t.Fatal(err)
+		}
+		if rec.Body.String() != want {
+			t.Errorf("%s: expected: %q, got: %q", name, want, rec.Body)
+		}
+	}
+	if err := tmpls.Render(httptest.NewRecorder(), "missing.html", nil); err == nil {
+		t.Error("expected an error")
+	}
+
+	t.Run("without layouts", func(t *testing.T) {
+		tmpls, err := ParseTemplates(fsys, "", "partials/*.html")
+		if err != nil {
+			t.Fatal(err)
+		}
+		rec := httptest.NewRecorder()
+		if err := tmpls.Render(rec, "card.html", page{Title: "card"}); err != nil {
+			t.Fatal(err)
+		}
+		if want := "<div>card</div>"; rec.Body.String() != want || rec.Code != http.StatusOK {
+			t.Errorf("expected: %q, got: %q", want, rec.Body)
+		}
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		if _, err := ParseTemplates(fsys, "", "missing/*.html"); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
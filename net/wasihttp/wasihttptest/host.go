@@ -0,0 +1,845 @@
+//go:build !wasm
+
+package wasihttptest
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"unsafe"
+
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+	monotonicclock "go.wasmcloud.dev/component/gen/wasi/clocks/monotonic-clock"
+	outgoinghandler "go.wasmcloud.dev/component/gen/wasi/http/outgoing-handler"
+	"go.wasmcloud.dev/component/gen/wasi/http/types"
+	"go.wasmcloud.dev/component/gen/wasi/io/streams"
+	"go.wasmcloud.dev/component/internal/fakehost"
+)
+
+// The `wasi:http` resources are kept in the resource table of fakehost, with
+// the streams and pollables served there. Only the imports used by the
+// wasihttp server adapter and Transport are implemented, others panic.
+
+type fields struct {
+	header http.Header
+}
+
+func (*fields) ResourceName() string { return "fields" }
+
+// incomingMessage is the body and trailers of an incoming request or
+// response.
+type incomingMessage struct {
+	body     []byte
+	trailers http.Header
+	consumed bool
+	// trailersFail fails the future-trailers with an HTTP protocol error
+	trailersFail bool
+}
+
+type incomingRequest struct {
+	method        string
+	authority     string
+	pathWithQuery string
+	headers       http.Header
+	incomingMessage
+}
+
+func (*incomingRequest) ResourceName() string { return "incoming-request" }
+
+type incomingResponse struct {
+	status  int
+	headers http.Header
+	incomingMessage
+}
+
+func (*incomingResponse) ResourceName() string { return "incoming-response" }
+
+type incomingBody struct {
+	message *incomingMessage
+}
+
+func (*incomingBody) ResourceName() string { return "incoming-body" }
+
+type futureTrailers struct {
+	trailers http.Header
+	fail     bool
+	// ready is set once a pollable of the future was polled
+	ready bool
+}
+
+func (*futureTrailers) ResourceName() string { return "future-trailers" }
+
+type outgoingResponse struct {
+	status   int
+	headers  http.Header
+	body     *bytes.Buffer
+	taken    bool
+	recorder *ResponseRecorder
+}
+
+func (*outgoingResponse) ResourceName() string { return "outgoing-response" }
+
+// outgoingRequest is a request of the Transport, served with Outgoing once
+// its body was finished.
+type outgoingRequest struct {
+	method        string
+	scheme        string
+	authority     string
+	pathWithQuery string
+	headers       http.Header
+	body          *bytes.Buffer
+	taken         bool
+	trailers      http.Header
+	finished      bool
+	// future is set once the request was handled
+	future *futureResponse
+}
+
+func (*outgoingRequest) ResourceName() string { return "outgoing-request" }
+
+type futureResponse struct {
+	response *incomingResponse
+	err      *types.ErrorCode
+	taken    bool
+}
+
+func (*futureResponse) ResourceName() string { return "future-incoming-response" }
+
+type requestOptions struct{}
+
+func (*requestOptions) ResourceName() string { return "request-options" }
+
+type outgoingBody struct {
+	body     *bytes.Buffer
+	response *outgoingResponse
+	request  *outgoingRequest
+	written  bool
+	stream   uint32
+}
+
+func (*outgoingBody) ResourceName() string { return "outgoing-body" }
+
+func methodOf(method string) types.Method {
+	switch method {
+	case http.MethodGet:
+		return types.MethodGet()
+	case http.MethodHead:
+		return types.MethodHead()
+	case http.MethodPost:
+		return types.MethodPost()
+	case http.MethodPut:
+		return types.MethodPut()
+	case http.MethodDelete:
+		return types.MethodDelete()
+	case http.MethodConnect:
+		return types.MethodConnect()
+	case http.MethodOptions:
+		return types.MethodOptions()
+	case http.MethodTrace:
+		return types.MethodTrace()
+	case http.MethodPatch:
+		return types.MethodPatch()
+	}
+	return types.MethodOther(method)
+}
+
+// stub wasi:http/types, fields
+//
+//go:linkname wasmimport_NewFields go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_NewFields
+func wasmimport_NewFields() (result0 uint32) {
+	fakehost.Lock()
+	defer fakehost.Unlock()
+	return fakehost.Add(&fields{header: http.Header{}})
+}
+
+//go:linkname wasmimport_FieldsResourceDrop go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_FieldsResourceDrop
+func wasmimport_FieldsResourceDrop(self0 uint32) {
+	fakehost.Drop(self0)
+}
+
+//go:linkname wasmimport_FieldsEntries go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_FieldsEntries
+func wasmimport_FieldsEntries(self0 uint32, result *cm.List[cm.Tuple[types.FieldKey, types.FieldValue]]) {
+	fakehost.Lock()
+	defer fakehost.Unlock()
+
+	header := fakehost.Resource[*fields](self0).header
+	keys := make([]string, 0, len(header))
+	for key := range header {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	var entries []cm.Tuple[types.FieldKey, types.FieldValue]
+	for _, key := range keys {
+		for _, value := range header[key] {
+			entries = append(entries, cm.Tuple[types.FieldKey, types.FieldValue]{
+				F0: types.FieldKey(key),
+				F1: types.FieldValue(cm.ToList([]byte(value))),
+			})
+		}
+	}
+	*result = cm.ToList(entries)
+}
+
+//go:linkname wasmimport_FieldsSet go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_FieldsSet
+func wasmimport_FieldsSet(self0 uint32, name0 *uint8, name1 uint32, value0 *types.FieldValue, value1 uint32, result *cm.Result[types.HeaderError, struct{}, types.HeaderError]) {
+	fakehost.Lock()
+	defer fakehost.Unlock()
+
+	name := http.CanonicalHeaderKey(unsafe.String(name0, name1))
+	var values []string
+	for _, value := range unsafe.Slice(value0, value1) {
+		values = append(values, string(cm.List[uint8](value).Slice()))
+	}
+	fakehost.Resource[*fields](self0).header[name] = values
+	*result = cm.OK[cm.Result[types.HeaderError, struct{}, types.HeaderError]](struct{}{})
+}
+
+// stub wasi:http/types, incoming-request
+//
+//go:linkname wasmimport_IncomingRequestMethod go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_IncomingRequestMethod
+func wasmimport_IncomingRequestMethod(self0 uint32, result *types.Method) {
+	fakehost.Lock()
+	defer fakehost.Unlock()
+	*result = methodOf(fakehost.Resource[*incomingRequest](self0).method)
+}
+
+//go:linkname wasmimport_IncomingRequestAuthority go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_IncomingRequestAuthority
+func wasmimport_IncomingRequestAuthority(self0 uint32, result *cm.Option[string]) {
+	fakehost.Lock()
+	defer fakehost.Unlock()
+	if authority := fakehost.Resource[*incomingRequest](self0).authority; authority != "" {
+		*result = cm.Some(authority)
+		return
+	}
+	*result = cm.None[string]()
+}
+
+//go:linkname wasmimport_IncomingRequestPathWithQuery go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_IncomingRequestPathWithQuery
+func wasmimport_IncomingRequestPathWithQuery(self0 uint32, result *cm.Option[string]) {
+	fakehost.Lock()
+	defer fakehost.Unlock()
+	*result = cm.Some(fakehost.Resource[*incomingRequest](self0).pathWithQuery)
+}
+
+//go:linkname wasmimport_IncomingRequestHeaders go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_IncomingRequestHeaders
+func wasmimport_IncomingRequestHeaders(self0 uint32) (result0 uint32) {
+	fakehost.Lock()
+	defer fakehost.Unlock()
+	// NOTE: like the host, each call returns a new child resource
+	return fakehost.Add(&fields{header: fakehost.Resource[*incomingRequest](self0).headers})
+}
+
+//go:linkname wasmimport_IncomingRequestResourceDrop go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_IncomingRequestResourceDrop
+func wasmimport_IncomingRequestResourceDrop(self0 uint32) {
+	fakehost.Drop(self0)
+}
+
+// stub wasi:http/types, the body of an incoming request may be consumed once
+//
+//go:linkname wasmimport_IncomingRequestConsume go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_IncomingRequestConsume
+func wasmimport_IncomingRequestConsume(self0 uint32, result *cm.Result[types.IncomingBody, types.IncomingBody, struct{}]) {
+	fakehost.Lock()
+	defer fakehost.Unlock()
+
+	req := fakehost.Resource[*incomingRequest](self0)
+	if req == nil || req.consumed {
+		*result = cm.Err[cm.Result[types.IncomingBody, types.IncomingBody, struct{}]](struct{}{})
+		return
+	}
+	req.consumed = true
+	*result = cm.OK[cm.Result[types.IncomingBody, types.IncomingBody, struct{}]](types.IncomingBody(fakehost.Add(&incomingBody{message: &req.incomingMessage})))
+}
+
+//go:linkname wasmimport_IncomingBodyStream go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_IncomingBodyStream
+func wasmimport_IncomingBodyStream(self0 uint32, result *cm.Result[streams.InputStream, streams.InputStream, struct{}]) {
+	fakehost.Lock()
+	defer fakehost.Unlock()
+
+	// NOTE: the body moves to the stream, so it is released once the stream
+	// is dropped
+	body := fakehost.Resource[*incomingBody](self0)
+	stream := fakehost.Add(&fakehost.InputStream{Data: body.message.body})
+	body.message.body = nil
+	*result = cm.OK[cm.Result[streams.InputStream, streams.InputStream, struct{}]](streams.InputStream(stream))
+}
+
+//go:linkname wasmimport_IncomingBodyResourceDrop go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_IncomingBodyResourceDrop
+func wasmimport_IncomingBodyResourceDrop(self0 uint32) {
+	fakehost.Drop(self0)
+}
+
+//go:linkname wasmimport_IncomingBodyFinish go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_IncomingBodyFinish
+func wasmimport_IncomingBodyFinish(this0 uint32) (result0 uint32) {
+	fakehost.Lock()
+	defer fakehost.Unlock()
+
+	body := fakehost.Resource[*incomingBody](this0)
+	fakehost.Delete(this0)
+	return fakehost.Add(&futureTrailers{trailers: body.message.trailers, fail: body.message.trailersFail})
+}
+
+//go:linkname wasmimport_FutureTrailersGet go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_FutureTrailersGet
+func wasmimport_FutureTrailersGet(self0 uint32, result *cm.Option[cm.Result[cm.Result[types.ErrorCodeShape, cm.Option[types.Fields], types.ErrorCode], cm.Result[types.ErrorCodeShape, cm.Option[types.Fields], types.ErrorCode], struct{}]]) {
+	fakehost.Lock()
+	defer fakehost.Unlock()
+
+	future := fakehost.Resource[*futureTrailers](self0)
+	if !future.ready {
+		*result = cm.None[cm.Result[cm.Result[types.ErrorCodeShape, cm.Option[types.Fields], types.ErrorCode], cm.Result[types.ErrorCodeShape, cm.Option[types.Fields], types.ErrorCode], struct{}]]()
+		return
+	}
+	if future.fail {
+		*result = cm.Some(cm.OK[cm.Result[cm.Result[types.ErrorCodeShape, cm.Option[types.Fields], types.ErrorCode], cm.Result[types.ErrorCodeShape, cm.Option[types.Fields], types.ErrorCode], struct{}]](
+			cm.Err[cm.Result[types.ErrorCodeShape, cm.Option[types.Fields], types.ErrorCode]](types.ErrorCodeHTTPProtocolError()),
+		))
+		return
+	}
+	trailers := cm.None[types.Fields]()
+	if t := future.trailers; len(t) > 0 {
+		trailers = cm.Some(types.Fields(fakehost.Add(&fields{header: t.Clone()})))
+	}
+	*result = cm.Some(cm.OK[cm.Result[cm.Result[types.ErrorCodeShape, cm.Option[types.Fields], types.ErrorCode], cm.Result[types.ErrorCodeShape, cm.Option[types.Fields], types.ErrorCode], struct{}]](
+		cm.OK[cm.Result[types.ErrorCodeShape, cm.Option[types.Fields], types.ErrorCode]](trailers),
+	))
+}
+
+// stub wasi:http/types, future-trailers are ready once their pollable
+// was polled
+//
+//go:linkname wasmimport_FutureTrailersSubscribe go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_FutureTrailersSubscribe
+func wasmimport_FutureTrailersSubscribe(self0 uint32) (result0 uint32) {
+	fakehost.Lock()
+	defer fakehost.Unlock()
+	future := fakehost.Resource[*futureTrailers](self0)
+	return fakehost.Add(&fakehost.Pollable{Ready: func() bool {
+		future.ready = true
+		return true
+	}})
+}
+
+//go:linkname wasmimport_FutureTrailersResourceDrop go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_FutureTrailersResourceDrop
+func wasmimport_FutureTrailersResourceDrop(self0 uint32) {
+	fakehost.Drop(self0)
+}
+
+// stub wasi:http/types, outgoing-response
+//
+//go:linkname wasmimport_NewOutgoingResponse go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_NewOutgoingResponse
+func wasmimport_NewOutgoingResponse(headers0 uint32) (result0 uint32) {
+	fakehost.Lock()
+	defer fakehost.Unlock()
+
+	headers := fakehost.Resource[*fields](headers0).header
+	fakehost.Delete(headers0)
+	return fakehost.Add(&outgoingResponse{status: http.StatusOK, headers: headers, body: &bytes.Buffer{}})
+}
+
+//go:linkname wasmimport_OutgoingResponseSetStatusCode go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_OutgoingResponseSetStatusCode
+func wasmimport_OutgoingResponseSetStatusCode(self0 uint32, statusCode0 uint32) (result0 uint32) {
+	fakehost.Lock()
+	defer fakehost.Unlock()
+
+	if statusCode0 < 100 || statusCode0 > 999 {
+		return 1
+	}
+	fakehost.Resource[*outgoingResponse](self0).status = int(statusCode0)
+	return 0
+}
+
+//go:linkname wasmimport_OutgoingResponseBody go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_OutgoingResponseBody
+func wasmimport_OutgoingResponseBody(self0 uint32, result *cm.Result[types.OutgoingBody, types.OutgoingBody, struct{}]) {
+	fakehost.Lock()
+	defer fakehost.Unlock()
+
+	resp := fakehost.Resource[*outgoingResponse](self0)
+	if resp.taken {
+		*result = cm.Err[cm.Result[types.OutgoingBody, types.OutgoingBody, struct{}]](struct{}{})
+		return
+	}
+	resp.taken = true
+	*result = cm.OK[cm.Result[types.OutgoingBody, types.OutgoingBody, struct{}]](types.OutgoingBody(fakehost.Add(&outgoingBody{body: resp.body, response: resp})))
+}
+
+//go:linkname wasmimport_OutgoingBodyWrite go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_OutgoingBodyWrite
+func wasmimport_OutgoingBodyWrite(self0 uint32, result *cm.Result[streams.OutputStream, streams.OutputStream, struct{}]) {
+	fakehost.Lock()
+	defer fakehost.Unlock()
+
+	body := fakehost.Resource[*outgoingBody](self0)
+	if body.written {
+		*result = cm.Err[cm.Result[streams.OutputStream, streams.OutputStream, struct{}]](struct{}{})
+		return
+	}
+	body.written = true
+	stream := fakehost.Add(&fakehost.OutputStream{Buffer: body.body})
+	body.stream = stream
+	*result = cm.OK[cm.Result[streams.OutputStream, streams.OutputStream, struct{}]](streams.OutputStream(stream))
+}
+
+// stub wasi:http/types, trailers0 is the option discriminant
+//
+//go:linkname wasmimport_OutgoingBodyFinish go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_OutgoingBodyFinish
+func wasmimport_OutgoingBodyFinish(this0 uint32, trailers0 uint32, trailers1 uint32, result *cm.Result[types.ErrorCode, struct{}, types.ErrorCode]) {
+	fakehost.Lock()
+	body := fakehost.Resource[*outgoingBody](this0)
+	if fakehost.Alive(body.stream) {
+		fakehost.Unlock()
+		panic("wasihttptest: outgoing-body finished while its output-stream is alive")
+	}
+	fakehost.Delete(this0)
+	*result = cm.OK[cm.Result[types.ErrorCode, struct{}, types.ErrorCode]](struct{}{})
+	var trailers http.Header
+	if trailers0 == 1 {
+		trailers = fakehost.Resource[*fields](trailers1).header
+		fakehost.Delete(trailers1)
+	}
+	if resp := body.response; resp != nil && resp.recorder != nil {
+		resp.recorder.Finished = true
+		if trailers != nil {
+			resp.recorder.Trailers = trailers
+		}
+	}
+	req := body.request
+	handled := req != nil && req.future != nil
+	if req != nil {
+		req.trailers, req.finished = trailers, true
+	}
+	fakehost.Unlock()
+
+	// NOTE: the request is served once its body was sent, like by a server
+	// reading the whole request first
+	if handled {
+		serveOutgoing(req)
+	}
+}
+
+// stub wasi:http/types, outgoing-request
+//
+//go:linkname wasmimport_NewOutgoingRequest go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_NewOutgoingRequest
+func wasmimport_NewOutgoingRequest(headers0 uint32) (result0 uint32) {
+	fakehost.Lock()
+	defer fakehost.Unlock()
+
+	headers := fakehost.Resource[*fields](headers0).header
+	fakehost.Delete(headers0)
+	return fakehost.Add(&outgoingRequest{method: http.MethodGet, scheme: "http", headers: headers, body: &bytes.Buffer{}})
+}
+
+//go:linkname wasmimport_OutgoingRequestResourceDrop go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_OutgoingRequestResourceDrop
+func wasmimport_OutgoingRequestResourceDrop(self0 uint32) {
+	fakehost.Drop(self0)
+}
+
+// methodNames are the names of the `wasi:http/types.method` cases, by tag.
+var methodNames = [...]string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodDelete,
+	http.MethodConnect,
+	http.MethodOptions,
+	http.MethodTrace,
+	http.MethodPatch,
+}
+
+// stub wasi:http/types, method0 is the method case, method1 and method2 the
+// name of other methods
+//
+//go:linkname wasmimport_OutgoingRequestSetMethod go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_OutgoingRequestSetMethod
+func wasmimport_OutgoingRequestSetMethod(self0 uint32, method0 uint32, method1 *uint8, method2 uint32) (result0 uint32) {
+	fakehost.Lock()
+	defer fakehost.Unlock()
+
+	method := unsafe.String(method1, method2)
+	if int(method0) < len(methodNames) {
+		method = methodNames[method0]
+	}
+	fakehost.Resource[*outgoingRequest](self0).method = method
+	return 0
+}
+
+// stub wasi:http/types, scheme0 is the option discriminant and scheme1 the
+// scheme case
+//
+//go:linkname wasmimport_OutgoingRequestSetScheme go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_OutgoingRequestSetScheme
+func wasmimport_OutgoingRequestSetScheme(self0 uint32, scheme0 uint32, scheme1 uint32, scheme2 *uint8, scheme3 uint32) (result0 uint32) {
+	fakehost.Lock()
+	defer fakehost.Unlock()
+
+	scheme := "http"
+	if scheme0 == 1 {
+		switch scheme1 {
+		case 0:
+		case 1:
+			scheme = "https"
+		default:
+			scheme = unsafe.String(scheme2, scheme3)
+		}
+	}
+	fakehost.Resource[*outgoingRequest](self0).scheme = scheme
+	return 0
+}
+
+//go:linkname wasmimport_OutgoingRequestSetAuthority go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_OutgoingRequestSetAuthority
+func wasmimport_OutgoingRequestSetAuthority(self0 uint32, authority0 uint32, authority1 *uint8, authority2 uint32) (result0 uint32) {
+	fakehost.Lock()
+	defer fakehost.Unlock()
+
+	var authority string
+	if authority0 == 1 {
+		authority = unsafe.String(authority1, authority2)
+	}
+	fakehost.Resource[*outgoingRequest](self0).authority = authority
+	return 0
+}
+
+//go:linkname wasmimport_OutgoingRequestSetPathWithQuery go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_OutgoingRequestSetPathWithQuery
+func wasmimport_OutgoingRequestSetPathWithQuery(self0 uint32, pathWithQuery0 uint32, pathWithQuery1 *uint8, pathWithQuery2 uint32) (result0 uint32) {
+	fakehost.Lock()
+	defer fakehost.Unlock()
+
+	pathWithQuery := "/"
+	if pathWithQuery0 == 1 {
+		pathWithQuery = unsafe.String(pathWithQuery1, pathWithQuery2)
+	}
+	fakehost.Resource[*outgoingRequest](self0).pathWithQuery = pathWithQuery
+	return 0
+}
+
+//go:linkname wasmimport_OutgoingRequestBody go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_OutgoingRequestBody
+func wasmimport_OutgoingRequestBody(self0 uint32, result *cm.Result[types.OutgoingBody, types.OutgoingBody, struct{}]) {
+	fakehost.Lock()
+	defer fakehost.Unlock()
+
+	req := fakehost.Resource[*outgoingRequest](self0)
+	if req.taken {
+		*result = cm.Err[cm.Result[types.OutgoingBody, types.OutgoingBody, struct{}]](struct{}{})
+		return
+	}
+	req.taken = true
+	*result = cm.OK[cm.Result[types.OutgoingBody, types.OutgoingBody, struct{}]](types.OutgoingBody(fakehost.Add(&outgoingBody{body: req.body, request: req})))
+}
+
+//go:linkname wasmimport_OutgoingBodyResourceDrop go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_OutgoingBodyResourceDrop
+func wasmimport_OutgoingBodyResourceDrop(self0 uint32) {
+	fakehost.Drop(self0)
+}
+
+// stub wasi:http/types, request-options are accepted and ignored
+//
+//go:linkname wasmimport_NewRequestOptions go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_NewRequestOptions
+func wasmimport_NewRequestOptions() (result0 uint32) {
+	fakehost.Lock()
+	defer fakehost.Unlock()
+	return fakehost.Add(&requestOptions{})
+}
+
+//go:linkname wasmimport_RequestOptionsSetConnectTimeout go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_RequestOptionsSetConnectTimeout
+func wasmimport_RequestOptionsSetConnectTimeout(self0 uint32, duration0 uint32, duration1 uint64) (result0 uint32) {
+	return 0
+}
+
+//go:linkname wasmimport_RequestOptionsSetFirstByteTimeout go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_RequestOptionsSetFirstByteTimeout
+func wasmimport_RequestOptionsSetFirstByteTimeout(self0 uint32, duration0 uint32, duration1 uint64) (result0 uint32) {
+	return 0
+}
+
+//go:linkname wasmimport_RequestOptionsResourceDrop go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_RequestOptionsResourceDrop
+func wasmimport_RequestOptionsResourceDrop(self0 uint32) {
+	fakehost.Drop(self0)
+}
+
+// stub wasi:http/outgoing-handler, requests without a body are served at once,
+// others once their body was finished
+//
+//go:linkname wasmimport_Handle go.wasmcloud.dev/component/gen/wasi/http/outgoing-handler.wasmimport_Handle
+func wasmimport_Handle(request0 uint32, options0 uint32, options1 uint32, result *cm.Result[outgoinghandler.ErrorCodeShape, types.FutureIncomingResponse, types.ErrorCode]) {
+	fakehost.Lock()
+	req := fakehost.Resource[*outgoingRequest](request0)
+	fakehost.Delete(request0)
+	if options0 == 1 {
+		fakehost.Delete(options1)
+	}
+	req.future = &futureResponse{}
+	future := fakehost.Add(req.future)
+	finished := !req.taken || req.finished
+	fakehost.Unlock()
+
+	*result = cm.OK[cm.Result[outgoinghandler.ErrorCodeShape, types.FutureIncomingResponse, types.ErrorCode]](types.FutureIncomingResponse(future))
+	if finished {
+		serveOutgoing(req)
+	}
+}
+
+// serveOutgoing serves req with Outgoing and sets the response of its future.
+func serveOutgoing(req *outgoingRequest) {
+	if Outgoing == nil {
+		failOutgoing(req, types.ErrorCodeConnectionRefused())
+		return
+	}
+
+	r := httptest.NewRequest(req.method, req.scheme+"://"+req.authority+req.pathWithQuery, bytes.NewReader(req.body.Bytes()))
+	r.Header = req.headers
+	r.Trailer = req.trailers
+	if n, err := strconv.ParseInt(req.headers.Get("Content-Length"), 10, 64); err == nil {
+		r.ContentLength = n
+	} else {
+		r.ContentLength = -1
+	}
+	rec := httptest.NewRecorder()
+	if !serveAborting(rec, r) {
+		failOutgoing(req, types.ErrorCodeConnectionTerminated())
+		return
+	}
+	resp := rec.Result()
+	body, _ := io.ReadAll(resp.Body)
+
+	fakehost.Lock()
+	defer fakehost.Unlock()
+	req.future.response = &incomingResponse{
+		status:  resp.StatusCode,
+		headers: resp.Header,
+		incomingMessage: incomingMessage{
+			body:     body,
+			trailers: resp.Trailer,
+		},
+	}
+}
+
+// serveAborting serves r with Outgoing, it reports false if the handler
+// aborted the response with http.ErrAbortHandler, like net/http does.
+func serveAborting(w http.ResponseWriter, r *http.Request) (ok bool) {
+	defer func() {
+		if v := recover(); v != nil {
+			if v != http.ErrAbortHandler {
+				panic(v)
+			}
+			ok = false
+		}
+	}()
+	Outgoing.ServeHTTP(w, r)
+	return true
+}
+
+// failOutgoing fails the future response of req with code.
+func failOutgoing(req *outgoingRequest, code types.ErrorCode) {
+	fakehost.Lock()
+	defer fakehost.Unlock()
+	req.future.err = &code
+}
+
+// stub wasi:http/types, future-incoming-response, ready once the request was
+// served
+//
+//go:linkname wasmimport_FutureIncomingResponseSubscribe go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_FutureIncomingResponseSubscribe
+func wasmimport_FutureIncomingResponseSubscribe(self0 uint32) (result0 uint32) {
+	fakehost.Lock()
+	defer fakehost.Unlock()
+	return fakehost.Add(&fakehost.Pollable{})
+}
+
+//go:linkname wasmimport_FutureIncomingResponseGet go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_FutureIncomingResponseGet
+func wasmimport_FutureIncomingResponseGet(self0 uint32, result *cm.Option[cm.Result[cm.Result[types.ErrorCodeShape, types.IncomingResponse, types.ErrorCode], cm.Result[types.ErrorCodeShape, types.IncomingResponse, types.ErrorCode], struct{}]]) {
+	fakehost.Lock()
+	defer fakehost.Unlock()
+
+	future := fakehost.Resource[*futureResponse](self0)
+	switch {
+	case future.response == nil && future.err == nil:
+		*result = cm.None[cm.Result[cm.Result[types.ErrorCodeShape, types.IncomingResponse, types.ErrorCode], cm.Result[types.ErrorCodeShape, types.IncomingResponse, types.ErrorCode], struct{}]]()
+		return
+	case future.taken:
+		*result = cm.Some(cm.Err[cm.Result[cm.Result[types.ErrorCodeShape, types.IncomingResponse, types.ErrorCode], cm.Result[types.ErrorCodeShape, types.IncomingResponse, types.ErrorCode], struct{}]](struct{}{}))
+		return
+	}
+	future.taken = true
+	var res cm.Result[types.ErrorCodeShape, types.IncomingResponse, types.ErrorCode]
+	if future.err != nil {
+		res = cm.Err[cm.Result[types.ErrorCodeShape, types.IncomingResponse, types.ErrorCode]](*future.err)
+	} else {
+		res = cm.OK[cm.Result[types.ErrorCodeShape, types.IncomingResponse, types.ErrorCode]](types.IncomingResponse(fakehost.Add(future.response)))
+	}
+	*result = cm.Some(cm.OK[cm.Result[cm.Result[types.ErrorCodeShape, types.IncomingResponse, types.ErrorCode], cm.Result[types.ErrorCodeShape, types.IncomingResponse, types.ErrorCode], struct{}]](res))
+}
+
+//go:linkname wasmimport_FutureIncomingResponseResourceDrop go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_FutureIncomingResponseResourceDrop
+func wasmimport_FutureIncomingResponseResourceDrop(self0 uint32) {
+	fakehost.Drop(self0)
+}
+
+// stub wasi:http/types, incoming-response
+//
+//go:linkname wasmimport_IncomingResponseStatus go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_IncomingResponseStatus
+func wasmimport_IncomingResponseStatus(self0 uint32) (result0 uint32) {
+	fakehost.Lock()
+	defer fakehost.Unlock()
+	return uint32(fakehost.Resource[*incomingResponse](self0).status)
+}
+
+//go:linkname wasmimport_IncomingResponseHeaders go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_IncomingResponseHeaders
+func wasmimport_IncomingResponseHeaders(self0 uint32) (result0 uint32) {
+	fakehost.Lock()
+	defer fakehost.Unlock()
+	return fakehost.Add(&fields{header: fakehost.Resource[*incomingResponse](self0).headers})
+}
+
+//go:linkname wasmimport_IncomingResponseConsume go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_IncomingResponseConsume
+func wasmimport_IncomingResponseConsume(self0 uint32, result *cm.Result[types.IncomingBody, types.IncomingBody, struct{}]) {
+	fakehost.Lock()
+	defer fakehost.Unlock()
+
+	resp := fakehost.Resource[*incomingResponse](self0)
+	if resp == nil || resp.consumed {
+		*result = cm.Err[cm.Result[types.IncomingBody, types.IncomingBody, struct{}]](struct{}{})
+		return
+	}
+	resp.consumed = true
+	*result = cm.OK[cm.Result[types.IncomingBody, types.IncomingBody, struct{}]](types.IncomingBody(fakehost.Add(&incomingBody{message: &resp.incomingMessage})))
+}
+
+//go:linkname wasmimport_IncomingResponseResourceDrop go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_IncomingResponseResourceDrop
+func wasmimport_IncomingResponseResourceDrop(self0 uint32) {
+	fakehost.Drop(self0)
+}
+
+// stub wasi:http/types, response is the flattened result, response0 being its
+// discriminant and response1 the response handle
+//
+//go:linkname wasmimport_ResponseOutparamSet go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_ResponseOutparamSet
+func wasmimport_ResponseOutparamSet(param0 uint32, response0 uint32, response1 uint32, response2 uint32, response3 uint64, response4 uint32, response5 uint32, response6 uint32, response7 uint32) {
+	fakehost.Lock()
+	defer fakehost.Unlock()
+
+	// NOTE: the outparam and the response are consumed
+	param := fakehost.Resource[*responseOutparam](param0)
+	fakehost.Delete(param0)
+	if response0 == 0 {
+		defer fakehost.Delete(response1)
+	}
+	if param == nil || response0 != 0 {
+		return
+	}
+	rec := param.rec
+	resp := fakehost.Resource[*outgoingResponse](response1)
+	resp.recorder = rec
+	rec.Code = resp.status
+	rec.HeaderMap = resp.headers
+	rec.Body = resp.body
+}
+
+// unsupported returns the panic value of the imports not served.
+func unsupported(name string) string {
+	return "wasihttptest: " + name + " is not served outside of a wasm build"
+}
+
+// stub wasi:http/types
+//
+//go:linkname httptypes_FieldsFromList go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_FieldsFromList
+func httptypes_FieldsFromList(entries0 *cm.Tuple[types.FieldKey, types.FieldValue], entries1 uint32, result *cm.Result[types.Fields, types.Fields, types.HeaderError]) {
+	panic(unsupported("wasi:http/types@0.2.0 [static]fields.from-list"))
+}
+
+//go:linkname httptypes_FieldsAppend go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_FieldsAppend
+func httptypes_FieldsAppend(self0 uint32, name0 *uint8, name1 uint32, value0 *uint8, value1 uint32, result *cm.Result[types.HeaderError, struct{}, types.HeaderError]) {
+	panic(unsupported("wasi:http/types@0.2.0 [method]fields.append"))
+}
+
+//go:linkname httptypes_FieldsClone go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_FieldsClone
+func httptypes_FieldsClone(self0 uint32) (result0 uint32) {
+	panic(unsupported("wasi:http/types@0.2.0 [method]fields.clone"))
+}
+
+//go:linkname httptypes_FieldsDelete go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_FieldsDelete
+func httptypes_FieldsDelete(self0 uint32, name0 *uint8, name1 uint32, result *cm.Result[types.HeaderError, struct{}, types.HeaderError]) {
+	panic(unsupported("wasi:http/types@0.2.0 [method]fields.delete"))
+}
+
+//go:linkname httptypes_FieldsGet go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_FieldsGet
+func httptypes_FieldsGet(self0 uint32, name0 *uint8, name1 uint32, result *cm.List[types.FieldValue]) {
+	panic(unsupported("wasi:http/types@0.2.0 [method]fields.get"))
+}
+
+//go:linkname httptypes_FieldsHas go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_FieldsHas
+func httptypes_FieldsHas(self0 uint32, name0 *uint8, name1 uint32) (result0 uint32) {
+	panic(unsupported("wasi:http/types@0.2.0 [method]fields.has"))
+}
+
+//go:linkname httptypes_IncomingRequestScheme go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_IncomingRequestScheme
+func httptypes_IncomingRequestScheme(self0 uint32, result *cm.Option[types.Scheme]) {
+	panic(unsupported("wasi:http/types@0.2.0 [method]incoming-request.scheme"))
+}
+
+//go:linkname httptypes_OutgoingRequestAuthority go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_OutgoingRequestAuthority
+func httptypes_OutgoingRequestAuthority(self0 uint32, result *cm.Option[string]) {
+	panic(unsupported("wasi:http/types@0.2.0 [method]outgoing-request.authority"))
+}
+
+//go:linkname httptypes_OutgoingRequestHeaders go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_OutgoingRequestHeaders
+func httptypes_OutgoingRequestHeaders(self0 uint32) (result0 uint32) {
+	panic(unsupported("wasi:http/types@0.2.0 [method]outgoing-request.headers"))
+}
+
+//go:linkname httptypes_OutgoingRequestMethod go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_OutgoingRequestMethod
+func httptypes_OutgoingRequestMethod(self0 uint32, result *types.Method) {
+	panic(unsupported("wasi:http/types@0.2.0 [method]outgoing-request.method"))
+}
+
+//go:linkname httptypes_OutgoingRequestPathWithQuery go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_OutgoingRequestPathWithQuery
+func httptypes_OutgoingRequestPathWithQuery(self0 uint32, result *cm.Option[string]) {
+	panic(unsupported("wasi:http/types@0.2.0 [method]outgoing-request.path-with-query"))
+}
+
+//go:linkname httptypes_OutgoingRequestScheme go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_OutgoingRequestScheme
+func httptypes_OutgoingRequestScheme(self0 uint32, result *cm.Option[types.Scheme]) {
+	panic(unsupported("wasi:http/types@0.2.0 [method]outgoing-request.scheme"))
+}
+
+//go:linkname httptypes_RequestOptionsBetweenBytesTimeout go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_RequestOptionsBetweenBytesTimeout
+func httptypes_RequestOptionsBetweenBytesTimeout(self0 uint32, result *cm.Option[monotonicclock.Duration]) {
+	panic(unsupported("wasi:http/types@0.2.0 [method]request-options.between-bytes-timeout"))
+}
+
+//go:linkname httptypes_RequestOptionsConnectTimeout go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_RequestOptionsConnectTimeout
+func httptypes_RequestOptionsConnectTimeout(self0 uint32, result *cm.Option[monotonicclock.Duration]) {
+	panic(unsupported("wasi:http/types@0.2.0 [method]request-options.connect-timeout"))
+}
+
+//go:linkname httptypes_RequestOptionsFirstByteTimeout go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_RequestOptionsFirstByteTimeout
+func httptypes_RequestOptionsFirstByteTimeout(self0 uint32, result *cm.Option[monotonicclock.Duration]) {
+	panic(unsupported("wasi:http/types@0.2.0 [method]request-options.first-byte-timeout"))
+}
+
+//go:linkname httptypes_RequestOptionsSetBetweenBytesTimeout go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_RequestOptionsSetBetweenBytesTimeout
+func httptypes_RequestOptionsSetBetweenBytesTimeout(self0 uint32, duration0 uint32, duration1 uint64) (result0 uint32) {
+	panic(unsupported("wasi:http/types@0.2.0 [method]request-options.set-between-bytes-timeout"))
+}
+
+//go:linkname httptypes_ResponseOutparamResourceDrop go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_ResponseOutparamResourceDrop
+func httptypes_ResponseOutparamResourceDrop(self0 uint32) {
+	panic(unsupported("wasi:http/types@0.2.0 [resource-drop]response-outparam"))
+}
+
+//go:linkname httptypes_OutgoingResponseResourceDrop go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_OutgoingResponseResourceDrop
+func httptypes_OutgoingResponseResourceDrop(self0 uint32) {
+	panic(unsupported("wasi:http/types@0.2.0 [resource-drop]outgoing-response"))
+}
+
+//go:linkname httptypes_OutgoingResponseHeaders go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_OutgoingResponseHeaders
+func httptypes_OutgoingResponseHeaders(self0 uint32) (result0 uint32) {
+	panic(unsupported("wasi:http/types@0.2.0 [method]outgoing-response.headers"))
+}
+
+//go:linkname httptypes_OutgoingResponseStatusCode go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_OutgoingResponseStatusCode
+func httptypes_OutgoingResponseStatusCode(self0 uint32) (result0 uint32) {
+	panic(unsupported("wasi:http/types@0.2.0 [method]outgoing-response.status-code"))
+}
+
+//go:linkname httptypes_HTTPErrorCode go.wasmcloud.dev/component/gen/wasi/http/types.wasmimport_HTTPErrorCode
+func httptypes_HTTPErrorCode(err0 uint32, result *cm.Option[types.ErrorCode]) {
+	panic(unsupported("wasi:http/types@0.2.0 http-error-code"))
+}
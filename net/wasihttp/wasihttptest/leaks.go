@@ -0,0 +1,27 @@
+//go:build !wasm
+
+package wasihttptest
+
+import (
+	"testing"
+
+	"go.wasmcloud.dev/component/internal/fakehost"
+)
+
+// CheckLeaks fails t if resources of the fake host created from now on, e.g.
+// fields, bodies, streams and futures, were not dropped by the end of t.
+// Handle leaks are otherwise invisible in tests, and only fail once the host
+// runs out of handles.
+//
+// Requests of the Transport whose response bodies were not closed are
+// reported as leaks too. Tests using CheckLeaks must not run in parallel.
+func CheckLeaks(t testing.TB) {
+	t.Helper()
+
+	since := fakehost.Last()
+	t.Cleanup(func() {
+		if l := fakehost.Live(since); len(l) > 0 {
+			t.Errorf("wasihttptest: %d resources were not dropped: %v", len(l), l)
+		}
+	})
+}
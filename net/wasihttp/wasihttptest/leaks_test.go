@@ -0,0 +1,46 @@
+//go:build !wasm
+
+package wasihttptest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"go.wasmcloud.dev/component/gen/wasi/http/types"
+)
+
+// recordingTB records the errors of the cleanups of CheckLeaks.
+type recordingTB struct {
+	testing.TB
+	cleanups []func()
+	errors   []string
+}
+
+func (tb *recordingTB) Helper()          {}
+func (tb *recordingTB) Cleanup(f func()) { tb.cleanups = append(tb.cleanups, f) }
+func (tb *recordingTB) Errorf(format string, args ...any) {
+	tb.errors = append(tb.errors, fmt.Sprintf(format, args...))
+}
+
+func TestCheckLeaks(t *testing.T) {
+	before := types.NewFields()
+	defer before.ResourceDrop()
+
+	tb := &recordingTB{TB: t}
+	CheckLeaks(tb)
+	dropped := types.NewFields()
+	dropped.ResourceDrop()
+	leaked := types.NewFields()
+	for _, f := range tb.cleanups {
+		f()
+	}
+	leaked.ResourceDrop()
+
+	if len(tb.errors) != 1 {
+		t.Fatalf("expected 1 error, got: %q", tb.errors)
+	}
+	if want := fmt.Sprintf("[fields %d]", leaked); !strings.HasSuffix(tb.errors[0], want) {
+		t.Errorf("expected the error to end with %s, got: %s", want, tb.errors[0])
+	}
+}
@@ -0,0 +1,117 @@
+//go:build !wasm
+
+package wasihttptest
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+
+	incominghandler "go.wasmcloud.dev/component/gen/wasi/http/incoming-handler"
+	"go.wasmcloud.dev/component/gen/wasi/http/types"
+	"go.wasmcloud.dev/component/internal/fakehost"
+)
+
+// NewIncomingRequest returns a `wasi:http` incoming request served by the
+// fake host, with the method, URL, headers, body and trailers of req. The body
+// of req is read at once.
+func NewIncomingRequest(req *http.Request) types.IncomingRequest {
+	var body []byte
+	if req.Body != nil {
+		body, _ = io.ReadAll(req.Body)
+		req.Body.Close()
+	}
+
+	// NOTE: like a request received over HTTP/1.1, a known length is sent
+	// as Content-Length
+	header := req.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	if req.ContentLength > 0 && header.Get("Content-Length") == "" {
+		header.Set("Content-Length", strconv.Itoa(len(body)))
+	}
+
+	authority := req.Host
+	if authority == "" {
+		authority = req.URL.Host
+	}
+
+	fakehost.Lock()
+	defer fakehost.Unlock()
+	return types.IncomingRequest(fakehost.Add(&incomingRequest{
+		method:        req.Method,
+		authority:     authority,
+		pathWithQuery: req.URL.RequestURI(),
+		headers:       header,
+		incomingMessage: incomingMessage{
+			body:     body,
+			trailers: req.Trailer.Clone(),
+		},
+	}))
+}
+
+// Outgoing serves the requests of wasihttp.Transport sent through the fake
+// host, they fail with a connection-refused error while it is nil. A request
+// with a body is served once its body was finished. A handler panicking with
+// http.ErrAbortHandler fails the request with a connection-terminated error.
+var Outgoing http.Handler
+
+// ResponseRecorder records the response set on a `wasi:http` response
+// outparam.
+type ResponseRecorder struct {
+	// Code is the status code, it is zero until a response was set.
+	Code int
+	// HeaderMap are the response headers.
+	HeaderMap http.Header
+	// Body is the response body written so far.
+	Body *bytes.Buffer
+	// Trailers are the trailers sent when the body was finished.
+	Trailers http.Header
+	// Finished reports whether the body was finished.
+	Finished bool
+}
+
+// responseOutparam is a `wasi:http/types.response-outparam` resource.
+type responseOutparam struct {
+	rec *ResponseRecorder
+}
+
+func (*responseOutparam) ResourceName() string { return "response-outparam" }
+
+// NewResponseOutparam returns a response outparam served by the fake host,
+// and the recorder of the response set on it.
+func NewResponseOutparam() (types.ResponseOutparam, *ResponseRecorder) {
+	rec := &ResponseRecorder{HeaderMap: http.Header{}, Body: &bytes.Buffer{}, Trailers: http.Header{}}
+
+	fakehost.Lock()
+	defer fakehost.Unlock()
+	return types.ResponseOutparam(fakehost.Add(&responseOutparam{rec})), rec
+}
+
+// Result returns the recorded response.
+func (rec *ResponseRecorder) Result() *http.Response {
+	fakehost.Lock()
+	defer fakehost.Unlock()
+
+	return &http.Response{
+		Status:        strconv.Itoa(rec.Code) + " " + http.StatusText(rec.Code),
+		StatusCode:    rec.Code,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        rec.HeaderMap.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(bytes.Clone(rec.Body.Bytes()))),
+		ContentLength: int64(rec.Body.Len()),
+		Trailer:       rec.Trailers.Clone(),
+	}
+}
+
+// Serve handles req with the exported `wasi:http/incoming-handler`, i.e. the
+// handler registered with wasihttp.Handle, and returns the recorded response.
+func Serve(req *http.Request) *ResponseRecorder {
+	out, rec := NewResponseOutparam()
+	incominghandler.Exports.Handle(NewIncomingRequest(req), out)
+	return rec
+}
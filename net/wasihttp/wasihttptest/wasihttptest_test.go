@@ -0,0 +1,956 @@
+//go:build !wasm
+
+package wasihttptest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"go.wasmcloud.dev/component/export"
+	incominghandler "go.wasmcloud.dev/component/gen/wasi/http/incoming-handler"
+	"go.wasmcloud.dev/component/gen/wasi/http/types"
+	"go.wasmcloud.dev/component/internal/fakehost"
+	"go.wasmcloud.dev/component/net/wasihttp"
+	"go.wasmcloud.dev/component/tasks"
+	"go.wasmcloud.dev/component/wasmcloud"
+)
+
+func TestServe(t *testing.T) {
+	CheckLeaks(t)
+	wasihttp.HandleFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("failed to read body: %s", err)
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Set("Trailer", "X-Checksum")
+		w.WriteHeader(http.StatusCreated)
+		io.WriteString(w, r.Method+" "+r.Host+r.URL.RequestURI()+" "+r.Header.Get("X-Test")+" "+string(body)+" "+r.Trailer.Get("X-Sent"))
+		w.Header().Set("X-Checksum", "abc")
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "http://example.com/orders?id=1", strings.NewReader("hello"))
+	req.Header.Set("X-Test", "yes")
+	req.Trailer = http.Header{"X-Sent": {"done"}}
+	rec := Serve(req)
+
+	if rec.Code != http.StatusCreated {
+		t.Errorf("expected: %v, got: %v", http.StatusCreated, rec.Code)
+	}
+	if got := rec.HeaderMap.Get("Content-Type"); got != "text/plain" {
+		t.Errorf("expected: %v, got: %v", "text/plain", got)
+	}
+	if want, got := "POST example.com/orders?id=1 yes hello done", rec.Body.String(); got != want {
+		t.Errorf("expected: %q, got: %q", want, got)
+	}
+	if !rec.Finished || rec.Trailers.Get("X-Checksum") != "abc" {
+		t.Errorf("expected the body to finish with trailers, got: %v %v", rec.Finished, rec.Trailers)
+	}
+
+	resp := rec.Result()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusCreated || string(body) != rec.Body.String() {
+		t.Errorf("unexpected result: %v %q", resp.Status, body)
+	}
+}
+
+func TestServeMethods(t *testing.T) {
+	var got string
+	wasihttp.HandleFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Method
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	for _, method := range []string{http.MethodGet, http.MethodPut, http.MethodPatch} {
+		t.Run(method, func(t *testing.T) {
+			rec := Serve(httptest.NewRequest(method, "/", nil))
+			if got != method {
+				t.Errorf("expected: %v, got: %v", method, got)
+			}
+			if rec.Code != http.StatusNoContent || rec.Body.Len() != 0 {
+				t.Errorf("unexpected response: %v %q", rec.Code, rec.Body)
+			}
+		})
+	}
+}
+
+func TestServeExtensionMethods(t *testing.T) {
+	CheckLeaks(t)
+	defer func() { wasihttp.MethodValidation = wasihttp.MethodStrict }()
+	var got string
+	wasihttp.HandleFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Method
+		w.WriteHeader(http.StatusMultiStatus)
+	})
+
+	tests := map[string]struct {
+		mode   wasihttp.MethodMode
+		method string
+		served bool
+	}{
+		"strict":         {mode: wasihttp.MethodStrict, method: "PROPFIND"},
+		"token":          {mode: wasihttp.MethodToken, method: "PROPFIND", served: true},
+		"token report":   {mode: wasihttp.MethodToken, method: "REPORT", served: true},
+		"token standard": {mode: wasihttp.MethodToken, method: http.MethodGet, served: true},
+		"invalid":        {mode: wasihttp.MethodToken, method: "PROP FIND"},
+		"empty":          {mode: wasihttp.MethodToken, method: ""},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			wasihttp.MethodValidation = tt.mode
+			got = ""
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Method = tt.method
+			rec := Serve(req)
+			if served := got == tt.method && rec.Code == http.StatusMultiStatus; served != tt.served {
+				t.Errorf("expected served: %v, got: %v %v", tt.served, got, rec.Code)
+			}
+		})
+	}
+}
+
+func TestServeHead(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /items", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		io.WriteString(w, "item 1\n")
+		io.WriteString(w, "item 2\n")
+	})
+	mux.HandleFunc("GET /sized", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "1024")
+		w.WriteHeader(http.StatusOK)
+	})
+	wasihttp.Handle(mux)
+
+	tests := map[string]struct {
+		method string
+		path   string
+		status int
+		header http.Header
+		body   string
+	}{
+		"get":    {method: http.MethodGet, path: "/items", status: http.StatusOK, body: "item 1\nitem 2\n"},
+		"head":   {method: http.MethodHead, path: "/items", status: http.StatusOK, header: http.Header{"Content-Length": {"14"}, "Content-Type": {"text/plain"}}},
+		"sized":  {method: http.MethodHead, path: "/sized", status: http.StatusOK, header: http.Header{"Content-Length": {"1024"}}},
+		"post":   {method: http.MethodPost, path: "/items", status: http.StatusMethodNotAllowed, header: http.Header{"Allow": {"GET, HEAD"}}, body: "Method Not Allowed\n"},
+		"absent": {method: http.MethodHead, path: "/absent", status: http.StatusNotFound},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := Serve(httptest.NewRequest(tt.method, tt.path, nil))
+			if rec.Code != tt.status {
+				t.Errorf("expected: %v, got: %v", tt.status, rec.Code)
+			}
+			for k := range tt.header {
+				if got := rec.HeaderMap.Get(k); got != tt.header.Get(k) {
+					t.Errorf("%s: expected: %q, got: %q", k, tt.header.Get(k), got)
+				}
+			}
+			if got := rec.Body.String(); got != tt.body {
+				t.Errorf("expected: %q, got: %q", tt.body, got)
+			}
+			if !rec.Finished {
+				t.Error("expected the body to finish")
+			}
+		})
+	}
+}
+
+func TestServeTrailers(t *testing.T) {
+	var before, after http.Header
+	var readErr error
+	wasihttp.HandleFunc(func(w http.ResponseWriter, r *http.Request) {
+		before = r.Trailer.Clone()
+		_, readErr = io.ReadAll(r.Body)
+		after = r.Trailer.Clone()
+
+		w.Header().Set("Content-Type", "application/grpc")
+		w.Header().Set("Trailer", "Grpc-Status, Grpc-Message")
+		w.Write([]byte{0, 0, 0, 0, 0})
+		w.Header().Set("Grpc-Status", "0")
+		w.Header().Set("Grpc-Message", "ok")
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/greeter.Greeter/SayHello", bytes.NewReader([]byte{0, 0, 0, 0, 0}))
+	req.Header.Set("Content-Type", "application/grpc")
+	req.Header.Set("Trailer", "Grpc-Status, Grpc-Message")
+	req.Trailer = http.Header{"Grpc-Status": {"0"}, "Grpc-Message": {"ok"}}
+	rec := Serve(req)
+
+	if readErr != nil {
+		t.Fatalf("expected: %v, got: %v", nil, readErr)
+	}
+	if want := (http.Header{"Grpc-Status": nil, "Grpc-Message": nil}); !reflect.DeepEqual(before, want) {
+		t.Errorf("expected: %v, got: %v", want, before)
+	}
+	if want := req.Trailer; !reflect.DeepEqual(after, want) {
+		t.Errorf("expected: %v, got: %v", want, after)
+	}
+	if got := rec.Trailers.Get("Grpc-Status"); got != "0" {
+		t.Errorf("expected: %v, got: %v", "0", got)
+	}
+}
+
+func TestServeTrailerError(t *testing.T) {
+	var body []byte
+	var readErr error
+	wasihttp.HandleFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, readErr = io.ReadAll(r.Body)
+	})
+
+	ir := NewIncomingRequest(httptest.NewRequest(http.MethodPost, "/", strings.NewReader("hello")))
+	fakehost.Lock()
+	fakehost.Resource[*incomingRequest](uint32(ir)).trailersFail = true
+	fakehost.Unlock()
+	out, _ := NewResponseOutparam()
+	incominghandler.Exports.Handle(ir, out)
+
+	var trailerErr *wasihttp.TrailerError
+	if !errors.As(readErr, &trailerErr) || trailerErr.Code != "HTTP-protocol-error" {
+		t.Errorf("expected: %v, got: %v", "HTTP-protocol-error", readErr)
+	}
+	if string(body) != "hello" {
+		t.Errorf("expected: %q, got: %q", "hello", body)
+	}
+}
+
+func TestServeDropsRequest(t *testing.T) {
+	tests := map[string]http.HandlerFunc{
+		"unread": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		},
+		"partial": func(w http.ResponseWriter, r *http.Request) {
+			r.Body.Read(make([]byte, 2))
+		},
+		"drained": func(w http.ResponseWriter, r *http.Request) {
+			io.ReadAll(r.Body)
+		},
+		"spliced": func(w http.ResponseWriter, r *http.Request) {
+			io.Copy(w, r.Body)
+		},
+		"limited": func(w http.ResponseWriter, r *http.Request) {
+			io.ReadAll(http.MaxBytesReader(w, r.Body, 1))
+		},
+	}
+
+	for name, h := range tests {
+		t.Run(name, func(t *testing.T) {
+			// NOTE: the request, its headers, body, stream and trailers
+			// are dropped with the response
+			CheckLeaks(t)
+			wasihttp.HandleFunc(h)
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("hello"))
+			req.Trailer = http.Header{"X-Sent": {"done"}}
+			Serve(req)
+		})
+	}
+}
+
+func TestServeRouter(t *testing.T) {
+	r := wasihttp.NewRouter()
+	r.HandleFunc("GET /items", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "item 1\n")
+	})
+	r.HandleFunc("PUT /items", func(w http.ResponseWriter, r *http.Request) {})
+	wasihttp.Handle(r)
+
+	tests := map[string]struct {
+		method string
+		status int
+		header http.Header
+		body   string
+	}{
+		"get":  {method: http.MethodGet, status: http.StatusOK, body: "item 1\n"},
+		"head": {method: http.MethodHead, status: http.StatusOK, header: http.Header{"Content-Length": {"7"}}},
+		"post": {method: http.MethodPost, status: http.StatusMethodNotAllowed, header: http.Header{"Allow": {"GET, HEAD, PUT"}}, body: "Method Not Allowed\n"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := Serve(httptest.NewRequest(tt.method, "/items", nil))
+			if rec.Code != tt.status {
+				t.Errorf("expected: %v, got: %v", tt.status, rec.Code)
+			}
+			for k := range tt.header {
+				if got := rec.HeaderMap.Get(k); got != tt.header.Get(k) {
+					t.Errorf("%s: expected: %q, got: %q", k, tt.header.Get(k), got)
+				}
+			}
+			if got := rec.Body.String(); got != tt.body {
+				t.Errorf("expected: %q, got: %q", tt.body, got)
+			}
+		})
+	}
+}
+
+func TestServeEmpty(t *testing.T) {
+	wasihttp.HandleFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	rec := Serve(httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusOK || !rec.Finished {
+		t.Errorf("unexpected response: %v %v", rec.Code, rec.Finished)
+	}
+}
+
+func TestServeHeaderValidation(t *testing.T) {
+	CheckLeaks(t)
+	wasihttp.HandleFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Ok", "yes")
+		w.Header()["X Invalid"] = []string{"dropped"}
+		w.Header()["X-Injected"] = []string{"a\r\nSet-Cookie: b"}
+		io.WriteString(w, "ok")
+	})
+
+	rec := Serve(httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != 0 {
+		t.Errorf("expected: %v, got: %v", 0, rec.Code)
+	}
+
+	defer func(mode wasihttp.HeaderMode) { wasihttp.HeaderValidation = mode }(wasihttp.HeaderValidation)
+	wasihttp.HeaderValidation = wasihttp.HeaderLenient
+	rec = Serve(httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusOK || rec.Body.String() != "ok" {
+		t.Errorf("unexpected response: %v %q", rec.Code, rec.Body)
+	}
+	want := http.Header{"X-Ok": {"yes"}, "X-Injected": {"a  Set-Cookie: b"}}
+	if len(rec.HeaderMap) != len(want) || rec.HeaderMap.Get("X-Ok") != "yes" || rec.HeaderMap.Get("X-Injected") != want.Get("X-Injected") {
+		t.Errorf("expected: %v, got: %v", want, rec.HeaderMap)
+	}
+}
+
+func TestServeCookies(t *testing.T) {
+	var got []string
+	wasihttp.HandleFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, c := range wasihttp.ReadCookies(r, "") {
+			got = append(got, c.Name+"="+c.Value)
+		}
+		wasihttp.SetCookie(w, &http.Cookie{Name: "b", Value: "2"})
+		wasihttp.SetCookie(w, &http.Cookie{Name: "a", Value: "1, 2"})
+		// NOTE: not canonical, merged after the canonical values
+		w.Header()["set-cookie"] = []string{"c=3"}
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Add("Cookie", "x=1")
+	req.Header.Add("Cookie", "y=2; x=3")
+	rec := Serve(req)
+
+	if want := []string{"x=1", "y=2", "x=3"}; !slices.Equal(got, want) {
+		t.Errorf("expected: %v, got: %v", want, got)
+	}
+	want := []string{"b=2; Path=/; Secure; SameSite=Lax", `a="1, 2"; Path=/; Secure; SameSite=Lax`, "c=3"}
+	if got := rec.HeaderMap.Values("Set-Cookie"); !slices.Equal(got, want) {
+		t.Errorf("expected: %q, got: %q", want, got)
+	}
+}
+
+func TestServeMetadata(t *testing.T) {
+	var got wasmcloud.Metadata
+	wasihttp.HandleFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = wasmcloud.FromContext(r.Context())
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(wasmcloud.TenantHeader, "acme")
+	Serve(req)
+	if got.Tenant != "acme" {
+		t.Errorf("expected: %v, got: %v", "acme", got.Tenant)
+	}
+}
+
+func TestServeForm(t *testing.T) {
+	var got string
+	wasihttp.HandleFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("failed to parse form: %s", err)
+		}
+		got = r.PostForm.Get("title") + " " + r.Form.Get("id")
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/?id=42", strings.NewReader("title=holiday"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	Serve(req)
+	if want := "holiday 42"; got != want {
+		t.Errorf("expected: %q, got: %q", want, got)
+	}
+}
+
+func TestServeMultipart(t *testing.T) {
+	var parts []string
+	wasihttp.HandleFunc(func(w http.ResponseWriter, r *http.Request) {
+		mr, err := r.MultipartReader()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for {
+			p, err := mr.NextPart()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, _ := io.ReadAll(p)
+			parts = append(parts, p.FormName()+"="+string(data))
+		}
+	})
+
+	var b bytes.Buffer
+	mw := multipart.NewWriter(&b)
+	mw.WriteField("title", "holiday")
+	part, _ := mw.CreateFormFile("photo", "beach.jpg")
+	part.Write(bytes.Repeat([]byte("j"), 1<<17))
+	mw.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/photos", &b)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	Serve(req)
+	if len(parts) != 2 || parts[0] != "title=holiday" || len(parts[1]) != len("photo=")+1<<17 {
+		t.Errorf("unexpected parts: %d %.32q", len(parts), parts)
+	}
+}
+
+func TestServeSplice(t *testing.T) {
+	wasihttp.HandleFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Trailer", "X-Sent")
+		if _, err := io.Copy(w, r.Body); err != nil {
+			t.Errorf("failed to copy body: %s", err)
+		}
+		w.Header().Set("X-Sent", r.Trailer.Get("X-Sent"))
+	})
+
+	body := strings.Repeat("a", 3<<20)
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Trailer = http.Header{"X-Sent": {"done"}}
+	rec := Serve(req)
+
+	if rec.Body.String() != body {
+		t.Errorf("expected: %d bytes, got: %d", len(body), rec.Body.Len())
+	}
+	if got := rec.Trailers.Get("X-Sent"); got != "done" {
+		t.Errorf("expected: %v, got: %v", "done", got)
+	}
+}
+
+func TestServeLargeWrite(t *testing.T) {
+	body := bytes.Repeat([]byte("0123456789"), 300<<10)
+	wasihttp.HandleFunc(func(w http.ResponseWriter, r *http.Request) {
+		// NOTE: larger than the check-write budget of the host
+		if n, err := w.Write(body); n != len(body) || err != nil {
+			t.Errorf("expected: %d bytes, got: %d, %v", len(body), n, err)
+		}
+	})
+
+	rec := Serve(httptest.NewRequest(http.MethodGet, "/", nil))
+	if !bytes.Equal(rec.Body.Bytes(), body) {
+		t.Errorf("expected: %d bytes, got: %d", len(body), rec.Body.Len())
+	}
+}
+
+func TestServeDeferredWork(t *testing.T) {
+	var rec *ResponseRecorder
+	var calls []string
+	defer export.OnShutdown(func(context.Context) error {
+		calls = append(calls, "shutdown")
+		return nil
+	})()
+	wasihttp.HandleFunc(func(w http.ResponseWriter, r *http.Request) {
+		tasks.Defer(r.Context(), func(ctx context.Context) error {
+			if _, ok := wasmcloud.FromContext(ctx); !rec.Finished || !ok {
+				t.Error("expected the task to run with the request context once the response was finished")
+			}
+			calls = append(calls, "task")
+			return nil
+		})
+		io.WriteString(w, "ok")
+	})
+
+	out, rec := NewResponseOutparam()
+	incominghandler.Exports.Handle(NewIncomingRequest(httptest.NewRequest(http.MethodGet, "/", nil)), out)
+	if want := []string{"task", "shutdown"}; !slices.Equal(calls, want) {
+		t.Errorf("expected: %v, got: %v", want, calls)
+	}
+}
+
+func TestServeWriterStates(t *testing.T) {
+	tests := map[string]struct {
+		// handler returns the error of its last write
+		handler func(w http.ResponseWriter, r *http.Request) error
+		status  int
+		body    string
+		err     error
+	}{
+		"superfluous WriteHeader": {
+			handler: func(w http.ResponseWriter, r *http.Request) error {
+				w.WriteHeader(http.StatusAccepted)
+				w.WriteHeader(http.StatusTeapot)
+				return nil
+			},
+			status: http.StatusAccepted,
+		},
+		"WriteHeader after Write": {
+			handler: func(w http.ResponseWriter, r *http.Request) error {
+				_, err := io.WriteString(w, "ok")
+				w.WriteHeader(http.StatusTeapot)
+				return err
+			},
+			status: http.StatusOK,
+			body:   "ok",
+		},
+		"informational": {
+			handler: func(w http.ResponseWriter, r *http.Request) error {
+				w.WriteHeader(http.StatusEarlyHints)
+				w.WriteHeader(http.StatusCreated)
+				return nil
+			},
+			status: http.StatusCreated,
+		},
+		"no content": {
+			handler: func(w http.ResponseWriter, r *http.Request) error {
+				w.WriteHeader(http.StatusNoContent)
+				_, err := io.WriteString(w, "body")
+				return err
+			},
+			status: http.StatusNoContent,
+			err:    http.ErrBodyNotAllowed,
+		},
+		"not modified splice": {
+			handler: func(w http.ResponseWriter, r *http.Request) error {
+				w.WriteHeader(http.StatusNotModified)
+				_, err := io.Copy(w, r.Body)
+				return err
+			},
+			status: http.StatusNotModified,
+			err:    http.ErrBodyNotAllowed,
+		},
+		"content length overrun": {
+			handler: func(w http.ResponseWriter, r *http.Request) error {
+				w.Header().Set("Content-Length", "4")
+				io.WriteString(w, "abc")
+				_, err := io.WriteString(w, "de")
+				return err
+			},
+			status: http.StatusOK,
+			body:   "abc",
+			err:    http.ErrContentLength,
+		},
+		"content length overrun then remainder": {
+			handler: func(w http.ResponseWriter, r *http.Request) error {
+				w.Header().Set("Content-Length", "4")
+				io.WriteString(w, "abc")
+				if _, err := io.WriteString(w, "de"); err != http.ErrContentLength {
+					return fmt.Errorf("expected: %v, got: %v", http.ErrContentLength, err)
+				}
+				_, err := io.WriteString(w, "d")
+				return err
+			},
+			status: http.StatusOK,
+			body:   "abcd",
+		},
+		"content length splice": {
+			handler: func(w http.ResponseWriter, r *http.Request) error {
+				w.Header().Set("Content-Length", "4")
+				_, err := io.Copy(w, r.Body)
+				return err
+			},
+			status: http.StatusOK,
+			body:   "body",
+			err:    http.ErrContentLength,
+		},
+		"content length splice exact": {
+			handler: func(w http.ResponseWriter, r *http.Request) error {
+				w.Header().Set("Content-Length", strconv.Itoa(len("body and more")))
+				_, err := io.Copy(w, r.Body)
+				return err
+			},
+			status: http.StatusOK,
+			body:   "body and more",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var err error
+			wasihttp.HandleFunc(func(w http.ResponseWriter, r *http.Request) {
+				err = tt.handler(w, r)
+			})
+			rec := Serve(httptest.NewRequest(http.MethodPost, "/", strings.NewReader("body and more")))
+			if err != tt.err {
+				t.Errorf("expected: %v, got: %v", tt.err, err)
+			}
+			if rec.Code != tt.status || rec.Body.String() != tt.body {
+				t.Errorf("expected: %v %q, got: %v %q", tt.status, tt.body, rec.Code, rec.Body)
+			}
+		})
+	}
+
+	t.Run("invalid code", func(t *testing.T) {
+		var recovered any
+		wasihttp.HandleFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() { recovered = recover() }()
+			w.WriteHeader(1000)
+		})
+		Serve(httptest.NewRequest(http.MethodGet, "/", nil))
+		if recovered == nil {
+			t.Error("expected a panic")
+		}
+	})
+}
+
+// unwrapWriter is a middleware response writer supporting
+// http.ResponseController.
+type unwrapWriter struct {
+	http.ResponseWriter
+}
+
+func (w unwrapWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func TestServeResponseHooks(t *testing.T) {
+	var statuses []int
+	var contentType string
+	var written int64
+	hooks := wasihttp.ResponseHooks{
+		WriteHeader: func(status int, header http.Header) {
+			statuses = append(statuses, status)
+			contentType = header.Get("Content-Type")
+			header.Set("X-Observed", "1")
+		},
+		Write: func(n int64) { written += n },
+	}
+	tests := map[string]struct {
+		method  string
+		handler http.HandlerFunc
+		status  int
+		written int64
+	}{
+		"write": {
+			method: http.MethodGet,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/plain")
+				io.WriteString(w, "hello")
+				w.(http.Flusher).Flush()
+				io.WriteString(w, " world")
+			},
+			status:  http.StatusOK,
+			written: 11,
+		},
+		"splice": {
+			method: http.MethodPost,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/plain")
+				w.WriteHeader(http.StatusCreated)
+				io.Copy(w, r.Body)
+			},
+			status:  http.StatusCreated,
+			written: 4,
+		},
+		"empty": {
+			method:  http.MethodGet,
+			handler: func(w http.ResponseWriter, r *http.Request) {},
+			status:  http.StatusOK,
+		},
+		"head": {
+			method: http.MethodHead,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/plain")
+				w.WriteHeader(http.StatusAccepted)
+				io.WriteString(w, "discarded")
+			},
+			status: http.StatusAccepted,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			statuses, contentType, written = nil, "", 0
+			wasihttp.HandleFunc(func(w http.ResponseWriter, r *http.Request) {
+				if !wasihttp.AddResponseHooks(unwrapWriter{w}, hooks) {
+					t.Error("expected hooks to be registered")
+				}
+				tt.handler(w, r)
+			})
+			rec := Serve(httptest.NewRequest(tt.method, "/", strings.NewReader("body")))
+			if !slices.Equal(statuses, []int{tt.status}) || written != tt.written {
+				t.Errorf("expected: %v %v, got: %v %v", tt.status, tt.written, statuses, written)
+			}
+			if want := rec.HeaderMap.Get("Content-Type"); contentType != want {
+				t.Errorf("expected: %q, got: %q", want, contentType)
+			}
+			if rec.Code != tt.status || rec.HeaderMap.Get("X-Observed") != "1" {
+				t.Errorf("unexpected response: %v %v", rec.Code, rec.HeaderMap)
+			}
+		})
+	}
+
+	if wasihttp.AddResponseHooks(httptest.NewRecorder(), hooks) {
+		t.Error("expected hooks not to be registered on a recorder")
+	}
+}
+
+// envelope returns a Connect and gRPC message envelope of msg.
+func envelope(flags byte, msg string) []byte {
+	return append([]byte{flags, 0, 0, 0, byte(len(msg))}, msg...)
+}
+
+func TestServeStreaming(t *testing.T) {
+	var flushed []int
+	var rec *ResponseRecorder
+	wasihttp.HandleFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength != int64(len(envelope(0, "count"))) {
+			t.Errorf("expected: %v, got: %v", len(envelope(0, "count")), r.ContentLength)
+		}
+		w.Header().Set("Content-Type", "application/connect+proto")
+		w.Header().Set(http.TrailerPrefix+"Grpc-Status", "0")
+		rc := http.NewResponseController(w)
+		if err := rc.Flush(); err != nil {
+			t.Errorf("failed to flush: %s", err)
+		}
+		flushed = append(flushed, rec.Code)
+		for _, msg := range []string{"one", "two", "three"} {
+			w.Write(envelope(0, msg))
+			w.(http.Flusher).Flush()
+			flushed = append(flushed, rec.Body.Len())
+		}
+		w.Write(envelope(2, "{}"))
+	})
+
+	ir := NewIncomingRequest(httptest.NewRequest(http.MethodPost, "/counter.v1.Counter/Count", bytes.NewReader(envelope(0, "count"))))
+	var out types.ResponseOutparam
+	out, rec = NewResponseOutparam()
+	incominghandler.Exports.Handle(ir, out)
+
+	if want := []int{http.StatusOK, 8, 16, 26}; !slices.Equal(flushed, want) {
+		t.Errorf("expected: %v, got: %v", want, flushed)
+	}
+	want := slices.Concat(envelope(0, "one"), envelope(0, "two"), envelope(0, "three"), envelope(2, "{}"))
+	if !bytes.Equal(rec.Body.Bytes(), want) {
+		t.Errorf("expected: %q, got: %q", want, rec.Body.Bytes())
+	}
+	if got := rec.HeaderMap.Get("Content-Type"); got != "application/connect+proto" {
+		t.Errorf("expected: %v, got: %v", "application/connect+proto", got)
+	}
+	if _, ok := rec.HeaderMap[http.TrailerPrefix+"Grpc-Status"]; ok {
+		t.Errorf("expected the trailer not to be sent as header, got: %v", rec.HeaderMap)
+	}
+	if got := rec.Trailers.Get("Grpc-Status"); !rec.Finished || got != "0" {
+		t.Errorf("expected: %v, got: %v", "0", got)
+	}
+}
+
+func TestTransport(t *testing.T) {
+	CheckLeaks(t)
+	defer func() { Outgoing = nil }()
+	Outgoing = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		switch r.URL.Path {
+		case "/greet.v1.Greeter/Greet":
+			if r.ContentLength != int64(len(body)) || r.Header.Get("Content-Length") == "" {
+				t.Errorf("expected: %v, got: %v %q", len(body), r.ContentLength, r.Header.Get("Content-Length"))
+			}
+			w.Header().Set("Content-Type", "application/proto")
+			w.Header().Set("Trailer-Greet-Version", "1")
+			w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+			w.Write(body)
+		case "/greet.v1.Greeter/GreetStream":
+			if r.ContentLength != -1 || r.Trailer.Get("X-Sent") != "done" {
+				t.Errorf("unexpected request: %v %v", r.ContentLength, r.Trailer)
+			}
+			w.Header().Set("Content-Type", "application/grpc")
+			w.Header().Set("Trailer", "Grpc-Status")
+			w.Write(envelope(0, "hi"))
+			w.Write(envelope(0, "ho"))
+			w.Header().Set("Grpc-Status", "0")
+		}
+	})
+	client := &http.Client{Transport: &wasihttp.Transport{}}
+
+	t.Run("unary", func(t *testing.T) {
+		msg := strings.Repeat("m", 10000)
+		resp, err := client.Post("http://greeter/greet.v1.Greeter/Greet", "application/proto", strings.NewReader(msg))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		if string(body) != msg {
+			t.Errorf("expected: %d bytes, got: %d", len(msg), len(body))
+		}
+		if resp.ContentLength != int64(len(msg)) || resp.ProtoMajor != 1 || resp.Request == nil {
+			t.Errorf("unexpected response: %v %v %v", resp.ContentLength, resp.Proto, resp.Request)
+		}
+		if got := resp.Header.Get("Trailer-Greet-Version"); got != "1" {
+			t.Errorf("expected: %v, got: %v", "1", got)
+		}
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, "http://greeter/greet.v1.Greeter/GreetStream", io.NopCloser(bytes.NewReader(envelope(0, "hello"))))
+		req.Trailer = http.Header{"X-Sent": {"done"}}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		if want := (http.Header{"Grpc-Status": nil}); resp.ContentLength != -1 || !reflect.DeepEqual(resp.Trailer, want) {
+			t.Errorf("unexpected response: %v %v", resp.ContentLength, resp.Trailer)
+		}
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := slices.Concat(envelope(0, "hi"), envelope(0, "ho")); !bytes.Equal(body, want) {
+			t.Errorf("expected: %q, got: %q", want, body)
+		}
+		if got := resp.Trailer.Get("Grpc-Status"); got != "0" {
+			t.Errorf("expected: %v, got: %v", "0", got)
+		}
+	})
+
+	t.Run("refused", func(t *testing.T) {
+		Outgoing = nil
+		_, err := client.Get("http://greeter/")
+		if err == nil || !strings.Contains(err.Error(), "connection-refused") {
+			t.Errorf("expected: %v, got: %v", "connection-refused", err)
+		}
+	})
+}
+
+func TestTransportLimits(t *testing.T) {
+	defer func() { Outgoing = nil }()
+	Outgoing = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/streamed" {
+			w.(http.Flusher).Flush()
+		} else {
+			w.Header().Set("Content-Length", "100")
+		}
+		w.Write(bytes.Repeat([]byte("x"), 100))
+	})
+
+	tests := map[string]struct {
+		transport *wasihttp.Transport
+		path      string
+		size      int
+		err       error
+	}{
+		"within": {
+			transport: &wasihttp.Transport{MaxResponseBodySize: 100, ResponseBodyTimeout: time.Minute},
+			path:      "/streamed",
+			size:      100,
+		},
+		"announced": {
+			transport: &wasihttp.Transport{MaxResponseBodySize: 99},
+			path:      "/",
+			err:       &wasihttp.ResponseBodyTooLargeError{Limit: 99},
+		},
+		"streamed": {
+			transport: &wasihttp.Transport{MaxResponseBodySize: 10},
+			path:      "/streamed",
+			size:      10,
+			err:       &wasihttp.ResponseBodyTooLargeError{Limit: 10},
+		},
+		"timeout": {
+			transport: &wasihttp.Transport{ResponseBodyTimeout: time.Nanosecond},
+			path:      "/",
+			err:       &wasihttp.ResponseBodyTimeoutError{Timeout: time.Nanosecond},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &http.Client{Transport: tt.transport}
+			resp, err := client.Get("http://upstream" + tt.path)
+			var body []byte
+			if err == nil {
+				time.Sleep(time.Millisecond)
+				body, err = io.ReadAll(resp.Body)
+				resp.Body.Close()
+			}
+			if len(body) != tt.size {
+				t.Errorf("expected: %d bytes, got: %d", tt.size, len(body))
+			}
+			if tt.err == nil {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			target := reflect.New(reflect.TypeOf(tt.err))
+			if !errors.As(err, target.Interface()) || !reflect.DeepEqual(target.Elem().Interface(), tt.err) {
+				t.Errorf("expected: %v, got: %v", tt.err, err)
+			}
+		})
+	}
+
+	if err := (&wasihttp.ResponseBodyTimeoutError{}); !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Errorf("expected %v to be %v", err, os.ErrDeadlineExceeded)
+	}
+}
+
+func TestTransportAuthority(t *testing.T) {
+	defer func() { Outgoing = nil }()
+	var host string
+	Outgoing = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host = r.Host
+		if v := r.Header.Get("Host"); v != "" {
+			t.Errorf("unexpected Host field: %v", v)
+		}
+	})
+
+	tests := map[string]struct {
+		host      string
+		url       string
+		header    string
+		authority string
+		expected  string
+	}{
+		"url":         {url: "http://example.com/", expected: "example.com"},
+		"url port":    {url: "http://example.com:8080/", expected: "example.com:8080"},
+		"host":        {host: "api.example.com", url: "http://10.0.0.1/", expected: "api.example.com"},
+		"host header": {url: "http://example.com/", header: "other.example.com", expected: "example.com"},
+		"ipv6":        {url: "http://[::1]/", expected: "[::1]"},
+		"ipv6 port":   {url: "http://[2001:db8::1]:8443/", expected: "[2001:db8::1]:8443"},
+		"ipv6 zone":   {url: "http://[fe80::1%25en0]:8080/", expected: "[fe80::1]:8080"},
+		"authority":   {host: "api.example.com", url: "http://10.0.0.1/", authority: "internal:8080", expected: "internal:8080"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			u, err := url.Parse(tt.url)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// NOTE: not http.NewRequest, which sets Host from the URL
+			req := &http.Request{Method: http.MethodGet, URL: u, Host: tt.host, Header: http.Header{}}
+			if tt.header != "" {
+				req.Header.Set("Host", tt.header)
+			}
+			host = ""
+			resp, err := (&wasihttp.Transport{Authority: tt.authority}).RoundTrip(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if host != tt.expected {
+				t.Errorf("expected: %v, got: %v", tt.expected, host)
+			}
+		})
+	}
+
+	t.Run("no host", func(t *testing.T) {
+		req := &http.Request{Method: http.MethodGet, URL: &url.URL{Scheme: "http", Path: "/"}, Header: http.Header{}}
+		if _, err := (&wasihttp.Transport{}).RoundTrip(req); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
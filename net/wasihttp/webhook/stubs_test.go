@@ -0,0 +1,6 @@
+//go:build !wasm
+
+package webhook
+
+// stubs of the host imports, tests drive wasiclock.NowFunc
+import _ "go.wasmcloud.dev/component/net/wasihttp/wasihttptest"
@@ -0,0 +1,268 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.wasmcloud.dev/component/net/wasihttp"
+	"go.wasmcloud.dev/component/secrets"
+	"go.wasmcloud.dev/component/time/wasiclock"
+)
+
+var (
+	// ErrNoSignature is returned when a request carries no signature of the
+	// Scheme.
+	ErrNoSignature = errors.New("webhook: no signature")
+	// ErrInvalidSignature is returned when no signature of a request matches
+	// its body.
+	ErrInvalidSignature = errors.New("webhook: invalid signature")
+	// ErrExpired is returned when the signature time of a request is outside
+	// of the Tolerance of the Verifier, e.g. of a replayed request.
+	ErrExpired = errors.New("webhook: signature expired")
+	// ErrNoSecret is returned when the Secret of a Verifier is unset or empty,
+	// any sender could sign requests with an empty key.
+	ErrNoSecret = errors.New("webhook: no secret")
+)
+
+const (
+	// DefaultTolerance is the default replay window of a Verifier.
+	DefaultTolerance = 5 * time.Minute
+	// DefaultMaxBytes is the default size limit of the verified bodies.
+	DefaultMaxBytes = 1 << 20
+)
+
+// Signature is a signature of a request, parsed by a Scheme.
+type Signature struct {
+	// Prefix is signed before the body, e.g. the timestamp.
+	Prefix string
+	// MACs are the HMAC-SHA256 of the request, it is valid if any matches,
+	// e.g. during a rotation of the secret.
+	MACs [][]byte
+	// Time is the signature time, checked against the Tolerance of the
+	// Verifier. The zero Time is not checked, for schemes without timestamp.
+	Time time.Time
+}
+
+// Scheme parses the signature of a request from its header.
+type Scheme interface {
+	Signature(h http.Header) (Signature, error)
+}
+
+// SchemeFunc adapts a function to a Scheme.
+type SchemeFunc func(h http.Header) (Signature, error)
+
+func (f SchemeFunc) Signature(h http.Header) (Signature, error) {
+	return f(h)
+}
+
+// HMACSHA256 is a generic scheme of HMAC-SHA256 signatures of the body, hex or
+// base64 encoded.
+type HMACSHA256 struct {
+	// Header carries the signature, e.g. "X-Signature".
+	Header string
+	// Prefix is trimmed from the signature, e.g. "sha256=".
+	Prefix string
+	// TimestampHeader carries the Unix time of the signature, if set. The
+	// payload signed is then "<timestamp>.<body>".
+	TimestampHeader string
+}
+
+func (s HMACSHA256) Signature(h http.Header) (Signature, error) {
+	value := h.Get(s.Header)
+	if value == "" {
+		return Signature{}, ErrNoSignature
+	}
+	mac, err := decodeMAC(strings.TrimPrefix(value, s.Prefix))
+	if err != nil {
+		return Signature{}, err
+	}
+	if s.TimestampHeader == "" {
+		return Signature{MACs: [][]byte{mac}}, nil
+	}
+
+	ts := h.Get(s.TimestampHeader)
+	t, err := parseUnix(ts)
+	if err != nil {
+		return Signature{}, err
+	}
+	return Signature{Prefix: ts + ".", MACs: [][]byte{mac}, Time: t}, nil
+}
+
+// GitHub is the scheme of GitHub webhooks, signed in X-Hub-Signature-256.
+// GitHub does not sign the delivery time, replays are not detected.
+var GitHub Scheme = HMACSHA256{Header: "X-Hub-Signature-256", Prefix: "sha256="}
+
+// Stripe is the scheme of Stripe webhooks, signed in Stripe-Signature.
+var Stripe Scheme = SchemeFunc(func(h http.Header) (Signature, error) {
+	value := h.Get("Stripe-Signature")
+	if value == "" {
+		return Signature{}, ErrNoSignature
+	}
+
+	var (
+		ts  string
+		sig Signature
+	)
+	for _, item := range strings.Split(value, ",") {
+		k, v, _ := strings.Cut(item, "=")
+		switch k {
+		case "t":
+			ts = v
+		case "v1":
+			// NOTE: other schemes, e.g. v0 of test mode, are ignored
+			mac, err := hex.DecodeString(v)
+			if err != nil {
+				return Signature{}, fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+			}
+			sig.MACs = append(sig.MACs, mac)
+		}
+	}
+	if len(sig.MACs) == 0 {
+		return Signature{}, ErrNoSignature
+	}
+	t, err := parseUnix(ts)
+	if err != nil {
+		return Signature{}, err
+	}
+	sig.Prefix, sig.Time = ts+".", t
+	return sig, nil
+})
+
+// Slack is the scheme of Slack requests, signed in X-Slack-Signature.
+var Slack Scheme = SchemeFunc(func(h http.Header) (Signature, error) {
+	value, ok := strings.CutPrefix(h.Get("X-Slack-Signature"), "v0=")
+	if !ok {
+		return Signature{}, ErrNoSignature
+	}
+	mac, err := hex.DecodeString(value)
+	if err != nil {
+		return Signature{}, fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+	ts := h.Get("X-Slack-Request-Timestamp")
+	t, err := parseUnix(ts)
+	if err != nil {
+		return Signature{}, err
+	}
+	return Signature{Prefix: "v0:" + ts + ":", MACs: [][]byte{mac}, Time: t}, nil
+})
+
+func decodeMAC(s string) ([]byte, error) {
+	if mac, err := hex.DecodeString(s); err == nil {
+		return mac, nil
+	}
+	mac, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: signature is neither hex nor base64", ErrInvalidSignature)
+	}
+	return mac, nil
+}
+
+func parseUnix(ts string) (time.Time, error) {
+	sec, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: invalid timestamp %q", ErrInvalidSignature, ts)
+	}
+	return time.Unix(sec, 0), nil
+}
+
+// Verifier verifies the signatures of webhook requests.
+type Verifier struct {
+	Scheme Scheme
+	// Secret is the signing secret shared with the sender.
+	Secret secrets.Secret
+	// Tolerance is the replay window, the largest difference between the
+	// signature time and `wasi:clocks/wall-clock`. It defaults to
+	// DefaultTolerance.
+	Tolerance time.Duration
+	// MaxBytes is the size limit of the bodies, it defaults to
+	// DefaultMaxBytes.
+	MaxBytes int64
+}
+
+// Verify verifies the signature of r and returns its body. The body is read
+// from the stream while it is hashed, and r.Body replaced with the verified
+// bytes, so handlers decode the body as usual, e.g. with wasihttp.DecodeJSON.
+//
+// Verification fails with a wasihttp.RequestError, of status 401 Unauthorized
+// wrapping ErrNoSignature, ErrInvalidSignature or ErrExpired, or of status 413
+// Request Entity Too Large. A Verifier without Secret fails with ErrNoSecret,
+// answered with 500 Internal Server Error.
+func (v *Verifier) Verify(r *http.Request) ([]byte, error) {
+	key := v.Secret.RevealBytes()
+	if v.Secret.IsZero() || len(key) == 0 {
+		return nil, ErrNoSecret
+	}
+
+	sig, err := v.Scheme.Signature(r.Header)
+	if err != nil {
+		return nil, unauthorized(err)
+	}
+	if !sig.Time.IsZero() {
+		tolerance := v.Tolerance
+		if tolerance == 0 {
+			tolerance = DefaultTolerance
+		}
+		if d := wasiclock.Since(sig.Time); d > tolerance || d < -tolerance {
+			return nil, unauthorized(ErrExpired)
+		}
+	}
+
+	maxBytes := v.MaxBytes
+	if maxBytes == 0 {
+		maxBytes = DefaultMaxBytes
+	}
+	mac := hmac.New(sha256.New, key)
+	io.WriteString(mac, sig.Prefix)
+
+	// NOTE: the body is only handed on once it is verified, handlers must not
+	// act on a prefix of a forged body
+	var body bytes.Buffer
+	if r.Body != nil {
+		_, err = body.ReadFrom(io.TeeReader(http.MaxBytesReader(nil, r.Body, maxBytes), mac))
+		r.Body.Close()
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				return nil, &wasihttp.RequestError{Status: http.StatusRequestEntityTooLarge, Err: fmt.Errorf("webhook: request body is larger than %d bytes", maxBytes)}
+			}
+			return nil, fmt.Errorf("webhook: failed to read request body: %w", err)
+		}
+	}
+
+	sum := mac.Sum(nil)
+	for _, m := range sig.MACs {
+		if hmac.Equal(m, sum) {
+			r.Body = io.NopCloser(bytes.NewReader(body.Bytes()))
+			r.ContentLength = int64(body.Len())
+			return body.Bytes(), nil
+		}
+	}
+	return nil, unauthorized(ErrInvalidSignature)
+}
+
+func unauthorized(err error) error {
+	return &wasihttp.RequestError{Status: http.StatusUnauthorized, Err: err}
+}
+
+// Handler returns a handler verifying the signature of the requests before
+// passing them on to next, with the verified body. Requests failing
+// verification are answered with the status of the error.
+func (v *Verifier) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := v.Verify(r); err != nil {
+			http.Error(w, err.Error(), wasihttp.ErrorStatus(err))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
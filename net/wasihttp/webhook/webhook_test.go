@@ -0,0 +1,198 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.wasmcloud.dev/component/net/wasihttp"
+	"go.wasmcloud.dev/component/secrets"
+	"go.wasmcloud.dev/component/time/wasiclock"
+)
+
+func sign(secret, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	io.WriteString(mac, payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// The GitHub and Slack test vectors are the ones of their documentation.
+func TestVerify(t *testing.T) {
+	now := time.Unix(1531420618, 0)
+	prev := wasiclock.NowFunc
+	wasiclock.NowFunc = func() time.Time { return now }
+	t.Cleanup(func() { wasiclock.NowFunc = prev })
+
+	slackBody := "token=xyzz0WbapA4vBCDEFasx0q6G&team_id=T1DC2JH3J&team_domain=testteamnow&channel_id=G8PSS9T3V&channel_name=foobar&user_id=U2CERLKJA&user_name=roadrunner&command=%2Fwebhook-collect&text=&response_url=https%3A%2F%2Fhooks.slack.com%2Fcommands%2FT1DC2JH3J%2F397700885554%2F96rGlfmibIGlgcZRskXaIFfN&trigger_id=398738663015.47445629121.803a0bc887a14d10d2c447fce8b6703c"
+	stripeTS := "1531420600"
+	generic := HMACSHA256{Header: "X-Signature", TimestampHeader: "X-Timestamp"}
+
+	tests := map[string]struct {
+		scheme   Scheme
+		secret   string
+		header   http.Header
+		body     string
+		maxBytes int64
+		status   int
+		err      error
+	}{
+		"github": {
+			scheme: GitHub,
+			secret: "It's a Secret to Everybody",
+			header: http.Header{"X-Hub-Signature-256": {"sha256=757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17"}},
+			body:   "Hello, World!",
+		},
+		"github forged": {
+			scheme: GitHub,
+			secret: "It's a Secret to Everybody",
+			header: http.Header{"X-Hub-Signature-256": {"sha256=757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17"}},
+			body:   "Hello, World?",
+			status: http.StatusUnauthorized,
+			err:    ErrInvalidSignature,
+		},
+		"github unsigned": {
+			scheme: GitHub,
+			secret: "It's a Secret to Everybody",
+			body:   "Hello, World!",
+			status: http.StatusUnauthorized,
+			err:    ErrNoSignature,
+		},
+		"slack": {
+			scheme: Slack,
+			secret: "8f742231b10e8888abcd99yyyzzz85a5",
+			header: http.Header{
+				"X-Slack-Signature":         {"v0=a2114d57b48eac39b9ad189dd8316235a7b4a8d21a10bd27519666489c69b503"},
+				"X-Slack-Request-Timestamp": {"1531420618"},
+			},
+			body: slackBody,
+		},
+		"slack replayed": {
+			scheme: Slack,
+			secret: "8f742231b10e8888abcd99yyyzzz85a5",
+			header: http.Header{
+				"X-Slack-Signature":         {"v0=" + sign("8f742231b10e8888abcd99yyyzzz85a5", "v0:1531420000:"+slackBody)},
+				"X-Slack-Request-Timestamp": {"1531420000"},
+			},
+			body:   slackBody,
+			status: http.StatusUnauthorized,
+			err:    ErrExpired,
+		},
+		"stripe": {
+			scheme: Stripe,
+			secret: "whsec_test",
+			header: http.Header{"Stripe-Signature": {"t=" + stripeTS + ",v1=" + sign("whsec_old", stripeTS+".{}") + ",v1=" + sign("whsec_test", stripeTS+".{}") + ",v0=00"}},
+			body:   "{}",
+		},
+		"stripe timestamp": {
+			scheme: Stripe,
+			secret: "whsec_test",
+			header: http.Header{"Stripe-Signature": {"t=1531420601,v1=" + sign("whsec_test", stripeTS+".{}")}},
+			body:   "{}",
+			status: http.StatusUnauthorized,
+			err:    ErrInvalidSignature,
+		},
+		"generic base64": {
+			scheme: generic,
+			secret: "secret",
+			header: http.Header{
+				"X-Signature": {"wkiK+AglWVy0jOZ34NPkFrjsuyKzh4/fHyhUh/5uqhA="},
+				"X-Timestamp": {"1531420618"},
+			},
+			body: "ping",
+		},
+		"too large": {
+			scheme:   GitHub,
+			secret:   "It's a Secret to Everybody",
+			header:   http.Header{"X-Hub-Signature-256": {"sha256=757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17"}},
+			body:     "Hello, World!",
+			maxBytes: 8,
+			status:   http.StatusRequestEntityTooLarge,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(tt.body))
+			for k, v := range tt.header {
+				r.Header[k] = v
+			}
+			v := &Verifier{Scheme: tt.scheme, Secret: secrets.New([]byte(tt.secret)), MaxBytes: tt.maxBytes}
+
+			body, err := v.Verify(r)
+			if tt.status != 0 {
+				if status := wasihttp.ErrorStatus(err); status != tt.status {
+					t.Errorf("expected: %v, got: %v (%v)", tt.status, status, err)
+				}
+				if tt.err != nil && !errors.Is(err, tt.err) {
+					t.Errorf("expected: %v, got: %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(body) != tt.body {
+				t.Errorf("expected: %q, got: %q", tt.body, body)
+			}
+			if got, _ := io.ReadAll(r.Body); string(got) != tt.body {
+				t.Errorf("expected the verified body, got: %q", got)
+			}
+		})
+	}
+}
+
+func TestHandler(t *testing.T) {
+	v := &Verifier{Scheme: GitHub, Secret: secrets.New([]byte("secret"))}
+	h := v.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(w, r.Body)
+	}))
+
+	for _, tc := range []struct {
+		signature string
+		status    int
+	}{
+		{"sha256=" + sign("secret", "ping"), http.StatusOK},
+		{"sha256=" + sign("other", "ping"), http.StatusUnauthorized},
+		{"", http.StatusUnauthorized},
+	} {
+		r := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader("ping"))
+		if tc.signature != "" {
+			r.Header.Set("X-Hub-Signature-256", tc.signature)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, r)
+		if rec.Code != tc.status {
+			t.Errorf("expected: %v, got: %v", tc.status, rec.Code)
+		}
+		if tc.status == http.StatusOK && rec.Body.String() != "ping" {
+			t.Errorf("expected: %q, got: %q", "ping", rec.Body)
+		}
+	}
+}
+
+func TestVerifyNoSecret(t *testing.T) {
+	for name, secret := range map[string]secrets.Secret{
+		"unset": {},
+		"empty": secrets.New(nil),
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader("ping"))
+			r.Header.Set("X-Hub-Signature-256", "sha256="+sign("", "ping"))
+
+			v := &Verifier{Scheme: GitHub, Secret: secret}
+			_, err := v.Verify(r)
+			if !errors.Is(err, ErrNoSecret) {
+				t.Errorf("expected: %v, got: %v", ErrNoSecret, err)
+			}
+			if status := wasihttp.ErrorStatus(err); status != http.StatusInternalServerError {
+				t.Errorf("expected: %v, got: %v", http.StatusInternalServerError, status)
+			}
+		})
+	}
+}
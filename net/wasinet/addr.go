@@ -0,0 +1,62 @@
+package wasinet
+
+import (
+	"net"
+	"net/netip"
+
+	"go.wasmcloud.dev/component/gen/wasi/sockets/network"
+)
+
+func toNetipAddr(addr network.IPAddress) netip.Addr {
+	if v4 := addr.IPv4(); v4 != nil {
+		return netip.AddrFrom4(*v4)
+	}
+
+	v6 := addr.IPv6()
+	var b [16]byte
+	for i, segment := range v6 {
+		b[2*i] = byte(segment >> 8)
+		b[2*i+1] = byte(segment)
+	}
+	return netip.AddrFrom16(b)
+}
+
+func toWasiSocketAddress(addr netip.AddrPort) network.IPSocketAddress {
+	ip := addr.Addr()
+	if ip.Is4() || ip.Is4In6() {
+		return network.IPSocketAddressIPv4(network.IPv4SocketAddress{
+			Port:    addr.Port(),
+			Address: ip.Unmap().As4(),
+		})
+	}
+
+	b := ip.As16()
+	var segments network.IPv6Address
+	for i := range segments {
+		segments[i] = uint16(b[2*i])<<8 | uint16(b[2*i+1])
+	}
+	return network.IPSocketAddressIPv6(network.IPv6SocketAddress{
+		Port:    addr.Port(),
+		Address: segments,
+	})
+}
+
+func fromWasiSocketAddress(addr network.IPSocketAddress) netip.AddrPort {
+	if v4 := addr.IPv4(); v4 != nil {
+		return netip.AddrPortFrom(netip.AddrFrom4(v4.Address), v4.Port)
+	}
+
+	v6 := addr.IPv6()
+	return netip.AddrPortFrom(toNetipAddr(network.IPAddressIPv6(v6.Address)), v6.Port)
+}
+
+func addressFamily(addr netip.Addr) network.IPAddressFamily {
+	if addr.Is4() || addr.Is4In6() {
+		return network.IPAddressFamilyIPv4
+	}
+	return network.IPAddressFamilyIPv6
+}
+
+func toTCPAddr(addr netip.AddrPort) *net.TCPAddr {
+	return net.TCPAddrFromAddrPort(addr)
+}
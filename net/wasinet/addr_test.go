@@ -0,0 +1,51 @@
+package wasinet
+
+import (
+	"net/netip"
+	"testing"
+
+	"go.wasmcloud.dev/component/gen/wasi/sockets/network"
+)
+
+func TestSocketAddressRoundTrip(t *testing.T) {
+	tt := map[string]struct {
+		addr   string
+		family network.IPAddressFamily
+	}{
+		"ipv4": {
+			addr:   "127.0.0.1:8080",
+			family: network.IPAddressFamilyIPv4,
+		},
+		"ipv6": {
+			addr:   "[2001:db8::1]:443",
+			family: network.IPAddressFamilyIPv6,
+		},
+		"ipv6 loopback": {
+			addr:   "[::1]:1",
+			family: network.IPAddressFamilyIPv6,
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			addr := netip.MustParseAddrPort(tc.addr)
+			if got := addressFamily(addr.Addr()); got != tc.family {
+				t.Errorf("expected family: %v, got: %v", tc.family, got)
+			}
+			if got := fromWasiSocketAddress(toWasiSocketAddress(addr)); got != addr {
+				t.Errorf("expected: %v, got: %v", addr, got)
+			}
+		})
+	}
+}
+
+func TestIPv4MappedAddress(t *testing.T) {
+	addr := netip.MustParseAddrPort("[::ffff:10.0.0.1]:53")
+	wasiAddr := toWasiSocketAddress(addr)
+	if wasiAddr.IPv4() == nil {
+		t.Fatalf("expected IPv4-mapped address to be converted to IPv4")
+	}
+	if want, got := netip.MustParseAddrPort("10.0.0.1:53"), fromWasiSocketAddress(wasiAddr); got != want {
+		t.Errorf("expected: %v, got: %v", want, got)
+	}
+}
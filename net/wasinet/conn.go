@@ -0,0 +1,286 @@
+package wasinet
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+	monotonicclock "go.wasmcloud.dev/component/gen/wasi/clocks/monotonic-clock"
+	"go.wasmcloud.dev/component/gen/wasi/io/poll"
+	"go.wasmcloud.dev/component/gen/wasi/io/streams"
+	"go.wasmcloud.dev/component/gen/wasi/sockets/tcp"
+	"go.wasmcloud.dev/component/io/wasipoll"
+)
+
+var _ net.Conn = (*Conn)(nil)
+
+// Conn is a net.Conn backed by a `wasi:sockets/tcp.tcp-socket`.
+type Conn struct {
+	socket tcp.TCPSocket
+	input  streams.InputStream
+	output streams.OutputStream
+
+	// subscriptions are children of their streams and must be dropped first
+	inputPollable  poll.Pollable
+	outputPollable poll.Pollable
+
+	localAddr  net.Addr
+	remoteAddr net.Addr
+
+	readLock  sync.Mutex
+	writeLock sync.Mutex
+
+	// deadlineLock guards the deadlines and the channels waking pending
+	// reads and writes when a deadline changes or the conn is closed.
+	deadlineLock  sync.Mutex
+	readDeadline  time.Time
+	writeDeadline time.Time
+	readWake      chan struct{}
+	writeWake     chan struct{}
+	closing       bool
+
+	// socketLock guards the socket against Close for the socket options,
+	// without waiting for pending reads and writes.
+	socketLock sync.RWMutex
+	closeOnce  sync.Once
+	closed     bool
+}
+
+func newConn(socket tcp.TCPSocket, input streams.InputStream, output streams.OutputStream) *Conn {
+	c := &Conn{
+		socket:         socket,
+		input:          input,
+		output:         output,
+		inputPollable:  input.Subscribe(),
+		outputPollable: output.Subscribe(),
+		readWake:       make(chan struct{}),
+		writeWake:      make(chan struct{}),
+	}
+
+	if res := socket.LocalAddress(); res.IsOK() {
+		c.localAddr = toTCPAddr(fromWasiSocketAddress(*res.OK()))
+	}
+	if res := socket.RemoteAddress(); res.IsOK() {
+		c.remoteAddr = toTCPAddr(fromWasiSocketAddress(*res.OK()))
+	}
+
+	return c
+}
+
+func (c *Conn) opError(op string, err error) error {
+	return &net.OpError{Op: op, Net: "tcp", Source: c.localAddr, Addr: c.remoteAddr, Err: err}
+}
+
+// Read implements net.Conn. It returns an error wrapping [os.ErrDeadlineExceeded]
+// if the read deadline passes before any data is available.
+func (c *Conn) Read(p []byte) (int, error) {
+	c.readLock.Lock()
+	defer c.readLock.Unlock()
+
+	if c.closed {
+		return 0, c.opError("read", net.ErrClosed)
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	for {
+		res := c.input.Read(uint64(len(p)))
+		if res.IsErr() {
+			if res.Err().Closed() {
+				return 0, io.EOF
+			}
+			return 0, c.opError("read", streamError(res.Err()))
+		}
+
+		if data := res.OK().Slice(); len(data) > 0 {
+			return copy(p, data), nil
+		}
+
+		c.deadlineLock.Lock()
+		deadline, wake, closing := c.readDeadline, c.readWake, c.closing
+		c.deadlineLock.Unlock()
+
+		if closing {
+			return 0, c.opError("read", net.ErrClosed)
+		}
+		if err := waitDeadline(c.inputPollable, deadline, wake); err != nil {
+			return 0, c.opError("read", err)
+		}
+	}
+}
+
+// Write implements net.Conn. It returns an error wrapping [os.ErrDeadlineExceeded]
+// if the write deadline passes before all of p has been accepted by the host.
+func (c *Conn) Write(p []byte) (int, error) {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	if c.closed {
+		return 0, c.opError("write", net.ErrClosed)
+	}
+
+	var n int
+	for n < len(p) {
+		res := c.output.CheckWrite()
+		if res.IsErr() {
+			if res.Err().Closed() {
+				return n, c.opError("write", io.ErrClosedPipe)
+			}
+			return n, c.opError("write", streamError(res.Err()))
+		}
+
+		budget := *res.OK()
+		if budget == 0 {
+			c.deadlineLock.Lock()
+			deadline, wake, closing := c.writeDeadline, c.writeWake, c.closing
+			c.deadlineLock.Unlock()
+
+			if closing {
+				return n, c.opError("write", net.ErrClosed)
+			}
+			if err := waitDeadline(c.outputPollable, deadline, wake); err != nil {
+				return n, c.opError("write", err)
+			}
+			continue
+		}
+
+		chunk := p[n:]
+		if uint64(len(chunk)) > budget {
+			chunk = chunk[:budget]
+		}
+
+		if res := c.output.Write(cm.ToList(chunk)); res.IsErr() {
+			if res.Err().Closed() {
+				return n, c.opError("write", io.ErrClosedPipe)
+			}
+			return n, c.opError("write", streamError(res.Err()))
+		}
+		n += len(chunk)
+	}
+
+	if res := c.output.Flush(); res.IsErr() && !res.Err().Closed() {
+		return n, c.opError("write", streamError(res.Err()))
+	}
+
+	return n, nil
+}
+
+// Close implements net.Conn. Pending reads and writes are woken up and fail
+// with net.ErrClosed before the underlying resources are dropped.
+func (c *Conn) Close() error {
+	c.closeOnce.Do(func() {
+		c.deadlineLock.Lock()
+		c.closing = true
+		c.wakeReaders()
+		c.wakeWriters()
+		c.deadlineLock.Unlock()
+
+		c.readLock.Lock()
+		defer c.readLock.Unlock()
+		c.writeLock.Lock()
+		defer c.writeLock.Unlock()
+		c.socketLock.Lock()
+		defer c.socketLock.Unlock()
+
+		c.closed = true
+
+		c.output.BlockingFlush()
+		c.inputPollable.ResourceDrop()
+		c.outputPollable.ResourceDrop()
+		c.input.ResourceDrop()
+		c.output.ResourceDrop()
+		c.socket.ResourceDrop()
+	})
+	return nil
+}
+
+// LocalAddr implements net.Conn.
+func (c *Conn) LocalAddr() net.Addr {
+	return c.localAddr
+}
+
+// RemoteAddr implements net.Conn.
+func (c *Conn) RemoteAddr() net.Addr {
+	return c.remoteAddr
+}
+
+// SetDeadline implements net.Conn.
+func (c *Conn) SetDeadline(t time.Time) error {
+	c.deadlineLock.Lock()
+	defer c.deadlineLock.Unlock()
+
+	c.readDeadline = t
+	c.writeDeadline = t
+	c.wakeReaders()
+	c.wakeWriters()
+	return nil
+}
+
+// SetReadDeadline implements net.Conn.
+func (c *Conn) SetReadDeadline(t time.Time) error {
+	c.deadlineLock.Lock()
+	defer c.deadlineLock.Unlock()
+
+	c.readDeadline = t
+	c.wakeReaders()
+	return nil
+}
+
+// SetWriteDeadline implements net.Conn.
+func (c *Conn) SetWriteDeadline(t time.Time) error {
+	c.deadlineLock.Lock()
+	defer c.deadlineLock.Unlock()
+
+	c.writeDeadline = t
+	c.wakeWriters()
+	return nil
+}
+
+// wakeReaders makes pending reads re-evaluate their deadline, it must be
+// called with the deadlineLock held.
+func (c *Conn) wakeReaders() {
+	close(c.readWake)
+	c.readWake = make(chan struct{})
+}
+
+// wakeWriters makes pending writes re-evaluate their deadline, it must be
+// called with the deadlineLock held.
+func (c *Conn) wakeWriters() {
+	close(c.writeWake)
+	c.writeWake = make(chan struct{})
+}
+
+// waitDeadline waits for the pollable to become ready, racing it against a
+// `wasi:clocks/monotonic-clock` pollable when a deadline is set.
+// It returns early, without error, once wake is closed.
+func waitDeadline(pollable poll.Pollable, deadline time.Time, wake <-chan struct{}) error {
+	if deadline.IsZero() {
+		wasipoll.WaitCancel(wake, pollable)
+		return nil
+	}
+
+	timeout := time.Until(deadline)
+	if timeout <= 0 {
+		return os.ErrDeadlineExceeded
+	}
+
+	timer := monotonicclock.SubscribeDuration(monotonicclock.Duration(timeout))
+	defer timer.ResourceDrop()
+
+	if wasipoll.WaitCancel(wake, pollable, timer) == 1 {
+		return os.ErrDeadlineExceeded
+	}
+	return nil
+}
+
+func streamError(err *streams.StreamError) error {
+	ioErr := err.LastOperationFailed()
+	defer ioErr.ResourceDrop()
+
+	return fmt.Errorf("stream operation failed: %s", ioErr.ToDebugString())
+}
@@ -0,0 +1,273 @@
+package wasinet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	instancenetwork "go.wasmcloud.dev/component/gen/wasi/sockets/instance-network"
+	ipnamelookup "go.wasmcloud.dev/component/gen/wasi/sockets/ip-name-lookup"
+	"go.wasmcloud.dev/component/gen/wasi/sockets/network"
+	tcpcreatesocket "go.wasmcloud.dev/component/gen/wasi/sockets/tcp-create-socket"
+)
+
+var (
+	instanceNetworkOnce sync.Once
+	instanceNetworkRes  network.Network
+)
+
+func instanceNetwork() network.Network {
+	instanceNetworkOnce.Do(func() {
+		instanceNetworkRes = instancenetwork.InstanceNetwork()
+	})
+	return instanceNetworkRes
+}
+
+// Dialer contains options for connecting to an address over `wasi:sockets`.
+// It mirrors the subset of net.Dialer that can be honored by the host.
+type Dialer struct {
+	// Timeout is the maximum amount of time a dial will wait for a connect
+	// to complete. If Deadline is also set, it may fail earlier.
+	Timeout time.Duration
+
+	// Deadline is the absolute point in time after which dials will fail.
+	Deadline time.Time
+
+	// SocketOptions are applied to the socket before connecting.
+	SocketOptions
+}
+
+// Dial connects to the address on the named network.
+// Only "tcp", "tcp4" and "tcp6" networks are supported.
+func Dial(network, address string) (net.Conn, error) {
+	var d Dialer
+	return d.Dial(network, address)
+}
+
+// DialTimeout acts like Dial but takes a timeout.
+func DialTimeout(network, address string, timeout time.Duration) (net.Conn, error) {
+	d := Dialer{Timeout: timeout}
+	return d.Dial(network, address)
+}
+
+// Dial connects to the address on the named network.
+func (d *Dialer) Dial(network, address string) (net.Conn, error) {
+	return d.DialContext(context.Background(), network, address)
+}
+
+// ContextDialer returns a dial function for the named network, with the
+// signature expected by e.g. grpc.WithContextDialer.
+func (d *Dialer) ContextDialer(network string) func(ctx context.Context, address string) (net.Conn, error) {
+	return func(ctx context.Context, address string) (net.Conn, error) {
+		return d.DialContext(ctx, network, address)
+	}
+}
+
+func (d *Dialer) deadline(ctx context.Context) time.Time {
+	var earliest time.Time
+	if d.Timeout > 0 {
+		earliest = time.Now().Add(d.Timeout)
+	}
+	for _, t := range []time.Time{d.Deadline, contextDeadline(ctx)} {
+		if !t.IsZero() && (earliest.IsZero() || t.Before(earliest)) {
+			earliest = t
+		}
+	}
+	return earliest
+}
+
+func contextDeadline(ctx context.Context) time.Time {
+	deadline, _ := ctx.Deadline()
+	return deadline
+}
+
+// DialContext connects to the address on the named network, using the context
+// deadline in addition to the Dialer options to bound the connect.
+func (d *Dialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	opError := func(err error) error {
+		return &net.OpError{Op: "dial", Net: network, Err: err}
+	}
+
+	switch network {
+	case "tcp", "tcp4", "tcp6":
+	default:
+		return nil, opError(net.UnknownNetworkError(network))
+	}
+
+	deadline := d.deadline(ctx)
+
+	addrs, err := resolve(ctx, network, address, deadline)
+	if err != nil {
+		return nil, opError(err)
+	}
+
+	var firstErr error
+	for _, addr := range addrs {
+		if err := ctx.Err(); err != nil {
+			return nil, opError(err)
+		}
+
+		conn, err := connect(ctx, addr, deadline, &d.SocketOptions)
+		if err == nil {
+			return conn, nil
+		}
+		if firstErr == nil {
+			firstErr = &net.OpError{Op: "dial", Net: network, Addr: toTCPAddr(addr), Err: err}
+		}
+	}
+	return nil, firstErr
+}
+
+func connect(ctx context.Context, addr netip.AddrPort, deadline time.Time, opts *SocketOptions) (*Conn, error) {
+	res := tcpcreatesocket.CreateTCPSocket(addressFamily(addr.Addr()))
+	if res.IsErr() {
+		return nil, newSocketError(*res.Err())
+	}
+	socket := *res.OK()
+
+	if err := opts.apply(socket); err != nil {
+		socket.ResourceDrop()
+		return nil, err
+	}
+
+	if res := socket.StartConnect(instanceNetwork(), toWasiSocketAddress(addr)); res.IsErr() {
+		socket.ResourceDrop()
+		return nil, newSocketError(*res.Err())
+	}
+
+	pollable := socket.Subscribe()
+	fail := func(err error) (*Conn, error) {
+		pollable.ResourceDrop()
+		socket.ResourceDrop()
+		return nil, err
+	}
+
+	for {
+		res := socket.FinishConnect()
+		if res.IsOK() {
+			pollable.ResourceDrop()
+			pair := *res.OK()
+			return newConn(socket, pair.F0, pair.F1), nil
+		}
+
+		if code := *res.Err(); code != network.ErrorCodeWouldBlock {
+			return fail(newSocketError(code))
+		}
+
+		// NOTE: a canceled context wakes the wait, e.g. once gRPC closes a
+		// connection that is still connecting
+		if err := waitDeadline(pollable, deadline, ctx.Done()); err != nil {
+			return fail(err)
+		}
+		if err := ctx.Err(); err != nil {
+			return fail(err)
+		}
+	}
+}
+
+// resolve returns the addresses for the host:port pair, looking up names
+// through `wasi:sockets/ip-name-lookup`.
+func resolve(ctx context.Context, network, address string, deadline time.Time) ([]netip.AddrPort, error) {
+	host, service, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+
+	port, err := strconv.ParseUint(service, 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port %q", service)
+	}
+
+	if ip, err := netip.ParseAddr(host); err == nil {
+		return []netip.AddrPort{netip.AddrPortFrom(ip, uint16(port))}, nil
+	}
+
+	ips, err := lookupIP(ctx, host, deadline)
+	if err != nil {
+		return nil, err
+	}
+
+	var addrs []netip.AddrPort
+	for _, ip := range ips {
+		switch {
+		case network == "tcp4" && !ip.Is4():
+		case network == "tcp6" && !ip.Is6():
+		default:
+			addrs = append(addrs, netip.AddrPortFrom(ip, uint16(port)))
+		}
+	}
+	if len(addrs) == 0 {
+		return nil, &net.DNSError{Err: "no suitable address found", Name: host, IsNotFound: true}
+	}
+	return addrs, nil
+}
+
+// LookupHost resolves host through `wasi:sockets/ip-name-lookup`.
+func LookupHost(ctx context.Context, host string) ([]string, error) {
+	ips, err := lookupIP(ctx, host, contextDeadline(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	addrs := make([]string, 0, len(ips))
+	for _, ip := range ips {
+		addrs = append(addrs, ip.String())
+	}
+	return addrs, nil
+}
+
+func lookupIP(ctx context.Context, host string, deadline time.Time) ([]netip.Addr, error) {
+	dnsError := func(err error) error {
+		dnsErr := &net.DNSError{Err: err.Error(), Name: host}
+		if code, ok := AsErrorCode(err); ok {
+			dnsErr.IsNotFound = code == network.ErrorCodeNameUnresolvable
+			dnsErr.IsTemporary = code == network.ErrorCodeTemporaryResolverFailure
+		}
+		if errors.Is(err, os.ErrDeadlineExceeded) {
+			dnsErr.IsTimeout = true
+		}
+		return dnsErr
+	}
+
+	res := ipnamelookup.ResolveAddresses(instanceNetwork(), host)
+	if res.IsErr() {
+		return nil, dnsError(newSocketError(*res.Err()))
+	}
+	stream := *res.OK()
+	defer stream.ResourceDrop()
+
+	pollable := stream.Subscribe()
+	defer pollable.ResourceDrop()
+
+	var ips []netip.Addr
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		res := stream.ResolveNextAddress()
+		if res.IsErr() {
+			code := *res.Err()
+			if code != network.ErrorCodeWouldBlock {
+				return nil, dnsError(newSocketError(code))
+			}
+
+			if err := waitDeadline(pollable, deadline, ctx.Done()); err != nil {
+				return nil, dnsError(err)
+			}
+			continue
+		}
+
+		next := res.OK()
+		if next.None() {
+			return ips, nil
+		}
+		ips = append(ips, toNetipAddr(*next.Some()))
+	}
+}
@@ -0,0 +1,81 @@
+package wasinet
+
+import (
+	"errors"
+	"syscall"
+
+	"go.wasmcloud.dev/component/gen/wasi/sockets/network"
+)
+
+// ErrorCode is a `wasi:sockets/network.error-code` returned by the host.
+type ErrorCode = network.ErrorCode
+
+// socketError adapts a `wasi:sockets/network.error-code` to the error
+// interfaces expected by the net package callers.
+type socketError struct {
+	code ErrorCode
+}
+
+func newSocketError(code ErrorCode) error {
+	return &socketError{code: code}
+}
+
+func (e *socketError) Error() string {
+	return e.code.String()
+}
+
+func (e *socketError) Timeout() bool {
+	return e.code == network.ErrorCodeTimeout
+}
+
+func (e *socketError) Temporary() bool {
+	switch e.code {
+	case network.ErrorCodeTimeout, network.ErrorCodeWouldBlock, network.ErrorCodeTemporaryResolverFailure:
+		return true
+	default:
+		return false
+	}
+}
+
+// Is maps host error codes to their closest syscall equivalent so callers can
+// keep using errors.Is(err, syscall.ECONNREFUSED) and friends.
+func (e *socketError) Is(target error) bool {
+	errno, ok := target.(syscall.Errno)
+	if !ok {
+		return false
+	}
+
+	switch e.code {
+	case network.ErrorCodeAccessDenied:
+		return errno == syscall.EACCES || errno == syscall.EPERM
+	case network.ErrorCodeNotSupported:
+		return errno == syscall.EOPNOTSUPP
+	case network.ErrorCodeInvalidArgument:
+		return errno == syscall.EINVAL
+	case network.ErrorCodeOutOfMemory:
+		return errno == syscall.ENOMEM
+	case network.ErrorCodeTimeout:
+		return errno == syscall.ETIMEDOUT
+	case network.ErrorCodeAddressInUse:
+		return errno == syscall.EADDRINUSE
+	case network.ErrorCodeRemoteUnreachable:
+		return errno == syscall.EHOSTUNREACH
+	case network.ErrorCodeConnectionRefused:
+		return errno == syscall.ECONNREFUSED
+	case network.ErrorCodeConnectionReset:
+		return errno == syscall.ECONNRESET
+	case network.ErrorCodeConnectionAborted:
+		return errno == syscall.ECONNABORTED
+	default:
+		return false
+	}
+}
+
+// AsErrorCode extracts the host error code from err, if any.
+func AsErrorCode(err error) (ErrorCode, bool) {
+	var serr *socketError
+	if errors.As(err, &serr) {
+		return serr.code, true
+	}
+	return 0, false
+}
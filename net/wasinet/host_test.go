@@ -0,0 +1,32 @@
+//go:build !wasm
+
+package wasinet
+
+import (
+	"net/netip"
+
+	"go.wasmcloud.dev/component/gen/wasi/io/poll"
+	"go.wasmcloud.dev/component/gen/wasi/sockets/tcp"
+	"go.wasmcloud.dev/component/internal/fakehost"
+)
+
+// fakeSocket is the socket of the listeners returned by fakeListener.
+var fakeSocket = &fakehost.TCPListener{Addr: netip.MustParseAddrPort("127.0.0.1:8080")}
+
+// fakeListener returns a Listener over fakeSocket.
+func fakeListener() *Listener {
+	fakehost.Lock()
+	defer fakehost.Unlock()
+
+	return &Listener{
+		socket:   tcp.TCPSocket(fakehost.Add(fakeSocket)),
+		pollable: poll.Pollable(fakehost.Add(fakeSocket.Subscribe())),
+		addr:     toTCPAddr(fakeSocket.Addr),
+		wake:     make(chan struct{}),
+	}
+}
+
+// fakeAccept queues a connection sending input, setting eof closes its input stream.
+func fakeAccept(input string, eof bool) *fakehost.TCPConn {
+	return fakeSocket.Connect(netip.MustParseAddrPort("127.0.0.1:50000"), input, eof)
+}
@@ -0,0 +1,207 @@
+package wasinet
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"sync"
+
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"go.wasmcloud.dev/component/gen/wasi/io/poll"
+	"go.wasmcloud.dev/component/gen/wasi/sockets/network"
+	"go.wasmcloud.dev/component/gen/wasi/sockets/tcp"
+	tcpcreatesocket "go.wasmcloud.dev/component/gen/wasi/sockets/tcp-create-socket"
+	"go.wasmcloud.dev/component/io/wasipoll"
+)
+
+var _ net.Listener = (*Listener)(nil)
+
+// ListenConfig contains options for listening to an address over `wasi:sockets`.
+type ListenConfig struct {
+	// Backlog is a hint for the maximum length of the pending connections
+	// queue. If zero, the host default is used.
+	Backlog int
+
+	// SocketOptions are applied to the listening socket, connections
+	// returned by Accept inherit them.
+	SocketOptions
+}
+
+// Listen announces on the local network address.
+// Only "tcp", "tcp4" and "tcp6" networks are supported.
+func Listen(network, address string) (net.Listener, error) {
+	var lc ListenConfig
+	return lc.Listen(context.Background(), network, address)
+}
+
+// Listen announces on the local network address.
+func (lc *ListenConfig) Listen(ctx context.Context, network, address string) (net.Listener, error) {
+	opError := func(err error) error {
+		return &net.OpError{Op: "listen", Net: network, Err: err}
+	}
+
+	var unspecified netip.Addr
+	switch network {
+	case "tcp", "tcp4":
+		unspecified = netip.IPv4Unspecified()
+	case "tcp6":
+		unspecified = netip.IPv6Unspecified()
+	default:
+		return nil, opError(net.UnknownNetworkError(network))
+	}
+
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, opError(err)
+	}
+	if host == "" {
+		address = net.JoinHostPort(unspecified.String(), port)
+	}
+
+	addrs, err := resolve(ctx, network, address, contextDeadline(ctx))
+	if err != nil {
+		return nil, opError(err)
+	}
+	addr := addrs[0]
+
+	l, err := listen(addr, lc)
+	if err != nil {
+		return nil, &net.OpError{Op: "listen", Net: network, Addr: toTCPAddr(addr), Err: err}
+	}
+	return l, nil
+}
+
+func listen(addr netip.AddrPort, lc *ListenConfig) (*Listener, error) {
+	res := tcpcreatesocket.CreateTCPSocket(addressFamily(addr.Addr()))
+	if res.IsErr() {
+		return nil, newSocketError(*res.Err())
+	}
+	socket := *res.OK()
+
+	pollable := socket.Subscribe()
+	fail := func(err error) (*Listener, error) {
+		pollable.ResourceDrop()
+		socket.ResourceDrop()
+		return nil, err
+	}
+
+	if err := lc.SocketOptions.apply(socket); err != nil {
+		return fail(err)
+	}
+
+	if res := socket.StartBind(instanceNetwork(), toWasiSocketAddress(addr)); res.IsErr() {
+		return fail(newSocketError(*res.Err()))
+	}
+	if err := finish(pollable, socket.FinishBind); err != nil {
+		return fail(err)
+	}
+
+	if lc.Backlog > 0 {
+		if res := socket.SetListenBacklogSize(uint64(lc.Backlog)); res.IsErr() {
+			return fail(newSocketError(*res.Err()))
+		}
+	}
+
+	if res := socket.StartListen(); res.IsErr() {
+		return fail(newSocketError(*res.Err()))
+	}
+	if err := finish(pollable, socket.FinishListen); err != nil {
+		return fail(err)
+	}
+
+	l := &Listener{
+		socket:   socket,
+		pollable: pollable,
+		addr:     toTCPAddr(addr),
+		wake:     make(chan struct{}),
+	}
+	if res := socket.LocalAddress(); res.IsOK() {
+		l.addr = toTCPAddr(fromWasiSocketAddress(*res.OK()))
+	}
+	return l, nil
+}
+
+// finish drives a `wasi:sockets` start/finish operation pair to completion.
+func finish(pollable poll.Pollable, fn func() cm.Result[network.ErrorCode, struct{}, network.ErrorCode]) error {
+	for {
+		res := fn()
+		if res.IsOK() {
+			return nil
+		}
+		if code := *res.Err(); code != network.ErrorCodeWouldBlock {
+			return newSocketError(code)
+		}
+		wasipoll.Wait(pollable)
+	}
+}
+
+// Listener is a net.Listener backed by a `wasi:sockets/tcp.tcp-socket`.
+type Listener struct {
+	socket   tcp.TCPSocket
+	pollable poll.Pollable
+	addr     net.Addr
+
+	acceptLock sync.Mutex
+
+	// wakeLock guards wake & closing, waking pending accepts on Close
+	wakeLock sync.Mutex
+	wake     chan struct{}
+	closing  bool
+
+	closeOnce sync.Once
+}
+
+// Accept waits for and returns the next connection to the listener.
+func (l *Listener) Accept() (net.Conn, error) {
+	l.acceptLock.Lock()
+	defer l.acceptLock.Unlock()
+
+	for {
+		l.wakeLock.Lock()
+		wake, closing := l.wake, l.closing
+		l.wakeLock.Unlock()
+
+		if closing {
+			return nil, l.opError(net.ErrClosed)
+		}
+
+		res := l.socket.Accept()
+		if res.IsOK() {
+			accepted := *res.OK()
+			return newConn(accepted.F0, accepted.F1, accepted.F2), nil
+		}
+
+		if code := *res.Err(); code != network.ErrorCodeWouldBlock {
+			return nil, l.opError(newSocketError(code))
+		}
+
+		wasipoll.WaitCancel(wake, l.pollable)
+	}
+}
+
+// Close stops listening, pending Accept calls fail with net.ErrClosed.
+// Already accepted connections are not closed.
+func (l *Listener) Close() error {
+	l.closeOnce.Do(func() {
+		l.wakeLock.Lock()
+		l.closing = true
+		close(l.wake)
+		l.wakeLock.Unlock()
+
+		l.acceptLock.Lock()
+		defer l.acceptLock.Unlock()
+
+		l.pollable.ResourceDrop()
+		l.socket.ResourceDrop()
+	})
+	return nil
+}
+
+// Addr returns the listener's network address.
+func (l *Listener) Addr() net.Addr {
+	return l.addr
+}
+
+func (l *Listener) opError(err error) error {
+	return &net.OpError{Op: "accept", Net: "tcp", Addr: l.addr, Err: err}
+}
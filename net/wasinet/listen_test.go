@@ -0,0 +1,82 @@
+//go:build !wasm
+
+package wasinet
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type connStates struct {
+	sync.Mutex
+	states []http.ConnState
+}
+
+func (s *connStates) track(_ net.Conn, state http.ConnState) {
+	s.Lock()
+	defer s.Unlock()
+	s.states = append(s.states, state)
+}
+
+func (s *connStates) get() []http.ConnState {
+	s.Lock()
+	defer s.Unlock()
+	return slices.Clone(s.states)
+}
+
+func TestServe(t *testing.T) {
+	l := fakeListener()
+
+	var states connStates
+	srv := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("hello " + r.URL.Path))
+		}),
+		IdleTimeout: 50 * time.Millisecond,
+		ConnState:   states.track,
+	}
+
+	served := make(chan error)
+	go func() {
+		served <- srv.Serve(l)
+	}()
+
+	// keep-alive connection, closed by the server once idle
+	conn := fakeAccept("GET /world HTTP/1.1\r\nHost: example.com\r\n\r\n", false)
+
+	want := []http.ConnState{http.StateNew, http.StateActive, http.StateIdle, http.StateClosed}
+	for start := time.Now(); !slices.Equal(want, states.get()); {
+		if time.Since(start) > time.Second {
+			t.Fatalf("expected states: %v, got: %v", want, states.get())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	out := conn.Output()
+	if !strings.HasPrefix(out, "HTTP/1.1 200 OK\r\n") || !strings.HasSuffix(out, "hello /world") {
+		t.Errorf("unexpected response: %q", out)
+	}
+
+	if err := srv.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-served:
+		if !errors.Is(err, http.ErrServerClosed) {
+			t.Errorf("expected: %v, got: %v", http.ErrServerClosed, err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Serve did not return after Close")
+	}
+
+	if _, err := l.Accept(); !errors.Is(err, net.ErrClosed) {
+		t.Errorf("expected: %v, got: %v", net.ErrClosed, err)
+	}
+}
@@ -0,0 +1,210 @@
+package wasinet
+
+import (
+	"errors"
+	"net"
+	"time"
+
+	monotonicclock "go.wasmcloud.dev/component/gen/wasi/clocks/monotonic-clock"
+	"go.wasmcloud.dev/component/gen/wasi/sockets/network"
+	"go.wasmcloud.dev/component/gen/wasi/sockets/tcp"
+)
+
+// NOTE: same default as stdlib net.Dialer
+const defaultKeepAlive = 15 * time.Second
+
+// SocketOptions are the `wasi:sockets/tcp` options applied to new sockets.
+type SocketOptions struct {
+	// KeepAlive specifies the keep-alive period for network connections.
+	// If zero, keep-alives are enabled with a default period of 15 seconds.
+	// If negative, keep-alives are disabled.
+	KeepAlive time.Duration
+
+	// KeepAliveCount is the number of unacknowledged probes sent before the
+	// connection is considered dead. If zero, the host default is used.
+	KeepAliveCount int
+
+	// ReceiveBufferSize and SendBufferSize are hints for the size of the host
+	// socket buffers in bytes. If zero, the host defaults are used.
+	ReceiveBufferSize int
+	SendBufferSize    int
+}
+
+func (o *SocketOptions) apply(socket tcp.TCPSocket) error {
+	switch {
+	case o.KeepAlive < 0:
+		if err := setKeepAlive(socket, false); err != nil {
+			return err
+		}
+	case o.KeepAlive == 0:
+		// NOTE: the default is best-effort, hosts are free to not support keep-alive
+		if setKeepAlive(socket, true) == nil {
+			_ = setKeepAlivePeriod(socket, defaultKeepAlive)
+		}
+	default:
+		if err := setKeepAlive(socket, true); err != nil {
+			return err
+		}
+		if err := setKeepAlivePeriod(socket, o.KeepAlive); err != nil {
+			return err
+		}
+	}
+
+	if o.KeepAliveCount > 0 {
+		if res := socket.SetKeepAliveCount(uint32(o.KeepAliveCount)); res.IsErr() {
+			return newSocketError(*res.Err())
+		}
+	}
+	if o.ReceiveBufferSize > 0 {
+		if err := setReceiveBufferSize(socket, o.ReceiveBufferSize); err != nil {
+			return err
+		}
+	}
+	if o.SendBufferSize > 0 {
+		if err := setSendBufferSize(socket, o.SendBufferSize); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func setKeepAlive(socket tcp.TCPSocket, enabled bool) error {
+	if res := socket.SetKeepAliveEnabled(enabled); res.IsErr() {
+		return newSocketError(*res.Err())
+	}
+	return nil
+}
+
+func setKeepAlivePeriod(socket tcp.TCPSocket, d time.Duration) error {
+	if d <= 0 {
+		return newSocketError(network.ErrorCodeInvalidArgument)
+	}
+	// NOTE: matches the stdlib behavior on Linux, setting both TCP_KEEPIDLE and TCP_KEEPINTVL
+	if res := socket.SetKeepAliveIdleTime(monotonicclock.Duration(d)); res.IsErr() {
+		return newSocketError(*res.Err())
+	}
+	if res := socket.SetKeepAliveInterval(monotonicclock.Duration(d)); res.IsErr() {
+		return newSocketError(*res.Err())
+	}
+	return nil
+}
+
+func setReceiveBufferSize(socket tcp.TCPSocket, bytes int) error {
+	if bytes <= 0 {
+		return newSocketError(network.ErrorCodeInvalidArgument)
+	}
+	if res := socket.SetReceiveBufferSize(uint64(bytes)); res.IsErr() {
+		return newSocketError(*res.Err())
+	}
+	return nil
+}
+
+func setSendBufferSize(socket tcp.TCPSocket, bytes int) error {
+	if bytes <= 0 {
+		return newSocketError(network.ErrorCodeInvalidArgument)
+	}
+	if res := socket.SetSendBufferSize(uint64(bytes)); res.IsErr() {
+		return newSocketError(*res.Err())
+	}
+	return nil
+}
+
+// errNoDelayUnsupported is returned when disabling TCP_NODELAY, which `wasi:sockets` does not expose.
+var errNoDelayUnsupported = errors.New("disabling no-delay is not supported by wasi:sockets")
+
+// control runs f on the socket of an open conn, failing like net.TCPConn with
+// net.ErrClosed once the conn is closed.
+func (c *Conn) control(op string, f func(socket tcp.TCPSocket) error) error {
+	c.socketLock.RLock()
+	defer c.socketLock.RUnlock()
+
+	if c.closed {
+		return c.opError(op, net.ErrClosed)
+	}
+	if err := f(c.socket); err != nil {
+		return c.opError(op, err)
+	}
+	return nil
+}
+
+// SetKeepAlive sets whether the host should send keep-alive messages on the connection.
+func (c *Conn) SetKeepAlive(keepalive bool) error {
+	return c.control("set", func(socket tcp.TCPSocket) error {
+		return setKeepAlive(socket, keepalive)
+	})
+}
+
+// SetKeepAlivePeriod sets the idle duration before the first keep-alive probe
+// and the interval between subsequent probes.
+func (c *Conn) SetKeepAlivePeriod(d time.Duration) error {
+	return c.control("set", func(socket tcp.TCPSocket) error {
+		return setKeepAlivePeriod(socket, d)
+	})
+}
+
+// SetKeepAliveCount sets the number of unacknowledged probes sent before the
+// connection is considered dead.
+func (c *Conn) SetKeepAliveCount(count int) error {
+	return c.control("set", func(socket tcp.TCPSocket) error {
+		if count <= 0 {
+			return newSocketError(network.ErrorCodeInvalidArgument)
+		}
+		if res := socket.SetKeepAliveCount(uint32(count)); res.IsErr() {
+			return newSocketError(*res.Err())
+		}
+		return nil
+	})
+}
+
+// SetNoDelay exists for compatibility with net.TCPConn.
+// `wasi:sockets` does not expose TCP_NODELAY, writes are always flushed to the
+// host immediately, so enabling is a no-op and disabling returns an error.
+func (c *Conn) SetNoDelay(noDelay bool) error {
+	return c.control("set", func(tcp.TCPSocket) error {
+		if !noDelay {
+			return errNoDelayUnsupported
+		}
+		return nil
+	})
+}
+
+// SetReadBuffer sets the size of the host receive buffer.
+func (c *Conn) SetReadBuffer(bytes int) error {
+	return c.control("set", func(socket tcp.TCPSocket) error {
+		return setReceiveBufferSize(socket, bytes)
+	})
+}
+
+// SetWriteBuffer sets the size of the host send buffer.
+func (c *Conn) SetWriteBuffer(bytes int) error {
+	return c.control("set", func(socket tcp.TCPSocket) error {
+		return setSendBufferSize(socket, bytes)
+	})
+}
+
+// CloseRead shuts down the reading side of the connection.
+func (c *Conn) CloseRead() error {
+	return c.control("close", func(socket tcp.TCPSocket) error {
+		if res := socket.Shutdown(tcp.ShutdownTypeReceive); res.IsErr() {
+			return newSocketError(*res.Err())
+		}
+		return nil
+	})
+}
+
+// CloseWrite shuts down the writing side of the connection.
+func (c *Conn) CloseWrite() error {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	if c.closed {
+		return c.opError("close", net.ErrClosed)
+	}
+
+	c.output.BlockingFlush()
+	if res := c.socket.Shutdown(tcp.ShutdownTypeSend); res.IsErr() {
+		return c.opError("close", newSocketError(*res.Err()))
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+//go:build !wasm
+
+package wasinet
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestOptionsClosed(t *testing.T) {
+	l := fakeListener()
+	defer l.Close()
+
+	fakeAccept("", true)
+	nc, err := l.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := nc.(*Conn)
+
+	tests := map[string]func() error{
+		"SetKeepAlive":       func() error { return c.SetKeepAlive(true) },
+		"SetKeepAlivePeriod": func() error { return c.SetKeepAlivePeriod(time.Second) },
+		"SetKeepAliveCount":  func() error { return c.SetKeepAliveCount(3) },
+		"SetNoDelay":         func() error { return c.SetNoDelay(true) },
+		"SetReadBuffer":      func() error { return c.SetReadBuffer(1024) },
+		"SetWriteBuffer":     func() error { return c.SetWriteBuffer(1024) },
+		"CloseRead":          c.CloseRead,
+		"CloseWrite":         c.CloseWrite,
+	}
+	for name, f := range tests {
+		if err := f(); err != nil {
+			t.Errorf("%s: unexpected error on an open conn: %v", name, err)
+		}
+	}
+
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+	for name, f := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := f()
+			var opErr *net.OpError
+			if !errors.As(err, &opErr) {
+				t.Fatalf("expected a *net.OpError, got: %#v", err)
+			}
+			if !errors.Is(err, net.ErrClosed) {
+				t.Errorf("expected: %v, got: %v", net.ErrClosed, err)
+			}
+		})
+	}
+}
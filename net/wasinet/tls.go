@@ -0,0 +1,174 @@
+package wasinet
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"go.wasmcloud.dev/component/gen/wasi/config/runtime"
+	"go.wasmcloud.dev/component/rand/wasirand"
+)
+
+// RootCAsConfigKey is the `wasi:config/runtime` key holding a PEM encoded CA
+// bundle used to verify TLS servers.
+const RootCAsConfigKey = "tls_ca_bundle"
+
+var (
+	rootCAsOnce sync.Once
+	rootCAs     *x509.CertPool
+	rootCAsErr  error
+)
+
+// RootCAs returns the certificate pool used to verify TLS servers when the
+// tls.Config does not specify one.
+//
+// The host provided bundle at [RootCAsConfigKey] takes precedence. Otherwise
+// the system pool is used, which includes the roots registered through
+// x509.SetFallbackRoots (e.g. by importing "golang.org/x/crypto/x509roots/fallback"
+// to embed the Mozilla roots into the component).
+func RootCAs() (*x509.CertPool, error) {
+	rootCAsOnce.Do(func() {
+		rootCAs, rootCAsErr = loadRootCAs()
+	})
+	return rootCAs, rootCAsErr
+}
+
+func loadRootCAs() (*x509.CertPool, error) {
+	res := runtime.Get(RootCAsConfigKey)
+	if res.IsOK() {
+		if bundle := res.OK(); !bundle.None() {
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM([]byte(*bundle.Some())) {
+				return nil, errors.New("no certificates found in " + RootCAsConfigKey)
+			}
+			return pool, nil
+		}
+	}
+
+	return x509.SystemCertPool()
+}
+
+// TLSDialer dials TLS connections over `wasi:sockets`.
+type TLSDialer struct {
+	// NetDialer is the Dialer used for the underlying TCP connection.
+	// If nil, a zero Dialer is used.
+	NetDialer *Dialer
+
+	// Config is the TLS configuration for new connections.
+	// A nil configuration is equivalent to the zero configuration.
+	Config *tls.Config
+}
+
+// DialTLS connects to the address on the named network and performs a TLS
+// client handshake.
+func DialTLS(network, address string, config *tls.Config) (*tls.Conn, error) {
+	d := TLSDialer{Config: config}
+	return d.dial(context.Background(), network, address)
+}
+
+// Dial connects to the address on the named network and performs a TLS
+// client handshake.
+func (d *TLSDialer) Dial(network, address string) (net.Conn, error) {
+	return d.DialContext(context.Background(), network, address)
+}
+
+// DialContext connects to the address on the named network and performs a TLS
+// client handshake. The Dialer timeout and the context deadline cover both the
+// connect and the handshake.
+func (d *TLSDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	conn, err := d.dial(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+	return conn, nil
+}
+
+func (d *TLSDialer) dial(ctx context.Context, network, address string) (*tls.Conn, error) {
+	netDialer := d.NetDialer
+	if netDialer == nil {
+		netDialer = &Dialer{}
+	}
+	deadline := netDialer.deadline(ctx)
+
+	config, err := clientConfig(d.Config, address)
+	if err != nil {
+		return nil, err
+	}
+
+	rawConn, err := netDialer.DialContext(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTE: stdlib timers don't fire while the component is blocked in the host,
+	// the handshake deadline is enforced through the socket streams instead.
+	if !deadline.IsZero() {
+		if err := rawConn.SetDeadline(deadline); err != nil {
+			rawConn.Close()
+			return nil, err
+		}
+	}
+
+	conn := tls.Client(rawConn, config)
+	if err := conn.HandshakeContext(ctx); err != nil {
+		rawConn.Close()
+		return nil, err
+	}
+
+	if !deadline.IsZero() {
+		if err := rawConn.SetDeadline(time.Time{}); err != nil {
+			rawConn.Close()
+			return nil, err
+		}
+	}
+
+	return conn, nil
+}
+
+// TLSClientConfig returns a copy of config with the defaults needed for TLS
+// to work in a component: RootCAs from [RootCAs] and Rand from `wasi:random`.
+// It is meant for libraries performing the handshake themselves, e.g.
+// credentials.NewTLS in gRPC. A nil config is equivalent to the zero configuration.
+func TLSClientConfig(config *tls.Config) (*tls.Config, error) {
+	if config == nil {
+		config = &tls.Config{}
+	} else {
+		config = config.Clone()
+	}
+
+	if config.RootCAs == nil && !config.InsecureSkipVerify {
+		pool, err := RootCAs()
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = pool
+	}
+
+	if config.Rand == nil {
+		config.Rand = wasirand.Reader
+	}
+
+	return config, nil
+}
+
+// clientConfig is TLSClientConfig, with ServerName defaulting to the dialed host.
+func clientConfig(config *tls.Config, address string) (*tls.Config, error) {
+	config, err := TLSClientConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.ServerName == "" {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, err
+		}
+		config.ServerName = host
+	}
+
+	return config, nil
+}
@@ -0,0 +1,48 @@
+package wasinet
+
+import (
+	"crypto/tls"
+	"testing"
+)
+
+func TestClientConfigServerName(t *testing.T) {
+	tt := map[string]struct {
+		address    string
+		config     *tls.Config
+		serverName string
+	}{
+		"from address": {
+			address:    "example.com:443",
+			config:     &tls.Config{InsecureSkipVerify: true},
+			serverName: "example.com",
+		},
+		"ipv6 literal": {
+			address:    "[::1]:443",
+			config:     &tls.Config{InsecureSkipVerify: true},
+			serverName: "::1",
+		},
+		"explicit": {
+			address:    "10.0.0.1:443",
+			config:     &tls.Config{InsecureSkipVerify: true, ServerName: "db.internal"},
+			serverName: "db.internal",
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			config, err := clientConfig(tc.config, tc.address)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if config.ServerName != tc.serverName {
+				t.Errorf("expected: %v, got: %v", tc.serverName, config.ServerName)
+			}
+			if config == tc.config {
+				t.Errorf("expected config to be cloned")
+			}
+			if config.Rand == nil {
+				t.Errorf("expected wasi:random backed Rand")
+			}
+		})
+	}
+}
@@ -0,0 +1,386 @@
+package websocket
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"go.wasmcloud.dev/component/rand/wasirand"
+)
+
+// MessageType is the type of a data message.
+type MessageType int
+
+const (
+	TextMessage   MessageType = 1
+	BinaryMessage MessageType = 2
+)
+
+// Close status codes of RFC 6455 section 7.4.1.
+const (
+	StatusNormalClosure    = 1000
+	StatusGoingAway        = 1001
+	StatusProtocolError    = 1002
+	StatusUnsupportedData  = 1003
+	StatusNoStatusReceived = 1005
+	StatusInvalidPayload   = 1007
+	StatusPolicyViolation  = 1008
+	StatusMessageTooBig    = 1009
+	StatusInternalError    = 1011
+)
+
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opBinary       = 0x2
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xa
+)
+
+const (
+	defaultReadLimit = 32 << 20
+	// maxControlPayload is the size limit of the payload of control frames
+	maxControlPayload = 125
+	// closeTimeout bounds the wait for the close frame of the server
+	closeTimeout = 5 * time.Second
+)
+
+// ErrCloseSent is returned by the writes following a close frame.
+var ErrCloseSent = errors.New("websocket: close sent")
+
+// CloseError is returned by ReadMessage once the server closed the
+// connection with a close frame.
+type CloseError struct {
+	// Code is the status code of the close frame, StatusNoStatusReceived if
+	// it had none.
+	Code   int
+	Reason string
+}
+
+func (e *CloseError) Error() string {
+	if e.Reason == "" {
+		return "websocket: closed with status " + strconv.Itoa(e.Code)
+	}
+	return "websocket: closed with status " + strconv.Itoa(e.Code) + ": " + e.Reason
+}
+
+// failure is a violation of the protocol by the server, which closes the
+// connection with code.
+type failure struct {
+	code   int
+	reason string
+}
+
+func (f *failure) Error() string {
+	return "websocket: " + f.reason
+}
+
+// Conn is a client WebSocket connection. Messages are read by a single
+// goroutine with ReadMessage, which answers pings and close frames as well.
+// Writes may be called concurrently with it.
+type Conn struct {
+	conn        net.Conn
+	br          *bufio.Reader
+	subprotocol string
+	readLimit   int64
+
+	// readMu is held by ReadMessage, Close drains the connection itself
+	// when it is not
+	readMu sync.Mutex
+	// readErr ends the reads, it is returned by every later ReadMessage
+	readErr error
+
+	writeMu   sync.Mutex
+	closeSent bool
+}
+
+// Subprotocol returns the subprotocol selected by the server, if any.
+func (c *Conn) Subprotocol() string {
+	return c.subprotocol
+}
+
+// NetConn returns the underlying connection, e.g. to set deadlines.
+func (c *Conn) NetConn() net.Conn {
+	return c.conn
+}
+
+// ReadMessage returns the next data message, reassembled from its fragments.
+// Pings are answered with a pong while reading. A close frame of the server
+// is answered, the connection closed, and a *CloseError returned.
+func (c *Conn) ReadMessage() (MessageType, []byte, error) {
+	c.readMu.Lock()
+	defer c.readMu.Unlock()
+
+	if c.readErr != nil {
+		return 0, nil, c.readErr
+	}
+	typ, msg, err := c.readMessage()
+	if err != nil {
+		c.readErr = err
+	}
+	return typ, msg, err
+}
+
+func (c *Conn) readMessage() (MessageType, []byte, error) {
+	var typ MessageType
+	var msg []byte
+	for {
+		fin, opcode, payload, err := c.readFrame(int64(len(msg)))
+		if err != nil {
+			return 0, nil, c.fail(err)
+		}
+
+		switch opcode {
+		case opPing:
+			if err := c.writeFrame(opPong, payload); err != nil && !errors.Is(err, ErrCloseSent) {
+				return 0, nil, c.fail(err)
+			}
+			continue
+		case opPong:
+			continue
+		case opClose:
+			return 0, nil, c.closeReceived(payload)
+		case opText, opBinary:
+			if typ != 0 {
+				return 0, nil, c.fail(&failure{StatusProtocolError, "message started before the end of the previous one"})
+			}
+			typ = MessageType(opcode)
+		case opContinuation:
+			if typ == 0 {
+				return 0, nil, c.fail(&failure{StatusProtocolError, "continuation frame without a message"})
+			}
+		}
+
+		msg = append(msg, payload...)
+		if !fin {
+			continue
+		}
+		if typ == TextMessage && !utf8.Valid(msg) {
+			return 0, nil, c.fail(&failure{StatusInvalidPayload, "text message is not valid UTF-8"})
+		}
+		if msg == nil {
+			msg = []byte{}
+		}
+		return typ, msg, nil
+	}
+}
+
+// readFrame reads a frame of the server, read is the size of the fragments
+// of the current message read so far.
+func (c *Conn) readFrame(read int64) (fin bool, opcode byte, payload []byte, err error) {
+	var head [2]byte
+	if _, err := io.ReadFull(c.br, head[:]); err != nil {
+		return false, 0, nil, err
+	}
+	fin, opcode = head[0]&0x80 != 0, head[0]&0x0f
+	if head[0]&0x70 != 0 {
+		return false, 0, nil, &failure{StatusProtocolError, "reserved bits set without extension"}
+	}
+	switch opcode {
+	case opContinuation, opText, opBinary, opClose, opPing, opPong:
+	default:
+		return false, 0, nil, &failure{StatusProtocolError, fmt.Sprintf("unknown opcode %#x", opcode)}
+	}
+	if head[1]&0x80 != 0 {
+		return false, 0, nil, &failure{StatusProtocolError, "masked frame from the server"}
+	}
+
+	n := uint64(head[1] & 0x7f)
+	switch n {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		n = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		n = binary.BigEndian.Uint64(ext[:])
+	}
+
+	if opcode >= opClose {
+		if !fin || n > maxControlPayload {
+			return false, 0, nil, &failure{StatusProtocolError, "fragmented or oversized control frame"}
+		}
+	} else if n > uint64(c.readLimit-read) {
+		return false, 0, nil, &failure{StatusMessageTooBig, fmt.Sprintf("message larger than %d bytes", c.readLimit)}
+	}
+
+	payload = make([]byte, n)
+	if _, err := io.ReadFull(c.br, payload); err != nil {
+		return false, 0, nil, err
+	}
+	return fin, opcode, payload, nil
+}
+
+// fail closes the connection after a read error, with a close frame if the
+// server violated the protocol.
+func (c *Conn) fail(err error) error {
+	var f *failure
+	if errors.As(err, &f) {
+		c.writeClose(f.code, f.reason)
+	}
+	c.conn.Close()
+	return err
+}
+
+// closeReceived answers the close frame of the server with payload, unless
+// a close frame was sent already, and closes the connection.
+func (c *Conn) closeReceived(payload []byte) error {
+	code, reason := StatusNoStatusReceived, ""
+	switch {
+	case len(payload) == 1:
+		return c.fail(&failure{StatusProtocolError, "invalid close frame payload"})
+	case len(payload) >= 2:
+		code, reason = int(binary.BigEndian.Uint16(payload)), string(payload[2:])
+		if !validCloseCode(code) || !utf8.ValidString(reason) {
+			return c.fail(&failure{StatusProtocolError, "invalid close frame payload"})
+		}
+	}
+
+	if code == StatusNoStatusReceived {
+		c.writeFrame(opClose, nil)
+	} else {
+		c.writeClose(code, "")
+	}
+	c.conn.Close()
+	return &CloseError{Code: code, Reason: reason}
+}
+
+// validCloseCode reports whether code may be sent in a close frame.
+func validCloseCode(code int) bool {
+	switch {
+	case code >= 1000 && code <= 1003, code >= 1007 && code <= 1011:
+		return true
+	default:
+		return code >= 3000 && code <= 4999
+	}
+}
+
+// WriteMessage writes data as a single frame message of typ. Text messages
+// must be valid UTF-8.
+func (c *Conn) WriteMessage(typ MessageType, data []byte) error {
+	switch typ {
+	case TextMessage:
+		if !utf8.Valid(data) {
+			return errors.New("websocket: text message is not valid UTF-8")
+		}
+	case BinaryMessage:
+	default:
+		return fmt.Errorf("websocket: unknown message type %d", typ)
+	}
+	return c.writeFrame(byte(typ), data)
+}
+
+// Ping sends a ping with data, of at most 125 bytes. The pong of the server
+// is read by ReadMessage.
+func (c *Conn) Ping(data []byte) error {
+	if len(data) > maxControlPayload {
+		return errors.New("websocket: ping payload larger than 125 bytes")
+	}
+	return c.writeFrame(opPing, data)
+}
+
+// Close closes the connection with StatusNormalClosure.
+func (c *Conn) Close() error {
+	return c.CloseWithStatus(StatusNormalClosure, "")
+}
+
+// CloseWithStatus performs the close handshake with code and reason, and
+// closes the connection. If no ReadMessage is in progress, the messages
+// still sent by the server are discarded until its close frame; otherwise
+// the pending ReadMessage returns the *CloseError of the answer. The wait
+// for the answer is bounded by 5 seconds.
+func (c *Conn) CloseWithStatus(code int, reason string) error {
+	if err := c.writeClose(code, reason); err != nil {
+		c.conn.Close()
+		if errors.Is(err, ErrCloseSent) {
+			return nil
+		}
+		return err
+	}
+	if err := c.conn.SetReadDeadline(time.Now().Add(closeTimeout)); err != nil {
+		c.conn.Close()
+		return err
+	}
+	if !c.readMu.TryLock() {
+		return nil
+	}
+	defer c.readMu.Unlock()
+
+	if c.readErr == nil {
+		for {
+			_, opcode, payload, err := c.readFrame(0)
+			if err != nil {
+				c.readErr = err
+				break
+			}
+			if opcode == opClose {
+				c.readErr = c.closeReceived(payload)
+				break
+			}
+		}
+	}
+	return c.conn.Close()
+}
+
+// writeClose sends a close frame with code and reason, truncated to fit a
+// control frame.
+func (c *Conn) writeClose(code int, reason string) error {
+	payload := binary.BigEndian.AppendUint16(nil, uint16(code))
+	payload = append(payload, reason...)
+	if len(payload) > maxControlPayload {
+		payload = payload[:maxControlPayload]
+	}
+	return c.writeFrame(opClose, payload)
+}
+
+// writeFrame writes a final frame of opcode with payload, masked as client
+// frames must be.
+func (c *Conn) writeFrame(opcode byte, payload []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	if c.closeSent {
+		return ErrCloseSent
+	}
+	if opcode == opClose {
+		c.closeSent = true
+	}
+
+	frame := make([]byte, 0, 14+len(payload))
+	frame = append(frame, 0x80|opcode)
+	switch n := len(payload); {
+	case n <= 125:
+		frame = append(frame, 0x80|byte(n))
+	case n <= 0xffff:
+		frame = binary.BigEndian.AppendUint16(append(frame, 0x80|126), uint16(n))
+	default:
+		frame = binary.BigEndian.AppendUint64(append(frame, 0x80|127), uint64(n))
+	}
+
+	var mask [4]byte
+	if _, err := wasirand.Read(mask[:]); err != nil {
+		return fmt.Errorf("failed to generate frame mask: %w", err)
+	}
+	frame = append(frame, mask[:]...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+
+	if _, err := c.conn.Write(frame); err != nil {
+		return fmt.Errorf("failed to write frame: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+package websocket
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"go.wasmcloud.dev/component/gen/wasi/sockets/network"
+	"go.wasmcloud.dev/component/net/wasinet"
+	"go.wasmcloud.dev/component/rand/wasirand"
+)
+
+// ErrSocketsUnavailable is returned by Dial when the host denies or does not
+// support `wasi:sockets`, e.g. when only `wasi:http` is available. WebSockets
+// need a raw connection, they cannot be opened through `wasi:http`.
+var ErrSocketsUnavailable = errors.New("websocket: wasi:sockets is not available on this host, WebSocket connections cannot go through wasi:http")
+
+// ErrBadHandshake is returned when the server does not accept the opening
+// handshake.
+var ErrBadHandshake = errors.New("websocket: bad handshake")
+
+// acceptGUID is appended to the key of the handshake, see RFC 6455 section 1.3.
+const acceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// Dialer contains options for opening WebSocket connections over
+// `wasi:sockets`.
+type Dialer struct {
+	// NetDialer is the Dialer of the underlying TCP connection.
+	// If nil, a zero Dialer is used.
+	NetDialer *wasinet.Dialer
+
+	// TLSConfig is the TLS configuration of wss connections, completed with
+	// wasinet.TLSClientConfig.
+	TLSConfig *tls.Config
+
+	// Header is sent with the opening handshake, e.g. Authorization or
+	// Origin.
+	Header http.Header
+
+	// Subprotocols are offered to the server in order of preference.
+	Subprotocols []string
+
+	// ReadLimit is the maximum size of a message read, larger messages close
+	// the connection. It defaults to 32 MiB.
+	ReadLimit int64
+}
+
+// Dial opens a WebSocket connection to the ws or wss url.
+func Dial(ctx context.Context, url string) (*Conn, error) {
+	var d Dialer
+	return d.Dial(ctx, url)
+}
+
+// Dial opens a WebSocket connection to the ws or wss url. The context
+// deadline covers the connect, the TLS handshake and the opening handshake.
+func (d *Dialer) Dial(ctx context.Context, rawURL string) (*Conn, error) {
+	u, err := parseURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	netDialer := d.NetDialer
+	if netDialer == nil {
+		netDialer = &wasinet.Dialer{}
+	}
+	var conn net.Conn
+	if u.Scheme == "wss" {
+		tlsDialer := &wasinet.TLSDialer{NetDialer: netDialer, Config: d.TLSConfig}
+		conn, err = tlsDialer.DialContext(ctx, "tcp", u.Host)
+	} else {
+		conn, err = netDialer.DialContext(ctx, "tcp", u.Host)
+	}
+	if err != nil {
+		if code, ok := wasinet.AsErrorCode(err); ok && (code == network.ErrorCodeAccessDenied || code == network.ErrorCodeNotSupported) {
+			return nil, fmt.Errorf("%w: %w", ErrSocketsUnavailable, err)
+		}
+		return nil, fmt.Errorf("failed to dial %s: %w", u.Host, err)
+	}
+
+	c, err := d.handshake(ctx, conn, u)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// Client performs the opening handshake of a WebSocket connection to the ws
+// or wss url over conn, which is already connected to its host, e.g. through
+// a proxy. The TLS configuration and the NetDialer of d are not used.
+func (d *Dialer) Client(ctx context.Context, conn net.Conn, rawURL string) (*Conn, error) {
+	u, err := parseURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	return d.handshake(ctx, conn, u)
+}
+
+// parseURL parses a ws or wss URL, its host including the default port.
+func parseURL(rawURL string) (*url.URL, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse url: %w", err)
+	}
+
+	var port string
+	switch u.Scheme {
+	case "ws":
+		port = "80"
+	case "wss":
+		port = "443"
+	default:
+		return nil, fmt.Errorf("websocket: unsupported url scheme %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("websocket: url %q has no host", rawURL)
+	}
+	if u.Port() == "" {
+		u.Host = net.JoinHostPort(u.Hostname(), port)
+	}
+	return u, nil
+}
+
+func (d *Dialer) handshake(ctx context.Context, conn net.Conn, u *url.URL) (*Conn, error) {
+	// NOTE: like wasinet.TLSDialer, the deadline is enforced through the
+	// socket streams
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return nil, err
+		}
+	}
+
+	var nonce [16]byte
+	if _, err := wasirand.Read(nonce[:]); err != nil {
+		return nil, fmt.Errorf("failed to generate handshake key: %w", err)
+	}
+	key := base64.StdEncoding.EncodeToString(nonce[:])
+
+	req := &http.Request{
+		Method:     http.MethodGet,
+		URL:        &url.URL{Opaque: u.RequestURI()},
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{},
+		Host:       requestHost(u),
+	}
+	for k, v := range d.Header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Sec-WebSocket-Key", key)
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	if len(d.Subprotocols) > 0 {
+		req.Header.Set("Sec-WebSocket-Protocol", strings.Join(d.Subprotocols, ", "))
+	}
+	if err := req.Write(conn); err != nil {
+		return nil, fmt.Errorf("failed to send handshake: %w", err)
+	}
+
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read handshake response: %w", err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w: unexpected status %s", ErrBadHandshake, resp.Status)
+	}
+	if !strings.EqualFold(resp.Header.Get("Upgrade"), "websocket") || !hasToken(resp.Header, "Connection", "upgrade") {
+		return nil, fmt.Errorf("%w: connection not upgraded to websocket", ErrBadHandshake)
+	}
+	if resp.Header.Get("Sec-WebSocket-Accept") != acceptKey(key) {
+		return nil, fmt.Errorf("%w: invalid Sec-WebSocket-Accept", ErrBadHandshake)
+	}
+	subprotocol := resp.Header.Get("Sec-WebSocket-Protocol")
+	if subprotocol != "" && !slices.Contains(d.Subprotocols, subprotocol) {
+		return nil, fmt.Errorf("%w: unexpected subprotocol %q", ErrBadHandshake, subprotocol)
+	}
+	if resp.Header.Get("Sec-WebSocket-Extensions") != "" {
+		return nil, fmt.Errorf("%w: unexpected extensions %q", ErrBadHandshake, resp.Header.Get("Sec-WebSocket-Extensions"))
+	}
+
+	if _, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(time.Time{}); err != nil {
+			return nil, err
+		}
+	}
+
+	readLimit := d.ReadLimit
+	if readLimit <= 0 {
+		readLimit = defaultReadLimit
+	}
+	return &Conn{conn: conn, br: br, subprotocol: subprotocol, readLimit: readLimit}, nil
+}
+
+// requestHost returns the Host header of u, without a default port.
+func requestHost(u *url.URL) string {
+	host, port, _ := net.SplitHostPort(u.Host)
+	if (u.Scheme == "ws" && port == "80") || (u.Scheme == "wss" && port == "443") {
+		if strings.Contains(host, ":") {
+			return "[" + host + "]"
+		}
+		return host
+	}
+	return u.Host
+}
+
+// hasToken reports whether the comma separated values of the header key hold
+// token, case-insensitively.
+func hasToken(header http.Header, key, token string) bool {
+	for _, v := range header.Values(key) {
+		for _, t := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(t), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func acceptKey(key string) string {
+	sum := sha1.Sum([]byte(key + acceptGUID))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,6 @@
+//go:build !wasm
+
+package websocket
+
+// stubs of the host imports, random masks are exercised with random bytes
+import _ "go.wasmcloud.dev/component/internal/fakehost"
@@ -0,0 +1,263 @@
+package websocket
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+)
+
+// server is the server end of a net.Pipe, req is the opening handshake it
+// read.
+type server struct {
+	t    *testing.T
+	conn net.Conn
+	br   *bufio.Reader
+	req  *http.Request
+}
+
+// dial connects a client to a server answering the handshake with respond,
+// which may alter the response.
+func dial(t *testing.T, d *Dialer, respond func(resp http.Header) int) (*Conn, *server, error) {
+	clientConn, serverConn := net.Pipe()
+	t.Cleanup(func() { clientConn.Close(); serverConn.Close() })
+	s := &server{t: t, conn: serverConn, br: bufio.NewReader(serverConn)}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		req, err := http.ReadRequest(s.br)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		s.req = req
+		header := http.Header{
+			"Upgrade":              {"websocket"},
+			"Connection":           {"Upgrade"},
+			"Sec-Websocket-Accept": {acceptKey(req.Header.Get("Sec-WebSocket-Key"))},
+		}
+		status := http.StatusSwitchingProtocols
+		if respond != nil {
+			status = respond(header)
+		}
+		resp := &http.Response{StatusCode: status, ProtoMajor: 1, ProtoMinor: 1, Header: header}
+		if status != http.StatusSwitchingProtocols {
+			resp.ContentLength = 0
+		}
+		resp.Write(serverConn)
+	}()
+	c, err := d.Client(context.Background(), clientConn, "ws://example.com/feed?x=1")
+	<-done
+	return c, s, err
+}
+
+// write writes an unmasked server frame.
+func (s *server) write(head byte, payload []byte) {
+	frame := []byte{head}
+	if len(payload) > 125 {
+		frame = binary.BigEndian.AppendUint16(append(frame, 126), uint16(len(payload)))
+	} else {
+		frame = append(frame, byte(len(payload)))
+	}
+	if _, err := s.conn.Write(append(frame, payload...)); err != nil {
+		s.t.Error(err)
+	}
+}
+
+// read reads a client frame, which must be masked.
+func (s *server) read() (byte, []byte) {
+	var head [2]byte
+	if _, err := io.ReadFull(s.br, head[:]); err != nil {
+		s.t.Fatal(err)
+	}
+	if head[1]&0x80 == 0 {
+		s.t.Errorf("expected a masked frame")
+	}
+	n := int(head[1] & 0x7f)
+	if n == 126 {
+		var ext [2]byte
+		io.ReadFull(s.br, ext[:])
+		n = int(binary.BigEndian.Uint16(ext[:]))
+	}
+	var mask [4]byte
+	io.ReadFull(s.br, mask[:])
+	payload := make([]byte, n)
+	io.ReadFull(s.br, payload)
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	return head[0], payload
+}
+
+func closePayload(code int, reason string) []byte {
+	return append(binary.BigEndian.AppendUint16(nil, uint16(code)), reason...)
+}
+
+func TestConn(t *testing.T) {
+	c, s, err := dial(t, &Dialer{Subprotocols: []string{"graphql-ws", "chat"}, Header: http.Header{"Origin": {"https://example.com"}}}, func(resp http.Header) int {
+		resp.Set("Sec-WebSocket-Protocol", "chat")
+		return http.StatusSwitchingProtocols
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.req.Host != "example.com" || s.req.RequestURI != "/feed?x=1" || s.req.Header.Get("Sec-WebSocket-Version") != "13" ||
+		s.req.Header.Get("Sec-WebSocket-Protocol") != "graphql-ws, chat" || s.req.Header.Get("Origin") != "https://example.com" {
+		t.Errorf("unexpected handshake request: %v %v %v", s.req.Host, s.req.RequestURI, s.req.Header)
+	}
+	if c.Subprotocol() != "chat" {
+		t.Errorf("expected: %v, got: %v", "chat", c.Subprotocol())
+	}
+
+	go func() {
+		if err := c.WriteMessage(TextMessage, []byte("hello")); err != nil {
+			t.Error(err)
+		}
+	}()
+	if op, payload := s.read(); op != 0x80|opText || string(payload) != "hello" {
+		t.Errorf("expected: %q, got: %#x %q", "hello", op, payload)
+	}
+
+	large := make([]byte, 300)
+	go func() {
+		s.write(opText, []byte("frag"))
+		s.write(0x80|opPing, []byte("are you there"))
+		if op, payload := s.read(); op != 0x80|opPong || string(payload) != "are you there" {
+			t.Errorf("expected a pong, got: %#x %q", op, payload)
+		}
+		s.write(0x80|opContinuation, []byte("mented"))
+		s.write(0x80|opBinary, large)
+		s.write(0x80|opClose, closePayload(StatusGoingAway, "restart"))
+		if op, payload := s.read(); op != 0x80|opClose || binary.BigEndian.Uint16(payload) != StatusGoingAway {
+			t.Errorf("expected the close frame to be echoed, got: %#x %q", op, payload)
+		}
+	}()
+
+	typ, msg, err := c.ReadMessage()
+	if err != nil || typ != TextMessage || string(msg) != "fragmented" {
+		t.Errorf("expected: %q, got: %v %q %v", "fragmented", typ, msg, err)
+	}
+	typ, msg, err = c.ReadMessage()
+	if err != nil || typ != BinaryMessage || len(msg) != len(large) {
+		t.Errorf("expected: %v bytes, got: %v %v %v", len(large), typ, len(msg), err)
+	}
+	var closeErr *CloseError
+	if _, _, err := c.ReadMessage(); !errors.As(err, &closeErr) || closeErr.Code != StatusGoingAway || closeErr.Reason != "restart" {
+		t.Errorf("expected: %v, got: %v", StatusGoingAway, err)
+	}
+	if err := c.WriteMessage(TextMessage, []byte("late")); !errors.Is(err, ErrCloseSent) {
+		t.Errorf("expected: %v, got: %v", ErrCloseSent, err)
+	}
+}
+
+func TestConnClose(t *testing.T) {
+	c, s, err := dial(t, &Dialer{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		if op, payload := s.read(); op != 0x80|opClose || binary.BigEndian.Uint16(payload) != StatusNormalClosure {
+			t.Errorf("expected a close frame, got: %#x %q", op, payload)
+		}
+		s.write(0x80|opText, []byte("in flight"))
+		s.write(0x80|opClose, closePayload(StatusNormalClosure, ""))
+	}()
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+	var closeErr *CloseError
+	if _, _, err := c.ReadMessage(); !errors.As(err, &closeErr) || closeErr.Code != StatusNormalClosure {
+		t.Errorf("expected: %v, got: %v", StatusNormalClosure, err)
+	}
+}
+
+func TestConnProtocolError(t *testing.T) {
+	tests := map[string]struct {
+		head     byte
+		payload  []byte
+		wantCode int
+	}{
+		"continuation without message": {head: 0x80 | opContinuation, payload: []byte("x"), wantCode: StatusProtocolError},
+		"reserved bits":                {head: 0x80 | 0x40 | opText, payload: []byte("x"), wantCode: StatusProtocolError},
+		"unknown opcode":               {head: 0x80 | 0x3, wantCode: StatusProtocolError},
+		"fragmented ping":              {head: opPing, wantCode: StatusProtocolError},
+		"invalid utf-8":                {head: 0x80 | opText, payload: []byte{0xff}, wantCode: StatusInvalidPayload},
+		"too big":                      {head: 0x80 | opBinary, payload: make([]byte, 200), wantCode: StatusMessageTooBig},
+		"invalid close code":           {head: 0x80 | opClose, payload: closePayload(1004, ""), wantCode: StatusProtocolError},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c, s, err := dial(t, &Dialer{ReadLimit: 100}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			go func() {
+				s.write(tt.head, tt.payload)
+				if op, payload := s.read(); op != 0x80|opClose || int(binary.BigEndian.Uint16(payload)) != tt.wantCode {
+					t.Errorf("expected: %v, got: %#x %q", tt.wantCode, op, payload)
+				}
+			}()
+			var f *failure
+			if _, _, err := c.ReadMessage(); !errors.As(err, &f) {
+				t.Errorf("expected a protocol error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestHandshakeErrors(t *testing.T) {
+	tests := map[string]func(resp http.Header) int{
+		"status": func(resp http.Header) int { return http.StatusForbidden },
+		"accept": func(resp http.Header) int {
+			resp.Set("Sec-WebSocket-Accept", "bogus")
+			return http.StatusSwitchingProtocols
+		},
+		"upgrade": func(resp http.Header) int {
+			resp.Del("Upgrade")
+			return http.StatusSwitchingProtocols
+		},
+		"subprotocol": func(resp http.Header) int {
+			resp.Set("Sec-WebSocket-Protocol", "unoffered")
+			return http.StatusSwitchingProtocols
+		},
+	}
+	for name, respond := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, _, err := dial(t, &Dialer{}, respond); !errors.Is(err, ErrBadHandshake) {
+				t.Errorf("expected: %v, got: %v", ErrBadHandshake, err)
+			}
+		})
+	}
+}
+
+func TestParseURL(t *testing.T) {
+	tests := map[string]struct {
+		url      string
+		wantHost string
+		wantErr  bool
+	}{
+		"ws":          {url: "ws://example.com/feed", wantHost: "example.com:80"},
+		"wss":         {url: "wss://example.com", wantHost: "example.com:443"},
+		"port":        {url: "wss://example.com:8443/x", wantHost: "example.com:8443"},
+		"ipv6":        {url: "ws://[::1]/", wantHost: "[::1]:80"},
+		"http scheme": {url: "http://example.com", wantErr: true},
+		"no host":     {url: "ws:///feed", wantErr: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			u, err := parseURL(tt.url)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error: %v, got: %v", tt.wantErr, err)
+			}
+			if err == nil && u.Host != tt.wantHost {
+				t.Errorf("expected: %v, got: %v", tt.wantHost, u.Host)
+			}
+		})
+	}
+}
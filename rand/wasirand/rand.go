@@ -0,0 +1,67 @@
+package wasirand
+
+import (
+	cryptorand "crypto/rand"
+	"io"
+	"math/rand/v2"
+
+	"go.wasmcloud.dev/component/gen/wasi/random/insecure"
+	insecureseed "go.wasmcloud.dev/component/gen/wasi/random/insecure-seed"
+	"go.wasmcloud.dev/component/gen/wasi/random/random"
+)
+
+// Reader is a cryptographically secure random number generator backed by
+// `wasi:random/random`, suitable for key material, TLS and nonces.
+var Reader io.Reader = reader{}
+
+type reader struct{}
+
+func (reader) Read(p []byte) (int, error) {
+	return Read(p)
+}
+
+// Read fills p with bytes from `wasi:random/random`. It never returns an error.
+func Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		n += copy(p[n:], random.GetRandomBytes(uint64(len(p)-n)).Slice())
+	}
+	return n, nil
+}
+
+// Uint64 returns a cryptographically secure random uint64 from `wasi:random/random`.
+func Uint64() uint64 {
+	return random.GetRandomU64()
+}
+
+// InstallReader replaces crypto/rand.Reader with [Reader].
+//
+// NOTE: TinyGo maps crypto/rand to a host call that is missing or not secure
+// on some targets, installing Reader from an init() function makes crypto/rand,
+// and hence crypto/tls or UUID libraries, draw from `wasi:random` instead.
+func InstallReader() {
+	cryptorand.Reader = Reader
+}
+
+// InsecureSource is a math/rand/v2 Source backed by `wasi:random/insecure`.
+// It is fast but not suitable for security-sensitive purposes.
+type InsecureSource struct{}
+
+var _ rand.Source = InsecureSource{}
+
+func (InsecureSource) Uint64() uint64 {
+	return insecure.GetInsecureRandomU64()
+}
+
+// NewInsecure returns a math/rand/v2 Rand reading from [InsecureSource].
+func NewInsecure() *rand.Rand {
+	return rand.New(InsecureSource{})
+}
+
+// NewSeeded returns a deterministic math/rand/v2 Rand, seeded once from
+// `wasi:random/insecure-seed`. Use it to avoid a host call per number, e.g.
+// for jitter or sampling in hot paths.
+func NewSeeded() *rand.Rand {
+	seed := insecureseed.InsecureSeed()
+	return rand.New(rand.NewPCG(seed[0], seed[1]))
+}
@@ -0,0 +1,88 @@
+package wasirand
+
+import (
+	"bytes"
+	cryptorand "crypto/rand"
+	"testing"
+	_ "unsafe"
+
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+)
+
+func TestRead(t *testing.T) {
+	tests := map[string]struct {
+		size int
+	}{
+		"empty": {size: 0},
+		"short": {size: 16},
+		"long":  {size: maxBytes*2 + 1},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := make([]byte, tt.size)
+			n, err := Reader.Read(p)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != tt.size {
+				t.Errorf("expected: %v, got: %v", tt.size, n)
+			}
+			if bytes.Count(p, []byte{0xaa}) != tt.size {
+				t.Error("expected buffer to be filled from wasi:random")
+			}
+		})
+	}
+}
+
+func TestInstallReader(t *testing.T) {
+	reader := cryptorand.Reader
+	defer func() { cryptorand.Reader = reader }()
+
+	InstallReader()
+
+	p := make([]byte, 8)
+	if _, err := cryptorand.Read(p); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(p, bytes.Repeat([]byte{0xaa}, 8)) {
+		t.Errorf("expected: %x, got: %x", bytes.Repeat([]byte{0xaa}, 8), p)
+	}
+}
+
+func TestInsecure(t *testing.T) {
+	if got := NewInsecure().Uint64(); got != 42 {
+		t.Errorf("expected: %v, got: %v", 42, got)
+	}
+
+	a, b := NewSeeded(), NewSeeded()
+	for range 8 {
+		if x, y := a.Uint64(), b.Uint64(); x != y {
+			t.Fatalf("expected seeded sources to match, got: %v and %v", x, y)
+		}
+	}
+}
+
+// maxBytes is the number of bytes returned per call by the random stub.
+const maxBytes = 64
+
+// stub wasi:random/random, returning at most maxBytes per call
+//
+//go:linkname wasmimport_GetRandomBytes go.wasmcloud.dev/component/gen/wasi/random/random.wasmimport_GetRandomBytes
+func wasmimport_GetRandomBytes(len0 uint64, result *cm.List[uint8]) {
+	*result = cm.ToList(bytes.Repeat([]byte{0xaa}, int(min(len0, maxBytes))))
+}
+
+// stub wasi:random/insecure
+//
+//go:linkname wasmimport_GetInsecureRandomU64 go.wasmcloud.dev/component/gen/wasi/random/insecure.wasmimport_GetInsecureRandomU64
+func wasmimport_GetInsecureRandomU64() uint64 {
+	return 42
+}
+
+// stub wasi:random/insecure-seed
+//
+//go:linkname wasmimport_InsecureSeed go.wasmcloud.dev/component/gen/wasi/random/insecure-seed.wasmimport_InsecureSeed
+func wasmimport_InsecureSeed(result *[2]uint64) {
+	*result = [2]uint64{1, 2}
+}
@@ -0,0 +1,58 @@
+package wasirand
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+
+	wallclock "go.wasmcloud.dev/component/gen/wasi/clocks/wall-clock"
+)
+
+// UUIDv4 returns a random RFC 9562 version 4 UUID in its canonical string form.
+func UUIDv4() string {
+	var u [16]byte
+	Read(u[:])
+	return formatUUID(u, 4)
+}
+
+// UUIDv7 returns a RFC 9562 version 7 UUID in its canonical string form,
+// prefixed by the Unix milliseconds of `wasi:clocks/wall-clock` so that IDs
+// sort by creation time.
+func UUIDv7() string {
+	var u [16]byte
+	Read(u[6:])
+
+	now := wallclock.Now()
+	ms := now.Seconds*1000 + uint64(now.Nanoseconds)/1_000_000
+	var ts [8]byte
+	binary.BigEndian.PutUint64(ts[:], ms)
+	copy(u[:6], ts[2:])
+
+	return formatUUID(u, 7)
+}
+
+func formatUUID(u [16]byte, version byte) string {
+	u[6] = u[6]&0x0f | version<<4
+	// RFC 9562 variant
+	u[8] = u[8]&0x3f | 0x80
+
+	var b [36]byte
+	hex.Encode(b[0:8], u[0:4])
+	b[8] = '-'
+	hex.Encode(b[9:13], u[4:6])
+	b[13] = '-'
+	hex.Encode(b[14:18], u[6:8])
+	b[18] = '-'
+	hex.Encode(b[19:23], u[8:10])
+	b[23] = '-'
+	hex.Encode(b[24:], u[10:])
+	return string(b[:])
+}
+
+// Token returns n bytes from `wasi:random/random` encoded as unpadded
+// base64url, e.g. for session tokens or CSRF secrets.
+func Token(n int) string {
+	b := make([]byte, n)
+	Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
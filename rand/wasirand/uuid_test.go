@@ -0,0 +1,52 @@
+package wasirand
+
+import (
+	"testing"
+	_ "unsafe"
+
+	wallclock "go.wasmcloud.dev/component/gen/wasi/clocks/wall-clock"
+)
+
+func TestUUID(t *testing.T) {
+	tests := map[string]struct {
+		uuid func() string
+		want string
+	}{
+		"v4": {uuid: UUIDv4, want: "aaaaaaaa-aaaa-4aaa-aaaa-aaaaaaaaaaaa"},
+		"v7": {uuid: UUIDv7, want: "018bcfe5-687b-7aaa-aaaa-aaaaaaaaaaaa"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tt.uuid(); got != tt.want {
+				t.Errorf("expected: %v, got: %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestToken(t *testing.T) {
+	tests := map[string]struct {
+		n    int
+		want string
+	}{
+		"empty": {n: 0, want: ""},
+		"short": {n: 3, want: "qqqq"},
+		"long":  {n: 4, want: "qqqqqg"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := Token(tt.n); got != tt.want {
+				t.Errorf("expected: %v, got: %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// stub wasi:clocks/wall-clock, at a fixed instant
+//
+//go:linkname wasmimport_Now go.wasmcloud.dev/component/gen/wasi/clocks/wall-clock.wasmimport_Now
+func wasmimport_Now(result *wallclock.DateTime) {
+	*result = wallclock.DateTime{Seconds: 1700000000, Nanoseconds: 123_000_000}
+}
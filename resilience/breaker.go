@@ -0,0 +1,251 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.wasmcloud.dev/component/time/wasiclock"
+)
+
+// ErrOpen is returned by a [Breaker] rejecting a call while it is open.
+var ErrOpen = errors.New("resilience: circuit breaker is open")
+
+// State is the state of a [Breaker].
+type State int
+
+const (
+	// StateClosed lets calls through and counts their failures.
+	StateClosed State = iota
+	// StateOpen rejects calls with [ErrOpen] until the cool-down passed.
+	StateOpen
+	// StateHalfOpen lets probe calls through, closing the breaker once they
+	// succeed and opening it again on the first failure.
+	StateHalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// BreakerOptions configures a [Breaker].
+type BreakerOptions struct {
+	// FailureRate is the rate of failed calls opening the breaker. Defaults
+	// to 0.5.
+	FailureRate float64
+	// MinRequests is the number of calls of a window before the failure rate
+	// is evaluated. Defaults to 10.
+	MinRequests int
+	// Window is the period calls are counted over while closed. Defaults to
+	// 1m.
+	Window time.Duration
+	// CoolDown is the time the breaker stays open before letting probes
+	// through. Defaults to 30s.
+	CoolDown time.Duration
+	// HalfOpenRequests is the number of probes that must succeed to close the
+	// breaker. Defaults to 1.
+	HalfOpenRequests int
+	// IsFailure reports whether a call failed. Defaults to err != nil, except
+	// for context cancellation.
+	IsFailure func(err error) bool
+	// Store, if set, shares the state of the breaker across the instances and
+	// replicas of the component, see [KeyValueStore].
+	Store Store
+	// SyncInterval is the minimum time between reads of the Store. Defaults
+	// to 1s.
+	SyncInterval time.Duration
+	// OnStateChange, if set, is called on every transition, it must not call
+	// the methods of the Breaker.
+	OnStateChange func(name string, from, to State)
+}
+
+// Breaker is a circuit breaker, failing calls fast while the callee keeps
+// failing instead of piling up requests on it.
+type Breaker struct {
+	name string
+	opts BreakerOptions
+
+	mu sync.Mutex
+	// generation changes with every transition, results of calls allowed in
+	// a previous generation are ignored
+	generation uint64
+	state      State
+	since      time.Time
+	// counts of the current window, or probes while half-open
+	start     time.Time
+	requests  int
+	failures  int
+	inflight  int
+	successes int
+	synced    time.Time
+}
+
+// NewBreaker returns a closed Breaker, name identifies it in the Store.
+func NewBreaker(name string, opts BreakerOptions) *Breaker {
+	if opts.FailureRate <= 0 {
+		opts.FailureRate = 0.5
+	}
+	if opts.MinRequests <= 0 {
+		opts.MinRequests = 10
+	}
+	if opts.Window <= 0 {
+		opts.Window = time.Minute
+	}
+	if opts.CoolDown <= 0 {
+		opts.CoolDown = 30 * time.Second
+	}
+	if opts.HalfOpenRequests <= 0 {
+		opts.HalfOpenRequests = 1
+	}
+	if opts.IsFailure == nil {
+		opts.IsFailure = isFailure
+	}
+	if opts.SyncInterval <= 0 {
+		opts.SyncInterval = time.Second
+	}
+	now := wasiclock.NowFunc()
+	return &Breaker{name: name, opts: opts, since: now, start: now}
+}
+
+func isFailure(err error) bool {
+	return err != nil && !errors.Is(err, context.Canceled)
+}
+
+// Name returns the name of b.
+func (b *Breaker) Name() string {
+	return b.name
+}
+
+// State returns the current state of b.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.update(wasiclock.NowFunc())
+	return b.state
+}
+
+// Allow reports whether a call may proceed, returning [ErrOpen] otherwise.
+// done must be called with the result of the allowed call.
+func (b *Breaker) Allow() (done func(err error), err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := wasiclock.NowFunc()
+	b.update(now)
+	switch b.state {
+	case StateOpen:
+		return nil, ErrOpen
+	case StateHalfOpen:
+		if b.inflight >= b.opts.HalfOpenRequests {
+			return nil, ErrOpen
+		}
+		b.inflight++
+	}
+
+	generation := b.generation
+	var once sync.Once
+	return func(err error) {
+		once.Do(func() { b.done(generation, b.opts.IsFailure(err)) })
+	}, nil
+}
+
+// Do calls fn unless b is open.
+func (b *Breaker) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	done, err := b.Allow()
+	if err != nil {
+		return err
+	}
+	err = fn(ctx)
+	done(err)
+	return err
+}
+
+// Call calls fn unless b is open, and returns its result.
+func Call[T any](ctx context.Context, b *Breaker, fn func(ctx context.Context) (T, error)) (T, error) {
+	done, err := b.Allow()
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	v, err := fn(ctx)
+	done(err)
+	return v, err
+}
+
+func (b *Breaker) done(generation uint64, failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := wasiclock.NowFunc()
+	b.update(now)
+	if generation != b.generation {
+		return
+	}
+
+	switch b.state {
+	case StateClosed:
+		b.requests++
+		if failed {
+			b.failures++
+		}
+		if b.requests >= b.opts.MinRequests && float64(b.failures)/float64(b.requests) >= b.opts.FailureRate {
+			b.transition(StateOpen, now, true)
+		}
+	case StateHalfOpen:
+		b.inflight--
+		if failed {
+			b.transition(StateOpen, now, true)
+			return
+		}
+		if b.successes++; b.successes >= b.opts.HalfOpenRequests {
+			b.transition(StateClosed, now, true)
+		}
+	}
+}
+
+// update applies the transitions due at now. It must be called with b.mu held.
+func (b *Breaker) update(now time.Time) {
+	if b.opts.Store != nil && now.Sub(b.synced) >= b.opts.SyncInterval {
+		b.synced = now
+		if shared, err := b.opts.Store.Load(b.name); err == nil && shared.Since.After(b.since) {
+			b.transition(shared.State, shared.Since, false)
+		}
+	}
+
+	switch {
+	case b.state == StateOpen && now.Sub(b.since) >= b.opts.CoolDown:
+		b.transition(StateHalfOpen, now, false)
+	case b.state == StateClosed && now.Sub(b.start) >= b.opts.Window:
+		b.start, b.requests, b.failures = now, 0, 0
+	}
+}
+
+// transition moves b to state at since, and saves it to the Store if save is
+// set. It must be called with b.mu held.
+//
+// NOTE: the cool-down is derived from the time the breaker opened, every
+// replica reaches half-open on its own without writing the Store
+func (b *Breaker) transition(state State, since time.Time, save bool) {
+	from := b.state
+	b.generation++
+	b.state, b.since = state, since
+	b.start, b.requests, b.failures = since, 0, 0
+	b.inflight, b.successes = 0, 0
+	if save && b.opts.Store != nil {
+		b.opts.Store.Save(b.name, Shared{State: state, Since: since})
+	}
+	if from != state && b.opts.OnStateChange != nil {
+		b.opts.OnStateChange(b.name, from, state)
+	}
+}
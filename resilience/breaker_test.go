@@ -0,0 +1,148 @@
+//go:build !wasm
+
+package resilience
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.wasmcloud.dev/component/internal/fakehost"
+	"go.wasmcloud.dev/component/internal/memory"
+	"go.wasmcloud.dev/component/kv/wasikv"
+	"go.wasmcloud.dev/component/time/wasiclock"
+)
+
+// fakeNow points wasiclock.NowFunc at the returned time for the duration of
+// the test.
+func fakeNow(t *testing.T) *time.Time {
+	t.Helper()
+	now := time.Unix(600, 0)
+	prev := wasiclock.NowFunc
+	wasiclock.NowFunc = func() time.Time { return now }
+	t.Cleanup(func() { wasiclock.NowFunc = prev })
+	return &now
+}
+
+func memoryBucket(t *testing.T) *wasikv.Bucket {
+	t.Helper()
+	name := fakehost.Buckets.Name("resilience-")
+	fakehost.Buckets.Set(name, memory.NewBucket(nil))
+	defer fakehost.Buckets.Remove(name)
+
+	b, err := wasikv.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+var errFailed = errors.New("failed")
+
+func TestBreaker(t *testing.T) {
+	now := fakeNow(t)
+	var transitions []string
+	b := NewBreaker("test", BreakerOptions{
+		MinRequests:      4,
+		CoolDown:         10 * time.Second,
+		HalfOpenRequests: 2,
+		OnStateChange: func(name string, from, to State) {
+			transitions = append(transitions, from.String()+"->"+to.String())
+		},
+	})
+
+	steps := []struct {
+		advance time.Duration
+		err     error
+		state   State
+		// rejected calls must fail with ErrOpen
+		rejected bool
+	}{
+		{err: nil, state: StateClosed},
+		{err: errFailed, state: StateClosed},
+		{err: context.Canceled, state: StateClosed},
+		// 2 of 4 failed
+		{err: errFailed, state: StateOpen},
+		{advance: 5 * time.Second, rejected: true, state: StateOpen},
+		{advance: 5 * time.Second, err: nil, state: StateHalfOpen},
+		{err: errFailed, state: StateOpen},
+		{advance: 10 * time.Second, err: nil, state: StateHalfOpen},
+		{err: nil, state: StateClosed},
+		// the window was reset on close
+		{err: errFailed, state: StateClosed},
+	}
+	for i, step := range steps {
+		*now = now.Add(step.advance)
+		err := b.Do(context.Background(), func(context.Context) error { return step.err })
+		switch {
+		case step.rejected && !errors.Is(err, ErrOpen):
+			t.Errorf("%d: expected: %v, got: %v", i, ErrOpen, err)
+		case !step.rejected && err != step.err:
+			t.Errorf("%d: expected: %v, got: %v", i, step.err, err)
+		}
+		if state := b.State(); state != step.state {
+			t.Errorf("%d: expected: %v, got: %v", i, step.state, state)
+		}
+	}
+
+	expected := []string{"closed->open", "open->half-open", "half-open->open", "open->half-open", "half-open->closed"}
+	if len(transitions) != len(expected) {
+		t.Fatalf("expected: %v, got: %v", expected, transitions)
+	}
+	for i := range expected {
+		if transitions[i] != expected[i] {
+			t.Errorf("expected: %v, got: %v", expected, transitions)
+		}
+	}
+}
+
+func TestBreakerHalfOpenProbes(t *testing.T) {
+	now := fakeNow(t)
+	b := NewBreaker("test", BreakerOptions{MinRequests: 1, CoolDown: time.Second})
+	b.Do(context.Background(), func(context.Context) error { return errFailed })
+
+	*now = now.Add(time.Second)
+	done, err := b.Allow()
+	if err != nil {
+		t.Fatalf("expected: %v, got: %v", nil, err)
+	}
+	if _, err := b.Allow(); !errors.Is(err, ErrOpen) {
+		t.Errorf("expected: %v, got: %v", ErrOpen, err)
+	}
+	done(nil)
+	done(errFailed)
+	if state := b.State(); state != StateClosed {
+		t.Errorf("expected: %v, got: %v", StateClosed, state)
+	}
+}
+
+func TestBreakerStore(t *testing.T) {
+	now := fakeNow(t)
+	s := KeyValueStore(memoryBucket(t))
+	opts := BreakerOptions{MinRequests: 1, CoolDown: 10 * time.Second, Store: s}
+	a, b := NewBreaker("shared", opts), NewBreaker("shared", opts)
+
+	*now = now.Add(time.Second)
+	a.Do(context.Background(), func(context.Context) error { return errFailed })
+	if state := b.State(); state != StateOpen {
+		t.Errorf("expected: %v, got: %v", StateOpen, state)
+	}
+	shared, err := s.Load("shared")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if shared.State != StateOpen || !shared.Since.Equal(*now) {
+		t.Errorf("expected: %v at %v, got: %v at %v", StateOpen, *now, shared.State, shared.Since)
+	}
+
+	// both replicas reach half-open, the first probe closes both
+	*now = now.Add(10 * time.Second)
+	if err := b.Do(context.Background(), func(context.Context) error { return nil }); err != nil {
+		t.Errorf("expected: %v, got: %v", nil, err)
+	}
+	*now = now.Add(time.Second)
+	if state := a.State(); state != StateClosed {
+		t.Errorf("expected: %v, got: %v", StateClosed, state)
+	}
+}
@@ -0,0 +1,87 @@
+package resilience
+
+import (
+	"errors"
+
+	"go.wasmcloud.dev/component/kv/wasikv"
+)
+
+// Bucket is a wasikv.Bucket calling the store through a [Breaker]. Missing
+// keys are not failures. Methods not overridden, e.g. Update, call the store
+// directly.
+type Bucket struct {
+	*wasikv.Bucket
+	breaker *Breaker
+}
+
+// NewBucket returns bucket calling the store through b.
+//
+// NOTE: the breaker of a bucket must not be its Store, the breaker state
+// would not be readable once it opens
+func NewBucket(bucket *wasikv.Bucket, b *Breaker) *Bucket {
+	return &Bucket{Bucket: bucket, breaker: b}
+}
+
+func (b *Bucket) do(fn func() error) error {
+	done, err := b.breaker.Allow()
+	if err != nil {
+		return err
+	}
+	err = fn()
+	if errors.Is(err, wasikv.ErrNotFound) {
+		done(nil)
+	} else {
+		done(err)
+	}
+	return err
+}
+
+// Get returns the value at key, see wasikv.Bucket.Get.
+func (b *Bucket) Get(key string) (value []byte, err error) {
+	err = b.do(func() error {
+		value, err = b.Bucket.Get(key)
+		return err
+	})
+	return value, err
+}
+
+// Set sets the value at key, see wasikv.Bucket.Set.
+func (b *Bucket) Set(key string, value []byte) error {
+	return b.do(func() error {
+		return b.Bucket.Set(key, value)
+	})
+}
+
+// Delete deletes key, see wasikv.Bucket.Delete.
+func (b *Bucket) Delete(key string) error {
+	return b.do(func() error {
+		return b.Bucket.Delete(key)
+	})
+}
+
+// Exists reports whether key exists, see wasikv.Bucket.Exists.
+func (b *Bucket) Exists(key string) (ok bool, err error) {
+	err = b.do(func() error {
+		ok, err = b.Bucket.Exists(key)
+		return err
+	})
+	return ok, err
+}
+
+// Keys returns all keys, see wasikv.Bucket.Keys.
+func (b *Bucket) Keys() (keys []string, err error) {
+	err = b.do(func() error {
+		keys, err = b.Bucket.Keys()
+		return err
+	})
+	return keys, err
+}
+
+// Incr adds delta to the counter at key, see wasikv.Bucket.Incr.
+func (b *Bucket) Incr(key string, delta uint64) (n uint64, err error) {
+	err = b.do(func() error {
+		n, err = b.Bucket.Incr(key, delta)
+		return err
+	})
+	return n, err
+}
@@ -0,0 +1,61 @@
+package resilience
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.wasmcloud.dev/component/kv/wasikv"
+)
+
+// Shared is the state of a [Breaker] shared through a [Store].
+type Shared struct {
+	State State     `json:"state"`
+	Since time.Time `json:"since"`
+}
+
+// Store shares the state of breakers by name. A breaker failing to read or
+// write its Store keeps its own state.
+type Store interface {
+	Load(name string) (Shared, error)
+	Save(name string, state Shared) error
+}
+
+// KeyValueStore returns a Store keeping the state of the breakers in bucket,
+// at "breaker:<name>". Every breaker reads it at most once per sync interval
+// and writes it when it opens or closes.
+func KeyValueStore(bucket *wasikv.Bucket) Store {
+	return kvStore{bucket: bucket}
+}
+
+type kvStore struct {
+	bucket *wasikv.Bucket
+}
+
+func (s kvStore) key(name string) string {
+	return "breaker:" + name
+}
+
+func (s kvStore) Load(name string) (Shared, error) {
+	var shared Shared
+	buf, err := s.bucket.Get(s.key(name))
+	if errors.Is(err, wasikv.ErrNotFound) {
+		return shared, nil
+	}
+	if err != nil {
+		return shared, err
+	}
+	if err := json.Unmarshal(buf, &shared); err != nil {
+		return shared, fmt.Errorf("failed to decode breaker %q: %w", name, err)
+	}
+	return shared, nil
+}
+
+func (s kvStore) Save(name string, state Shared) error {
+	buf, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return s.bucket.Set(s.key(name), buf)
+}
@@ -0,0 +1,44 @@
+package resilience
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// StatusError is the failure recorded by [Transport] for responses with a 5xx
+// status, the response itself is returned as is.
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("server responded with %d", e.StatusCode)
+}
+
+// Transport returns a RoundTripper passing requests on to next, e.g. a
+// wasihttp.Transport, through b. Errors and responses with a 5xx status are
+// failures, requests fail with [ErrOpen] while b is open.
+func Transport(b *Breaker, next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		done, err := b.Allow()
+		if err != nil {
+			return nil, err
+		}
+		resp, err := next.RoundTrip(r)
+		switch {
+		case err != nil:
+			done(err)
+		case resp.StatusCode >= http.StatusInternalServerError:
+			done(&StatusError{StatusCode: resp.StatusCode})
+		default:
+			done(nil)
+		}
+		return resp, err
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
@@ -0,0 +1,63 @@
+//go:build !wasm
+
+package resilience
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.wasmcloud.dev/component/kv/wasikv"
+)
+
+func TestTransport(t *testing.T) {
+	fakeNow(t)
+	status := http.StatusServiceUnavailable
+	calls := 0
+	rt := Transport(NewBreaker("upstream", BreakerOptions{MinRequests: 2, FailureRate: 1}), roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		calls++
+		return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader("")), Request: r}, nil
+	}))
+
+	for range 2 {
+		resp, err := rt.RoundTrip(httptest.NewRequest(http.MethodGet, "http://upstream/", nil))
+		if err != nil {
+			t.Fatalf("expected: %v, got: %v", nil, err)
+		}
+		if resp.StatusCode != status {
+			t.Errorf("expected: %v, got: %v", status, resp.StatusCode)
+		}
+	}
+	if _, err := rt.RoundTrip(httptest.NewRequest(http.MethodGet, "http://upstream/", nil)); !errors.Is(err, ErrOpen) {
+		t.Errorf("expected: %v, got: %v", ErrOpen, err)
+	}
+	if calls != 2 {
+		t.Errorf("expected: %v, got: %v", 2, calls)
+	}
+}
+
+func TestBucket(t *testing.T) {
+	now := fakeNow(t)
+	b := NewBucket(memoryBucket(t), NewBreaker("kv", BreakerOptions{MinRequests: 2, CoolDown: time.Second}))
+
+	for range 3 {
+		if _, err := b.Get("missing"); !errors.Is(err, wasikv.ErrNotFound) {
+			t.Errorf("expected: %v, got: %v", wasikv.ErrNotFound, err)
+		}
+	}
+	if err := b.Set("key", []byte("value")); err != nil {
+		t.Fatalf("expected: %v, got: %v", nil, err)
+	}
+	if ok, err := b.Exists("key"); err != nil || !ok {
+		t.Errorf("expected: %v, got: %v, %v", true, ok, err)
+	}
+
+	b.breaker.transition(StateOpen, *now, false)
+	if _, err := b.Get("key"); !errors.Is(err, ErrOpen) {
+		t.Errorf("expected: %v, got: %v", ErrOpen, err)
+	}
+}
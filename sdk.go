@@ -1,6 +1,7 @@
 package component
 
 //go:generate wit-bindgen-go generate --world sdk --out gen ./wit
+//go:generate go run ./cmd/component shims gen
 
 import (
 	"embed"
@@ -0,0 +1,69 @@
+package secrets
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// TLSCertificate returns the certificate built from the PEM encoded
+// certificate chain and private key secrets at certKey and keyKey.
+func TLSCertificate(certKey, keyKey string) (tls.Certificate, error) {
+	cert, err := Get(certKey)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	key, err := Get(keyKey)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	pair, err := tls.X509KeyPair(cert.RevealBytes(), key.RevealBytes())
+	if err != nil {
+		// NOTE: X509KeyPair errors do not include key material
+		return tls.Certificate{}, fmt.Errorf("failed to load key pair %s, %s: %w", certKey, keyKey, err)
+	}
+	return pair, nil
+}
+
+// CertPool returns a pool holding the PEM encoded certificates of the secret
+// at key, e.g. a private CA to set as tls.Config.RootCAs.
+func CertPool(key string) (*x509.CertPool, error) {
+	pem, err := Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem.RevealBytes()) {
+		return nil, fmt.Errorf("failed to load certificates from %s: no certificate found", key)
+	}
+	return pool, nil
+}
+
+// DSN returns the URL style data source name dsn with the userinfo set to user
+// and the password secret at passwordKey, e.g.
+//
+//	dsn, err := secrets.DSN("postgres://db:5432/orders?sslmode=require", "app", "db-password")
+//	db, err := sql.Open("pgx", dsn.Reveal())
+//
+// The result is a Secret since it embeds the password.
+func DSN(dsn, user, passwordKey string) (Secret, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		// NOTE: url errors quote the input, which holds no secret yet
+		return Secret{}, fmt.Errorf("failed to parse DSN: %w", err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return Secret{}, errors.New("secrets: DSN requires a scheme and host")
+	}
+
+	password, err := Get(passwordKey)
+	if err != nil {
+		return Secret{}, err
+	}
+	u.User = url.UserPassword(user, password.Reveal())
+	return New([]byte(u.String())), nil
+}
@@ -0,0 +1,125 @@
+//go:build !wasm
+
+package secrets
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func selfSigned(t *testing.T) (certPEM, keyPEM string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		IsCA:         true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
+
+func TestTLSCertificate(t *testing.T) {
+	certPEM, keyPEM := selfSigned(t)
+	_, otherKey := selfSigned(t)
+	fakeSecrets(map[string]string{"cert": certPEM, "key": keyPEM, "other-key": otherKey}, nil)
+
+	tests := map[string]struct {
+		cert, key string
+		err       error
+		invalid   bool
+	}{
+		"pair":         {cert: "cert", key: "key"},
+		"missing key":  {cert: "cert", key: "missing", err: ErrNotFound},
+		"mismatched":   {cert: "cert", key: "other-key", invalid: true},
+		"missing cert": {cert: "missing", key: "key", err: ErrNotFound},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			pair, err := TLSCertificate(tt.cert, tt.key)
+			if tt.invalid {
+				if err == nil {
+					t.Error("expected error")
+				}
+				return
+			}
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected: %v, got: %v", tt.err, err)
+			}
+			if err == nil && len(pair.Certificate) != 1 {
+				t.Errorf("expected: %v, got: %v", 1, len(pair.Certificate))
+			}
+		})
+	}
+}
+
+func TestCertPool(t *testing.T) {
+	certPEM, _ := selfSigned(t)
+	fakeSecrets(map[string]string{"ca": certPEM, "empty": "not a certificate"}, nil)
+
+	if _, err := CertPool("ca"); err != nil {
+		t.Errorf("expected pool, got: %v", err)
+	}
+	if _, err := CertPool("empty"); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestDSN(t *testing.T) {
+	fakeSecrets(map[string]string{"db-password": "p@ss/word"}, nil)
+
+	tests := map[string]struct {
+		dsn  string
+		key  string
+		want string
+		err  bool
+	}{
+		"postgres": {
+			dsn:  "postgres://db:5432/orders?sslmode=require",
+			key:  "db-password",
+			want: "postgres://app:p%40ss%2Fword@db:5432/orders?sslmode=require",
+		},
+		"replaces userinfo": {
+			dsn:  "mysql://root@db/orders",
+			key:  "db-password",
+			want: "mysql://app:p%40ss%2Fword@db/orders",
+		},
+		"no host":          {dsn: "orders.db", key: "db-password", err: true},
+		"missing password": {dsn: "postgres://db/orders", key: "missing", err: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			dsn, err := DSN(tt.dsn, "app", tt.key)
+			if (err != nil) != tt.err {
+				t.Fatalf("expected error: %v, got: %v", tt.err, err)
+			}
+			if got := dsn.Reveal(); got != tt.want {
+				t.Errorf("expected: %v, got: %v", tt.want, got)
+			}
+		})
+	}
+}
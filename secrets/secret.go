@@ -0,0 +1,119 @@
+package secrets
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"go.wasmcloud.dev/component/gen/wasmcloud/secrets/reveal"
+	"go.wasmcloud.dev/component/gen/wasmcloud/secrets/store"
+)
+
+// Redacted replaces secret values in formatted, logged and encoded output.
+const Redacted = "[REDACTED]"
+
+// ErrNotFound is returned by [Get] when no secret is set at the key.
+var ErrNotFound = errors.New("secrets: not found")
+
+// Secret holds a secret value. It formats as [Redacted] through fmt, log/slog,
+// encoding/json and encoding.TextMarshaler users, the value is only
+// accessible through [Secret.Reveal] and [Secret.RevealBytes].
+type Secret struct {
+	// NOTE: a pointer keeps the value out of fmt output for structs holding a
+	// Secret in an unexported field, where the methods below are not called
+	s *secret
+}
+
+type secret struct {
+	value []byte
+}
+
+// New returns a Secret holding value, e.g. for values derived from secrets.
+func New(value []byte) Secret {
+	return Secret{s: &secret{value: value}}
+}
+
+// Get returns the secret at key from `wasmcloud:secrets/store`, or
+// [ErrNotFound] if it is not set.
+//
+// NOTE: the value is revealed by Get and the host handle dropped right away,
+// auditing the reveal call at the point of use is left to the caller.
+func Get(key string) (Secret, error) {
+	res := store.Get(key)
+	if res.IsErr() {
+		err := res.Err()
+		if err.NotFound() {
+			return Secret{}, ErrNotFound
+		}
+		return Secret{}, fmt.Errorf("failed to get secret %s: %s", key, secretsError(err))
+	}
+
+	handle := *res.OK()
+	defer handle.ResourceDrop()
+
+	value := reveal.Reveal(handle)
+	if s := value.String(); s != nil {
+		return New([]byte(*s)), nil
+	}
+	return New(value.Bytes().Slice()), nil
+}
+
+// IsZero reports whether the Secret holds no value.
+func (s Secret) IsZero() bool {
+	return s.s == nil
+}
+
+// Reveal returns the secret value.
+func (s Secret) Reveal() string {
+	if s.s == nil {
+		return ""
+	}
+	return string(s.s.value)
+}
+
+// RevealBytes returns a copy of the secret value.
+func (s Secret) RevealBytes() []byte {
+	if s.s == nil {
+		return nil
+	}
+	return append([]byte(nil), s.s.value...)
+}
+
+// String implements fmt.Stringer, returning [Redacted].
+func (s Secret) String() string {
+	return Redacted
+}
+
+// GoString implements fmt.GoStringer, returning [Redacted].
+func (s Secret) GoString() string {
+	return Redacted
+}
+
+// Format implements fmt.Formatter, writing [Redacted] for every verb.
+func (s Secret) Format(f fmt.State, verb rune) {
+	if verb == 'q' {
+		fmt.Fprintf(f, "%q", Redacted)
+		return
+	}
+	f.Write([]byte(Redacted))
+}
+
+// LogValue implements slog.LogValuer, returning [Redacted].
+func (s Secret) LogValue() slog.Value {
+	return slog.StringValue(Redacted)
+}
+
+// MarshalText implements encoding.TextMarshaler, returning [Redacted].
+func (s Secret) MarshalText() ([]byte, error) {
+	return []byte(Redacted), nil
+}
+
+func secretsError(err *store.SecretsError) string {
+	if msg := err.Upstream(); msg != nil {
+		return *msg
+	}
+	if msg := err.IO(); msg != nil {
+		return *msg
+	}
+	return "not found"
+}
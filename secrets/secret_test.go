@@ -0,0 +1,107 @@
+//go:build !wasm
+
+package secrets
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"go.wasmcloud.dev/component/internal/fakehost"
+)
+
+// fakeSecrets serves the secrets in values, string values are revealed as
+// strings and values set through bytes as bytes. The "broken" key fails with
+// an upstream error.
+func fakeSecrets(values map[string]string, bytes map[string][]byte) {
+	fakehost.Secrets.Values = values
+	fakehost.Secrets.Bytes = bytes
+	fakehost.Secrets.Errors = map[string]string{"broken": "vault sealed"}
+}
+
+func TestGet(t *testing.T) {
+	fakeSecrets(map[string]string{"token": "s3cr3t"}, map[string][]byte{"key": {0, 1, 2}})
+	since := fakehost.Last()
+
+	tests := map[string]struct {
+		key  string
+		want string
+		err  error
+	}{
+		"string":  {key: "token", want: "s3cr3t"},
+		"bytes":   {key: "key", want: "\x00\x01\x02"},
+		"missing": {key: "missing", err: ErrNotFound},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			s, err := Get(tt.key)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected: %v, got: %v", tt.err, err)
+			}
+			if got := s.Reveal(); got != tt.want {
+				t.Errorf("expected: %q, got: %q", tt.want, got)
+			}
+		})
+	}
+
+	if _, err := Get("broken"); err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("expected upstream error, got: %v", err)
+	}
+	if open := fakehost.Live(since); len(open) != 0 {
+		t.Errorf("expected all handles dropped, got: %v", open)
+	}
+}
+
+func TestRedaction(t *testing.T) {
+	s := New([]byte("s3cr3t"))
+
+	type config struct {
+		Token  Secret
+		hidden Secret
+	}
+	cfg := config{Token: s, hidden: s}
+
+	for _, format := range []string{"%v", "%s", "%q", "%x", "%d", "%+v", "%#v", "%10s"} {
+		t.Run(format, func(t *testing.T) {
+			for _, v := range []any{s, &s, cfg, &cfg, []Secret{s}, map[string]Secret{"k": s}} {
+				if got := fmt.Sprintf(format, v); strings.Contains(got, "s3cr3t") || strings.Contains(got, "733363723374") {
+					t.Errorf("expected %T to be redacted, got: %s", v, got)
+				}
+			}
+		})
+	}
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	logger.Info("loaded", "token", s, "config", cfg)
+	logger = slog.New(slog.NewTextHandler(&buf, nil))
+	logger.Info("loaded", "token", s, slog.Any("ptr", &s))
+	if strings.Contains(buf.String(), "s3cr3t") {
+		t.Errorf("expected log output to be redacted, got: %s", buf.String())
+	}
+
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"Token":"[REDACTED]"}`; string(data) != want {
+		t.Errorf("expected: %v, got: %s", want, data)
+	}
+
+	if got := s.Reveal(); got != "s3cr3t" {
+		t.Errorf("expected: %v, got: %v", "s3cr3t", got)
+	}
+	revealed := s.RevealBytes()
+	revealed[0] = 'x'
+	if got := s.Reveal(); got != "s3cr3t" {
+		t.Errorf("expected RevealBytes to return a copy, got: %v", got)
+	}
+	if !(Secret{}).IsZero() || s.IsZero() {
+		t.Error("expected only the zero Secret to be zero")
+	}
+}
@@ -0,0 +1,103 @@
+package singleflight
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Group coalesces concurrent calls with the same key into a single call,
+// e.g. the loads of a value missing from a cache.
+//
+// NOTE: unlike golang.org/x/sync/singleflight, calls are awaited with a
+// sync.WaitGroup only, there are no channels or goroutines, which TinyGo
+// schedules cooperatively
+type Group[T any] struct {
+	mu    sync.Mutex
+	calls map[string]*call[T]
+}
+
+type call[T any] struct {
+	wg  sync.WaitGroup
+	val T
+	err error
+	// dups is the number of callers waiting for the call
+	dups int
+}
+
+var errGoexit = errors.New("singleflight: function called runtime.Goexit")
+
+// PanicError is returned to the callers waiting for a function that panicked,
+// the caller that called the function panics with Value.
+type PanicError struct {
+	Value any
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("singleflight: function panicked: %v", e.Value)
+}
+
+// Do calls fn and returns its results, unless a call with the same key is in
+// flight, in which case it waits for it and returns its results. shared
+// reports whether the results were returned to multiple callers.
+func (g *Group[T]) Do(key string, fn func() (T, error)) (v T, err error, shared bool) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*call[T])
+	}
+	if c, ok := g.calls[key]; ok {
+		c.dups++
+		g.mu.Unlock()
+		c.wg.Wait()
+		return c.val, c.err, true
+	}
+	c := &call[T]{}
+	c.wg.Add(1)
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	g.call(c, key, fn)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return c.val, c.err, c.dups > 0
+}
+
+func (g *Group[T]) call(c *call[T], key string, fn func() (T, error)) {
+	normal := false
+	defer func() {
+		if normal {
+			return
+		}
+		r := recover()
+		if r == nil {
+			// NOTE: fn called runtime.Goexit, which keeps unwinding
+			c.err = errGoexit
+			g.done(c, key)
+			return
+		}
+		c.err = &PanicError{Value: r}
+		g.done(c, key)
+		panic(r)
+	}()
+	c.val, c.err = fn()
+	normal = true
+	g.done(c, key)
+}
+
+func (g *Group[T]) done(c *call[T], key string) {
+	g.mu.Lock()
+	if g.calls[key] == c {
+		delete(g.calls, key)
+	}
+	g.mu.Unlock()
+	c.wg.Done()
+}
+
+// Forget makes the next call with key call its function, instead of waiting
+// for the call in flight.
+func (g *Group[T]) Forget(key string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.calls, key)
+}
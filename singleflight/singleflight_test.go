@@ -0,0 +1,106 @@
+package singleflight
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestDo(t *testing.T) {
+	var g Group[string]
+	v, err, shared := g.Do("key", func() (string, error) { return "value", nil })
+	if v != "value" || err != nil || shared {
+		t.Errorf("expected: %v, %v, %v, got: %v, %v, %v", "value", nil, false, v, err, shared)
+	}
+
+	failed := errors.New("failed")
+	if _, err, _ := g.Do("key", func() (string, error) { return "", failed }); !errors.Is(err, failed) {
+		t.Errorf("expected: %v, got: %v", failed, err)
+	}
+}
+
+func TestDoCoalesces(t *testing.T) {
+	var g Group[int]
+	var calls atomic.Int32
+	started, release := make(chan struct{}), make(chan struct{})
+
+	const n = 5
+	var wg sync.WaitGroup
+	results := make([]int, n)
+	shared := make([]bool, n)
+	leader := func(i int) {
+		defer wg.Done()
+		results[i], _, shared[i] = g.Do("key", func() (int, error) {
+			calls.Add(1)
+			close(started)
+			<-release
+			return 42, nil
+		})
+	}
+	wg.Add(1)
+	go leader(0)
+	<-started
+
+	for i := 1; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _, shared[i] = g.Do("key", func() (int, error) {
+				calls.Add(1)
+				return 0, nil
+			})
+		}()
+	}
+	// NOTE: the waiters must be registered before the call returns
+	for {
+		g.mu.Lock()
+		dups := g.calls["key"].dups
+		g.mu.Unlock()
+		if dups == n-1 {
+			break
+		}
+		runtime.Gosched()
+	}
+	close(release)
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Errorf("expected: %v, got: %v", 1, calls.Load())
+	}
+	for i := range n {
+		if results[i] != 42 || !shared[i] {
+			t.Errorf("%d: expected: %v, %v, got: %v, %v", i, 42, true, results[i], shared[i])
+		}
+	}
+}
+
+func TestDoPanic(t *testing.T) {
+	var g Group[int]
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Errorf("expected: %v, got: %v", "boom", r)
+		}
+		if _, err, _ := g.Do("key", func() (int, error) { return 1, nil }); err != nil {
+			t.Errorf("expected: %v, got: %v", nil, err)
+		}
+	}()
+	g.Do("key", func() (int, error) { panic("boom") })
+}
+
+func TestForget(t *testing.T) {
+	var g Group[int]
+	var calls int
+	g.Do("key", func() (int, error) {
+		g.Forget("key")
+		g.Do("key", func() (int, error) {
+			calls++
+			return 0, nil
+		})
+		return 0, nil
+	})
+	if calls != 1 {
+		t.Errorf("expected: %v, got: %v", 1, calls)
+	}
+}
@@ -0,0 +1,78 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Queue holds the tasks deferred during an invocation of an export, run once
+// its work is done, e.g. after the response to a `wasi:http` request was
+// finished, but before the export returns to the host.
+//
+// NOTE: goroutines do not outlive the invocation on most hosts, the instance
+// is suspended or torn down once the export returns
+type Queue struct {
+	mu    sync.Mutex
+	tasks []func(ctx context.Context) error
+}
+
+type queueKey struct{}
+
+// NewContext returns a Queue and a copy of ctx carrying it, for [Defer]. It is
+// called by the SDK adapters for each invocation, custom exports call it
+// along with [Queue.Run].
+func NewContext(ctx context.Context) (context.Context, *Queue) {
+	q := &Queue{}
+	return context.WithValue(ctx, queueKey{}, q), q
+}
+
+// Defer queues fn on the Queue of ctx, e.g. a metrics flush, a cache write
+// behind or an audit log publish, which then does not delay the response.
+// Without a Queue, e.g. outside of an invocation, fn is called at once.
+func Defer(ctx context.Context, fn func(ctx context.Context) error) error {
+	q, ok := ctx.Value(queueKey{}).(*Queue)
+	if !ok {
+		return fn(ctx)
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.tasks = append(q.tasks, fn)
+	return nil
+}
+
+// Run calls the queued tasks in order, including those deferred by the tasks
+// themselves, and returns their errors joined. The tasks are called with a
+// context keeping the values of ctx, which is not canceled with it. A
+// panicking task fails with an error instead of skipping the others.
+func (q *Queue) Run(ctx context.Context) error {
+	ctx = context.WithoutCancel(ctx)
+
+	var errs []error
+	for {
+		q.mu.Lock()
+		tasks := q.tasks
+		q.tasks = nil
+		q.mu.Unlock()
+		if len(tasks) == 0 {
+			return errors.Join(errs...)
+		}
+
+		for _, fn := range tasks {
+			if err := call(ctx, fn); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+}
+
+func call(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("tasks: task panicked: %v", r)
+		}
+	}()
+	return fn(ctx)
+}
@@ -0,0 +1,69 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type key struct{}
+
+func TestQueue(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), key{}, "value"))
+	ctx, q := NewContext(ctx)
+
+	var calls []string
+	errFailed := errors.New("failed")
+	Defer(ctx, func(ctx context.Context) error {
+		calls = append(calls, "first")
+		// NOTE: deferred by a task, run after the queued ones
+		return Defer(ctx, func(context.Context) error {
+			calls = append(calls, "nested")
+			return nil
+		})
+	})
+	Defer(ctx, func(context.Context) error {
+		calls = append(calls, "failing")
+		return errFailed
+	})
+	Defer(ctx, func(context.Context) error {
+		panic("boom")
+	})
+	Defer(ctx, func(ctx context.Context) error {
+		calls = append(calls, "last")
+		if ctx.Err() != nil || ctx.Value(key{}) != "value" {
+			t.Errorf("expected a live context with the values of the invocation, got: %v %v", ctx.Err(), ctx.Value(key{}))
+		}
+		return nil
+	})
+	if len(calls) != 0 {
+		t.Fatalf("expected the tasks to be queued, got: %v", calls)
+	}
+
+	cancel()
+	err := q.Run(ctx)
+	if want := []string{"first", "failing", "last", "nested"}; !slices.Equal(calls, want) {
+		t.Errorf("expected: %v, got: %v", want, calls)
+	}
+	if !errors.Is(err, errFailed) || !strings.Contains(err.Error(), "tasks: task panicked: boom") {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	calls = nil
+	if err := q.Run(ctx); err != nil || len(calls) != 0 {
+		t.Errorf("expected an empty queue, got: %v %v", calls, err)
+	}
+}
+
+func TestDeferWithoutQueue(t *testing.T) {
+	called := false
+	err := Defer(context.Background(), func(context.Context) error {
+		called = true
+		return errors.New("failed")
+	})
+	if !called || err == nil {
+		t.Errorf("expected the task to be called at once, got: %v %v", called, err)
+	}
+}
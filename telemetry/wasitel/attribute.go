@@ -0,0 +1,38 @@
+package wasitel
+
+// Attribute is a key-value pair describing a span, event or resource. Values
+// are strings, booleans, int64s, float64s or string slices.
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+// String returns a string attribute.
+func String(key, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Int returns an integer attribute.
+func Int(key string, value int) Attribute {
+	return Attribute{Key: key, Value: int64(value)}
+}
+
+// Int64 returns an integer attribute.
+func Int64(key string, value int64) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Float64 returns a floating point attribute.
+func Float64(key string, value float64) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Bool returns a boolean attribute.
+func Bool(key string, value bool) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Strings returns a string slice attribute.
+func Strings(key string, value []string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
@@ -0,0 +1,368 @@
+package wasitel
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.wasmcloud.dev/component/export"
+	"go.wasmcloud.dev/component/time/wasiclock"
+)
+
+// MetricKind is the aggregation of a metric.
+type MetricKind int
+
+const (
+	// MetricSum is a monotonic sum, recorded by a [Counter].
+	MetricSum MetricKind = iota + 1
+	// MetricUpDownSum is a non-monotonic sum, recorded by an [UpDownCounter].
+	MetricUpDownSum
+	// MetricGauge is the last recorded value, recorded by a [Gauge].
+	MetricGauge
+	// MetricHistogram is a distribution of values, recorded by a [Histogram].
+	MetricHistogram
+)
+
+// DefaultHistogramBounds are the OpenTelemetry default explicit bucket bounds.
+var DefaultHistogramBounds = []float64{0, 5, 10, 25, 50, 75, 100, 250, 500, 750, 1000, 2500, 5000, 7500, 10000}
+
+// Point is an aggregated data point of a metric for one attribute set.
+type Point struct {
+	Attributes []Attribute
+	Start      time.Time
+	Time       time.Time
+	// Value is the sum or gauge value.
+	Value float64
+
+	// Count, Sum, Min, Max, Bounds and BucketCounts are set for histograms,
+	// BucketCounts has one more element than Bounds.
+	Count        uint64
+	Sum          float64
+	Min          float64
+	Max          float64
+	Bounds       []float64
+	BucketCounts []uint64
+}
+
+// MetricData is the snapshot of a metric handed to a [MetricExporter].
+// Sums and histograms are cumulative since the meter was created.
+type MetricData struct {
+	Name        string
+	Description string
+	Unit        string
+	Kind        MetricKind
+	Points      []Point
+}
+
+// MetricExporter ships metrics to a backend.
+type MetricExporter interface {
+	ExportMetrics(ctx context.Context, resource []Attribute, metrics []MetricData) error
+}
+
+// MetricExporterFunc adapts a function to a [MetricExporter].
+type MetricExporterFunc func(ctx context.Context, resource []Attribute, metrics []MetricData) error
+
+func (f MetricExporterFunc) ExportMetrics(ctx context.Context, resource []Attribute, metrics []MetricData) error {
+	return f(ctx, resource, metrics)
+}
+
+// DefaultExportInterval is the time after which recorded metrics are exported.
+const DefaultExportInterval = time.Minute
+
+// MeterOptions configure a [Meter].
+type MeterOptions struct {
+	// ServiceName sets the service.name resource attribute.
+	ServiceName string
+	// Resource are additional attributes describing the component.
+	Resource []Attribute
+	// ExportInterval defaults to [DefaultExportInterval].
+	ExportInterval time.Duration
+	// OnError is called with errors of exports started in the background.
+	OnError func(error)
+}
+
+// Meter creates instruments and exports their metrics once the export
+// interval passed after a recording, on a `wasi:clocks` timer, or once the
+// invocation ends, on [export.Shutdown].
+//
+// NOTE: as with [Tracer], call [Meter.Flush] before a handler returns to not
+// lose the metrics of short-lived component instances, if the export does not
+// call [export.Shutdown].
+type Meter struct {
+	exporter MetricExporter
+	resource []Attribute
+	opts     MeterOptions
+	start    time.Time
+	sleep    func(ctx context.Context, d time.Duration) error
+
+	mu          sync.Mutex
+	instruments []*instrument
+	byName      map[string]*instrument
+	callbacks   []func()
+	// pending is set while recorded metrics wait for their export, it stops
+	// the export timer and removes the shutdown hook.
+	pending func()
+}
+
+// NewMeter returns a Meter exporting metrics to exporter. A Meter with a nil
+// exporter only aggregates, e.g. to be scraped through [PrometheusHandler].
+func NewMeter(exporter MetricExporter, opts MeterOptions) *Meter {
+	if opts.ExportInterval <= 0 {
+		opts.ExportInterval = DefaultExportInterval
+	}
+	return &Meter{
+		exporter: exporter,
+		resource: newResource(opts.ServiceName, opts.Resource),
+		opts:     opts,
+		start:    wasiclock.Now(),
+		sleep:    sleep,
+		byName:   make(map[string]*instrument),
+	}
+}
+
+// Counter is a monotonically increasing sum, e.g. a request count.
+type Counter struct{ *instrument }
+
+// Add adds a non-negative delta for attrs, negative deltas are ignored.
+func (c Counter) Add(delta float64, attrs ...Attribute) {
+	if delta < 0 {
+		return
+	}
+	c.record(delta, attrs)
+}
+
+// UpDownCounter is a sum that may decrease, e.g. in-flight requests.
+type UpDownCounter struct{ *instrument }
+
+// Add adds delta for attrs.
+func (c UpDownCounter) Add(delta float64, attrs ...Attribute) {
+	c.record(delta, attrs)
+}
+
+// Gauge holds the last value set, e.g. the heap size.
+type Gauge struct{ *instrument }
+
+// Set sets the value for attrs.
+func (g Gauge) Set(value float64, attrs ...Attribute) {
+	g.record(value, attrs)
+}
+
+// Histogram records a distribution of values, e.g. request durations.
+type Histogram struct{ *instrument }
+
+// Record records value for attrs.
+func (h Histogram) Record(value float64, attrs ...Attribute) {
+	h.record(value, attrs)
+}
+
+// Counter returns the counter named name, creating it on first use.
+func (m *Meter) Counter(name, description, unit string) Counter {
+	return Counter{m.instrument(name, description, unit, MetricSum, nil)}
+}
+
+// UpDownCounter returns the up-down counter named name, creating it on first use.
+func (m *Meter) UpDownCounter(name, description, unit string) UpDownCounter {
+	return UpDownCounter{m.instrument(name, description, unit, MetricUpDownSum, nil)}
+}
+
+// Gauge returns the gauge named name, creating it on first use.
+func (m *Meter) Gauge(name, description, unit string) Gauge {
+	return Gauge{m.instrument(name, description, unit, MetricGauge, nil)}
+}
+
+// Histogram returns the histogram named name, creating it on first use with
+// the given ascending bucket bounds, or [DefaultHistogramBounds] if none.
+func (m *Meter) Histogram(name, description, unit string, bounds ...float64) Histogram {
+	if len(bounds) == 0 {
+		bounds = DefaultHistogramBounds
+	}
+	return Histogram{m.instrument(name, description, unit, MetricHistogram, slices.Clone(bounds))}
+}
+
+// RegisterCallback registers fn to be called before every collection, e.g. to
+// set gauges observing external state.
+func (m *Meter) RegisterCallback(fn func()) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.callbacks = append(m.callbacks, fn)
+}
+
+// instrument returns the instrument named name. Instruments are identified by
+// name, it panics if name is registered with a different kind.
+func (m *Meter) instrument(name, description, unit string, kind MetricKind, bounds []float64) *instrument {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if inst, ok := m.byName[name]; ok {
+		if inst.data.Kind != kind {
+			panic(fmt.Sprintf("wasitel: metric %s is already registered with a different kind", name))
+		}
+		return inst
+	}
+
+	inst := &instrument{
+		meter:  m,
+		bounds: bounds,
+		data:   MetricData{Name: name, Description: description, Unit: unit, Kind: kind},
+		points: make(map[string]*Point),
+	}
+	m.instruments = append(m.instruments, inst)
+	m.byName[name] = inst
+	return inst
+}
+
+// arm starts the export timer and registers the flush with
+// [export.OnShutdown], if no export is pending.
+func (m *Meter) arm() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.pending != nil || m.exporter == nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	remove := export.OnShutdown(m.Flush)
+	m.pending = func() {
+		cancel()
+		remove()
+	}
+
+	go func() {
+		if m.sleep(ctx, m.opts.ExportInterval) != nil {
+			return
+		}
+		if err := m.Flush(context.Background()); err != nil && m.opts.OnError != nil {
+			m.opts.OnError(err)
+		}
+	}()
+}
+
+// Collect runs the registered callbacks and returns a snapshot of all metrics
+// with recorded points.
+func (m *Meter) Collect() []MetricData {
+	m.mu.Lock()
+	callbacks := slices.Clone(m.callbacks)
+	m.mu.Unlock()
+	for _, fn := range callbacks {
+		fn()
+	}
+
+	m.mu.Lock()
+	instruments := slices.Clone(m.instruments)
+	m.mu.Unlock()
+
+	now := wasiclock.Now()
+	var metrics []MetricData
+	for _, inst := range instruments {
+		if data, ok := inst.snapshot(now); ok {
+			metrics = append(metrics, data)
+		}
+	}
+	return metrics
+}
+
+// Flush exports the collected metrics, stopping the pending export.
+func (m *Meter) Flush(ctx context.Context) error {
+	m.mu.Lock()
+	if m.pending != nil {
+		m.pending()
+		m.pending = nil
+	}
+	m.mu.Unlock()
+	if m.exporter == nil {
+		return nil
+	}
+
+	metrics := m.Collect()
+	if len(metrics) == 0 {
+		return nil
+	}
+	return m.exporter.ExportMetrics(ctx, m.resource, metrics)
+}
+
+type instrument struct {
+	meter  *Meter
+	bounds []float64
+
+	mu     sync.Mutex
+	data   MetricData
+	keys   []string
+	points map[string]*Point
+}
+
+func (inst *instrument) record(value float64, attrs []Attribute) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return
+	}
+	key, attrs := attributeSet(attrs)
+
+	inst.mu.Lock()
+	p, ok := inst.points[key]
+	if !ok {
+		p = &Point{Attributes: attrs, Start: inst.meter.start}
+		if inst.data.Kind == MetricHistogram {
+			p.Bounds = inst.bounds
+			p.BucketCounts = make([]uint64, len(inst.bounds)+1)
+			p.Min, p.Max = value, value
+		}
+		inst.points[key] = p
+		inst.keys = append(inst.keys, key)
+	}
+
+	switch inst.data.Kind {
+	case MetricSum, MetricUpDownSum:
+		p.Value += value
+	case MetricGauge:
+		p.Value = value
+	case MetricHistogram:
+		p.Count++
+		p.Sum += value
+		p.Min = min(p.Min, value)
+		p.Max = max(p.Max, value)
+		// buckets are upper inclusive, (bounds[i-1], bounds[i]]
+		p.BucketCounts[sort.SearchFloat64s(inst.bounds, value)]++
+	}
+	inst.mu.Unlock()
+
+	inst.meter.arm()
+}
+
+func (inst *instrument) snapshot(now time.Time) (MetricData, bool) {
+	inst.mu.Lock()
+	defer inst.mu.Unlock()
+
+	if len(inst.keys) == 0 {
+		return MetricData{}, false
+	}
+	data := inst.data
+	data.Points = make([]Point, 0, len(inst.keys))
+	for _, key := range inst.keys {
+		p := *inst.points[key]
+		p.Time = now
+		p.BucketCounts = slices.Clone(p.BucketCounts)
+		data.Points = append(data.Points, p)
+	}
+	return data, true
+}
+
+// attributeSet returns the attributes sorted by key and a key identifying the set.
+func attributeSet(attrs []Attribute) (string, []Attribute) {
+	if len(attrs) == 0 {
+		return "", nil
+	}
+	attrs = slices.Clone(attrs)
+	slices.SortStableFunc(attrs, func(a, b Attribute) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+
+	var b strings.Builder
+	for _, a := range attrs {
+		fmt.Fprintf(&b, "%q=%#v;", a.Key, a.Value)
+	}
+	return b.String(), attrs
+}
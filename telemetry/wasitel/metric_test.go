@@ -0,0 +1,189 @@
+package wasitel
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"go.wasmcloud.dev/component/export"
+)
+
+// discard is a MetricExporter dropping all metrics.
+var discard = MetricExporterFunc(func(context.Context, []Attribute, []MetricData) error { return nil })
+
+func findMetric(t *testing.T, metrics []MetricData, name string) MetricData {
+	t.Helper()
+	for _, m := range metrics {
+		if m.Name == name {
+			return m
+		}
+	}
+	t.Fatalf("metric %s not collected", name)
+	return MetricData{}
+}
+
+func TestMeter(t *testing.T) {
+	noSleep(t)
+
+	m := NewMeter(discard, MeterOptions{})
+	requests := m.Counter("http.server.requests", "Requests.", "{request}")
+	requests.Add(1, String("method", "GET"), Int("status", 200))
+	requests.Add(2, Int("status", 200), String("method", "GET"))
+	requests.Add(1, String("method", "POST"), Int("status", 201))
+	requests.Add(-5, String("method", "GET"), Int("status", 200))
+
+	inflight := m.UpDownCounter("http.server.active_requests", "In-flight requests.", "{request}")
+	inflight.Add(1)
+	inflight.Add(1)
+	inflight.Add(-1)
+
+	heap := m.Gauge("heap", "", "By")
+	heap.Set(10)
+	heap.Set(7)
+
+	duration := m.Histogram("http.server.duration", "", "s", 0.1, 1)
+	for _, v := range []float64{0.05, 0.1, 0.5, 2, 3} {
+		duration.Record(v)
+	}
+
+	m.Counter("unused", "", "")
+
+	metrics := m.Collect()
+	if len(metrics) != 4 {
+		t.Errorf("expected metrics without points to be skipped, got: %v", len(metrics))
+	}
+
+	counter := findMetric(t, metrics, "http.server.requests")
+	if counter.Kind != MetricSum || counter.Unit != "{request}" || len(counter.Points) != 2 {
+		t.Fatalf("unexpected counter: %+v", counter)
+	}
+	if p := counter.Points[0]; p.Value != 3 || p.Attributes[0] != String("method", "GET") || p.Attributes[1] != Int("status", 200) {
+		t.Errorf("unexpected point: %+v", p)
+	}
+	if p := counter.Points[1]; p.Value != 1 {
+		t.Errorf("unexpected point: %+v", p)
+	}
+
+	if p := findMetric(t, metrics, "http.server.active_requests").Points[0]; p.Value != 1 {
+		t.Errorf("expected: %v, got: %v", 1, p.Value)
+	}
+	if p := findMetric(t, metrics, "heap").Points[0]; p.Value != 7 {
+		t.Errorf("expected: %v, got: %v", 7, p.Value)
+	}
+
+	p := findMetric(t, metrics, "http.server.duration").Points[0]
+	if p.Count != 5 || p.Sum != 5.65 || p.Min != 0.05 || p.Max != 3 {
+		t.Errorf("unexpected histogram: %+v", p)
+	}
+	if want := []uint64{2, 1, 2}; !slices.Equal(p.BucketCounts, want) {
+		t.Errorf("expected: %v, got: %v", want, p.BucketCounts)
+	}
+	if !p.Time.After(p.Start) {
+		t.Errorf("expected point time after start, got: %v %v", p.Start, p.Time)
+	}
+
+	if m.Counter("http.server.requests", "", "").instrument != requests.instrument {
+		t.Error("expected instruments to be identified by name")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a kind mismatch to panic")
+		}
+	}()
+	m.Gauge("http.server.requests", "", "")
+}
+
+func TestMeterFlush(t *testing.T) {
+	orig := sleep
+	defer func() { sleep = orig }()
+
+	wake := make(chan struct{})
+	sleep = func(ctx context.Context, d time.Duration) error {
+		if d != time.Second {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		<-wake
+		return nil
+	}
+
+	exported := make(chan []MetricData, 2)
+	m := NewMeter(MetricExporterFunc(func(_ context.Context, resource []Attribute, metrics []MetricData) error {
+		exported <- metrics
+		return nil
+	}), MeterOptions{ServiceName: "orders", ExportInterval: time.Second})
+
+	if err := m.Flush(context.Background()); err != nil || len(exported) != 0 {
+		t.Errorf("expected nothing exported, got: %v %v", err, len(exported))
+	}
+
+	m.Counter("requests", "", "").Add(1)
+	m.Counter("requests", "", "").Add(1)
+	close(wake)
+
+	select {
+	case metrics := <-exported:
+		if len(metrics) != 1 || metrics[0].Points[0].Value != 2 {
+			t.Errorf("unexpected export: %+v", metrics)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the export timer to flush the metrics")
+	}
+}
+
+func TestMeterShutdown(t *testing.T) {
+	orig := sleep
+	defer func() { sleep = orig }()
+
+	stopped := make(chan struct{})
+	sleep = func(ctx context.Context, _ time.Duration) error {
+		<-ctx.Done()
+		close(stopped)
+		return ctx.Err()
+	}
+
+	var exports int
+	m := NewMeter(MetricExporterFunc(func(context.Context, []Attribute, []MetricData) error {
+		exports++
+		return nil
+	}), MeterOptions{})
+	m.Counter("requests", "", "").Add(1)
+
+	if err := export.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if exports != 1 {
+		t.Errorf("expected: %v, got: %v", 1, exports)
+	}
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("expected the export timer to be stopped")
+	}
+
+	// the hook is removed with the pending export
+	if err := export.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if exports != 1 {
+		t.Errorf("expected: %v, got: %v", 1, exports)
+	}
+}
+
+func TestRuntimeMetrics(t *testing.T) {
+	noSleep(t)
+
+	m := NewMeter(discard, MeterOptions{})
+	RegisterRuntimeMetrics(m)
+
+	metrics := m.Collect()
+	for _, name := range []string{"go.memory.heap", "go.memory.sys", "go.gc.count", "go.gc.pause_total", "go.goroutine.count"} {
+		if metric := findMetric(t, metrics, name); metric.Kind != MetricGauge || len(metric.Points) != 1 {
+			t.Errorf("unexpected metric: %+v", metric)
+		}
+	}
+	if heap := findMetric(t, metrics, "go.memory.heap").Points[0].Value; heap <= 0 {
+		t.Errorf("expected a heap size, got: %v", heap)
+	}
+}
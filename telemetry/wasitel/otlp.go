@@ -0,0 +1,286 @@
+package wasitel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.wasmcloud.dev/component/config/wasiconfig"
+)
+
+// scopeName is the instrumentation scope reported for all telemetry.
+const scopeName = "go.wasmcloud.dev/component/telemetry/wasitel"
+
+// OTLPConfig is the exporter configuration read from `wasi:config/runtime`,
+// the keys follow the OpenTelemetry environment variables.
+type OTLPConfig struct {
+	// Endpoint is the collector base URL, signals are sent to /v1/<signal>.
+	Endpoint string `config:"otel_exporter_otlp_endpoint" default:"http://localhost:4318"`
+	// TracesEndpoint overrides the full URL spans are sent to.
+	TracesEndpoint string `config:"otel_exporter_otlp_traces_endpoint"`
+	// MetricsEndpoint overrides the full URL metrics are sent to.
+	MetricsEndpoint string `config:"otel_exporter_otlp_metrics_endpoint"`
+	// Headers are "key=value" pairs sent with every export, e.g. for auth.
+	Headers []string `config:"otel_exporter_otlp_headers"`
+	// Timeout bounds each export.
+	Timeout time.Duration `config:"otel_exporter_otlp_timeout" default:"10s"`
+	// ServiceName is the service.name to pass to [TracerOptions].
+	ServiceName string `config:"otel_service_name"`
+}
+
+// LoadOTLPConfig reads the exporter configuration from runtime config.
+func LoadOTLPConfig() (OTLPConfig, error) {
+	var cfg OTLPConfig
+	err := wasiconfig.Load(&cfg)
+	return cfg, err
+}
+
+// OTLPExporter exports telemetry to an OpenTelemetry collector over OTLP/HTTP.
+//
+// NOTE: payloads are encoded as OTLP JSON rather than protobuf, keeping the
+// component free of generated protobuf code. Collectors accept both on the
+// same endpoints.
+type OTLPExporter struct {
+	// TracesURL is the URL spans are posted to.
+	TracesURL string
+	// MetricsURL is the URL metrics are posted to.
+	MetricsURL string
+	// Header is sent with every export.
+	Header http.Header
+	// Timeout bounds each export, if set.
+	Timeout time.Duration
+	// Client sends the exports, e.g. wasihttp.DefaultClient.
+	Client *http.Client
+}
+
+var (
+	_ SpanExporter   = (*OTLPExporter)(nil)
+	_ MetricExporter = (*OTLPExporter)(nil)
+)
+
+// NewOTLPExporter returns an exporter sending through client, configured
+// through [LoadOTLPConfig].
+func NewOTLPExporter(client *http.Client) (*OTLPExporter, error) {
+	cfg, err := LoadOTLPConfig()
+	if err != nil {
+		return nil, err
+	}
+	return NewOTLPExporterFromConfig(client, cfg)
+}
+
+// NewOTLPExporterFromConfig returns an exporter sending through client, configured by cfg.
+func NewOTLPExporterFromConfig(client *http.Client, cfg OTLPConfig) (*OTLPExporter, error) {
+	header := http.Header{}
+	for _, kv := range cfg.Headers {
+		key, value, ok := strings.Cut(kv, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid OTLP header %q", kv)
+		}
+		header.Add(strings.TrimSpace(key), strings.TrimSpace(value))
+	}
+
+	signalURL := func(override, signal string) string {
+		if override != "" {
+			return override
+		}
+		return strings.TrimSuffix(cfg.Endpoint, "/") + "/v1/" + signal
+	}
+
+	return &OTLPExporter{
+		TracesURL:  signalURL(cfg.TracesEndpoint, "traces"),
+		MetricsURL: signalURL(cfg.MetricsEndpoint, "metrics"),
+		Header:     header,
+		Timeout:    cfg.Timeout,
+		Client:     client,
+	}, nil
+}
+
+// ExportSpans implements [SpanExporter].
+func (e *OTLPExporter) ExportSpans(ctx context.Context, resource []Attribute, spans []SpanData) error {
+	if len(spans) == 0 {
+		return nil
+	}
+
+	encoded := make([]otlpSpan, 0, len(spans))
+	for _, s := range spans {
+		encoded = append(encoded, encodeSpan(s))
+	}
+	return e.post(ctx, e.TracesURL, otlpTraces{
+		ResourceSpans: []otlpResourceSpans{{
+			Resource:   otlpResource{Attributes: encodeAttributes(resource)},
+			ScopeSpans: []otlpScopeSpans{{Scope: otlpScope{Name: scopeName}, Spans: encoded}},
+		}},
+	})
+}
+
+func (e *OTLPExporter) post(ctx context.Context, url string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode OTLP payload: %w", err)
+	}
+
+	if e.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.Timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for key, values := range e.Header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to export to %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("failed to export to %s: %s", url, resp.Status)
+	}
+	return nil
+}
+
+type otlpTraces struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	TraceState        string         `json:"traceState,omitempty"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Flags             uint32         `json:"flags,omitempty"`
+	Name              string         `json:"name"`
+	Kind              SpanKind       `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Events            []otlpEvent    `json:"events,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpEvent struct {
+	TimeUnixNano string         `json:"timeUnixNano"`
+	Name         string         `json:"name"`
+	Attributes   []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    StatusCode `json:"code,omitempty"`
+	Message string     `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+// otlpAnyValue is the OTLP JSON AnyValue, 64 bit integers are encoded as strings.
+type otlpAnyValue struct {
+	StringValue *string         `json:"stringValue,omitempty"`
+	BoolValue   *bool           `json:"boolValue,omitempty"`
+	IntValue    string          `json:"intValue,omitempty"`
+	DoubleValue *float64        `json:"doubleValue,omitempty"`
+	ArrayValue  *otlpArrayValue `json:"arrayValue,omitempty"`
+}
+
+type otlpArrayValue struct {
+	Values []otlpAnyValue `json:"values"`
+}
+
+func encodeSpan(s SpanData) otlpSpan {
+	span := otlpSpan{
+		TraceID:           s.SpanContext.TraceID.String(),
+		SpanID:            s.SpanContext.SpanID.String(),
+		TraceState:        s.SpanContext.TraceState,
+		Flags:             uint32(s.SpanContext.Flags),
+		Name:              s.Name,
+		Kind:              s.Kind,
+		StartTimeUnixNano: unixNano(s.Start),
+		EndTimeUnixNano:   unixNano(s.End),
+		Attributes:        encodeAttributes(s.Attributes),
+		Status:            otlpStatus{Code: s.Status.Code, Message: s.Status.Message},
+	}
+	if s.Parent.IsValid() {
+		span.ParentSpanID = s.Parent.String()
+	}
+	for _, e := range s.Events {
+		span.Events = append(span.Events, otlpEvent{
+			TimeUnixNano: unixNano(e.Time),
+			Name:         e.Name,
+			Attributes:   encodeAttributes(e.Attributes),
+		})
+	}
+	return span
+}
+
+func encodeAttributes(attrs []Attribute) []otlpKeyValue {
+	if len(attrs) == 0 {
+		return nil
+	}
+	kvs := make([]otlpKeyValue, 0, len(attrs))
+	for _, a := range attrs {
+		kvs = append(kvs, otlpKeyValue{Key: a.Key, Value: encodeValue(a.Value)})
+	}
+	return kvs
+}
+
+func encodeValue(v any) otlpAnyValue {
+	switch v := v.(type) {
+	case string:
+		return otlpAnyValue{StringValue: &v}
+	case bool:
+		return otlpAnyValue{BoolValue: &v}
+	case int64:
+		return otlpAnyValue{IntValue: strconv.FormatInt(v, 10)}
+	case float64:
+		return otlpAnyValue{DoubleValue: &v}
+	case []string:
+		values := make([]otlpAnyValue, 0, len(v))
+		for _, s := range v {
+			values = append(values, encodeValue(s))
+		}
+		return otlpAnyValue{ArrayValue: &otlpArrayValue{Values: values}}
+	default:
+		s := fmt.Sprint(v)
+		return otlpAnyValue{StringValue: &s}
+	}
+}
+
+func unixNano(t time.Time) string {
+	if t.IsZero() {
+		return "0"
+	}
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
@@ -0,0 +1,238 @@
+//go:build !wasm
+
+package wasitel
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"go.wasmcloud.dev/component/internal/fakehost"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// collector returns a client recording the requests it sends, answering with status.
+func collector(status int, requests *[]*http.Request, bodies *[]string) *http.Client {
+	return &http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		body, _ := io.ReadAll(r.Body)
+		*requests = append(*requests, r)
+		*bodies = append(*bodies, string(body))
+		return &http.Response{StatusCode: status, Status: http.StatusText(status), Body: io.NopCloser(strings.NewReader(""))}, nil
+	})}
+}
+
+func TestNewOTLPExporter(t *testing.T) {
+	tests := map[string]struct {
+		config     map[string]string
+		url        string
+		metricsURL string
+		header     http.Header
+		err        bool
+	}{
+		"default": {
+			config:     map[string]string{},
+			url:        "http://localhost:4318/v1/traces",
+			metricsURL: "http://localhost:4318/v1/metrics",
+			header:     http.Header{},
+		},
+		"endpoint": {
+			config: map[string]string{
+				"otel_exporter_otlp_endpoint": "https://collector:4318/",
+				"otel_exporter_otlp_headers":  "Authorization=Bearer t, X-Tenant = a",
+			},
+			url:    "https://collector:4318/v1/traces",
+			header: http.Header{"Authorization": {"Bearer t"}, "X-Tenant": {"a"}},
+		},
+		"signal endpoints": {
+			config: map[string]string{
+				"otel_exporter_otlp_traces_endpoint":  "https://traces/ingest",
+				"otel_exporter_otlp_metrics_endpoint": "https://metrics/ingest",
+			},
+			url:        "https://traces/ingest",
+			metricsURL: "https://metrics/ingest",
+			header:     http.Header{},
+		},
+		"invalid header": {
+			config: map[string]string{"otel_exporter_otlp_headers": "novalue"},
+			err:    true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			fakehost.Config.Values = tt.config
+			e, err := NewOTLPExporter(http.DefaultClient)
+			if (err != nil) != tt.err {
+				t.Fatalf("expected error: %v, got: %v", tt.err, err)
+			}
+			if err != nil {
+				return
+			}
+			if e.TracesURL != tt.url {
+				t.Errorf("expected: %v, got: %v", tt.url, e.TracesURL)
+			}
+			if tt.metricsURL != "" && e.MetricsURL != tt.metricsURL {
+				t.Errorf("expected: %v, got: %v", tt.metricsURL, e.MetricsURL)
+			}
+			if len(e.Header) != len(tt.header) {
+				t.Errorf("expected: %v, got: %v", tt.header, e.Header)
+			}
+			for key := range tt.header {
+				if e.Header.Get(key) != tt.header.Get(key) {
+					t.Errorf("expected: %v, got: %v", tt.header, e.Header)
+				}
+			}
+			if e.Timeout != 10*time.Second {
+				t.Errorf("expected: %v, got: %v", 10*time.Second, e.Timeout)
+			}
+		})
+	}
+}
+
+func TestOTLPExportSpans(t *testing.T) {
+	var requests []*http.Request
+	var bodies []string
+	e := &OTLPExporter{
+		TracesURL: "http://collector/v1/traces",
+		Header:    http.Header{"Authorization": {"Bearer t"}},
+		Client:    collector(http.StatusOK, &requests, &bodies),
+	}
+
+	start := time.Unix(1700000000, 5)
+	span := SpanData{
+		Name:        "GET /orders",
+		Kind:        SpanKindServer,
+		SpanContext: SpanContext{TraceID: TraceID{0: 0xab, 15: 1}, SpanID: SpanID{7: 2}, Flags: FlagsSampled},
+		Parent:      SpanID{7: 1},
+		Start:       start,
+		End:         start.Add(time.Millisecond),
+		Attributes: []Attribute{
+			String("http.route", "/orders"),
+			Int("http.response.status_code", 500),
+			Bool("retry", true),
+			Float64("ratio", 0.5),
+			Strings("tags", []string{"a"}),
+		},
+		Events: []Event{{Name: "exception", Time: start, Attributes: []Attribute{String("exception.message", "boom")}}},
+		Status: Status{Code: StatusError, Message: "boom"},
+	}
+	if err := e.ExportSpans(context.Background(), []Attribute{String("service.name", "orders")}, []SpanData{span}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(requests) != 1 {
+		t.Fatalf("expected: %v, got: %v", 1, len(requests))
+	}
+	r := requests[0]
+	if r.Method != http.MethodPost || r.URL.String() != e.TracesURL || r.Header.Get("Content-Type") != "application/json" || r.Header.Get("Authorization") != "Bearer t" {
+		t.Errorf("unexpected request: %s %s %v", r.Method, r.URL, r.Header)
+	}
+
+	var got, want any
+	if err := json.Unmarshal([]byte(bodies[0]), &got); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(`{"resourceSpans":[{
+		"resource":{"attributes":[{"key":"service.name","value":{"stringValue":"orders"}}]},
+		"scopeSpans":[{"scope":{"name":"go.wasmcloud.dev/component/telemetry/wasitel"},"spans":[{
+			"traceId":"ab000000000000000000000000000001",
+			"spanId":"0000000000000002",
+			"parentSpanId":"0000000000000001",
+			"flags":1,
+			"name":"GET /orders",
+			"kind":2,
+			"startTimeUnixNano":"1700000000000000005",
+			"endTimeUnixNano":"1700000000001000005",
+			"attributes":[
+				{"key":"http.route","value":{"stringValue":"/orders"}},
+				{"key":"http.response.status_code","value":{"intValue":"500"}},
+				{"key":"retry","value":{"boolValue":true}},
+				{"key":"ratio","value":{"doubleValue":0.5}},
+				{"key":"tags","value":{"arrayValue":{"values":[{"stringValue":"a"}]}}}
+			],
+			"events":[{"timeUnixNano":"1700000000000000005","name":"exception","attributes":[{"key":"exception.message","value":{"stringValue":"boom"}}]}],
+			"status":{"code":2,"message":"boom"}
+		}]}]
+	}]}`), &want); err != nil {
+		t.Fatal(err)
+	}
+	gotJSON, _ := json.Marshal(got)
+	wantJSON, _ := json.Marshal(want)
+	if string(gotJSON) != string(wantJSON) {
+		t.Errorf("expected: %s, got: %s", wantJSON, gotJSON)
+	}
+}
+
+func TestOTLPExportError(t *testing.T) {
+	var requests []*http.Request
+	var bodies []string
+	e := &OTLPExporter{TracesURL: "http://collector/v1/traces", Client: collector(http.StatusServiceUnavailable, &requests, &bodies)}
+
+	if err := e.ExportSpans(context.Background(), nil, []SpanData{{Name: "span"}}); err == nil {
+		t.Error("expected error")
+	}
+	if err := e.ExportSpans(context.Background(), nil, nil); err != nil || len(requests) != 1 {
+		t.Errorf("expected empty exports to be skipped, got: %v %d", err, len(requests))
+	}
+}
+
+func TestOTLPExportMetrics(t *testing.T) {
+	var requests []*http.Request
+	var bodies []string
+	e := &OTLPExporter{MetricsURL: "http://collector/v1/metrics", Client: collector(http.StatusOK, &requests, &bodies)}
+
+	start, now := time.Unix(1700000000, 0), time.Unix(1700000060, 0)
+	metrics := []MetricData{
+		{Name: "requests", Unit: "{request}", Kind: MetricSum, Points: []Point{{Attributes: []Attribute{String("method", "GET")}, Start: start, Time: now, Value: 3}}},
+		{Name: "inflight", Kind: MetricUpDownSum, Points: []Point{{Start: start, Time: now, Value: 1}}},
+		{Name: "heap", Kind: MetricGauge, Points: []Point{{Start: start, Time: now, Value: 7}}},
+		{Name: "duration", Unit: "s", Kind: MetricHistogram, Points: []Point{{
+			Start: start, Time: now, Count: 3, Sum: 2.5, Min: 0.5, Max: 1.5,
+			Bounds: []float64{1}, BucketCounts: []uint64{1, 2},
+		}}},
+	}
+	if err := e.ExportMetrics(context.Background(), []Attribute{String("service.name", "orders")}, metrics); err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) != 1 || requests[0].URL.String() != e.MetricsURL {
+		t.Fatalf("unexpected requests: %v", requests)
+	}
+
+	var got, want any
+	if err := json.Unmarshal([]byte(bodies[0]), &got); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(`{"resourceMetrics":[{
+		"resource":{"attributes":[{"key":"service.name","value":{"stringValue":"orders"}}]},
+		"scopeMetrics":[{"scope":{"name":"go.wasmcloud.dev/component/telemetry/wasitel"},"metrics":[
+			{"name":"requests","unit":"{request}","sum":{"dataPoints":[{
+				"attributes":[{"key":"method","value":{"stringValue":"GET"}}],
+				"startTimeUnixNano":"1700000000000000000","timeUnixNano":"1700000060000000000","asDouble":3
+			}],"aggregationTemporality":2,"isMonotonic":true}},
+			{"name":"inflight","sum":{"dataPoints":[{
+				"startTimeUnixNano":"1700000000000000000","timeUnixNano":"1700000060000000000","asDouble":1
+			}],"aggregationTemporality":2,"isMonotonic":false}},
+			{"name":"heap","gauge":{"dataPoints":[{"timeUnixNano":"1700000060000000000","asDouble":7}]}},
+			{"name":"duration","unit":"s","histogram":{"dataPoints":[{
+				"startTimeUnixNano":"1700000000000000000","timeUnixNano":"1700000060000000000",
+				"count":"3","sum":2.5,"bucketCounts":["1","2"],"explicitBounds":[1],"min":0.5,"max":1.5
+			}],"aggregationTemporality":2}}
+		]}]
+	}]}`), &want); err != nil {
+		t.Fatal(err)
+	}
+	gotJSON, _ := json.Marshal(got)
+	wantJSON, _ := json.Marshal(want)
+	if string(gotJSON) != string(wantJSON) {
+		t.Errorf("expected: %s, got: %s", wantJSON, gotJSON)
+	}
+}
@@ -0,0 +1,136 @@
+package wasitel
+
+import (
+	"context"
+	"strconv"
+)
+
+// aggregationCumulative is the OTLP AGGREGATION_TEMPORALITY_CUMULATIVE.
+const aggregationCumulative = 2
+
+// ExportMetrics implements [MetricExporter].
+func (e *OTLPExporter) ExportMetrics(ctx context.Context, resource []Attribute, metrics []MetricData) error {
+	if len(metrics) == 0 {
+		return nil
+	}
+
+	encoded := make([]otlpMetric, 0, len(metrics))
+	for _, m := range metrics {
+		encoded = append(encoded, encodeMetric(m))
+	}
+	return e.post(ctx, e.MetricsURL, otlpMetrics{
+		ResourceMetrics: []otlpResourceMetrics{{
+			Resource:     otlpResource{Attributes: encodeAttributes(resource)},
+			ScopeMetrics: []otlpScopeMetrics{{Scope: otlpScope{Name: scopeName}, Metrics: encoded}},
+		}},
+	})
+}
+
+type otlpMetrics struct {
+	ResourceMetrics []otlpResourceMetrics `json:"resourceMetrics"`
+}
+
+type otlpResourceMetrics struct {
+	Resource     otlpResource       `json:"resource"`
+	ScopeMetrics []otlpScopeMetrics `json:"scopeMetrics"`
+}
+
+type otlpScopeMetrics struct {
+	Scope   otlpScope    `json:"scope"`
+	Metrics []otlpMetric `json:"metrics"`
+}
+
+type otlpMetric struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Unit        string         `json:"unit,omitempty"`
+	Sum         *otlpSum       `json:"sum,omitempty"`
+	Gauge       *otlpGauge     `json:"gauge,omitempty"`
+	Histogram   *otlpHistogram `json:"histogram,omitempty"`
+}
+
+type otlpSum struct {
+	DataPoints             []otlpNumberPoint `json:"dataPoints"`
+	AggregationTemporality int               `json:"aggregationTemporality"`
+	IsMonotonic            bool              `json:"isMonotonic"`
+}
+
+type otlpGauge struct {
+	DataPoints []otlpNumberPoint `json:"dataPoints"`
+}
+
+type otlpHistogram struct {
+	DataPoints             []otlpHistogramPoint `json:"dataPoints"`
+	AggregationTemporality int                  `json:"aggregationTemporality"`
+}
+
+type otlpNumberPoint struct {
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano,omitempty"`
+	TimeUnixNano      string         `json:"timeUnixNano"`
+	AsDouble          float64        `json:"asDouble"`
+}
+
+// otlpHistogramPoint encodes the 64 bit counts as strings, as OTLP JSON requires.
+type otlpHistogramPoint struct {
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	TimeUnixNano      string         `json:"timeUnixNano"`
+	Count             string         `json:"count"`
+	Sum               float64        `json:"sum"`
+	BucketCounts      []string       `json:"bucketCounts"`
+	ExplicitBounds    []float64      `json:"explicitBounds"`
+	Min               float64        `json:"min"`
+	Max               float64        `json:"max"`
+}
+
+func encodeMetric(m MetricData) otlpMetric {
+	metric := otlpMetric{Name: m.Name, Description: m.Description, Unit: m.Unit}
+	switch m.Kind {
+	case MetricSum, MetricUpDownSum:
+		metric.Sum = &otlpSum{
+			DataPoints:             encodeNumberPoints(m.Points, true),
+			AggregationTemporality: aggregationCumulative,
+			IsMonotonic:            m.Kind == MetricSum,
+		}
+	case MetricGauge:
+		metric.Gauge = &otlpGauge{DataPoints: encodeNumberPoints(m.Points, false)}
+	case MetricHistogram:
+		points := make([]otlpHistogramPoint, 0, len(m.Points))
+		for _, p := range m.Points {
+			counts := make([]string, 0, len(p.BucketCounts))
+			for _, n := range p.BucketCounts {
+				counts = append(counts, strconv.FormatUint(n, 10))
+			}
+			points = append(points, otlpHistogramPoint{
+				Attributes:        encodeAttributes(p.Attributes),
+				StartTimeUnixNano: unixNano(p.Start),
+				TimeUnixNano:      unixNano(p.Time),
+				Count:             strconv.FormatUint(p.Count, 10),
+				Sum:               p.Sum,
+				BucketCounts:      counts,
+				ExplicitBounds:    p.Bounds,
+				Min:               p.Min,
+				Max:               p.Max,
+			})
+		}
+		metric.Histogram = &otlpHistogram{DataPoints: points, AggregationTemporality: aggregationCumulative}
+	}
+	return metric
+}
+
+func encodeNumberPoints(points []Point, cumulative bool) []otlpNumberPoint {
+	encoded := make([]otlpNumberPoint, 0, len(points))
+	for _, p := range points {
+		point := otlpNumberPoint{
+			Attributes:   encodeAttributes(p.Attributes),
+			TimeUnixNano: unixNano(p.Time),
+			AsDouble:     p.Value,
+		}
+		if cumulative {
+			point.StartTimeUnixNano = unixNano(p.Start)
+		}
+		encoded = append(encoded, point)
+	}
+	return encoded
+}
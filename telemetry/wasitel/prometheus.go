@@ -0,0 +1,164 @@
+package wasitel
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// PrometheusContentType is the content type of the Prometheus text format.
+const PrometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// PrometheusHandler returns a handler rendering the metrics of m in the
+// Prometheus text exposition format, for components scraped through their
+// HTTP export rather than pushing OTLP. Sums and counters are cumulative since
+// the meter was created.
+//
+// Metric and label names are converted to Prometheus conventions: dots become
+// underscores, the unit is appended as a suffix and counters end in _total,
+// e.g. http.server.request.duration in "s" is exposed as
+// http_server_request_duration_seconds.
+func PrometheusHandler(m *Meter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", PrometheusContentType)
+		bw := bufio.NewWriter(w)
+		for _, metric := range m.Collect() {
+			writePrometheus(bw, metric)
+		}
+		bw.Flush()
+	})
+}
+
+func writePrometheus(w *bufio.Writer, metric MetricData) {
+	name := prometheusName(metric.Name, metric.Unit)
+	typ := "gauge"
+	switch metric.Kind {
+	case MetricSum:
+		typ = "counter"
+		name += "_total"
+	case MetricHistogram:
+		typ = "histogram"
+	}
+
+	if metric.Description != "" {
+		fmt.Fprintf(w, "# HELP %s %s\n", name, escapeHelp(metric.Description))
+	}
+	fmt.Fprintf(w, "# TYPE %s %s\n", name, typ)
+
+	for _, p := range metric.Points {
+		labels := prometheusLabels(p.Attributes)
+		if metric.Kind != MetricHistogram {
+			writeSample(w, name, labels, "", p.Value)
+			continue
+		}
+
+		var cumulative uint64
+		for i, bound := range p.Bounds {
+			cumulative += p.BucketCounts[i]
+			writeSample(w, name+"_bucket", labels, formatFloat(bound), float64(cumulative))
+		}
+		writeSample(w, name+"_bucket", labels, "+Inf", float64(p.Count))
+		writeSample(w, name+"_sum", labels, "", p.Sum)
+		writeSample(w, name+"_count", labels, "", float64(p.Count))
+	}
+}
+
+func writeSample(w *bufio.Writer, name string, labels []string, le string, value float64) {
+	w.WriteString(name)
+	if le != "" {
+		labels = append(labels[:len(labels):len(labels)], `le="`+le+`"`)
+	}
+	if len(labels) > 0 {
+		w.WriteByte('{')
+		w.WriteString(strings.Join(labels, ","))
+		w.WriteByte('}')
+	}
+	w.WriteByte(' ')
+	w.WriteString(formatFloat(value))
+	w.WriteByte('\n')
+}
+
+func prometheusLabels(attrs []Attribute) []string {
+	labels := make([]string, 0, len(attrs))
+	for _, attr := range attrs {
+		labels = append(labels, sanitizeName(attr.Key)+`="`+escapeLabel(formatAttribute(attr.Value))+`"`)
+	}
+	return labels
+}
+
+// prometheusUnits are the suffixes of common UCUM units.
+var prometheusUnits = map[string]string{
+	"s":    "seconds",
+	"ms":   "milliseconds",
+	"us":   "microseconds",
+	"ns":   "nanoseconds",
+	"By":   "bytes",
+	"KiBy": "kibibytes",
+	"MiBy": "mebibytes",
+	"1":    "ratio",
+	"%":    "percent",
+}
+
+// prometheusName returns the Prometheus metric name of an OpenTelemetry
+// metric, annotations in curly braces are not units and are dropped.
+func prometheusName(name, unit string) string {
+	name = sanitizeName(name)
+	if suffix, ok := prometheusUnits[unit]; ok && !strings.HasSuffix(name, "_"+suffix) {
+		name += "_" + suffix
+	}
+	return name
+}
+
+func sanitizeName(name string) string {
+	b := []byte(name)
+	for i, c := range b {
+		if !(c == '_' || c == ':' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9') {
+			b[i] = '_'
+		}
+	}
+	if len(b) > 0 && '0' <= b[0] && b[0] <= '9' {
+		return "_" + string(b)
+	}
+	return string(b)
+}
+
+func formatAttribute(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []string:
+		return strings.Join(v, ",")
+	case float64:
+		return formatFloat(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+var (
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string {
+	return helpEscaper.Replace(s)
+}
+
+func escapeLabel(s string) string {
+	return labelEscaper.Replace(s)
+}
@@ -0,0 +1,69 @@
+package wasitel
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPrometheusHandler(t *testing.T) {
+	noSleep(t)
+
+	m := NewMeter(nil, MeterOptions{})
+	requests := m.Counter("http.server.request.count", "Number of requests.", "{request}")
+	requests.Add(2, String("method", "GET"), Int("status", 200))
+	requests.Add(1, String("method", "POST"), String("path", "/a\"b\\c\n"))
+	m.UpDownCounter("queue.depth", "", "").Add(3)
+	m.Gauge("memory.heap", "Heap\nsize.", "By").Set(1024)
+	duration := m.Histogram("http.server.request.duration", "", "s", 0.1, 1)
+	for _, v := range []float64{0.05, 0.5, 0.5, 5} {
+		duration.Record(v)
+	}
+
+	rec := httptest.NewRecorder()
+	PrometheusHandler(m).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if got := rec.Header().Get("Content-Type"); got != PrometheusContentType {
+		t.Errorf("expected: %v, got: %v", PrometheusContentType, got)
+	}
+	want := `# HELP http_server_request_count_total Number of requests.
+# TYPE http_server_request_count_total counter
+http_server_request_count_total{method="GET",status="200"} 2
+http_server_request_count_total{method="POST",path="/a\"b\\c\n"} 1
+# TYPE queue_depth gauge
+queue_depth 3
+# HELP memory_heap_bytes Heap\nsize.
+# TYPE memory_heap_bytes gauge
+memory_heap_bytes 1024
+# TYPE http_server_request_duration_seconds histogram
+http_server_request_duration_seconds_bucket{le="0.1"} 1
+http_server_request_duration_seconds_bucket{le="1"} 3
+http_server_request_duration_seconds_bucket{le="+Inf"} 4
+http_server_request_duration_seconds_sum 6.05
+http_server_request_duration_seconds_count 4
+`
+	if got := rec.Body.String(); got != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestPrometheusName(t *testing.T) {
+	tests := map[string]struct {
+		name, unit, want string
+	}{
+		"dots":            {name: "http.server.duration", unit: "s", want: "http_server_duration_seconds"},
+		"suffix present":  {name: "go.memory.heap_bytes", unit: "By", want: "go_memory_heap_bytes"},
+		"annotation":      {name: "go.goroutine.count", unit: "{goroutine}", want: "go_goroutine_count"},
+		"leading digit":   {name: "1xx", want: "_1xx"},
+		"invalid runes":   {name: "a-b/c", unit: "1", want: "a_b_c_ratio"},
+		"colons are kept": {name: "job:rate", want: "job:rate"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := prometheusName(tt.name, tt.unit); got != tt.want {
+				t.Errorf("expected: %v, got: %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package wasitel
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// W3C trace context and baggage headers.
+const (
+	TraceParentHeader = "Traceparent"
+	TraceStateHeader  = "Tracestate"
+	BaggageHeader     = "Baggage"
+)
+
+const (
+	maxBaggageMembers = 180
+	maxBaggageBytes   = 8192
+)
+
+var (
+	ErrInvalidTraceParent = errors.New("wasitel: invalid traceparent")
+	ErrInvalidBaggage     = errors.New("wasitel: invalid baggage")
+)
+
+// ParseTraceParent parses a W3C traceparent header value.
+func ParseTraceParent(traceparent string) (SpanContext, error) {
+	parts := strings.Split(strings.TrimSpace(traceparent), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return SpanContext{}, ErrInvalidTraceParent
+	}
+	// NOTE: future versions may append fields, version 00 may not
+	if parts[0] == "ff" || (parts[0] == "00" && len(parts) != 4) {
+		return SpanContext{}, ErrInvalidTraceParent
+	}
+	for _, part := range parts[:4] {
+		if strings.Trim(part, "0123456789abcdef") != "" {
+			return SpanContext{}, ErrInvalidTraceParent
+		}
+	}
+
+	var sc SpanContext
+	var flags [1]byte
+	hex.Decode(sc.TraceID[:], []byte(parts[1]))
+	hex.Decode(sc.SpanID[:], []byte(parts[2]))
+	hex.Decode(flags[:], []byte(parts[3]))
+	sc.Flags = flags[0] & FlagsSampled
+	if !sc.IsValid() {
+		return SpanContext{}, ErrInvalidTraceParent
+	}
+	return sc, nil
+}
+
+// TraceParent formats sc as a W3C traceparent header value.
+func (sc SpanContext) TraceParent() string {
+	return "00-" + sc.TraceID.String() + "-" + sc.SpanID.String() + "-" + hex.EncodeToString([]byte{sc.Flags & FlagsSampled})
+}
+
+// Baggage is a set of W3C baggage entries propagated with the trace context.
+type Baggage map[string]string
+
+type baggageContextKey struct{}
+
+// ContextWithBaggage returns a copy of ctx carrying b.
+func ContextWithBaggage(ctx context.Context, b Baggage) context.Context {
+	return context.WithValue(ctx, baggageContextKey{}, b)
+}
+
+// BaggageFromContext returns the baggage carried by ctx, or nil.
+func BaggageFromContext(ctx context.Context) Baggage {
+	b, _ := ctx.Value(baggageContextKey{}).(Baggage)
+	return b
+}
+
+// ParseBaggage parses a W3C baggage header value. Member properties are
+// dropped.
+func ParseBaggage(header string) (Baggage, error) {
+	if len(header) > maxBaggageBytes {
+		return nil, ErrInvalidBaggage
+	}
+
+	b := Baggage{}
+	for _, member := range strings.Split(header, ",") {
+		if strings.TrimSpace(member) == "" {
+			continue
+		}
+		member, _, _ = strings.Cut(member, ";")
+		key, value, ok := strings.Cut(member, "=")
+		key = strings.TrimSpace(key)
+		if !ok || !isToken(key) {
+			return nil, ErrInvalidBaggage
+		}
+		value, err := url.PathUnescape(strings.TrimSpace(value))
+		if err != nil {
+			return nil, ErrInvalidBaggage
+		}
+		b[key] = value
+	}
+	if len(b) > maxBaggageMembers {
+		return nil, ErrInvalidBaggage
+	}
+	return b, nil
+}
+
+// String formats b as a W3C baggage header value, with keys sorted.
+func (b Baggage) String() string {
+	keys := make([]string, 0, len(b))
+	for key := range b {
+		if isToken(key) {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	members := make([]string, 0, len(keys))
+	for _, key := range keys {
+		members = append(members, key+"="+url.PathEscape(b[key]))
+	}
+	return strings.Join(members, ",")
+}
+
+// Extract returns a copy of ctx carrying the remote span context and baggage
+// of header. Invalid headers are ignored.
+func Extract(ctx context.Context, header http.Header) context.Context {
+	if sc, err := ParseTraceParent(header.Get(TraceParentHeader)); err == nil {
+		sc.TraceState = strings.Join(header.Values(TraceStateHeader), ",")
+		ctx = ContextWithRemoteSpanContext(ctx, sc)
+	}
+	if values := header.Values(BaggageHeader); len(values) > 0 {
+		if b, err := ParseBaggage(strings.Join(values, ",")); err == nil && len(b) > 0 {
+			ctx = ContextWithBaggage(ctx, b)
+		}
+	}
+	return ctx
+}
+
+// Inject sets the trace context and baggage headers of the span context and
+// baggage carried by ctx. Headers are left untouched if ctx carries none.
+func Inject(ctx context.Context, header http.Header) {
+	if sc := SpanContextFromContext(ctx); sc.IsValid() {
+		header.Set(TraceParentHeader, sc.TraceParent())
+		if sc.TraceState != "" {
+			header.Set(TraceStateHeader, sc.TraceState)
+		} else {
+			header.Del(TraceStateHeader)
+		}
+	}
+	if b := BaggageFromContext(ctx); len(b) > 0 {
+		header.Set(BaggageHeader, b.String())
+	}
+}
+
+// isToken reports whether s is a non-empty RFC 7230 token.
+func isToken(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range []byte(s) {
+		if c <= ' ' || c >= 0x7f || strings.IndexByte(`"(),/:;<=>?@[\]{}`, c) >= 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,124 @@
+package wasitel
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"net/http"
+	"testing"
+)
+
+func TestParseTraceParent(t *testing.T) {
+	tests := map[string]struct {
+		traceparent string
+		want        string
+		sampled     bool
+		err         error
+	}{
+		"sampled":          {traceparent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", want: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", sampled: true},
+		"not sampled":      {traceparent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00", want: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00"},
+		"unknown flags":    {traceparent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-03", want: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", sampled: true},
+		"future version":   {traceparent: "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", want: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", sampled: true},
+		"version 00 extra": {traceparent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", err: ErrInvalidTraceParent},
+		"version ff":       {traceparent: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", err: ErrInvalidTraceParent},
+		"uppercase":        {traceparent: "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01", err: ErrInvalidTraceParent},
+		"zero trace":       {traceparent: "00-00000000000000000000000000000000-00f067aa0ba902b7-01", err: ErrInvalidTraceParent},
+		"zero span":        {traceparent: "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", err: ErrInvalidTraceParent},
+		"short":            {traceparent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7", err: ErrInvalidTraceParent},
+		"empty":            {err: ErrInvalidTraceParent},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			sc, err := ParseTraceParent(tt.traceparent)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected: %v, got: %v", tt.err, err)
+			}
+			if err != nil {
+				return
+			}
+			if got := sc.TraceParent(); got != tt.want {
+				t.Errorf("expected: %v, got: %v", tt.want, got)
+			}
+			if sc.IsSampled() != tt.sampled {
+				t.Errorf("expected: %v, got: %v", tt.sampled, sc.IsSampled())
+			}
+		})
+	}
+}
+
+func TestParseBaggage(t *testing.T) {
+	tests := map[string]struct {
+		header string
+		want   Baggage
+		err    error
+	}{
+		"members":    {header: "userId=alice, serverNode=DF%2028 ,isProduction=false", want: Baggage{"userId": "alice", "serverNode": "DF 28", "isProduction": "false"}},
+		"properties": {header: "userId=alice;ttl=60", want: Baggage{"userId": "alice"}},
+		"empty":      {header: "", want: Baggage{}},
+		"no value":   {header: "userId", err: ErrInvalidBaggage},
+		"bad key":    {header: "user id=alice", err: ErrInvalidBaggage},
+		"bad escape": {header: "userId=%zz", err: ErrInvalidBaggage},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			b, err := ParseBaggage(tt.header)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected: %v, got: %v", tt.err, err)
+			}
+			if !maps.Equal(b, tt.want) {
+				t.Errorf("expected: %v, got: %v", tt.want, b)
+			}
+		})
+	}
+
+	if got, want := (Baggage{"b": "x y", "a": "1"}).String(), "a=1,b=x%20y"; got != want {
+		t.Errorf("expected: %v, got: %v", want, got)
+	}
+}
+
+func TestPropagation(t *testing.T) {
+	noSleep(t)
+
+	incoming := http.Header{}
+	incoming.Set(TraceParentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	incoming.Add(TraceStateHeader, "vendor=a")
+	incoming.Add(TraceStateHeader, "other=b")
+	incoming.Set(BaggageHeader, "userId=alice")
+
+	ctx := Extract(context.Background(), incoming)
+	sc := SpanContextFromContext(ctx)
+	if !sc.Remote || sc.TraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" || sc.TraceState != "vendor=a,other=b" {
+		t.Fatalf("unexpected span context: %+v", sc)
+	}
+
+	// without a span the remote context is forwarded as is
+	outgoing := http.Header{}
+	Inject(ctx, outgoing)
+	for key, want := range map[string]string{
+		TraceParentHeader: incoming.Get(TraceParentHeader),
+		TraceStateHeader:  "vendor=a,other=b",
+		BaggageHeader:     "userId=alice",
+	} {
+		if got := outgoing.Get(key); got != want {
+			t.Errorf("expected %s: %v, got: %v", key, want, got)
+		}
+	}
+
+	tracer := NewTracer(&recorder{}, TracerOptions{})
+	ctx, span := tracer.Start(ctx, "handle", SpanKindServer)
+	defer span.End()
+
+	outgoing = http.Header{}
+	Inject(ctx, outgoing)
+	if want := span.SpanContext().TraceParent(); outgoing.Get(TraceParentHeader) != want {
+		t.Errorf("expected: %v, got: %v", want, outgoing.Get(TraceParentHeader))
+	}
+
+	empty := http.Header{}
+	Inject(Extract(context.Background(), http.Header{TraceParentHeader: {"garbage"}}), empty)
+	if len(empty) != 0 {
+		t.Errorf("expected no headers, got: %v", empty)
+	}
+}
@@ -0,0 +1,35 @@
+package wasitel
+
+import (
+	"runtime"
+	"time"
+)
+
+// RegisterRuntimeMetrics registers gauges observing the Go runtime on m,
+// collected with every export:
+//   - go.memory.heap: bytes of allocated heap objects
+//   - go.memory.sys: bytes of memory obtained from the host
+//   - go.gc.count: completed GC cycles
+//   - go.gc.pause_total: cumulative GC pause time, in seconds
+//   - go.goroutine.count: live goroutines
+//
+// NOTE: TinyGo fills a subset of runtime.MemStats, pause times in particular
+// are reported as zero by its collectors.
+func RegisterRuntimeMetrics(m *Meter) {
+	heap := m.Gauge("go.memory.heap", "Bytes of allocated heap objects.", "By")
+	sys := m.Gauge("go.memory.sys", "Bytes of memory obtained from the host.", "By")
+	gcCount := m.Gauge("go.gc.count", "Completed GC cycles.", "{cycle}")
+	gcPause := m.Gauge("go.gc.pause_total", "Cumulative GC pause time.", "s")
+	goroutines := m.Gauge("go.goroutine.count", "Live goroutines.", "{goroutine}")
+
+	m.RegisterCallback(func() {
+		var stats runtime.MemStats
+		runtime.ReadMemStats(&stats)
+
+		heap.Set(float64(stats.HeapAlloc))
+		sys.Set(float64(stats.Sys))
+		gcCount.Set(float64(stats.NumGC))
+		gcPause.Set(time.Duration(stats.PauseTotalNs).Seconds())
+		goroutines.Set(float64(runtime.NumGoroutine()))
+	})
+}
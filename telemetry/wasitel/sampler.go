@@ -0,0 +1,57 @@
+package wasitel
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// Sampler decides whether a span is recorded and exported.
+type Sampler interface {
+	// ShouldSample is called with the parent span context, which is invalid for
+	// root spans, and the trace ID of the span to start.
+	ShouldSample(parent SpanContext, traceID TraceID, name string, kind SpanKind) bool
+}
+
+// SamplerFunc adapts a function to a [Sampler].
+type SamplerFunc func(parent SpanContext, traceID TraceID, name string, kind SpanKind) bool
+
+func (f SamplerFunc) ShouldSample(parent SpanContext, traceID TraceID, name string, kind SpanKind) bool {
+	return f(parent, traceID, name, kind)
+}
+
+// AlwaysSample returns a Sampler recording every span.
+func AlwaysSample() Sampler {
+	return SamplerFunc(func(SpanContext, TraceID, string, SpanKind) bool { return true })
+}
+
+// NeverSample returns a Sampler recording no span.
+func NeverSample() Sampler {
+	return SamplerFunc(func(SpanContext, TraceID, string, SpanKind) bool { return false })
+}
+
+// TraceIDRatioBased returns a Sampler recording the given fraction of traces,
+// deciding on the trace ID so that all spans of a trace agree.
+func TraceIDRatioBased(fraction float64) Sampler {
+	if fraction >= 1 {
+		return AlwaysSample()
+	}
+	if fraction <= 0 {
+		return NeverSample()
+	}
+	bound := uint64(fraction * math.MaxUint64)
+	return SamplerFunc(func(_ SpanContext, traceID TraceID, _ string, _ SpanKind) bool {
+		// NOTE: the low 8 bytes are random for W3C trace IDs
+		return binary.BigEndian.Uint64(traceID[8:]) < bound
+	})
+}
+
+// ParentBased returns a Sampler following the decision of the parent span,
+// root spans are sampled by root.
+func ParentBased(root Sampler) Sampler {
+	return SamplerFunc(func(parent SpanContext, traceID TraceID, name string, kind SpanKind) bool {
+		if parent.IsValid() {
+			return parent.IsSampled()
+		}
+		return root.ShouldSample(parent, traceID, name, kind)
+	})
+}
@@ -0,0 +1,439 @@
+package wasitel
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"go.wasmcloud.dev/component/rand/wasirand"
+	"go.wasmcloud.dev/component/time/wasiclock"
+)
+
+// TraceID identifies a trace.
+type TraceID [16]byte
+
+// IsValid reports whether the ID is not all zeros.
+func (id TraceID) IsValid() bool {
+	return id != TraceID{}
+}
+
+func (id TraceID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// SpanID identifies a span within a trace.
+type SpanID [8]byte
+
+// IsValid reports whether the ID is not all zeros.
+func (id SpanID) IsValid() bool {
+	return id != SpanID{}
+}
+
+func (id SpanID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// FlagsSampled is the trace flag set on sampled spans.
+const FlagsSampled = 0x01
+
+// SpanContext is the part of a span propagated across process boundaries.
+type SpanContext struct {
+	TraceID    TraceID
+	SpanID     SpanID
+	Flags      byte
+	TraceState string
+	// Remote is set on span contexts extracted from an incoming request.
+	Remote bool
+}
+
+// IsValid reports whether both the trace and span ID are valid.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid() && sc.SpanID.IsValid()
+}
+
+// IsSampled reports whether the sampled flag is set.
+func (sc SpanContext) IsSampled() bool {
+	return sc.Flags&FlagsSampled != 0
+}
+
+// SpanKind describes the relationship of a span to its parent and children.
+type SpanKind int
+
+const (
+	SpanKindInternal SpanKind = iota + 1
+	SpanKindServer
+	SpanKindClient
+	SpanKindProducer
+	SpanKindConsumer
+)
+
+// StatusCode is the status of a span.
+type StatusCode int
+
+const (
+	StatusUnset StatusCode = iota
+	StatusOK
+	StatusError
+)
+
+// Status is a span status and its description, set for errors only.
+type Status struct {
+	Code    StatusCode
+	Message string
+}
+
+// Event is a timestamped annotation of a span.
+type Event struct {
+	Name       string
+	Time       time.Time
+	Attributes []Attribute
+}
+
+// SpanData is the snapshot of an ended span handed to a [SpanExporter].
+type SpanData struct {
+	Name        string
+	Kind        SpanKind
+	SpanContext SpanContext
+	Parent      SpanID
+	Start       time.Time
+	End         time.Time
+	Attributes  []Attribute
+	Events      []Event
+	Status      Status
+}
+
+// Span is an operation within a trace. Spans not sampled only carry their
+// SpanContext for propagation, recording on them is a no-op.
+type Span struct {
+	tracer *Tracer
+
+	mu    sync.Mutex
+	data  SpanData
+	ended bool
+}
+
+// SpanContext returns the span context.
+func (s *Span) SpanContext() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+	return s.data.SpanContext
+}
+
+// Tracer returns the tracer that started the span.
+func (s *Span) Tracer() *Tracer {
+	if s == nil {
+		return nil
+	}
+	return s.tracer
+}
+
+// IsRecording reports whether the span is sampled and not ended.
+func (s *Span) IsRecording() bool {
+	if s == nil || !s.data.SpanContext.IsSampled() {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return !s.ended
+}
+
+// SetName replaces the span name, e.g. once the route of a request is known.
+func (s *Span) SetName(name string) {
+	s.record(func(d *SpanData) { d.Name = name })
+}
+
+// SetAttributes adds attributes to the span.
+func (s *Span) SetAttributes(attrs ...Attribute) {
+	s.record(func(d *SpanData) { d.Attributes = append(d.Attributes, attrs...) })
+}
+
+// AddEvent adds an event at the current time to the span.
+func (s *Span) AddEvent(name string, attrs ...Attribute) {
+	now := wasiclock.Now()
+	s.record(func(d *SpanData) {
+		d.Events = append(d.Events, Event{Name: name, Time: now, Attributes: attrs})
+	})
+}
+
+// RecordError adds an exception event for err and sets the span status to
+// [StatusError]. A nil err is ignored.
+func (s *Span) RecordError(err error) {
+	if err == nil {
+		return
+	}
+	s.AddEvent("exception", String("exception.message", err.Error()))
+	s.SetStatus(StatusError, err.Error())
+}
+
+// SetStatus sets the span status, the message is only kept for [StatusError].
+// An OK status is final.
+func (s *Span) SetStatus(code StatusCode, message string) {
+	s.record(func(d *SpanData) {
+		if d.Status.Code == StatusOK {
+			return
+		}
+		if code != StatusError {
+			message = ""
+		}
+		d.Status = Status{Code: code, Message: message}
+	})
+}
+
+// End ends the span, queueing it for export. Calls after the first are no-ops.
+func (s *Span) End() {
+	if s == nil || !s.data.SpanContext.IsSampled() {
+		return
+	}
+
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.data.End = wasiclock.Now()
+	data := s.data
+	s.mu.Unlock()
+
+	s.tracer.enqueue(data)
+}
+
+func (s *Span) record(fn func(*SpanData)) {
+	if s == nil || !s.data.SpanContext.IsSampled() {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.ended {
+		fn(&s.data)
+	}
+}
+
+// newResource returns the resource attributes, service.name first.
+func newResource(serviceName string, attrs []Attribute) []Attribute {
+	resource := make([]Attribute, 0, len(attrs)+1)
+	if serviceName != "" {
+		resource = append(resource, String("service.name", serviceName))
+	}
+	return append(resource, attrs...)
+}
+
+// sleep waits for d or until ctx is done.
+var sleep = func(ctx context.Context, d time.Duration) error {
+	timer := wasiclock.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+type spanContextKey struct{}
+
+type remoteContextKey struct{}
+
+// ContextWithSpan returns a copy of ctx carrying span as the active span.
+func ContextWithSpan(ctx context.Context, span *Span) context.Context {
+	return context.WithValue(ctx, spanContextKey{}, span)
+}
+
+// SpanFromContext returns the active span of ctx, or nil.
+func SpanFromContext(ctx context.Context) *Span {
+	span, _ := ctx.Value(spanContextKey{}).(*Span)
+	return span
+}
+
+// ContextWithRemoteSpanContext returns a copy of ctx carrying sc as the parent
+// of spans started from it, sc is marked as remote.
+func ContextWithRemoteSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	sc.Remote = true
+	return context.WithValue(ctx, remoteContextKey{}, sc)
+}
+
+// SpanContextFromContext returns the span context of the active span of ctx,
+// or the remote span context it carries.
+func SpanContextFromContext(ctx context.Context) SpanContext {
+	if span := SpanFromContext(ctx); span != nil {
+		return span.SpanContext()
+	}
+	sc, _ := ctx.Value(remoteContextKey{}).(SpanContext)
+	return sc
+}
+
+// SpanExporter ships ended spans to a backend.
+type SpanExporter interface {
+	ExportSpans(ctx context.Context, resource []Attribute, spans []SpanData) error
+}
+
+// SpanExporterFunc adapts a function to a [SpanExporter].
+type SpanExporterFunc func(ctx context.Context, resource []Attribute, spans []SpanData) error
+
+func (f SpanExporterFunc) ExportSpans(ctx context.Context, resource []Attribute, spans []SpanData) error {
+	return f(ctx, resource, spans)
+}
+
+const (
+	// DefaultBatchSize is the number of spans exported at once.
+	DefaultBatchSize = 512
+	// DefaultMaxQueueSize is the number of spans queued before spans are dropped.
+	DefaultMaxQueueSize = 2048
+	// DefaultBatchTimeout is the time after which a partial batch is exported.
+	DefaultBatchTimeout = 5 * time.Second
+)
+
+// TracerOptions configure a [Tracer].
+type TracerOptions struct {
+	// ServiceName sets the service.name resource attribute.
+	ServiceName string
+	// Resource are additional attributes describing the component.
+	Resource []Attribute
+	// Sampler decides which traces are recorded, defaults to
+	// ParentBased(AlwaysSample()).
+	Sampler Sampler
+	// BatchSize defaults to [DefaultBatchSize].
+	BatchSize int
+	// MaxQueueSize defaults to [DefaultMaxQueueSize].
+	MaxQueueSize int
+	// BatchTimeout defaults to [DefaultBatchTimeout].
+	BatchTimeout time.Duration
+	// OnError is called with errors of exports started in the background.
+	OnError func(error)
+}
+
+// Tracer starts spans and exports them in batches, once a batch is full or
+// the batch timeout passed on a `wasi:clocks` timer.
+//
+// NOTE: component instances may be torn down as soon as a handler returns,
+// call [Tracer.Flush] before returning to export the queued spans.
+type Tracer struct {
+	exporter SpanExporter
+	resource []Attribute
+	sampler  Sampler
+	opts     TracerOptions
+	sleep    func(ctx context.Context, d time.Duration) error
+
+	mu      sync.Mutex
+	queue   []SpanData
+	armed   bool
+	dropped int
+}
+
+// ErrQueueFull is reported to TracerOptions.OnError when spans are dropped.
+var ErrQueueFull = errors.New("wasitel: span queue full, spans dropped")
+
+// NewTracer returns a Tracer exporting spans to exporter.
+func NewTracer(exporter SpanExporter, opts TracerOptions) *Tracer {
+	if opts.Sampler == nil {
+		opts.Sampler = ParentBased(AlwaysSample())
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultBatchSize
+	}
+	if opts.MaxQueueSize <= 0 {
+		opts.MaxQueueSize = DefaultMaxQueueSize
+	}
+	if opts.BatchTimeout <= 0 {
+		opts.BatchTimeout = DefaultBatchTimeout
+	}
+
+	return &Tracer{
+		exporter: exporter,
+		resource: newResource(opts.ServiceName, opts.Resource),
+		sampler:  opts.Sampler,
+		opts:     opts,
+		sleep:    sleep,
+	}
+}
+
+// Start starts a span as a child of the active or remote span of ctx,
+// returning a copy of ctx with the span active. The sampling decision of
+// the sampler is recorded in the span context flags.
+func (t *Tracer) Start(ctx context.Context, name string, kind SpanKind, attrs ...Attribute) (context.Context, *Span) {
+	parent := SpanContextFromContext(ctx)
+
+	sc := SpanContext{TraceID: parent.TraceID, TraceState: parent.TraceState}
+	if !parent.IsValid() {
+		wasirand.Read(sc.TraceID[:])
+		sc.TraceState = ""
+	}
+	wasirand.Read(sc.SpanID[:])
+	if t.sampler.ShouldSample(parent, sc.TraceID, name, kind) {
+		sc.Flags |= FlagsSampled
+	}
+
+	span := &Span{
+		tracer: t,
+		data: SpanData{
+			Name:        name,
+			Kind:        kind,
+			SpanContext: sc,
+			Start:       wasiclock.Now(),
+			Attributes:  attrs,
+		},
+	}
+	if parent.IsValid() {
+		span.data.Parent = parent.SpanID
+	}
+	return ContextWithSpan(ctx, span), span
+}
+
+func (t *Tracer) enqueue(data SpanData) {
+	t.mu.Lock()
+	if len(t.queue) >= t.opts.MaxQueueSize {
+		t.dropped++
+		t.mu.Unlock()
+		return
+	}
+	t.queue = append(t.queue, data)
+
+	full := len(t.queue) >= t.opts.BatchSize
+	arm := !full && !t.armed
+	if arm {
+		t.armed = true
+	}
+	t.mu.Unlock()
+
+	switch {
+	case full:
+		go t.flushBackground()
+	case arm:
+		go func() {
+			if t.sleep(context.Background(), t.opts.BatchTimeout) == nil {
+				t.flushBackground()
+			}
+		}()
+	}
+}
+
+func (t *Tracer) flushBackground() {
+	if err := t.Flush(context.Background()); err != nil && t.opts.OnError != nil {
+		t.opts.OnError(err)
+	}
+}
+
+// Flush exports all queued spans in batches.
+func (t *Tracer) Flush(ctx context.Context) error {
+	t.mu.Lock()
+	queue, dropped := t.queue, t.dropped
+	t.queue, t.dropped, t.armed = nil, 0, false
+	t.mu.Unlock()
+
+	var errs []error
+	if dropped > 0 {
+		errs = append(errs, ErrQueueFull)
+	}
+	for len(queue) > 0 {
+		n := min(len(queue), t.opts.BatchSize)
+		if err := t.exporter.ExportSpans(ctx, t.resource, queue[:n]); err != nil {
+			errs = append(errs, err)
+		}
+		queue = queue[n:]
+	}
+	return errors.Join(errs...)
+}
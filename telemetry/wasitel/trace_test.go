@@ -0,0 +1,222 @@
+package wasitel
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recorder is a SpanExporter recording exported batches.
+type recorder struct {
+	mu       sync.Mutex
+	batches  [][]SpanData
+	resource []Attribute
+	err      error
+}
+
+func (r *recorder) ExportSpans(_ context.Context, resource []Attribute, spans []SpanData) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.resource = resource
+	r.batches = append(r.batches, append([]SpanData(nil), spans...))
+	return r.err
+}
+
+func (r *recorder) spans() []SpanData {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var spans []SpanData
+	for _, batch := range r.batches {
+		spans = append(spans, batch...)
+	}
+	return spans
+}
+
+// noSleep replaces sleep, so batch timers never fire during a test.
+func noSleep(t *testing.T) {
+	orig := sleep
+	sleep = func(ctx context.Context, _ time.Duration) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	t.Cleanup(func() { sleep = orig })
+}
+
+func TestTracer(t *testing.T) {
+	noSleep(t)
+
+	exporter := &recorder{}
+	tracer := NewTracer(exporter, TracerOptions{ServiceName: "orders"})
+
+	ctx, parent := tracer.Start(context.Background(), "parent", SpanKindServer, String("http.request.method", "GET"))
+	_, child := tracer.Start(ctx, "child", SpanKindInternal)
+	child.AddEvent("cache miss")
+	child.RecordError(errors.New("boom"))
+	child.End()
+	child.SetAttributes(String("ignored", "after end"))
+	child.End()
+	parent.SetStatus(StatusOK, "ignored")
+	parent.SetStatus(StatusError, "ignored, OK is final")
+	parent.End()
+
+	if err := tracer.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	spans := exporter.spans()
+	if len(spans) != 2 {
+		t.Fatalf("expected: %v, got: %v", 2, len(spans))
+	}
+	c, p := spans[0], spans[1]
+	if c.Name != "child" || p.Name != "parent" {
+		t.Errorf("expected child then parent, got: %v %v", c.Name, p.Name)
+	}
+	if c.SpanContext.TraceID != p.SpanContext.TraceID || c.Parent != p.SpanContext.SpanID || p.Parent.IsValid() {
+		t.Errorf("expected child of parent, got: %+v %+v", c.SpanContext, p.SpanContext)
+	}
+	if c.Status != (Status{Code: StatusError, Message: "boom"}) || len(c.Events) != 2 || len(c.Attributes) != 0 {
+		t.Errorf("unexpected child: %+v", c)
+	}
+	if p.Status != (Status{Code: StatusOK}) || len(p.Attributes) != 1 {
+		t.Errorf("unexpected parent: %+v", p)
+	}
+	if len(exporter.resource) != 1 || exporter.resource[0] != String("service.name", "orders") {
+		t.Errorf("unexpected resource: %v", exporter.resource)
+	}
+}
+
+func TestTracerRemoteParent(t *testing.T) {
+	noSleep(t)
+
+	exporter := &recorder{}
+	tracer := NewTracer(exporter, TracerOptions{})
+
+	remote := SpanContext{TraceID: TraceID{1}, SpanID: SpanID{2}, Flags: FlagsSampled, TraceState: "k=v"}
+	ctx := ContextWithRemoteSpanContext(context.Background(), remote)
+	if sc := SpanContextFromContext(ctx); !sc.Remote {
+		t.Errorf("expected remote span context, got: %+v", sc)
+	}
+
+	_, span := tracer.Start(ctx, "handler", SpanKindServer)
+	sc := span.SpanContext()
+	if sc.TraceID != remote.TraceID || sc.TraceState != "k=v" || span.data.Parent != remote.SpanID || !sc.IsSampled() {
+		t.Errorf("expected child of remote, got: %+v", sc)
+	}
+
+	// an unsampled remote parent is followed by the default sampler
+	unsampled := ContextWithRemoteSpanContext(context.Background(), SpanContext{TraceID: TraceID{1}, SpanID: SpanID{3}})
+	_, span = tracer.Start(unsampled, "handler", SpanKindServer)
+	if span.IsRecording() {
+		t.Error("expected span not to record")
+	}
+	span.SetAttributes(String("k", "v"))
+	span.End()
+
+	if err := tracer.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if spans := exporter.spans(); len(spans) != 0 {
+		t.Errorf("expected no exported spans, got: %v", spans)
+	}
+}
+
+func TestTracerBatching(t *testing.T) {
+	noSleep(t)
+
+	exporter := &recorder{}
+	tracer := NewTracer(exporter, TracerOptions{BatchSize: 10, MaxQueueSize: 5})
+	for range 7 {
+		_, span := tracer.Start(context.Background(), "span", SpanKindInternal)
+		span.End()
+	}
+
+	if err := tracer.Flush(context.Background()); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("expected: %v, got: %v", ErrQueueFull, err)
+	}
+	if spans := exporter.spans(); len(spans) != 5 {
+		t.Errorf("expected: %v, got: %v", 5, len(spans))
+	}
+
+	// flushing exports the queue in batches
+	exporter = &recorder{}
+	tracer = NewTracer(exporter, TracerOptions{BatchSize: 2})
+	tracer.queue = make([]SpanData, 5)
+	if err := tracer.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	var sizes []int
+	for _, batch := range exporter.batches {
+		sizes = append(sizes, len(batch))
+	}
+	if want := []int{2, 2, 1}; !slices.Equal(sizes, want) {
+		t.Errorf("expected: %v, got: %v", want, sizes)
+	}
+}
+
+func TestTracerBatchTimeout(t *testing.T) {
+	orig := sleep
+	defer func() { sleep = orig }()
+
+	slept := make(chan time.Duration, 1)
+	sleep = func(_ context.Context, d time.Duration) error {
+		slept <- d
+		return nil
+	}
+
+	exported := make(chan struct{})
+	exporter := &recorder{}
+	tracer := NewTracer(SpanExporterFunc(func(ctx context.Context, resource []Attribute, spans []SpanData) error {
+		defer close(exported)
+		return exporter.ExportSpans(ctx, resource, spans)
+	}), TracerOptions{BatchTimeout: time.Second})
+
+	_, span := tracer.Start(context.Background(), "span", SpanKindInternal)
+	span.End()
+
+	select {
+	case <-exported:
+	case <-time.After(time.Second):
+		t.Fatal("expected the batch timer to flush the span")
+	}
+	if d := <-slept; d != time.Second {
+		t.Errorf("expected: %v, got: %v", time.Second, d)
+	}
+	if spans := exporter.spans(); len(spans) != 1 {
+		t.Errorf("expected: %v, got: %v", 1, len(spans))
+	}
+}
+
+func TestSamplers(t *testing.T) {
+	root := SpanContext{}
+	sampledParent := SpanContext{TraceID: TraceID{1}, SpanID: SpanID{1}, Flags: FlagsSampled}
+	unsampledParent := SpanContext{TraceID: TraceID{1}, SpanID: SpanID{1}}
+
+	tests := map[string]struct {
+		sampler Sampler
+		parent  SpanContext
+		traceID TraceID
+		want    bool
+	}{
+		"always":              {sampler: AlwaysSample(), want: true},
+		"never":               {sampler: NeverSample()},
+		"ratio below":         {sampler: TraceIDRatioBased(0.5), traceID: TraceID{15: 1}, want: true},
+		"ratio above":         {sampler: TraceIDRatioBased(0.5), traceID: TraceID{8: 0xff}},
+		"parent sampled":      {sampler: ParentBased(NeverSample()), parent: sampledParent, want: true},
+		"parent unsampled":    {sampler: ParentBased(AlwaysSample()), parent: unsampledParent},
+		"parent based root":   {sampler: ParentBased(AlwaysSample()), parent: root, want: true},
+		"ratio one":           {sampler: TraceIDRatioBased(1), traceID: TraceID{8: 0xff}, want: true},
+		"ratio zero":          {sampler: TraceIDRatioBased(0), traceID: TraceID{15: 1}},
+		"parent based unseen": {sampler: ParentBased(NeverSample()), parent: root},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tt.sampler.ShouldSample(tt.parent, tt.traceID, "span", SpanKindInternal); got != tt.want {
+				t.Errorf("expected: %v, got: %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package wasiclock
+
+import (
+	"time"
+
+	wallclock "go.wasmcloud.dev/component/gen/wasi/clocks/wall-clock"
+)
+
+// Now returns the current time read from `wasi:clocks/wall-clock`.
+// The returned time carries no monotonic reading, use [Since] or the
+// monotonic-clock backed timers to measure elapsed time.
+func Now() time.Time {
+	dt := wallclock.Now()
+	return time.Unix(int64(dt.Seconds), int64(dt.Nanoseconds))
+}
+
+// Resolution returns the resolution of `wasi:clocks/wall-clock`.
+func Resolution() time.Duration {
+	dt := wallclock.Resolution()
+	return time.Duration(dt.Seconds)*time.Second + time.Duration(dt.Nanoseconds)
+}
+
+// NowFunc is the wall-clock source of the SDK packages.
+//
+// Stdlib time.Now cannot be replaced, but NowFunc can be handed to libraries
+// accepting a clock function where time.Now is unreliable, e.g. returning the
+// zero time on some TinyGo targets. Tests may assign it to a fixed clock.
+var NowFunc = Now
+
+// Since returns the time elapsed since t, according to NowFunc.
+func Since(t time.Time) time.Duration {
+	return NowFunc().Sub(t)
+}
+
+// Until returns the duration until t, according to NowFunc.
+func Until(t time.Time) time.Duration {
+	return t.Sub(NowFunc())
+}
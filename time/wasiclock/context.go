@@ -0,0 +1,102 @@
+package wasiclock
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	monotonicclock "go.wasmcloud.dev/component/gen/wasi/clocks/monotonic-clock"
+	"go.wasmcloud.dev/component/io/wasipoll"
+)
+
+// ContextWithTimeout is the equivalent of context.WithTimeout, with the
+// deadline driven by a `wasi:clocks/monotonic-clock` pollable instead of a stdlib timer.
+//
+// The pollable is waited on through `io/wasipoll` together with the host I/O
+// of the other packages, so the context expires even while the component is
+// blocked on the host. The `wasinet` dialers and `wasihttp.Transport` honor
+// both the deadline and the cancellation of the returned context.
+func ContextWithTimeout(parent context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	return ContextWithDeadline(parent, time.Now().Add(d))
+}
+
+// ContextWithDeadline is the equivalent of context.WithDeadline, see [ContextWithTimeout].
+func ContextWithDeadline(parent context.Context, deadline time.Time) (context.Context, context.CancelFunc) {
+	if cur, ok := parent.Deadline(); ok && cur.Before(deadline) {
+		// NOTE: same as stdlib, the parent expires first
+		return context.WithCancel(parent)
+	}
+
+	c := &timerCtx{
+		// NOTE: values are looked up in the parent, while hiding its
+		// cancellation from context.Cause
+		values:   context.WithoutCancel(parent),
+		deadline: deadline,
+		done:     make(chan struct{}),
+	}
+	stop := context.AfterFunc(parent, func() {
+		c.cancel(parent.Err())
+	})
+	cancel := func() {
+		stop()
+		c.cancel(context.Canceled)
+	}
+
+	d := time.Until(deadline)
+	if d <= 0 {
+		c.cancel(context.DeadlineExceeded)
+		return c, cancel
+	}
+
+	pollable := monotonicclock.SubscribeDuration(monotonicclock.Duration(d))
+	go func() {
+		defer pollable.ResourceDrop()
+
+		if wasipoll.WaitCancel(c.done, pollable) == 0 {
+			stop()
+			c.cancel(context.DeadlineExceeded)
+		}
+	}()
+
+	return c, cancel
+}
+
+// timerCtx is a context canceled by a clock pollable.
+type timerCtx struct {
+	values   context.Context
+	deadline time.Time
+	done     chan struct{}
+
+	mu  sync.Mutex
+	err error
+}
+
+func (c *timerCtx) cancel(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.err != nil {
+		return
+	}
+	c.err = err
+	close(c.done)
+}
+
+func (c *timerCtx) Deadline() (time.Time, bool) {
+	return c.deadline, true
+}
+
+func (c *timerCtx) Done() <-chan struct{} {
+	return c.done
+}
+
+func (c *timerCtx) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.err
+}
+
+func (c *timerCtx) Value(key any) any {
+	return c.values.Value(key)
+}
@@ -0,0 +1,65 @@
+package wasiclock
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestContextWithTimeout(t *testing.T) {
+	parent, cancelParent := ContextWithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancelParent()
+
+	tests := map[string]struct {
+		parent  context.Context
+		timeout time.Duration
+		cancel  bool
+		err     error
+	}{
+		"expires": {
+			parent:  context.Background(),
+			timeout: 10 * time.Millisecond,
+			err:     context.DeadlineExceeded,
+		},
+		"already expired": {
+			parent:  context.Background(),
+			timeout: -time.Second,
+			err:     context.DeadlineExceeded,
+		},
+		"canceled": {
+			parent:  context.Background(),
+			timeout: time.Hour,
+			cancel:  true,
+			err:     context.Canceled,
+		},
+		"parent deadline": {
+			parent:  parent,
+			timeout: time.Hour,
+			err:     context.DeadlineExceeded,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := ContextWithTimeout(tt.parent, tt.timeout)
+			defer cancel()
+
+			if _, ok := ctx.Deadline(); !ok {
+				t.Error("expected context to have a deadline")
+			}
+			if tt.cancel {
+				cancel()
+			}
+
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Second):
+				t.Fatal("expected context to be done")
+			}
+
+			if err := ctx.Err(); err != tt.err {
+				t.Errorf("expected: %v, got: %v", tt.err, err)
+			}
+		})
+	}
+}
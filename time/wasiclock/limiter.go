@@ -0,0 +1,255 @@
+package wasiclock
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	monotonicclock "go.wasmcloud.dev/component/gen/wasi/clocks/monotonic-clock"
+)
+
+// Limit is the maximum frequency of events, in events per second.
+type Limit float64
+
+// Inf is the infinite rate limit, allowing all events.
+const Inf = Limit(math.MaxFloat64)
+
+// Every converts a minimum time interval between events to a Limit.
+func Every(interval time.Duration) Limit {
+	if interval <= 0 {
+		return Inf
+	}
+	return 1 / Limit(interval.Seconds())
+}
+
+func (limit Limit) durationFromTokens(tokens float64) time.Duration {
+	if limit <= 0 {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(tokens / float64(limit) * float64(time.Second))
+}
+
+func (limit Limit) tokensFromDuration(d time.Duration) float64 {
+	if limit <= 0 {
+		return 0
+	}
+	return d.Seconds() * float64(limit)
+}
+
+// monotonicNow returns the host monotonic clock as a duration from its origin.
+func monotonicNow() time.Duration {
+	return time.Duration(monotonicclock.Now())
+}
+
+// Limiter is a token bucket rate limiter, mirroring the golang.org/x/time/rate
+// API surface. Tokens are refilled according to `wasi:clocks/monotonic-clock`,
+// so the methods taking a time.Time in x/time/rate read the host clock instead.
+type Limiter struct {
+	mu     sync.Mutex
+	limit  Limit
+	burst  int
+	tokens float64
+	// last is the host monotonic time of the last tokens update
+	last time.Duration
+}
+
+// NewLimiter returns a new Limiter allowing events up to rate r and permitting
+// bursts of at most b tokens. The bucket starts full.
+func NewLimiter(r Limit, b int) *Limiter {
+	return &Limiter{
+		limit:  r,
+		burst:  b,
+		tokens: float64(b),
+		last:   monotonicNow(),
+	}
+}
+
+// Limit returns the maximum overall event rate.
+func (l *Limiter) Limit() Limit {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.limit
+}
+
+// Burst returns the maximum burst size.
+func (l *Limiter) Burst() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.burst
+}
+
+// Tokens returns the number of tokens available now.
+func (l *Limiter) Tokens() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.advance(monotonicNow())
+}
+
+// SetLimit sets a new Limit for the limiter.
+func (l *Limiter) SetLimit(newLimit Limit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := monotonicNow()
+	l.tokens = l.advance(now)
+	l.last = now
+	l.limit = newLimit
+}
+
+// SetBurst sets a new burst size for the limiter.
+func (l *Limiter) SetBurst(newBurst int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := monotonicNow()
+	l.tokens = l.advance(now)
+	l.last = now
+	l.burst = newBurst
+}
+
+// Allow reports whether an event may happen now.
+func (l *Limiter) Allow() bool {
+	return l.AllowN(1)
+}
+
+// AllowN reports whether n events may happen now, consuming the tokens if so.
+func (l *Limiter) AllowN(n int) bool {
+	return l.reserveN(monotonicNow(), n, 0).ok
+}
+
+// Reserve is shorthand for ReserveN(1).
+func (l *Limiter) Reserve() *Reservation {
+	return l.ReserveN(1)
+}
+
+// ReserveN returns a Reservation that indicates how long the caller must wait
+// before n events happen. The Reservation is not OK if n exceeds the burst.
+func (l *Limiter) ReserveN(n int) *Reservation {
+	return l.reserveN(monotonicNow(), n, time.Duration(math.MaxInt64))
+}
+
+// Wait is shorthand for WaitN(ctx, 1).
+func (l *Limiter) Wait(ctx context.Context) error {
+	return l.WaitN(ctx, 1)
+}
+
+// WaitN blocks until the limiter permits n events to happen, waiting on a
+// [Timer]. It returns an error if n exceeds the burst, the context is canceled,
+// or the wait would outlast the context deadline.
+func (l *Limiter) WaitN(ctx context.Context, n int) error {
+	l.mu.Lock()
+	burst, limit := l.burst, l.limit
+	l.mu.Unlock()
+
+	if n > burst && limit != Inf {
+		return fmt.Errorf("wasiclock: Wait(n=%d) exceeds limiter's burst %d", n, burst)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	now := monotonicNow()
+	maxWait := time.Duration(math.MaxInt64)
+	if deadline, ok := ctx.Deadline(); ok {
+		maxWait = time.Until(deadline)
+	}
+
+	r := l.reserveN(now, n, maxWait)
+	if !r.ok {
+		return fmt.Errorf("wasiclock: Wait(n=%d) would exceed context deadline", n)
+	}
+
+	delay := r.delayFrom(now)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		r.Cancel()
+		return ctx.Err()
+	}
+}
+
+// advance returns the tokens available at now, it must be called with l.mu held.
+func (l *Limiter) advance(now time.Duration) float64 {
+	elapsed := max(now-l.last, 0)
+	return min(l.tokens+l.limit.tokensFromDuration(elapsed), float64(l.burst))
+}
+
+func (l *Limiter) reserveN(now time.Duration, n int, maxWait time.Duration) *Reservation {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.limit == Inf {
+		return &Reservation{ok: true, lim: l, tokens: n, timeToAct: now}
+	}
+
+	tokens := l.advance(now) - float64(n)
+
+	var wait time.Duration
+	if tokens < 0 {
+		wait = l.limit.durationFromTokens(-tokens)
+	}
+
+	// NOTE: a zero limit never refills, the wait is unbounded
+	if n > l.burst || wait > maxWait || wait == time.Duration(math.MaxInt64) {
+		return &Reservation{lim: l}
+	}
+
+	l.tokens = tokens
+	l.last = now
+	return &Reservation{ok: true, lim: l, tokens: n, timeToAct: now + wait}
+}
+
+// Reservation holds information about events permitted by a Limiter after a delay.
+type Reservation struct {
+	ok        bool
+	lim       *Limiter
+	tokens    int
+	timeToAct time.Duration
+}
+
+// OK returns whether the limiter can provide the requested number of tokens.
+func (r *Reservation) OK() bool {
+	return r.ok
+}
+
+// Delay returns how long the reservation holder must wait before acting.
+func (r *Reservation) Delay() time.Duration {
+	return r.delayFrom(monotonicNow())
+}
+
+func (r *Reservation) delayFrom(now time.Duration) time.Duration {
+	if !r.ok {
+		return time.Duration(math.MaxInt64)
+	}
+	return max(r.timeToAct-now, 0)
+}
+
+// Cancel indicates that the reservation holder will not perform the reserved
+// action, returning the tokens to the limiter if the action was still pending.
+func (r *Reservation) Cancel() {
+	if !r.ok || r.tokens == 0 {
+		return
+	}
+
+	r.lim.mu.Lock()
+	defer r.lim.mu.Unlock()
+
+	now := monotonicNow()
+	if r.timeToAct <= now {
+		return
+	}
+
+	r.lim.tokens = min(r.lim.advance(now)+float64(r.tokens), float64(r.lim.burst))
+	r.lim.last = now
+	r.tokens = 0
+}
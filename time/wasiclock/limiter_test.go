@@ -0,0 +1,88 @@
+package wasiclock
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLimiterAllow(t *testing.T) {
+	tests := map[string]struct {
+		limit   Limit
+		burst   int
+		allowed int
+	}{
+		"burst":    {limit: Every(time.Hour), burst: 3, allowed: 3},
+		"infinite": {limit: Inf, burst: 0, allowed: 10},
+		"zero":     {limit: 0, burst: 1, allowed: 1},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			l := NewLimiter(tt.limit, tt.burst)
+
+			var allowed int
+			for range 10 {
+				if l.Allow() {
+					allowed++
+				}
+			}
+			if allowed != tt.allowed {
+				t.Errorf("expected: %v, got: %v", tt.allowed, allowed)
+			}
+		})
+	}
+}
+
+func TestLimiterRefill(t *testing.T) {
+	l := NewLimiter(Every(10*time.Millisecond), 1)
+	if !l.Allow() {
+		t.Fatal("expected first event to be allowed")
+	}
+	if l.Allow() {
+		t.Fatal("expected second event to be limited")
+	}
+
+	time.Sleep(15 * time.Millisecond)
+	if !l.Allow() {
+		t.Error("expected event to be allowed after refill")
+	}
+}
+
+func TestLimiterWait(t *testing.T) {
+	l := NewLimiter(Every(20*time.Millisecond), 1)
+	l.Allow()
+
+	start := time.Now()
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 15*time.Millisecond {
+		t.Errorf("expected to wait for a token, waited %v", elapsed)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx); err == nil {
+		t.Error("expected wait to exceed the context deadline")
+	}
+
+	if err := l.WaitN(context.Background(), 2); err == nil {
+		t.Error("expected wait to exceed the burst")
+	}
+}
+
+func TestReservationCancel(t *testing.T) {
+	l := NewLimiter(Every(time.Hour), 1)
+	l.Allow()
+
+	r := l.Reserve()
+	if !r.OK() || r.Delay() <= 0 {
+		t.Fatalf("expected a delayed reservation, got ok: %v, delay: %v", r.OK(), r.Delay())
+	}
+
+	r.Cancel()
+	if tokens := l.Tokens(); tokens < 0 || tokens > 0.01 {
+		t.Errorf("expected tokens to be restored, got: %v", tokens)
+	}
+}
@@ -0,0 +1,204 @@
+package wasiclock
+
+import (
+	"sync"
+	"time"
+
+	monotonicclock "go.wasmcloud.dev/component/gen/wasi/clocks/monotonic-clock"
+	"go.wasmcloud.dev/component/io/wasipoll"
+)
+
+// Sleep pauses the current goroutine for at least the duration d, waiting on a
+// `wasi:clocks/monotonic-clock` pollable. Other goroutines keep running.
+func Sleep(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+
+	pollable := monotonicclock.SubscribeDuration(monotonicclock.Duration(d))
+	defer pollable.ResourceDrop()
+
+	wasipoll.Wait(pollable)
+}
+
+// After waits for the duration to elapse and then sends the current time on
+// the returned channel. It is equivalent to NewTimer(d).C.
+func After(d time.Duration) <-chan time.Time {
+	return NewTimer(d).C
+}
+
+// Timer is the equivalent of time.Timer, firing through `wasi:clocks/monotonic-clock`.
+type Timer struct {
+	C <-chan time.Time
+	c chan time.Time
+
+	mu     sync.Mutex
+	active bool
+	// cancel stops the wait of the current generation of the timer
+	cancel chan struct{}
+}
+
+// NewTimer creates a new Timer that will send the current time on its channel
+// after at least duration d.
+func NewTimer(d time.Duration) *Timer {
+	c := make(chan time.Time, 1)
+	t := &Timer{C: c, c: c}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.start(d)
+	return t
+}
+
+// Stop prevents the Timer from firing. It returns true if the call stops the
+// timer, false if the timer has already expired or been stopped.
+// As with time.Timer before Go 1.23, Stop does not drain the channel.
+func (t *Timer) Stop() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.stop()
+}
+
+// Reset changes the timer to expire after duration d. It returns true if the
+// timer had been active, false if the timer had expired or been stopped.
+func (t *Timer) Reset(d time.Duration) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	active := t.stop()
+	t.start(d)
+	return active
+}
+
+// stop must be called with t.mu held.
+func (t *Timer) stop() bool {
+	if !t.active {
+		return false
+	}
+	t.active = false
+	close(t.cancel)
+	return true
+}
+
+// start arms a new generation of the timer, it must be called with t.mu held.
+func (t *Timer) start(d time.Duration) {
+	cancel := make(chan struct{})
+	t.active = true
+	t.cancel = cancel
+
+	pollable := monotonicclock.SubscribeDuration(monotonicclock.Duration(max(d, 0)))
+	go func() {
+		defer pollable.ResourceDrop()
+
+		if wasipoll.WaitCancel(cancel, pollable) < 0 {
+			return
+		}
+
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		// NOTE: a Stop or Reset racing with the wakeup takes precedence
+		select {
+		case <-cancel:
+			return
+		default:
+		}
+		t.active = false
+
+		select {
+		case t.c <- time.Now():
+		default:
+		}
+	}()
+}
+
+// Ticker is the equivalent of time.Ticker, ticking through `wasi:clocks/monotonic-clock`.
+// Ticks are scheduled at fixed instants from the start of the ticker, a slow
+// receiver drops ticks instead of shifting the following ones.
+type Ticker struct {
+	C <-chan time.Time
+	c chan time.Time
+
+	mu     sync.Mutex
+	cancel chan struct{}
+}
+
+// NewTicker returns a new Ticker sending the current time on its channel every
+// period d. It panics if d <= 0.
+func NewTicker(d time.Duration) *Ticker {
+	if d <= 0 {
+		panic("non-positive interval for wasiclock.NewTicker")
+	}
+
+	c := make(chan time.Time, 1)
+	t := &Ticker{C: c, c: c}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.start(d)
+	return t
+}
+
+// Stop turns off the ticker. No more ticks will be sent.
+// Stop does not close the channel.
+func (t *Ticker) Stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.stop()
+}
+
+// stop must be called with t.mu held.
+func (t *Ticker) stop() {
+	if t.cancel != nil {
+		close(t.cancel)
+		t.cancel = nil
+	}
+}
+
+// Reset stops the ticker and resets its period to d, restarting it if stopped.
+// It panics if d <= 0.
+func (t *Ticker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("non-positive interval for wasiclock.Ticker.Reset")
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.stop()
+	t.start(d)
+}
+
+// start must be called with t.mu held.
+func (t *Ticker) start(d time.Duration) {
+	cancel := make(chan struct{})
+	t.cancel = cancel
+
+	period := monotonicclock.Instant(d)
+	next := monotonicclock.Now() + period
+	go func() {
+		for {
+			pollable := monotonicclock.SubscribeInstant(next)
+			ready := wasipoll.WaitCancel(cancel, pollable)
+			pollable.ResourceDrop()
+			if ready < 0 {
+				return
+			}
+
+			select {
+			case <-cancel:
+				return
+			case t.c <- time.Now():
+			default:
+			}
+
+			// skip the ticks missed while the component was busy
+			now := monotonicclock.Now()
+			for next <= now {
+				next += period
+			}
+		}
+	}()
+}
@@ -0,0 +1,160 @@
+package wasiclock
+
+import (
+	"sync"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"go.wasmcloud.dev/component/gen/wasi/io/poll"
+)
+
+func TestSleep(t *testing.T) {
+	start := time.Now()
+	Sleep(20 * time.Millisecond)
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("expected to sleep at least 20ms, slept %v", elapsed)
+	}
+}
+
+func TestTimer(t *testing.T) {
+	tests := map[string]struct {
+		d      time.Duration
+		stop   bool
+		reset  time.Duration
+		active bool
+		fires  bool
+	}{
+		"fires": {
+			d:     10 * time.Millisecond,
+			fires: true,
+		},
+		"stopped": {
+			d:      10 * time.Millisecond,
+			stop:   true,
+			active: true,
+		},
+		"reset": {
+			d:      time.Hour,
+			reset:  10 * time.Millisecond,
+			active: true,
+			fires:  true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			timer := NewTimer(tt.d)
+
+			switch {
+			case tt.stop:
+				if active := timer.Stop(); active != tt.active {
+					t.Errorf("expected: %v, got: %v", tt.active, active)
+				}
+			case tt.reset > 0:
+				if active := timer.Reset(tt.reset); active != tt.active {
+					t.Errorf("expected: %v, got: %v", tt.active, active)
+				}
+			}
+
+			select {
+			case <-timer.C:
+				if !tt.fires {
+					t.Error("expected timer not to fire")
+				}
+				if timer.Stop() {
+					t.Error("expected fired timer to be inactive")
+				}
+			case <-time.After(100 * time.Millisecond):
+				if tt.fires {
+					t.Error("expected timer to fire")
+				}
+			}
+		})
+	}
+}
+
+func TestTicker(t *testing.T) {
+	ticker := NewTicker(10 * time.Millisecond)
+
+	for range 3 {
+		select {
+		case <-ticker.C:
+		case <-time.After(time.Second):
+			t.Fatal("expected ticker to tick")
+		}
+	}
+
+	ticker.Stop()
+	// drain a tick sent while stopping
+	select {
+	case <-ticker.C:
+	default:
+	}
+
+	select {
+	case <-ticker.C:
+		t.Error("expected stopped ticker not to tick")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+var (
+	clockLock sync.Mutex
+	clockNext uint32
+	deadlines = map[uint32]time.Time{}
+	epoch     = time.Now()
+)
+
+func subscribe(deadline time.Time) uint32 {
+	clockLock.Lock()
+	defer clockLock.Unlock()
+
+	clockNext++
+	deadlines[clockNext] = deadline
+	return clockNext
+}
+
+// stub wasi:clocks/monotonic-clock, instants are relative to the test start
+//
+//go:linkname wasmimport_Now go.wasmcloud.dev/component/gen/wasi/clocks/monotonic-clock.wasmimport_Now
+func wasmimport_Now() (result0 uint64) {
+	return uint64(time.Since(epoch))
+}
+
+//go:linkname wasmimport_SubscribeDuration go.wasmcloud.dev/component/gen/wasi/clocks/monotonic-clock.wasmimport_SubscribeDuration
+func wasmimport_SubscribeDuration(when0 uint64) (result0 uint32) {
+	return subscribe(time.Now().Add(time.Duration(when0)))
+}
+
+//go:linkname wasmimport_SubscribeInstant go.wasmcloud.dev/component/gen/wasi/clocks/monotonic-clock.wasmimport_SubscribeInstant
+func wasmimport_SubscribeInstant(when0 uint64) (result0 uint32) {
+	return subscribe(epoch.Add(time.Duration(when0)))
+}
+
+// stub wasi:io/poll, returning early to let the Poller observe cancellation
+//
+//go:linkname wasmimport_Poll go.wasmcloud.dev/component/gen/wasi/io/poll.wasmimport_Poll
+func wasmimport_Poll(in0 *poll.Pollable, in1 uint32, result *cm.List[uint32]) {
+	time.Sleep(time.Millisecond)
+
+	clockLock.Lock()
+	defer clockLock.Unlock()
+
+	var indices []uint32
+	for i, p := range unsafe.Slice(in0, in1) {
+		if deadline, ok := deadlines[uint32(p)]; ok && !time.Now().Before(deadline) {
+			indices = append(indices, uint32(i))
+		}
+	}
+	*result = cm.ToList(indices)
+}
+
+//go:linkname wasmimport_PollableResourceDrop go.wasmcloud.dev/component/gen/wasi/io/poll.wasmimport_PollableResourceDrop
+func wasmimport_PollableResourceDrop(self0 uint32) {
+	clockLock.Lock()
+	defer clockLock.Unlock()
+
+	delete(deadlines, self0)
+}
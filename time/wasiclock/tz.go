@@ -0,0 +1,69 @@
+package wasiclock
+
+import (
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"go.wasmcloud.dev/component/gen/wasi/config/runtime"
+)
+
+const (
+	// TZDataConfigPrefix prefixes the `wasi:config/runtime` keys holding
+	// base64 encoded TZif data, e.g. "tzdata_Europe/Berlin".
+	TZDataConfigPrefix = "tzdata_"
+
+	// LocalConfigKey is the `wasi:config/runtime` key naming the location
+	// installed as time.Local by [InstallLocal].
+	LocalConfigKey = "timezone"
+)
+
+// LoadLocation is the equivalent of time.LoadLocation.
+//
+// Components have no zoneinfo database by default, locations are looked up in order:
+//   - the host provided TZif data at [TZDataConfigPrefix] + name
+//   - time.LoadLocation, finding the database embedded by importing
+//     "time/tzdata" or a zoneinfo directory preopened by the host
+func LoadLocation(name string) (*time.Location, error) {
+	res := runtime.Get(TZDataConfigPrefix + name)
+	if res.IsOK() {
+		if encoded := res.OK(); !encoded.None() {
+			data, err := base64.StdEncoding.DecodeString(*encoded.Some())
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode %s: %s", TZDataConfigPrefix+name, err)
+			}
+			return time.LoadLocationFromTZData(name, data)
+		}
+	}
+
+	return time.LoadLocation(name)
+}
+
+// InstallLocal sets time.Local to the location named at [LocalConfigKey],
+// loaded through [LoadLocation]. time.Local is left untouched, UTC for
+// components, when the key is not set.
+func InstallLocal() error {
+	res := runtime.Get(LocalConfigKey)
+	if res.IsErr() {
+		return fmt.Errorf("failed to get %s: %s", LocalConfigKey, configError(res.Err()))
+	}
+
+	name := res.OK()
+	if name.None() {
+		return nil
+	}
+
+	loc, err := LoadLocation(*name.Some())
+	if err != nil {
+		return err
+	}
+	time.Local = loc
+	return nil
+}
+
+func configError(err *runtime.ConfigError) string {
+	if msg := err.Upstream(); msg != nil {
+		return *msg
+	}
+	return *err.IO()
+}
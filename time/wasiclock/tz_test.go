@@ -0,0 +1,102 @@
+package wasiclock
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+	wallclock "go.wasmcloud.dev/component/gen/wasi/clocks/wall-clock"
+	"go.wasmcloud.dev/component/gen/wasi/config/runtime"
+)
+
+var config = map[string]string{}
+
+// tzif returns a version 1 TZif file with a single fixed offset zone.
+func tzif(abbrev string, offset int32) []byte {
+	b := append([]byte("TZif"), make([]byte, 16)...)
+	// isutcnt, isstdcnt, leapcnt, timecnt, typecnt, charcnt
+	for _, n := range []uint32{0, 0, 0, 0, 1, uint32(len(abbrev) + 1)} {
+		b = binary.BigEndian.AppendUint32(b, n)
+	}
+	b = binary.BigEndian.AppendUint32(b, uint32(offset))
+	b = append(b, 0, 0)
+	return append(append(b, abbrev...), 0)
+}
+
+func TestLoadLocation(t *testing.T) {
+	config[TZDataConfigPrefix+"Test/Zone"] = base64.StdEncoding.EncodeToString(tzif("TST", 3600))
+	config[TZDataConfigPrefix+"Test/Invalid"] = "not base64"
+
+	tests := map[string]struct {
+		name   string
+		offset int
+		err    bool
+	}{
+		"config":  {name: "Test/Zone", offset: 3600},
+		"invalid": {name: "Test/Invalid", err: true},
+		"UTC":     {name: "UTC", offset: 0},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			loc, err := LoadLocation(tt.name)
+			if tt.err {
+				if err == nil {
+					t.Error("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, offset := time.Unix(0, 0).In(loc).Zone(); offset != tt.offset {
+				t.Errorf("expected: %v, got: %v", tt.offset, offset)
+			}
+		})
+	}
+}
+
+func TestInstallLocal(t *testing.T) {
+	local := time.Local
+	defer func() { time.Local = local }()
+
+	config[TZDataConfigPrefix+"Test/Local"] = base64.StdEncoding.EncodeToString(tzif("LCL", -7200))
+	config[LocalConfigKey] = "Test/Local"
+	defer delete(config, LocalConfigKey)
+
+	if err := InstallLocal(); err != nil {
+		t.Fatal(err)
+	}
+	if got := time.Local.String(); got != "Test/Local" {
+		t.Errorf("expected: %v, got: %v", "Test/Local", got)
+	}
+}
+
+func TestNow(t *testing.T) {
+	want := time.Unix(1700000000, 42)
+	if got := Now(); !got.Equal(want) {
+		t.Errorf("expected: %v, got: %v", want, got)
+	}
+}
+
+// stub wasi:config/runtime, serving the config map
+//
+//go:linkname wasmimport_Get go.wasmcloud.dev/component/gen/wasi/config/runtime.wasmimport_Get
+func wasmimport_Get(key0 *uint8, key1 uint32, result *cm.Result[runtime.OptionStringShape, cm.Option[string], runtime.ConfigError]) {
+	value, ok := config[unsafe.String(key0, key1)]
+	if !ok {
+		*result = cm.OK[cm.Result[runtime.OptionStringShape, cm.Option[string], runtime.ConfigError]](cm.None[string]())
+		return
+	}
+	*result = cm.OK[cm.Result[runtime.OptionStringShape, cm.Option[string], runtime.ConfigError]](cm.Some(value))
+}
+
+// stub wasi:clocks/wall-clock, at a fixed instant
+//
+//go:linkname wasmimport_WallClockNow go.wasmcloud.dev/component/gen/wasi/clocks/wall-clock.wasmimport_Now
+func wasmimport_WallClockNow(result *wallclock.DateTime) {
+	*result = wallclock.DateTime{Seconds: 1700000000, Nanoseconds: 42}
+}
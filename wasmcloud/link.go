@@ -1,12 +1,84 @@
 package wasmcloud
 
-import "go.wasmcloud.dev/component/gen/wasmcloud/bus/lattice"
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"go.wasmcloud.dev/component/gen/wasmcloud/bus/lattice"
+)
 
 const DefaultLinkName = "default"
 
 type CallTargetInterface = lattice.CallTargetInterface
 
-var (
-	NewCallTargetInterface = lattice.NewCallTargetInterface
-	SetLinkName            = lattice.SetLinkName
-)
+var NewCallTargetInterface = lattice.NewCallTargetInterface
+
+// SetLinkName sets the link name of the calls to interfaces, until it is set
+// again.
+func SetLinkName(name string, interfaces cm.List[CallTargetInterface]) {
+	links.Lock()
+	defer links.Unlock()
+
+	// NOTE: the interfaces are opaque, the link names of all interfaces are
+	// unknown afterwards
+	clear(links.current)
+	links.unknown = true
+	lattice.SetLinkName(name, interfaces)
+}
+
+type linkNameKey struct{}
+
+// WithLinkName returns a copy of ctx selecting the link name of the calls made
+// with it by the SDK adapters, e.g. wasikv.OpenContext or messaging.Publish,
+// so a component calls multiple providers of the same interface.
+func WithLinkName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, linkNameKey{}, name)
+}
+
+// LinkName returns the link name selected by [WithLinkName], or
+// [DefaultLinkName].
+func LinkName(ctx context.Context) string {
+	if name, ok := ctx.Value(linkNameKey{}).(string); ok && name != "" {
+		return name
+	}
+	return DefaultLinkName
+}
+
+// links are the link names set on the host, by interface. Interfaces without
+// a link name use the default one, unless unknown is set.
+var links = struct {
+	sync.Mutex
+	current map[string]string
+	unknown bool
+}{current: map[string]string{}}
+
+// UseLinkName sets the link name of the calls to interfaces, e.g.
+// "wasi:keyvalue/store", unless it is set already. It is called by the SDK
+// adapters before calling the host.
+func UseLinkName(name string, interfaces ...string) {
+	links.Lock()
+	defer links.Unlock()
+
+	var targets []CallTargetInterface
+	for _, iface := range interfaces {
+		current, ok := links.current[iface]
+		if !ok && !links.unknown {
+			current = DefaultLinkName
+		}
+		if current == name {
+			continue
+		}
+		namespace, rest, _ := strings.Cut(iface, ":")
+		pkg, ifaceName, _ := strings.Cut(rest, "/")
+		targets = append(targets, lattice.NewCallTargetInterface(namespace, pkg, ifaceName))
+	}
+	if len(targets) == 0 {
+		return
+	}
+	lattice.SetLinkName(name, cm.ToList(targets))
+	for _, iface := range interfaces {
+		links.current[iface] = name
+	}
+}
@@ -0,0 +1,49 @@
+//go:build !wasm
+
+package wasmcloud
+
+import (
+	"context"
+	"testing"
+
+	"go.wasmcloud.dev/component/internal/fakehost"
+)
+
+func TestLinkName(t *testing.T) {
+	ctx := context.Background()
+	if got := LinkName(ctx); got != DefaultLinkName {
+		t.Errorf("expected: %v, got: %v", DefaultLinkName, got)
+	}
+
+	backup := WithLinkName(ctx, "backup-kv")
+	if got := LinkName(backup); got != "backup-kv" {
+		t.Errorf("expected: %v, got: %v", "backup-kv", got)
+	}
+	if got := LinkName(WithLinkName(backup, "")); got != DefaultLinkName {
+		t.Errorf("expected: %v, got: %v", DefaultLinkName, got)
+	}
+}
+
+func TestUseLinkName(t *testing.T) {
+	t.Cleanup(func() { clear(links.current) })
+
+	UseLinkName(DefaultLinkName, "wasi:keyvalue/store")
+	if _, ok := links.current["wasi:keyvalue/store"]; ok {
+		t.Error("expected the default link name not to be set")
+	}
+
+	UseLinkName("backup-kv", "wasi:keyvalue/store", "wasi:keyvalue/atomics")
+	for _, iface := range []string{"wasi:keyvalue/store", "wasi:keyvalue/atomics"} {
+		if got := links.current[iface]; got != "backup-kv" {
+			t.Errorf("expected: %v, got: %v", "backup-kv", got)
+		}
+		if got := fakehost.LinkName(iface); got != "backup-kv" {
+			t.Errorf("expected: %v, got: %v", "backup-kv", got)
+		}
+	}
+
+	UseLinkName(DefaultLinkName, "wasi:keyvalue/store")
+	if got := links.current["wasi:keyvalue/store"]; got != DefaultLinkName {
+		t.Errorf("expected: %v, got: %v", DefaultLinkName, got)
+	}
+}
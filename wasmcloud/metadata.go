@@ -0,0 +1,62 @@
+package wasmcloud
+
+import (
+	"context"
+	"net/textproto"
+)
+
+// NOTE: `wasmcloud:bus@1.0.0` does not expose the invocation to components,
+// nor the claims of the caller. The metadata is read from the headers of the
+// invocation instead, which are set by the caller. They are only trustworthy
+// if every caller is, e.g. behind a gateway overwriting them.
+
+// Headers of the invocation metadata.
+const (
+	SourceIDHeader  = "Wasmcloud-Source-Id"
+	LatticeIDHeader = "Wasmcloud-Lattice-Id"
+	TenantHeader    = "Wasmcloud-Tenant-Id"
+)
+
+// Metadata describes the invocation a component is serving.
+type Metadata struct {
+	// SourceID is the ID of the calling component or provider.
+	SourceID string
+	// LatticeID is the lattice the invocation was made in.
+	LatticeID string
+	// Tenant is the tenant the invocation is made on behalf of.
+	Tenant string
+	// Header holds all headers of the invocation, including the ones above.
+	Header map[string][]string
+}
+
+// Get returns the first value of the header key, case-insensitively.
+func (md Metadata) Get(key string) string {
+	return textproto.MIMEHeader(md.Header).Get(key)
+}
+
+// MetadataFromHeader returns the metadata of an invocation with headers h,
+// e.g. the header of an incoming http.Request.
+func MetadataFromHeader(h map[string][]string) Metadata {
+	header := textproto.MIMEHeader(h)
+	return Metadata{
+		SourceID:  header.Get(SourceIDHeader),
+		LatticeID: header.Get(LatticeIDHeader),
+		Tenant:    header.Get(TenantHeader),
+		Header:    h,
+	}
+}
+
+type metadataKey struct{}
+
+// NewContext returns a copy of ctx carrying md. It is called by the SDK
+// adapters serving an export, e.g. wasihttp.
+func NewContext(ctx context.Context, md Metadata) context.Context {
+	return context.WithValue(ctx, metadataKey{}, md)
+}
+
+// FromContext returns the metadata of the invocation served with ctx, and
+// whether it is set.
+func FromContext(ctx context.Context) (Metadata, bool) {
+	md, ok := ctx.Value(metadataKey{}).(Metadata)
+	return md, ok
+}
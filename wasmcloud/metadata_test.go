@@ -0,0 +1,29 @@
+package wasmcloud
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestFromContext(t *testing.T) {
+	if md, ok := FromContext(context.Background()); ok {
+		t.Errorf("expected no metadata, got: %v", md)
+	}
+
+	h := http.Header{}
+	h.Set(SourceIDHeader, "orders-component")
+	h.Set(LatticeIDHeader, "default")
+	h.Set(TenantHeader, "acme")
+	h.Set("X-Request-Id", "42")
+
+	want := Metadata{SourceID: "orders-component", LatticeID: "default", Tenant: "acme", Header: h}
+	md, ok := FromContext(NewContext(context.Background(), MetadataFromHeader(h)))
+	if !ok || !reflect.DeepEqual(md, want) {
+		t.Errorf("expected: %v, got: %v", want, md)
+	}
+	if got := md.Get("x-request-id"); got != "42" {
+		t.Errorf("expected: %v, got: %v", "42", got)
+	}
+}
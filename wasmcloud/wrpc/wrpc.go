@@ -0,0 +1,196 @@
+package wrpc
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// Invoker calls an imported function of the bindings with its params. The
+// wasmCloud host forwards the call over wRPC to the provider or component
+// linked to the interface.
+type Invoker func(params []any) (any, error)
+
+var invokers = struct {
+	sync.RWMutex
+	m map[string]Invoker
+}{m: map[string]Invoker{}}
+
+func key(instance, fn string) string {
+	return instance + "#" + fn
+}
+
+// Register registers the invoker of the function fn of the imported interface
+// instance, e.g. "wasmcloud:example/greeter@0.1.0" and "greet". It is called by
+// the init function written by `component gen -invokers`.
+func Register(instance, fn string, invoker Invoker) {
+	invokers.Lock()
+	defer invokers.Unlock()
+
+	k := key(instance, fn)
+	if _, ok := invokers.m[k]; ok {
+		panic(fmt.Sprintf("wrpc: multiple registrations for %q", k))
+	}
+	invokers.m[k] = invoker
+}
+
+// Registered returns the sorted functions with a registered invoker, e.g.
+// "wasmcloud:example/greeter@0.1.0#greet".
+func Registered() []string {
+	invokers.RLock()
+	defer invokers.RUnlock()
+
+	names := make([]string, 0, len(invokers.m))
+	for name := range invokers.m {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Invoke calls the function fn of the imported interface instance with
+// params, and returns its result. Functions without results return a
+// struct{}.
+//
+// The types of params and T must be the ones of the bindings, e.g. a
+// cm.Result for functions returning a WIT result.
+func Invoke[T any](ctx context.Context, instance, fn string, params ...any) (T, error) {
+	var zero T
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
+
+	invokers.RLock()
+	invoker, ok := invokers.m[key(instance, fn)]
+	invokers.RUnlock()
+	if !ok {
+		return zero, fmt.Errorf("wrpc: %s is not imported, generate its invoker with `component gen -invokers`", key(instance, fn))
+	}
+
+	result, err := invoker(params)
+	if err != nil {
+		return zero, fmt.Errorf("wrpc: failed to invoke %s: %w", key(instance, fn), err)
+	}
+	t, ok := result.(T)
+	if !ok {
+		return zero, fmt.Errorf("wrpc: %s returns a %T, not a %T", key(instance, fn), result, zero)
+	}
+	return t, nil
+}
+
+// Param returns the i-th of params as a T.
+func Param[T any](params []any, i int) (T, error) {
+	var zero T
+	if i >= len(params) {
+		return zero, fmt.Errorf("missing parameter %d of type %T", i, zero)
+	}
+	t, ok := params[i].(T)
+	if !ok {
+		return zero, fmt.Errorf("parameter %d is a %T, not a %T", i, params[i], zero)
+	}
+	return t, nil
+}
+
+func arity(params []any, n int) error {
+	if len(params) != n {
+		return fmt.Errorf("expected %d parameters, got %d", n, len(params))
+	}
+	return nil
+}
+
+// Func0 returns the invoker of fn.
+func Func0[R any](fn func() R) Invoker {
+	return func(params []any) (any, error) {
+		if err := arity(params, 0); err != nil {
+			return nil, err
+		}
+		return fn(), nil
+	}
+}
+
+// Func1 returns the invoker of fn.
+func Func1[P0, R any](fn func(P0) R) Invoker {
+	return func(params []any) (any, error) {
+		if err := arity(params, 1); err != nil {
+			return nil, err
+		}
+		p0, err := Param[P0](params, 0)
+		if err != nil {
+			return nil, err
+		}
+		return fn(p0), nil
+	}
+}
+
+// Func2 returns the invoker of fn.
+func Func2[P0, P1, R any](fn func(P0, P1) R) Invoker {
+	return func(params []any) (any, error) {
+		if err := arity(params, 2); err != nil {
+			return nil, err
+		}
+		p0, err := Param[P0](params, 0)
+		if err != nil {
+			return nil, err
+		}
+		p1, err := Param[P1](params, 1)
+		if err != nil {
+			return nil, err
+		}
+		return fn(p0, p1), nil
+	}
+}
+
+// Func3 returns the invoker of fn.
+func Func3[P0, P1, P2, R any](fn func(P0, P1, P2) R) Invoker {
+	return func(params []any) (any, error) {
+		if err := arity(params, 3); err != nil {
+			return nil, err
+		}
+		p0, err := Param[P0](params, 0)
+		if err != nil {
+			return nil, err
+		}
+		p1, err := Param[P1](params, 1)
+		if err != nil {
+			return nil, err
+		}
+		p2, err := Param[P2](params, 2)
+		if err != nil {
+			return nil, err
+		}
+		return fn(p0, p1, p2), nil
+	}
+}
+
+// Proc0 returns the invoker of fn, a function without results.
+func Proc0(fn func()) Invoker {
+	return Func0(func() struct{} {
+		fn()
+		return struct{}{}
+	})
+}
+
+// Proc1 returns the invoker of fn, a function without results.
+func Proc1[P0 any](fn func(P0)) Invoker {
+	return Func1(func(p0 P0) struct{} {
+		fn(p0)
+		return struct{}{}
+	})
+}
+
+// Proc2 returns the invoker of fn, a function without results.
+func Proc2[P0, P1 any](fn func(P0, P1)) Invoker {
+	return Func2(func(p0 P0, p1 P1) struct{} {
+		fn(p0, p1)
+		return struct{}{}
+	})
+}
+
+// Proc3 returns the invoker of fn, a function without results.
+func Proc3[P0, P1, P2 any](fn func(P0, P1, P2)) Invoker {
+	return Func3(func(p0 P0, p1 P1, p2 P2) struct{} {
+		fn(p0, p1, p2)
+		return struct{}{}
+	})
+}
@@ -0,0 +1,64 @@
+package wrpc
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestInvoke(t *testing.T) {
+	var notified string
+	Register("test:greeter/greeter", "greet", Func1(func(name string) string { return "hello " + name }))
+	Register("test:greeter/greeter", "add", Func2(func(a, b uint32) uint32 { return a + b }))
+	Register("test:greeter/greeter", "notify", Proc1(func(msg string) { notified = msg }))
+
+	ctx := context.Background()
+	if got, err := Invoke[string](ctx, "test:greeter/greeter", "greet", "world"); err != nil || got != "hello world" {
+		t.Errorf("expected: %v, got: %v %v", "hello world", got, err)
+	}
+	if got, err := Invoke[uint32](ctx, "test:greeter/greeter", "add", uint32(1), uint32(2)); err != nil || got != 3 {
+		t.Errorf("expected: %v, got: %v %v", 3, got, err)
+	}
+	if _, err := Invoke[struct{}](ctx, "test:greeter/greeter", "notify", "hi"); err != nil || notified != "hi" {
+		t.Errorf("expected: %v, got: %v %v", "hi", notified, err)
+	}
+
+	for _, tt := range []struct {
+		name   string
+		invoke func() error
+		want   string
+	}{
+		{"unknown function", func() error {
+			_, err := Invoke[string](ctx, "test:greeter/greeter", "wave")
+			return err
+		}, "test:greeter/greeter#wave is not imported"},
+		{"parameter count", func() error {
+			_, err := Invoke[string](ctx, "test:greeter/greeter", "greet")
+			return err
+		}, "expected 1 parameters, got 0"},
+		{"parameter type", func() error {
+			_, err := Invoke[uint32](ctx, "test:greeter/greeter", "add", 1, 2)
+			return err
+		}, "parameter 0 is a int, not a uint32"},
+		{"result type", func() error {
+			_, err := Invoke[int](ctx, "test:greeter/greeter", "greet", "world")
+			return err
+		}, "returns a string, not a int"},
+		{"canceled", func() error {
+			ctx, cancel := context.WithCancel(ctx)
+			cancel()
+			_, err := Invoke[string](ctx, "test:greeter/greeter", "greet", "world")
+			return err
+		}, "context canceled"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.invoke(); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected: %v, got: %v", tt.want, err)
+			}
+		})
+	}
+
+	if got := Registered(); len(got) != 3 || got[0] != "test:greeter/greeter#add" {
+		t.Errorf("unexpected registered functions: %v", got)
+	}
+}